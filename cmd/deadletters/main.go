@@ -0,0 +1,129 @@
+// Command deadletters lists, replays and discards the event messages
+// handlers gave up on. Replayed messages go back through the outbox, so a
+// running server publishes them under their original IDs.
+//
+//	deadletters list [-all]
+//	deadletters replay <seq>...
+//	deadletters discard <seq>...
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"bitmerchant/internal/infrastructure/events"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const usage = `usage:
+  deadletters list [-all]        list dead letters (-all includes replayed ones)
+  deadletters replay <seq>...    publish dead letters again through the outbox
+  deadletters discard <seq>...   delete dead letters without replaying them
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "deadletters:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("missing command")
+	}
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return fmt.Errorf("DATABASE_URL is required")
+	}
+	db, err := sql.Open("pgx", databaseURL)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	store := events.NewPostgresDeadLetters(db)
+
+	switch cmd, rest := args[0], args[1:]; cmd {
+	case "list":
+		fs := flag.NewFlagSet("list", flag.ContinueOnError)
+		all := fs.Bool("all", false, "include replayed dead letters")
+		if err := fs.Parse(rest); err != nil {
+			return err
+		}
+		letters, err := store.List(ctx, *all)
+		if err != nil {
+			return err
+		}
+		return printDeadLetters(out, letters)
+	case "replay":
+		return eachSeq(rest, func(seq int64) error {
+			dl, err := store.Replay(ctx, seq)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "replayed %d (%s on %s)\n", seq, dl.MessageID, dl.Topic)
+			return nil
+		})
+	case "discard":
+		return eachSeq(rest, func(seq int64) error {
+			if err := store.Discard(ctx, seq); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "discarded %d\n", seq)
+			return nil
+		})
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// eachSeq runs fn for every sequence number in args, stopping at the first
+// failure.
+func eachSeq(args []string, fn func(seq int64) error) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no dead letter sequence numbers given")
+	}
+	for _, arg := range args {
+		seq, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid sequence number %q", arg)
+		}
+		if err := fn(seq); err != nil {
+			return fmt.Errorf("dead letter %d: %w", seq, err)
+		}
+	}
+	return nil
+}
+
+func printDeadLetters(out io.Writer, letters []events.DeadLetter) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SEQ\tDEAD AT\tTOPIC\tHANDLER\tMESSAGE ID\tREPLAYED\tREASON")
+	for _, dl := range letters {
+		replayed := "-"
+		if dl.ReplayedAt != nil {
+			replayed = dl.ReplayedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			dl.Seq, dl.DeadAt.Format(time.RFC3339), dl.Topic, dl.Handler, dl.MessageID, replayed, oneLine(dl.Reason))
+	}
+	return w.Flush()
+}
+
+// oneLine keeps a multi-line failure reason on its row.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
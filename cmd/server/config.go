@@ -1,12 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+
+	"bitmerchant/internal/common/money"
 )
 
 // firstEnv returns the first non-empty trimmed value from the given keys.
@@ -45,9 +48,41 @@ type serverConfig struct {
 	NATSSubscribersCount int
 	NATSInstanceID       string
 
+	EventRetryMax             int
+	EventRetryInitialInterval time.Duration
+	EventRetryMaxInterval     time.Duration
+	EventDedupTTL             time.Duration
+	EventRetention            time.Duration
+
+	// MetricsEnabled serves Prometheus metrics on /metrics. It is off by
+	// default; with MetricsToken set, scrapes must present it as a bearer
+	// token.
+	MetricsEnabled bool
+	MetricsToken   string
+
+	// TracesExporter is where OpenTelemetry spans go: otlp, stdout or none.
+	TracesExporter string
+
 	VAPIDPublicKey  string
 	VAPIDPrivateKey string
 	VAPIDSubject    string
+
+	LightningBackend        string
+	LNDRESTURL              string
+	LNDMacaroonHex          string
+	LNDTLSCertPath          string
+	CLNRESTURL              string
+	CLNRune                 string
+	CLNTLSCertPath          string
+	LightningInvoiceExpiry  time.Duration
+	LightningPollInterval   time.Duration
+	LightningFakeAutoSettle time.Duration
+	LNURLAllowLocal         bool
+	LightningBTCRates       map[string]float64
+	FXProviders             []string
+	FXCacheTTL              time.Duration
+	FXMaxStaleness          time.Duration
+	Currencies              []money.Currency
 }
 
 func loadBaseURL() string {
@@ -94,10 +129,114 @@ func resolveEventBusBackend() (string, error) {
 	if backend == "" {
 		return "nats", nil
 	}
-	if backend != "memory" && backend != "nats" {
-		return "", fmt.Errorf("invalid EVENT_BUS_BACKEND %q: expected memory or nats", backend)
+	switch backend {
+	case "memory", "nats":
+		return backend, nil
+	case "postgres":
+		if strings.TrimSpace(os.Getenv("DATABASE_URL")) == "" {
+			return "", errors.New("EVENT_BUS_BACKEND=postgres requires DATABASE_URL")
+		}
+		return backend, nil
+	default:
+		return "", fmt.Errorf("invalid EVENT_BUS_BACKEND %q: expected memory, nats or postgres", backend)
+	}
+}
+
+func resolveLightningBackend() (string, error) {
+	backend := strings.ToLower(strings.TrimSpace(os.Getenv("LIGHTNING_BACKEND")))
+	switch backend {
+	case "", "fake", "lnd", "cln":
+		return backend, nil
+	default:
+		return "", fmt.Errorf("invalid LIGHTNING_BACKEND %q: expected fake, lnd or cln", backend)
+	}
+}
+
+// resolveMetrics reads METRICS_ENABLED and METRICS_TOKEN. Metrics are off
+// by default; enabled without a token, /metrics is open to anyone, which
+// main warns about at startup.
+func resolveMetrics() (bool, string) {
+	return resolveBool("METRICS_ENABLED", false), strings.TrimSpace(os.Getenv("METRICS_TOKEN"))
+}
+
+// resolveTracesExporter reads OTEL_TRACES_EXPORTER; "console" is the
+// OpenTelemetry spelling of stdout.
+func resolveTracesExporter() (string, error) {
+	exporter := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER")))
+	switch exporter {
+	case "", "none":
+		return "none", nil
+	case "otlp", "stdout":
+		return exporter, nil
+	case "console":
+		return "stdout", nil
+	default:
+		return "", fmt.Errorf("invalid OTEL_TRACES_EXPORTER %q: expected otlp, stdout or none", exporter)
+	}
+}
+
+// resolveBTCRates parses LIGHTNING_BTC_RATES, a comma-separated list of
+// CODE=price-of-one-bitcoin pairs (e.g. "USD=65000,THB=2300000").
+func resolveBTCRates() (map[string]float64, error) {
+	v := strings.TrimSpace(os.Getenv("LIGHTNING_BTC_RATES"))
+	if v == "" {
+		return nil, nil
+	}
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(v, ",") {
+		code, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if !ok || err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid LIGHTNING_BTC_RATES entry %q: expected CODE=price", pair)
+		}
+		rates[strings.ToUpper(strings.TrimSpace(code))] = rate
 	}
-	return backend, nil
+	return rates, nil
+}
+
+// resolveFXProviders parses FX_PROVIDERS, a comma-separated list of live
+// bitcoin price feeds tried in order (e.g. "coingecko,coinbase").
+func resolveFXProviders() ([]string, error) {
+	v := strings.TrimSpace(os.Getenv("FX_PROVIDERS"))
+	if v == "" {
+		return nil, nil
+	}
+	var providers []string
+	for _, name := range strings.Split(v, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case "coingecko", "coinbase":
+			providers = append(providers, name)
+		default:
+			return nil, fmt.Errorf("invalid FX_PROVIDERS entry %q: expected coingecko or coinbase", name)
+		}
+	}
+	return providers, nil
+}
+
+// resolveCurrencies parses CURRENCIES, a comma-separated list of currencies
+// to offer beyond USD, THB and SAT. Each entry is an ISO 4217 code, optionally
+// overriding its symbol and symbol placement (e.g. "EUR,CHF:Fr.:2:after"); a
+// code outside the ISO table also sets its scale.
+func resolveCurrencies() ([]money.Currency, error) {
+	v := strings.TrimSpace(os.Getenv("CURRENCIES"))
+	if v == "" {
+		return nil, nil
+	}
+	var currencies []money.Currency
+	for _, spec := range strings.Split(v, ",") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		c, err := money.ParseSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CURRENCIES entry: %w", err)
+		}
+		currencies = append(currencies, c)
+	}
+	return currencies, nil
 }
 
 func resolveBool(key string, fallback bool) bool {
@@ -166,6 +305,27 @@ func loadConfig() (serverConfig, error) {
 	if err != nil {
 		return serverConfig{}, err
 	}
+	lightningBackend, err := resolveLightningBackend()
+	if err != nil {
+		return serverConfig{}, err
+	}
+	metricsEnabled, metricsToken := resolveMetrics()
+	tracesExporter, err := resolveTracesExporter()
+	if err != nil {
+		return serverConfig{}, err
+	}
+	btcRates, err := resolveBTCRates()
+	if err != nil {
+		return serverConfig{}, err
+	}
+	fxProviders, err := resolveFXProviders()
+	if err != nil {
+		return serverConfig{}, err
+	}
+	currencies, err := resolveCurrencies()
+	if err != nil {
+		return serverConfig{}, err
+	}
 
 	cfg := serverConfig{
 		Port:                   resolvePort(os.Getenv("PORT")),
@@ -189,10 +349,36 @@ func loadConfig() (serverConfig, error) {
 		NATSCloseTimeout:       resolveDuration("NATS_CLOSE_TIMEOUT", 30*time.Second),
 		NATSSubscribersCount:   resolveInt("NATS_SUBSCRIBERS_COUNT", 1),
 		NATSInstanceID:         resolveNATSInstanceID(),
+		EventRetryMax:          resolveInt("EVENT_RETRY_MAX", 3),
+		MetricsEnabled:         metricsEnabled,
+		MetricsToken:           metricsToken,
+		TracesExporter:         tracesExporter,
 		VAPIDPublicKey:         strings.TrimSpace(os.Getenv("VAPID_PUBLIC_KEY")),
 		VAPIDPrivateKey:        strings.TrimSpace(os.Getenv("VAPID_PRIVATE_KEY")),
 		VAPIDSubject:           strings.TrimSpace(os.Getenv("VAPID_SUBJECT")),
+		LightningBackend:       lightningBackend,
+		LNDRESTURL:             strings.TrimSpace(os.Getenv("LND_REST_URL")),
+		LNDMacaroonHex:         strings.TrimSpace(os.Getenv("LND_MACAROON_HEX")),
+		LNDTLSCertPath:         strings.TrimSpace(os.Getenv("LND_TLS_CERT_PATH")),
+		CLNRESTURL:             strings.TrimSpace(os.Getenv("CLN_REST_URL")),
+		CLNRune:                strings.TrimSpace(os.Getenv("CLN_RUNE")),
+		CLNTLSCertPath:         strings.TrimSpace(os.Getenv("CLN_TLS_CERT_PATH")),
+		LightningInvoiceExpiry: resolveDuration("LIGHTNING_INVOICE_EXPIRY", 15*time.Minute),
+		LightningPollInterval:  resolveDuration("LIGHTNING_POLL_INTERVAL", 3*time.Second),
+		LightningBTCRates:      btcRates,
+		FXProviders:            fxProviders,
+		FXCacheTTL:             resolveDuration("FX_CACHE_TTL", time.Minute),
+		FXMaxStaleness:         resolveDuration("FX_MAX_STALENESS", 15*time.Minute),
+		Currencies:             currencies,
 	}
+	// Auto-settle is a dev convenience for the fake node; zero keeps it off.
+	cfg.LightningFakeAutoSettle = resolveDuration("LIGHTNING_FAKE_AUTOSETTLE", 0)
+	// So is reaching a Lightning address wallet on this machine.
+	cfg.LNURLAllowLocal = resolveBool("LNURL_ALLOW_LOCAL", false)
+	cfg.EventRetryInitialInterval = resolveDuration("EVENT_RETRY_BACKOFF", 100*time.Millisecond)
+	cfg.EventRetryMaxInterval = resolveDuration("EVENT_RETRY_MAX_BACKOFF", time.Second)
+	cfg.EventDedupTTL = resolveDuration("EVENT_DEDUP_TTL", 24*time.Hour)
+	cfg.EventRetention = resolveDuration("EVENT_RETENTION", 7*24*time.Hour)
 	if cfg.NATSURL == "" {
 		cfg.NATSURL = "nats://localhost:4222"
 	}
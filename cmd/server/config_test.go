@@ -71,3 +71,149 @@ func TestLoadConfig_InvalidEventBusBackend(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "EVENT_BUS_BACKEND")
 }
+
+func TestLoadConfig_PostgresEventBusNeedsDatabase(t *testing.T) {
+	t.Setenv("EVENT_BUS_BACKEND", "Postgres")
+	t.Setenv("DATABASE_URL", "")
+
+	_, err := loadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DATABASE_URL")
+
+	t.Setenv("DATABASE_URL", "postgres://localhost/bitmerchant")
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", cfg.EventBusBackend)
+}
+
+func TestLoadConfig_EventRetry(t *testing.T) {
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.EventRetryMax)
+	assert.Equal(t, 100*time.Millisecond, cfg.EventRetryInitialInterval)
+	assert.Equal(t, time.Second, cfg.EventRetryMaxInterval)
+	assert.Equal(t, 24*time.Hour, cfg.EventDedupTTL)
+	assert.Equal(t, 7*24*time.Hour, cfg.EventRetention)
+
+	t.Setenv("EVENT_RETRY_MAX", "5")
+	t.Setenv("EVENT_RETRY_BACKOFF", "250ms")
+	t.Setenv("EVENT_RETRY_MAX_BACKOFF", "10s")
+	t.Setenv("EVENT_DEDUP_TTL", "2h")
+	t.Setenv("EVENT_RETENTION", "72h")
+	cfg, err = loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.EventRetryMax)
+	assert.Equal(t, 250*time.Millisecond, cfg.EventRetryInitialInterval)
+	assert.Equal(t, 10*time.Second, cfg.EventRetryMaxInterval)
+	assert.Equal(t, 2*time.Hour, cfg.EventDedupTTL)
+	assert.Equal(t, 72*time.Hour, cfg.EventRetention)
+}
+
+func TestLoadConfig_Metrics(t *testing.T) {
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	assert.False(t, cfg.MetricsEnabled, "metrics are off by default")
+	assert.Empty(t, cfg.MetricsToken)
+
+	t.Setenv("METRICS_ENABLED", "true")
+	cfg, err = loadConfig()
+	require.NoError(t, err, "the token is optional")
+	assert.True(t, cfg.MetricsEnabled)
+	assert.Empty(t, cfg.MetricsToken)
+
+	t.Setenv("METRICS_TOKEN", " s3cret ")
+	cfg, err = loadConfig()
+	require.NoError(t, err)
+	assert.True(t, cfg.MetricsEnabled)
+	assert.Equal(t, "s3cret", cfg.MetricsToken)
+}
+
+func TestLoadConfig_TracesExporter(t *testing.T) {
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "none", cfg.TracesExporter)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", " OTLP ")
+	cfg, err = loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "otlp", cfg.TracesExporter)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+	cfg, err = loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "stdout", cfg.TracesExporter)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "jaeger")
+	_, err = loadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OTEL_TRACES_EXPORTER")
+}
+
+func TestLoadConfig_Lightning(t *testing.T) {
+	t.Setenv("LIGHTNING_BACKEND", "LND")
+	t.Setenv("LND_REST_URL", "https://lnd.local:8080")
+	t.Setenv("LIGHTNING_INVOICE_EXPIRY", "10m")
+	t.Setenv("LIGHTNING_BTC_RATES", "usd=65000, THB=2300000")
+
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, "lnd", cfg.LightningBackend)
+	assert.Equal(t, "https://lnd.local:8080", cfg.LNDRESTURL)
+	assert.Equal(t, 10*time.Minute, cfg.LightningInvoiceExpiry)
+	assert.Equal(t, 3*time.Second, cfg.LightningPollInterval)
+	assert.Equal(t, map[string]float64{"USD": 65000, "THB": 2300000}, cfg.LightningBTCRates)
+}
+
+func TestLoadConfig_InvalidLightningConfig(t *testing.T) {
+	t.Setenv("LIGHTNING_BACKEND", "eclair")
+	_, err := loadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LIGHTNING_BACKEND")
+
+	t.Setenv("LIGHTNING_BACKEND", "fake")
+	t.Setenv("LIGHTNING_BTC_RATES", "USD")
+	_, err = loadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LIGHTNING_BTC_RATES")
+}
+
+func TestLoadConfig_FX(t *testing.T) {
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.FXProviders)
+	assert.Equal(t, time.Minute, cfg.FXCacheTTL)
+	assert.Equal(t, 15*time.Minute, cfg.FXMaxStaleness)
+
+	t.Setenv("FX_PROVIDERS", "CoinGecko, coinbase")
+	t.Setenv("FX_CACHE_TTL", "30s")
+	cfg, err = loadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"coingecko", "coinbase"}, cfg.FXProviders)
+	assert.Equal(t, 30*time.Second, cfg.FXCacheTTL)
+
+	t.Setenv("FX_PROVIDERS", "coingecko,kraken")
+	_, err = loadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "FX_PROVIDERS")
+}
+
+func TestLoadConfig_Currencies(t *testing.T) {
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Currencies)
+
+	t.Setenv("CURRENCIES", "EUR, ars,CHF:Fr.:2:after")
+	cfg, err = loadConfig()
+	require.NoError(t, err)
+	require.Len(t, cfg.Currencies, 3)
+	assert.Equal(t, "EUR", cfg.Currencies[0].Code)
+	assert.Equal(t, "ARS", cfg.Currencies[1].Code)
+	assert.Equal(t, "Fr.", cfg.Currencies[2].Symbol)
+	assert.True(t, cfg.Currencies[2].SymbolAfter)
+
+	t.Setenv("CURRENCIES", "EUR,XYZ")
+	_, err = loadConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CURRENCIES")
+}
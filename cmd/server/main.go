@@ -21,28 +21,50 @@ func main() {
 	}
 
 	application, cleanup, err := service.NewApplication(context.Background(), service.Config{
-		PublicBaseURL:          cfg.PublicBaseURL,
-		CustomerBaseURL:        cfg.CustomerBaseURL,
-		MerchantBaseURL:        cfg.MerchantBaseURL,
-		RPID:                   cfg.RPID,
-		ForceSecureCookie:      cfg.ForceSecureCookie,
-		DatabaseURL:            cfg.DatabaseURL,
-		S3BucketName:           cfg.S3BucketName,
-		AWSRegion:              cfg.AWSRegion,
-		S3Endpoint:             cfg.S3Endpoint,
-		S3UsePathStyle:         cfg.S3UsePathStyle,
-		S3PublicBaseURL:        cfg.S3PublicBaseURL,
-		S3PresignGetExpiresSec: cfg.S3PresignGetExpiresSec,
-		EventBusBackend:        cfg.EventBusBackend,
-		NATSURL:                cfg.NATSURL,
-		NATSAutoProvision:      cfg.NATSAutoProvision,
-		NATSAckWait:            cfg.NATSAckWait,
-		NATSCloseTimeout:       cfg.NATSCloseTimeout,
-		NATSSubscribersCount:   cfg.NATSSubscribersCount,
-		NATSInstanceID:         cfg.NATSInstanceID,
-		VAPIDPublicKey:         cfg.VAPIDPublicKey,
-		VAPIDPrivateKey:        cfg.VAPIDPrivateKey,
-		VAPIDSubject:           cfg.VAPIDSubject,
+		PublicBaseURL:             cfg.PublicBaseURL,
+		CustomerBaseURL:           cfg.CustomerBaseURL,
+		MerchantBaseURL:           cfg.MerchantBaseURL,
+		RPID:                      cfg.RPID,
+		ForceSecureCookie:         cfg.ForceSecureCookie,
+		DatabaseURL:               cfg.DatabaseURL,
+		S3BucketName:              cfg.S3BucketName,
+		AWSRegion:                 cfg.AWSRegion,
+		S3Endpoint:                cfg.S3Endpoint,
+		S3UsePathStyle:            cfg.S3UsePathStyle,
+		S3PublicBaseURL:           cfg.S3PublicBaseURL,
+		S3PresignGetExpiresSec:    cfg.S3PresignGetExpiresSec,
+		EventBusBackend:           cfg.EventBusBackend,
+		NATSURL:                   cfg.NATSURL,
+		NATSAutoProvision:         cfg.NATSAutoProvision,
+		NATSAckWait:               cfg.NATSAckWait,
+		NATSCloseTimeout:          cfg.NATSCloseTimeout,
+		NATSSubscribersCount:      cfg.NATSSubscribersCount,
+		NATSInstanceID:            cfg.NATSInstanceID,
+		EventRetryMax:             cfg.EventRetryMax,
+		EventRetryInitialInterval: cfg.EventRetryInitialInterval,
+		EventRetryMaxInterval:     cfg.EventRetryMaxInterval,
+		EventDedupTTL:             cfg.EventDedupTTL,
+		EventRetention:            cfg.EventRetention,
+		TracesExporter:            cfg.TracesExporter,
+		VAPIDPublicKey:            cfg.VAPIDPublicKey,
+		VAPIDPrivateKey:           cfg.VAPIDPrivateKey,
+		VAPIDSubject:              cfg.VAPIDSubject,
+		LightningBackend:          cfg.LightningBackend,
+		LNDRESTURL:                cfg.LNDRESTURL,
+		LNDMacaroonHex:            cfg.LNDMacaroonHex,
+		LNDTLSCertPath:            cfg.LNDTLSCertPath,
+		CLNRESTURL:                cfg.CLNRESTURL,
+		CLNRune:                   cfg.CLNRune,
+		CLNTLSCertPath:            cfg.CLNTLSCertPath,
+		LightningInvoiceExpiry:    cfg.LightningInvoiceExpiry,
+		LightningPollInterval:     cfg.LightningPollInterval,
+		LightningFakeAutoSettle:   cfg.LightningFakeAutoSettle,
+		LNURLAllowLocal:           cfg.LNURLAllowLocal,
+		LightningBTCRates:         cfg.LightningBTCRates,
+		FXProviders:               cfg.FXProviders,
+		FXCacheTTL:                cfg.FXCacheTTL,
+		FXMaxStaleness:            cfg.FXMaxStaleness,
+		Currencies:                cfg.Currencies,
 	})
 	if err != nil {
 		_, _ = os.Stderr.WriteString("failed to initialize application: " + err.Error() + "\n")
@@ -53,30 +75,46 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	httpSrv := cserver.Component{Config: cserver.HTTPConfig{
+	httpCfg := cserver.HTTPConfig{
 		Port:             cfg.Port,
 		PublicBaseURL:    cfg.PublicBaseURL,
 		CustomerBaseURL:  cfg.CustomerBaseURL,
 		MerchantBaseURL:  cfg.MerchantBaseURL,
 		DisableRateLimit: cfg.DisableRateLimit,
 		S3Endpoint:       cfg.S3Endpoint,
-	}}
+	}
+	if cfg.MetricsEnabled {
+		httpCfg.Metrics = application.Infra.Metrics
+		httpCfg.MetricsHandler = application.Infra.Metrics.Handler()
+		httpCfg.MetricsToken = cfg.MetricsToken
+		if cfg.MetricsToken == "" {
+			application.Infra.Logger.Warn("/metrics is served without authentication — anyone who can reach the server can read it",
+				"hint", "set METRICS_TOKEN and have the scraper send it as a bearer token",
+			)
+		}
+	}
+	httpSrv := cserver.Component{Config: httpCfg}
 	err = httpSrv.Run(ctx, application.Infra.Logger, func(e *echo.Echo) {
 		e.Use(middleware.SessionMiddlewareWithReposAndOptions(application.Ports.SessionRepo, application.Ports.UserRepo, application.Ports.SessionOptions))
 
 		registerRoutes(e, routeHandlers{
-			Menu:      application.Ports.Menu,
-			Cart:      application.Ports.Cart,
-			Order:     application.Ports.Order,
-			Places:    application.Ports.Places,
-			Kitchen:   application.Ports.Kitchen,
-			Server:    application.Ports.Server,
-			Push:      application.Ports.Push,
-			Admin:     application.Ports.Admin,
-			Owner:     application.Ports.Owner,
-			Dashboard: application.Ports.Dashboard,
-			Auth:      application.Ports.Auth,
-			SSE:       application.Ports.SSE,
+			Menu:         application.Ports.Menu,
+			Cart:         application.Ports.Cart,
+			Order:        application.Ports.Order,
+			LightningPay: application.Ports.LightningPay,
+			Places:       application.Ports.Places,
+			Kitchen:      application.Ports.Kitchen,
+			Server:       application.Ports.Server,
+			Drawer:       application.Ports.Drawer,
+			Shifts:       application.Ports.Shifts,
+			TimeClock:    application.Ports.TimeClock,
+			Promotions:   application.Ports.Promotions,
+			Push:         application.Ports.Push,
+			Admin:        application.Ports.Admin,
+			Owner:        application.Ports.Owner,
+			Dashboard:    application.Ports.Dashboard,
+			TipOut:       application.Ports.TipOut,
+			Auth:         application.Ports.Auth,
 		}, application.Ports.MembershipRepo)
 	})
 	if err != nil {
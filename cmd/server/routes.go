@@ -4,30 +4,37 @@ import (
 	"bitmerchant/internal/auth/domain/membership"
 	authhttp "bitmerchant/internal/auth/ports/http"
 	"bitmerchant/internal/common"
-	commonhttp "bitmerchant/internal/common/http"
 	"bitmerchant/internal/common/http/middleware"
 	dashboardhttp "bitmerchant/internal/dashboard/ports/http"
 	menuhttp "bitmerchant/internal/menu/ports/http"
 	orderinghttp "bitmerchant/internal/ordering/ports/http"
+	paymenthttp "bitmerchant/internal/payment/ports/http"
 	placeshttp "bitmerchant/internal/places/ports/http"
+	promotionhttp "bitmerchant/internal/promotion/ports/http"
 	restauranthttp "bitmerchant/internal/restaurant/ports/http"
 
 	"github.com/labstack/echo/v4"
 )
 
 type routeHandlers struct {
-	Menu      *menuhttp.MenuHandler
-	Cart      *orderinghttp.CartHandler
-	Order     *orderinghttp.OrderHandler
-	Places    *placeshttp.PlacesHandler
-	Kitchen   *orderinghttp.KitchenHandler
-	Server    *orderinghttp.ServerHandler
-	Push      *orderinghttp.PushHandler
-	Admin     *restauranthttp.AdminHandler
-	Owner     *restauranthttp.OwnerHandler
-	Dashboard *dashboardhttp.DashboardHandler
-	Auth      *authhttp.AuthHandler
-	SSE       *commonhttp.SSEHandler
+	Menu  *menuhttp.MenuHandler
+	Cart  *orderinghttp.CartHandler
+	Order *orderinghttp.OrderHandler
+	// LightningPay is nil when no Lightning backend is configured.
+	LightningPay *orderinghttp.LightningPayHandler
+	Places       *placeshttp.PlacesHandler
+	Kitchen      *orderinghttp.KitchenHandler
+	Server       *orderinghttp.ServerHandler
+	Drawer       *paymenthttp.DrawerHandler
+	Shifts       *paymenthttp.ShiftsHandler
+	TimeClock    *paymenthttp.TimeClockHandler
+	Promotions   *promotionhttp.PromotionsHandler
+	Push         *orderinghttp.PushHandler
+	Admin        *restauranthttp.AdminHandler
+	Owner        *restauranthttp.OwnerHandler
+	Dashboard    *dashboardhttp.DashboardHandler
+	TipOut       *dashboardhttp.TipOutHandler
+	Auth         *authhttp.AuthHandler
 }
 
 func registerRoutes(e *echo.Echo, handlers routeHandlers, membershipRepo membership.Repository) {
@@ -48,29 +55,48 @@ func registerRoutes(e *echo.Echo, handlers routeHandlers, membershipRepo members
 	e.GET("/order/lookup", handlers.Order.GetLookup)
 	e.POST("/order/lookup", handlers.Order.PostLookup)
 	e.GET("/order/confirm", handlers.Order.GetConfirmOrder)
+	e.POST("/order/confirm/promo", handlers.Order.PostPromoCode)
 	e.POST("/order/create", handlers.Order.CreateOrder)
 	e.GET("/order/:orderNumber", handlers.Order.GetOrder)
-	e.GET("/order/:orderNumber/stream", handlers.SSE.OrderStatusStream)
+	e.GET("/order/:orderNumber/stream", handlers.Order.StatusStream)
 	e.GET("/order/:orderNumber/receipt", handlers.Order.GetReceipt)
 	e.POST("/order/:orderNumber/call-server", handlers.Order.CallServer)
 	e.POST("/order/:orderNumber/request-bill", handlers.Order.RequestBill)
+	if handlers.LightningPay != nil {
+		e.GET("/order/:orderNumber/pay", handlers.LightningPay.GetPay)
+		e.GET("/order/:orderNumber/pay/qr.png", handlers.LightningPay.GetPayQR)
+	}
 	e.POST("/push/subscribe", handlers.Push.SubscribeCustomer)
 
 	kitchenGroup := e.Group("/kitchen")
 	kitchenGroup.Use(middleware.RequireAuth(), middleware.RequireRole(membershipRepo, common.RoleOwner, common.RoleKitchenStaff))
 	kitchenGroup.GET("", handlers.Kitchen.GetKitchen)
-	kitchenGroup.GET("/stream", handlers.SSE.KitchenStream)
+	kitchenGroup.GET("/stream", handlers.Kitchen.Stream)
 	kitchenGroup.POST("/order/:id/mark-preparing", handlers.Kitchen.MarkPreparing)
 	kitchenGroup.POST("/order/:id/mark-ready", handlers.Kitchen.MarkReady)
 	kitchenGroup.POST("/order/:id/mark-completed", handlers.Kitchen.MarkCompleted)
 	kitchenGroup.POST("/order/:id/item/:itemID/toggle-prep", handlers.Kitchen.ToggleItemPrep)
+	kitchenGroup.POST("/order/:id/cancel", handlers.Kitchen.CancelOrder)
 	kitchenGroup.POST("/push/subscribe", handlers.Push.SubscribeKitchen)
 
 	serverGroup := e.Group("/server")
 	serverGroup.Use(middleware.RequireAuth(), middleware.RequireRole(membershipRepo, common.RoleOwner, common.RoleServer))
 	serverGroup.GET("", handlers.Server.GetServer)
-	serverGroup.GET("/stream", handlers.SSE.ServerStream)
+	serverGroup.GET("/stream", handlers.Server.Stream)
 	serverGroup.POST("/order/:id/mark-paid", handlers.Server.MarkPaid)
+	serverGroup.POST("/order/:id/cancel", handlers.Server.CancelOrder)
+	serverGroup.POST("/order/:id/split", handlers.Server.SplitBill)
+	serverGroup.POST("/order/:id/parts/:partId/pay", handlers.Server.PayBillPart)
+	serverGroup.GET("/drawer", handlers.Drawer.GetDrawer)
+	serverGroup.POST("/drawer/open", handlers.Drawer.PostOpen)
+	serverGroup.POST("/drawer/movement", handlers.Drawer.PostMovement)
+	serverGroup.POST("/drawer/close", handlers.Drawer.PostClose)
+
+	staffGroup := e.Group("/staff")
+	staffGroup.Use(middleware.RequireAuth(), middleware.RequireRole(membershipRepo, common.RoleOwner, common.RoleKitchenStaff, common.RoleServer))
+	staffGroup.GET("/clock", handlers.TimeClock.GetTimeClock)
+	staffGroup.POST("/clock/in", handlers.TimeClock.PostClockIn)
+	staffGroup.POST("/clock/out", handlers.TimeClock.PostClockOut)
 
 	adminGroup := e.Group("/admin")
 	adminGroup.Use(middleware.RequireAuth(), middleware.RequireRole(membershipRepo, common.RoleOwner))
@@ -87,6 +113,17 @@ func registerRoutes(e *echo.Echo, handlers routeHandlers, membershipRepo members
 	adminGroup.POST("/menu/reorder-items", handlers.Admin.PostReorderItems)
 	adminGroup.GET("/kitchen", handlers.Admin.GetKitchenSettings)
 	adminGroup.POST("/kitchen/settings", handlers.Admin.PostKitchenSettings)
+	adminGroup.GET("/payments", handlers.Admin.GetPaymentSettings)
+	adminGroup.POST("/payments/settings", handlers.Admin.PostPaymentSettings)
+	adminGroup.POST("/payments/cash-rounding", handlers.Admin.PostCashRounding)
+	adminGroup.POST("/payments/tips", handlers.Admin.PostTipSettings)
+	adminGroup.POST("/payments/tip-pools", handlers.Admin.PostTipPooling)
+	adminGroup.GET("/taxes", handlers.Admin.GetTaxSettings)
+	adminGroup.POST("/taxes/settings", handlers.Admin.PostTaxSettings)
+	adminGroup.GET("/promotions", handlers.Promotions.GetPromotions)
+	adminGroup.POST("/promotions", handlers.Promotions.PostCreate)
+	adminGroup.POST("/promotions/:id/update", handlers.Promotions.PostUpdate)
+	adminGroup.POST("/promotions/:id/toggle", handlers.Promotions.PostToggle)
 	adminGroup.GET("/qr", handlers.Admin.GetQRPage)
 	adminGroup.POST("/qr/settings", handlers.Admin.PostQRSettings)
 	adminGroup.GET("/qr/print", handlers.Admin.GetQRPrint)
@@ -126,5 +163,10 @@ func registerRoutes(e *echo.Echo, handlers routeHandlers, membershipRepo members
 	dashboardGroup.POST("/toggle-open", handlers.Dashboard.ToggleOpen)
 	dashboardGroup.POST("/pause", handlers.Dashboard.Pause)
 	dashboardGroup.GET("/orders/:orderNumber", handlers.Dashboard.OrderDetail)
+	dashboardGroup.POST("/orders/:orderNumber/cancel", handlers.Dashboard.CancelOrder)
+	dashboardGroup.GET("/shifts", handlers.Shifts.GetShifts)
+	dashboardGroup.GET("/shifts/:id", handlers.Shifts.GetShift)
+	dashboardGroup.GET("/tips", handlers.TipOut.GetTipOut)
+	dashboardGroup.GET("/tips/export", handlers.TipOut.GetTipOutCSV)
 	dashboardGroup.POST("/invite", handlers.Auth.CreateInvitation)
 }
@@ -6,6 +6,7 @@ import (
 	authInfra "bitmerchant/internal/auth/adapters"
 	authapp "bitmerchant/internal/auth/app"
 	authhttp "bitmerchant/internal/auth/ports/http"
+	"bitmerchant/internal/common/decorator"
 	"bitmerchant/internal/common/http/middleware"
 	restaurantCmd "bitmerchant/internal/restaurant/app/command"
 	"bitmerchant/internal/wiring"
@@ -22,6 +23,7 @@ func New(
 	repos wiring.Repositories,
 	webauthnSvc *authInfra.WebAuthnService,
 	logger *slog.Logger,
+	metrics decorator.MetricsClient,
 	sessionOpts middleware.SessionOptions,
 	createRestaurant restaurantCmd.CreateRestaurantHandler,
 	resetBaseURL string,
@@ -31,7 +33,7 @@ func New(
 	}
 	hasher := authInfra.NewBcryptPasswordHasher()
 	mailer := authInfra.NewLoggingMailer(logger)
-	app := authapp.NewApplication(repos.User, repos.Membership, repos.Invitation, repos.Session, repos.Restaurant, repos.PasswordResetToken, mailer, resetBaseURL, createRestaurant, hasher, logger, nil)
+	app := authapp.NewApplication(repos.User, repos.Membership, repos.Invitation, repos.Session, repos.Restaurant, repos.PasswordResetToken, mailer, resetBaseURL, createRestaurant, hasher, logger, metrics)
 	return &Auth{
 		Application: app,
 		HTTP:        authhttp.NewAuthHandler(webauthnSvc, app, logger, sessionOpts),
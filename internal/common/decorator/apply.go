@@ -5,9 +5,13 @@ import (
 	"log/slog"
 	"reflect"
 	"time"
+
+	"bitmerchant/internal/common/tracecontext"
 )
 
-// ApplyCommandDecorators wraps a command handler with logging and metrics.
+var tracer = tracecontext.Tracer("bitmerchant/decorator")
+
+// ApplyCommandDecorators wraps a command handler with tracing, logging and metrics.
 func ApplyCommandDecorators[C any](h CommandHandler[C], log *slog.Logger, metrics MetricsClient) CommandHandler[C] {
 	if metrics == nil {
 		metrics = NoopMetrics{}
@@ -15,7 +19,7 @@ func ApplyCommandDecorators[C any](h CommandHandler[C], log *slog.Logger, metric
 	return commandDecorated[C]{inner: h, log: log, metrics: metrics}
 }
 
-// ApplyQueryDecorators wraps a query handler with logging and metrics.
+// ApplyQueryDecorators wraps a query handler with tracing, logging and metrics.
 func ApplyQueryDecorators[Q any, R any](h QueryHandler[Q, R], log *slog.Logger, metrics MetricsClient) QueryHandler[Q, R] {
 	if metrics == nil {
 		metrics = NoopMetrics{}
@@ -40,14 +44,18 @@ type commandDecorated[C any] struct {
 	metrics MetricsClient
 }
 
-func (d commandDecorated[C]) Handle(ctx context.Context, cmd C) error {
+func (d commandDecorated[C]) Handle(ctx context.Context, cmd C) (err error) {
 	name := typeName(cmd)
+	ctx, span := tracer.Start(ctx, "command "+name)
+	defer func() { tracecontext.End(span, err) }()
+
 	start := time.Now()
-	err := d.inner.Handle(ctx, cmd)
+	err = d.inner.Handle(ctx, cmd)
+	duration := time.Since(start)
 	if d.log != nil {
-		d.log.DebugContext(ctx, "command", "name", name, "duration_ms", time.Since(start).Milliseconds(), "err", err)
+		d.log.DebugContext(ctx, "command", "name", name, "duration_ms", duration.Milliseconds(), "err", err)
 	}
-	d.metrics.IncCommand(name)
+	d.metrics.ObserveCommand(name, duration, err)
 	return err
 }
 
@@ -57,13 +65,17 @@ type queryDecorated[Q any, R any] struct {
 	metrics MetricsClient
 }
 
-func (d queryDecorated[Q, R]) Handle(ctx context.Context, q Q) (R, error) {
+func (d queryDecorated[Q, R]) Handle(ctx context.Context, q Q) (r R, err error) {
 	name := typeName(q)
+	ctx, span := tracer.Start(ctx, "query "+name)
+	defer func() { tracecontext.End(span, err) }()
+
 	start := time.Now()
-	r, err := d.inner.Handle(ctx, q)
+	r, err = d.inner.Handle(ctx, q)
+	duration := time.Since(start)
 	if d.log != nil {
-		d.log.DebugContext(ctx, "query", "name", name, "duration_ms", time.Since(start).Milliseconds(), "err", err)
+		d.log.DebugContext(ctx, "query", "name", name, "duration_ms", duration.Milliseconds(), "err", err)
 	}
-	d.metrics.IncQuery(name)
+	d.metrics.ObserveQuery(name, duration, err)
 	return r, err
 }
@@ -0,0 +1,60 @@
+package decorator_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"bitmerchant/internal/common/decorator"
+)
+
+type placeOrder struct{}
+
+type placeOrderHandler struct {
+	err     error
+	spanCtx trace.SpanContext
+}
+
+func (h *placeOrderHandler) Handle(ctx context.Context, _ placeOrder) error {
+	h.spanCtx = trace.SpanContextFromContext(ctx)
+	return h.err
+}
+
+func TestApplyCommandDecorators_TracesHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	inner := &placeOrderHandler{err: errors.New("kitchen closed")}
+	h := decorator.ApplyCommandDecorators[placeOrder](inner, nil, nil)
+
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	err := h.Handle(ctx, placeOrder{})
+	request.End()
+	if err == nil {
+		t.Fatal("expected the handler's error")
+	}
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected command and request spans, got %d", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "command placeOrder" {
+		t.Fatalf("span name = %q", span.Name())
+	}
+	if span.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Fatal("command span is not a child of the caller's span")
+	}
+	if inner.spanCtx.SpanID() != span.SpanContext().SpanID() {
+		t.Fatal("handler does not run under the command span")
+	}
+	if span.Status().Code != codes.Error {
+		t.Fatalf("span status = %v, want error", span.Status().Code)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log/slog"
 	"time"
+
+	"bitmerchant/internal/common/tracecontext"
 )
 
 // CommandResultHandler handles a command that returns a result (e.g. newly created aggregate).
@@ -12,7 +14,7 @@ type CommandResultHandler[C any, R any] interface {
 	Handle(ctx context.Context, cmd C) (R, error)
 }
 
-// ApplyCommandResultDecorators wraps a command+result handler with logging and metrics.
+// ApplyCommandResultDecorators wraps a command+result handler with tracing, logging and metrics.
 func ApplyCommandResultDecorators[C any, R any](h CommandResultHandler[C, R], log *slog.Logger, metrics MetricsClient) CommandResultHandler[C, R] {
 	if metrics == nil {
 		metrics = NoopMetrics{}
@@ -26,13 +28,17 @@ type commandResultDecorated[C any, R any] struct {
 	metrics MetricsClient
 }
 
-func (d commandResultDecorated[C, R]) Handle(ctx context.Context, cmd C) (R, error) {
+func (d commandResultDecorated[C, R]) Handle(ctx context.Context, cmd C) (r R, err error) {
 	name := typeName(cmd)
+	ctx, span := tracer.Start(ctx, "command "+name)
+	defer func() { tracecontext.End(span, err) }()
+
 	start := time.Now()
-	r, err := d.inner.Handle(ctx, cmd)
+	r, err = d.inner.Handle(ctx, cmd)
+	duration := time.Since(start)
 	if d.log != nil {
-		d.log.DebugContext(ctx, "command", "name", name, "duration_ms", time.Since(start).Milliseconds(), "err", err)
+		d.log.DebugContext(ctx, "command", "name", name, "duration_ms", duration.Milliseconds(), "err", err)
 	}
-	d.metrics.IncCommand(name)
+	d.metrics.ObserveCommand(name, duration, err)
 	return r, err
 }
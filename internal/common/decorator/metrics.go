@@ -1,13 +1,16 @@
 package decorator
 
-// MetricsClient records command/query execution (optional; use NoopMetrics when unset).
+import "time"
+
+// MetricsClient records command/query executions: how long each took and
+// whether it failed, per handler name (optional; use NoopMetrics when unset).
 type MetricsClient interface {
-	IncCommand(name string)
-	IncQuery(name string)
+	ObserveCommand(name string, duration time.Duration, err error)
+	ObserveQuery(name string, duration time.Duration, err error)
 }
 
 // NoopMetrics is a no-op MetricsClient.
 type NoopMetrics struct{}
 
-func (NoopMetrics) IncCommand(string) {}
-func (NoopMetrics) IncQuery(string)   {}
+func (NoopMetrics) ObserveCommand(string, time.Duration, error) {}
+func (NoopMetrics) ObserveQuery(string, time.Duration, error)   {}
@@ -0,0 +1,113 @@
+// Package envelope defines the wrapper every event travels in on the bus.
+// The envelope carries what a consumer needs besides the event itself: a
+// stable ID, the event type and schema version, the restaurant it belongs
+// to, the correlation, causation and actor metadata of the request that
+// raised it, and the trace context it was raised in. Consumers decode
+// through Decode, which upcasts data written under an older schema version
+// before handing it over.
+package envelope
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"bitmerchant/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnknownVersion is returned when an event arrives under a schema
+// version the consumer has no upcaster for, typically one newer than its own.
+var ErrUnknownVersion = errors.New("unknown event version")
+
+// Envelope is an event as published on the bus.
+type Envelope struct {
+	ID           string              `json:"id"`
+	Type         string              `json:"type"`
+	Version      int                 `json:"version"`
+	RestaurantID common.RestaurantID `json:"restaurant_id,omitempty"`
+	// CorrelationID is the request ID of the HTTP request the chain of
+	// events started from.
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// CausationID is the ID of the event whose handler raised this one,
+	// empty when a request raised it directly.
+	CausationID string `json:"causation_id,omitempty"`
+	Actor       string `json:"actor,omitempty"`
+	// TraceContext is the W3C trace context of the span that raised the
+	// event, so its consumers join the same trace even when the event
+	// waited in the outbox.
+	TraceContext map[string]string `json:"trace_context,omitempty"`
+	OccurredAt   time.Time         `json:"occurred_at"`
+	Data         json.RawMessage   `json:"data"`
+}
+
+// Versioned is implemented by events whose schema has changed since it was
+// first published; other events are version 1.
+type Versioned interface {
+	EventVersion() int
+}
+
+// New wraps ev, published as eventType, in an envelope carrying md. An event
+// stamped with Stamp carries its own metadata, which takes precedence.
+func New(eventType string, ev any, md Metadata) (Envelope, error) {
+	if s, ok := ev.(stamped); ok {
+		ev, md = s.DomainEvent, s.md
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("encode %s: %w", eventType, err)
+	}
+	env := Envelope{
+		ID:            uuid.NewString(),
+		Type:          eventType,
+		Version:       versionOf(ev),
+		CorrelationID: md.CorrelationID,
+		CausationID:   md.CausationID,
+		Actor:         md.Actor,
+		TraceContext:  md.TraceContext,
+		OccurredAt:    time.Now().UTC(),
+		Data:          data,
+	}
+	if de, ok := ev.(common.DomainEvent); ok {
+		env.Type = de.EventName()
+		if at := de.OccurredAt(); !at.IsZero() {
+			env.OccurredAt = at.UTC()
+		}
+	}
+	if re, ok := ev.(common.RestaurantEvent); ok {
+		env.RestaurantID = re.EventRestaurantID()
+	}
+	return env, nil
+}
+
+// Decode reads the envelope in payload, upcasts its data to the version v
+// expects and decodes the data into v. A payload published before events
+// were enveloped is read as version 1 data.
+func Decode(payload []byte, v any) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(payload, &env); err != nil || env.Type == "" || len(env.Data) == 0 {
+		env = Envelope{Version: 1, Data: payload}
+	}
+	if err := upcast(&env, versionOf(v)); err != nil {
+		return env, err
+	}
+	if err := json.Unmarshal(env.Data, v); err != nil {
+		return env, fmt.Errorf("decode %s: %w", env.Type, err)
+	}
+	return env, nil
+}
+
+// Caused returns the metadata for events raised while handling e: they
+// share its correlation ID and actor and are caused by it.
+func (e Envelope) Caused() Metadata {
+	return Metadata{CorrelationID: e.CorrelationID, CausationID: e.ID, Actor: e.Actor}
+}
+
+func versionOf(v any) int {
+	if ver, ok := v.(Versioned); ok {
+		return ver.EventVersion()
+	}
+	return 1
+}
@@ -0,0 +1,101 @@
+package envelope_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/envelope"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tableSeated struct {
+	RestaurantID common.RestaurantID
+	Table        string
+	At           time.Time
+}
+
+func (e tableSeated) EventName() string                      { return "test.table_seated" }
+func (e tableSeated) OccurredAt() time.Time                  { return e.At }
+func (e tableSeated) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
+
+// tableSeatedV2 split Table into Section and Number.
+type tableSeatedV2 struct {
+	Section string
+	Number  int
+}
+
+func (tableSeatedV2) EventVersion() int { return 2 }
+
+func init() {
+	envelope.RegisterUpcaster("test.table_seated", 1, func(data json.RawMessage) (json.RawMessage, error) {
+		var v1 struct{ Table string }
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(tableSeatedV2{Section: "main", Number: len(v1.Table)})
+	})
+}
+
+func TestNew_FillsEnvelopeFromEventAndMetadata(t *testing.T) {
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	ev := tableSeated{RestaurantID: "r1", Table: "T4", At: at}
+	env, err := envelope.New("ignored", ev, envelope.Metadata{CorrelationID: "req-1", Actor: "user-1"})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, env.ID)
+	assert.Equal(t, "test.table_seated", env.Type, "a domain event names its own type")
+	assert.Equal(t, 1, env.Version)
+	assert.Equal(t, common.RestaurantID("r1"), env.RestaurantID)
+	assert.Equal(t, "req-1", env.CorrelationID)
+	assert.Equal(t, "user-1", env.Actor)
+	assert.Equal(t, at, env.OccurredAt)
+
+	ctx := envelope.WithMetadata(context.Background(), envelope.Metadata{CorrelationID: "req-2"})
+	stamped, err := envelope.New("ignored", envelope.Stamp(ctx, ev), envelope.Metadata{CorrelationID: "req-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "req-2", stamped.CorrelationID, "a stamp wins over the publisher's metadata")
+	assert.JSONEq(t, string(env.Data), string(stamped.Data), "the stamp is not part of the data")
+}
+
+func TestDecode_UpcastsAndReadsLegacyPayloads(t *testing.T) {
+	env, err := envelope.New("", tableSeated{RestaurantID: "r1", Table: "T12"}, envelope.Metadata{})
+	require.NoError(t, err)
+	payload, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	var v1 tableSeated
+	got, err := envelope.Decode(payload, &v1)
+	require.NoError(t, err)
+	assert.Equal(t, env.ID, got.ID)
+	assert.Equal(t, "T12", v1.Table)
+
+	var v2 tableSeatedV2
+	got, err = envelope.Decode(payload, &v2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Version)
+	assert.Equal(t, tableSeatedV2{Section: "main", Number: 3}, v2)
+
+	var old tableSeated
+	newer, _ := json.Marshal(envelope.Envelope{ID: "e1", Type: "test.table_seated", Version: 2, Data: json.RawMessage(`{}`)})
+	_, err = envelope.Decode(newer, &old)
+	assert.ErrorIs(t, err, envelope.ErrUnknownVersion)
+
+	var legacy tableSeated
+	got, err = envelope.Decode([]byte(`{"RestaurantID":"r1","Table":"T1"}`), &legacy)
+	require.NoError(t, err, "events published before the envelope still decode")
+	assert.Equal(t, "T1", legacy.Table)
+	assert.Equal(t, 1, got.Version)
+
+	_, err = envelope.Decode([]byte(`not json`), &legacy)
+	assert.Error(t, err)
+}
+
+func TestCaused_ChainsCorrelation(t *testing.T) {
+	env := envelope.Envelope{ID: "e1", CorrelationID: "req-1", CausationID: "e0", Actor: "user-1"}
+	assert.Equal(t, envelope.Metadata{CorrelationID: "req-1", CausationID: "e1", Actor: "user-1"}, env.Caused())
+}
@@ -0,0 +1,60 @@
+package envelope
+
+import (
+	"context"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tracecontext"
+)
+
+// ActorGuest is the actor of events raised by a customer without an account.
+const ActorGuest = "guest"
+
+// Metadata is what an envelope records about the work that raised an event.
+type Metadata struct {
+	CorrelationID string
+	CausationID   string
+	// Actor is the ID of the signed-in user, ActorGuest for a customer
+	// session, or empty for background work.
+	Actor string
+	// TraceContext is the trace context of the current span, if any.
+	TraceContext map[string]string
+}
+
+type metadataKey struct{}
+
+// WithMetadata returns a context whose events are published with md.
+func WithMetadata(ctx context.Context, md Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// MetadataFromContext returns the metadata stored in ctx, or none, with the
+// trace context of the span in ctx.
+func MetadataFromContext(ctx context.Context) Metadata {
+	md, _ := ctx.Value(metadataKey{}).(Metadata)
+	md.TraceContext = tracecontext.Carrier(ctx)
+	return md
+}
+
+// Stamp attaches the metadata in ctx to ev. Aggregates keep their events
+// until the repository stores them, out of reach of the request context;
+// a stamped event carries the metadata there.
+func Stamp(ctx context.Context, ev common.DomainEvent) common.DomainEvent {
+	if s, ok := ev.(stamped); ok {
+		ev = s.DomainEvent
+	}
+	return stamped{DomainEvent: ev, md: MetadataFromContext(ctx)}
+}
+
+// Unstamp returns the event ev was stamped from, or ev itself.
+func Unstamp(ev common.DomainEvent) common.DomainEvent {
+	if s, ok := ev.(stamped); ok {
+		return s.DomainEvent
+	}
+	return ev
+}
+
+type stamped struct {
+	common.DomainEvent
+	md Metadata
+}
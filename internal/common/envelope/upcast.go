@@ -0,0 +1,57 @@
+package envelope
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Upcaster rewrites an event's data from one schema version to the next.
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+type upcastKey struct {
+	eventType string
+	from      int
+}
+
+var (
+	upcastersMu sync.RWMutex
+	upcasters   = map[upcastKey]Upcaster{}
+)
+
+// RegisterUpcaster teaches Decode to read eventType data written under
+// schema version from as version from+1. When an event's schema changes,
+// bump its EventVersion and register an upcaster from the old version, so
+// messages already on the bus or in the outbox still decode. It panics if
+// an upcaster for the same step is already registered.
+func RegisterUpcaster(eventType string, from int, up Upcaster) {
+	upcastersMu.Lock()
+	defer upcastersMu.Unlock()
+	key := upcastKey{eventType: eventType, from: from}
+	if _, ok := upcasters[key]; ok {
+		panic(fmt.Sprintf("envelope: upcaster for %s v%d already registered", eventType, from))
+	}
+	upcasters[key] = up
+}
+
+// upcast steps env's data up one version at a time until it reaches target.
+func upcast(env *Envelope, target int) error {
+	if env.Version > target {
+		return fmt.Errorf("%s v%d, expected at most v%d: %w", env.Type, env.Version, target, ErrUnknownVersion)
+	}
+	upcastersMu.RLock()
+	defer upcastersMu.RUnlock()
+	for env.Version < target {
+		up, ok := upcasters[upcastKey{eventType: env.Type, from: env.Version}]
+		if !ok {
+			return fmt.Errorf("%s v%d, no upcaster to v%d: %w", env.Type, env.Version, env.Version+1, ErrUnknownVersion)
+		}
+		data, err := up(env.Data)
+		if err != nil {
+			return fmt.Errorf("upcast %s v%d: %w", env.Type, env.Version, err)
+		}
+		env.Data = data
+		env.Version++
+	}
+	return nil
+}
@@ -11,9 +11,15 @@ const (
 	EventOrderPreparing       = "order.preparing"
 	EventOrderReady           = "order.ready"
 	EventOrderCompleted       = "order.completed"
+	EventOrderCancelled       = "order.cancelled"
+	EventOrderBillSplit       = "order.bill_split"
+	EventOrderBillPartPaid    = "order.bill_part_paid"
+	EventOrderTipAdded        = "order.tip_added"
 	EventOrderItemPrepToggled = "order_item.prep_toggled"
 	EventServerCalled         = "order.server_called"
 	EventBillRequested        = "order.bill_requested"
+	EventPaymentCompleted     = "payment.completed"
+	EventPaymentRefunded      = "payment.refunded"
 )
 
 // DomainEvent represents a domain event interface.
@@ -22,6 +28,13 @@ type DomainEvent interface {
 	OccurredAt() time.Time
 }
 
+// RestaurantEvent is a DomainEvent raised inside one restaurant. The event
+// envelope records which, so consumers can scope it without decoding it.
+type RestaurantEvent interface {
+	DomainEvent
+	EventRestaurantID() RestaurantID
+}
+
 // EventBus defines the interface for publishing domain events.
 type EventBus interface {
 	Publish(ctx context.Context, topic string, event interface{}) error
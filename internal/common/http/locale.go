@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strings"
 
+	"bitmerchant/internal/common/money"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -13,6 +15,9 @@ const LocaleCookieName = "lang"
 // DefaultLocale is the base language; menu items fall back to it.
 const DefaultLocale = "en"
 
+// contextLocale caches the locale LocaleMiddleware resolved for the request.
+const contextLocale = "locale"
+
 func normalizeLocale(raw string) string {
 	raw = strings.ToLower(strings.TrimSpace(raw))
 	if raw == "" {
@@ -38,10 +43,20 @@ func normalizeLocale(raw string) string {
 }
 
 // ResolveLocale determines the menu language for the request, in priority order:
-// an explicit ?lang= query param (also persisted to a cookie), the lang cookie,
-// the Accept-Language header, then DefaultLocale. When ?lang= is present it sets
-// the cookie so the choice survives navigation.
+// an explicit ?lang= query param, the lang cookie, the Accept-Language header,
+// then DefaultLocale. It only reads the request; SwitchLocale is what saves a
+// choice. Once LocaleMiddleware has run, its result is reused.
 func ResolveLocale(c echo.Context) string {
+	if resolved, ok := c.Get(contextLocale).(string); ok && resolved != "" {
+		return resolved
+	}
+	return resolveLocale(c)
+}
+
+// SwitchLocale is ResolveLocale for the page behind the language switch: an
+// explicit ?lang= is also written to the lang cookie, so the choice survives
+// navigation.
+func SwitchLocale(c echo.Context) string {
 	if q := normalizeLocale(c.QueryParam("lang")); q != "" {
 		c.SetCookie(&http.Cookie{
 			Name:     LocaleCookieName,
@@ -51,6 +66,12 @@ func ResolveLocale(c echo.Context) string {
 			HttpOnly: false,
 			SameSite: http.SameSiteLaxMode,
 		})
+	}
+	return ResolveLocale(c)
+}
+
+func resolveLocale(c echo.Context) string {
+	if q := normalizeLocale(c.QueryParam("lang")); q != "" {
 		return q
 	}
 	if ck, err := c.Cookie(LocaleCookieName); err == nil {
@@ -63,3 +84,32 @@ func ResolveLocale(c echo.Context) string {
 	}
 	return DefaultLocale
 }
+
+// LocaleMiddleware resolves the request locale once and stores it on the
+// request context, so money amounts rendered by templates use its grouping
+// and decimal separators (see money.FormatContext).
+func LocaleMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := resolveLocale(c)
+			c.Set(contextLocale, locale)
+			c.SetRequest(c.Request().WithContext(money.WithLocale(c.Request().Context(), formatLocale(c, locale))))
+			return next(c)
+		}
+	}
+}
+
+// formatLocale is the locale amounts are written in: the request language,
+// with the region from Accept-Language when it names the same language
+// ("pt-br"), since a region can write amounts differently from the rest of
+// its language.
+func formatLocale(c echo.Context, lang string) string {
+	tag := strings.ToLower(strings.TrimSpace(c.Request().Header.Get("Accept-Language")))
+	if i := strings.IndexAny(tag, ",;"); i >= 0 {
+		tag = tag[:i]
+	}
+	if primary, region, ok := strings.Cut(tag, "-"); ok && primary == lang && region != "" {
+		return lang + "-" + region
+	}
+	return lang
+}
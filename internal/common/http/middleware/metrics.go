@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPMetrics records served requests.
+type HTTPMetrics interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+}
+
+// MetricsMiddleware records every request under its route pattern (c.Path),
+// so path parameters such as order numbers stay out of the labels.
+func MetricsMiddleware(metrics HTTPMetrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				// The error handler has not written the response yet.
+				status, _ = resolveHTTPError(err)
+			}
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			metrics.ObserveHTTPRequest(c.Request().Method, route, status, time.Since(start))
+			return err
+		}
+	}
+}
@@ -1,18 +1,23 @@
 package middleware
 
 import (
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/infrastructure/logging"
 	"log/slog"
 
 	"github.com/labstack/echo/v4"
 	"github.com/lithammer/shortuuid/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const correlationIDHeader = "Correlation-ID"
 
 // RequestIDMiddleware generates or propagates a Correlation-ID for each request,
 // attaches an enriched logger to the request context, and echoes the ID back in
-// the response header.
+// the response header. Events the request raises carry the ID as their
+// correlation ID. When the request is traced, its span records the ID and
+// the logger the trace ID, so logs and traces lead to each other.
 func RequestIDMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -22,7 +27,12 @@ func RequestIDMiddleware() echo.MiddlewareFunc {
 			}
 
 			enriched := slog.Default().With("request_id", id)
+			if span := trace.SpanFromContext(c.Request().Context()); span.SpanContext().IsValid() {
+				span.SetAttributes(attribute.String("request.id", id))
+				enriched = enriched.With("trace_id", span.SpanContext().TraceID().String())
+			}
 			ctx := logging.ToContext(c.Request().Context(), enriched)
+			ctx = envelope.WithMetadata(ctx, envelope.Metadata{CorrelationID: id})
 			c.SetRequest(c.Request().WithContext(ctx))
 			c.Response().Header().Set(correlationIDHeader, id)
 
@@ -3,6 +3,7 @@ package middleware
 import (
 	"bitmerchant/internal/auth/domain/session"
 	"bitmerchant/internal/auth/domain/user"
+	"bitmerchant/internal/common/envelope"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -169,13 +170,21 @@ func loadOrCreateSession(sessionRepo session.Repository, sessionID string, opts
 }
 
 func attachIdentityFromSession(c echo.Context, session *session.Session, userRepo user.Repository) {
+	actor := envelope.ActorGuest
 	if session.UserID != nil {
 		user, err := userRepo.FindByID(*session.UserID)
 		if err == nil && user != nil {
 			c.Set(ContextAuthUser, user)
+			actor = string(user.ID)
 		}
 	}
 	if session.RestaurantID != nil {
 		c.Set(ContextRestaurantID, *session.RestaurantID)
 	}
+
+	// Events raised by the request name who raised them.
+	ctx := c.Request().Context()
+	md := envelope.MetadataFromContext(ctx)
+	md.Actor = actor
+	c.SetRequest(c.Request().WithContext(envelope.WithMetadata(ctx, md)))
 }
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+
+	"bitmerchant/internal/common/tracecontext"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracecontext.Tracer("bitmerchant/http")
+
+// TracingMiddleware starts a server span per request, continuing the trace
+// in the request's traceparent header if there is one. The span is named
+// after the route pattern (c.Path), like the request metrics, and handlers
+// see it in the request context.
+func TracingMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			ctx := tracecontext.ExtractHeaders(req.Context(), req.Header)
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				status, _ = resolveHTTPError(err)
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return err
+		}
+	}
+}
@@ -1,121 +1,286 @@
 package commonhttp
 
 import (
+	"context"
 	"fmt"
-	"net/http"
+	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
+	"bitmerchant/internal/common"
+
 	"github.com/labstack/echo/v4"
 )
 
 const (
 	// Datastar event names
 	EventDatastarPatchElements = "datastar-patch-elements"
+)
+
+// KitchenTopic is the kitchen board topic for one restaurant.
+func KitchenTopic(restaurantID common.RestaurantID) string {
+	return "kitchen:" + string(restaurantID)
+}
+
+// ServerTopic is the FOH server board topic for one restaurant.
+func ServerTopic(restaurantID common.RestaurantID) string {
+	return "server:" + string(restaurantID)
+}
+
+// OrderTopic is the customer status topic for one order. Order numbers are
+// only unique within a restaurant, so the topic is keyed by order ID.
+func OrderTopic(restaurantID common.RestaurantID, orderID common.OrderID) string {
+	return "order:" + string(restaurantID) + ":" + string(orderID)
+}
 
-	// Topic names for internal broadcasting
-	TopicKitchen = "kitchen"
-	TopicServer  = "server"
-	// TopicOrder is a format string for orderNumber.
-	TopicOrder = "order:%s"
+// SSERelay carries broadcasts between server replicas. Every Publish must
+// reach the Subscribe callback of every replica, this one included.
+type SSERelay interface {
+	Publish(topic string, message []byte) error
+	Subscribe(deliver func(topic string, message []byte)) error
+}
+
+// Snapshot renders a patch that brings a view fully up to date. A stream
+// sends it when a client can't be caught up from the replay buffer.
+type Snapshot func(ctx context.Context) ([]byte, error)
+
+const (
+	// clientBuffer is how many frames a slow client may fall behind before
+	// it is resynced with a snapshot.
+	clientBuffer = 64
+	// sweepInterval is how often idle topic buffers are dropped.
+	sweepInterval = time.Minute
 )
 
-// SSEHandler handles Server-Sent Events.
+// SSEHandler handles Server-Sent Events. Every broadcast gets a per-topic
+// event ID and is kept in a bounded replay buffer, so a client that
+// reconnects with Last-Event-ID picks up where it left off.
 type SSEHandler struct {
-	mu      sync.RWMutex
-	clients map[string]map[chan []byte]bool
+	mu        sync.Mutex
+	topics    map[string]*topicLog
+	relay     SSERelay
+	origin    string
+	lastSweep time.Time
+	now       func() time.Time
 }
 
-// NewSSEHandler creates a new SSEHandler.
+// NewSSEHandler creates an SSEHandler that only reaches clients connected to
+// this process. Enough for a single node.
 func NewSSEHandler() *SSEHandler {
 	return &SSEHandler{
-		clients: make(map[string]map[chan []byte]bool),
+		topics: make(map[string]*topicLog),
+		origin: newOrigin(),
+		now:    time.Now,
 	}
 }
 
-// OrderStatusStream handles GET /order/:orderNumber/stream
-func (h *SSEHandler) OrderStatusStream(c echo.Context) error {
-	orderNumber := c.Param("orderNumber")
-	if orderNumber == "" {
-		return c.String(http.StatusBadRequest, "Order number required")
+// NewRelayedSSEHandler creates an SSEHandler whose broadcasts go through
+// relay, so clients on every replica receive them.
+func NewRelayedSSEHandler(relay SSERelay) (*SSEHandler, error) {
+	if relay == nil {
+		panic("nil SSE relay")
 	}
-
-	return h.handleStream(c, fmt.Sprintf(TopicOrder, orderNumber))
-}
-
-// KitchenStream handles GET /kitchen/stream
-func (h *SSEHandler) KitchenStream(c echo.Context) error {
-	return h.handleStream(c, TopicKitchen)
-}
-
-// ServerStream handles GET /server/stream
-func (h *SSEHandler) ServerStream(c echo.Context) error {
-	return h.handleStream(c, TopicServer)
+	h := NewSSEHandler()
+	if err := relay.Subscribe(h.deliver); err != nil {
+		return nil, fmt.Errorf("subscribe sse relay: %w", err)
+	}
+	h.relay = relay
+	return h, nil
 }
 
-func (h *SSEHandler) handleStream(c echo.Context, topic string) error {
+// Stream writes everything broadcast on topic to the response until the
+// client goes away. Callers resolve and authorise the topic first.
+//
+// A Last-Event-ID header replays the frames the client missed. When they
+// have left the buffer, or the client falls too far behind while connected,
+// it is sent snapshot instead. Without a snapshot a client past the buffer
+// just carries on from now, and one that falls behind is hung up on so it
+// reconnects and replays.
+func (h *SSEHandler) Stream(c echo.Context, topic string, snapshot Snapshot) error {
 	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
 	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
 	c.Response().Header().Set(echo.HeaderConnection, "keep-alive")
 
-	clientChan := make(chan []byte)
-	h.addClient(topic, clientChan)
-	defer h.removeClient(topic, clientChan)
+	ctx := c.Request().Context()
+	client := newSSEClient()
+	missed, cursor, caughtUp := h.addClient(topic, client, c.Request().Header.Get("Last-Event-ID"))
+	defer h.removeClient(topic, client)
+
+	write := func(frame []byte) error {
+		if _, err := c.Response().Write(frame); err != nil {
+			return err
+		}
+		c.Response().Flush()
+		return nil
+	}
+	resync := func() error {
+		at := h.drain(topic, client)
+		patch, err := snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		return write(append(idLine(at), patch...))
+	}
+
+	if !caughtUp && snapshot != nil {
+		if err := resync(); err != nil {
+			return err
+		}
+	} else {
+		// Tell the client where it stands so a reconnect can resume even if
+		// nothing is broadcast before it drops.
+		if err := write(cursorFrame(cursor)); err != nil {
+			return err
+		}
+		for _, frame := range missed {
+			if err := write(frame.data); err != nil {
+				return err
+			}
+		}
+	}
 
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-c.Request().Context().Done():
+		case <-ctx.Done():
 			return nil
-		case msg := <-clientChan:
-			if _, err := c.Response().Write(msg); err != nil {
+		case <-client.overflow:
+			if snapshot == nil {
+				// Hang up; the client reconnects with Last-Event-ID and
+				// replays from the buffer.
+				return nil
+			}
+			if err := resync(); err != nil {
+				return err
+			}
+		case frame := <-client.frames:
+			if err := write(frame.data); err != nil {
 				return err
 			}
-			c.Response().Flush()
 		case <-ticker.C:
-			if _, err := c.Response().Write([]byte(": keepalive\n\n")); err != nil {
+			if err := write([]byte(": keepalive\n\n")); err != nil {
 				return err
 			}
-			c.Response().Flush()
 		}
 	}
 }
 
-func (h *SSEHandler) addClient(id string, client chan []byte) {
+// addClient registers client on topic and works out what it missed since
+// lastEventID. caughtUp is false when the gap can't be replayed.
+func (h *SSEHandler) addClient(topic string, client *sseClient, lastEventID string) (missed []sseFrame, cursor string, caughtUp bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	log := h.topic(topic)
+	log.clients[client] = struct{}{}
+	if lastEventID == "" {
+		return nil, log.lastID(), true
+	}
+	missed, caughtUp = log.since(lastEventID)
+	return missed, log.lastID(), caughtUp
+}
+
+func (h *SSEHandler) removeClient(topic string, client *sseClient) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	if _, ok := h.clients[id]; !ok {
-		h.clients[id] = make(map[chan []byte]bool)
+	if log, ok := h.topics[topic]; ok {
+		delete(log.clients, client)
 	}
-	h.clients[id][client] = true
 }
 
-func (h *SSEHandler) removeClient(id string, client chan []byte) {
+// drain drops the frames queued for client and returns the ID of the
+// newest frame on topic, which a snapshot taken now covers.
+func (h *SSEHandler) drain(topic string, client *sseClient) string {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	if clients, ok := h.clients[id]; ok {
-		delete(clients, client)
-		close(client)
-		if len(clients) == 0 {
-			delete(h.clients, id)
+	for {
+		select {
+		case <-client.frames:
+		case <-client.overflow:
+		default:
+			return h.topic(topic).lastID()
 		}
 	}
 }
 
-// Broadcast sends message to all clients listening to id.
+// Broadcast sends message, a single SSE event, to all clients listening to
+// id, on every replica when the handler is relayed. If the relay is
+// unreachable the local clients still get the message.
 func (h *SSEHandler) Broadcast(id string, message []byte) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
-	if clients, ok := h.clients[id]; ok {
-		for client := range clients {
+	h.mu.Lock()
+	frame := append(idLine(h.topic(id).nextID(h.origin)), message...)
+	h.mu.Unlock()
+
+	if h.relay != nil {
+		err := h.relay.Publish(id, frame)
+		if err == nil {
+			return
+		}
+		slog.Default().Warn("sse relay publish failed; delivering locally", "topic", id, "error", err)
+	}
+	h.deliver(id, frame)
+}
+
+// deliver buffers frame on topic and hands it to the topic's clients. A
+// client whose queue is full is flagged for a resync instead of blocking.
+func (h *SSEHandler) deliver(topic string, frame []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	log := h.topic(topic)
+	f := sseFrame{id: frameID(frame), data: frame}
+	log.append(f, h.now())
+	for client := range log.clients {
+		select {
+		case client.frames <- f:
+		default:
 			select {
-			case client <- message:
+			case client.overflow <- struct{}{}:
 			default:
 			}
 		}
 	}
+	h.sweep()
+}
+
+// ClientCounts returns how many clients are connected to this process, by
+// topic kind: the part of the topic before its first colon ("kitchen",
+// "server", "order"), so per-restaurant and per-order topics add up.
+func (h *SSEHandler) ClientCounts() map[string]int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	counts := make(map[string]int)
+	for topic, log := range h.topics {
+		kind, _, _ := strings.Cut(topic, ":")
+		counts[kind] += len(log.clients)
+	}
+	return counts
+}
+
+// topic returns the log for topic, creating it. Callers hold h.mu.
+func (h *SSEHandler) topic(topic string) *topicLog {
+	log, ok := h.topics[topic]
+	if !ok {
+		log = newTopicLog(h.origin, h.now())
+		h.topics[topic] = log
+	}
+	return log
+}
+
+// sweep drops the buffers of topics nobody has listened to or broadcast on
+// for a replay window. Callers hold h.mu.
+func (h *SSEHandler) sweep() {
+	now := h.now()
+	if now.Sub(h.lastSweep) < sweepInterval {
+		return
+	}
+	h.lastSweep = now
+	for topic, log := range h.topics {
+		if len(log.clients) == 0 && now.Sub(log.touched) > replayWindow {
+			delete(h.topics, topic)
+		}
+	}
 }
 
 // FormatDatastarEvent formats the message as a Datastar SSE event.
@@ -0,0 +1,119 @@
+package commonhttp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+const (
+	// replayBuffer is how many frames each topic keeps for reconnecting
+	// clients.
+	replayBuffer = 128
+	// replayWindow is how long a topic's buffer outlives its last client
+	// and broadcast.
+	replayWindow = 10 * time.Minute
+)
+
+// sseFrame is one broadcast SSE event, its id line included.
+type sseFrame struct {
+	id   string
+	data []byte
+}
+
+type sseClient struct {
+	frames   chan sseFrame
+	overflow chan struct{}
+}
+
+func newSSEClient() *sseClient {
+	return &sseClient{
+		frames:   make(chan sseFrame, clientBuffer),
+		overflow: make(chan struct{}, 1),
+	}
+}
+
+// topicLog holds a topic's clients and its most recent frames, oldest
+// first. head is the ID just before the oldest buffered frame: a client
+// that last saw head has missed exactly the buffer.
+type topicLog struct {
+	clients map[*sseClient]struct{}
+	frames  []sseFrame
+	head    string
+	seq     uint64
+	touched time.Time
+}
+
+func newTopicLog(origin string, now time.Time) *topicLog {
+	return &topicLog{
+		clients: make(map[*sseClient]struct{}),
+		head:    origin + "-0",
+		touched: now,
+	}
+}
+
+// nextID hands out the next event ID for a broadcast from this process.
+// IDs carry the origin so replicas relaying the same topic never collide.
+func (l *topicLog) nextID(origin string) string {
+	l.seq++
+	return origin + "-" + strconv.FormatUint(l.seq, 10)
+}
+
+func (l *topicLog) append(f sseFrame, now time.Time) {
+	l.touched = now
+	l.frames = append(l.frames, f)
+	if over := len(l.frames) - replayBuffer; over > 0 {
+		l.head = l.frames[over-1].id
+		l.frames = append(l.frames[:0:0], l.frames[over:]...)
+	}
+}
+
+func (l *topicLog) lastID() string {
+	if len(l.frames) == 0 {
+		return l.head
+	}
+	return l.frames[len(l.frames)-1].id
+}
+
+// since returns the frames after id, or false when id is no longer (or
+// never was) in the buffer.
+func (l *topicLog) since(id string) ([]sseFrame, bool) {
+	if id == l.head {
+		return append([]sseFrame(nil), l.frames...), true
+	}
+	for i, f := range l.frames {
+		if f.id == id {
+			return append([]sseFrame(nil), l.frames[i+1:]...), true
+		}
+	}
+	return nil, false
+}
+
+// newOrigin names this process in event IDs.
+func newOrigin() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func idLine(id string) []byte {
+	return []byte("id: " + id + "\n")
+}
+
+// cursorFrame sets the client's last event ID without dispatching an event.
+func cursorFrame(id string) []byte {
+	return append([]byte(": connected\n"), append(idLine(id), '\n')...)
+}
+
+// frameID reads the ID back off a frame built by Broadcast, possibly on
+// another replica.
+func frameID(frame []byte) string {
+	line, _, _ := bytes.Cut(frame, []byte("\n"))
+	id, ok := bytes.CutPrefix(line, []byte("id: "))
+	if !ok {
+		return ""
+	}
+	return string(id)
+}
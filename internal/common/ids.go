@@ -21,6 +21,18 @@ type OrderItemID string
 // PaymentID represents a unique payment identifier.
 type PaymentID string
 
+// BillPartID identifies one share of a split bill.
+type BillPartID string
+
+// ShiftID represents a unique cash drawer shift identifier.
+type ShiftID string
+
+// TimecardID identifies one clock-in to clock-out stretch of staff time.
+type TimecardID string
+
+// PromotionID represents a unique promotion identifier.
+type PromotionID string
+
 // UserID represents a unique user identifier.
 type UserID string
 
@@ -56,6 +68,12 @@ const (
 	PaymentStatusPaid    PaymentStatus = "paid"
 	PaymentStatusFailed  PaymentStatus = "failed"
 	PaymentStatusExpired PaymentStatus = "expired"
+	// PaymentStatusPartiallyPaid marks a split bill with some parts settled.
+	PaymentStatusPartiallyPaid PaymentStatus = "partially_paid"
+	// PaymentStatusVoided marks an order cancelled before any money changed hands.
+	PaymentStatusVoided PaymentStatus = "voided"
+	// PaymentStatusRefunded marks a paid order whose payment was returned.
+	PaymentStatusRefunded PaymentStatus = "refunded"
 )
 
 // FulfillmentStatus represents order fulfillment status.
@@ -66,4 +84,5 @@ const (
 	FulfillmentStatusPreparing FulfillmentStatus = "preparing"
 	FulfillmentStatusReady     FulfillmentStatus = "ready"
 	FulfillmentStatusCompleted FulfillmentStatus = "completed"
+	FulfillmentStatusCancelled FulfillmentStatus = "cancelled"
 )
@@ -6,28 +6,84 @@ package money
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 )
 
-// Currency identifies a unit of account.
+// Currency identifies a unit of account. Numeric and Scale are the ISO 4217
+// numeric code and minor-unit exponent; SymbolAfter places the symbol after
+// the amount ("5,000 sats") rather than before it ("$12.34").
 type Currency struct {
-	Code   string
-	Symbol string
-	Scale  int
+	Code        string
+	Numeric     string
+	Symbol      string
+	Scale       int
+	SymbolAfter bool
 }
 
 var (
-	USD = Currency{Code: "USD", Symbol: "$", Scale: 2}
-	THB = Currency{Code: "THB", Symbol: "฿", Scale: 2}
-	SAT = Currency{Code: "SAT", Symbol: "sats", Scale: 0}
+	USD = Currency{Code: "USD", Numeric: "840", Symbol: "$", Scale: 2}
+	THB = Currency{Code: "THB", Numeric: "764", Symbol: "฿", Scale: 2}
+	SAT = Currency{Code: "SAT", Symbol: "sats", Scale: 0, SymbolAfter: true}
 )
 
-var registry = map[string]Currency{
-	USD.Code: USD,
-	THB.Code: THB,
-	SAT.Code: SAT,
+// maxScale bounds configured minor units so amounts still fit an int64
+// comfortably; no ISO 4217 currency uses more than four.
+const maxScale = 8
+
+// iso4217 is reference data for currencies an install can enable by code
+// alone (see Register and ParseSpec). Symbols are the ones shown to
+// customers; where "$" would be ambiguous the country prefix is kept.
+var iso4217 = map[string]Currency{
+	"USD": USD,
+	"THB": THB,
+	"EUR": {Code: "EUR", Numeric: "978", Symbol: "€", Scale: 2},
+	"GBP": {Code: "GBP", Numeric: "826", Symbol: "£", Scale: 2},
+	"CHF": {Code: "CHF", Numeric: "756", Symbol: "CHF", Scale: 2},
+	"ARS": {Code: "ARS", Numeric: "032", Symbol: "AR$", Scale: 2},
+	"BRL": {Code: "BRL", Numeric: "986", Symbol: "R$", Scale: 2},
+	"CLP": {Code: "CLP", Numeric: "152", Symbol: "CLP$", Scale: 0},
+	"COP": {Code: "COP", Numeric: "170", Symbol: "COL$", Scale: 2},
+	"MXN": {Code: "MXN", Numeric: "484", Symbol: "MX$", Scale: 2},
+	"PEN": {Code: "PEN", Numeric: "604", Symbol: "S/", Scale: 2},
+	"UYU": {Code: "UYU", Numeric: "858", Symbol: "$U", Scale: 2},
+	"GTQ": {Code: "GTQ", Numeric: "320", Symbol: "Q", Scale: 2},
+	"CAD": {Code: "CAD", Numeric: "124", Symbol: "CA$", Scale: 2},
+	"ZAR": {Code: "ZAR", Numeric: "710", Symbol: "R", Scale: 2},
+	"NGN": {Code: "NGN", Numeric: "566", Symbol: "₦", Scale: 2},
+	"KES": {Code: "KES", Numeric: "404", Symbol: "KSh", Scale: 2},
+	"SEK": {Code: "SEK", Numeric: "752", Symbol: "kr", Scale: 2, SymbolAfter: true},
+	"NOK": {Code: "NOK", Numeric: "578", Symbol: "kr", Scale: 2, SymbolAfter: true},
+	"DKK": {Code: "DKK", Numeric: "208", Symbol: "kr.", Scale: 2, SymbolAfter: true},
+	"PLN": {Code: "PLN", Numeric: "985", Symbol: "zł", Scale: 2, SymbolAfter: true},
+	"CZK": {Code: "CZK", Numeric: "203", Symbol: "Kč", Scale: 2, SymbolAfter: true},
+	"HUF": {Code: "HUF", Numeric: "348", Symbol: "Ft", Scale: 2, SymbolAfter: true},
+	"TRY": {Code: "TRY", Numeric: "949", Symbol: "₺", Scale: 2},
+	"JPY": {Code: "JPY", Numeric: "392", Symbol: "¥", Scale: 0},
+	"KRW": {Code: "KRW", Numeric: "410", Symbol: "₩", Scale: 0},
+	"CNY": {Code: "CNY", Numeric: "156", Symbol: "CN¥", Scale: 2},
+	"HKD": {Code: "HKD", Numeric: "344", Symbol: "HK$", Scale: 2},
+	"SGD": {Code: "SGD", Numeric: "702", Symbol: "S$", Scale: 2},
+	"IDR": {Code: "IDR", Numeric: "360", Symbol: "Rp", Scale: 2},
+	"PHP": {Code: "PHP", Numeric: "608", Symbol: "₱", Scale: 2},
+	"VND": {Code: "VND", Numeric: "704", Symbol: "₫", Scale: 0, SymbolAfter: true},
+	"INR": {Code: "INR", Numeric: "356", Symbol: "₹", Scale: 2},
+	"AUD": {Code: "AUD", Numeric: "036", Symbol: "A$", Scale: 2},
+	"NZD": {Code: "NZD", Numeric: "554", Symbol: "NZ$", Scale: 2},
+	"KWD": {Code: "KWD", Numeric: "414", Symbol: "KD", Scale: 3},
+	"BHD": {Code: "BHD", Numeric: "048", Symbol: "BD", Scale: 3},
 }
 
+// registry holds the currencies this install accepts, in the order All
+// lists them. USD, THB and SAT are always present; Register adds the rest
+// from configuration at startup.
+var (
+	registryMu    sync.RWMutex
+	registry      = map[string]Currency{USD.Code: USD, THB.Code: THB, SAT.Code: SAT}
+	registryOrder = []string{USD.Code, THB.Code, SAT.Code}
+)
+
 // ErrUnknownCurrency is returned by Parse when the code is not in the registry.
 type ErrUnknownCurrency struct{ Code string }
 
@@ -42,7 +98,10 @@ func Parse(code string) (Currency, error) {
 	if code == "" {
 		return USD, nil
 	}
-	if c, ok := registry[strings.ToUpper(code)]; ok {
+	registryMu.RLock()
+	c, ok := registry[strings.ToUpper(code)]
+	registryMu.RUnlock()
+	if ok {
 		return c, nil
 	}
 	return Currency{}, ErrUnknownCurrency{Code: code}
@@ -58,9 +117,120 @@ func MustParse(code string) Currency {
 }
 
 // All returns the registered currencies in a stable order suitable for UI
-// dropdowns. USD first (default), then THB, then SAT.
+// dropdowns: USD first (default), then THB and SAT, then configured
+// currencies in the order they were registered.
 func All() []Currency {
-	return []Currency{USD, THB, SAT}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	all := make([]Currency, len(registryOrder))
+	for i, code := range registryOrder {
+		all[i] = registry[code]
+	}
+	return all
+}
+
+// ISO returns the ISO 4217 reference data for code (case-insensitive), and
+// false when the code is not in the built-in table.
+func ISO(code string) (Currency, bool) {
+	c, ok := iso4217[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// Register makes currencies available to Parse and All. A currency already
+// registered under the same code is replaced in place. Amounts are stored in
+// minor units, so a currency this package ships (SAT or one in the ISO 4217
+// table) keeps its numeric code and scale; only the symbol and its placement
+// can be changed. Symbol defaults to the code.
+func Register(currencies ...Currency) error {
+	valid := make([]Currency, 0, len(currencies))
+	for _, c := range currencies {
+		c.Code = strings.ToUpper(strings.TrimSpace(c.Code))
+		if err := validateCurrency(c); err != nil {
+			return err
+		}
+		if base, ok := builtin(c.Code); ok {
+			if c.Numeric == "" {
+				c.Numeric = base.Numeric
+			}
+			if c.Numeric != base.Numeric || c.Scale != base.Scale {
+				return fmt.Errorf("currency %s: numeric code %q and scale %d are fixed, only the symbol can change", c.Code, base.Numeric, base.Scale)
+			}
+		}
+		if c.Symbol == "" {
+			c.Symbol = c.Code
+		}
+		valid = append(valid, c)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, c := range valid {
+		if _, ok := registry[c.Code]; !ok {
+			registryOrder = append(registryOrder, c.Code)
+		}
+		registry[c.Code] = c
+	}
+	return nil
+}
+
+// builtin returns the definition this package ships for code.
+func builtin(code string) (Currency, bool) {
+	if code == SAT.Code {
+		return SAT, true
+	}
+	c, ok := iso4217[code]
+	return c, ok
+}
+
+func validateCurrency(c Currency) error {
+	if len(c.Code) != 3 || strings.Trim(c.Code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("currency code %q: expected three letters", c.Code)
+	}
+	if c.Scale < 0 || c.Scale > maxScale {
+		return fmt.Errorf("currency %s: scale %d out of range 0..%d", c.Code, c.Scale, maxScale)
+	}
+	return nil
+}
+
+// ParseSpec reads one configured currency, "CODE[:SYMBOL[:SCALE[:before|after]]]"
+// (e.g. "EUR", "CHF:Fr.:2:after"). Omitted parts come from the ISO 4217
+// table; a code outside it must spell out its scale.
+func ParseSpec(spec string) (Currency, error) {
+	parts := strings.Split(strings.TrimSpace(spec), ":")
+	code := strings.ToUpper(strings.TrimSpace(parts[0]))
+	c, known := ISO(code)
+	if !known {
+		c = Currency{Code: code, Scale: -1}
+	}
+	if len(parts) > 4 {
+		return Currency{}, fmt.Errorf("currency %q: expected CODE[:SYMBOL[:SCALE[:before|after]]]", spec)
+	}
+	if len(parts) > 1 && strings.TrimSpace(parts[1]) != "" {
+		c.Symbol = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 && strings.TrimSpace(parts[2]) != "" {
+		scale, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil {
+			return Currency{}, fmt.Errorf("currency %q: invalid scale %q", spec, parts[2])
+		}
+		c.Scale = scale
+	}
+	if len(parts) > 3 {
+		switch strings.ToLower(strings.TrimSpace(parts[3])) {
+		case "before":
+			c.SymbolAfter = false
+		case "after":
+			c.SymbolAfter = true
+		default:
+			return Currency{}, fmt.Errorf("currency %q: symbol placement must be before or after", spec)
+		}
+	}
+	if c.Scale < 0 {
+		return Currency{}, fmt.Errorf("currency %q: not an ISO 4217 code known here, so its scale is required", spec)
+	}
+	if err := validateCurrency(c); err != nil {
+		return Currency{}, err
+	}
+	return c, nil
 }
 
 // IsZero reports whether the Currency is the zero value (uninitialized).
@@ -1,54 +1,185 @@
 package money
 
 import (
-	"fmt"
+	"context"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-// Format renders Money for human display.
+// NumberFormat is how a locale writes amounts: its digit-group and decimal
+// separators, whether currency symbols follow the number, and whether a
+// leading symbol is set apart from it ("R$ 1.234,50").
+type NumberFormat struct {
+	Group       string
+	Decimal     string
+	SymbolAfter bool
+	SymbolGap   bool
+}
+
+var (
+	englishFormat = NumberFormat{Group: ",", Decimal: "."}
+	// europeanFormat is the "1.234,50 €" convention shared by most of
+	// continental Europe and Latin America.
+	europeanFormat = NumberFormat{Group: ".", Decimal: ",", SymbolAfter: true}
+	// spacedFormat groups digits with a no-break space ("1 234,50 zł").
+	spacedFormat = NumberFormat{Group: "\u00a0", Decimal: ",", SymbolAfter: true}
+)
+
+// localeFormats is keyed by primary language subtag, the form
+// commonhttp.ResolveLocale produces, or by language and region where a
+// region writes amounts differently from the rest of its language.
+var localeFormats = map[string]NumberFormat{
+	"en": englishFormat,
+	"th": englishFormat,
+	"ja": englishFormat,
+	"ko": englishFormat,
+	"zh": englishFormat,
+	"he": englishFormat,
+	"hi": englishFormat,
+	"pt": europeanFormat,
+	"es": europeanFormat,
+	"it": europeanFormat,
+	"de": europeanFormat,
+	"da": europeanFormat,
+	"el": europeanFormat,
+	"ro": europeanFormat,
+	"vi": europeanFormat,
+	"nl": {Group: ".", Decimal: ","},
+	"id": {Group: ".", Decimal: ","},
+	"tr": {Group: ".", Decimal: ","},
+	"fr": {Group: "\u202f", Decimal: ",", SymbolAfter: true},
+	"pl": spacedFormat,
+	"cs": spacedFormat,
+	"sk": spacedFormat,
+	"hu": spacedFormat,
+	"ru": spacedFormat,
+	"uk": spacedFormat,
+	"sv": spacedFormat,
+	"nb": spacedFormat,
+	"no": spacedFormat,
+	"fi": spacedFormat,
+
+	// Brazil puts the symbol first: "R$ 1.234,50".
+	"pt-br": {Group: ".", Decimal: ",", SymbolGap: true},
+}
+
+// LocaleFormat returns the number format for a locale such as "pt" or
+// "pt-BR". A region without a format of its own gets its language's;
+// unknown and empty locales get the English format.
+func LocaleFormat(locale string) NumberFormat {
+	tag := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+	if nf, ok := localeFormats[tag]; ok {
+		return nf
+	}
+	lang, _, _ := strings.Cut(tag, "-")
+	if nf, ok := localeFormats[lang]; ok {
+		return nf
+	}
+	return englishFormat
+}
+
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx carrying the request locale, so amounts
+// rendered further down can use FormatContext.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFrom returns the locale stored by WithLocale, or "" when none is.
+func LocaleFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// Format renders Money for human display in the English convention.
 //   - SAT: "5,000 sats" (thousands separator, no decimals, suffix label).
-//   - Fiat (USD/THB/...): "$12.34" / "฿420.00" (symbol prefix, fixed decimals).
+//   - Fiat (USD/THB/...): "$1,234.50" / "฿420.00" (symbol prefix, fixed decimals).
 func (m Money) Format() string {
-	if m.Currency.Code == SAT.Code {
-		return fmt.Sprintf("%s sats", thousands(m.Amount))
+	return m.FormatLocale("")
+}
+
+// FormatLocale renders Money with the locale's separators and symbol
+// placement, e.g. "1.234,50 €" for "pt". A currency whose symbol always
+// follows the amount (SAT) keeps it there in every locale.
+func (m Money) FormatLocale(locale string) string {
+	nf := LocaleFormat(locale)
+	number := nf.number(m.Amount, m.Currency.Scale)
+	symbol := m.Currency.Symbol
+	switch {
+	case symbol == "":
+		return number
+	case m.Currency.SymbolAfter || nf.SymbolAfter:
+		return number + " " + symbol
+	}
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	// Letter symbols ("CHF", "Rp") need a gap before the digits; "$" does not
+	// unless the locale sets every symbol apart.
+	if last, _ := utf8.DecodeLastRuneInString(symbol); nf.SymbolGap || utf8.RuneCountInString(symbol) > 1 && unicode.IsLetter(last) {
+		symbol += " "
 	}
-	return fmt.Sprintf("%s%.*f", m.Currency.Symbol, m.Currency.Scale, m.Major())
+	return sign + symbol + number
+}
+
+// FormatContext renders Money for the locale stored in ctx by WithLocale.
+func (m Money) FormatContext(ctx context.Context) string {
+	return m.FormatLocale(LocaleFrom(ctx))
 }
 
 // FormatNoSymbol renders the bare numeric amount in major units, suitable for
-// pre-filling input fields.
+// pre-filling input fields: no grouping and a "." decimal point, so the
+// result reads back through ParseMajor.
 func (m Money) FormatNoSymbol() string {
-	if m.Currency.Code == SAT.Code {
-		return strconv.FormatInt(m.Amount, 10)
-	}
-	return fmt.Sprintf("%.*f", m.Currency.Scale, m.Major())
+	return NumberFormat{Decimal: "."}.number(m.Amount, m.Currency.Scale)
 }
 
-func thousands(n int64) string {
-	negative := n < 0
+// number writes amount minor units at scale with nf's separators. It works
+// on the integer digits, so large amounts never round through float64.
+func (nf NumberFormat) number(amount int64, scale int) string {
+	negative := amount < 0
+	abs := uint64(amount)
 	if negative {
-		n = -n
+		abs = -abs
 	}
-	s := strconv.FormatInt(n, 10)
-	if len(s) <= 3 {
-		if negative {
-			return "-" + s
-		}
-		return s
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
 	}
+	whole, frac := digits[:len(digits)-scale], digits[len(digits)-scale:]
+
 	var b strings.Builder
-	first := len(s) % 3
+	if negative {
+		b.WriteByte('-')
+	}
+	b.WriteString(group(whole, nf.Group))
+	if scale > 0 {
+		b.WriteString(nf.Decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+func group(digits, sep string) string {
+	if sep == "" || len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	first := len(digits) % 3
 	if first == 0 {
 		first = 3
 	}
-	b.WriteString(s[:first])
-	for i := first; i < len(s); i += 3 {
-		b.WriteByte(',')
-		b.WriteString(s[i : i+3])
-	}
-	if negative {
-		return "-" + b.String()
+	b.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		b.WriteString(sep)
+		b.WriteString(digits[i : i+3])
 	}
 	return b.String()
 }
@@ -3,12 +3,13 @@ package money
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // ErrConversionNotSupported indicates the Converter cannot exchange between
-// the requested currencies. The Lightning rollout will replace NoopConverter
-// with a real rate provider; until then, only same-currency "conversions"
-// succeed.
+// the requested currencies at all. A RateConverter whose providers are down
+// returns ErrRateUnavailable instead.
 var ErrConversionNotSupported = errors.New("currency conversion not supported")
 
 // Converter exchanges Money between currencies. Implementations should be
@@ -17,8 +18,67 @@ type Converter interface {
 	Convert(ctx context.Context, from Money, to Currency) (Money, error)
 }
 
+// ExchangeRate is a snapshot of the rate used to convert From into To: one
+// major unit of From buys Rate major units of To. Source names the feed that
+// priced it and AsOf when. Orders and payments keep the snapshot so their
+// value at the moment of sale never moves with later rates.
+type ExchangeRate struct {
+	From   string
+	To     string
+	Rate   float64
+	Source string
+	AsOf   time.Time
+}
+
+// IsZero reports whether no rate was recorded.
+func (r ExchangeRate) IsZero() bool { return r.From == "" || r.Rate <= 0 }
+
+// Apply converts m, which must be in From, into To at the snapshot rate.
+func (r ExchangeRate) Apply(m Money) (Money, error) {
+	if r.IsZero() {
+		return Money{}, ErrConversionNotSupported
+	}
+	if m.Currency.Code != r.From {
+		return Money{}, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency.Code, r.From)
+	}
+	to, err := Parse(r.To)
+	if err != nil {
+		return Money{}, err
+	}
+	return FromMajor(m.Major()*r.Rate, to), nil
+}
+
+// Inverse returns the same snapshot quoted the other way round.
+func (r ExchangeRate) Inverse() ExchangeRate {
+	if r.IsZero() {
+		return ExchangeRate{}
+	}
+	return ExchangeRate{From: r.To, To: r.From, Rate: 1 / r.Rate, Source: r.Source, AsOf: r.AsOf}
+}
+
+// RateSource is implemented by Converters that can report the rate they
+// convert at, so callers can snapshot it alongside the converted amount.
+type RateSource interface {
+	ExchangeRate(ctx context.Context, from, to Currency) (ExchangeRate, error)
+}
+
+// SnapshotRate asks converter for the rate between from and to. Converters
+// that cannot report their rate return ErrConversionNotSupported.
+func SnapshotRate(ctx context.Context, converter Converter, from, to Currency) (ExchangeRate, error) {
+	src, ok := converter.(RateSource)
+	if !ok {
+		return ExchangeRate{}, ErrConversionNotSupported
+	}
+	return src.ExchangeRate(ctx, from, to)
+}
+
+// identityRate is the rate between a currency and itself.
+func identityRate(c Currency, at time.Time) ExchangeRate {
+	return ExchangeRate{From: c.Code, To: c.Code, Rate: 1, Source: "identity", AsOf: at}
+}
+
 // NoopConverter is a Converter that only succeeds when the source and target
-// currencies match. It is the default until an FX provider is wired.
+// currencies match. It is the default when no rate provider is configured.
 type NoopConverter struct{}
 
 func (NoopConverter) Convert(_ context.Context, from Money, to Currency) (Money, error) {
@@ -27,3 +87,52 @@ func (NoopConverter) Convert(_ context.Context, from Money, to Currency) (Money,
 	}
 	return Money{}, ErrConversionNotSupported
 }
+
+func (NoopConverter) ExchangeRate(_ context.Context, from, to Currency) (ExchangeRate, error) {
+	if from.Code == to.Code {
+		return identityRate(from, time.Now()), nil
+	}
+	return ExchangeRate{}, ErrConversionNotSupported
+}
+
+// StaticConverter converts between currencies using fixed prices quoted per
+// whole bitcoin (e.g. {"USD": 65000, "SAT": 100_000_000}). It lets local and
+// test checkouts convert fiat totals to sats without a rate provider.
+type StaticConverter struct {
+	PerBTC map[string]float64
+}
+
+func (s StaticConverter) Convert(ctx context.Context, from Money, to Currency) (Money, error) {
+	if from.Currency.Code == to.Code {
+		return from, nil
+	}
+	r, err := s.ExchangeRate(ctx, from.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	return r.Apply(from)
+}
+
+func (s StaticConverter) ExchangeRate(_ context.Context, from, to Currency) (ExchangeRate, error) {
+	now := time.Now()
+	if from.Code == to.Code {
+		return identityRate(from, now), nil
+	}
+	fromRate, ok := s.rate(from.Code)
+	if !ok {
+		return ExchangeRate{}, ErrConversionNotSupported
+	}
+	toRate, ok := s.rate(to.Code)
+	if !ok {
+		return ExchangeRate{}, ErrConversionNotSupported
+	}
+	return ExchangeRate{From: from.Code, To: to.Code, Rate: toRate / fromRate, Source: "static", AsOf: now}, nil
+}
+
+func (s StaticConverter) rate(code string) (float64, bool) {
+	if code == SAT.Code {
+		return 100_000_000, true
+	}
+	r, ok := s.PerBTC[code]
+	return r, ok && r > 0
+}
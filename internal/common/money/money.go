@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/bits"
+	"strconv"
+	"strings"
 )
 
 // Money is an amount in a specific currency, stored as int64 minor units
@@ -18,14 +21,43 @@ func New(amount int64, c Currency) Money {
 	return Money{Amount: amount, Currency: c}
 }
 
-// FromMajor converts a major-unit float (e.g. 12.34 USD) into Money. Used
-// only on read paths that still hold legacy float columns — new code should
-// stay in minor units end-to-end.
+// FromMajor converts a major-unit float (e.g. 12.34 USD) into Money. It is
+// for rate arithmetic (see ExchangeRate.Apply) — amounts typed by people go
+// through ParseMajor, and everything else stays in minor units end-to-end.
 func FromMajor(major float64, c Currency) Money {
 	scale := math.Pow10(c.Scale)
 	return Money{Amount: int64(math.Round(major * scale)), Currency: c}
 }
 
+// ErrInvalidAmount is returned by ParseMajor for input that is not a plain
+// decimal or carries more decimals than the currency has minor units.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// ParseMajor reads a major-unit decimal string (e.g. "12.5" USD) into Money
+// without going through float64, so "0.29" is exactly 29 cents. A leading
+// minus sign and thousands separators are rejected.
+func ParseMajor(s string, c Currency) (Money, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || len(frac) > c.Scale {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	digits := whole + frac + strings.Repeat("0", c.Scale-len(frac))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+	}
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	return Money{Amount: amount, Currency: c}, nil
+}
+
 // Major returns the amount as a major-unit float. Lossy for SAT past 2^53;
 // avoid in arithmetic.
 func (m Money) Major() float64 {
@@ -62,3 +94,74 @@ func (m Money) Sub(other Money) (Money, error) {
 func (m Money) Mul(factor int) Money {
 	return Money{Amount: m.Amount * int64(factor), Currency: m.Currency}
 }
+
+// ErrInvalidAllocation is returned by Allocate when the weights cannot share
+// out an amount (no weights, a negative weight, all zero, or a sum too large
+// for int64).
+var ErrInvalidAllocation = errors.New("invalid allocation weights")
+
+// Allocate splits m into len(weights) parts proportional to weights without
+// losing a minor unit: the rounding remainder goes to the earliest parts, so
+// the parts always add back up to m.
+func (m Money) Allocate(weights []int64) ([]Money, error) {
+	var total int64
+	for _, w := range weights {
+		if w < 0 || w > math.MaxInt64-total {
+			return nil, ErrInvalidAllocation
+		}
+		total += w
+	}
+	if len(weights) == 0 || total == 0 {
+		return nil, ErrInvalidAllocation
+	}
+	parts := make([]Money, len(weights))
+	remainder := m.Amount
+	for i, w := range weights {
+		share := mulDiv(m.Amount, w, total)
+		parts[i] = Money{Amount: share, Currency: m.Currency}
+		remainder -= share
+	}
+	for i := 0; remainder > 0; i = (i + 1) % len(parts) {
+		if weights[i] == 0 {
+			continue
+		}
+		parts[i].Amount++
+		remainder--
+	}
+	return parts, nil
+}
+
+// mulDiv returns a*w/total, truncated toward zero like integer division. The
+// product is taken in 128 bits so large amounts with large weights cannot
+// wrap; with 0 <= w <= total the result always fits back in an int64.
+func mulDiv(a, w, total int64) int64 {
+	neg := a < 0
+	abs := uint64(a)
+	if neg {
+		abs = -abs
+	}
+	hi, lo := bits.Mul64(abs, uint64(w))
+	q, _ := bits.Div64(hi, lo, uint64(total))
+	if neg {
+		return -int64(q)
+	}
+	return int64(q)
+}
+
+// RoundTo rounds m to the nearest multiple of step minor units, halves away
+// from zero, e.g. ฿12.38 to ฿12.50 with a step of 25 satang. A step of one
+// or less leaves m unchanged.
+func (m Money) RoundTo(step int64) Money {
+	if step <= 1 {
+		return m
+	}
+	if m.Amount < 0 {
+		r := Money{Amount: -m.Amount, Currency: m.Currency}.RoundTo(step)
+		return Money{Amount: -r.Amount, Currency: m.Currency}
+	}
+	rounded := m.Amount - m.Amount%step
+	if 2*(m.Amount%step) >= step {
+		rounded += step
+	}
+	return Money{Amount: rounded, Currency: m.Currency}
+}
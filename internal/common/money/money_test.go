@@ -3,7 +3,10 @@ package money_test
 import (
 	"context"
 	"errors"
+	"math"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,11 +37,64 @@ func TestParse(t *testing.T) {
 
 func TestAll(t *testing.T) {
 	all := money.All()
-	require.Len(t, all, 3)
+	require.GreaterOrEqual(t, len(all), 3)
 	assert.Equal(t, money.USD, all[0])
+	assert.Equal(t, money.THB, all[1])
 	assert.Equal(t, money.SAT, all[2])
 }
 
+func TestRegister(t *testing.T) {
+	t.Run("ISO code enables the currency", func(t *testing.T) {
+		eur, err := money.ParseSpec("eur")
+		require.NoError(t, err)
+		require.NoError(t, money.Register(eur))
+
+		got, err := money.Parse("EUR")
+		require.NoError(t, err)
+		assert.Equal(t, "978", got.Numeric)
+		assert.Equal(t, "€", got.Symbol)
+		assert.Equal(t, 2, got.Scale)
+		assert.Contains(t, money.All(), got)
+	})
+	t.Run("spec overrides symbol, scale and placement", func(t *testing.T) {
+		chf, err := money.ParseSpec("CHF:Fr.:2:after")
+		require.NoError(t, err)
+		assert.Equal(t, "Fr.", chf.Symbol)
+		assert.True(t, chf.SymbolAfter)
+		assert.Equal(t, "12.50 Fr.", money.New(1250, chf).Format())
+	})
+	t.Run("codes outside ISO need a scale", func(t *testing.T) {
+		_, err := money.ParseSpec("XTS")
+		require.Error(t, err)
+		c, err := money.ParseSpec("XTS:T:1")
+		require.NoError(t, err)
+		assert.Equal(t, "T12.5", money.New(125, c).Format())
+	})
+	t.Run("rejects malformed entries", func(t *testing.T) {
+		for _, spec := range []string{"EU", "EUR:€:9", "EUR:€:2:left", "EUR:€:x", "E1R:€:2"} {
+			_, err := money.ParseSpec(spec)
+			assert.Error(t, err, spec)
+		}
+		assert.Error(t, money.Register(money.Currency{Code: "EURO", Scale: 2}))
+	})
+	t.Run("built-ins keep their numeric code and scale", func(t *testing.T) {
+		assert.Error(t, money.Register(money.Currency{Code: "SAT", Symbol: "sat", Scale: 2}))
+		assert.Error(t, money.Register(money.Currency{Code: "USD", Numeric: "999", Symbol: "$", Scale: 2}))
+		jpy, err := money.ParseSpec("JPY:¥:2")
+		require.NoError(t, err)
+		assert.Error(t, money.Register(jpy))
+		assert.Equal(t, money.SAT, money.MustParse("SAT"))
+		assert.Equal(t, money.USD, money.MustParse("USD"))
+	})
+	t.Run("built-ins take a new symbol", func(t *testing.T) {
+		require.NoError(t, money.Register(money.Currency{Code: "USD", Symbol: "US$", Scale: 2}))
+		t.Cleanup(func() { require.NoError(t, money.Register(money.USD)) })
+		got := money.MustParse("USD")
+		assert.Equal(t, "US$", got.Symbol)
+		assert.Equal(t, "840", got.Numeric)
+	})
+}
+
 func TestFormat(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -62,9 +118,54 @@ func TestFormat(t *testing.T) {
 
 func TestFormatNoSymbol(t *testing.T) {
 	assert.Equal(t, "12.34", money.New(1234, money.USD).FormatNoSymbol())
+	assert.Equal(t, "123456.05", money.New(12345605, money.USD).FormatNoSymbol())
+	assert.Equal(t, "-0.05", money.New(-5, money.USD).FormatNoSymbol())
 	assert.Equal(t, "5000", money.New(5000, money.SAT).FormatNoSymbol())
 }
 
+func TestFormatLocale(t *testing.T) {
+	eur, ok := money.ISO("EUR")
+	require.True(t, ok)
+	kwd, ok := money.ISO("KWD")
+	require.True(t, ok)
+	jpy, ok := money.ISO("JPY")
+	require.True(t, ok)
+	brl, ok := money.ISO("BRL")
+	require.True(t, ok)
+	cases := []struct {
+		name   string
+		money  money.Money
+		locale string
+		want   string
+	}{
+		{"EUR in Portuguese", money.New(123450, eur), "pt", "1.234,50 €"},
+		{"EUR in English", money.New(123450, eur), "en", "€1,234.50"},
+		{"Brazilian reais put the symbol first", money.New(123450, brl), "pt-BR", "R$ 1.234,50"},
+		{"negative reais keep sign first", money.New(-250, brl), "pt-BR", "-R$ 2,50"},
+		{"region without its own format uses the language's", money.New(123450, eur), "pt-PT", "1.234,50 €"},
+		{"USD default locale groups thousands", money.New(123450, money.USD), "", "$1,234.50"},
+		{"negative fiat keeps sign first", money.New(-250, money.USD), "en", "-$2.50"},
+		{"SAT stays a suffix in German", money.New(2_500_000, money.SAT), "de", "2.500.000 sats"},
+		{"French narrow no-break grouping", money.New(123450, eur), "fr", "1\u202f234,50 €"},
+		{"three-decimal currency", money.New(1234567, kwd), "en", "KD 1,234.567"},
+		{"zero-decimal currency", money.New(1500, jpy), "en", "¥1,500"},
+		{"unknown locale falls back to English", money.New(123450, eur), "xx", "€1,234.50"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.money.FormatLocale(tc.locale))
+		})
+	}
+}
+
+func TestFormatContext(t *testing.T) {
+	eur, _ := money.ISO("EUR")
+	ctx := money.WithLocale(context.Background(), "es")
+	assert.Equal(t, "es", money.LocaleFrom(ctx))
+	assert.Equal(t, "9,90 €", money.New(990, eur).FormatContext(ctx))
+	assert.Equal(t, "€9.90", money.New(990, eur).FormatContext(context.Background()))
+}
+
 func TestArithmetic(t *testing.T) {
 	t.Run("add same currency", func(t *testing.T) {
 		sum, err := money.New(100, money.USD).Add(money.New(250, money.USD))
@@ -87,6 +188,32 @@ func TestFromMajorRoundsHalfUp(t *testing.T) {
 	assert.Equal(t, int64(5000), money.FromMajor(5000.0, money.SAT).Amount)
 }
 
+func TestParseMajor(t *testing.T) {
+	for in, want := range map[string]money.Money{
+		"12.34": money.New(1234, money.USD),
+		"0.29":  money.New(29, money.USD),
+		"12.5":  money.New(1250, money.USD),
+		"7":     money.New(700, money.USD),
+		".5":    money.New(50, money.USD),
+		" 3. ":  money.New(300, money.USD),
+	} {
+		got, err := money.ParseMajor(in, money.USD)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	got, err := money.ParseMajor("5000", money.SAT)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(5000, money.SAT), got)
+
+	for _, in := range []string{"", ".", "1.234", "-1", "1,000", "abc", "1e3"} {
+		_, err := money.ParseMajor(in, money.USD)
+		assert.ErrorIs(t, err, money.ErrInvalidAmount, in)
+	}
+	_, err = money.ParseMajor("1.5", money.SAT)
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
 func TestNoopConverter(t *testing.T) {
 	c := money.NoopConverter{}
 	t.Run("same currency passthrough", func(t *testing.T) {
@@ -99,3 +226,305 @@ func TestNoopConverter(t *testing.T) {
 		require.ErrorIs(t, err, money.ErrConversionNotSupported)
 	})
 }
+
+func TestStaticConverter(t *testing.T) {
+	c := money.StaticConverter{PerBTC: map[string]float64{"USD": 50000}}
+	t.Run("fiat to sats", func(t *testing.T) {
+		got, err := c.Convert(context.Background(), money.New(1000, money.USD), money.SAT)
+		require.NoError(t, err)
+		assert.Equal(t, money.New(20000, money.SAT), got)
+	})
+	t.Run("sats to fiat", func(t *testing.T) {
+		got, err := c.Convert(context.Background(), money.New(20000, money.SAT), money.USD)
+		require.NoError(t, err)
+		assert.Equal(t, money.New(1000, money.USD), got)
+	})
+	t.Run("unknown rate errors", func(t *testing.T) {
+		_, err := c.Convert(context.Background(), money.New(500, money.THB), money.SAT)
+		require.ErrorIs(t, err, money.ErrConversionNotSupported)
+	})
+}
+
+// slowRates runs wait before each quote, as a slow feed would let a test
+// clock run on.
+type slowRates struct {
+	money.FixtureRates
+	wait func()
+}
+
+func (s slowRates) BTCPrice(ctx context.Context, code string) (float64, error) {
+	s.wait()
+	return s.FixtureRates.BTCPrice(ctx, code)
+}
+
+// flakyRates fails while down is set and counts how often it was asked.
+// With gate set, each call blocks until the gate is closed.
+type flakyRates struct {
+	money.FixtureRates
+	down  bool
+	gate  chan struct{}
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *flakyRates) Name() string { return "flaky" }
+
+func (f *flakyRates) BTCPrice(ctx context.Context, code string) (float64, error) {
+	f.mu.Lock()
+	f.calls++
+	gate, down := f.gate, f.down
+	f.mu.Unlock()
+	if gate != nil {
+		<-gate
+	}
+	if down {
+		return 0, errors.New("feed down")
+	}
+	return f.FixtureRates.BTCPrice(ctx, code)
+}
+
+func (f *flakyRates) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestRateConverter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("converts through the first provider that answers", func(t *testing.T) {
+		primary := &flakyRates{FixtureRates: money.FixtureRates{"USD": 50000}, down: true}
+		c := money.NewRateConverter(money.RateConverterOptions{TTL: time.Minute, Now: clock},
+			primary, money.FixtureRates{"USD": 40000})
+
+		got, err := c.Convert(ctx, money.New(1000, money.USD), money.SAT)
+		require.NoError(t, err)
+		assert.Equal(t, money.New(25000, money.SAT), got)
+
+		rate, err := c.Rate(ctx, "usd")
+		require.NoError(t, err)
+		assert.Equal(t, "fixture", rate.Source)
+		assert.Equal(t, now, rate.AsOf)
+	})
+
+	t.Run("caches rates for the TTL", func(t *testing.T) {
+		feed := &flakyRates{FixtureRates: money.FixtureRates{"USD": 50000}}
+		c := money.NewRateConverter(money.RateConverterOptions{TTL: time.Minute, Now: clock}, feed)
+		for range 3 {
+			_, err := c.Convert(ctx, money.New(1000, money.USD), money.SAT)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, feed.calls)
+	})
+
+	t.Run("serves a stale rate up to MaxAge", func(t *testing.T) {
+		at := now
+		feed := &flakyRates{FixtureRates: money.FixtureRates{"USD": 50000}}
+		c := money.NewRateConverter(money.RateConverterOptions{
+			TTL: time.Minute, MaxAge: 10 * time.Minute, Now: func() time.Time { return at },
+		}, feed)
+		_, err := c.Rate(ctx, "USD")
+		require.NoError(t, err)
+
+		feed.down = true
+		at = now.Add(5 * time.Minute)
+		got, err := c.Convert(ctx, money.New(1000, money.USD), money.SAT)
+		require.NoError(t, err)
+		assert.Equal(t, money.New(20000, money.SAT), got)
+
+		at = now.Add(11 * time.Minute)
+		_, err = c.Convert(ctx, money.New(1000, money.USD), money.SAT)
+		require.ErrorIs(t, err, money.ErrRateUnavailable)
+	})
+
+	t.Run("serves a stale rate while it refreshes in the background", func(t *testing.T) {
+		at := now
+		feed := &flakyRates{FixtureRates: money.FixtureRates{"USD": 50000}}
+		c := money.NewRateConverter(money.RateConverterOptions{
+			TTL: time.Minute, MaxAge: 10 * time.Minute, Now: func() time.Time { return at },
+		}, feed)
+		_, err := c.Rate(ctx, "USD")
+		require.NoError(t, err)
+
+		gate := make(chan struct{})
+		feed.mu.Lock()
+		feed.gate, feed.FixtureRates = gate, money.FixtureRates{"USD": 60000}
+		feed.mu.Unlock()
+		at = now.Add(2 * time.Minute)
+		for range 5 {
+			rate, err := c.Rate(ctx, "USD")
+			require.NoError(t, err)
+			assert.Equal(t, 50000.0, rate.PerBTC, "the stale rate is served without waiting")
+		}
+		close(gate)
+
+		assert.Eventually(t, func() bool {
+			rate, err := c.Rate(ctx, "USD")
+			return err == nil && rate.PerBTC == 60000
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, 2, feed.Calls(), "one background refresh")
+	})
+
+	t.Run("collapses concurrent fetches", func(t *testing.T) {
+		gate := make(chan struct{})
+		feed := &flakyRates{FixtureRates: money.FixtureRates{"USD": 50000}, gate: gate}
+		c := money.NewRateConverter(money.RateConverterOptions{TTL: time.Minute, Now: clock}, feed)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for range 10 {
+			wg.Go(func() {
+				_, err := c.Rate(ctx, "USD")
+				errs <- err
+			})
+		}
+		require.Eventually(t, func() bool { return feed.Calls() == 1 }, time.Second, time.Millisecond)
+		close(gate)
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 1, feed.Calls())
+	})
+
+	t.Run("remembers a failure for RetryAfter", func(t *testing.T) {
+		at := now
+		feed := &flakyRates{down: true}
+		c := money.NewRateConverter(money.RateConverterOptions{
+			TTL: time.Minute, RetryAfter: 30 * time.Second, Now: func() time.Time { return at },
+		}, feed)
+
+		for range 3 {
+			_, err := c.Rate(ctx, "USD")
+			require.ErrorIs(t, err, money.ErrRateUnavailable)
+		}
+		assert.Equal(t, 1, feed.Calls(), "the outage is not retried on every call")
+
+		at = now.Add(30 * time.Second)
+		_, err := c.Rate(ctx, "USD")
+		require.ErrorIs(t, err, money.ErrRateUnavailable)
+		assert.Equal(t, 2, feed.Calls())
+	})
+
+	t.Run("stamps a rate with when the provider answered", func(t *testing.T) {
+		at := now
+		feed := slowRates{FixtureRates: money.FixtureRates{"USD": 50000}, wait: func() { at = at.Add(10 * time.Second) }}
+		c := money.NewRateConverter(money.RateConverterOptions{TTL: time.Minute, Now: func() time.Time { return at }}, feed)
+
+		rate, err := c.Rate(ctx, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(10*time.Second), rate.AsOf)
+	})
+
+	t.Run("unknown currency errors", func(t *testing.T) {
+		c := money.NewRateConverter(money.RateConverterOptions{Now: clock}, money.FixtureRates{"USD": 50000})
+		_, err := c.Convert(ctx, money.New(500, money.THB), money.SAT)
+		require.ErrorIs(t, err, money.ErrRateUnavailable)
+	})
+}
+
+func TestSatsQuoter(t *testing.T) {
+	q := money.SatsQuoter(context.Background(), money.StaticConverter{PerBTC: map[string]float64{"USD": 50000}})
+	got, ok := q.Quote(money.New(1000, money.USD))
+	require.True(t, ok)
+	assert.Equal(t, money.New(20000, money.SAT), got)
+
+	_, ok = q.Quote(money.New(500, money.THB))
+	assert.False(t, ok)
+	_, ok = money.Quoter(nil).Quote(money.New(1000, money.USD))
+	assert.False(t, ok)
+}
+
+func TestExchangeRate(t *testing.T) {
+	asOf := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coingecko", AsOf: asOf}
+
+	got, err := r.Apply(money.New(1000, money.USD))
+	require.NoError(t, err)
+	assert.Equal(t, money.New(20000, money.SAT), got)
+
+	back, err := r.Inverse().Apply(money.New(20000, money.SAT))
+	require.NoError(t, err)
+	assert.Equal(t, money.New(1000, money.USD), back)
+	assert.Equal(t, "coingecko", r.Inverse().Source)
+
+	_, err = r.Apply(money.New(1000, money.THB))
+	require.ErrorIs(t, err, money.ErrCurrencyMismatch)
+	assert.True(t, money.ExchangeRate{}.IsZero())
+}
+
+func TestSnapshotRate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := money.NewRateConverter(money.RateConverterOptions{Now: func() time.Time { return now }}, money.FixtureRates{"USD": 50000})
+
+	r, err := money.SnapshotRate(ctx, c, money.USD, money.SAT)
+	require.NoError(t, err)
+	assert.Equal(t, money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "fixture", AsOf: now}, r)
+
+	_, err = money.SnapshotRate(ctx, money.NoopConverter{}, money.USD, money.SAT)
+	require.ErrorIs(t, err, money.ErrConversionNotSupported)
+
+	static, err := money.SnapshotRate(ctx, money.StaticConverter{PerBTC: map[string]float64{"USD": 50000}}, money.USD, money.SAT)
+	require.NoError(t, err)
+	assert.Equal(t, 2000.0, static.Rate)
+}
+
+func TestAllocate(t *testing.T) {
+	t.Run("even split hands the remainder to the first parts", func(t *testing.T) {
+		parts, err := money.New(1000, money.USD).Allocate([]int64{1, 1, 1})
+		require.NoError(t, err)
+		assert.Equal(t, []money.Money{money.New(334, money.USD), money.New(333, money.USD), money.New(333, money.USD)}, parts)
+	})
+	t.Run("weighted split adds back up", func(t *testing.T) {
+		parts, err := money.New(1099, money.USD).Allocate([]int64{450, 0, 550})
+		require.NoError(t, err)
+		assert.Equal(t, int64(495), parts[0].Amount)
+		assert.Zero(t, parts[1].Amount)
+		assert.Equal(t, int64(604), parts[2].Amount)
+	})
+	t.Run("large amounts with large weights do not overflow", func(t *testing.T) {
+		// 21M BTC in sats times a weight of a million is far past int64.
+		total := money.New(2_100_000_000_000_000, money.SAT)
+		parts, err := total.Allocate([]int64{1_000_000, 3_000_000})
+		require.NoError(t, err)
+		assert.Equal(t, int64(525_000_000_000_000), parts[0].Amount)
+		assert.Equal(t, int64(1_575_000_000_000_000), parts[1].Amount)
+	})
+	t.Run("rejects weights that overflow", func(t *testing.T) {
+		_, err := money.New(100, money.SAT).Allocate([]int64{math.MaxInt64, 1})
+		assert.ErrorIs(t, err, money.ErrInvalidAllocation)
+	})
+	t.Run("rejects empty or zero weights", func(t *testing.T) {
+		_, err := money.New(100, money.USD).Allocate(nil)
+		assert.ErrorIs(t, err, money.ErrInvalidAllocation)
+		_, err = money.New(100, money.USD).Allocate([]int64{0, 0})
+		assert.ErrorIs(t, err, money.ErrInvalidAllocation)
+	})
+}
+
+func TestRoundTo(t *testing.T) {
+	chf := money.Currency{Code: "CHF", Symbol: "CHF", Scale: 2}
+	cases := []struct {
+		amount, step, want int64
+	}{
+		{1238, 25, 1250},
+		{1237, 25, 1225},
+		{1212, 25, 1200},
+		{1213, 25, 1225},
+		{1215, 10, 1220}, // halves round away from zero
+		{1000, 25, 1000},
+		{1997, 5, 1995},
+		{1998, 5, 2000},
+		{-1238, 25, -1250},
+		{-1215, 10, -1220},
+		{1238, 1, 1238},
+		{1238, 0, 1238},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, money.New(tc.want, chf), money.New(tc.amount, chf).RoundTo(tc.step), "%d to %d", tc.amount, tc.step)
+	}
+}
@@ -0,0 +1,281 @@
+package money
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrRateUnavailable is returned when no provider could price a currency and
+// the last known rate is older than the converter's staleness bound.
+var ErrRateUnavailable = errors.New("exchange rate unavailable")
+
+// satsPerBTC prices SAT against bitcoin; it never needs a provider, so its
+// rate is reported with fixedSource.
+const (
+	satsPerBTC  = 100_000_000
+	fixedSource = "fixed"
+)
+
+// RateProvider quotes the price of one whole bitcoin in a currency code.
+// Implementations should be safe for concurrent use.
+type RateProvider interface {
+	Name() string
+	BTCPrice(ctx context.Context, code string) (float64, error)
+}
+
+// Rate is the price of one bitcoin in Code, where it came from and when it
+// was fetched.
+type Rate struct {
+	Code   string
+	PerBTC float64
+	Source string
+	AsOf   time.Time
+}
+
+// FixtureRates is a RateProvider backed by fixed prices per bitcoin (e.g.
+// {"USD": 65000}). It is deterministic, so tests and offline installs use it
+// in place of a live feed.
+type FixtureRates map[string]float64
+
+func (FixtureRates) Name() string { return "fixture" }
+
+func (f FixtureRates) BTCPrice(_ context.Context, code string) (float64, error) {
+	if r, ok := f[strings.ToUpper(code)]; ok && r > 0 {
+		return r, nil
+	}
+	return 0, fmt.Errorf("%w: no fixture rate for %s", ErrRateUnavailable, code)
+}
+
+// defaultRetryAfter is how long a failed refresh is remembered when
+// RateConverterOptions.RetryAfter is unset.
+const defaultRetryAfter = 30 * time.Second
+
+// RateConverterOptions tunes a RateConverter. TTL is how long a fetched rate
+// is served before the providers are asked again; MaxAge is how old a rate
+// may get while every provider is failing. RetryAfter is how long a failed
+// refresh is remembered before the providers are asked again (30s when
+// zero). Now defaults to time.Now.
+type RateConverterOptions struct {
+	TTL        time.Duration
+	MaxAge     time.Duration
+	RetryAfter time.Duration
+	Now        func() time.Time
+}
+
+// RateConverter is a Converter priced by RateProviders. Providers are tried
+// in order until one answers; rates are cached per currency for TTL, and a
+// cached rate keeps being served up to MaxAge when every provider fails.
+//
+// Callers never wait on the providers while a usable rate is cached: a rate
+// past its TTL is served as is while one refresh runs in the background.
+// Concurrent refreshes of a currency collapse into one, and a failed refresh
+// is not retried for RetryAfter, so an outage costs one round of provider
+// timeouts rather than one per call.
+type RateConverter struct {
+	providers  []RateProvider
+	ttl        time.Duration
+	maxAge     time.Duration
+	retryAfter time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	rates    map[string]Rate
+	failures map[string]rateFailure
+	inflight map[string]*rateFetch
+}
+
+// rateFailure is a refresh that failed, remembered for RetryAfter.
+type rateFailure struct {
+	at  time.Time
+	err error
+}
+
+// rateFetch is a refresh in progress; done is closed once rate or err is set.
+type rateFetch struct {
+	done chan struct{}
+	rate Rate
+	err  error
+}
+
+// NewRateConverter builds a RateConverter over providers, in fallback order.
+// MaxAge is raised to TTL when set lower.
+func NewRateConverter(opts RateConverterOptions, providers ...RateProvider) *RateConverter {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.MaxAge < opts.TTL {
+		opts.MaxAge = opts.TTL
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = defaultRetryAfter
+	}
+	return &RateConverter{
+		providers:  providers,
+		ttl:        opts.TTL,
+		maxAge:     opts.MaxAge,
+		retryAfter: opts.RetryAfter,
+		now:        opts.Now,
+		rates:      make(map[string]Rate),
+		failures:   make(map[string]rateFailure),
+		inflight:   make(map[string]*rateFetch),
+	}
+}
+
+func (c *RateConverter) Convert(ctx context.Context, from Money, to Currency) (Money, error) {
+	if from.Currency.Code == to.Code {
+		return from, nil
+	}
+	r, err := c.ExchangeRate(ctx, from.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	return r.Apply(from)
+}
+
+// ExchangeRate crosses the bitcoin prices of from and to. The snapshot is
+// as old as the older of the two and names the feed that priced the fiat
+// side (SAT is fixed against bitcoin).
+func (c *RateConverter) ExchangeRate(ctx context.Context, from, to Currency) (ExchangeRate, error) {
+	if from.Code == to.Code {
+		return identityRate(from, c.now()), nil
+	}
+	fromRate, err := c.Rate(ctx, from.Code)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	toRate, err := c.Rate(ctx, to.Code)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	r := ExchangeRate{From: from.Code, To: to.Code, Rate: toRate.PerBTC / fromRate.PerBTC, Source: fromRate.Source, AsOf: fromRate.AsOf}
+	switch {
+	case fromRate.Source == fixedSource:
+		r.Source = toRate.Source
+	case toRate.Source != fixedSource && toRate.Source != fromRate.Source:
+		r.Source = fromRate.Source + "/" + toRate.Source
+	}
+	if toRate.AsOf.Before(r.AsOf) {
+		r.AsOf = toRate.AsOf
+	}
+	return r, nil
+}
+
+// Rate returns the current price of one bitcoin in code. A rate older than
+// TTL is refreshed in the background while it is still served; only with no
+// rate younger than MaxAge does the caller wait for the providers.
+func (c *RateConverter) Rate(ctx context.Context, code string) (Rate, error) {
+	code = strings.ToUpper(code)
+	now := c.now()
+	if code == SAT.Code {
+		return Rate{Code: code, PerBTC: satsPerBTC, Source: fixedSource, AsOf: now}, nil
+	}
+
+	c.mu.Lock()
+	cached, ok := c.rates[code]
+	if ok && now.Sub(cached.AsOf) < c.ttl {
+		c.mu.Unlock()
+		return cached, nil
+	}
+	usable := ok && now.Sub(cached.AsOf) <= c.maxAge
+	if failed, ok := c.failures[code]; ok && now.Sub(failed.at) < c.retryAfter {
+		c.mu.Unlock()
+		if usable {
+			return cached, nil
+		}
+		return Rate{}, failed.err
+	}
+	f := c.refresh(ctx, code)
+	c.mu.Unlock()
+
+	if usable {
+		return cached, nil
+	}
+	select {
+	case <-f.done:
+		return f.rate, f.err
+	case <-ctx.Done():
+		return Rate{}, ctx.Err()
+	}
+}
+
+// refresh returns the fetch in flight for code, starting one if there is
+// none. The fetch outlives ctx's cancellation so a caller that gives up, or
+// was served a stale rate, does not abort it. c.mu must be held.
+func (c *RateConverter) refresh(ctx context.Context, code string) *rateFetch {
+	if f, ok := c.inflight[code]; ok {
+		return f
+	}
+	f := &rateFetch{done: make(chan struct{})}
+	c.inflight[code] = f
+	go func() {
+		rate, err := c.fetch(context.WithoutCancel(ctx), code)
+		c.mu.Lock()
+		if err == nil {
+			c.rates[code] = rate
+			delete(c.failures, code)
+		} else {
+			c.failures[code] = rateFailure{at: c.now(), err: err}
+		}
+		delete(c.inflight, code)
+		c.mu.Unlock()
+		f.rate, f.err = rate, err
+		close(f.done)
+	}()
+	return f
+}
+
+// fetch asks the providers in order for code's price, stamping the rate
+// with the time the provider answered: a slow provider must not hand out a
+// rate that looks older than it is and so expires early.
+func (c *RateConverter) fetch(ctx context.Context, code string) (Rate, error) {
+	var errs []error
+	for _, p := range c.providers {
+		price, err := p.BTCPrice(ctx, code)
+		if err == nil && price <= 0 {
+			err = fmt.Errorf("non-positive price %v", price)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+			continue
+		}
+		return Rate{Code: code, PerBTC: price, Source: p.Name(), AsOf: c.now()}, nil
+	}
+	if len(errs) == 0 {
+		return Rate{}, fmt.Errorf("%w: %s", ErrRateUnavailable, code)
+	}
+	return Rate{}, fmt.Errorf("%w: %s: %w", ErrRateUnavailable, code, errors.Join(errs...))
+}
+
+// Quoter shows an amount in another currency for display, reporting false
+// when it cannot. The zero Quoter never quotes.
+type Quoter func(Money) (Money, bool)
+
+// Quote converts m, or reports false when q is nil or the conversion failed.
+func (q Quoter) Quote(m Money) (Money, bool) {
+	if q == nil || m.IsZero() {
+		return Money{}, false
+	}
+	return q(m)
+}
+
+// SatsQuoter returns a Quoter that prices amounts in sats with converter.
+// Amounts already in sats are not quoted again.
+func SatsQuoter(ctx context.Context, converter Converter) Quoter {
+	if converter == nil {
+		return nil
+	}
+	return func(m Money) (Money, bool) {
+		if m.Currency.Code == SAT.Code {
+			return Money{}, false
+		}
+		sats, err := converter.Convert(ctx, m, SAT)
+		if err != nil || !sats.IsPositive() {
+			return Money{}, false
+		}
+		return sats, true
+	}
+}
@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is the outbox for the memory backend. Repositories append to
+// it under the same lock that guards their aggregates, which is as
+// transactional as memory storage gets.
+type MemoryStore struct {
+	mu       sync.Mutex
+	messages []*memoryMessage
+	written  chan struct{}
+	now      func() time.Time
+}
+
+type memoryMessage struct {
+	Message
+	nextAttemptAt time.Time
+	inFlight      bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{written: make(chan struct{}, 1), now: time.Now}
+}
+
+// Add appends msgs to the outbox.
+func (s *MemoryStore) Add(msgs ...Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	s.mu.Lock()
+	for _, m := range msgs {
+		s.messages = append(s.messages, &memoryMessage{Message: m})
+	}
+	s.mu.Unlock()
+	select {
+	case s.written <- struct{}{}:
+	default:
+	}
+}
+
+// Pending returns the messages not yet published, oldest first.
+func (s *MemoryStore) Pending() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Message, len(s.messages))
+	for i, m := range s.messages {
+		out[i] = m.Message
+	}
+	return out
+}
+
+func (s *MemoryStore) Written() <-chan struct{} { return s.written }
+
+func (s *MemoryStore) Dispatch(ctx context.Context, limit int, publish func(Message) error, retryAt func(attempts int) time.Time) (int, error) {
+	// Claim the batch, then publish without the lock so repositories are
+	// not held up by a slow broker.
+	s.mu.Lock()
+	now := s.now()
+	var batch []*memoryMessage
+	for _, m := range s.messages {
+		if len(batch) == limit {
+			break
+		}
+		if m.inFlight || m.nextAttemptAt.After(now) {
+			continue
+		}
+		m.inFlight = true
+		batch = append(batch, m)
+	}
+	s.mu.Unlock()
+
+	published := make(map[*memoryMessage]bool, len(batch))
+	for _, m := range batch {
+		if ctx.Err() != nil {
+			break
+		}
+		published[m] = publish(m.Message) == nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	kept := s.messages[:0]
+	for _, m := range s.messages {
+		ok, tried := published[m]
+		if ok {
+			n++
+			continue
+		}
+		if tried {
+			m.Attempts++
+			m.nextAttemptAt = retryAt(m.Attempts)
+		}
+		m.inFlight = false
+		kept = append(kept, m)
+	}
+	clear(s.messages[len(kept):])
+	s.messages = kept
+	return n, ctx.Err()
+}
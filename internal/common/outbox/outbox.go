@@ -0,0 +1,73 @@
+// Package outbox implements the transactional outbox: domain events are
+// written alongside the aggregate that raised them, in the same transaction,
+// and a Relay publishes them to the event bus afterwards. An event is never
+// lost because the broker was down when the aggregate was saved; it may be
+// published more than once, so it carries a stable ID consumers can dedupe on.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/envelope"
+)
+
+// Message is a domain event waiting in the outbox.
+type Message struct {
+	// ID is the message ID the event is published under, so retries of the
+	// same event are recognisable downstream.
+	ID        string
+	Topic     string
+	Payload   []byte
+	CreatedAt time.Time
+	// Attempts counts failed publishes so far.
+	Attempts int
+}
+
+// NewMessage wraps ev in an envelope, published under the envelope's ID so
+// retries of the same event are recognisable downstream. Stamp ev first to
+// carry the request's correlation metadata.
+func NewMessage(ev common.DomainEvent) (Message, error) {
+	env, err := envelope.New(ev.EventName(), ev, envelope.Metadata{})
+	if err != nil {
+		return Message{}, err
+	}
+	payload, err := json.Marshal(env)
+	if err != nil {
+		return Message{}, fmt.Errorf("encode %s: %w", env.Type, err)
+	}
+	return Message{
+		ID:        env.ID,
+		Topic:     env.Type,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// NewMessages encodes events in the order they were raised.
+func NewMessages(events []common.DomainEvent) ([]Message, error) {
+	msgs := make([]Message, 0, len(events))
+	for _, ev := range events {
+		m, err := NewMessage(ev)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, nil
+}
+
+// Store holds messages until they are published.
+type Store interface {
+	// Dispatch hands up to limit messages that are due to publish, oldest
+	// first. Published messages leave the outbox; a message publish fails
+	// on is kept and retried at retryAt(attempts) without holding back the
+	// messages behind it. It returns how many messages were published.
+	Dispatch(ctx context.Context, limit int, publish func(Message) error, retryAt func(attempts int) time.Time) (int, error)
+	// Written signals after this process writes messages to the outbox, so
+	// a relay need not wait for its next poll. It may return nil.
+	Written() <-chan struct{}
+}
@@ -0,0 +1,119 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/outbox"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tipAdded struct {
+	OrderID string
+	At      time.Time
+}
+
+func (e tipAdded) EventName() string     { return "order.tip_added" }
+func (e tipAdded) OccurredAt() time.Time { return e.At }
+
+// flakyPublisher fails its first `failures` publishes, then records the rest.
+type flakyPublisher struct {
+	mu        sync.Mutex
+	failures  int
+	published []string
+}
+
+func (p *flakyPublisher) PublishRaw(topic, id string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, id)
+	return nil
+}
+
+func (p *flakyPublisher) ids() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.published...)
+}
+
+func TestNewMessage_EnvelopesEventUnderItsName(t *testing.T) {
+	ctx := envelope.WithMetadata(context.Background(), envelope.Metadata{CorrelationID: "req-1", Actor: "user-1"})
+	m, err := outbox.NewMessage(envelope.Stamp(ctx, tipAdded{OrderID: "o1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "order.tip_added", m.Topic)
+
+	var ev tipAdded
+	env, err := envelope.Decode(m.Payload, &ev)
+	require.NoError(t, err)
+	assert.Equal(t, "o1", ev.OrderID)
+	assert.Equal(t, m.ID, env.ID, "the message is published under the envelope ID")
+	assert.Equal(t, "req-1", env.CorrelationID)
+	assert.Equal(t, "user-1", env.Actor)
+}
+
+func TestMemoryStore_RetriesFailedPublishesWithBackoff(t *testing.T) {
+	store := outbox.NewMemoryStore()
+	first, _ := outbox.NewMessage(tipAdded{OrderID: "o1"})
+	second, _ := outbox.NewMessage(tipAdded{OrderID: "o2"})
+	store.Add(first, second)
+
+	now := time.Now()
+	var retries []int
+	retryAt := func(attempts int) time.Time {
+		retries = append(retries, attempts)
+		return now.Add(time.Hour)
+	}
+	var sent []string
+	publish := func(m outbox.Message) error {
+		if m.ID == first.ID {
+			return errors.New("broker unavailable")
+		}
+		sent = append(sent, m.ID)
+		return nil
+	}
+
+	n, err := store.Dispatch(context.Background(), 10, publish, retryAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{second.ID}, sent, "a failure does not hold back later messages")
+	assert.Equal(t, []int{1}, retries)
+	pending := store.Pending()
+	require.Len(t, pending, 1)
+	assert.Equal(t, first.ID, pending[0].ID)
+	assert.Equal(t, 1, pending[0].Attempts)
+
+	n, err = store.Dispatch(context.Background(), 10, publish, retryAt)
+	require.NoError(t, err)
+	assert.Zero(t, n, "the failed message waits out its backoff")
+}
+
+func TestRelay_PublishesUnderTheOutboxID(t *testing.T) {
+	store := outbox.NewMemoryStore()
+	pub := &flakyPublisher{failures: 1}
+	relay := outbox.NewRelay(store, pub, nil)
+
+	m, _ := outbox.NewMessage(tipAdded{OrderID: "o1"})
+	store.Add(m)
+	n, err := relay.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, store.Pending(), 1, "kept for a retry")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Run(ctx)
+	next, _ := outbox.NewMessage(tipAdded{OrderID: "o2"})
+	store.Add(next)
+	require.Eventually(t, func() bool { return len(pub.ids()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{next.ID}, pub.ids(), "a write wakes the relay; the failed message is still backing off")
+}
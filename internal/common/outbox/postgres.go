@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Insert writes msgs to the event_outbox table in tx, so they commit or roll
+// back with the aggregate that raised them.
+func Insert(tx *sql.Tx, msgs []Message) error {
+	for _, m := range msgs {
+		_, err := tx.Exec(
+			`INSERT INTO event_outbox (id, topic, payload, created_at) VALUES ($1, $2, $3, $4)`,
+			m.ID, m.Topic, m.Payload, m.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("write %s to outbox: %w", m.Topic, err)
+		}
+	}
+	return nil
+}
+
+// PostgresStore reads the event_outbox table. Replicas may relay
+// concurrently: each claims its batch with FOR UPDATE SKIP LOCKED.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Written returns nil: rows are written by repositories in their own
+// transactions, so the relay finds them by polling.
+func (s *PostgresStore) Written() <-chan struct{} { return nil }
+
+func (s *PostgresStore) Dispatch(ctx context.Context, limit int, publish func(Message) error, retryAt func(attempts int) time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT seq, id, topic, payload, created_at, attempts FROM event_outbox
+		 WHERE next_attempt_at <= NOW()
+		 ORDER BY seq
+		 LIMIT $1
+		 FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, err
+	}
+	type claimed struct {
+		seq int64
+		Message
+	}
+	var batch []claimed
+	for rows.Next() {
+		var c claimed
+		if err := rows.Scan(&c.seq, &c.ID, &c.Topic, &c.Payload, &c.CreatedAt, &c.Attempts); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		batch = append(batch, c)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, c := range batch {
+		if ctx.Err() != nil {
+			break
+		}
+		if perr := publish(c.Message); perr != nil {
+			_, err = tx.ExecContext(ctx,
+				`UPDATE event_outbox SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE seq = $1`,
+				c.seq, retryAt(c.Attempts+1), perr.Error())
+		} else {
+			n++
+			_, err = tx.ExecContext(ctx, `DELETE FROM event_outbox WHERE seq = $1`, c.seq)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	// A failed commit leaves the published rows in place; they go out again
+	// under the same IDs.
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return n, ctx.Err()
+}
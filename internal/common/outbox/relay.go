@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	pollInterval = 250 * time.Millisecond
+	batchSize    = 100
+	maxBackoff   = time.Minute
+)
+
+// Publisher publishes an encoded event under a caller-chosen message ID.
+type Publisher interface {
+	PublishRaw(topic, id string, payload []byte) error
+}
+
+// Relay moves messages from a Store to the event bus, retrying failed
+// publishes with exponential backoff.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	log       *slog.Logger
+	now       func() time.Time
+}
+
+func NewRelay(store Store, publisher Publisher, log *slog.Logger) *Relay {
+	if store == nil {
+		panic("nil outbox.Store")
+	}
+	if publisher == nil {
+		panic("nil outbox.Publisher")
+	}
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Relay{store: store, publisher: publisher, log: log, now: time.Now}
+}
+
+// Run relays until ctx is done, draining the outbox whenever the store
+// reports a write and at least every poll interval.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := r.Flush(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.log.WarnContext(ctx, "outbox relay failed", "error", err)
+			}
+			if n < batchSize || err != nil {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.store.Written():
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush publishes one batch of due messages and returns how many went out.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	return r.store.Dispatch(ctx, batchSize, func(m Message) error {
+		if err := r.publisher.PublishRaw(m.Topic, m.ID, m.Payload); err != nil {
+			r.log.WarnContext(ctx, "outbox publish failed; will retry",
+				"topic", m.Topic, "messageID", m.ID, "attempts", m.Attempts+1, "error", err)
+			return err
+		}
+		return nil
+	}, r.retryAt)
+}
+
+// retryAt backs off exponentially from one second, capped at maxBackoff.
+func (r *Relay) retryAt(attempts int) time.Time {
+	delay := maxBackoff
+	if attempts <= 6 {
+		delay = time.Second << (attempts - 1)
+	}
+	return r.now().Add(delay)
+}
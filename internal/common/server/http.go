@@ -4,11 +4,13 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	commonhttp "bitmerchant/internal/common/http"
 	"bitmerchant/internal/common/http/middleware"
 	"bitmerchant/internal/infrastructure/logging"
 
@@ -28,6 +30,12 @@ type HTTPConfig struct {
 	// allowlist so presigned image URLs served from that host are not blocked.
 	// Leave empty when object storage is not configured.
 	S3Endpoint string
+	// Metrics records every request when set.
+	Metrics middleware.HTTPMetrics
+	// MetricsHandler is served on GET /metrics when set. With MetricsToken
+	// set, a scrape must send it as "Authorization: Bearer <token>".
+	MetricsHandler http.Handler
+	MetricsToken   string
 }
 
 // Component is the HTTP transport adapter used by the application composition root (cmd/server).
@@ -44,6 +52,10 @@ func (c Component) Run(ctx context.Context, logger *logging.Logger, register fun
 func RunHTTPServer(ctx context.Context, cfg HTTPConfig, logger *logging.Logger, register func(e *echo.Echo)) error {
 	e := echo.New()
 
+	if cfg.Metrics != nil {
+		e.Use(middleware.MetricsMiddleware(cfg.Metrics))
+	}
+	e.Use(middleware.TracingMiddleware())
 	// Skip the request timeout for SSE stream endpoints — they are long-lived by design.
 	e.Use(echoMiddleware.ContextTimeoutWithConfig(echoMiddleware.ContextTimeoutConfig{
 		Skipper: func(c echo.Context) bool {
@@ -65,10 +77,14 @@ func RunHTTPServer(ctx context.Context, cfg HTTPConfig, logger *logging.Logger,
 	}
 	e.Use(middleware.CSRFMiddleware())
 	e.Use(middleware.CSPMiddleware(cfg.S3Endpoint))
+	e.Use(commonhttp.LocaleMiddleware())
 
 	e.GET("/health", func(c echo.Context) error {
 		return c.NoContent(http.StatusOK)
 	})
+	if cfg.MetricsHandler != nil {
+		e.GET("/metrics", MetricsEndpoint(cfg.MetricsHandler, cfg.MetricsToken))
+	}
 
 	e.Static("/static", "static")
 	e.Static("/assets", "assets")
@@ -102,3 +118,19 @@ func RunHTTPServer(ctx context.Context, cfg HTTPConfig, logger *logging.Logger,
 		return nil
 	}
 }
+
+// MetricsEndpoint serves handler, requiring the bearer token when token is
+// not empty.
+func MetricsEndpoint(handler http.Handler, token string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if token != "" {
+			got, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+				return c.NoContent(http.StatusUnauthorized)
+			}
+		}
+		handler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
@@ -0,0 +1,307 @@
+// Package tax prices the taxes and service charge on an order. Rates are
+// whole basis points of a percent (RateDenominator = 100%), so 700 is 7% and
+// 825 is 8.25%; amounts are int64 minor units, like package money.
+package tax
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RateDenominator is what a rate of 100% is stored as. It matches the
+// NUMERIC(5,4) precision restaurants stored their single tax rate at.
+const RateDenominator = 10_000
+
+// MaxTaxes caps how many named taxes a restaurant can layer.
+const MaxTaxes = 5
+
+// Mode says whether menu prices already include tax.
+type Mode string
+
+const (
+	// ModeExclusive adds tax on top of menu prices (US style).
+	ModeExclusive Mode = "exclusive"
+	// ModeInclusive treats menu prices as tax-inclusive (VAT style); the tax
+	// lines show what the total already contains.
+	ModeInclusive Mode = "inclusive"
+)
+
+// Class groups menu items that are taxed alike, e.g. "alcohol" or "takeaway".
+// Items and categories without one are ClassStandard; ClassExempt items are
+// skipped by taxes that do not name them.
+type Class string
+
+const (
+	ClassStandard Class = ""
+	ClassExempt   Class = "exempt"
+)
+
+var classPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,23}$`)
+
+var (
+	ErrInvalidClass = errors.New("tax class must be up to 24 letters, digits, - or _")
+	ErrInvalidRate  = errors.New("tax rates must be between 0% and 100%")
+	ErrInvalidName  = errors.New("each tax needs a name of up to 40 characters")
+	ErrTooManyTaxes = fmt.Errorf("at most %d taxes", MaxTaxes)
+	ErrInvalidMode  = errors.New("tax mode must be inclusive or exclusive")
+)
+
+// NormalizeClass trims and lower-cases a class name; blank is ClassStandard.
+func NormalizeClass(raw string) (Class, error) {
+	c := strings.ToLower(strings.TrimSpace(raw))
+	if c == "" {
+		return ClassStandard, nil
+	}
+	if !classPattern.MatchString(c) {
+		return "", ErrInvalidClass
+	}
+	return Class(c), nil
+}
+
+// Tax is one named tax. With no Classes it applies to every item except
+// ClassExempt ones; otherwise only to the listed classes.
+type Tax struct {
+	Name    string
+	Rate    int64
+	Classes []Class
+}
+
+// AppliesTo reports whether the tax is charged on items of class c.
+func (t Tax) AppliesTo(c Class) bool {
+	if len(t.Classes) == 0 {
+		return c != ClassExempt
+	}
+	for _, tc := range t.Classes {
+		if tc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Config is a restaurant's tax setup: its taxes, whether menu prices include
+// them, and an optional service charge on the order.
+type Config struct {
+	Mode  Mode
+	Taxes []Tax
+	// ServiceChargeRate is charged on the subtotal after any discount.
+	ServiceChargeRate int64
+	// ServiceChargeTaxable makes the service charge subject to the taxes
+	// that apply to standard-class items, as Thai VAT is.
+	ServiceChargeTaxable bool
+}
+
+// Exclusive is a single tax named "Tax" added on top of prices: the setup
+// every restaurant had before named taxes. rate is a fraction (0.08 = 8%).
+func Exclusive(rate float64) Config {
+	cfg := Config{Mode: ModeExclusive}
+	if bp := int64(rate*RateDenominator + 0.5); bp > 0 {
+		cfg.Taxes = []Tax{{Name: "Tax", Rate: bp}}
+	}
+	return cfg
+}
+
+// IsZero reports an unset config; restaurants fall back to their legacy
+// single rate (see Exclusive).
+func (c Config) IsZero() bool {
+	return c.Mode == "" && len(c.Taxes) == 0 && c.ServiceChargeRate == 0
+}
+
+// Inclusive reports whether menu prices include tax.
+func (c Config) Inclusive() bool { return c.Mode == ModeInclusive }
+
+// Normalize validates the config, trimming names and dropping duplicate
+// classes. An empty mode becomes ModeExclusive.
+func (c Config) Normalize() (Config, error) {
+	switch c.Mode {
+	case "":
+		c.Mode = ModeExclusive
+	case ModeExclusive, ModeInclusive:
+	default:
+		return Config{}, ErrInvalidMode
+	}
+	if len(c.Taxes) > MaxTaxes {
+		return Config{}, ErrTooManyTaxes
+	}
+	if !validRate(c.ServiceChargeRate) {
+		return Config{}, ErrInvalidRate
+	}
+	taxes := make([]Tax, 0, len(c.Taxes))
+	for _, t := range c.Taxes {
+		t.Name = strings.TrimSpace(t.Name)
+		if t.Name == "" || len(t.Name) > 40 {
+			return Config{}, ErrInvalidName
+		}
+		if !validRate(t.Rate) {
+			return Config{}, ErrInvalidRate
+		}
+		var classes []Class
+		seen := map[Class]bool{}
+		for _, raw := range t.Classes {
+			cl, err := NormalizeClass(string(raw))
+			if err != nil {
+				return Config{}, err
+			}
+			if !seen[cl] {
+				seen[cl] = true
+				classes = append(classes, cl)
+			}
+		}
+		t.Classes = classes
+		taxes = append(taxes, t)
+	}
+	c.Taxes = taxes
+	return c, nil
+}
+
+func validRate(r int64) bool { return r >= 0 && r < RateDenominator }
+
+// Line is an amount of the order taxed as Class.
+type Line struct {
+	Class  Class
+	Amount int64
+}
+
+// Charge is what one tax came to on an order.
+type Charge struct {
+	Name   string
+	Rate   int64
+	Amount int64
+}
+
+// Label names the charge with its rate, e.g. "VAT (7%)"; just the name when
+// the rate is unknown.
+func (c Charge) Label() string {
+	if c.Rate == 0 {
+		return c.Name
+	}
+	return c.Name + " (" + FormatRate(c.Rate) + ")"
+}
+
+// Result prices an order's taxes and service charge. Tax is the sum of
+// Charges; when Inclusive it is already part of the menu prices and so of
+// Total. Total excludes any tip.
+type Result struct {
+	ServiceCharge int64
+	Charges       []Charge
+	Tax           int64
+	Inclusive     bool
+	Total         int64
+}
+
+// Apply prices lines with discount taken off first, spread over the lines in
+// proportion to their amounts so each tax sees its share of it. Every figure
+// is rounded half up in minor units.
+func (c Config) Apply(lines []Line, discount int64) Result {
+	var subtotal int64
+	for _, l := range lines {
+		subtotal += l.Amount
+	}
+	discount = min(max(discount, 0), subtotal)
+	net := subtotal - discount
+
+	// Taxable amount per class, after the discount.
+	byClass := map[Class]int64{}
+	var order []Class
+	remaining := discount
+	for i, l := range lines {
+		share := Share(discount, l.Amount, max(subtotal, 1))
+		if i == len(lines)-1 {
+			share = remaining
+		}
+		remaining -= share
+		if _, ok := byClass[l.Class]; !ok {
+			order = append(order, l.Class)
+		}
+		byClass[l.Class] += l.Amount - share
+	}
+
+	res := Result{Inclusive: c.Inclusive()}
+	res.ServiceCharge = Share(net, c.ServiceChargeRate, RateDenominator)
+	if c.ServiceChargeTaxable && res.ServiceCharge > 0 {
+		if _, ok := byClass[ClassStandard]; !ok {
+			order = append(order, ClassStandard)
+		}
+		byClass[ClassStandard] += res.ServiceCharge
+	}
+
+	for _, t := range c.Taxes {
+		ch := Charge{Name: t.Name, Rate: t.Rate}
+		for _, cl := range order {
+			if !t.AppliesTo(cl) {
+				continue
+			}
+			if res.Inclusive {
+				ch.Amount += Share(byClass[cl], t.Rate, RateDenominator+c.combinedRate(cl))
+			} else {
+				ch.Amount += Share(byClass[cl], t.Rate, RateDenominator)
+			}
+		}
+		if ch.Amount > 0 {
+			res.Charges = append(res.Charges, ch)
+			res.Tax += ch.Amount
+		}
+	}
+
+	res.Total = net + res.ServiceCharge
+	if !res.Inclusive {
+		res.Total += res.Tax
+	}
+	return res
+}
+
+// combinedRate sums the rates of every tax charged on class cl, which is
+// what an inclusive price carries on top of its net amount.
+func (c Config) combinedRate(cl Class) int64 {
+	var sum int64
+	for _, t := range c.Taxes {
+		if t.AppliesTo(cl) {
+			sum += t.Rate
+		}
+	}
+	return sum
+}
+
+// Share returns amount*num/den rounded half up, for non-negative inputs.
+func Share(amount, num, den int64) int64 {
+	return (2*amount*num + den) / (2 * den)
+}
+
+// ParseRate reads a percentage such as "7" or "8.25" into a rate. Blank is
+// zero.
+func ParseRate(percent string) (int64, error) {
+	s := strings.TrimSuffix(strings.TrimSpace(percent), "%")
+	if s == "" {
+		return 0, nil
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > 2 || whole == "" && frac == "" {
+		return 0, ErrInvalidRate
+	}
+	w, err := strconv.ParseInt("0"+whole, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidRate
+	}
+	f, err := strconv.ParseInt("0"+frac+strings.Repeat("0", 2-len(frac)), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidRate
+	}
+	rate := w*100 + f
+	if !validRate(rate) {
+		return 0, ErrInvalidRate
+	}
+	return rate, nil
+}
+
+// FormatRate renders a rate as a percentage without trailing zeros:
+// 700 is "7%", 825 is "8.25%".
+func FormatRate(rate int64) string {
+	s := strconv.FormatInt(rate/100, 10)
+	if frac := rate % 100; frac != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%02d", frac), "0")
+	}
+	return s + "%"
+}
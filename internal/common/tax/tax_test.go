@@ -0,0 +1,103 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bitmerchant/internal/common/tax"
+)
+
+func TestApply_ExclusiveLayeredTaxes(t *testing.T) {
+	// A city tax on top of state sales tax, with a liquor tax on alcohol only.
+	cfg := tax.Config{Mode: tax.ModeExclusive, Taxes: []tax.Tax{
+		{Name: "State", Rate: 625},
+		{Name: "City", Rate: 200},
+		{Name: "Liquor", Rate: 1000, Classes: []tax.Class{"alcohol"}},
+	}}
+	res := cfg.Apply([]tax.Line{{Amount: 2000}, {Class: "alcohol", Amount: 1200}}, 0)
+
+	assert.Equal(t, []tax.Charge{
+		{Name: "State", Rate: 625, Amount: 125 + 75},
+		{Name: "City", Rate: 200, Amount: 40 + 24},
+		{Name: "Liquor", Rate: 1000, Amount: 120},
+	}, res.Charges)
+	assert.Equal(t, int64(384), res.Tax)
+	assert.Equal(t, int64(3200+384), res.Total)
+	assert.False(t, res.Inclusive)
+}
+
+func TestApply_InclusiveWithTaxableServiceCharge(t *testing.T) {
+	// Thai restaurant: 10% service charge, then 7% VAT inside the total.
+	cfg := tax.Config{Mode: tax.ModeInclusive, Taxes: []tax.Tax{{Name: "VAT", Rate: 700}}, ServiceChargeRate: 1000, ServiceChargeTaxable: true}
+	res := cfg.Apply([]tax.Line{{Amount: 500_00}}, 0)
+
+	assert.Equal(t, int64(50_00), res.ServiceCharge)
+	assert.Equal(t, int64(35_98), res.Tax, "550 * 7/107 = 35.98")
+	assert.Equal(t, int64(550_00), res.Total, "inclusive tax is not added again")
+	assert.True(t, res.Inclusive)
+}
+
+func TestApply_ExemptClassAndUntaxedServiceCharge(t *testing.T) {
+	cfg := tax.Config{Mode: tax.ModeExclusive, Taxes: []tax.Tax{{Name: "Tax", Rate: 800}}, ServiceChargeRate: 1800}
+	res := cfg.Apply([]tax.Line{{Amount: 1000}, {Class: tax.ClassExempt, Amount: 500}}, 0)
+
+	assert.Equal(t, int64(270), res.ServiceCharge)
+	assert.Equal(t, int64(80), res.Tax, "exempt items and the service charge are untaxed")
+	assert.Equal(t, int64(1500+270+80), res.Total)
+}
+
+func TestApply_DiscountIsSpreadAcrossClasses(t *testing.T) {
+	cfg := tax.Config{Mode: tax.ModeExclusive, Taxes: []tax.Tax{{Name: "Liquor", Rate: 1000, Classes: []tax.Class{"alcohol"}}}}
+	// 25% off a 3000 order: the 1000 of alcohol is taxed on 750.
+	res := cfg.Apply([]tax.Line{{Amount: 2000}, {Class: "alcohol", Amount: 1000}}, 750)
+
+	assert.Equal(t, int64(75), res.Tax)
+	assert.Equal(t, int64(2250+75), res.Total)
+
+	res = cfg.Apply([]tax.Line{{Amount: 100}}, 500)
+	assert.Equal(t, int64(0), res.Total, "discount is capped at the subtotal")
+}
+
+func TestExclusive_LegacyRate(t *testing.T) {
+	cfg := tax.Exclusive(0.0825)
+	require.Len(t, cfg.Taxes, 1)
+	assert.Equal(t, tax.Tax{Name: "Tax", Rate: 825}, cfg.Taxes[0])
+	assert.Empty(t, tax.Exclusive(0).Taxes)
+}
+
+func TestNormalize(t *testing.T) {
+	cfg, err := tax.Config{Taxes: []tax.Tax{{Name: " VAT ", Rate: 700, Classes: []tax.Class{"Food", "food", " alcohol"}}}}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, tax.ModeExclusive, cfg.Mode)
+	assert.Equal(t, "VAT", cfg.Taxes[0].Name)
+	assert.Equal(t, []tax.Class{"food", "alcohol"}, cfg.Taxes[0].Classes)
+
+	for name, bad := range map[string]tax.Config{
+		"mode":      {Mode: "sometimes"},
+		"no name":   {Taxes: []tax.Tax{{Rate: 700}}},
+		"rate 100%": {Taxes: []tax.Tax{{Name: "X", Rate: tax.RateDenominator}}},
+		"class":     {Taxes: []tax.Tax{{Name: "X", Rate: 100, Classes: []tax.Class{"two words"}}}},
+		"too many":  {Taxes: make([]tax.Tax, tax.MaxTaxes+1)},
+	} {
+		_, err := bad.Normalize()
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParseAndFormatRate(t *testing.T) {
+	for in, want := range map[string]int64{"7": 700, "8.25": 825, "8.5%": 850, " 0.07 ": 7, "": 0, ".5": 50} {
+		got, err := tax.ParseRate(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, bad := range []string{"100", "-1", "8.125", "abc", "."} {
+		_, err := tax.ParseRate(bad)
+		assert.ErrorIs(t, err, tax.ErrInvalidRate, bad)
+	}
+	assert.Equal(t, "7%", tax.FormatRate(700))
+	assert.Equal(t, "8.25%", tax.FormatRate(825))
+	assert.Equal(t, "8.5%", tax.FormatRate(850))
+	assert.Equal(t, "VAT (7%)", tax.Charge{Name: "VAT", Rate: 700}.Label())
+}
@@ -0,0 +1,227 @@
+package tip
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitmerchant/internal/common"
+)
+
+// MaxPools caps how many tip pools a restaurant can split tips into.
+const MaxPools = 3
+
+// Split says how a pool is shared among the staff who worked the period.
+type Split string
+
+const (
+	// SplitHours shares each pool in proportion to hours on the clock.
+	SplitHours Split = "hours"
+	// SplitEqual shares each pool equally among everyone who clocked in.
+	SplitEqual Split = "equal"
+)
+
+var (
+	ErrInvalidPool   = errors.New("each tip pool needs a name of up to 40 characters and at least one role")
+	ErrTooManyPools  = fmt.Errorf("at most %d tip pools", MaxPools)
+	ErrPoolShares    = errors.New("tip pool shares must be whole percentages adding up to 100")
+	ErrPoolRoleTaken = errors.New("a role can belong to only one tip pool")
+	ErrInvalidSplit  = errors.New("tips are shared by hours worked or equally")
+)
+
+// Pool is one share of the period's tips, e.g. 60% to front of house,
+// shared among staff who clocked in under one of Roles.
+type Pool struct {
+	Name  string
+	Share int
+	Roles []common.MemberRole
+}
+
+// Includes reports whether staff working as role share in the pool.
+func (p Pool) Includes(role common.MemberRole) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// Pooling is how a restaurant shares out tips. With no pools tips stay with
+// whoever collected them.
+type Pooling struct {
+	Pools []Pool
+	Split Split
+}
+
+// Enabled reports whether tips are pooled at all.
+func (p Pooling) Enabled() bool { return len(p.Pools) > 0 }
+
+// PoolFor names the pool staff working as role share in; "" when none.
+func (p Pooling) PoolFor(role common.MemberRole) string {
+	for _, pool := range p.Pools {
+		if pool.Includes(role) {
+			return pool.Name
+		}
+	}
+	return ""
+}
+
+// Normalize validates the pooling, trimming names and dropping duplicate
+// roles within a pool. An empty split becomes SplitHours; with no pools the
+// split is cleared.
+func (p Pooling) Normalize() (Pooling, error) {
+	if len(p.Pools) == 0 {
+		return Pooling{}, nil
+	}
+	if len(p.Pools) > MaxPools {
+		return Pooling{}, ErrTooManyPools
+	}
+	switch p.Split {
+	case "":
+		p.Split = SplitHours
+	case SplitHours, SplitEqual:
+	default:
+		return Pooling{}, ErrInvalidSplit
+	}
+	taken := map[common.MemberRole]bool{}
+	pools := make([]Pool, 0, len(p.Pools))
+	total := 0
+	for _, pool := range p.Pools {
+		pool.Name = strings.TrimSpace(pool.Name)
+		if pool.Name == "" || len(pool.Name) > 40 || len(pool.Roles) == 0 {
+			return Pooling{}, ErrInvalidPool
+		}
+		if pool.Share < 1 || pool.Share > 100 {
+			return Pooling{}, ErrPoolShares
+		}
+		roles := slices.Clone(pool.Roles)
+		slices.Sort(roles)
+		roles = slices.Compact(roles)
+		for _, r := range roles {
+			if r != common.RoleOwner && r != common.RoleServer && r != common.RoleKitchenStaff {
+				return Pooling{}, ErrInvalidPool
+			}
+			if taken[r] {
+				return Pooling{}, ErrPoolRoleTaken
+			}
+			taken[r] = true
+		}
+		pool.Roles = roles
+		total += pool.Share
+		pools = append(pools, pool)
+	}
+	if total != 100 {
+		return Pooling{}, ErrPoolShares
+	}
+	p.Pools = pools
+	return p, nil
+}
+
+// Worked is the time one staff member spent on the clock under one role
+// during a period.
+type Worked struct {
+	StaffID common.UserID
+	Role    common.MemberRole
+	Minutes int64
+}
+
+// Allocation is one staff member's share of a pool.
+type Allocation struct {
+	Pool    string
+	StaffID common.UserID
+	Role    common.MemberRole
+	Minutes int64
+	Amount  int64
+}
+
+// Distribute shares total, in minor units, across the pools and then among
+// the staff in worked. Entries for the same staff member and role are
+// combined first. Every minor unit is handed out by largest remainder, so
+// allocations add up to total less unallocated: the shares of pools nobody
+// in worked belongs to.
+func (p Pooling) Distribute(total int64, worked []Worked) (allocs []Allocation, unallocated int64) {
+	if !p.Enabled() || total <= 0 {
+		return nil, max(total, 0)
+	}
+	shares := make([]int64, len(p.Pools))
+	for i, pool := range p.Pools {
+		shares[i] = int64(pool.Share)
+	}
+	for i, amount := range allocate(total, shares) {
+		pool := p.Pools[i]
+		var members []Allocation
+		for _, w := range combine(worked) {
+			if pool.Includes(w.Role) && w.Minutes > 0 {
+				members = append(members, Allocation{Pool: pool.Name, StaffID: w.StaffID, Role: w.Role, Minutes: w.Minutes})
+			}
+		}
+		if len(members) == 0 {
+			unallocated += amount
+			continue
+		}
+		weights := make([]int64, len(members))
+		for j, m := range members {
+			weights[j] = 1
+			if p.Split == SplitHours {
+				weights[j] = m.Minutes
+			}
+		}
+		for j, a := range allocate(amount, weights) {
+			members[j].Amount = a
+		}
+		allocs = append(allocs, members...)
+	}
+	return allocs, unallocated
+}
+
+// combine merges entries for the same staff member and role, keeping the
+// order each first appeared in.
+func combine(worked []Worked) []Worked {
+	var out []Worked
+	index := map[Worked]int{}
+	for _, w := range worked {
+		key := Worked{StaffID: w.StaffID, Role: w.Role}
+		if i, ok := index[key]; ok {
+			out[i].Minutes += w.Minutes
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, w)
+	}
+	return out
+}
+
+// allocate splits total in proportion to weights, handing the minor units
+// lost to rounding down to the largest remainders (earliest first on a tie).
+func allocate(total int64, weights []int64) []int64 {
+	var sum int64
+	for _, w := range weights {
+		sum += w
+	}
+	out := make([]int64, len(weights))
+	if sum <= 0 {
+		return out
+	}
+	rems := make([]int64, len(weights))
+	left := total
+	for i, w := range weights {
+		out[i] = total * w / sum
+		rems[i] = total * w % sum
+		left -= out[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case rems[a] > rems[b]:
+			return -1
+		case rems[a] < rems[b]:
+			return 1
+		}
+		return 0
+	})
+	for i := 0; left > 0; i++ {
+		out[order[i%len(order)]]++
+		left--
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package tip_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tip"
+)
+
+func fohAndKitchen(split tip.Split) tip.Pooling {
+	return tip.Pooling{
+		Pools: []tip.Pool{
+			{Name: "Front of house", Share: 60, Roles: []common.MemberRole{common.RoleServer, common.RoleOwner}},
+			{Name: "Kitchen", Share: 40, Roles: []common.MemberRole{common.RoleKitchenStaff}},
+		},
+		Split: split,
+	}
+}
+
+func TestPoolingNormalize(t *testing.T) {
+	p, err := tip.Pooling{Pools: []tip.Pool{
+		{Name: "  Floor ", Share: 100, Roles: []common.MemberRole{common.RoleServer, common.RoleServer}},
+	}}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, "Floor", p.Pools[0].Name)
+	assert.Equal(t, []common.MemberRole{common.RoleServer}, p.Pools[0].Roles, "duplicate roles dropped")
+	assert.Equal(t, tip.SplitHours, p.Split, "hours by default")
+	assert.Equal(t, "Floor", p.PoolFor(common.RoleServer))
+	assert.Equal(t, "", p.PoolFor(common.RoleKitchenStaff))
+
+	off, err := tip.Pooling{Split: tip.SplitEqual}.Normalize()
+	require.NoError(t, err)
+	assert.False(t, off.Enabled())
+	assert.Equal(t, tip.Pooling{}, off)
+
+	_, err = fohAndKitchen("weekly").Normalize()
+	assert.ErrorIs(t, err, tip.ErrInvalidSplit)
+
+	short := fohAndKitchen(tip.SplitHours)
+	short.Pools[1].Share = 30
+	_, err = short.Normalize()
+	assert.ErrorIs(t, err, tip.ErrPoolShares)
+
+	taken := fohAndKitchen(tip.SplitHours)
+	taken.Pools[1].Roles = []common.MemberRole{common.RoleServer}
+	_, err = taken.Normalize()
+	assert.ErrorIs(t, err, tip.ErrPoolRoleTaken)
+
+	_, err = tip.Pooling{Pools: []tip.Pool{{Name: "Guests", Share: 100, Roles: []common.MemberRole{common.RoleCustomer}}}}.Normalize()
+	assert.ErrorIs(t, err, tip.ErrInvalidPool)
+	_, err = tip.Pooling{Pools: []tip.Pool{{Name: "Floor", Share: 100}}}.Normalize()
+	assert.ErrorIs(t, err, tip.ErrInvalidPool)
+
+	four := tip.Pooling{}
+	for _, r := range []common.MemberRole{common.RoleServer, common.RoleKitchenStaff, common.RoleOwner, "host"} {
+		four.Pools = append(four.Pools, tip.Pool{Name: string(r), Share: 25, Roles: []common.MemberRole{r}})
+	}
+	_, err = four.Normalize()
+	assert.ErrorIs(t, err, tip.ErrTooManyPools)
+}
+
+func TestDistribute_ByHours(t *testing.T) {
+	allocs, unallocated := fohAndKitchen(tip.SplitHours).Distribute(1000, []tip.Worked{
+		{StaffID: "s1", Role: common.RoleServer, Minutes: 60},
+		{StaffID: "s2", Role: common.RoleServer, Minutes: 60},
+		{StaffID: "k1", Role: common.RoleKitchenStaff, Minutes: 90},
+		{StaffID: "s1", Role: common.RoleServer, Minutes: 60},
+	})
+	assert.Zero(t, unallocated)
+	require.Len(t, allocs, 3, "s1's two shifts are combined")
+	assert.Equal(t, tip.Allocation{Pool: "Front of house", StaffID: "s1", Role: common.RoleServer, Minutes: 120, Amount: 400}, allocs[0])
+	assert.Equal(t, int64(200), allocs[1].Amount)
+	assert.Equal(t, tip.Allocation{Pool: "Kitchen", StaffID: "k1", Role: common.RoleKitchenStaff, Minutes: 90, Amount: 400}, allocs[2])
+}
+
+func TestDistribute_EqualShares(t *testing.T) {
+	allocs, _ := fohAndKitchen(tip.SplitEqual).Distribute(1000, []tip.Worked{
+		{StaffID: "s1", Role: common.RoleServer, Minutes: 480},
+		{StaffID: "s2", Role: common.RoleServer, Minutes: 30},
+		{StaffID: "k1", Role: common.RoleKitchenStaff, Minutes: 90},
+	})
+	require.Len(t, allocs, 3)
+	assert.Equal(t, int64(300), allocs[0].Amount)
+	assert.Equal(t, int64(300), allocs[1].Amount)
+	assert.Equal(t, int64(400), allocs[2].Amount)
+}
+
+func TestDistribute_NobodyInPool(t *testing.T) {
+	allocs, unallocated := fohAndKitchen(tip.SplitHours).Distribute(1000, []tip.Worked{
+		{StaffID: "s1", Role: common.RoleServer, Minutes: 60},
+	})
+	require.Len(t, allocs, 1)
+	assert.Equal(t, int64(600), allocs[0].Amount)
+	assert.Equal(t, int64(400), unallocated, "the kitchen's share waits for someone to pay it to")
+
+	none, unallocated := tip.Pooling{}.Distribute(1000, nil)
+	assert.Empty(t, none)
+	assert.Equal(t, int64(1000), unallocated, "without pooling nothing is shared out")
+}
+
+func TestDistribute_HandsOutEveryMinorUnit(t *testing.T) {
+	p := tip.Pooling{Pools: []tip.Pool{{Name: "All", Share: 100, Roles: []common.MemberRole{common.RoleServer}}}, Split: tip.SplitEqual}
+	allocs, unallocated := p.Distribute(100, []tip.Worked{
+		{StaffID: "a", Role: common.RoleServer, Minutes: 60},
+		{StaffID: "b", Role: common.RoleServer, Minutes: 60},
+		{StaffID: "c", Role: common.RoleServer, Minutes: 60},
+	})
+	assert.Zero(t, unallocated)
+	require.Len(t, allocs, 3)
+	assert.Equal(t, []int64{34, 33, 33}, []int64{allocs[0].Amount, allocs[1].Amount, allocs[2].Amount})
+}
@@ -0,0 +1,162 @@
+// Package tip holds a restaurant's tipping setup: the percentage presets
+// offered at checkout, whether guests may enter their own amount, and
+// whether the restaurant takes tips at all, and how tips are pooled and
+// shared out among staff. Percentages are whole percents of the
+// pre-discount, pre-tax subtotal; amounts are int64 minor units, like
+// package money.
+package tip
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxPresets caps how many percentage chips checkout shows.
+const MaxPresets = 4
+
+// MaxPercent is the largest preset a restaurant can offer.
+const MaxPercent = 50
+
+// StandardDefault is the preset selected first under Standard settings.
+const StandardDefault = 15
+
+var (
+	ErrInvalidPreset  = fmt.Errorf("tip presets must be whole percentages from 1 to %d", MaxPercent)
+	ErrTooManyPresets = fmt.Errorf("at most %d tip presets", MaxPresets)
+	ErrInvalidDefault = errors.New("the default tip must be one of the presets or no tip")
+	ErrNotAllowed     = errors.New("that tip is not offered here")
+	ErrInvalidCustom  = errors.New("a custom tip must be more than zero and no more than the subtotal")
+)
+
+// Settings is a restaurant's tipping setup. Off is for markets where tipping
+// is not customary: checkout shows no tip choice and no tip is asked for
+// after the meal. Otherwise guests pick one of Presets, no tip, or, when
+// Custom is set, an amount of their own.
+type Settings struct {
+	Off     bool
+	Presets []int
+	// Default is the preset selected when checkout opens; 0 selects no tip.
+	Default int
+	Custom  bool
+}
+
+// Standard is the 10/15/20% setup every restaurant had before tip settings.
+func Standard() Settings {
+	return Settings{Presets: []int{10, 15, 20}, Default: StandardDefault}
+}
+
+// IsZero reports unset settings; restaurants fall back to Standard.
+func (s Settings) IsZero() bool {
+	return !s.Off && len(s.Presets) == 0 && s.Default == 0 && !s.Custom
+}
+
+// Enabled reports whether guests are offered any way to tip.
+func (s Settings) Enabled() bool {
+	return !s.Off && (len(s.Presets) > 0 || s.Custom)
+}
+
+// Percents lists the choices offered at checkout, starting with 0 for no
+// tip. With tipping off it is just 0.
+func (s Settings) Percents() []int {
+	out := []int{0}
+	if s.Off {
+		return out
+	}
+	return append(out, s.Presets...)
+}
+
+// Allows reports whether pct is a choice offered at checkout. No tip is
+// always allowed.
+func (s Settings) Allows(pct int) bool {
+	return slices.Contains(s.Percents(), pct)
+}
+
+// CheckCustom validates a custom tip of amount on an order whose subtotal
+// is subtotal. Custom tips are capped at the subtotal to catch typos.
+func (s Settings) CheckCustom(amount, subtotal int64) error {
+	if !s.Enabled() || !s.Custom {
+		return ErrNotAllowed
+	}
+	if amount <= 0 || amount > subtotal {
+		return ErrInvalidCustom
+	}
+	return nil
+}
+
+// Amounts is what each preset comes to on subtotal, for offering a tip as
+// an amount once the bill is known.
+func (s Settings) Amounts(subtotal int64) []int64 {
+	if s.Off {
+		return nil
+	}
+	out := make([]int64, len(s.Presets))
+	for i, p := range s.Presets {
+		out[i] = Amount(subtotal, p)
+	}
+	return out
+}
+
+// CheckAmount validates a tip given as an amount, as one added after
+// payment is: it must be what one of the presets comes to on subtotal, or
+// pass CheckCustom.
+func (s Settings) CheckAmount(amount, subtotal int64) error {
+	if s.Enabled() && amount > 0 && slices.Contains(s.Amounts(subtotal), amount) {
+		return nil
+	}
+	return s.CheckCustom(amount, subtotal)
+}
+
+// Normalize validates the settings, sorting the presets and dropping
+// duplicates. Turning tipping off clears everything else.
+func (s Settings) Normalize() (Settings, error) {
+	if s.Off {
+		return Settings{Off: true}, nil
+	}
+	presets := slices.Clone(s.Presets)
+	slices.Sort(presets)
+	presets = slices.Compact(presets)
+	if len(presets) > MaxPresets {
+		return Settings{}, ErrTooManyPresets
+	}
+	for _, p := range presets {
+		if p < 1 || p > MaxPercent {
+			return Settings{}, ErrInvalidPreset
+		}
+	}
+	s.Presets = presets
+	if s.Default != 0 && !slices.Contains(presets, s.Default) {
+		return Settings{}, ErrInvalidDefault
+	}
+	return s, nil
+}
+
+// Amount is pct percent of subtotal, rounded half up.
+func Amount(subtotal int64, pct int) int64 {
+	return (2*subtotal*int64(pct) + 100) / 200
+}
+
+// ParsePresets reads a list of percentages such as "10, 15, 20%". Blank is
+// no presets.
+func ParsePresets(raw string) ([]int, error) {
+	var out []int
+	for _, f := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(strings.TrimSuffix(f, "%"))
+		if err != nil {
+			return nil, ErrInvalidPreset
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// FormatPresets renders presets the way ParsePresets reads them.
+func FormatPresets(presets []int) string {
+	parts := make([]string, len(presets))
+	for i, p := range presets {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ", ")
+}
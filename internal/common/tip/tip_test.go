@@ -0,0 +1,78 @@
+package tip_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bitmerchant/internal/common/tip"
+)
+
+func TestNormalize(t *testing.T) {
+	s, err := tip.Settings{Presets: []int{20, 10, 15, 10}, Default: 15, Custom: true}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 15, 20}, s.Presets, "sorted and deduplicated")
+	assert.Equal(t, []int{0, 10, 15, 20}, s.Percents())
+	assert.True(t, s.Enabled())
+
+	off, err := tip.Settings{Off: true, Presets: []int{10}, Default: 10, Custom: true}.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, tip.Settings{Off: true}, off, "turning tips off clears the rest")
+	assert.False(t, off.Enabled())
+	assert.Equal(t, []int{0}, off.Percents())
+
+	customOnly, err := tip.Settings{Custom: true}.Normalize()
+	require.NoError(t, err)
+	assert.True(t, customOnly.Enabled())
+
+	_, err = tip.Settings{Presets: []int{0, 10}}.Normalize()
+	assert.ErrorIs(t, err, tip.ErrInvalidPreset)
+	_, err = tip.Settings{Presets: []int{51}}.Normalize()
+	assert.ErrorIs(t, err, tip.ErrInvalidPreset)
+	_, err = tip.Settings{Presets: []int{5, 10, 15, 20, 25}}.Normalize()
+	assert.ErrorIs(t, err, tip.ErrTooManyPresets)
+	_, err = tip.Settings{Presets: []int{10, 20}, Default: 15}.Normalize()
+	assert.ErrorIs(t, err, tip.ErrInvalidDefault)
+}
+
+func TestAllowsAndCheckCustom(t *testing.T) {
+	s := tip.Settings{Presets: []int{5, 10}, Default: 5}
+	assert.True(t, s.Allows(0), "no tip is always allowed")
+	assert.True(t, s.Allows(10))
+	assert.False(t, s.Allows(15))
+
+	assert.ErrorIs(t, s.CheckCustom(200, 1000), tip.ErrNotAllowed, "custom amounts need Custom")
+	s.Custom = true
+	assert.NoError(t, s.CheckCustom(200, 1000))
+	assert.ErrorIs(t, s.CheckCustom(0, 1000), tip.ErrInvalidCustom)
+	assert.ErrorIs(t, s.CheckCustom(1001, 1000), tip.ErrInvalidCustom, "capped at the subtotal")
+}
+
+func TestCheckAmount(t *testing.T) {
+	s := tip.Standard()
+	assert.Equal(t, []int64{124, 186, 248}, s.Amounts(1240), "15% of 12.40 is 1.86")
+	assert.NoError(t, s.CheckAmount(186, 1240))
+	assert.ErrorIs(t, s.CheckAmount(200, 1240), tip.ErrNotAllowed, "off-preset amounts need Custom")
+
+	s.Custom = true
+	assert.NoError(t, s.CheckAmount(200, 1240))
+	assert.ErrorIs(t, tip.Settings{Off: true}.CheckAmount(124, 1240), tip.ErrNotAllowed)
+}
+
+func TestAmountAndPresets(t *testing.T) {
+	assert.Equal(t, int64(150), tip.Amount(1000, 15))
+	assert.Equal(t, int64(2), tip.Amount(15, 10), "1.5 rounds half up")
+
+	presets, err := tip.ParsePresets(" 10, 15% 20 ")
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 15, 20}, presets)
+	assert.Equal(t, "10, 15, 20", tip.FormatPresets(presets))
+
+	none, err := tip.ParsePresets("")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	_, err = tip.ParsePresets("10, lots")
+	assert.ErrorIs(t, err, tip.ErrInvalidPreset)
+}
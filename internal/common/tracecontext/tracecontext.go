@@ -0,0 +1,68 @@
+// Package tracecontext starts and ends spans and carries W3C trace context
+// across process boundaries: in HTTP headers, message metadata and event
+// envelopes. It uses only the OpenTelemetry API, so the packages in common
+// can trace without depending on the exporters infrastructure/tracing sets
+// up; until that installs a tracer provider, spans are no-ops.
+package tracecontext
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// propagator reads and writes W3C trace context and baggage. It is used
+// directly, not through the global, so trace context is carried even when
+// this process does not export spans.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Propagator returns the propagator the helpers here use, for installing as
+// the global one.
+func Propagator() propagation.TextMapPropagator {
+	return propagator
+}
+
+// Tracer returns the named tracer from the global provider.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject writes the trace context of ctx into carrier.
+func Inject(ctx context.Context, carrier map[string]string) {
+	propagator.Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns ctx with the remote trace context read from carrier.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// ExtractHeaders returns ctx with the remote trace context read from the
+// traceparent and tracestate headers of an incoming request.
+func ExtractHeaders(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Carrier returns the trace context of ctx as a carrier map, or nil when ctx
+// has no span to continue.
+func Carrier(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := make(map[string]string, 2)
+	Inject(ctx, carrier)
+	return carrier
+}
@@ -0,0 +1,33 @@
+package tracecontext_test
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"bitmerchant/internal/common/tracecontext"
+)
+
+func TestCarrier_RoundTrips(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	carrier := tracecontext.Carrier(trace.ContextWithSpanContext(context.Background(), sc))
+	if carrier["traceparent"] == "" {
+		t.Fatalf("carrier has no traceparent: %v", carrier)
+	}
+
+	got := trace.SpanContextFromContext(tracecontext.Extract(context.Background(), carrier))
+	if got.TraceID() != sc.TraceID() || got.SpanID() != sc.SpanID() || !got.IsRemote() {
+		t.Fatalf("extracted %v, want remote %v", got, sc)
+	}
+}
+
+func TestCarrier_NilWithoutSpan(t *testing.T) {
+	if carrier := tracecontext.Carrier(context.Background()); carrier != nil {
+		t.Fatalf("carrier = %v, want nil", carrier)
+	}
+}
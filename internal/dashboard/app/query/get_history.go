@@ -10,7 +10,8 @@ import (
 	"log/slog"
 )
 
-// PaidOrdersForRestaurant lists paid orders for a restaurant (newest first).
+// PaidOrdersForRestaurant lists paid orders for a restaurant (newest first),
+// including paid orders that were later cancelled and refunded.
 type PaidOrdersForRestaurant struct {
 	RestaurantID common.RestaurantID
 }
@@ -37,7 +38,7 @@ func (h paidOrdersForRestaurantHandler) Handle(ctx context.Context, q PaidOrders
 	}
 	var paidOrders []*order.Order
 	for _, o := range orders {
-		if o.PaymentStatus != common.PaymentStatusPaid {
+		if o.PaymentStatus != common.PaymentStatusPaid && o.PaymentStatus != common.PaymentStatusRefunded {
 			continue
 		}
 		paidOrders = append(paidOrders, o)
@@ -26,7 +26,7 @@ func TestOrdersByHour_BucketsAndPeak(t *testing.T) {
 			RestaurantID:  "r1",
 			PaymentStatus: common.PaymentStatusPaid,
 			CreatedAt:     time.Date(2026, 5, 13, hour, minute, 0, 0, time.UTC),
-			FiatAmount:    10,
+			TotalAmount:   1000,
 		}
 	}
 	repo := &fakeOrderReadModel{orders: []*order.Order{
@@ -6,6 +6,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/ordering/domain/order"
 	"log/slog"
 )
@@ -20,24 +21,38 @@ const (
 
 // PeriodStats is the subset of metrics computed for a single window. Counts
 // are nil-safe — TotalSales / OrderCount / AvgPrepSeconds are zero when no
-// paid orders fell in the window.
+// paid orders fell in the window. TotalSales and AverageOrderValue are in
+// minor units of the orders' currency.
 type PeriodStats struct {
 	OrderCount        int
-	TotalSales        float64
-	AverageOrderValue float64
+	TotalSales        int64
+	AverageOrderValue int64
 	// AvgPrepSeconds is the mean (PreparingAt..ReadyAt) duration over orders
 	// that crossed both transitions inside the window. Zero when the window
 	// holds no fully-prepared orders.
 	AvgPrepSeconds float64
+	// SatsAtSale is what the window's paid orders were worth in sats, priced
+	// with the rates snapshotted when each was paid rather than today's rate.
+	SatsAtSale int64
+	// Discounts is what promotions took off the window's paid orders;
+	// DiscountedOrders counts the orders that had one.
+	Discounts        int64
+	DiscountedOrders int
 }
 
 // DashboardStats now bundles the active period plus the prior comparable
 // period so the template can render deltas without re-computing math.
 type DashboardStats struct {
 	OrderCount        int
-	TotalSales        float64
-	AverageOrderValue float64
+	TotalSales        int64
+	AverageOrderValue int64
 	AvgPrepSeconds    float64
+	SatsAtSale        int64
+	Discounts         int64
+	DiscountedOrders  int
+	// Currency is what TotalSales and AverageOrderValue are counted in,
+	// taken from the restaurant's orders. Zero when there are none.
+	Currency money.Currency
 	// Previous holds the same metrics over the prior comparable window
 	// (today vs yesterday, week vs the seven days before, month vs the
 	// preceding calendar month). Used only for delta display.
@@ -84,6 +99,10 @@ func (h restaurantDashboardStatsHandler) Handle(ctx context.Context, q Restauran
 		TotalSales:        cur.TotalSales,
 		AverageOrderValue: cur.AverageOrderValue,
 		AvgPrepSeconds:    cur.AvgPrepSeconds,
+		SatsAtSale:        cur.SatsAtSale,
+		Discounts:         cur.Discounts,
+		DiscountedOrders:  cur.DiscountedOrders,
+		Currency:          ordersCurrency(orders),
 		Previous:          prev,
 	}, nil
 }
@@ -95,16 +114,24 @@ func (h restaurantDashboardStatsHandler) Handle(ctx context.Context, q Restauran
 func computePeriodStats(orders []*order.Order, start, end time.Time) PeriodStats {
 	var (
 		count          int
-		totalSales     float64
+		totalSales     int64
 		prepSumSeconds float64
 		prepCount      int
+		sats           int64
+		discounts      int64
+		discounted     int
 	)
 	for _, o := range orders {
 		if !orderInWindow(o, start, end) {
 			continue
 		}
 		count++
-		totalSales += o.FiatAmount
+		totalSales += o.TotalAmount
+		sats += o.SatsAtSale().Amount
+		if o.DiscountAmount > 0 {
+			discounts += o.DiscountAmount
+			discounted++
+		}
 		if d, ok := orderPrepDuration(o); ok {
 			prepSumSeconds += d.Seconds()
 			prepCount++
@@ -113,8 +140,11 @@ func computePeriodStats(orders []*order.Order, start, end time.Time) PeriodStats
 	return PeriodStats{
 		OrderCount:        count,
 		TotalSales:        totalSales,
-		AverageOrderValue: safeMean(totalSales, count),
+		AverageOrderValue: safeMeanMinor(totalSales, count),
 		AvgPrepSeconds:    safeMean(prepSumSeconds, prepCount),
+		SatsAtSale:        sats,
+		Discounts:         discounts,
+		DiscountedOrders:  discounted,
 	}
 }
 
@@ -153,6 +183,28 @@ func safeMean(sum float64, n int) float64 {
 	return sum / float64(n)
 }
 
+// safeMeanMinor averages minor-unit amounts, rounding half away from zero.
+func safeMeanMinor(sum int64, n int) int64 {
+	if n <= 0 {
+		return 0
+	}
+	half := int64(n) / 2
+	if sum < 0 {
+		half = -half
+	}
+	return (sum + half) / int64(n)
+}
+
+// ordersCurrency returns the currency the restaurant's orders are priced in.
+func ordersCurrency(orders []*order.Order) money.Currency {
+	for _, o := range orders {
+		if !o.Currency.IsZero() {
+			return o.Currency
+		}
+	}
+	return money.Currency{}
+}
+
 // RangeWindow returns [start, end) for the active range. Today/week/month
 // match the existing semantics; end == now for live ranges so prior-period
 // math compares apples to apples (e.g. "today through noon" vs
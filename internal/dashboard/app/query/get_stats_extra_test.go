@@ -22,20 +22,20 @@ func TestRestaurantDashboardStats_AvgPrepAndPrevious(t *testing.T) {
 	yesterdayPrep := now.AddDate(0, 0, -1).Add(-30 * time.Minute)
 	yesterdayReady := yesterdayPrep.Add(12 * time.Minute) // 12 min prep
 
-	mkPaid := func(t time.Time, amount float64, prepStart, prepEnd *time.Time) *order.Order {
+	mkPaid := func(t time.Time, amount int64, prepStart, prepEnd *time.Time) *order.Order {
 		return &order.Order{
 			RestaurantID:  "r1",
 			PaymentStatus: common.PaymentStatusPaid,
 			CreatedAt:     t,
-			FiatAmount:    amount,
+			TotalAmount:   amount,
 			PreparingAt:   prepStart,
 			ReadyAt:       prepEnd,
 		}
 	}
 	repo := &fakeOrderReadModel{orders: []*order.Order{
-		mkPaid(now.Add(-1*time.Hour), 20, &preparing, &ready),                                // today, 8m prep
-		mkPaid(now.Add(-2*time.Hour), 10, nil, nil),                                          // today, no prep timestamps
-		mkPaid(now.AddDate(0, 0, -1).Add(-3*time.Hour), 30, &yesterdayPrep, &yesterdayReady), // yesterday, 12m prep
+		mkPaid(now.Add(-1*time.Hour), 2000, &preparing, &ready),                                // today, 8m prep
+		mkPaid(now.Add(-2*time.Hour), 1000, nil, nil),                                          // today, no prep timestamps
+		mkPaid(now.AddDate(0, 0, -1).Add(-3*time.Hour), 3000, &yesterdayPrep, &yesterdayReady), // yesterday, 12m prep
 	}}
 	h := restaurantDashboardStatsHandler{orders: repo, now: func() time.Time { return now }}
 	stats, err := h.Handle(context.Background(), RestaurantDashboardStats{RestaurantID: "r1", Range: DateRangeToday})
@@ -45,8 +45,11 @@ func TestRestaurantDashboardStats_AvgPrepAndPrevious(t *testing.T) {
 	if stats.OrderCount != 2 {
 		t.Fatalf("expected 2 today, got %d", stats.OrderCount)
 	}
-	if stats.TotalSales != 30 {
-		t.Fatalf("expected $30 today, got %.2f", stats.TotalSales)
+	if stats.TotalSales != 3000 {
+		t.Fatalf("expected $30 today, got %d minor units", stats.TotalSales)
+	}
+	if stats.AverageOrderValue != 1500 {
+		t.Fatalf("expected $15 average today, got %d minor units", stats.AverageOrderValue)
 	}
 	if stats.AvgPrepSeconds != (8 * 60) {
 		t.Fatalf("expected 480s avg prep today, got %.0f", stats.AvgPrepSeconds)
@@ -20,7 +20,7 @@ type TopItem struct {
 	MenuItemID common.ItemID
 	Name       string
 	Quantity   int
-	Revenue    float64
+	Revenue    int64 // minor units of the restaurant currency
 	PhotoURL   string
 	// RevenueShare is this item's revenue divided by the leader's revenue,
 	// in the range [0..1]. The renderer multiplies by the bar width.
@@ -59,7 +59,7 @@ type topItemBucket struct {
 	menuItemID common.ItemID
 	name       string
 	quantity   int
-	revenue    float64
+	revenue    int64
 }
 
 func (h topSellingMenuItemsHandler) Handle(ctx context.Context, q TopSellingMenuItems) ([]TopItem, error) {
@@ -125,7 +125,7 @@ func topItemsFromBuckets(buckets map[string]*topItemBucket) []TopItem {
 	if len(result) > 0 && result[0].Revenue > 0 {
 		leader := result[0].Revenue
 		for i := range result {
-			result[i].RevenueShare = result[i].Revenue / leader
+			result[i].RevenueShare = float64(result[i].Revenue) / float64(leader)
 		}
 	}
 	return result
@@ -0,0 +1,233 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tip"
+	"bitmerchant/internal/ordering/domain/order"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/timecard"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+)
+
+// PaymentReadModel is the read-side dependency for who collected an
+// order's payment.
+type PaymentReadModel interface {
+	FindChargesByOrderID(orderID common.OrderID) ([]*payment.Payment, error)
+}
+
+// TimecardReadModel is the read-side dependency for staff hours.
+type TimecardReadModel interface {
+	FindByRestaurant(restaurantID common.RestaurantID, from, to time.Time) ([]*timecard.Timecard, error)
+}
+
+// RestaurantReadModel is the read-side dependency for restaurant settings.
+type RestaurantReadModel interface {
+	FindByID(id common.RestaurantID) (*restaurant.Restaurant, error)
+}
+
+// TipOutLine is one staff member's part in a period's tips: what they
+// collected when marking orders paid, their time on the clock and what the
+// tip pools pay them. A staff member who worked under two roles has a line
+// for each; Collected sits on the first.
+type TipOutLine struct {
+	StaffID   common.UserID
+	Role      common.MemberRole
+	Pool      string
+	Minutes   int64
+	Collected int64
+	TipOut    int64
+}
+
+// TipOutView is the tip-out report for [From, To). Total is every tip on
+// orders paid in the period, in Currency: Online is the part guests paid
+// by Lightning with no staff member collecting it. Unallocated is what
+// nobody is paid: the shares of pools nobody in them clocked in for, or,
+// without pooling, the online tips.
+type TipOutView struct {
+	From        time.Time
+	To          time.Time
+	Currency    money.Currency
+	Pooling     tip.Pooling
+	Total       int64
+	Online      int64
+	Lines       []TipOutLine
+	Unallocated int64
+}
+
+// Money wraps a minor-unit amount in the report currency for display.
+func (v TipOutView) Money(amount int64) money.Money {
+	return money.New(amount, v.Currency)
+}
+
+// TipOutReport shares out a restaurant's tips for a period. Tips count on
+// orders created in [From, To) that are paid and not refunded; orders in
+// a currency other than the restaurant's are left out. A checkout tip is
+// credited to whoever settled the order, and tips added after paying go
+// to Online.
+type TipOutReport struct {
+	RestaurantID common.RestaurantID
+	From         time.Time
+	To           time.Time
+}
+
+type TipOutReportHandler decorator.QueryHandler[TipOutReport, *TipOutView]
+
+type tipOutReportHandler struct {
+	orders      OrderReadModel
+	payments    PaymentReadModel
+	timecards   TimecardReadModel
+	restaurants RestaurantReadModel
+	now         func() time.Time
+}
+
+func NewTipOutReportHandler(orders OrderReadModel, payments PaymentReadModel, timecards TimecardReadModel, restaurants RestaurantReadModel, log *slog.Logger, metrics decorator.MetricsClient) TipOutReportHandler {
+	if orders == nil {
+		panic("nil OrderReadModel")
+	}
+	if payments == nil {
+		panic("nil PaymentReadModel")
+	}
+	if timecards == nil {
+		panic("nil TimecardReadModel")
+	}
+	if restaurants == nil {
+		panic("nil RestaurantReadModel")
+	}
+	h := tipOutReportHandler{orders: orders, payments: payments, timecards: timecards, restaurants: restaurants, now: time.Now}
+	return decorator.ApplyQueryDecorators[TipOutReport, *TipOutView](h, log, metrics)
+}
+
+func (h tipOutReportHandler) Handle(ctx context.Context, q TipOutReport) (*TipOutView, error) {
+	_ = ctx
+	rest, err := h.restaurants.FindByID(q.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := h.orders.FindByRestaurantID(q.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := h.timecards.FindByRestaurant(q.RestaurantID, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &TipOutView{From: q.From, To: q.To, Currency: rest.BaseCurrency, Pooling: rest.TipPooling}
+	collected := map[common.UserID]int64{}
+	var collectors []common.UserID
+	for _, o := range orders {
+		if o.TipAmount <= 0 || !orderInWindow(o, q.From, q.To) || o.Total().Currency.Code != rest.BaseCurrency.Code {
+			continue
+		}
+		view.Total += o.TipAmount
+		view.Online += o.LateTipAmount
+		atCheckout := o.TipAmount - o.LateTipAmount
+		if atCheckout <= 0 {
+			continue
+		}
+		by, err := h.settledBy(o)
+		if err != nil {
+			return nil, err
+		}
+		if by == "" {
+			view.Online += atCheckout
+			continue
+		}
+		if _, ok := collected[by]; !ok {
+			collectors = append(collectors, by)
+		}
+		collected[by] += atCheckout
+	}
+
+	now := h.now()
+	var worked []tip.Worked
+	for _, t := range cards {
+		if m := int64(t.Worked(q.From, q.To, now) / time.Minute); m > 0 {
+			worked = append(worked, tip.Worked{StaffID: t.StaffID, Role: t.Role, Minutes: m})
+		}
+	}
+
+	if rest.TipPooling.Enabled() {
+		allocs, unallocated := rest.TipPooling.Distribute(view.Total, worked)
+		view.Unallocated = unallocated
+		for _, a := range allocs {
+			view.Lines = append(view.Lines, TipOutLine{StaffID: a.StaffID, Role: a.Role, Pool: a.Pool, Minutes: a.Minutes, TipOut: a.Amount})
+		}
+	} else {
+		view.Unallocated = view.Online
+		for _, by := range collectors {
+			view.Lines = append(view.Lines, TipOutLine{StaffID: by, TipOut: collected[by]})
+		}
+		for _, w := range worked {
+			view.Lines = addMinutes(view.Lines, w)
+		}
+	}
+	for _, by := range collectors {
+		view.Lines = addCollected(view.Lines, by, collected[by])
+	}
+	// Pools in the order the restaurant set them up, staff outside any
+	// pool last; biggest tip-out first within each.
+	rank := func(pool string) int {
+		for i, p := range rest.TipPooling.Pools {
+			if p.Name == pool {
+				return i
+			}
+		}
+		return len(rest.TipPooling.Pools)
+	}
+	sort.SliceStable(view.Lines, func(i, j int) bool {
+		if ri, rj := rank(view.Lines[i].Pool), rank(view.Lines[j].Pool); ri != rj {
+			return ri < rj
+		}
+		return view.Lines[i].TipOut > view.Lines[j].TipOut
+	})
+	return view, nil
+}
+
+// settledBy is the staff member who took the payment that settled o; ""
+// when the guest paid online.
+func (h tipOutReportHandler) settledBy(o *order.Order) (common.UserID, error) {
+	charges, err := h.payments.FindChargesByOrderID(o.ID)
+	if err != nil {
+		return "", err
+	}
+	for i := len(charges) - 1; i >= 0; i-- {
+		p := charges[i]
+		if !p.IsTip() && p.Status == common.PaymentStatusPaid {
+			return p.CollectedBy, nil
+		}
+	}
+	return "", nil
+}
+
+// addMinutes puts w's time on the staff member's line, filling in the role
+// when the line came from collected tips alone.
+func addMinutes(lines []TipOutLine, w tip.Worked) []TipOutLine {
+	for i := range lines {
+		if lines[i].StaffID == w.StaffID && (lines[i].Role == w.Role || lines[i].Role == "") {
+			lines[i].Role = w.Role
+			lines[i].Minutes += w.Minutes
+			return lines
+		}
+	}
+	return append(lines, TipOutLine{StaffID: w.StaffID, Role: w.Role, Minutes: w.Minutes})
+}
+
+// addCollected records what staffID collected on their first line, adding
+// one when they are not paid from any pool.
+func addCollected(lines []TipOutLine, staffID common.UserID, amount int64) []TipOutLine {
+	for i := range lines {
+		if lines[i].StaffID == staffID {
+			lines[i].Collected += amount
+			return lines
+		}
+	}
+	return append(lines, TipOutLine{StaffID: staffID, Collected: amount})
+}
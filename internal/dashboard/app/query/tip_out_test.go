@@ -0,0 +1,110 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tip"
+	"bitmerchant/internal/ordering/domain/order"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/timecard"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePaymentReadModel map[common.OrderID][]*payment.Payment
+
+func (f fakePaymentReadModel) FindChargesByOrderID(orderID common.OrderID) ([]*payment.Payment, error) {
+	return f[orderID], nil
+}
+
+type fakeTimecardReadModel []*timecard.Timecard
+
+func (f fakeTimecardReadModel) FindByRestaurant(_ common.RestaurantID, _, _ time.Time) ([]*timecard.Timecard, error) {
+	return f, nil
+}
+
+type fakeRestaurantReadModel struct{ rest *restaurant.Restaurant }
+
+func (f fakeRestaurantReadModel) FindByID(_ common.RestaurantID) (*restaurant.Restaurant, error) {
+	return f.rest, nil
+}
+
+func tipOutFixture(pooling tip.Pooling) (tipOutReportHandler, TipOutReport) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	paidOrder := func(id common.OrderID, tipAmount, lateTip int64, cur money.Currency) *order.Order {
+		return &order.Order{
+			ID: id, RestaurantID: "r1", PaymentStatus: common.PaymentStatusPaid, CreatedAt: day.Add(12 * time.Hour),
+			TotalAmount: 2000 + tipAmount, TipAmount: tipAmount, LateTipAmount: lateTip, Currency: cur,
+		}
+	}
+	pending := paidOrder("o4", 100, 0, money.USD)
+	pending.PaymentStatus = common.PaymentStatusPending
+	orders := &fakeOrderReadModel{orders: []*order.Order{
+		paidOrder("o1", 300, 0, money.USD),
+		paidOrder("o2", 200, 200, money.USD),
+		paidOrder("o3", 500, 0, money.USD),
+		pending,
+		paidOrder("o5", 900, 0, money.THB),
+	}}
+	charge := func(by common.UserID) *payment.Payment {
+		return &payment.Payment{Kind: payment.KindCharge, Status: common.PaymentStatusPaid, CollectedBy: by}
+	}
+	payments := fakePaymentReadModel{
+		"o1": {charge("s1")},
+		"o2": {charge("s2"), {Kind: payment.KindTip, Status: common.PaymentStatusPaid}},
+		"o3": {charge("")},
+	}
+	shift := func(staff common.UserID, role common.MemberRole, hours int) *timecard.Timecard {
+		out := day.Add(time.Duration(8+hours) * time.Hour)
+		return &timecard.Timecard{StaffID: staff, Role: role, ClockedInAt: day.Add(8 * time.Hour), ClockedOutAt: &out}
+	}
+	cards := fakeTimecardReadModel{
+		shift("s1", common.RoleServer, 4),
+		shift("s2", common.RoleServer, 2),
+		shift("k1", common.RoleKitchenStaff, 5),
+	}
+	rest := &restaurant.Restaurant{ID: "r1", BaseCurrency: money.USD, TipPooling: pooling}
+	h := tipOutReportHandler{
+		orders: orders, payments: payments, timecards: cards, restaurants: fakeRestaurantReadModel{rest},
+		now: func() time.Time { return day.AddDate(0, 0, 2) },
+	}
+	return h, TipOutReport{RestaurantID: "r1", From: day, To: day.AddDate(0, 0, 1)}
+}
+
+func TestTipOutReport_WithoutPoolingStaffKeepWhatTheyCollect(t *testing.T) {
+	h, q := tipOutFixture(tip.Pooling{})
+	view, err := h.Handle(context.Background(), q)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1000), view.Total, "pending and foreign-currency orders are left out")
+	assert.Equal(t, int64(700), view.Online, "late tips and online payments have no collector")
+	assert.Equal(t, int64(700), view.Unallocated)
+	require.Len(t, view.Lines, 3)
+	assert.Equal(t, TipOutLine{StaffID: "s1", Role: common.RoleServer, Minutes: 240, Collected: 300, TipOut: 300}, view.Lines[0])
+	assert.Equal(t, TipOutLine{StaffID: "s2", Role: common.RoleServer, Minutes: 120}, view.Lines[1])
+	assert.Equal(t, TipOutLine{StaffID: "k1", Role: common.RoleKitchenStaff, Minutes: 300}, view.Lines[2])
+}
+
+func TestTipOutReport_PoolsShareEveryTip(t *testing.T) {
+	h, q := tipOutFixture(tip.Pooling{
+		Pools: []tip.Pool{
+			{Name: "Front of house", Share: 60, Roles: []common.MemberRole{common.RoleServer}},
+			{Name: "Kitchen", Share: 40, Roles: []common.MemberRole{common.RoleKitchenStaff}},
+		},
+		Split: tip.SplitHours,
+	})
+	view, err := h.Handle(context.Background(), q)
+	require.NoError(t, err)
+
+	assert.Zero(t, view.Unallocated)
+	require.Len(t, view.Lines, 3)
+	assert.Equal(t, TipOutLine{StaffID: "s1", Role: common.RoleServer, Pool: "Front of house", Minutes: 240, Collected: 300, TipOut: 400}, view.Lines[0])
+	assert.Equal(t, TipOutLine{StaffID: "s2", Role: common.RoleServer, Pool: "Front of house", Minutes: 120, TipOut: 200}, view.Lines[1])
+	assert.Equal(t, TipOutLine{StaffID: "k1", Role: common.RoleKitchenStaff, Pool: "Kitchen", Minutes: 300, TipOut: 400}, view.Lines[2])
+}
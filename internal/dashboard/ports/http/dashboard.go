@@ -6,6 +6,7 @@ import (
 	dashboard "bitmerchant/internal/dashboard/app/query"
 
 	"bitmerchant/internal/interfaces/templates"
+	orderCmd "bitmerchant/internal/ordering/app/command"
 	"bitmerchant/internal/ordering/domain/order"
 	restaurantCmd "bitmerchant/internal/restaurant/app/command"
 	"bitmerchant/internal/restaurant/domain/restaurant"
@@ -26,6 +27,7 @@ type DashboardHandler struct {
 	getByHourUC    dashboard.OrdersByHourHandler
 	toggleOpenUC   restaurantCmd.ToggleRestaurantOpenHandler
 	pauseUC        restaurantCmd.PauseRestaurantHandler
+	cancelOrderUC  orderCmd.CancelOrderHandler
 	restaurantRepo restaurant.Repository
 	orderRepo      order.Repository
 	membershipRepo membership.Repository
@@ -61,6 +63,7 @@ func NewDashboardHandler(
 	getByHourUC dashboard.OrdersByHourHandler,
 	toggleOpenUC restaurantCmd.ToggleRestaurantOpenHandler,
 	pauseUC restaurantCmd.PauseRestaurantHandler,
+	cancelOrderUC orderCmd.CancelOrderHandler,
 	restaurantRepo restaurant.Repository,
 	orderRepo order.Repository,
 	membershipRepo membership.Repository,
@@ -77,6 +80,7 @@ func NewDashboardHandler(
 		getByHourUC:    getByHourUC,
 		toggleOpenUC:   toggleOpenUC,
 		pauseUC:        pauseUC,
+		cancelOrderUC:  cancelOrderUC,
 		restaurantRepo: restaurantRepo,
 		orderRepo:      orderRepo,
 		membershipRepo: membershipRepo,
@@ -271,7 +275,43 @@ func (h *DashboardHandler) OrderDetail(c echo.Context) error {
 	if sErr != nil {
 		return c.String(http.StatusInternalServerError, "Failed to load navigation")
 	}
-	return templates.DashboardOrderDetail(o, rest, label, dn, st, ini, commonhttp.CSRFToken(c), switchOpts, activeRole, canCreate).Render(c.Request().Context(), c.Response())
+	return templates.DashboardOrderDetail(o, rest, label, dn, st, ini, commonhttp.CSRFToken(c), switchOpts, activeRole, canCreate, c.QueryParam("error")).Render(c.Request().Context(), c.Response())
+}
+
+// CancelOrder handles POST /dashboard/orders/:orderNumber/cancel: the owner
+// voids an unpaid order or cancels and refunds a paid one, then lands back
+// on the order detail page.
+func (h *DashboardHandler) CancelOrder(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	orderNumber := c.Param("orderNumber")
+	o, err := h.orderRepo.FindByOrderNumber(restaurantID, orderNumber)
+	if err != nil {
+		if err.Error() == "order not found" {
+			return c.String(http.StatusNotFound, "Order not found")
+		}
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	detailURL := "/dashboard/orders/" + url.PathEscape(orderNumber)
+	reason, err := order.ParseCancelReason(c.FormValue("reason"))
+	if err == nil {
+		cmd := orderCmd.CancelOrder{
+			OrderID:      o.ID,
+			RestaurantID: restaurantID,
+			Reason:       reason,
+			Note:         c.FormValue("note"),
+		}
+		if u, ok := commonhttp.GetAuthenticatedUser(c); ok && u != nil {
+			cmd.CancelledBy = u.ID
+		}
+		_, err = h.cancelOrderUC.Handle(c.Request().Context(), cmd)
+	}
+	if err != nil {
+		return c.Redirect(http.StatusFound, detailURL+"?error="+url.QueryEscape(err.Error()))
+	}
+	return c.Redirect(http.StatusFound, detailURL)
 }
 
 // Pause applies a quick-pause window (15/30/60 minutes are typical). A
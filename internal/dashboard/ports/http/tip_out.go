@@ -0,0 +1,179 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bitmerchant/internal/auth/domain/membership"
+	"bitmerchant/internal/auth/domain/user"
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	dashboard "bitmerchant/internal/dashboard/app/query"
+	"bitmerchant/internal/interfaces/templates"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+)
+
+// tipOutDateLayout is how the report period travels in ?from= and ?to=.
+const tipOutDateLayout = "2006-01-02"
+
+// maxTipOutDays caps the report period.
+const maxTipOutDays = 93
+
+var errInvalidTipOutDate = errors.New("enter dates as YYYY-MM-DD")
+
+// TipOutHandler renders the owner's tip-out report under /dashboard/tips
+// and exports it as CSV for payroll.
+type TipOutHandler struct {
+	reportUC       dashboard.TipOutReportHandler
+	restaurantRepo restaurant.Repository
+	membershipRepo membership.Repository
+	userRepo       user.Repository
+	now            func() time.Time
+}
+
+func NewTipOutHandler(
+	reportUC dashboard.TipOutReportHandler,
+	restaurantRepo restaurant.Repository,
+	membershipRepo membership.Repository,
+	userRepo user.Repository,
+) *TipOutHandler {
+	return &TipOutHandler{
+		reportUC:       reportUC,
+		restaurantRepo: restaurantRepo,
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+		now:            time.Now,
+	}
+}
+
+// GetTipOut handles GET /dashboard/tips[?from=YYYY-MM-DD&to=YYYY-MM-DD].
+// The period covers both days in full and defaults to the last seven days.
+func (h *TipOutHandler) GetTipOut(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	from, to, err := h.period(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	ctx := c.Request().Context()
+	report, err := h.reportUC.Handle(ctx, dashboard.TipOutReport{RestaurantID: restaurantID, From: from, To: to})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load tips: "+err.Error())
+	}
+
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(ctx, restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	return templates.TipOutPage(templates.TipOutPageView{
+		Report:      report,
+		StaffNames:  h.staffNames(restaurantID),
+		FromValue:   from.Format(tipOutDateLayout),
+		ToValue:     to.AddDate(0, 0, -1).Format(tipOutDateLayout),
+		CSRFToken:   commonhttp.CSRFToken(c),
+		ActiveLabel: label,
+		DisplayName: dn,
+		Subtitle:    st,
+		Initials:    ini,
+		Switcher:    switchOpts,
+		ActiveRole:  activeRole,
+		CanCreate:   canCreate,
+	}).Render(ctx, c.Response())
+}
+
+// GetTipOutCSV handles GET /dashboard/tips/export, the same period as
+// GetTipOut as one CSV row per report line. Amounts are in major units of
+// the report currency.
+func (h *TipOutHandler) GetTipOutCSV(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	from, to, err := h.period(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	report, err := h.reportUC.Handle(c.Request().Context(), dashboard.TipOutReport{RestaurantID: restaurantID, From: from, To: to})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load tips: "+err.Error())
+	}
+	names := h.staffNames(restaurantID)
+
+	filename := fmt.Sprintf("tips-%s-to-%s.csv", from.Format(tipOutDateLayout), to.AddDate(0, 0, -1).Format(tipOutDateLayout))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	_ = w.Write([]string{"staff", "staff_id", "role", "pool", "hours", "tips_collected", "tip_out", "currency"})
+	for _, l := range report.Lines {
+		name := names[l.StaffID]
+		if name == "" {
+			name = string(l.StaffID)
+		}
+		_ = w.Write([]string{
+			name,
+			string(l.StaffID),
+			string(l.Role),
+			l.Pool,
+			strconv.FormatFloat(float64(l.Minutes)/60, 'f', 2, 64),
+			report.Money(l.Collected).FormatNoSymbol(),
+			report.Money(l.TipOut).FormatNoSymbol(),
+			report.Currency.Code,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// period reads ?from= and ?to= as whole days in the server's time zone and
+// returns [from, day after to).
+func (h *TipOutHandler) period(c echo.Context) (time.Time, time.Time, error) {
+	now := h.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	from, to := today.AddDate(0, 0, -6), today
+	var err error
+	if raw := c.QueryParam("from"); raw != "" {
+		if from, err = time.ParseInLocation(tipOutDateLayout, raw, now.Location()); err != nil {
+			return time.Time{}, time.Time{}, errInvalidTipOutDate
+		}
+	}
+	if raw := c.QueryParam("to"); raw != "" {
+		if to, err = time.ParseInLocation(tipOutDateLayout, raw, now.Location()); err != nil {
+			return time.Time{}, time.Time{}, errInvalidTipOutDate
+		}
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("the period must end on or after its first day")
+	}
+	if to.Sub(from) > maxTipOutDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("the period can be at most %d days", maxTipOutDays)
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+// staffNames resolves display names for the restaurant's members. Lookup
+// failures fall back to showing raw IDs rather than failing the report.
+func (h *TipOutHandler) staffNames(restaurantID common.RestaurantID) map[common.UserID]string {
+	names := map[common.UserID]string{}
+	members, err := h.membershipRepo.FindByRestaurantID(restaurantID)
+	if err != nil {
+		return names
+	}
+	for _, m := range members {
+		if u, err := h.userRepo.FindByID(m.UserID); err == nil && u.DisplayName != "" {
+			names[m.UserID] = u.DisplayName
+		}
+	}
+	return names
+}
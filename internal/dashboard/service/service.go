@@ -3,10 +3,12 @@ package service
 import (
 	"log/slog"
 
+	"bitmerchant/internal/common/decorator"
 	dashboardQuery "bitmerchant/internal/dashboard/app/query"
 	dashboardhttp "bitmerchant/internal/dashboard/ports/http"
 	menuQuery "bitmerchant/internal/menu/app/query"
 	"bitmerchant/internal/menu/domain/menu"
+	orderCmd "bitmerchant/internal/ordering/app/command"
 	restaurantCmd "bitmerchant/internal/restaurant/app/command"
 	"bitmerchant/internal/wiring"
 )
@@ -18,20 +20,25 @@ type Dashboard struct {
 	GetTopItems dashboardQuery.TopSellingMenuItemsHandler
 	GetStalled  dashboardQuery.StalledOrdersHandler
 	GetByHour   dashboardQuery.OrdersByHourHandler
+	GetTipOut   dashboardQuery.TipOutReportHandler
 	HTTP        *dashboardhttp.DashboardHandler
+	TipOut      *dashboardhttp.TipOutHandler
 }
 
-// New wires dashboard queries and HTTP port. toggleOpen and pause must be the
-// same handler instances used in service.Application.Commands. photoStorage +
-// cfg presign the top-items thumbnails the same way the menu page does — pass
-// nil + zero for in-memory tests.
+// New wires dashboard queries and HTTP port. toggleOpen, pause and
+// cancelOrder must be the same handler instances used in
+// service.Application.Commands. photoStorage + cfg presign the top-items
+// thumbnails the same way the menu page does — pass nil + zero for
+// in-memory tests.
 func New(
 	repos wiring.Repositories,
 	toggleOpen restaurantCmd.ToggleRestaurantOpenHandler,
 	pause restaurantCmd.PauseRestaurantHandler,
+	cancelOrder orderCmd.CancelOrderHandler,
 	photoStorage menu.PhotoStorage,
 	cfg wiring.Config,
 	logger *slog.Logger,
+	metrics decorator.MetricsClient,
 ) Dashboard {
 	if logger == nil {
 		logger = slog.Default()
@@ -41,17 +48,20 @@ func New(
 		Endpoint:      cfg.S3Endpoint,
 		PublicBaseURL: cfg.S3PublicBaseURL,
 	}
-	getStatsUC := dashboardQuery.NewRestaurantDashboardStatsHandler(repos.Order, nil, nil)
-	getHistoryUC := dashboardQuery.NewPaidOrdersForRestaurantHandler(repos.Order, nil, nil)
-	getTopItemsUC := dashboardQuery.NewTopSellingMenuItemsHandler(repos.Order, repos.MenuItem, photoStorage, photoCfg, nil, nil)
-	getStalledUC := dashboardQuery.NewStalledOrdersHandler(repos.Order, nil, nil)
-	getByHourUC := dashboardQuery.NewOrdersByHourHandler(repos.Order, nil, nil)
+	getStatsUC := dashboardQuery.NewRestaurantDashboardStatsHandler(repos.Order, nil, metrics)
+	getHistoryUC := dashboardQuery.NewPaidOrdersForRestaurantHandler(repos.Order, nil, metrics)
+	getTopItemsUC := dashboardQuery.NewTopSellingMenuItemsHandler(repos.Order, repos.MenuItem, photoStorage, photoCfg, nil, metrics)
+	getStalledUC := dashboardQuery.NewStalledOrdersHandler(repos.Order, nil, metrics)
+	getByHourUC := dashboardQuery.NewOrdersByHourHandler(repos.Order, nil, metrics)
+	getTipOutUC := dashboardQuery.NewTipOutReportHandler(repos.Order, repos.Payment, repos.Timecard, repos.Restaurant, nil, metrics)
 	return Dashboard{
 		GetStats:    getStatsUC,
 		GetHistory:  getHistoryUC,
 		GetTopItems: getTopItemsUC,
 		GetStalled:  getStalledUC,
 		GetByHour:   getByHourUC,
-		HTTP:        dashboardhttp.NewDashboardHandler(getStatsUC, getHistoryUC, getTopItemsUC, getStalledUC, getByHourUC, toggleOpen, pause, repos.Restaurant, repos.Order, repos.Membership, logger),
+		GetTipOut:   getTipOutUC,
+		HTTP:        dashboardhttp.NewDashboardHandler(getStatsUC, getHistoryUC, getTopItemsUC, getStalledUC, getByHourUC, toggleOpen, pause, cancelOrder, repos.Restaurant, repos.Order, repos.Membership, logger),
+		TipOut:      dashboardhttp.NewTipOutHandler(getTipOutUC, repos.Restaurant, repos.Membership, repos.User),
 	}
 }
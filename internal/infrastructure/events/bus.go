@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,16 +12,25 @@ import (
 	"sync"
 	"time"
 
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/tracecontext"
+
 	"github.com/ThreeDotsLabs/watermill"
 	watermillnats "github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+	watermillsql "github.com/ThreeDotsLabs/watermill-sql/v3/pkg/sql"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
 	natsgo "github.com/nats-io/nats.go"
 )
 
 const (
-	backendMemory = "memory"
-	backendNATS   = "nats"
+	backendMemory   = "memory"
+	backendNATS     = "nats"
+	backendPostgres = "postgres"
+
+	// postgresPollInterval is how long an idle Postgres subscriber waits
+	// before looking for new rows again.
+	postgresPollInterval = 250 * time.Millisecond
 )
 
 var invalidInstanceIDChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
@@ -41,6 +51,8 @@ func (c natsConnectionCloser) Close() error {
 }
 
 // Config controls event bus backend selection and backend-specific settings.
+// The postgres backend reuses NATSInstanceID to name its consumer groups and
+// NATSAckWait as the ack deadline.
 type Config struct {
 	Backend           string
 	NATSURL           string
@@ -49,6 +61,13 @@ type Config struct {
 	NATSCloseTimeout  time.Duration
 	NATSSubscribers   int
 	NATSInstanceID    string
+
+	// DB is the database the postgres backend stores messages in. The bus
+	// does not close it.
+	DB *sql.DB
+
+	// Metrics counts published events and handler outcomes. Optional.
+	Metrics Metrics
 }
 
 func (c Config) withDefaults() Config {
@@ -76,6 +95,9 @@ func (c Config) withDefaults() Config {
 	if strings.TrimSpace(c.NATSInstanceID) == "" {
 		c.NATSInstanceID = "bitmerchant"
 	}
+	if c.Metrics == nil {
+		c.Metrics = NoopMetrics{}
+	}
 	return c
 }
 
@@ -97,6 +119,21 @@ type EventBus struct {
 	newGroupSubscriber func(group string) (message.Subscriber, error)
 	groupSubscribers   map[string]message.Subscriber
 	groupMu            sync.Mutex
+
+	// newSharedSubscriber is newGroupSubscriber without the instance
+	// prefix: replicas join one queue group and compete for messages.
+	newSharedSubscriber func(group string) (message.Subscriber, error)
+
+	// sseRelay carries SSE frames between replicas. nil for in-memory
+	// backend, where there is only ever one replica.
+	sseRelay *SSERelay
+
+	// db holds the postgres backend's messages; nil on the other backends.
+	db *sql.DB
+
+	metrics Metrics
+	// backend names the messaging system on spans.
+	backend string
 }
 
 // NewEventBus creates a default in-memory event bus.
@@ -111,7 +148,16 @@ func NewEventBus() *EventBus {
 // NewEventBusWithConfig creates an event bus using the configured backend.
 func NewEventBusWithConfig(cfg Config) (*EventBus, error) {
 	cfg = cfg.withDefaults()
+	bus, err := newEventBus(cfg)
+	if err != nil {
+		return nil, err
+	}
+	bus.metrics = cfg.Metrics
+	bus.backend = cfg.Backend
+	return bus, nil
+}
 
+func newEventBus(cfg Config) (*EventBus, error) {
 	switch cfg.Backend {
 	case backendMemory:
 		logger := watermill.NewStdLogger(false, false)
@@ -119,11 +165,12 @@ func NewEventBusWithConfig(cfg Config) (*EventBus, error) {
 		// gochannel already broadcasts every message to every subscriber, so
 		// "consumer groups" are a no-op — return the same pubsub for any group.
 		return &EventBus{
-			publisher:          pubSub,
-			subscriber:         pubSub,
-			closers:            uniqueClosers(pubSub),
-			groupSubscribers:   make(map[string]message.Subscriber),
-			newGroupSubscriber: func(string) (message.Subscriber, error) { return pubSub, nil },
+			publisher:           pubSub,
+			subscriber:          pubSub,
+			closers:             uniqueClosers(pubSub),
+			groupSubscribers:    make(map[string]message.Subscriber),
+			newGroupSubscriber:  func(string) (message.Subscriber, error) { return pubSub, nil },
+			newSharedSubscriber: func(string) (message.Subscriber, error) { return pubSub, nil },
 		}, nil
 
 	case backendNATS:
@@ -186,17 +233,27 @@ func NewEventBusWithConfig(cfg Config) (*EventBus, error) {
 			provisionCloser = natsConnectionCloser{conn: provisionConn}
 		}
 
+		sseRelay, err := newSSERelay(cfg.NATSURL)
+		if err != nil {
+			if provisionCloser != nil {
+				_ = provisionCloser.Close()
+			}
+			_ = subscriber.Close()
+			_ = publisher.Close()
+			return nil, err
+		}
+
 		bus := &EventBus{
 			publisher:           publisher,
 			subscriber:          subscriber,
 			autoProvisionTopics: cfg.NATSAutoProvision,
 			jetstreamManager:    jsManager,
 			provisionedTopics:   make(map[string]struct{}),
-			closers:             uniqueClosers(subscriber, publisher, provisionCloser),
+			closers:             uniqueClosers(subscriber, publisher, provisionCloser, sseRelay),
 			groupSubscribers:    make(map[string]message.Subscriber),
+			sseRelay:            sseRelay,
 		}
-		bus.newGroupSubscriber = func(group string) (message.Subscriber, error) {
-			groupPrefix := prefix + "_" + sanitizeInstanceID(group)
+		newSubscriber := func(groupPrefix string) (message.Subscriber, error) {
 			groupJetStreamCfg := jetStreamCfg
 			groupJetStreamCfg.DurablePrefix = groupPrefix
 			return watermillnats.NewSubscriber(watermillnats.SubscriberConfig{
@@ -208,13 +265,76 @@ func NewEventBusWithConfig(cfg Config) (*EventBus, error) {
 				JetStream:        groupJetStreamCfg,
 			}, wmLogger)
 		}
+		bus.newGroupSubscriber = func(group string) (message.Subscriber, error) {
+			return newSubscriber(prefix + "_" + sanitizeInstanceID(group))
+		}
+		bus.newSharedSubscriber = func(group string) (message.Subscriber, error) {
+			return newSubscriber("bitmerchant_shared_" + sanitizeInstanceID(group))
+		}
 		return bus, nil
 
+	case backendPostgres:
+		return newPostgresEventBus(cfg)
+
 	default:
 		return nil, errors.New("unsupported event backend: " + cfg.Backend)
 	}
 }
 
+// newPostgresEventBus keeps messages in the application database, one table
+// per topic, through Watermill's SQL pub/sub. Consumer groups track their own
+// offsets, so groups are named exactly like the NATS durables: per instance
+// for SubscriberForGroup, cluster-wide for SharedSubscriberForGroup. A new
+// group starts at the newest message, and Purge keeps the tables from
+// growing forever. There is no SSE relay; each replica serves its own SSE
+// clients.
+func newPostgresEventBus(cfg Config) (*EventBus, error) {
+	if cfg.DB == nil {
+		return nil, errors.New("postgres event backend needs a database")
+	}
+	wmLogger := watermill.NewStdLogger(false, false)
+	prefix := "bitmerchant_" + sanitizeInstanceID(cfg.NATSInstanceID)
+	ackDeadline := cfg.NATSAckWait
+
+	publisher, err := watermillsql.NewPublisher(cfg.DB, watermillsql.PublisherConfig{
+		SchemaAdapter:        watermillsql.DefaultPostgreSQLSchema{},
+		AutoInitializeSchema: true,
+	}, wmLogger)
+	if err != nil {
+		return nil, err
+	}
+	newSubscriber := func(consumerGroup string) (message.Subscriber, error) {
+		return watermillsql.NewSubscriber(cfg.DB, watermillsql.SubscriberConfig{
+			ConsumerGroup:    consumerGroup,
+			AckDeadline:      &ackDeadline,
+			PollInterval:     postgresPollInterval,
+			SchemaAdapter:    watermillsql.DefaultPostgreSQLSchema{},
+			OffsetsAdapter:   latestOffsetsAdapter{},
+			InitializeSchema: true,
+		}, wmLogger)
+	}
+	subscriber, err := newSubscriber(prefix)
+	if err != nil {
+		_ = publisher.Close()
+		return nil, err
+	}
+
+	bus := &EventBus{
+		publisher:        publisher,
+		subscriber:       subscriber,
+		closers:          uniqueClosers(subscriber, publisher),
+		groupSubscribers: make(map[string]message.Subscriber),
+		db:               cfg.DB,
+	}
+	bus.newGroupSubscriber = func(group string) (message.Subscriber, error) {
+		return newSubscriber(prefix + "_" + sanitizeInstanceID(group))
+	}
+	bus.newSharedSubscriber = func(group string) (message.Subscriber, error) {
+		return newSubscriber("bitmerchant_shared_" + sanitizeInstanceID(group))
+	}
+	return bus, nil
+}
+
 func sanitizeInstanceID(instanceID string) string {
 	sanitized := invalidInstanceIDChars.ReplaceAllString(strings.TrimSpace(instanceID), "_")
 	sanitized = strings.Trim(sanitized, "_")
@@ -242,19 +362,42 @@ func uniqueClosers(closers ...closeable) []closeable {
 	return unique
 }
 
-// Publish publishes a domain event.
+// Publish publishes a domain event in an envelope carrying the correlation
+// metadata and trace context in ctx, under the envelope's ID.
 func (b *EventBus) Publish(ctx context.Context, topic string, event interface{}) error {
 	if err := b.ensureTopic(topic); err != nil {
 		return err
 	}
 
-	payload, err := json.Marshal(event)
+	env, err := envelope.New(topic, event, envelope.MetadataFromContext(ctx))
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(env)
 	if err != nil {
 		return err
 	}
 
-	msg := message.NewMessage(watermill.NewUUID(), payload)
-	return b.publisher.Publish(topic, msg)
+	if err := b.publish(ctx, topic, env.ID, payload); err != nil {
+		return err
+	}
+	b.metrics.IncPublished(topic)
+	return nil
+}
+
+// PublishRaw publishes an already enveloped event under message ID id, so an
+// event relayed twice from the outbox carries the same ID both times. It
+// continues the trace the event was raised in.
+func (b *EventBus) PublishRaw(topic, id string, payload []byte) error {
+	if err := b.ensureTopic(topic); err != nil {
+		return err
+	}
+	ctx := tracecontext.Extract(context.Background(), traceContextOf(payload))
+	if err := b.publish(ctx, topic, id, payload); err != nil {
+		return err
+	}
+	b.metrics.IncPublished(topic)
+	return nil
 }
 
 // Subscribe subscribes to domain events.
@@ -283,7 +426,8 @@ func (b *EventBus) Subscriber() message.Subscriber {
 // SubscriberForGroup returns a subscriber whose handlers form a distinct
 // consumer group from Subscriber() and from other named groups. On NATS this
 // translates to a separate QueueGroupPrefix so each group receives every
-// message independently (instead of competing). On the in-memory backend it
+// message independently (instead of competing); on Postgres, to a separate
+// consumer group with its own offsets. On the in-memory backend it
 // is functionally identical to Subscriber() — gochannel already broadcasts
 // to every subscriber.
 //
@@ -295,19 +439,47 @@ func (b *EventBus) SubscriberForGroup(group string) message.Subscriber {
 	if group == "" || b.newGroupSubscriber == nil {
 		return b.Subscriber()
 	}
+	return b.cachedSubscriber(group, func() (message.Subscriber, error) {
+		return b.newGroupSubscriber(group)
+	})
+}
+
+// SharedSubscriberForGroup returns a subscriber whose group spans every
+// replica: on NATS and Postgres each message is handled once across the
+// cluster instead of once per instance. Use it for handlers whose side effects are already
+// cluster-wide — e.g. SSE projections, whose broadcasts the SSERelay fans
+// out to every replica. On the in-memory backend it behaves like
+// SubscriberForGroup.
+func (b *EventBus) SharedSubscriberForGroup(group string) message.Subscriber {
+	if group == "" || b.newSharedSubscriber == nil {
+		return b.Subscriber()
+	}
+	return b.cachedSubscriber("shared:"+group, func() (message.Subscriber, error) {
+		return b.newSharedSubscriber(group)
+	})
+}
+
+// SSERelay returns the relay SSE handlers broadcast through so clients on
+// every replica see the same frames. nil on the in-memory and postgres
+// backends.
+func (b *EventBus) SSERelay() *SSERelay {
+	return b.sseRelay
+}
+
+func (b *EventBus) cachedSubscriber(key string, build func() (message.Subscriber, error)) message.Subscriber {
 	b.groupMu.Lock()
 	defer b.groupMu.Unlock()
-	sub, ok := b.groupSubscribers[group]
+	sub, ok := b.groupSubscribers[key]
 	if !ok {
-		built, err := b.newGroupSubscriber(group)
+		built, err := build()
 		if err != nil {
 			// Construction failure is a startup-config issue; falling back to
 			// the shared subscriber re-creates the original bug, so panic
 			// loudly instead of degrading silently.
-			panic(fmt.Errorf("events: build subscriber for group %q: %w", group, err))
+			panic(fmt.Errorf("events: build subscriber for group %q: %w", key, err))
 		}
 		sub = built
-		b.groupSubscribers[group] = built
+		b.groupSubscribers[key] = built
 		if c, ok := built.(closeable); ok && !sameSubscriber(built, b.subscriber) {
 			b.closers = append(b.closers, c)
 		}
@@ -2,11 +2,16 @@ package events_test
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/infrastructure/events"
 )
 
@@ -61,3 +66,215 @@ func TestSubscriberForGroup_FansOutToEveryGroup(t *testing.T) {
 		}
 	}
 }
+
+// A single in-memory node has no replicas to relay SSE frames to, and its
+// shared groups still see every message.
+func TestMemoryBackend_SharedGroupWithoutSSERelay(t *testing.T) {
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory"})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Close() })
+
+	if relay := bus.SSERelay(); relay != nil {
+		t.Fatalf("memory backend should not relay SSE, got %v", relay)
+	}
+
+	const topic = "test.shared"
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	sharedCh, err := bus.SharedSubscriberForGroup("sse").Subscribe(ctx, topic)
+	if err != nil {
+		t.Fatalf("shared group subscribe: %v", err)
+	}
+	if err := bus.Publish(ctx, topic, map[string]string{"k": "v"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case m := <-sharedCh:
+		if m == nil {
+			t.Fatal("shared group: channel closed before message")
+		}
+		m.Ack()
+	case <-time.After(2 * time.Second):
+		t.Fatal("shared group: did not receive published message")
+	}
+}
+
+// The postgres backend keeps messages in the app database, so it cannot
+// start without one.
+func TestPostgresBackend_NeedsDatabase(t *testing.T) {
+	if _, err := events.NewEventBusWithConfig(events.Config{Backend: "postgres"}); err == nil {
+		t.Fatal("expected an error without a database")
+	}
+}
+
+// Events reach handlers in an envelope; Handle decodes it and hands the
+// handler a context whose events are caused by the one it handles.
+func TestHandle_PropagatesCorrelation(t *testing.T) {
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory"})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Close() })
+
+	router, err := message.NewRouter(message.RouterConfig{}, watermill.NopLogger{})
+	if err != nil {
+		t.Fatalf("new router: %v", err)
+	}
+	type seated struct{ Table string }
+	got := make(chan envelope.Metadata, 1)
+	router.AddConsumerHandler("seated", "test.seated", bus.Subscriber(), events.Handle(func(ctx context.Context, ev seated) error {
+		if ev.Table != "T4" {
+			t.Errorf("decoded table %q, want T4", ev.Table)
+		}
+		got <- envelope.MetadataFromContext(ctx)
+		return nil
+	}))
+	go func() { _ = router.Run(context.Background()) }()
+	t.Cleanup(func() { _ = router.Close() })
+	<-router.Running()
+
+	ctx := envelope.WithMetadata(context.Background(), envelope.Metadata{CorrelationID: "req-1", Actor: "user-1"})
+	if err := bus.Publish(ctx, "test.seated", seated{Table: "T4"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	select {
+	case md := <-got:
+		if md.CorrelationID != "req-1" || md.Actor != "user-1" || md.CausationID == "" {
+			t.Fatalf("handler metadata = %+v, want the request's correlation and a causation ID", md)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not run")
+	}
+}
+
+type countingMetrics struct {
+	events.NoopMetrics
+	mu           sync.Mutex
+	published    map[string]int
+	failures     map[string]int
+	deadLettered map[string]int
+}
+
+func (m *countingMetrics) IncPublished(topic string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.published[topic]++
+}
+
+func (m *countingMetrics) IncHandlerFailure(handler string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[handler]++
+}
+
+func (m *countingMetrics) IncDeadLettered(handler string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadLettered[handler]++
+}
+
+// startDeadLetterRouter runs handler on topic under the bus's handler
+// middleware, recording dead letters in the returned store.
+func startDeadLetterRouter(t *testing.T, bus *events.EventBus, topic string, handler message.NoPublishHandlerFunc) *events.MemoryDeadLetters {
+	t.Helper()
+	router, err := message.NewRouter(message.RouterConfig{}, watermill.NopLogger{})
+	if err != nil {
+		t.Fatalf("new router: %v", err)
+	}
+	mw, err := bus.HandlerMiddleware(events.RetryPolicy{MaxRetries: 2, InitialInterval: time.Millisecond}, watermill.NopLogger{})
+	if err != nil {
+		t.Fatalf("handler middleware: %v", err)
+	}
+	router.AddMiddleware(mw...)
+	router.AddConsumerHandler("flaky", topic, bus.Subscriber(), handler)
+	store := events.NewMemoryDeadLetters()
+	events.RegisterDeadLetterHandler(router, bus.SubscriberForGroup("deadletters"), store)
+	go func() { _ = router.Run(context.Background()) }()
+	t.Cleanup(func() { _ = router.Close() })
+	<-router.Running()
+	return store
+}
+
+func waitForDeadLetter(t *testing.T, store *events.MemoryDeadLetters) events.DeadLetter {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if letters := store.List(); len(letters) > 0 {
+			return letters[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("message was not dead-lettered")
+	return events.DeadLetter{}
+}
+
+// A handler that keeps failing is retried under the policy, then its
+// message is dead-lettered with the reason, and both are counted.
+func TestHandlerMiddleware_DeadLettersAfterRetries(t *testing.T) {
+	metrics := &countingMetrics{published: map[string]int{}, failures: map[string]int{}, deadLettered: map[string]int{}}
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory", Metrics: metrics})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Close() })
+
+	type seated struct{ Table string }
+	var attempts atomic.Int32
+	store := startDeadLetterRouter(t, bus, "test.flaky", events.Handle(func(context.Context, seated) error {
+		attempts.Add(1)
+		return errors.New("printer offline")
+	}))
+
+	if err := bus.Publish(context.Background(), "test.flaky", seated{Table: "T4"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	dl := waitForDeadLetter(t, store)
+	if dl.Topic != "test.flaky" || dl.Handler != "flaky" || dl.MessageID == "" {
+		t.Fatalf("dead letter = %+v, want topic test.flaky from handler flaky", dl)
+	}
+	if dl.Reason == "" {
+		t.Fatal("dead letter has no reason")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Fatalf("handler ran %d times, want 1 attempt and 2 retries", got)
+	}
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if metrics.failures["flaky"] != 3 || metrics.deadLettered["flaky"] != 1 {
+		t.Fatalf("metrics = %v failures, %v dead-lettered", metrics.failures, metrics.deadLettered)
+	}
+	if metrics.published["test.flaky"] != 1 {
+		t.Fatalf("published = %v, want test.flaky once", metrics.published)
+	}
+}
+
+// A message that cannot be decoded fails Permanent and is dead-lettered
+// without retries.
+func TestHandlerMiddleware_PermanentErrorSkipsRetries(t *testing.T) {
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory"})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Close() })
+
+	type seated struct{ Table string }
+	var attempts atomic.Int32
+	store := startDeadLetterRouter(t, bus, "test.garbled", events.Handle(func(context.Context, seated) error {
+		attempts.Add(1)
+		return nil
+	}))
+
+	if err := bus.PublishRaw("test.garbled", watermill.NewUUID(), []byte("not json")); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	dl := waitForDeadLetter(t, store)
+	if string(dl.Payload) != "not json" {
+		t.Fatalf("dead letter payload = %q, want the original bytes", dl.Payload)
+	}
+	if got := attempts.Load(); got != 0 {
+		t.Fatalf("handler ran %d times on an undecodable message", got)
+	}
+}
@@ -0,0 +1,174 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"bitmerchant/internal/common/outbox"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	wmmiddleware "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+)
+
+// ErrDeadLetterNotFound is returned for a dead letter that does not exist
+// or was already replayed or discarded.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a message a handler gave up on.
+type DeadLetter struct {
+	Seq int64
+	// MessageID is the ID the message was published under; a replay
+	// publishes it under the same ID.
+	MessageID string
+	Topic     string
+	Handler   string
+	Reason    string
+	Payload   []byte
+	DeadAt    time.Time
+	// ReplayedAt is set once an operator replays the message.
+	ReplayedAt *time.Time
+}
+
+// DeadLetterStore keeps dead letters for an operator to inspect.
+type DeadLetterStore interface {
+	Add(ctx context.Context, dl DeadLetter) error
+}
+
+// RegisterDeadLetterHandler stores every message published to PoisonTopic
+// in store. subscriber should be shared across replicas so each dead
+// letter is stored once.
+func RegisterDeadLetterHandler(router *message.Router, subscriber message.Subscriber, store DeadLetterStore) {
+	router.AddConsumerHandler("dead_letters", PoisonTopic, subscriber, func(msg *message.Message) error {
+		return store.Add(msg.Context(), DeadLetter{
+			MessageID: msg.UUID,
+			Topic:     msg.Metadata.Get(wmmiddleware.PoisonedTopicKey),
+			Handler:   msg.Metadata.Get(wmmiddleware.PoisonedHandlerKey),
+			Reason:    msg.Metadata.Get(wmmiddleware.ReasonForPoisonedKey),
+			Payload:   msg.Payload,
+			DeadAt:    time.Now(),
+		})
+	})
+}
+
+// MemoryDeadLetters keeps dead letters in process, for the in-memory
+// deployment and tests. They do not survive a restart.
+type MemoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+func NewMemoryDeadLetters() *MemoryDeadLetters {
+	return &MemoryDeadLetters{}
+}
+
+func (s *MemoryDeadLetters) Add(_ context.Context, dl DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dl.Seq = int64(len(s.letters) + 1)
+	s.letters = append(s.letters, dl)
+	return nil
+}
+
+// List returns the stored dead letters, oldest first.
+func (s *MemoryDeadLetters) List() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DeadLetter(nil), s.letters...)
+}
+
+// PostgresDeadLetters keeps dead letters in the event_dead_letters table.
+type PostgresDeadLetters struct {
+	db *sql.DB
+}
+
+func NewPostgresDeadLetters(db *sql.DB) *PostgresDeadLetters {
+	return &PostgresDeadLetters{db: db}
+}
+
+func (s *PostgresDeadLetters) Add(ctx context.Context, dl DeadLetter) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO event_dead_letters (message_id, topic, handler, reason, payload, dead_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		dl.MessageID, dl.Topic, dl.Handler, dl.Reason, dl.Payload, dl.DeadAt)
+	if err != nil {
+		return fmt.Errorf("store dead letter %s: %w", dl.MessageID, err)
+	}
+	return nil
+}
+
+// List returns dead letters oldest first; replayed ones only with
+// includeReplayed.
+func (s *PostgresDeadLetters) List(ctx context.Context, includeReplayed bool) ([]DeadLetter, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT seq, message_id, topic, handler, reason, payload, dead_at, replayed_at
+		 FROM event_dead_letters
+		 WHERE $1 OR replayed_at IS NULL
+		 ORDER BY seq`, includeReplayed)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var letters []DeadLetter
+	for rows.Next() {
+		var dl DeadLetter
+		var replayedAt sql.NullTime
+		if err := rows.Scan(&dl.Seq, &dl.MessageID, &dl.Topic, &dl.Handler, &dl.Reason, &dl.Payload, &dl.DeadAt, &replayedAt); err != nil {
+			return nil, err
+		}
+		if replayedAt.Valid {
+			dl.ReplayedAt = &replayedAt.Time
+		}
+		letters = append(letters, dl)
+	}
+	return letters, rows.Err()
+}
+
+// Replay puts the dead letter back in the outbox under its original ID and
+// topic, so the relay publishes it again, and marks it replayed. Every
+// group subscribed to the topic sees it again, not only the handler that
+// failed.
+func (s *PostgresDeadLetters) Replay(ctx context.Context, seq int64) (DeadLetter, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return DeadLetter{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	dl := DeadLetter{Seq: seq}
+	err = tx.QueryRowContext(ctx,
+		`UPDATE event_dead_letters SET replayed_at = NOW()
+		 WHERE seq = $1 AND replayed_at IS NULL
+		 RETURNING message_id, topic, handler, reason, payload, dead_at`, seq).
+		Scan(&dl.MessageID, &dl.Topic, &dl.Handler, &dl.Reason, &dl.Payload, &dl.DeadAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DeadLetter{}, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return DeadLetter{}, err
+	}
+	msg := outbox.Message{ID: dl.MessageID, Topic: dl.Topic, Payload: dl.Payload, CreatedAt: time.Now()}
+	if err := outbox.Insert(tx, []outbox.Message{msg}); err != nil {
+		return DeadLetter{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return DeadLetter{}, err
+	}
+	return dl, nil
+}
+
+// Discard deletes a dead letter that should not be replayed.
+func (s *PostgresDeadLetters) Discard(ctx context.Context, seq int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM event_dead_letters WHERE seq = $1 AND replayed_at IS NULL`, seq)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
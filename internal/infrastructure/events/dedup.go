@@ -0,0 +1,173 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"bitmerchant/internal/infrastructure/logging"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// DedupStore remembers which handler processed which message, so a message
+// redelivered by the bus, or replayed from the dead letters, is handled
+// once per handler.
+type DedupStore interface {
+	// Claim records that handler is processing messageID and reports
+	// whether it may; false means it was claimed less than ttl ago.
+	Claim(ctx context.Context, handler, messageID string, ttl time.Duration) (bool, error)
+	// Release forgets a claim whose handler failed, so a retry runs it.
+	Release(ctx context.Context, handler, messageID string) error
+	// Purge deletes claims older than ttl and returns how many it deleted.
+	Purge(ctx context.Context, ttl time.Duration) (int64, error)
+}
+
+// Deduplicate returns handler middleware that skips a message its handler
+// already processed within ttl. Add it to side-effecting handlers, such as
+// push notifications, so an at-least-once delivery runs them effectively
+// once. With the Postgres store the claim is shared, so replicas that each
+// consume the message still run the handler once between them. A handler
+// that crashes the process mid-message keeps its claim, so the redelivery
+// is skipped: at most once until ttl runs out.
+func Deduplicate(store DedupStore, ttl time.Duration) message.HandlerMiddleware {
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			ctx := msg.Context()
+			handler := message.HandlerNameFromCtx(ctx)
+			claimed, err := store.Claim(ctx, handler, msg.UUID, ttl)
+			if err != nil {
+				return nil, fmt.Errorf("claim %s for %s: %w", msg.UUID, handler, err)
+			}
+			if !claimed {
+				logging.FromContext(ctx).Debug("skipping duplicate event", "handler", handler, "message_id", msg.UUID)
+				return nil, nil
+			}
+
+			release := func() {
+				if rerr := store.Release(context.WithoutCancel(ctx), handler, msg.UUID); rerr != nil {
+					logging.FromContext(ctx).Warn("release event claim failed", "handler", handler, "message_id", msg.UUID, "error", rerr)
+				}
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					release()
+					panic(r)
+				}
+			}()
+			out, err := h(msg)
+			if err != nil {
+				release()
+			}
+			return out, err
+		}
+	}
+}
+
+// PurgeDedup deletes expired claims from store every interval until ctx is
+// done.
+func PurgeDedup(ctx context.Context, store DedupStore, ttl, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := store.Purge(ctx, ttl); err != nil && ctx.Err() == nil {
+				logging.FromContext(ctx).Warn("purge processed events failed", "error", err)
+			}
+		}
+	}
+}
+
+type dedupKey struct {
+	handler   string
+	messageID string
+}
+
+// MemoryDedupStore keeps claims in process, for the in-memory deployment
+// and tests.
+type MemoryDedupStore struct {
+	mu     sync.Mutex
+	claims map[dedupKey]time.Time
+}
+
+func NewMemoryDedupStore() *MemoryDedupStore {
+	return &MemoryDedupStore{claims: make(map[dedupKey]time.Time)}
+}
+
+func (s *MemoryDedupStore) Claim(_ context.Context, handler, messageID string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := dedupKey{handler: handler, messageID: messageID}
+	now := time.Now()
+	if at, ok := s.claims[key]; ok && now.Sub(at) < ttl {
+		return false, nil
+	}
+	s.claims[key] = now
+	return true, nil
+}
+
+func (s *MemoryDedupStore) Release(_ context.Context, handler, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.claims, dedupKey{handler: handler, messageID: messageID})
+	return nil
+}
+
+func (s *MemoryDedupStore) Purge(_ context.Context, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	cutoff := time.Now().Add(-ttl)
+	for key, at := range s.claims {
+		if at.Before(cutoff) {
+			delete(s.claims, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// PostgresDedupStore keeps claims in the event_processed table, shared by
+// every replica.
+type PostgresDedupStore struct {
+	db *sql.DB
+}
+
+func NewPostgresDedupStore(db *sql.DB) *PostgresDedupStore {
+	return &PostgresDedupStore{db: db}
+}
+
+func (s *PostgresDedupStore) Claim(ctx context.Context, handler, messageID string, ttl time.Duration) (bool, error) {
+	// A claim older than ttl is taken over as if it were not there.
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO event_processed (handler, message_id, processed_at) VALUES ($1, $2, NOW())
+		 ON CONFLICT (handler, message_id) DO UPDATE SET processed_at = NOW()
+		 WHERE event_processed.processed_at < NOW() - make_interval(secs => $3)`,
+		handler, messageID, ttl.Seconds())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (s *PostgresDedupStore) Release(ctx context.Context, handler, messageID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM event_processed WHERE handler = $1 AND message_id = $2`, handler, messageID)
+	return err
+}
+
+func (s *PostgresDedupStore) Purge(ctx context.Context, ttl time.Duration) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM event_processed WHERE processed_at < NOW() - make_interval(secs => $1)`, ttl.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
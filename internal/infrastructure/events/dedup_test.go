@@ -0,0 +1,96 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
+
+	"bitmerchant/internal/infrastructure/events"
+)
+
+// A redelivered message runs its handler once; a failed run is released so
+// the retry runs it.
+func TestDeduplicate_RunsHandlerOncePerMessage(t *testing.T) {
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory"})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	t.Cleanup(func() { _ = bus.Close() })
+
+	router, err := message.NewRouter(message.RouterConfig{}, watermill.NopLogger{})
+	if err != nil {
+		t.Fatalf("new router: %v", err)
+	}
+	router.AddMiddleware(middleware.Retry{MaxRetries: 1}.Middleware)
+	runs := make(chan string, 10)
+	failed := false
+	router.AddConsumerHandler("notif_order_ready", "test.ready", bus.Subscriber(), func(msg *message.Message) error {
+		if string(msg.Payload) == "flaky" && !failed {
+			failed = true
+			return errors.New("push gateway down")
+		}
+		runs <- msg.UUID
+		return nil
+	}).AddMiddleware(events.Deduplicate(events.NewMemoryDedupStore(), time.Hour))
+	go func() { _ = router.Run(context.Background()) }()
+	t.Cleanup(func() { _ = router.Close() })
+	<-router.Running()
+
+	for _, m := range []struct{ id, payload string }{{"m1", "ok"}, {"m1", "ok"}, {"m2", "flaky"}, {"m1", "ok"}} {
+		if err := bus.PublishRaw("test.ready", m.id, []byte(m.payload)); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	var got []string
+	for range 2 {
+		select {
+		case id := <-runs:
+			got = append(got, id)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("handler ran for %v, want m1 and m2", got)
+		}
+	}
+	if got[0] != "m1" || got[1] != "m2" {
+		t.Fatalf("handler ran for %v, want m1 then m2 after its retry", got)
+	}
+	select {
+	case id := <-runs:
+		t.Fatalf("handler ran again for %s", id)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// Claims expire after the TTL and are purged.
+func TestMemoryDedupStore_ClaimsExpire(t *testing.T) {
+	store := events.NewMemoryDedupStore()
+	ctx := context.Background()
+
+	if ok, _ := store.Claim(ctx, "h", "m1", time.Hour); !ok {
+		t.Fatal("first claim refused")
+	}
+	if ok, _ := store.Claim(ctx, "other", "m1", time.Hour); !ok {
+		t.Fatal("claims are per handler")
+	}
+	if ok, _ := store.Claim(ctx, "h", "m1", time.Hour); ok {
+		t.Fatal("second claim within the TTL allowed")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if ok, _ := store.Claim(ctx, "h", "m1", time.Millisecond); !ok {
+		t.Fatal("claim older than the TTL refused")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	n, err := store.Purge(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("purged %d claims, want 2", n)
+	}
+}
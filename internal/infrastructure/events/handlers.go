@@ -1,3 +1,39 @@
 package events
 
-// Handlers will be implemented in separate files or here as needed.
+import (
+	"context"
+
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/infrastructure/logging"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// Handle adapts fn to a Watermill handler for events of type T. It decodes
+// the envelope, upcasting older versions; a message it cannot decode fails
+// Permanent, so it is dead-lettered without retries. fn runs under a
+// context whose events are caused by this one and whose logger carries the
+// originating request ID.
+func Handle[T any](fn func(ctx context.Context, ev T) error) message.NoPublishHandlerFunc {
+	return func(msg *message.Message) error {
+		log := logging.FromContext(msg.Context()).With("topic", message.SubscribeTopicFromCtx(msg.Context()), "message_id", msg.UUID)
+
+		var ev T
+		env, err := envelope.Decode(msg.Payload, &ev)
+		if err != nil {
+			log.Warn("undecodable event", "error", err)
+			return Permanent(err)
+		}
+		if env.CorrelationID != "" {
+			log = log.With("request_id", env.CorrelationID)
+		}
+
+		ctx := envelope.WithMetadata(msg.Context(), env.Caused())
+		ctx = logging.ToContext(ctx, log)
+		if err := fn(ctx, ev); err != nil {
+			log.Warn("event handler failed", "error", err)
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitmerchant/internal/infrastructure/logging"
+
+	watermillsql "github.com/ThreeDotsLabs/watermill-sql/v3/pkg/sql"
+)
+
+// latestOffsetsAdapter starts a consumer group it has not seen before at the
+// newest message instead of the oldest. Per-instance groups are named after
+// the host, so a redeploy brings new groups, and replaying the whole topic
+// history into them would notify everyone again.
+type latestOffsetsAdapter struct {
+	watermillsql.DefaultPostgreSQLOffsetsAdapter
+	schema watermillsql.DefaultPostgreSQLSchema
+}
+
+func (a latestOffsetsAdapter) BeforeSubscribingQueries(topic, consumerGroup string) []watermillsql.Query {
+	// Only rows below the snapshot's xmin are settled; the subscriber reads
+	// nothing above it either, so a newer row still committing is delivered.
+	return []watermillsql.Query{{
+		Query: `INSERT INTO ` + a.MessagesOffsetsTable(topic) + ` (consumer_group, offset_acked, last_processed_transaction_id)
+			SELECT $1, COALESCE(newest."offset", 0), COALESCE(newest.transaction_id, '0')
+			FROM (SELECT 1) AS one
+			LEFT JOIN (
+				SELECT "offset", transaction_id FROM ` + a.schema.MessagesTable(topic) + `
+				WHERE transaction_id < pg_snapshot_xmin(pg_current_snapshot())
+				ORDER BY transaction_id DESC, "offset" DESC
+				LIMIT 1
+			) AS newest ON true
+			ON CONFLICT DO NOTHING`,
+		Args: []any{consumerGroup},
+	}}
+}
+
+// Purge deletes messages older than retention from the postgres backend's
+// topic tables and returns how many it deleted. A consumer group that left
+// such a message unconsumed belonged to an instance that is gone, so its
+// offset is deleted too. The other backends keep no messages here.
+func (b *EventBus) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	if b.db == nil {
+		return 0, nil
+	}
+	// Topic tables are created on first publish or subscribe, so they are
+	// found in the catalog rather than from a list of known topics.
+	rows, err := b.db.QueryContext(ctx,
+		`SELECT substr(m.tablename, 11), o.tablename IS NOT NULL
+		 FROM pg_tables m
+		 LEFT JOIN pg_tables o ON o.schemaname = m.schemaname AND o.tablename = 'watermill_offsets_' || substr(m.tablename, 11)
+		 WHERE m.schemaname = current_schema()
+		   AND m.tablename LIKE 'watermill\_%'
+		   AND m.tablename NOT LIKE 'watermill\_offsets\_%'`)
+	if err != nil {
+		return 0, err
+	}
+	type topicTables struct {
+		topic      string
+		hasOffsets bool
+	}
+	var topics []topicTables
+	for rows.Next() {
+		var t topicTables
+		if err := rows.Scan(&t.topic, &t.hasOffsets); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		topics = append(topics, t)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, t := range topics {
+		n, err := b.purgeTopic(ctx, t.topic, t.hasOffsets, retention)
+		if err != nil {
+			return total, fmt.Errorf("purge topic %s: %w", t.topic, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (b *EventBus) purgeTopic(ctx context.Context, topic string, hasOffsets bool, retention time.Duration) (int64, error) {
+	messages := watermillsql.DefaultPostgreSQLSchema{}.MessagesTable(topic)
+	offsets := watermillsql.DefaultPostgreSQLOffsetsAdapter{}.MessagesOffsetsTable(topic)
+
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if hasOffsets {
+		// Offsets go first, while the messages that show a group is stale
+		// are still there.
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM `+offsets+` AS o WHERE EXISTS (
+				SELECT 1 FROM `+messages+` AS m
+				WHERE m.created_at < NOW() - make_interval(secs => $1)
+				  AND (m.transaction_id > o.last_processed_transaction_id
+				       OR (m.transaction_id = o.last_processed_transaction_id AND m."offset" > o.offset_acked))
+			)`, retention.Seconds()); err != nil {
+			return 0, err
+		}
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM `+messages+` WHERE created_at < NOW() - make_interval(secs => $1)`, retention.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return n, tx.Commit()
+}
+
+// PurgeEvents deletes messages older than retention from bus every interval
+// until ctx is done.
+func PurgeEvents(ctx context.Context, bus *EventBus, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := bus.Purge(ctx, retention); err != nil && ctx.Err() == nil {
+				logging.FromContext(ctx).Warn("purge event messages failed", "error", err)
+			}
+		}
+	}
+}
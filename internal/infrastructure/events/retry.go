@@ -0,0 +1,144 @@
+package events
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	wmmiddleware "github.com/ThreeDotsLabs/watermill/message/router/middleware"
+)
+
+// PoisonTopic receives messages a handler still failed after its retries,
+// or could not decode at all. The dead-letter handler stores them for an
+// operator to inspect and replay.
+const PoisonTopic = "bitmerchant.poisoned"
+
+// RetryPolicy controls how a failing handler is retried before its message
+// is dead-lettered. Intervals grow exponentially from InitialInterval up to
+// MaxInterval.
+type RetryPolicy struct {
+	MaxRetries      int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxRetries < 0 {
+		p.MaxRetries = 0
+	}
+	if p.InitialInterval <= 0 {
+		p.InitialInterval = 100 * time.Millisecond
+	}
+	if p.MaxInterval < p.InitialInterval {
+		p.MaxInterval = p.InitialInterval
+	}
+	return p
+}
+
+// Metrics counts event traffic: events published, and what became of each
+// message a handler received.
+type Metrics interface {
+	IncPublished(topic string)
+	// IncConsumed counts a message handler processed successfully.
+	IncConsumed(handler string)
+	// IncHandlerFailure counts one failed attempt by handler.
+	IncHandlerFailure(handler string)
+	// IncDeadLettered counts a message handler gave up on.
+	IncDeadLettered(handler string)
+}
+
+// NoopMetrics is a no-op Metrics.
+type NoopMetrics struct{}
+
+func (NoopMetrics) IncPublished(string)      {}
+func (NoopMetrics) IncConsumed(string)       {}
+func (NoopMetrics) IncHandlerFailure(string) {}
+func (NoopMetrics) IncDeadLettered(string)   {}
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as one retrying cannot fix, such as a payload that
+// does not decode: the message is dead-lettered straight away.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// HandlerMiddleware returns the middleware event routers run handlers
+// under, outermost first. A panicking or failing handler is retried under
+// policy; once retries run out, or at once for a Permanent error, the
+// message is published to PoisonTopic and acked, so one bad message never
+// blocks its topic. Handlers on PoisonTopic itself are never dead-lettered.
+// Outcomes are counted in the bus's Metrics, and each attempt is traced.
+func (b *EventBus) HandlerMiddleware(policy RetryPolicy, logger watermill.LoggerAdapter) ([]message.HandlerMiddleware, error) {
+	policy = policy.withDefaults()
+	metrics := b.metrics
+	if err := b.ensureTopic(PoisonTopic); err != nil {
+		return nil, err
+	}
+	poison, err := wmmiddleware.PoisonQueue(b.publisher, PoisonTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetter := func(h message.HandlerFunc) message.HandlerFunc {
+		poisoned := poison(h)
+		return func(msg *message.Message) ([]*message.Message, error) {
+			if message.SubscribeTopicFromCtx(msg.Context()) == PoisonTopic {
+				return h(msg)
+			}
+			return poisoned(msg)
+		}
+	}
+	countDeadLettered := func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			out, err := h(msg)
+			if err != nil && message.SubscribeTopicFromCtx(msg.Context()) != PoisonTopic {
+				metrics.IncDeadLettered(message.HandlerNameFromCtx(msg.Context()))
+			}
+			return out, err
+		}
+	}
+	countAttempts := func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			out, err := h(msg)
+			if err != nil {
+				metrics.IncHandlerFailure(message.HandlerNameFromCtx(msg.Context()))
+			} else {
+				metrics.IncConsumed(message.HandlerNameFromCtx(msg.Context()))
+			}
+			return out, err
+		}
+	}
+	retry := wmmiddleware.Retry{
+		MaxRetries:      policy.MaxRetries,
+		InitialInterval: policy.InitialInterval,
+		MaxInterval:     policy.MaxInterval,
+		Multiplier:      2.0,
+		ShouldRetry: func(params wmmiddleware.RetryParams) bool {
+			return !IsPermanent(params.Err)
+		},
+		Logger: logger,
+	}
+
+	return []message.HandlerMiddleware{
+		deadLetter,
+		countDeadLettered,
+		retry.Middleware,
+		countAttempts,
+		b.traceHandler,
+		wmmiddleware.Recoverer,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+
+	natsgo "github.com/nats-io/nats.go"
+)
+
+// sseSubjectPrefix roots the NATS core subjects SSE frames travel on. It is
+// deliberately not instance-scoped: every replica listens on the same tree.
+const sseSubjectPrefix = "bitmerchant.sse"
+
+// SSERelay fans SSE frames out to every replica over NATS core pub/sub, one
+// subject per SSE topic. Core subjects are fire-and-forget, which suits
+// live view patches: a replica that is down has no clients to miss them.
+type SSERelay struct {
+	conn *natsgo.Conn
+
+	mu  sync.Mutex
+	sub *natsgo.Subscription
+}
+
+func newSSERelay(url string) (*SSERelay, error) {
+	conn, err := natsgo.Connect(url, natsgo.Name("bitmerchant-sse"))
+	if err != nil {
+		return nil, err
+	}
+	return &SSERelay{conn: conn}, nil
+}
+
+// Publish sends message to every replica listening on topic.
+func (r *SSERelay) Publish(topic string, message []byte) error {
+	return r.conn.Publish(sseSubject(topic), message)
+}
+
+// Subscribe calls deliver for every frame published by any replica,
+// including this one. A relay has a single subscriber.
+func (r *SSERelay) Subscribe(deliver func(topic string, message []byte)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sub != nil {
+		return errors.New("sse relay already subscribed")
+	}
+	sub, err := r.conn.Subscribe(sseSubjectPrefix+".>", func(msg *natsgo.Msg) {
+		topic, ok := sseTopic(msg.Subject)
+		if !ok {
+			return
+		}
+		deliver(topic, msg.Data)
+	})
+	if err != nil {
+		return err
+	}
+	r.sub = sub
+	// Flush so the subscription is registered with the server before the
+	// first Publish from this process.
+	return r.conn.Flush()
+}
+
+// Close closes the relay's connection along with its subscription.
+func (r *SSERelay) Close() error {
+	r.conn.Close()
+	return nil
+}
+
+// sseSubject maps an SSE topic onto a single subject token. Topics carry
+// restaurant and order IDs, which may contain characters NATS treats as
+// separators or wildcards, so the topic is base64url-encoded.
+func sseSubject(topic string) string {
+	return sseSubjectPrefix + "." + base64.RawURLEncoding.EncodeToString([]byte(topic))
+}
+
+func sseTopic(subject string) (string, bool) {
+	token, ok := strings.CutPrefix(subject, sseSubjectPrefix+".")
+	if !ok {
+		return "", false
+	}
+	topic, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", false
+	}
+	return string(topic), true
+}
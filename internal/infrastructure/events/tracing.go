@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/tracecontext"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracecontext.Tracer("bitmerchant/events")
+
+// publish sends payload to topic under id in a producer span that is a
+// child of ctx. The span's trace context travels in the message metadata,
+// where traceHandler picks it up.
+func (b *EventBus) publish(ctx context.Context, topic, id string, payload []byte) (err error) {
+	ctx, span := tracer.Start(ctx, "send "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String(b.backend),
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingMessageID(id),
+		),
+	)
+	defer func() { tracecontext.End(span, err) }()
+
+	msg := message.NewMessage(id, payload)
+	tracecontext.Inject(ctx, msg.Metadata)
+	return b.publisher.Publish(topic, msg)
+}
+
+// traceContextOf returns the trace context an enveloped payload was raised
+// in, or nil for a payload that is not an envelope.
+func traceContextOf(payload []byte) map[string]string {
+	var env envelope.Envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return nil
+	}
+	return env.TraceContext
+}
+
+// traceHandler runs each handler attempt in a consumer span that continues
+// the trace in the message metadata. The handler sees the span in
+// msg.Context().
+func (b *EventBus) traceHandler(h message.HandlerFunc) message.HandlerFunc {
+	return func(msg *message.Message) ([]*message.Message, error) {
+		parent := msg.Context()
+		defer msg.SetContext(parent)
+
+		handler := message.HandlerNameFromCtx(parent)
+		topic := message.SubscribeTopicFromCtx(parent)
+		ctx, span := tracer.Start(tracecontext.Extract(parent, msg.Metadata), "process "+handler,
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystemKey.String(b.backend),
+				semconv.MessagingOperationTypeProcess,
+				semconv.MessagingDestinationName(topic),
+				semconv.MessagingMessageID(msg.UUID),
+				attribute.String("messaging.handler", handler),
+			),
+		)
+		msg.SetContext(ctx)
+		out, err := h(msg)
+		tracecontext.End(span, err)
+		return out, err
+	}
+}
@@ -0,0 +1,159 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/infrastructure/events"
+)
+
+var (
+	spansOnce sync.Once
+	spans     *tracetest.SpanRecorder
+)
+
+// recordSpans installs a recording tracer provider for the test binary.
+// The global provider can only be delegated to once, so tests share it and
+// tell their spans apart by trace ID.
+func recordSpans() *tracetest.SpanRecorder {
+	spansOnce.Do(func() {
+		spans = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	})
+	return spans
+}
+
+type tableSeated struct {
+	Table string `json:"table"`
+}
+
+// startTracedHandler runs a handler on topic that reports the span context
+// it ran under.
+func startTracedHandler(t *testing.T, bus *events.EventBus, topic string) <-chan trace.SpanContext {
+	t.Helper()
+	router, err := message.NewRouter(message.RouterConfig{}, watermill.NopLogger{})
+	if err != nil {
+		t.Fatalf("new router: %v", err)
+	}
+	mw, err := bus.HandlerMiddleware(events.RetryPolicy{}, watermill.NopLogger{})
+	if err != nil {
+		t.Fatalf("handler middleware: %v", err)
+	}
+	router.AddMiddleware(mw...)
+	handled := make(chan trace.SpanContext, 1)
+	router.AddConsumerHandler("seat_table", topic, bus.Subscriber(), events.Handle(func(ctx context.Context, _ tableSeated) error {
+		handled <- trace.SpanContextFromContext(ctx)
+		return nil
+	}))
+	go func() { _ = router.Run(context.Background()) }()
+	t.Cleanup(func() { _ = router.Close() })
+	<-router.Running()
+	return handled
+}
+
+func waitForSpanContext(t *testing.T, handled <-chan trace.SpanContext) trace.SpanContext {
+	t.Helper()
+	select {
+	case sc := <-handled:
+		return sc
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not run")
+		return trace.SpanContext{}
+	}
+}
+
+func spansIn(recorder *tracetest.SpanRecorder, traceID trace.TraceID) map[string]sdktrace.ReadOnlySpan {
+	byName := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		if s.SpanContext().TraceID() == traceID {
+			byName[s.Name()] = s
+		}
+	}
+	return byName
+}
+
+// A handler joins the trace of the request that published the event: the
+// consumer span is a child of the producer span, which is a child of the
+// request's.
+func TestPublish_PropagatesTraceToHandler(t *testing.T) {
+	recorder := recordSpans()
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory"})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	handled := startTracedHandler(t, bus, "test.traced")
+
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	if err := bus.Publish(ctx, "test.traced", tableSeated{Table: "T1"}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	request.End()
+
+	sc := waitForSpanContext(t, handled)
+	traceID := request.SpanContext().TraceID()
+	if sc.TraceID() != traceID {
+		t.Fatalf("handler ran in trace %s, want %s", sc.TraceID(), traceID)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	var byName map[string]sdktrace.ReadOnlySpan
+	for time.Now().Before(deadline) {
+		if byName = spansIn(recorder, traceID); byName["process seat_table"] != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	send, process := byName["send test.traced"], byName["process seat_table"]
+	if send == nil || process == nil {
+		t.Fatalf("expected send and process spans, got %v", byName)
+	}
+	if send.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Fatal("send span is not a child of the request span")
+	}
+	if process.Parent().SpanID() != send.SpanContext().SpanID() {
+		t.Fatal("process span is not a child of the send span")
+	}
+	if process.SpanKind() != trace.SpanKindConsumer {
+		t.Fatalf("process span kind = %v, want consumer", process.SpanKind())
+	}
+}
+
+// An event relayed from the outbox carries the trace it was raised in, in
+// its envelope, though the relay publishes it outside any request.
+func TestPublishRaw_ContinuesTraceFromEnvelope(t *testing.T) {
+	recordSpans()
+	bus, err := events.NewEventBusWithConfig(events.Config{Backend: "memory"})
+	if err != nil {
+		t.Fatalf("new event bus: %v", err)
+	}
+	handled := startTracedHandler(t, bus, "test.relayed")
+
+	ctx, request := otel.Tracer("test").Start(context.Background(), "request")
+	env, err := envelope.New("test.relayed", tableSeated{Table: "T2"}, envelope.MetadataFromContext(ctx))
+	if err != nil {
+		t.Fatalf("new envelope: %v", err)
+	}
+	request.End()
+	payload, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	if err := bus.PublishRaw("test.relayed", env.ID, payload); err != nil {
+		t.Fatalf("publish raw: %v", err)
+	}
+
+	if sc := waitForSpanContext(t, handled); sc.TraceID() != request.SpanContext().TraceID() {
+		t.Fatalf("handler ran in trace %s, want %s", sc.TraceID(), request.SpanContext().TraceID())
+	}
+}
@@ -0,0 +1,122 @@
+// Package fx holds live bitcoin price feeds implementing money.RateProvider.
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/common/money"
+)
+
+// Provider names accepted by FX_PROVIDERS.
+const (
+	ProviderCoinGecko = "coingecko"
+	ProviderCoinbase  = "coinbase"
+)
+
+const (
+	coinGeckoBaseURL = "https://api.coingecko.com"
+	coinbaseBaseURL  = "https://api.coinbase.com"
+)
+
+// NewProvider builds the named feed against its public API. client may be
+// nil, in which case a client with a short timeout is used.
+func NewProvider(name string, client *http.Client) (money.RateProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case ProviderCoinGecko:
+		return NewCoinGecko(coinGeckoBaseURL, client), nil
+	case ProviderCoinbase:
+		return NewCoinbase(coinbaseBaseURL, client), nil
+	default:
+		return nil, fmt.Errorf("fx: unknown rate provider %q", name)
+	}
+}
+
+// CoinGecko prices bitcoin with the CoinGecko simple price endpoint.
+type CoinGecko struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewCoinGecko(baseURL string, client *http.Client) *CoinGecko {
+	return &CoinGecko{baseURL: strings.TrimRight(baseURL, "/"), client: defaultClient(client)}
+}
+
+func (*CoinGecko) Name() string { return ProviderCoinGecko }
+
+func (p *CoinGecko) BTCPrice(ctx context.Context, code string) (float64, error) {
+	vs := strings.ToLower(code)
+	var out map[string]map[string]float64
+	q := url.Values{"ids": {"bitcoin"}, "vs_currencies": {vs}}
+	if err := getJSON(ctx, p.client, p.baseURL+"/api/v3/simple/price?"+q.Encode(), &out); err != nil {
+		return 0, err
+	}
+	price, ok := out["bitcoin"][vs]
+	if !ok {
+		return 0, fmt.Errorf("fx: coingecko has no price for %s", code)
+	}
+	return price, nil
+}
+
+// Coinbase prices bitcoin with the Coinbase spot price endpoint.
+type Coinbase struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewCoinbase(baseURL string, client *http.Client) *Coinbase {
+	return &Coinbase{baseURL: strings.TrimRight(baseURL, "/"), client: defaultClient(client)}
+}
+
+func (*Coinbase) Name() string { return ProviderCoinbase }
+
+func (p *Coinbase) BTCPrice(ctx context.Context, code string) (float64, error) {
+	var out struct {
+		Data struct {
+			Amount string `json:"amount"`
+		} `json:"data"`
+	}
+	pair := "BTC-" + strings.ToUpper(code)
+	if err := getJSON(ctx, p.client, p.baseURL+"/v2/prices/"+url.PathEscape(pair)+"/spot", &out); err != nil {
+		return 0, err
+	}
+	price, err := strconv.ParseFloat(out.Data.Amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("fx: coinbase price for %s: %w", code, err)
+	}
+	return price, nil
+}
+
+func defaultClient(client *http.Client) *http.Client {
+	if client == nil {
+		return &http.Client{Timeout: 5 * time.Second}
+	}
+	return client
+}
+
+func getJSON(ctx context.Context, client *http.Client, rawURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fx: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("fx: GET %s: status %d", req.URL.Path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(out); err != nil {
+		return fmt.Errorf("fx: decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package metrics exports application metrics to Prometheus. One Prometheus
+// value implements the metrics interfaces the other packages declare
+// (decorator.MetricsClient, events.Metrics, the HTTP middleware's and the
+// web push notifier's), so the composition root wires it in once.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "bitmerchant"
+
+// Prometheus records metrics in its own registry, served by Handler.
+type Prometheus struct {
+	registry *prometheus.Registry
+
+	commands        *prometheus.CounterVec
+	commandErrors   *prometheus.CounterVec
+	commandDuration *prometheus.HistogramVec
+	queries         *prometheus.CounterVec
+	queryErrors     *prometheus.CounterVec
+	queryDuration   *prometheus.HistogramVec
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	eventsPublished *prometheus.CounterVec
+	eventsConsumed  *prometheus.CounterVec
+	handlerFailures *prometheus.CounterVec
+	deadLettered    *prometheus.CounterVec
+
+	pushes *prometheus.CounterVec
+}
+
+// NewPrometheus registers the application's metrics, plus the Go runtime
+// and process collectors, in a fresh registry.
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "commands_total",
+			Help: "Commands handled, by command name.",
+		}, []string{"name"}),
+		commandErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "command_errors_total",
+			Help: "Commands that returned an error, by command name.",
+		}, []string{"name"}),
+		commandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "command_duration_seconds",
+			Help:    "Time to handle a command, by command name.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"name"}),
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "queries_total",
+			Help: "Queries handled, by query name.",
+		}, []string{"name"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "query_errors_total",
+			Help: "Queries that returned an error, by query name.",
+		}, []string{"name"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "query_duration_seconds",
+			Help:    "Time to handle a query, by query name.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"name"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "http_requests_total",
+			Help: "HTTP requests served, by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace, Name: "http_request_duration_seconds",
+			Help:    "Time to serve an HTTP request, by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		eventsPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "events_published_total",
+			Help: "Events published to the bus, by topic.",
+		}, []string{"topic"}),
+		eventsConsumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "events_consumed_total",
+			Help: "Events an event handler processed successfully, by handler.",
+		}, []string{"handler"}),
+		handlerFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "event_handler_failures_total",
+			Help: "Failed event handler attempts, retries included, by handler.",
+		}, []string{"handler"}),
+		deadLettered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "events_dead_lettered_total",
+			Help: "Events an event handler gave up on, by handler.",
+		}, []string{"handler"}),
+		pushes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace, Name: "web_push_sends_total",
+			Help: "Web push deliveries, by recipient role and outcome (accepted, rejected, expired, failed).",
+		}, []string{"role", "outcome"}),
+	}
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.commands, p.commandErrors, p.commandDuration,
+		p.queries, p.queryErrors, p.queryDuration,
+		p.httpRequests, p.httpDuration,
+		p.eventsPublished, p.eventsConsumed, p.handlerFailures, p.deadLettered,
+		p.pushes,
+	)
+	return p
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{Registry: p.registry})
+}
+
+func (p *Prometheus) ObserveCommand(name string, duration time.Duration, err error) {
+	p.commands.WithLabelValues(name).Inc()
+	p.commandDuration.WithLabelValues(name).Observe(duration.Seconds())
+	if err != nil {
+		p.commandErrors.WithLabelValues(name).Inc()
+	}
+}
+
+func (p *Prometheus) ObserveQuery(name string, duration time.Duration, err error) {
+	p.queries.WithLabelValues(name).Inc()
+	p.queryDuration.WithLabelValues(name).Observe(duration.Seconds())
+	if err != nil {
+		p.queryErrors.WithLabelValues(name).Inc()
+	}
+}
+
+// ObserveHTTPRequest records a request under its route pattern, such as
+// /order/:orderNumber, so order numbers do not become label values.
+func (p *Prometheus) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	p.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	p.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) IncPublished(topic string) {
+	p.eventsPublished.WithLabelValues(topic).Inc()
+}
+
+func (p *Prometheus) IncConsumed(handler string) {
+	p.eventsConsumed.WithLabelValues(handler).Inc()
+}
+
+func (p *Prometheus) IncHandlerFailure(handler string) {
+	p.handlerFailures.WithLabelValues(handler).Inc()
+}
+
+func (p *Prometheus) IncDeadLettered(handler string) {
+	p.deadLettered.WithLabelValues(handler).Inc()
+}
+
+func (p *Prometheus) IncPush(role, outcome string) {
+	p.pushes.WithLabelValues(role, outcome).Inc()
+}
+
+var sseClientsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "sse_clients"),
+	"SSE clients connected to this replica, by topic kind (kitchen, server, order).",
+	[]string{"topic"}, nil,
+)
+
+// ObserveSSEClients reports connected SSE clients as counts returns them at
+// scrape time, keyed by topic kind.
+func (p *Prometheus) ObserveSSEClients(counts func() map[string]int) {
+	p.registry.MustRegister(prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+		for topic, n := range counts() {
+			ch <- prometheus.MustNewConstMetric(sseClientsDesc, prometheus.GaugeValue, float64(n), topic)
+		}
+	}))
+}
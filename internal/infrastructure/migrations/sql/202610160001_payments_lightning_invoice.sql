@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS payment_hash TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS invoice TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS invoice_expires_at TIMESTAMPTZ NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_payment_hash ON payments(payment_hash) WHERE payment_hash <> '';
+CREATE INDEX IF NOT EXISTS idx_payments_method_status ON payments(method, status);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_payments_method_status;
+DROP INDEX IF EXISTS idx_payments_payment_hash;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS invoice_expires_at,
+    DROP COLUMN IF EXISTS invoice,
+    DROP COLUMN IF EXISTS payment_hash;
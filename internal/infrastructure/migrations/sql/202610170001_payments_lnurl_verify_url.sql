@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS verify_url TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS verify_url;
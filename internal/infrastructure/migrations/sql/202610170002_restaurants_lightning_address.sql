@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE restaurants
+    ADD COLUMN IF NOT EXISTS lightning_address TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE restaurants
+    DROP COLUMN IF EXISTS lightning_address;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Tender and change are minor units of the payment's currency, like every
+-- other amount the domain keeps.
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS tendered_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS change_given BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS collected_by TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS collected_by,
+    DROP COLUMN IF EXISTS change_given,
+    DROP COLUMN IF EXISTS tendered_amount;
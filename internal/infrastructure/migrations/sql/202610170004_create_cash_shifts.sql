@@ -0,0 +1,24 @@
+-- +goose Up
+-- Float, count and expected cash are minor units of the shift's currency.
+CREATE TABLE IF NOT EXISTS cash_shifts (
+    id TEXT PRIMARY KEY,
+    restaurant_id TEXT NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    staff_id TEXT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    opening_float BIGINT NOT NULL DEFAULT 0,
+    movements JSONB NOT NULL DEFAULT '[]'::jsonb,
+    status TEXT NOT NULL,
+    opened_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ NULL,
+    closed_by TEXT NOT NULL DEFAULT '',
+    counted_amount BIGINT NOT NULL DEFAULT 0,
+    expected_amount BIGINT NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_shifts_restaurant_opened ON cash_shifts(restaurant_id, opened_at DESC);
+-- At most one open drawer per staff member per restaurant.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cash_shifts_open_staff ON cash_shifts(restaurant_id, staff_id) WHERE status = 'open';
+
+-- +goose Down
+DROP TABLE IF EXISTS cash_shifts;
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS cancelled_by TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cancel_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS cancel_note TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS cancel_note,
+    DROP COLUMN IF EXISTS cancel_reason,
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancelled_at;
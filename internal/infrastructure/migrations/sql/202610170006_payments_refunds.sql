@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'charge',
+    ADD COLUMN IF NOT EXISTS refund_of TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMPTZ NULL;
+
+-- +goose Down
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS refunded_at,
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS refund_of,
+    DROP COLUMN IF EXISTS kind;
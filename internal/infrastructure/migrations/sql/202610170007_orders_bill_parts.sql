@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS bill_parts JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS bill_parts;
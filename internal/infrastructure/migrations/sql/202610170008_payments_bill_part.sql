@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS bill_part_id TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS bill_part_id;
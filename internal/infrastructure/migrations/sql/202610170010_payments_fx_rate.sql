@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS fx_from TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fx_to TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fx_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fx_source TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fx_as_of TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS fx_as_of,
+    DROP COLUMN IF EXISTS fx_source,
+    DROP COLUMN IF EXISTS fx_rate,
+    DROP COLUMN IF EXISTS fx_to,
+    DROP COLUMN IF EXISTS fx_from;
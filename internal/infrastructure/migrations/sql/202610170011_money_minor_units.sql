@@ -0,0 +1,116 @@
+-- +goose Up
+-- Every amount is stored as BIGINT minor units of its row's currency (cents,
+-- satang, sats). The *_minor columns become the only source of truth and the
+-- DOUBLE PRECISION major-unit columns go away.
+--
+-- Only USD and THB (two decimals) and SAT (none) existed when amounts were
+-- kept in major units, so those are the only factors below. Any other code
+-- would be scaled wrongly, so the migration stops instead.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    unknown TEXT;
+BEGIN
+    SELECT string_agg(DISTINCT code, ', ') INTO unknown
+    FROM (
+        SELECT currency AS code FROM menu_items
+        UNION SELECT currency FROM order_items
+        UNION SELECT currency FROM orders
+        UNION SELECT currency FROM payments
+        UNION SELECT currency FROM cash_shifts
+    ) c
+    WHERE code NOT IN ('USD', 'THB', 'SAT');
+    IF unknown IS NOT NULL THEN
+        RAISE EXCEPTION 'no minor-unit scale known for currency %', unknown;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+UPDATE menu_items
+SET price_minor = ROUND(price * CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT
+WHERE price_minor = 0 AND price > 0;
+
+UPDATE order_items
+SET unit_price_minor = ROUND(unit_price * CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT,
+    subtotal_minor   = ROUND(subtotal   * CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT
+WHERE unit_price_minor = 0 AND unit_price > 0;
+
+UPDATE payments
+SET amount_minor = ROUND(amount * CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT
+WHERE amount_minor = 0 AND amount > 0;
+
+UPDATE menu_items m
+SET option_groups = (
+    SELECT COALESCE(jsonb_agg(jsonb_set(g, '{options}', (
+        SELECT COALESCE(jsonb_agg(jsonb_set(o, '{price_delta}', to_jsonb(ROUND(COALESCE((o->>'price_delta')::NUMERIC, 0) * CASE m.currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT))), '[]'::jsonb)
+        FROM jsonb_array_elements(COALESCE(g->'options', '[]'::jsonb)) o
+    ))), '[]'::jsonb)
+    FROM jsonb_array_elements(m.option_groups) g
+)
+WHERE jsonb_array_length(m.option_groups) > 0;
+
+UPDATE order_items oi
+SET modifiers = (
+    SELECT COALESCE(jsonb_agg(jsonb_set(md, '{price_delta}', to_jsonb(ROUND(COALESCE((md->>'price_delta')::NUMERIC, 0) * CASE oi.currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT))), '[]'::jsonb)
+    FROM jsonb_array_elements(oi.modifiers) md
+)
+WHERE jsonb_array_length(oi.modifiers) > 0;
+
+UPDATE cash_shifts s
+SET movements = (
+    SELECT COALESCE(jsonb_agg(jsonb_set(mv, '{amount}', to_jsonb(ROUND(COALESCE((mv->>'amount')::NUMERIC, 0) * CASE s.currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END)::BIGINT))), '[]'::jsonb)
+    FROM jsonb_array_elements(s.movements) mv
+)
+WHERE jsonb_array_length(s.movements) > 0;
+
+ALTER TABLE menu_items DROP COLUMN IF EXISTS price;
+ALTER TABLE order_items DROP COLUMN IF EXISTS unit_price, DROP COLUMN IF EXISTS subtotal;
+ALTER TABLE payments DROP COLUMN IF EXISTS amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS fiat_amount;
+
+-- +goose Down
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS fiat_amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS amount DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS unit_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS subtotal DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS price DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE orders
+SET fiat_amount = total_amount::DOUBLE PRECISION / CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END
+WHERE currency <> 'SAT';
+
+UPDATE payments
+SET amount = amount_minor::DOUBLE PRECISION / CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END;
+
+UPDATE order_items
+SET unit_price = unit_price_minor::DOUBLE PRECISION / CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END,
+    subtotal   = subtotal_minor::DOUBLE PRECISION / CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END;
+
+UPDATE menu_items
+SET price = price_minor::DOUBLE PRECISION / CASE currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END;
+
+UPDATE cash_shifts s
+SET movements = (
+    SELECT COALESCE(jsonb_agg(jsonb_set(mv, '{amount}', to_jsonb(COALESCE((mv->>'amount')::NUMERIC, 0) / CASE s.currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END))), '[]'::jsonb)
+    FROM jsonb_array_elements(s.movements) mv
+)
+WHERE jsonb_array_length(s.movements) > 0;
+
+UPDATE order_items oi
+SET modifiers = (
+    SELECT COALESCE(jsonb_agg(jsonb_set(md, '{price_delta}', to_jsonb(COALESCE((md->>'price_delta')::NUMERIC, 0) / CASE oi.currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END))), '[]'::jsonb)
+    FROM jsonb_array_elements(oi.modifiers) md
+)
+WHERE jsonb_array_length(oi.modifiers) > 0;
+
+UPDATE menu_items m
+SET option_groups = (
+    SELECT COALESCE(jsonb_agg(jsonb_set(g, '{options}', (
+        SELECT COALESCE(jsonb_agg(jsonb_set(o, '{price_delta}', to_jsonb(COALESCE((o->>'price_delta')::NUMERIC, 0) / CASE m.currency WHEN 'SAT' THEN 1 WHEN 'USD' THEN 100 WHEN 'THB' THEN 100 END))), '[]'::jsonb)
+        FROM jsonb_array_elements(COALESCE(g->'options', '[]'::jsonb)) o
+    ))), '[]'::jsonb)
+    FROM jsonb_array_elements(m.option_groups) g
+)
+WHERE jsonb_array_length(m.option_groups) > 0;
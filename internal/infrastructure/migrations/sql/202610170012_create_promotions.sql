@@ -0,0 +1,51 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS promotions (
+    id TEXT PRIMARY KEY,
+    restaurant_id TEXT NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    code TEXT NOT NULL DEFAULT '',
+    kind TEXT NOT NULL,
+    percent_off INTEGER NOT NULL DEFAULT 0,
+    amount_off BIGINT NOT NULL DEFAULT 0,
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    get_quantity INTEGER NOT NULL DEFAULT 0,
+    category_id TEXT NOT NULL DEFAULT '',
+    min_subtotal BIGINT NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    starts_at TIMESTAMPTZ NULL,
+    ends_at TIMESTAMPTZ NULL,
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    max_uses_per_session INTEGER NOT NULL DEFAULT 0,
+    uses_count INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotions_restaurant ON promotions(restaurant_id, created_at DESC);
+-- Codes are unique per restaurant; automatic deals have none.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_restaurant_code ON promotions(restaurant_id, code) WHERE code <> '';
+
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    promotion_id TEXT NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    session_id TEXT NOT NULL,
+    order_id TEXT NOT NULL,
+    amount BIGINT NOT NULL,
+    redeemed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_promotion_redemptions_session ON promotion_redemptions(promotion_id, session_id);
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS discount_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS promotion_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS discount_label TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount_label,
+    DROP COLUMN IF EXISTS promotion_id,
+    DROP COLUMN IF EXISTS discount_amount;
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TABLE IF EXISTS promotions;
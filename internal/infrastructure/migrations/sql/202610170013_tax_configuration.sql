@@ -0,0 +1,23 @@
+-- +goose Up
+-- Named taxes, inclusive pricing and the service charge live in tax_config.
+-- NULL keeps a restaurant on its single exclusive tax_rate.
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS tax_config JSONB NULL;
+
+-- Tax classes route items to the taxes that apply to them; '' is standard
+-- and items without a class inherit their category's.
+ALTER TABLE menu_categories ADD COLUMN IF NOT EXISTS tax_class TEXT NOT NULL DEFAULT '';
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS tax_class TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS service_charge BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS tax_lines JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- +goose Down
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_lines,
+    DROP COLUMN IF EXISTS tax_inclusive,
+    DROP COLUMN IF EXISTS service_charge;
+ALTER TABLE menu_items DROP COLUMN IF EXISTS tax_class;
+ALTER TABLE menu_categories DROP COLUMN IF EXISTS tax_class;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS tax_config;
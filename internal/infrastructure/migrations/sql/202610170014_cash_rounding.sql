@@ -0,0 +1,14 @@
+-- +goose Up
+-- cash_rounding is the step, in minor units, cash totals are rounded to;
+-- 0 leaves them exact.
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS cash_rounding BIGINT NOT NULL DEFAULT 0;
+
+-- The signed adjustment rounding added to a cash order's total, and to the
+-- payment that settled it.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cash_rounding BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS rounding_adjustment BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE payments DROP COLUMN IF EXISTS rounding_adjustment;
+ALTER TABLE orders DROP COLUMN IF EXISTS cash_rounding;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS cash_rounding;
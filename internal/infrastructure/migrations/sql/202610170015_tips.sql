@@ -0,0 +1,12 @@
+-- +goose Up
+-- tip_config holds the restaurant's tip presets; NULL keeps the standard
+-- 10/15/20%.
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS tip_config JSONB;
+
+-- The part of tip_amount guests added after the order was paid. Each late
+-- tip is its own payments row of kind 'tip'.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS late_tip_amount BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS late_tip_amount;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS tip_config;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- tip_pooling holds the restaurant's tip pools and how each is shared out;
+-- NULL leaves tips with whoever collected them.
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS tip_pooling JSONB;
+
+-- Staff time on the clock, which tip pools are shared out by.
+CREATE TABLE IF NOT EXISTS staff_timecards (
+    id TEXT PRIMARY KEY,
+    restaurant_id TEXT NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    staff_id TEXT NOT NULL,
+    role TEXT NOT NULL,
+    clocked_in_at TIMESTAMPTZ NOT NULL,
+    clocked_out_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_staff_timecards_restaurant_in ON staff_timecards(restaurant_id, clocked_in_at);
+-- At most one open timecard per staff member per restaurant.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_staff_timecards_open_staff ON staff_timecards(restaurant_id, staff_id) WHERE clocked_out_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS staff_timecards;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS tip_pooling;
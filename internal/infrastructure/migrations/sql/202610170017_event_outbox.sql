@@ -0,0 +1,19 @@
+-- +goose Up
+-- Domain events waiting to be published, written in the same transaction as
+-- the aggregate that raised them and deleted once the relay has published
+-- them. seq keeps events in the order they were written.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    seq BIGSERIAL PRIMARY KEY,
+    id TEXT NOT NULL UNIQUE,
+    topic TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_due ON event_outbox(next_attempt_at, seq);
+
+-- +goose Down
+DROP TABLE IF EXISTS event_outbox;
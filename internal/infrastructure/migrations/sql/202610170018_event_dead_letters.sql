@@ -0,0 +1,19 @@
+-- +goose Up
+-- Messages an event handler gave up on after its retries. They stay here
+-- until an operator replays them through the outbox (replayed_at is set)
+-- or discards them.
+CREATE TABLE IF NOT EXISTS event_dead_letters (
+    seq BIGSERIAL PRIMARY KEY,
+    message_id TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    handler TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    dead_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    replayed_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_dead_letters_pending ON event_dead_letters(seq) WHERE replayed_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS event_dead_letters;
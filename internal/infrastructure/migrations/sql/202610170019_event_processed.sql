@@ -0,0 +1,14 @@
+-- +goose Up
+-- Which event handler processed which message, so redelivered messages are
+-- handled once. Rows older than the dedup TTL are purged.
+CREATE TABLE IF NOT EXISTS event_processed (
+    handler TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (handler, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_processed_at ON event_processed(processed_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS event_processed;
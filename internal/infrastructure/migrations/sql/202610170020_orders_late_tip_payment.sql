@@ -0,0 +1,6 @@
+-- +goose Up
+-- The payment that settled the late tip, so settling it again is a no-op.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS late_tip_payment_id TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS late_tip_payment_id;
@@ -0,0 +1,7 @@
+package memory
+
+import payAdapters "bitmerchant/internal/payment/adapters"
+
+type MemoryShiftRepository = payAdapters.MemoryShiftRepository
+
+var NewMemoryShiftRepository = payAdapters.NewMemoryShiftRepository
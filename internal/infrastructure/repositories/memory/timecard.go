@@ -0,0 +1,7 @@
+package memory
+
+import payAdapters "bitmerchant/internal/payment/adapters"
+
+type MemoryTimecardRepository = payAdapters.MemoryTimecardRepository
+
+var NewMemoryTimecardRepository = payAdapters.NewMemoryTimecardRepository
@@ -0,0 +1,7 @@
+package postgres
+
+import payAdapters "bitmerchant/internal/payment/adapters"
+
+type ShiftRepository = payAdapters.PostgresShiftRepository
+
+var NewShiftRepository = payAdapters.NewPostgresShiftRepository
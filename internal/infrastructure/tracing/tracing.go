@@ -0,0 +1,85 @@
+// Package tracing sets up OpenTelemetry tracing: the exporter, resource and
+// global tracer provider. Spans are started through common/tracecontext
+// against the global provider, which is a no-op until Setup installs an
+// exporter, so instrumented code costs next to nothing when tracing is off.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"bitmerchant/internal/common/tracecontext"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+const (
+	// ExporterNone disables tracing.
+	ExporterNone = "none"
+	// ExporterOTLP sends spans to an OTLP/HTTP collector, configured by the
+	// standard OTEL_EXPORTER_OTLP_* variables (localhost:4318 by default).
+	ExporterOTLP = "otlp"
+	// ExporterStdout prints spans as JSON, for local debugging.
+	ExporterStdout = "stdout"
+)
+
+// Config selects where spans go.
+type Config struct {
+	// Exporter is ExporterOTLP, ExporterStdout or ExporterNone; empty means
+	// none.
+	Exporter string
+	// ServiceName is reported unless OTEL_SERVICE_NAME overrides it.
+	ServiceName string
+}
+
+// Setup installs the global tracer provider for cfg and returns a function
+// that flushes and stops it. With no exporter it installs nothing and the
+// shutdown is a no-op. Sampling follows OTEL_TRACES_SAMPLER, parent-based
+// always-on by default.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(tracecontext.Propagator())
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(strings.TrimSpace(cfg.Exporter)) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q (want otlp, stdout or none)", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("init %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "bitmerchant"
+	}
+	// Attributes from the environment (OTEL_SERVICE_NAME,
+	// OTEL_RESOURCE_ATTRIBUTES) win over ours.
+	res, err := resource.Merge(
+		resource.NewSchemaless(semconv.ServiceName(serviceName)),
+		resource.Environment(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("init trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -169,9 +169,9 @@ func CartSummary(cart *cart.Cart, showCheckout bool) templ.Component {
 										return templ_7745c5c3_Err
 									}
 									var templ_7745c5c3_Var8 string
-									templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(money.FromMajor(mod.PriceDelta, cartCurrency(cart)).Format())
+									templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(money.New(mod.PriceDelta, cartCurrency(cart)).FormatContext(ctx))
 									if templ_7745c5c3_Err != nil {
-										return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 37, Col: 100}
+										return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 37, Col: 104}
 									}
 									_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 									if templ_7745c5c3_Err != nil {
@@ -216,9 +216,9 @@ func CartSummary(cart *cart.Cart, showCheckout bool) templ.Component {
 							return templ_7745c5c3_Err
 						}
 						var templ_7745c5c3_Var10 string
-						templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(money.FromMajor(item.UnitPrice+item.ModifierPrice, cartCurrency(cart)).Format())
+						templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(money.New(item.UnitPrice+item.ModifierPrice, cartCurrency(cart)).FormatContext(ctx))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 46, Col: 137}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 46, Col: 141}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
 						if templ_7745c5c3_Err != nil {
@@ -310,9 +310,9 @@ func CartSummary(cart *cart.Cart, showCheckout bool) templ.Component {
 							return templ_7745c5c3_Err
 						}
 						var templ_7745c5c3_Var14 string
-						templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(money.FromMajor(item.Subtotal, cartCurrency(cart)).Format())
+						templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(money.New(item.Subtotal, cartCurrency(cart)).FormatContext(ctx))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 75, Col: 113}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 75, Col: 117}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
 						if templ_7745c5c3_Err != nil {
@@ -356,9 +356,9 @@ func CartSummary(cart *cart.Cart, showCheckout bool) templ.Component {
 						return templ_7745c5c3_Err
 					}
 					var templ_7745c5c3_Var16 string
-					templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(cart.Money().Format())
+					templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(cart.Money().FormatContext(ctx))
 					if templ_7745c5c3_Err != nil {
-						return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 85, Col: 35}
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 85, Col: 45}
 					}
 					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
 					if templ_7745c5c3_Err != nil {
@@ -480,9 +480,9 @@ func CartFloatingButton(cart *cart.Cart) templ.Component {
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var21 string
-				templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(cart.Money().Format())
+				templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(cart.Money().FormatContext(ctx))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 123, Col: 52}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/components/cart_summary.templ`, Line: 123, Col: 62}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
 				if templ_7745c5c3_Err != nil {
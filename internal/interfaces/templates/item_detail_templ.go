@@ -348,9 +348,9 @@ func ItemDetailPage(item *menu.MenuItem, restaurantID, tableLabel, csrfToken, lo
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var16 string
-			templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(item.Money().Format())
+			templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(item.Money().FormatContext(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/item_detail.templ`, Line: 126, Col: 61}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/item_detail.templ`, Line: 126, Col: 71}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
 			if templ_7745c5c3_Err != nil {
@@ -666,7 +666,7 @@ func ItemDetailPage(item *menu.MenuItem, restaurantID, tableLabel, csrfToken, lo
 							Name:  fmt.Sprintf("mod_%s", group.ID),
 							Value: opt.ID,
 							Attributes: templ.Attributes{
-								"data-price-delta": fmt.Sprintf("%.4f", opt.PriceDelta),
+								"data-price-delta": item.OptionMoney(opt).FormatNoSymbol(),
 								"onchange":         "updateItemDetailTotal()",
 							},
 						}).Render(ctx, templ_7745c5c3_Buffer)
@@ -679,7 +679,7 @@ func ItemDetailPage(item *menu.MenuItem, restaurantID, tableLabel, csrfToken, lo
 							Name:  fmt.Sprintf("mod_%s", group.ID),
 							Value: opt.ID,
 							Attributes: templ.Attributes{
-								"data-price-delta": fmt.Sprintf("%.4f", opt.PriceDelta),
+								"data-price-delta": item.OptionMoney(opt).FormatNoSymbol(),
 								"onchange":         "updateItemDetailTotal()",
 							},
 						}).Render(ctx, templ_7745c5c3_Buffer)
@@ -737,9 +737,9 @@ func ItemDetailPage(item *menu.MenuItem, restaurantID, tableLabel, csrfToken, lo
 							return templ_7745c5c3_Err
 						}
 						var templ_7745c5c3_Var35 string
-						templ_7745c5c3_Var35, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%.2f", opt.PriceDelta))
+						templ_7745c5c3_Var35, templ_7745c5c3_Err = templ.JoinStringErrs(item.OptionMoney(opt).FormatNoSymbol())
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/item_detail.templ`, Line: 207, Col: 73}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/item_detail.templ`, Line: 207, Col: 76}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var35))
 						if templ_7745c5c3_Err != nil {
@@ -853,9 +853,9 @@ func ItemDetailPage(item *menu.MenuItem, restaurantID, tableLabel, csrfToken, lo
 					return templ_7745c5c3_Err
 				}
 				var templ_7745c5c3_Var39 string
-				templ_7745c5c3_Var39, templ_7745c5c3_Err = templ.JoinStringErrs(item.Money().Format())
+				templ_7745c5c3_Var39, templ_7745c5c3_Err = templ.JoinStringErrs(item.Money().FormatContext(ctx))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/item_detail.templ`, Line: 266, Col: 75}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/item_detail.templ`, Line: 266, Col: 85}
 				}
 				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var39))
 				if templ_7745c5c3_Err != nil {
@@ -892,7 +892,7 @@ func ItemDetailPage(item *menu.MenuItem, restaurantID, tableLabel, csrfToken, lo
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "\">\n\t\t\t(function () {\n\t\t\t\tvar basePrice = { fmt.Sprintf(\"%.4f\", item.Price) };\n\t\t\t\tvar currencyCode = '{ item.Currency.Code }';\n\t\t\t\tif (!currencyCode) currencyCode = 'USD';\n\t\t\t\tvar qty = 1;\n\n\t\t\t\tfunction fmtMoney(amount) {\n\t\t\t\t\ttry {\n\t\t\t\t\t\treturn new Intl.NumberFormat('en-US', { style: 'currency', currency: currencyCode }).format(amount);\n\t\t\t\t\t} catch (e) {\n\t\t\t\t\t\treturn '$' + amount.toFixed(2);\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\twindow.updateItemDetailTotal = function () {\n\t\t\t\t\tvar modTotal = 0;\n\t\t\t\t\tvar form = document.getElementById('item-detail-form');\n\t\t\t\t\tif (!form) return;\n\t\t\t\t\tfor (var el of form.elements) {\n\t\t\t\t\t\tif (!el.name || !el.name.startsWith('mod_')) continue;\n\t\t\t\t\t\tif ((el.type === 'radio' || el.type === 'checkbox') && el.checked) {\n\t\t\t\t\t\t\tmodTotal += parseFloat(el.getAttribute('data-price-delta') || '0');\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tvar total = (basePrice + modTotal) * qty;\n\t\t\t\t\tvar lbl = document.getElementById('item-detail-btn-label');\n\t\t\t\t\tif (lbl) lbl.textContent = 'Add to cart · ' + fmtMoney(total);\n\t\t\t\t};\n\n\t\t\t\twindow.changeItemDetailQty = function (delta) {\n\t\t\t\t\tqty = Math.max(1, qty + delta);\n\t\t\t\t\tvar display = document.getElementById('item-detail-qty-display');\n\t\t\t\t\tvar input = document.getElementById('item-detail-qty');\n\t\t\t\t\tif (display) display.textContent = qty;\n\t\t\t\t\tif (input) input.value = qty;\n\t\t\t\t\twindow.updateItemDetailTotal();\n\t\t\t\t};\n\n\t\t\t\twindow.validateItemDetail = function () {\n\t\t\t\t\tvar form = document.getElementById('item-detail-form');\n\t\t\t\t\tif (!form) return true;\n\t\t\t\t\tvar fieldsets = form.querySelectorAll('fieldset[data-group-required=\"true\"]');\n\t\t\t\t\tvar ok = true;\n\t\t\t\t\tfor (var fs of fieldsets) {\n\t\t\t\t\t\tvar groupID = fs.getAttribute('data-group-id');\n\t\t\t\t\t\tvar checked = fs.querySelector('input[type=\"radio\"]:checked');\n\t\t\t\t\t\tvar errEl = document.getElementById('group-' + groupID + '-err');\n\t\t\t\t\t\tif (!checked) {\n\t\t\t\t\t\t\tif (errEl) errEl.classList.remove('hidden');\n\t\t\t\t\t\t\tfs.scrollIntoView({ behavior: 'smooth', block: 'center' });\n\t\t\t\t\t\t\tok = false;\n\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\tif (errEl) errEl.classList.add('hidden');\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\treturn ok;\n\t\t\t\t};\n\t\t\t})();\n\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 56, "\">\n\t\t\t(function () {\n\t\t\t\tvar basePrice = { item.Money().FormatNoSymbol() };\n\t\t\t\tvar currencyCode = '{ item.Currency.Code }';\n\t\t\t\tif (!currencyCode) currencyCode = 'USD';\n\t\t\t\tvar qty = 1;\n\n\t\t\t\tfunction fmtMoney(amount) {\n\t\t\t\t\ttry {\n\t\t\t\t\t\treturn new Intl.NumberFormat('en-US', { style: 'currency', currency: currencyCode }).format(amount);\n\t\t\t\t\t} catch (e) {\n\t\t\t\t\t\treturn '$' + amount.toFixed(2);\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\twindow.updateItemDetailTotal = function () {\n\t\t\t\t\tvar modTotal = 0;\n\t\t\t\t\tvar form = document.getElementById('item-detail-form');\n\t\t\t\t\tif (!form) return;\n\t\t\t\t\tfor (var el of form.elements) {\n\t\t\t\t\t\tif (!el.name || !el.name.startsWith('mod_')) continue;\n\t\t\t\t\t\tif ((el.type === 'radio' || el.type === 'checkbox') && el.checked) {\n\t\t\t\t\t\t\tmodTotal += parseFloat(el.getAttribute('data-price-delta') || '0');\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tvar total = (basePrice + modTotal) * qty;\n\t\t\t\t\tvar lbl = document.getElementById('item-detail-btn-label');\n\t\t\t\t\tif (lbl) lbl.textContent = 'Add to cart · ' + fmtMoney(total);\n\t\t\t\t};\n\n\t\t\t\twindow.changeItemDetailQty = function (delta) {\n\t\t\t\t\tqty = Math.max(1, qty + delta);\n\t\t\t\t\tvar display = document.getElementById('item-detail-qty-display');\n\t\t\t\t\tvar input = document.getElementById('item-detail-qty');\n\t\t\t\t\tif (display) display.textContent = qty;\n\t\t\t\t\tif (input) input.value = qty;\n\t\t\t\t\twindow.updateItemDetailTotal();\n\t\t\t\t};\n\n\t\t\t\twindow.validateItemDetail = function () {\n\t\t\t\t\tvar form = document.getElementById('item-detail-form');\n\t\t\t\t\tif (!form) return true;\n\t\t\t\t\tvar fieldsets = form.querySelectorAll('fieldset[data-group-required=\"true\"]');\n\t\t\t\t\tvar ok = true;\n\t\t\t\t\tfor (var fs of fieldsets) {\n\t\t\t\t\t\tvar groupID = fs.getAttribute('data-group-id');\n\t\t\t\t\t\tvar checked = fs.querySelector('input[type=\"radio\"]:checked');\n\t\t\t\t\t\tvar errEl = document.getElementById('group-' + groupID + '-err');\n\t\t\t\t\t\tif (!checked) {\n\t\t\t\t\t\t\tif (errEl) errEl.classList.remove('hidden');\n\t\t\t\t\t\t\tfs.scrollIntoView({ behavior: 'smooth', block: 'center' });\n\t\t\t\t\t\t\tok = false;\n\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\tif (errEl) errEl.classList.add('hidden');\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\treturn ok;\n\t\t\t\t};\n\t\t\t})();\n\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -50,7 +50,13 @@ func kitchenCountByStatus(orders []*order.Order, status string) int {
 	return total
 }
 
-func kitchenLaneColumn(key string, title string, subtitle string, emptyText string, tone string) templ.Component {
+// KitchenLaneKeys are the kitchen board's lanes, left to right.
+var KitchenLaneKeys = []string{"waiting-start", "preparing", "ready"}
+
+// KitchenLane renders one lane with the orders whose status puts them in it.
+// The board page leaves lanes empty and lets the client route the cards from
+// KitchenOrdersList; stream snapshots fill them server-side.
+func KitchenLane(key string, orders []*order.Order) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -71,129 +77,219 @@ func kitchenLaneColumn(key string, title string, subtitle string, emptyText stri
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		var templ_7745c5c3_Var2 = []any{fmt.Sprintf("kitchen-lane-shell rounded-xl border p-4 space-y-3 bg-gradient-to-b %s", tone)}
-		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var2...)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div id=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var2 string
+		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs("lane-" + key)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 52, Col: 24}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<section id=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\" data-kitchen-lane=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var3 string
-		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs("lane-shell-" + key)
+		templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(key)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 46, Col: 34}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 52, Col: 50}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\" class=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\" class=\"min-h-[6rem] space-y-3\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var4 string
-		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var2).String())
-		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 1, Col: 0}
+		for _, o := range orders {
+			if kitchenStatusForSummary(o) == key {
+				templ_7745c5c3_Err = components.OrderCard(o).Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "\"><div class=\"flex items-start justify-between gap-3\"><div><h2 class=\"text-base font-semibold tracking-tight\">")
-		if templ_7745c5c3_Err != nil {
-			return templ_7745c5c3_Err
+		return nil
+	})
+}
+
+// KitchenOrdersList is the hidden staging list new order cards are patched
+// into before the client routes them to a lane.
+func KitchenOrdersList(orders []*order.Order) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
 		}
-		var templ_7745c5c3_Var5 string
-		templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(title)
-		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 49, Col: 62}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var4 == nil {
+			templ_7745c5c3_Var4 = templ.NopComponent
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<div id=\"orders-list\" class=\"hidden\" aria-hidden=\"true\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</h2><p class=\"text-xs text-muted-foreground mt-1\">")
+		for _, o := range orders {
+			templ_7745c5c3_Err = components.OrderCard(o).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var6 string
-		templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(subtitle)
-		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 50, Col: 60}
+		return nil
+	})
+}
+
+func kitchenLaneColumn(key string, title string, subtitle string, emptyText string, tone string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var5 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var5 == nil {
+			templ_7745c5c3_Var5 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		var templ_7745c5c3_Var6 = []any{fmt.Sprintf("kitchen-lane-shell rounded-xl border p-4 space-y-3 bg-gradient-to-b %s", tone)}
+		templ_7745c5c3_Err = templ.RenderCSSItems(ctx, templ_7745c5c3_Buffer, templ_7745c5c3_Var6...)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</p></div><span class=\"rounded-full border border-border bg-background/80 px-2 py-0.5 text-xs font-semibold tabular-nums\" data-lane-count=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<section id=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var7 string
-		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(key)
+		templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs("lane-shell-" + key)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 52, Col: 136}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 72, Col: 34}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\">0</span></div><div id=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\" class=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var8 string
-		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs("lane-" + key)
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(templ.CSSClasses(templ_7745c5c3_Var6).String())
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 54, Col: 25}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 1, Col: 0}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\" data-kitchen-lane=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "\"><div class=\"flex items-start justify-between gap-3\"><div><h2 class=\"text-base font-semibold tracking-tight\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var9 string
-		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(key)
+		templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(title)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 54, Col: 51}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 75, Col: 62}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\" class=\"min-h-[6rem] space-y-3\"></div><p data-lane-empty=\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</h2><p class=\"text-xs text-muted-foreground mt-1\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var10 string
-		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(key)
+		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(subtitle)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 55, Col: 26}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 76, Col: 60}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "\" class=\"rounded-md border border-dashed border-border/80 bg-background/60 px-3 py-3 text-sm text-muted-foreground\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</p></div><span class=\"rounded-full border border-border bg-background/80 px-2 py-0.5 text-xs font-semibold tabular-nums\" data-lane-count=\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		var templ_7745c5c3_Var11 string
-		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(emptyText)
+		templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(key)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 55, Col: 154}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 78, Col: 136}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</p></section>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "\">0</span></div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = KitchenLane(key, nil).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "<p data-lane-empty=\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var12 string
+		templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(key)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 81, Col: 26}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "\" class=\"rounded-md border border-dashed border-border/80 bg-background/60 px-3 py-3 text-sm text-muted-foreground\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var13 string
+		templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(emptyText)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 81, Col: 154}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "</p></section>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -217,12 +313,12 @@ func KitchenPage(orders []*order.Order, csrfToken string, activeRestaurantLabel
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var12 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var12 == nil {
-			templ_7745c5c3_Var12 = templ.NopComponent
+		templ_7745c5c3_Var14 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var14 == nil {
+			templ_7745c5c3_Var14 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Var13 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var15 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -234,98 +330,98 @@ func KitchenPage(orders []*order.Order, csrfToken string, activeRestaurantLabel
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "<div id=\"kitchen-display\" data-init=\"@get('/kitchen/stream')\" data-warning-minutes=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "<div id=\"kitchen-display\" data-init=\"@get('/kitchen/stream')\" data-warning-minutes=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var14 string
-			templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", warningMinutes))
+			var templ_7745c5c3_Var16 string
+			templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", warningMinutes))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 64, Col: 59}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 90, Col: 59}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "\" data-overdue-minutes=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "\" data-overdue-minutes=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var15 string
-			templ_7745c5c3_Var15, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", overdueMinutes))
+			var templ_7745c5c3_Var17 string
+			templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", overdueMinutes))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 65, Col: 59}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 91, Col: 59}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var15))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "\" class=\"space-y-6 mt-4 pb-6\"><div id=\"kitchen-stream-banner\" hidden role=\"alert\" class=\"flex items-center justify-between gap-3 rounded-lg border-2 border-amber-400 bg-amber-50 px-4 py-3 text-amber-900 dark:border-amber-500/60 dark:bg-amber-950/40 dark:text-amber-200\"><div class=\"flex items-center gap-2 min-w-0\"><svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-5 w-5 shrink-0\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><path d=\"M12 20h.01\"></path> <path d=\"M8.5 16.429a5 5 0 0 1 7 0\"></path> <path d=\"M5 12.859a10 10 0 0 1 5.17-2.69\"></path> <path d=\"M19 12.859a10 10 0 0 0-2.007-1.523\"></path> <path d=\"M2 8.82a15 15 0 0 1 4.177-2.643\"></path> <path d=\"M22 8.82a15 15 0 0 0-11.288-3.764\"></path> <path d=\"m2 2 20 20\"></path></svg><p id=\"kitchen-stream-banner-text\" class=\"text-sm font-medium\">Live updates offline — new orders may not appear.</p></div><button id=\"kitchen-banner-reconnect\" type=\"button\" class=\"shrink-0 rounded-md border border-amber-500/50 bg-background/70 px-3 py-1.5 text-sm font-semibold hover:bg-amber-100 dark:hover:bg-amber-900/40\">Reconnect</button></div><section class=\"relative overflow-hidden rounded-2xl bg-gradient-to-br from-background via-background to-muted/50 p-5\"><div class=\"absolute inset-0 pointer-events-none bg-[radial-gradient(circle_at_20%_20%,rgba(56,189,248,0.12),transparent_55%),radial-gradient(circle_at_80%_10%,rgba(16,185,129,0.1),transparent_45%)]\"></div><div class=\"relative space-y-2\"><div class=\"flex flex-wrap items-center justify-between gap-2\"><div class=\"flex flex-wrap items-center gap-2\"><h1 class=\"text-2xl font-bold tracking-tight\">Kitchen Board</h1><div id=\"kitchen-stream-state\" class=\"inline-flex items-center gap-1.5 rounded-full border border-border/80 bg-background/80 px-2 py-1\"><span id=\"kitchen-stream-dot\" class=\"kitchen-stream-dot\" data-state=\"connecting\"></span> <span id=\"kitchen-stream-label\" class=\"text-[10px] font-semibold uppercase tracking-[0.06em] leading-none text-muted-foreground\">Checking live updates...</span></div></div><div class=\"flex items-center gap-2\"><button id=\"kitchen-sound-toggle\" type=\"button\" class=\"inline-flex h-9 w-9 items-center justify-center rounded-md border border-border bg-background text-muted-foreground shadow-sm hover:bg-muted hover:text-foreground\" aria-label=\"Toggle sound alerts\" aria-pressed=\"true\" title=\"Toggle sound alerts\" data-sound-state=\"on\"><svg data-sound-icon=\"on\" xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon> <path d=\"M15.54 8.46a5 5 0 0 1 0 7.07\"></path> <path d=\"M19.07 4.93a10 10 0 0 1 0 14.14\"></path></svg> <svg data-sound-icon=\"off\" xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4 hidden\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon> <line x1=\"22\" y1=\"9\" x2=\"16\" y2=\"15\"></line> <line x1=\"16\" y1=\"9\" x2=\"22\" y2=\"15\"></line></svg></button> <button id=\"kitchen-stream-reconnect\" type=\"button\" class=\"inline-flex h-9 w-9 items-center justify-center rounded-md border border-border bg-background text-muted-foreground shadow-sm hover:bg-muted hover:text-foreground\" aria-label=\"Reconnect live updates\" title=\"Reconnect live updates\"><svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><path d=\"M3 12a9 9 0 0 1 15.6-6.2\"></path> <path d=\"M21 3v6h-6\"></path> <path d=\"M21 12a9 9 0 0 1-15.6 6.2\"></path> <path d=\"M3 21v-6h6\"></path></svg></button> <button id=\"kitchen-refetch\" type=\"button\" class=\"inline-flex h-9 w-9 items-center justify-center rounded-md border border-border bg-background text-muted-foreground shadow-sm hover:bg-muted hover:text-foreground\" aria-label=\"Refresh kitchen board\" title=\"Refresh kitchen board\"><svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><path d=\"M21 12a9 9 0 1 1-2.64-6.36\"></path> <path d=\"M21 3v6h-6\"></path></svg></button></div></div><p class=\"text-sm text-muted-foreground\">Orders grouped by stage, oldest first.</p></div><div id=\"kitchen-summary\" class=\"relative mt-4\"><div class=\"kitchen-summary-card rounded-lg border border-border/70 bg-background/80 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-muted-foreground\">Active orders</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"total\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "\" class=\"space-y-6 mt-4 pb-6\"><div id=\"kitchen-stream-banner\" hidden role=\"alert\" class=\"flex items-center justify-between gap-3 rounded-lg border-2 border-amber-400 bg-amber-50 px-4 py-3 text-amber-900 dark:border-amber-500/60 dark:bg-amber-950/40 dark:text-amber-200\"><div class=\"flex items-center gap-2 min-w-0\"><svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-5 w-5 shrink-0\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><path d=\"M12 20h.01\"></path> <path d=\"M8.5 16.429a5 5 0 0 1 7 0\"></path> <path d=\"M5 12.859a10 10 0 0 1 5.17-2.69\"></path> <path d=\"M19 12.859a10 10 0 0 0-2.007-1.523\"></path> <path d=\"M2 8.82a15 15 0 0 1 4.177-2.643\"></path> <path d=\"M22 8.82a15 15 0 0 0-11.288-3.764\"></path> <path d=\"m2 2 20 20\"></path></svg><p id=\"kitchen-stream-banner-text\" class=\"text-sm font-medium\">Live updates offline — new orders may not appear.</p></div><button id=\"kitchen-banner-reconnect\" type=\"button\" class=\"shrink-0 rounded-md border border-amber-500/50 bg-background/70 px-3 py-1.5 text-sm font-semibold hover:bg-amber-100 dark:hover:bg-amber-900/40\">Reconnect</button></div><section class=\"relative overflow-hidden rounded-2xl bg-gradient-to-br from-background via-background to-muted/50 p-5\"><div class=\"absolute inset-0 pointer-events-none bg-[radial-gradient(circle_at_20%_20%,rgba(56,189,248,0.12),transparent_55%),radial-gradient(circle_at_80%_10%,rgba(16,185,129,0.1),transparent_45%)]\"></div><div class=\"relative space-y-2\"><div class=\"flex flex-wrap items-center justify-between gap-2\"><div class=\"flex flex-wrap items-center gap-2\"><h1 class=\"text-2xl font-bold tracking-tight\">Kitchen Board</h1><div id=\"kitchen-stream-state\" class=\"inline-flex items-center gap-1.5 rounded-full border border-border/80 bg-background/80 px-2 py-1\"><span id=\"kitchen-stream-dot\" class=\"kitchen-stream-dot\" data-state=\"connecting\"></span> <span id=\"kitchen-stream-label\" class=\"text-[10px] font-semibold uppercase tracking-[0.06em] leading-none text-muted-foreground\">Checking live updates...</span></div></div><div class=\"flex items-center gap-2\"><button id=\"kitchen-sound-toggle\" type=\"button\" class=\"inline-flex h-9 w-9 items-center justify-center rounded-md border border-border bg-background text-muted-foreground shadow-sm hover:bg-muted hover:text-foreground\" aria-label=\"Toggle sound alerts\" aria-pressed=\"true\" title=\"Toggle sound alerts\" data-sound-state=\"on\"><svg data-sound-icon=\"on\" xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon> <path d=\"M15.54 8.46a5 5 0 0 1 0 7.07\"></path> <path d=\"M19.07 4.93a10 10 0 0 1 0 14.14\"></path></svg> <svg data-sound-icon=\"off\" xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4 hidden\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon> <line x1=\"22\" y1=\"9\" x2=\"16\" y2=\"15\"></line> <line x1=\"16\" y1=\"9\" x2=\"22\" y2=\"15\"></line></svg></button> <button id=\"kitchen-stream-reconnect\" type=\"button\" class=\"inline-flex h-9 w-9 items-center justify-center rounded-md border border-border bg-background text-muted-foreground shadow-sm hover:bg-muted hover:text-foreground\" aria-label=\"Reconnect live updates\" title=\"Reconnect live updates\"><svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><path d=\"M3 12a9 9 0 0 1 15.6-6.2\"></path> <path d=\"M21 3v6h-6\"></path> <path d=\"M21 12a9 9 0 0 1-15.6 6.2\"></path> <path d=\"M3 21v-6h6\"></path></svg></button> <button id=\"kitchen-refetch\" type=\"button\" class=\"inline-flex h-9 w-9 items-center justify-center rounded-md border border-border bg-background text-muted-foreground shadow-sm hover:bg-muted hover:text-foreground\" aria-label=\"Refresh kitchen board\" title=\"Refresh kitchen board\"><svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 24 24\" class=\"h-4 w-4\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" aria-hidden=\"true\"><path d=\"M21 12a9 9 0 1 1-2.64-6.36\"></path> <path d=\"M21 3v6h-6\"></path></svg></button></div></div><p class=\"text-sm text-muted-foreground\">Orders grouped by stage, oldest first.</p></div><div id=\"kitchen-summary\" class=\"relative mt-4\"><div class=\"kitchen-summary-card rounded-lg border border-border/70 bg-background/80 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-muted-foreground\">Active orders</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"total\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var16 string
-			templ_7745c5c3_Var16, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", len(orders)))
+			var templ_7745c5c3_Var18 string
+			templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", len(orders)))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 157, Col: 130}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 183, Col: 130}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var16))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "</p></div><a href=\"/server\" title=\"Open Server / FOH view to mark orders paid\" class=\"kitchen-summary-card rounded-lg border border-amber-500/30 bg-amber-500/10 px-2 py-2 md:px-3 transition hover:bg-amber-500/20 focus-visible:outline focus-visible:outline-2 focus-visible:outline-amber-500\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-amber-700 dark:text-amber-300\">Unpaid</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"unpaid\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "</p></div><a href=\"/server\" title=\"Open Server / FOH view to mark orders paid\" class=\"kitchen-summary-card rounded-lg border border-amber-500/30 bg-amber-500/10 px-2 py-2 md:px-3 transition hover:bg-amber-500/20 focus-visible:outline focus-visible:outline-2 focus-visible:outline-amber-500\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-amber-700 dark:text-amber-300\">Unpaid</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"unpaid\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var17 string
-			templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenUnpaidCount(orders)))
+			var templ_7745c5c3_Var19 string
+			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenUnpaidCount(orders)))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 165, Col: 146}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 191, Col: 146}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, "</p></a><div class=\"kitchen-summary-card rounded-lg border border-indigo-500/30 bg-indigo-500/10 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-indigo-700 dark:text-indigo-300\">Waiting to start</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"waiting-start\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "</p></a><div class=\"kitchen-summary-card rounded-lg border border-indigo-500/30 bg-indigo-500/10 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-indigo-700 dark:text-indigo-300\">Waiting to start</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"waiting-start\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var18 string
-			templ_7745c5c3_Var18, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenCountByStatus(orders, "waiting-start")))
+			var templ_7745c5c3_Var20 string
+			templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenCountByStatus(orders, "waiting-start")))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 169, Col: 172}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 195, Col: 172}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var18))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</p></div><div class=\"kitchen-summary-card rounded-lg border border-sky-500/30 bg-sky-500/10 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-sky-700 dark:text-sky-300\">Preparing</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"preparing\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "</p></div><div class=\"kitchen-summary-card rounded-lg border border-sky-500/30 bg-sky-500/10 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-sky-700 dark:text-sky-300\">Preparing</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"preparing\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var19 string
-			templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenCountByStatus(orders, "preparing")))
+			var templ_7745c5c3_Var21 string
+			templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenCountByStatus(orders, "preparing")))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 173, Col: 164}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 199, Col: 164}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "</p></div><div class=\"kitchen-summary-card rounded-lg border border-emerald-500/30 bg-emerald-500/10 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-emerald-700 dark:text-emerald-300\">Ready</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"ready\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "</p></div><div class=\"kitchen-summary-card rounded-lg border border-emerald-500/30 bg-emerald-500/10 px-2 py-2 md:px-3\"><p class=\"text-xs font-medium uppercase tracking-[0.08em] text-emerald-700 dark:text-emerald-300\">Ready</p><p class=\"mt-0.5 text-xl font-semibold tabular-nums md:text-2xl\" data-summary-count=\"ready\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var20 string
-			templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenCountByStatus(orders, "ready")))
+			var templ_7745c5c3_Var22 string
+			templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", kitchenCountByStatus(orders, "ready")))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 177, Col: 156}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 203, Col: 156}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var22))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "</p></div></div></section><div id=\"kitchen-lanes\" class=\"kitchen-lanes\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "</p></div></div></section><div id=\"kitchen-lanes\" class=\"kitchen-lanes\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -341,94 +437,92 @@ func KitchenPage(orders []*order.Order, csrfToken string, activeRestaurantLabel
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "</div><div id=\"orders-list\" class=\"hidden\" aria-hidden=\"true\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "</div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			for _, order := range orders {
-				templ_7745c5c3_Err = components.OrderCard(order).Render(ctx, templ_7745c5c3_Buffer)
-				if templ_7745c5c3_Err != nil {
-					return templ_7745c5c3_Err
-				}
+			templ_7745c5c3_Err = KitchenOrdersList(orders).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "</div></div><style nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "</div><style nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var21 string
-			templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			var templ_7745c5c3_Var23 string
+			templ_7745c5c3_Var23, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 194, Col: 36}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 216, Col: 36}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var23))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "\">\n\t\t\t#kitchen-summary {\n\t\t\t\tdisplay: flex;\n\t\t\t\tgap: 0.5rem;\n\t\t\t\toverflow-x: auto;\n\t\t\t\tpadding-bottom: 0.25rem;\n\t\t\t\tscroll-snap-type: x mandatory;\n\t\t\t\tscrollbar-width: none;\n\t\t\t}\n\t\t\t#kitchen-summary::-webkit-scrollbar {\n\t\t\t\tdisplay: none;\n\t\t\t}\n\t\t\t#kitchen-summary .kitchen-summary-card {\n\t\t\t\tmin-width: 8.5rem;\n\t\t\t\tflex: 0 0 auto;\n\t\t\t\tscroll-snap-align: start;\n\t\t\t}\n\t\t\t#kitchen-lanes {\n\t\t\t\tdisplay: flex;\n\t\t\t\tflex-direction: column;\n\t\t\t\tgap: 1rem;\n\t\t\t\toverflow: visible;\n\t\t\t\tpadding-bottom: 0;\n\t\t\t\tscroll-snap-type: none;\n\t\t\t}\n\t\t\t#kitchen-lanes .kitchen-lane-shell {\n\t\t\t\tmin-width: 0;\n\t\t\t\tflex: initial;\n\t\t\t}\n\t\t\t.kitchen-stream-dot {\n\t\t\t\tdisplay: inline-block;\n\t\t\t\twidth: 0.625rem;\n\t\t\t\theight: 0.625rem;\n\t\t\t\tflex-shrink: 0;\n\t\t\t\tborder-radius: 9999px;\n\t\t\t\tbackground: #f59e0b;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(245, 158, 11, 0.35);\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"connected\"] {\n\t\t\t\tbackground: #10b981;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(16, 185, 129, 0.35);\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"connecting\"] {\n\t\t\t\tbackground: #f59e0b;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(245, 158, 11, 0.35);\n\t\t\t\tanimation: kitchen-dot-pulse 1.2s infinite ease-in-out;\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"disconnected\"] {\n\t\t\t\tbackground: #ef4444;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(239, 68, 68, 0.35);\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"unsupported\"] {\n\t\t\t\tbackground: #71717a;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(113, 113, 122, 0.3);\n\t\t\t}\n\t\t\t@keyframes kitchen-dot-pulse {\n\t\t\t\t0% { transform: scale(0.9); opacity: 0.8; }\n\t\t\t\t50% { transform: scale(1); opacity: 1; }\n\t\t\t\t100% { transform: scale(0.9); opacity: 0.8; }\n\t\t\t}\n\t\t\t@media (min-width: 768px) {\n\t\t\t\t#kitchen-summary {\n\t\t\t\t\tdisplay: grid;\n\t\t\t\t\tgrid-template-columns: repeat(5, minmax(0, 1fr));\n\t\t\t\t\toverflow: visible;\n\t\t\t\t\tpadding-bottom: 0;\n\t\t\t\t\tscroll-snap-type: none;\n\t\t\t\t}\n\t\t\t\t#kitchen-summary .kitchen-summary-card {\n\t\t\t\t\tmin-width: 0;\n\t\t\t\t\tflex: initial;\n\t\t\t\t}\n\t\t\t\t#kitchen-lanes {\n\t\t\t\t\tdisplay: grid;\n\t\t\t\t\tgrid-template-columns: repeat(2, minmax(0, 1fr));\n\t\t\t\t\toverflow: visible;\n\t\t\t\t}\n\t\t\t\t#kitchen-lanes .kitchen-lane-shell {\n\t\t\t\t\tmin-width: 0;\n\t\t\t\t\tflex: initial;\n\t\t\t\t}\n\t\t\t}\n\t\t\t@media (min-width: 1280px) {\n\t\t\t\t#kitchen-lanes {\n\t\t\t\t\tgrid-template-columns: repeat(4, minmax(0, 1fr));\n\t\t\t\t}\n\t\t\t}\n\t\t</style> <script nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "\">\n\t\t\t#kitchen-summary {\n\t\t\t\tdisplay: flex;\n\t\t\t\tgap: 0.5rem;\n\t\t\t\toverflow-x: auto;\n\t\t\t\tpadding-bottom: 0.25rem;\n\t\t\t\tscroll-snap-type: x mandatory;\n\t\t\t\tscrollbar-width: none;\n\t\t\t}\n\t\t\t#kitchen-summary::-webkit-scrollbar {\n\t\t\t\tdisplay: none;\n\t\t\t}\n\t\t\t#kitchen-summary .kitchen-summary-card {\n\t\t\t\tmin-width: 8.5rem;\n\t\t\t\tflex: 0 0 auto;\n\t\t\t\tscroll-snap-align: start;\n\t\t\t}\n\t\t\t#kitchen-lanes {\n\t\t\t\tdisplay: flex;\n\t\t\t\tflex-direction: column;\n\t\t\t\tgap: 1rem;\n\t\t\t\toverflow: visible;\n\t\t\t\tpadding-bottom: 0;\n\t\t\t\tscroll-snap-type: none;\n\t\t\t}\n\t\t\t#kitchen-lanes .kitchen-lane-shell {\n\t\t\t\tmin-width: 0;\n\t\t\t\tflex: initial;\n\t\t\t}\n\t\t\t.kitchen-stream-dot {\n\t\t\t\tdisplay: inline-block;\n\t\t\t\twidth: 0.625rem;\n\t\t\t\theight: 0.625rem;\n\t\t\t\tflex-shrink: 0;\n\t\t\t\tborder-radius: 9999px;\n\t\t\t\tbackground: #f59e0b;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(245, 158, 11, 0.35);\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"connected\"] {\n\t\t\t\tbackground: #10b981;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(16, 185, 129, 0.35);\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"connecting\"] {\n\t\t\t\tbackground: #f59e0b;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(245, 158, 11, 0.35);\n\t\t\t\tanimation: kitchen-dot-pulse 1.2s infinite ease-in-out;\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"disconnected\"] {\n\t\t\t\tbackground: #ef4444;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(239, 68, 68, 0.35);\n\t\t\t}\n\t\t\t.kitchen-stream-dot[data-state=\"unsupported\"] {\n\t\t\t\tbackground: #71717a;\n\t\t\t\tbox-shadow: 0 0 0 2px rgba(113, 113, 122, 0.3);\n\t\t\t}\n\t\t\t@keyframes kitchen-dot-pulse {\n\t\t\t\t0% { transform: scale(0.9); opacity: 0.8; }\n\t\t\t\t50% { transform: scale(1); opacity: 1; }\n\t\t\t\t100% { transform: scale(0.9); opacity: 0.8; }\n\t\t\t}\n\t\t\t@media (min-width: 768px) {\n\t\t\t\t#kitchen-summary {\n\t\t\t\t\tdisplay: grid;\n\t\t\t\t\tgrid-template-columns: repeat(5, minmax(0, 1fr));\n\t\t\t\t\toverflow: visible;\n\t\t\t\t\tpadding-bottom: 0;\n\t\t\t\t\tscroll-snap-type: none;\n\t\t\t\t}\n\t\t\t\t#kitchen-summary .kitchen-summary-card {\n\t\t\t\t\tmin-width: 0;\n\t\t\t\t\tflex: initial;\n\t\t\t\t}\n\t\t\t\t#kitchen-lanes {\n\t\t\t\t\tdisplay: grid;\n\t\t\t\t\tgrid-template-columns: repeat(2, minmax(0, 1fr));\n\t\t\t\t\toverflow: visible;\n\t\t\t\t}\n\t\t\t\t#kitchen-lanes .kitchen-lane-shell {\n\t\t\t\t\tmin-width: 0;\n\t\t\t\t\tflex: initial;\n\t\t\t\t}\n\t\t\t}\n\t\t\t@media (min-width: 1280px) {\n\t\t\t\t#kitchen-lanes {\n\t\t\t\t\tgrid-template-columns: repeat(4, minmax(0, 1fr));\n\t\t\t\t}\n\t\t\t}\n\t\t</style> <script nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var22 string
-			templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			var templ_7745c5c3_Var24 string
+			templ_7745c5c3_Var24, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 282, Col: 37}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 304, Col: 37}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var22))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var24))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "\">\n\t\t\t(function () {\n\t\t\t\tconst root = document.getElementById(\"kitchen-display\");\n\t\t\t\tif (!root) return;\n\n\t\t\t\tconst laneElements = {\n\t\t\t\t\t\"waiting-start\": document.getElementById(\"lane-waiting-start\"),\n\t\t\t\t\t\"preparing\": document.getElementById(\"lane-preparing\"),\n\t\t\t\t\t\"ready\": document.getElementById(\"lane-ready\"),\n\t\t\t\t};\n\t\t\t\tconst streamDot = document.getElementById(\"kitchen-stream-dot\");\n\t\t\t\tconst streamLabel = document.getElementById(\"kitchen-stream-label\");\n\t\t\t\tconst streamReconnectButton = document.getElementById(\"kitchen-stream-reconnect\");\n\t\t\t\tconst streamBanner = document.getElementById(\"kitchen-stream-banner\");\n\t\t\t\tconst streamBannerText = document.getElementById(\"kitchen-stream-banner-text\");\n\t\t\t\tconst bannerReconnectButton = document.getElementById(\"kitchen-banner-reconnect\");\n\t\t\t\tconst refetchButton = document.getElementById(\"kitchen-refetch\");\n\n\t\t\t\tconst summaryCountElements = {\n\t\t\t\t\ttotal: root.querySelector('[data-summary-count=\"total\"]'),\n\t\t\t\t\tunpaid: root.querySelector('[data-summary-count=\"unpaid\"]'),\n\t\t\t\t\t\"waiting-start\": root.querySelector('[data-summary-count=\"waiting-start\"]'),\n\t\t\t\t\tpreparing: root.querySelector('[data-summary-count=\"preparing\"]'),\n\t\t\t\t\tready: root.querySelector('[data-summary-count=\"ready\"]'),\n\t\t\t\t};\n\t\t\t\tconst laneCountElements = {};\n\t\t\t\troot.querySelectorAll(\"[data-lane-count]\").forEach((element) => {\n\t\t\t\t\tconst key = element.getAttribute(\"data-lane-count\");\n\t\t\t\t\tif (key) laneCountElements[key] = element;\n\t\t\t\t});\n\t\t\t\tconst laneEmptyElements = {};\n\t\t\t\troot.querySelectorAll(\"[data-lane-empty]\").forEach((element) => {\n\t\t\t\t\tconst key = element.getAttribute(\"data-lane-empty\");\n\t\t\t\t\tif (key) laneEmptyElements[key] = element;\n\t\t\t\t});\n\n\t\t\t\tfunction allCards() {\n\t\t\t\t\treturn Array.from(root.querySelectorAll(\".kitchen-order-card\"));\n\t\t\t\t}\n\n\t\t\t\tfunction statusOf(card) {\n\t\t\t\t\tconst raw = (card.getAttribute(\"data-kitchen-status\") || \"waiting-start\").toLowerCase();\n\t\t\t\t\tif (raw === \"completed\") return \"completed\";\n\t\t\t\t\tif (laneElements[raw]) return raw;\n\t\t\t\t\treturn \"waiting-start\";\n\t\t\t\t}\n\n\t\t\t\tfunction createdAtOf(card) {\n\t\t\t\t\tconst value = Number.parseInt(card.getAttribute(\"data-order-created-at\") || \"0\", 10);\n\t\t\t\t\treturn Number.isFinite(value) ? value : 0;\n\t\t\t\t}\n\n\t\t\t\tfunction routeCard(card) {\n\t\t\t\t\tconst status = statusOf(card);\n\t\t\t\t\tif (status === \"completed\") {\n\t\t\t\t\t\tcard.remove();\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tconst lane = laneElements[status];\n\t\t\t\t\tif (!lane) return;\n\t\t\t\t\tif (card.parentElement !== lane) {\n\t\t\t\t\t\tlane.appendChild(card);\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction sortLane(lane) {\n\t\t\t\t\tif (!lane) return;\n\t\t\t\t\tconst cards = Array.from(lane.querySelectorAll(\".kitchen-order-card\"));\n\t\t\t\t\tif (cards.length < 2) return;\n\t\t\t\t\tconst sorted = [...cards].sort((a, b) => createdAtOf(a) - createdAtOf(b));\n\t\t\t\t\tlet changed = false;\n\t\t\t\t\tfor (let i = 0; i < cards.length; i += 1) {\n\t\t\t\t\t\tif (cards[i] !== sorted[i]) {\n\t\t\t\t\t\t\tchanged = true;\n\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tif (!changed) return;\n\t\t\t\t\tsorted.forEach((card) => lane.appendChild(card));\n\t\t\t\t}\n\n\t\t\t\tfunction ageLabel(minutes) {\n\t\t\t\t\tif (minutes < 1) return \"now\";\n\t\t\t\t\tif (minutes < 60) return `${minutes}m`;\n\t\t\t\t\tconst hours = Math.floor(minutes / 60);\n\t\t\t\t\tconst remaining = minutes % 60;\n\t\t\t\t\tif (hours < 24) return remaining === 0 ? `${hours}h` : `${hours}h ${remaining}m`;\n\t\t\t\t\treturn `${Math.floor(hours / 24)}d`;\n\t\t\t\t}\n\n\t\t\t\tconst warningMinutes = parseInt(root.dataset.warningMinutes || \"8\", 10) || 8;\n\t\t\t\tconst overdueMinutes = parseInt(root.dataset.overdueMinutes || \"12\", 10) || 12;\n\n\t\t\t\t// Tier classes for the card ring, the age timer, and the primary CTA.\n\t\t\t\tconst CARD_WARNING = [\"ring-2\", \"ring-amber-400/70\", \"ring-offset-1\"];\n\t\t\t\tconst CARD_OVERDUE = [\"ring-2\", \"ring-red-500/80\", \"ring-offset-1\", \"shadow-[0_0_18px_rgba(239,68,68,0.55)]\"];\n\t\t\t\tconst TIMER_WARNING = [\"text-amber-600\", \"dark:text-amber-300\", \"font-bold\"];\n\t\t\t\tconst TIMER_OVERDUE = [\"text-red-600\", \"dark:text-red-400\", \"font-bold\"];\n\t\t\t\tconst CTA_WARNING = [\"ring-1\", \"ring-amber-400/70\"];\n\t\t\t\tconst CTA_OVERDUE = [\"ring-2\", \"ring-red-500/80\"];\n\n\t\t\t\tfunction tierOf(minutes) {\n\t\t\t\t\tif (minutes >= overdueMinutes) return \"overdue\";\n\t\t\t\t\tif (minutes >= warningMinutes) return \"warning\";\n\t\t\t\t\treturn \"nominal\";\n\t\t\t\t}\n\n\t\t\t\tfunction applyTier(card, tier) {\n\t\t\t\t\tcard.classList.remove(...CARD_WARNING, ...CARD_OVERDUE);\n\t\t\t\t\tif (tier === \"warning\") card.classList.add(...CARD_WARNING);\n\t\t\t\t\telse if (tier === \"overdue\") card.classList.add(...CARD_OVERDUE);\n\n\t\t\t\t\tconst ageNode = card.querySelector(\"[data-order-age]\");\n\t\t\t\t\tif (ageNode) {\n\t\t\t\t\t\tageNode.classList.remove(...TIMER_WARNING, ...TIMER_OVERDUE);\n\t\t\t\t\t\tif (tier === \"warning\") ageNode.classList.add(...TIMER_WARNING);\n\t\t\t\t\t\telse if (tier === \"overdue\") ageNode.classList.add(...TIMER_OVERDUE);\n\t\t\t\t\t}\n\n\t\t\t\t\t// Primary CTA (Start Preparing / Mark Ready / Close Ticket): tint it and,\n\t\t\t\t\t// when overdue, append the \"· OVERDUE\" suffix the audit calls for.\n\t\t\t\t\tconst cta = card.querySelector(\"[data-kitchen-action]\");\n\t\t\t\t\tif (cta) {\n\t\t\t\t\t\tcta.classList.remove(...CTA_WARNING, ...CTA_OVERDUE);\n\t\t\t\t\t\tif (tier === \"warning\") cta.classList.add(...CTA_WARNING);\n\t\t\t\t\t\telse if (tier === \"overdue\") cta.classList.add(...CTA_OVERDUE);\n\t\t\t\t\t\tlet badge = cta.querySelector(\"[data-overdue-suffix]\");\n\t\t\t\t\t\tif (tier === \"overdue\") {\n\t\t\t\t\t\t\tif (!badge) {\n\t\t\t\t\t\t\t\tbadge = document.createElement(\"span\");\n\t\t\t\t\t\t\t\tbadge.setAttribute(\"data-overdue-suffix\", \"\");\n\t\t\t\t\t\t\t\tbadge.className = \"ml-1 font-bold uppercase tracking-wide\";\n\t\t\t\t\t\t\t\tbadge.textContent = \" · OVERDUE\";\n\t\t\t\t\t\t\t\tcta.appendChild(badge);\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t} else if (badge) {\n\t\t\t\t\t\t\tbadge.remove();\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction updateAges() {\n\t\t\t\t\tconst nowUnix = Math.floor(Date.now() / 1000);\n\t\t\t\t\tallCards().forEach((card) => {\n\t\t\t\t\t\tconst ageNode = card.querySelector(\"[data-order-age]\");\n\t\t\t\t\t\tif (!ageNode) return;\n\t\t\t\t\t\tconst createdAt = createdAtOf(card);\n\t\t\t\t\t\tif (createdAt <= 0) {\n\t\t\t\t\t\t\tageNode.textContent = \"Unknown\";\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tconst minutes = Math.max(0, Math.floor((nowUnix - createdAt) / 60));\n\t\t\t\t\t\tageNode.textContent = ageLabel(minutes);\n\t\t\t\t\t\tapplyTier(card, tierOf(minutes));\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction updateCountsAndEmptyStates() {\n\t\t\t\t\tconst counts = {\n\t\t\t\t\t\t\"waiting-start\": 0,\n\t\t\t\t\t\tpreparing: 0,\n\t\t\t\t\t\tready: 0,\n\t\t\t\t\t};\n\n\t\t\t\t\tObject.keys(laneElements).forEach((key) => {\n\t\t\t\t\t\tconst lane = laneElements[key];\n\t\t\t\t\t\tconst value = lane ? lane.querySelectorAll(\".kitchen-order-card\").length : 0;\n\t\t\t\t\t\tcounts[key] = value;\n\t\t\t\t\t\tif (laneCountElements[key]) laneCountElements[key].textContent = String(value);\n\t\t\t\t\t\tif (laneEmptyElements[key]) laneEmptyElements[key].classList.toggle(\"hidden\", value > 0);\n\t\t\t\t\t});\n\n\t\t\t\t\tcounts.total = counts[\"waiting-start\"] + counts.preparing + counts.ready;\n\t\t\t\t\tcounts.unpaid = allCards().filter((c) => c.getAttribute(\"data-order-unpaid\") === \"true\").length;\n\t\t\t\t\tObject.keys(summaryCountElements).forEach((key) => {\n\t\t\t\t\t\tconst node = summaryCountElements[key];\n\t\t\t\t\tif (!node) return;\n\t\t\t\t\tconst value = counts[key] || 0;\n\t\t\t\t\tnode.textContent = String(value);\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tlet streamProbe = null;\n\t\t\t\tfunction setStreamStatus(status) {\n\t\t\t\t\tif (!streamDot || !streamLabel) return;\n\t\t\t\t\tlet state = \"unsupported\";\n\t\t\t\t\t// The banner only appears for genuine problems (offline / unsupported)\n\t\t\t\t\t// so a normal connecting→connected load doesn't flash a warning.\n\t\t\t\t\tlet bannerText = \"\";\n\t\t\t\t\tswitch (status) {\n\t\t\t\t\tcase \"connected\":\n\t\t\t\t\t\tstate = \"connected\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Live updates on\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\tcase \"connecting\":\n\t\t\t\t\t\tstate = \"connecting\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Connecting live updates...\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\tcase \"disconnected\":\n\t\t\t\t\t\tstate = \"disconnected\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Live updates offline\";\n\t\t\t\t\t\tbannerText = \"Live updates offline — new orders may not appear. Reconnect to catch up.\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\tdefault:\n\t\t\t\t\t\tstate = \"unsupported\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Live updates unavailable\";\n\t\t\t\t\t\tbannerText = \"Live updates aren't supported in this browser. Refresh to see new orders.\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\t}\n\t\t\t\t\tstreamDot.setAttribute(\"data-state\", state);\n\t\t\t\t\tif (streamBanner) {\n\t\t\t\t\t\tif (bannerText) {\n\t\t\t\t\t\t\tif (streamBannerText) streamBannerText.textContent = bannerText;\n\t\t\t\t\t\t\tstreamBanner.hidden = false;\n\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\tstreamBanner.hidden = true;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction connectStreamProbe() {\n\t\t\t\t\tif (!window.EventSource) {\n\t\t\t\t\t\tsetStreamStatus(\"unsupported\");\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tif (streamProbe) {\n\t\t\t\t\t\tstreamProbe.close();\n\t\t\t\t\t\tstreamProbe = null;\n\t\t\t\t\t}\n\t\t\t\t\tsetStreamStatus(\"connecting\");\n\t\t\t\t\tconst probe = new EventSource(\"/kitchen/stream\");\n\t\t\t\t\tstreamProbe = probe;\n\t\t\t\t\tprobe.onopen = () => {\n\t\t\t\t\t\tsetStreamStatus(\"connected\");\n\t\t\t\t\t};\n\t\t\t\t\tprobe.onerror = () => {\n\t\t\t\t\t\tsetStreamStatus(\"disconnected\");\n\t\t\t\t\t};\n\t\t\t\t}\n\n\t\t\t\tif (streamReconnectButton instanceof HTMLButtonElement) {\n\t\t\t\t\tstreamReconnectButton.addEventListener(\"click\", () => {\n\t\t\t\t\t\tconnectStreamProbe();\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tif (bannerReconnectButton instanceof HTMLButtonElement) {\n\t\t\t\t\tbannerReconnectButton.addEventListener(\"click\", () => {\n\t\t\t\t\t\tconnectStreamProbe();\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tif (refetchButton instanceof HTMLButtonElement) {\n\t\t\t\t\trefetchButton.addEventListener(\"click\", () => {\n\t\t\t\t\t\twindow.location.reload();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tlet syncing = false;\n\t\t\t\tfunction syncBoard() {\n\t\t\t\t\tif (syncing) return;\n\t\t\t\t\tsyncing = true;\n\t\t\t\t\ttry {\n\t\t\t\t\t\tallCards().forEach(routeCard);\n\t\t\t\t\t\tObject.values(laneElements).forEach(sortLane);\n\t\t\t\t\t\tupdateCountsAndEmptyStates();\n\t\t\t\t\t\tupdateAges();\n\t\t\t\t\t} finally {\n\t\t\t\t\t\tsyncing = false;\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tlet frame = 0;\n\t\t\t\tfunction requestSync() {\n\t\t\t\t\tif (frame) return;\n\t\t\t\t\tframe = window.requestAnimationFrame(() => {\n\t\t\t\t\t\tframe = 0;\n\t\t\t\t\t\tsyncBoard();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tconst observer = new MutationObserver((mutations) => {\n\t\t\t\t\tif (syncing) return;\n\t\t\t\t\tfor (const mutation of mutations) {\n\t\t\t\t\t\tif (mutation.type === \"attributes\") {\n\t\t\t\t\t\t\tconst target = mutation.target;\n\t\t\t\t\t\t\tif (target instanceof HTMLElement && target.classList.contains(\"kitchen-order-card\")) {\n\t\t\t\t\t\t\t\trequestSync();\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t\tfor (const node of mutation.addedNodes) {\n\t\t\t\t\t\t\tif (!(node instanceof HTMLElement)) continue;\n\t\t\t\t\t\t\tif (node.classList.contains(\"kitchen-order-card\") || node.querySelector(\".kitchen-order-card\")) {\n\t\t\t\t\t\t\t\trequestSync();\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tobserver.observe(root, {\n\t\t\t\t\tchildList: true,\n\t\t\t\t\tsubtree: true,\n\t\t\t\t\tattributes: true,\n\t\t\t\t\tattributeFilter: [\"data-kitchen-status\", \"data-order-created-at\"],\n\t\t\t\t});\n\n\t\t\t\tfunction labelForAction(action) {\n\t\t\t\t\tswitch (action) {\n\t\t\t\t\tcase \"mark-preparing\":\n\t\t\t\t\t\treturn \"Start Preparing\";\n\t\t\t\t\tcase \"mark-ready\":\n\t\t\t\t\t\treturn \"Mark Ready\";\n\t\t\t\t\tcase \"mark-completed\":\n\t\t\t\t\t\treturn \"Close Ticket\";\n\t\t\t\t\tdefault:\n\t\t\t\t\t\treturn \"Update\";\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\troot.addEventListener(\"click\", (event) => {\n\t\t\t\t\tif (!(event.target instanceof Element)) return;\n\t\t\t\t\tconst actionButton = event.target.closest(\"[data-kitchen-action]\");\n\t\t\t\t\tif (!(actionButton instanceof HTMLButtonElement)) return;\n\t\t\t\t\tif (actionButton.disabled) {\n\t\t\t\t\t\tevent.preventDefault();\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tconst initialAction = actionButton.getAttribute(\"data-kitchen-action\") || \"\";\n\t\t\t\t\tactionButton.disabled = true;\n\t\t\t\t\tactionButton.textContent = \"Updating...\";\n\t\t\t\t\twindow.setTimeout(() => {\n\t\t\t\t\t\tif (!actionButton.isConnected) return;\n\t\t\t\t\t\tactionButton.disabled = false;\n\t\t\t\t\t\tconst currentAction = actionButton.getAttribute(\"data-kitchen-action\") || initialAction;\n\t\t\t\t\t\tactionButton.textContent = labelForAction(currentAction);\n\t\t\t\t\t}, 5000);\n\t\t\t\t});\n\n\t\t\t\tconnectStreamProbe();\n\t\t\t\tsyncBoard();\n\t\t\t\twindow.setInterval(updateAges, 60000);\n\t\t\t\twindow.addEventListener(\"beforeunload\", () => {\n\t\t\t\t\tif (streamProbe) {\n\t\t\t\t\t\tstreamProbe.close();\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t})();\n\t\t</script> <script nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "\">\n\t\t\t(function () {\n\t\t\t\tconst root = document.getElementById(\"kitchen-display\");\n\t\t\t\tif (!root) return;\n\n\t\t\t\tconst laneElements = {\n\t\t\t\t\t\"waiting-start\": document.getElementById(\"lane-waiting-start\"),\n\t\t\t\t\t\"preparing\": document.getElementById(\"lane-preparing\"),\n\t\t\t\t\t\"ready\": document.getElementById(\"lane-ready\"),\n\t\t\t\t};\n\t\t\t\tconst streamDot = document.getElementById(\"kitchen-stream-dot\");\n\t\t\t\tconst streamLabel = document.getElementById(\"kitchen-stream-label\");\n\t\t\t\tconst streamReconnectButton = document.getElementById(\"kitchen-stream-reconnect\");\n\t\t\t\tconst streamBanner = document.getElementById(\"kitchen-stream-banner\");\n\t\t\t\tconst streamBannerText = document.getElementById(\"kitchen-stream-banner-text\");\n\t\t\t\tconst bannerReconnectButton = document.getElementById(\"kitchen-banner-reconnect\");\n\t\t\t\tconst refetchButton = document.getElementById(\"kitchen-refetch\");\n\n\t\t\t\tconst summaryCountElements = {\n\t\t\t\t\ttotal: root.querySelector('[data-summary-count=\"total\"]'),\n\t\t\t\t\tunpaid: root.querySelector('[data-summary-count=\"unpaid\"]'),\n\t\t\t\t\t\"waiting-start\": root.querySelector('[data-summary-count=\"waiting-start\"]'),\n\t\t\t\t\tpreparing: root.querySelector('[data-summary-count=\"preparing\"]'),\n\t\t\t\t\tready: root.querySelector('[data-summary-count=\"ready\"]'),\n\t\t\t\t};\n\t\t\t\tconst laneCountElements = {};\n\t\t\t\troot.querySelectorAll(\"[data-lane-count]\").forEach((element) => {\n\t\t\t\t\tconst key = element.getAttribute(\"data-lane-count\");\n\t\t\t\t\tif (key) laneCountElements[key] = element;\n\t\t\t\t});\n\t\t\t\tconst laneEmptyElements = {};\n\t\t\t\troot.querySelectorAll(\"[data-lane-empty]\").forEach((element) => {\n\t\t\t\t\tconst key = element.getAttribute(\"data-lane-empty\");\n\t\t\t\t\tif (key) laneEmptyElements[key] = element;\n\t\t\t\t});\n\n\t\t\t\tfunction allCards() {\n\t\t\t\t\treturn Array.from(root.querySelectorAll(\".kitchen-order-card\"));\n\t\t\t\t}\n\n\t\t\t\tfunction statusOf(card) {\n\t\t\t\t\tconst raw = (card.getAttribute(\"data-kitchen-status\") || \"waiting-start\").toLowerCase();\n\t\t\t\t\tif (raw === \"completed\") return \"completed\";\n\t\t\t\t\tif (laneElements[raw]) return raw;\n\t\t\t\t\treturn \"waiting-start\";\n\t\t\t\t}\n\n\t\t\t\tfunction createdAtOf(card) {\n\t\t\t\t\tconst value = Number.parseInt(card.getAttribute(\"data-order-created-at\") || \"0\", 10);\n\t\t\t\t\treturn Number.isFinite(value) ? value : 0;\n\t\t\t\t}\n\n\t\t\t\tfunction routeCard(card) {\n\t\t\t\t\tconst status = statusOf(card);\n\t\t\t\t\tif (status === \"completed\") {\n\t\t\t\t\t\tcard.remove();\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tconst lane = laneElements[status];\n\t\t\t\t\tif (!lane) return;\n\t\t\t\t\tif (card.parentElement !== lane) {\n\t\t\t\t\t\tlane.appendChild(card);\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction sortLane(lane) {\n\t\t\t\t\tif (!lane) return;\n\t\t\t\t\tconst cards = Array.from(lane.querySelectorAll(\".kitchen-order-card\"));\n\t\t\t\t\tif (cards.length < 2) return;\n\t\t\t\t\tconst sorted = [...cards].sort((a, b) => createdAtOf(a) - createdAtOf(b));\n\t\t\t\t\tlet changed = false;\n\t\t\t\t\tfor (let i = 0; i < cards.length; i += 1) {\n\t\t\t\t\t\tif (cards[i] !== sorted[i]) {\n\t\t\t\t\t\t\tchanged = true;\n\t\t\t\t\t\t\tbreak;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tif (!changed) return;\n\t\t\t\t\tsorted.forEach((card) => lane.appendChild(card));\n\t\t\t\t}\n\n\t\t\t\tfunction ageLabel(minutes) {\n\t\t\t\t\tif (minutes < 1) return \"now\";\n\t\t\t\t\tif (minutes < 60) return `${minutes}m`;\n\t\t\t\t\tconst hours = Math.floor(minutes / 60);\n\t\t\t\t\tconst remaining = minutes % 60;\n\t\t\t\t\tif (hours < 24) return remaining === 0 ? `${hours}h` : `${hours}h ${remaining}m`;\n\t\t\t\t\treturn `${Math.floor(hours / 24)}d`;\n\t\t\t\t}\n\n\t\t\t\tconst warningMinutes = parseInt(root.dataset.warningMinutes || \"8\", 10) || 8;\n\t\t\t\tconst overdueMinutes = parseInt(root.dataset.overdueMinutes || \"12\", 10) || 12;\n\n\t\t\t\t// Tier classes for the card ring, the age timer, and the primary CTA.\n\t\t\t\tconst CARD_WARNING = [\"ring-2\", \"ring-amber-400/70\", \"ring-offset-1\"];\n\t\t\t\tconst CARD_OVERDUE = [\"ring-2\", \"ring-red-500/80\", \"ring-offset-1\", \"shadow-[0_0_18px_rgba(239,68,68,0.55)]\"];\n\t\t\t\tconst TIMER_WARNING = [\"text-amber-600\", \"dark:text-amber-300\", \"font-bold\"];\n\t\t\t\tconst TIMER_OVERDUE = [\"text-red-600\", \"dark:text-red-400\", \"font-bold\"];\n\t\t\t\tconst CTA_WARNING = [\"ring-1\", \"ring-amber-400/70\"];\n\t\t\t\tconst CTA_OVERDUE = [\"ring-2\", \"ring-red-500/80\"];\n\n\t\t\t\tfunction tierOf(minutes) {\n\t\t\t\t\tif (minutes >= overdueMinutes) return \"overdue\";\n\t\t\t\t\tif (minutes >= warningMinutes) return \"warning\";\n\t\t\t\t\treturn \"nominal\";\n\t\t\t\t}\n\n\t\t\t\tfunction applyTier(card, tier) {\n\t\t\t\t\tcard.classList.remove(...CARD_WARNING, ...CARD_OVERDUE);\n\t\t\t\t\tif (tier === \"warning\") card.classList.add(...CARD_WARNING);\n\t\t\t\t\telse if (tier === \"overdue\") card.classList.add(...CARD_OVERDUE);\n\n\t\t\t\t\tconst ageNode = card.querySelector(\"[data-order-age]\");\n\t\t\t\t\tif (ageNode) {\n\t\t\t\t\t\tageNode.classList.remove(...TIMER_WARNING, ...TIMER_OVERDUE);\n\t\t\t\t\t\tif (tier === \"warning\") ageNode.classList.add(...TIMER_WARNING);\n\t\t\t\t\t\telse if (tier === \"overdue\") ageNode.classList.add(...TIMER_OVERDUE);\n\t\t\t\t\t}\n\n\t\t\t\t\t// Primary CTA (Start Preparing / Mark Ready / Close Ticket): tint it and,\n\t\t\t\t\t// when overdue, append the \"· OVERDUE\" suffix the audit calls for.\n\t\t\t\t\tconst cta = card.querySelector(\"[data-kitchen-action]\");\n\t\t\t\t\tif (cta) {\n\t\t\t\t\t\tcta.classList.remove(...CTA_WARNING, ...CTA_OVERDUE);\n\t\t\t\t\t\tif (tier === \"warning\") cta.classList.add(...CTA_WARNING);\n\t\t\t\t\t\telse if (tier === \"overdue\") cta.classList.add(...CTA_OVERDUE);\n\t\t\t\t\t\tlet badge = cta.querySelector(\"[data-overdue-suffix]\");\n\t\t\t\t\t\tif (tier === \"overdue\") {\n\t\t\t\t\t\t\tif (!badge) {\n\t\t\t\t\t\t\t\tbadge = document.createElement(\"span\");\n\t\t\t\t\t\t\t\tbadge.setAttribute(\"data-overdue-suffix\", \"\");\n\t\t\t\t\t\t\t\tbadge.className = \"ml-1 font-bold uppercase tracking-wide\";\n\t\t\t\t\t\t\t\tbadge.textContent = \" · OVERDUE\";\n\t\t\t\t\t\t\t\tcta.appendChild(badge);\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t} else if (badge) {\n\t\t\t\t\t\t\tbadge.remove();\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction updateAges() {\n\t\t\t\t\tconst nowUnix = Math.floor(Date.now() / 1000);\n\t\t\t\t\tallCards().forEach((card) => {\n\t\t\t\t\t\tconst ageNode = card.querySelector(\"[data-order-age]\");\n\t\t\t\t\t\tif (!ageNode) return;\n\t\t\t\t\t\tconst createdAt = createdAtOf(card);\n\t\t\t\t\t\tif (createdAt <= 0) {\n\t\t\t\t\t\t\tageNode.textContent = \"Unknown\";\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tconst minutes = Math.max(0, Math.floor((nowUnix - createdAt) / 60));\n\t\t\t\t\t\tageNode.textContent = ageLabel(minutes);\n\t\t\t\t\t\tapplyTier(card, tierOf(minutes));\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction updateCountsAndEmptyStates() {\n\t\t\t\t\tconst counts = {\n\t\t\t\t\t\t\"waiting-start\": 0,\n\t\t\t\t\t\tpreparing: 0,\n\t\t\t\t\t\tready: 0,\n\t\t\t\t\t};\n\n\t\t\t\t\tObject.keys(laneElements).forEach((key) => {\n\t\t\t\t\t\tconst lane = laneElements[key];\n\t\t\t\t\t\tconst value = lane ? lane.querySelectorAll(\".kitchen-order-card\").length : 0;\n\t\t\t\t\t\tcounts[key] = value;\n\t\t\t\t\t\tif (laneCountElements[key]) laneCountElements[key].textContent = String(value);\n\t\t\t\t\t\tif (laneEmptyElements[key]) laneEmptyElements[key].classList.toggle(\"hidden\", value > 0);\n\t\t\t\t\t});\n\n\t\t\t\t\tcounts.total = counts[\"waiting-start\"] + counts.preparing + counts.ready;\n\t\t\t\t\tcounts.unpaid = allCards().filter((c) => c.getAttribute(\"data-order-unpaid\") === \"true\").length;\n\t\t\t\t\tObject.keys(summaryCountElements).forEach((key) => {\n\t\t\t\t\t\tconst node = summaryCountElements[key];\n\t\t\t\t\tif (!node) return;\n\t\t\t\t\tconst value = counts[key] || 0;\n\t\t\t\t\tnode.textContent = String(value);\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tlet streamProbe = null;\n\t\t\t\tfunction setStreamStatus(status) {\n\t\t\t\t\tif (!streamDot || !streamLabel) return;\n\t\t\t\t\tlet state = \"unsupported\";\n\t\t\t\t\t// The banner only appears for genuine problems (offline / unsupported)\n\t\t\t\t\t// so a normal connecting→connected load doesn't flash a warning.\n\t\t\t\t\tlet bannerText = \"\";\n\t\t\t\t\tswitch (status) {\n\t\t\t\t\tcase \"connected\":\n\t\t\t\t\t\tstate = \"connected\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Live updates on\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\tcase \"connecting\":\n\t\t\t\t\t\tstate = \"connecting\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Connecting live updates...\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\tcase \"disconnected\":\n\t\t\t\t\t\tstate = \"disconnected\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Live updates offline\";\n\t\t\t\t\t\tbannerText = \"Live updates offline — new orders may not appear. Reconnect to catch up.\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\tdefault:\n\t\t\t\t\t\tstate = \"unsupported\";\n\t\t\t\t\t\tstreamLabel.textContent = \"Live updates unavailable\";\n\t\t\t\t\t\tbannerText = \"Live updates aren't supported in this browser. Refresh to see new orders.\";\n\t\t\t\t\t\tbreak;\n\t\t\t\t\t}\n\t\t\t\t\tstreamDot.setAttribute(\"data-state\", state);\n\t\t\t\t\tif (streamBanner) {\n\t\t\t\t\t\tif (bannerText) {\n\t\t\t\t\t\t\tif (streamBannerText) streamBannerText.textContent = bannerText;\n\t\t\t\t\t\t\tstreamBanner.hidden = false;\n\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\tstreamBanner.hidden = true;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction connectStreamProbe() {\n\t\t\t\t\tif (!window.EventSource) {\n\t\t\t\t\t\tsetStreamStatus(\"unsupported\");\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tif (streamProbe) {\n\t\t\t\t\t\tstreamProbe.close();\n\t\t\t\t\t\tstreamProbe = null;\n\t\t\t\t\t}\n\t\t\t\t\tsetStreamStatus(\"connecting\");\n\t\t\t\t\tconst probe = new EventSource(\"/kitchen/stream\");\n\t\t\t\t\tstreamProbe = probe;\n\t\t\t\t\tprobe.onopen = () => {\n\t\t\t\t\t\tsetStreamStatus(\"connected\");\n\t\t\t\t\t};\n\t\t\t\t\tprobe.onerror = () => {\n\t\t\t\t\t\tsetStreamStatus(\"disconnected\");\n\t\t\t\t\t};\n\t\t\t\t}\n\n\t\t\t\tif (streamReconnectButton instanceof HTMLButtonElement) {\n\t\t\t\t\tstreamReconnectButton.addEventListener(\"click\", () => {\n\t\t\t\t\t\tconnectStreamProbe();\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tif (bannerReconnectButton instanceof HTMLButtonElement) {\n\t\t\t\t\tbannerReconnectButton.addEventListener(\"click\", () => {\n\t\t\t\t\t\tconnectStreamProbe();\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tif (refetchButton instanceof HTMLButtonElement) {\n\t\t\t\t\trefetchButton.addEventListener(\"click\", () => {\n\t\t\t\t\t\twindow.location.reload();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tlet syncing = false;\n\t\t\t\tfunction syncBoard() {\n\t\t\t\t\tif (syncing) return;\n\t\t\t\t\tsyncing = true;\n\t\t\t\t\ttry {\n\t\t\t\t\t\tallCards().forEach(routeCard);\n\t\t\t\t\t\tObject.values(laneElements).forEach(sortLane);\n\t\t\t\t\t\tupdateCountsAndEmptyStates();\n\t\t\t\t\t\tupdateAges();\n\t\t\t\t\t} finally {\n\t\t\t\t\t\tsyncing = false;\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tlet frame = 0;\n\t\t\t\tfunction requestSync() {\n\t\t\t\t\tif (frame) return;\n\t\t\t\t\tframe = window.requestAnimationFrame(() => {\n\t\t\t\t\t\tframe = 0;\n\t\t\t\t\t\tsyncBoard();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tconst observer = new MutationObserver((mutations) => {\n\t\t\t\t\tif (syncing) return;\n\t\t\t\t\tfor (const mutation of mutations) {\n\t\t\t\t\t\tif (mutation.type === \"attributes\") {\n\t\t\t\t\t\t\tconst target = mutation.target;\n\t\t\t\t\t\t\tif (target instanceof HTMLElement && target.classList.contains(\"kitchen-order-card\")) {\n\t\t\t\t\t\t\t\trequestSync();\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t\t// Removals count too: a resync snapshot drops finished tickets.\n\t\t\t\t\t\tfor (const node of [...mutation.addedNodes, ...mutation.removedNodes]) {\n\t\t\t\t\t\t\tif (!(node instanceof HTMLElement)) continue;\n\t\t\t\t\t\t\tif (node.classList.contains(\"kitchen-order-card\") || node.querySelector(\".kitchen-order-card\")) {\n\t\t\t\t\t\t\t\trequestSync();\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tobserver.observe(root, {\n\t\t\t\t\tchildList: true,\n\t\t\t\t\tsubtree: true,\n\t\t\t\t\tattributes: true,\n\t\t\t\t\tattributeFilter: [\"data-kitchen-status\", \"data-order-created-at\"],\n\t\t\t\t});\n\n\t\t\t\tfunction labelForAction(action) {\n\t\t\t\t\tswitch (action) {\n\t\t\t\t\tcase \"mark-preparing\":\n\t\t\t\t\t\treturn \"Start Preparing\";\n\t\t\t\t\tcase \"mark-ready\":\n\t\t\t\t\t\treturn \"Mark Ready\";\n\t\t\t\t\tcase \"mark-completed\":\n\t\t\t\t\t\treturn \"Close Ticket\";\n\t\t\t\t\tdefault:\n\t\t\t\t\t\treturn \"Update\";\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\troot.addEventListener(\"click\", (event) => {\n\t\t\t\t\tif (!(event.target instanceof Element)) return;\n\t\t\t\t\tconst actionButton = event.target.closest(\"[data-kitchen-action]\");\n\t\t\t\t\tif (!(actionButton instanceof HTMLButtonElement)) return;\n\t\t\t\t\tif (actionButton.disabled) {\n\t\t\t\t\t\tevent.preventDefault();\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tconst initialAction = actionButton.getAttribute(\"data-kitchen-action\") || \"\";\n\t\t\t\t\tactionButton.disabled = true;\n\t\t\t\t\tactionButton.textContent = \"Updating...\";\n\t\t\t\t\twindow.setTimeout(() => {\n\t\t\t\t\t\tif (!actionButton.isConnected) return;\n\t\t\t\t\t\tactionButton.disabled = false;\n\t\t\t\t\t\tconst currentAction = actionButton.getAttribute(\"data-kitchen-action\") || initialAction;\n\t\t\t\t\t\tactionButton.textContent = labelForAction(currentAction);\n\t\t\t\t\t}, 5000);\n\t\t\t\t});\n\n\t\t\t\tconnectStreamProbe();\n\t\t\t\tsyncBoard();\n\t\t\t\twindow.setInterval(updateAges, 60000);\n\t\t\t\twindow.addEventListener(\"beforeunload\", () => {\n\t\t\t\t\tif (streamProbe) {\n\t\t\t\t\t\tstreamProbe.close();\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t})();\n\t\t</script> <script nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			var templ_7745c5c3_Var23 string
-			templ_7745c5c3_Var23, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+			var templ_7745c5c3_Var25 string
+			templ_7745c5c3_Var25, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 630, Col: 37}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 653, Col: 37}
 			}
-			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var23))
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var25))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "\">\n\t\t\t(function () {\n\t\t\t\t// Kitchen audio: short bell on new ticket, distinct chime on overdue (>12m).\n\t\t\t\t// Persist toggle per device. Honor autoplay. Dedup via BroadcastChannel.\n\t\t\t\tconst root = document.getElementById(\"kitchen-display\");\n\t\t\t\tconst toggle = document.getElementById(\"kitchen-sound-toggle\");\n\t\t\t\tif (!root || !toggle) return;\n\n\t\t\t\tconst STORAGE_KEY = \"kitchen-sound-on\";\n\t\t\t\tconst OVERDUE_MINUTES = parseInt(root.dataset.overdueMinutes || \"12\", 10) || 12;\n\t\t\t\tconst stored = window.localStorage.getItem(STORAGE_KEY);\n\t\t\t\tlet soundOn = stored === null ? true : stored === \"true\";\n\n\t\t\t\tfunction setIcon() {\n\t\t\t\t\tconst onIcon = toggle.querySelector('[data-sound-icon=\"on\"]');\n\t\t\t\t\tconst offIcon = toggle.querySelector('[data-sound-icon=\"off\"]');\n\t\t\t\t\tif (onIcon) onIcon.classList.toggle(\"hidden\", !soundOn);\n\t\t\t\t\tif (offIcon) offIcon.classList.toggle(\"hidden\", soundOn);\n\t\t\t\t\ttoggle.setAttribute(\"aria-pressed\", String(soundOn));\n\t\t\t\t\ttoggle.setAttribute(\"data-sound-state\", soundOn ? \"on\" : \"off\");\n\t\t\t\t\ttoggle.title = soundOn ? \"Mute alerts\" : \"Unmute alerts\";\n\t\t\t\t}\n\t\t\t\tsetIcon();\n\n\t\t\t\tlet audioCtx = null;\n\t\t\t\tfunction ensureCtx() {\n\t\t\t\t\tif (audioCtx) return audioCtx;\n\t\t\t\t\tconst Ctor = window.AudioContext || window.webkitAudioContext;\n\t\t\t\t\tif (!Ctor) return null;\n\t\t\t\t\ttry { audioCtx = new Ctor(); } catch (_) { audioCtx = null; }\n\t\t\t\t\treturn audioCtx;\n\t\t\t\t}\n\t\t\t\t// Unlock audio on the first interaction anywhere in the document.\n\t\t\t\tfunction unlock() {\n\t\t\t\t\tconst ctx = ensureCtx();\n\t\t\t\t\tif (ctx && ctx.state === \"suspended\") ctx.resume().catch(() => {});\n\t\t\t\t\tdocument.removeEventListener(\"click\", unlock, true);\n\t\t\t\t\tdocument.removeEventListener(\"keydown\", unlock, true);\n\t\t\t\t}\n\t\t\t\tdocument.addEventListener(\"click\", unlock, true);\n\t\t\t\tdocument.addEventListener(\"keydown\", unlock, true);\n\n\t\t\t\tfunction tone(freq, durationMs, type) {\n\t\t\t\t\tconst ctx = ensureCtx();\n\t\t\t\t\tif (!ctx) return;\n\t\t\t\t\tconst osc = ctx.createOscillator();\n\t\t\t\t\tconst gain = ctx.createGain();\n\t\t\t\t\tosc.type = type || \"sine\";\n\t\t\t\t\tosc.frequency.value = freq;\n\t\t\t\t\tgain.gain.value = 0.0001;\n\t\t\t\t\tosc.connect(gain).connect(ctx.destination);\n\t\t\t\t\tconst now = ctx.currentTime;\n\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.4, now + 0.01);\n\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.0001, now + durationMs / 1000);\n\t\t\t\t\tosc.start(now);\n\t\t\t\t\tosc.stop(now + durationMs / 1000 + 0.02);\n\t\t\t\t}\n\n\t\t\t\tfunction playBell() { tone(880, 150, \"sine\"); }\n\t\t\t\tfunction playChime() {\n\t\t\t\t\ttone(659, 180, \"triangle\");\n\t\t\t\t\tsetTimeout(() => tone(523, 220, \"triangle\"), 180);\n\t\t\t\t}\n\n\t\t\t\tlet channel = null;\n\t\t\t\ttry { channel = new BroadcastChannel(\"kitchen-audio\"); } catch (_) { channel = null; }\n\t\t\t\tconst recentlyPlayed = new Map();\n\t\t\t\tfunction shouldPlay(kind, key) {\n\t\t\t\t\tconst now = Date.now();\n\t\t\t\t\tconst id = kind + \":\" + key;\n\t\t\t\t\tconst last = recentlyPlayed.get(id) || 0;\n\t\t\t\t\tif (now - last < 1500) return false;\n\t\t\t\t\trecentlyPlayed.set(id, now);\n\t\t\t\t\treturn true;\n\t\t\t\t}\n\t\t\t\tif (channel) {\n\t\t\t\t\tchannel.onmessage = (ev) => {\n\t\t\t\t\t\tif (!ev.data || !ev.data.id) return;\n\t\t\t\t\t\trecentlyPlayed.set(ev.data.id, ev.data.ts || Date.now());\n\t\t\t\t\t};\n\t\t\t\t}\n\n\t\t\t\tfunction announce(kind, key) {\n\t\t\t\t\tconst id = kind + \":\" + key;\n\t\t\t\t\tif (channel) channel.postMessage({ id: id, ts: Date.now() });\n\t\t\t\t}\n\n\t\t\t\tfunction shouldEmit() {\n\t\t\t\t\tif (!soundOn) return false;\n\t\t\t\t\tif (document.hidden) return false;\n\t\t\t\t\treturn true;\n\t\t\t\t}\n\n\t\t\t\tconst seenOrders = new Set();\n\t\t\t\troot.querySelectorAll(\".kitchen-order-card\").forEach((c) => {\n\t\t\t\t\tconst id = c.getAttribute(\"data-order-number\");\n\t\t\t\t\tif (id) seenOrders.add(id);\n\t\t\t\t});\n\t\t\t\tconst overdueFired = new Set();\n\n\t\t\t\tfunction checkNewTickets() {\n\t\t\t\t\troot.querySelectorAll(\".kitchen-order-card\").forEach((c) => {\n\t\t\t\t\t\tconst id = c.getAttribute(\"data-order-number\");\n\t\t\t\t\t\tif (!id || seenOrders.has(id)) return;\n\t\t\t\t\t\tseenOrders.add(id);\n\t\t\t\t\t\tconst key = \"new:\" + id;\n\t\t\t\t\t\tif (!shouldEmit()) return;\n\t\t\t\t\t\tif (!shouldPlay(\"new\", id)) return;\n\t\t\t\t\t\tannounce(\"new\", id);\n\t\t\t\t\t\tplayBell();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction checkOverdue() {\n\t\t\t\t\tconst nowUnix = Math.floor(Date.now() / 1000);\n\t\t\t\t\troot.querySelectorAll(\".kitchen-order-card\").forEach((c) => {\n\t\t\t\t\t\tconst status = (c.getAttribute(\"data-kitchen-status\") || \"\").toLowerCase();\n\t\t\t\t\t\tif (status === \"ready\" || status === \"completed\") return;\n\t\t\t\t\t\tconst ts = Number.parseInt(c.getAttribute(\"data-order-created-at\") || \"0\", 10);\n\t\t\t\t\t\tif (!Number.isFinite(ts) || ts <= 0) return;\n\t\t\t\t\t\tconst minutes = Math.floor((nowUnix - ts) / 60);\n\t\t\t\t\t\tif (minutes < OVERDUE_MINUTES) return;\n\t\t\t\t\t\tconst id = c.getAttribute(\"data-order-number\");\n\t\t\t\t\t\tif (!id || overdueFired.has(id)) return;\n\t\t\t\t\t\toverdueFired.add(id);\n\t\t\t\t\t\tif (!shouldEmit()) return;\n\t\t\t\t\t\tif (!shouldPlay(\"overdue\", id)) return;\n\t\t\t\t\t\tannounce(\"overdue\", id);\n\t\t\t\t\t\tplayChime();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tconst observer = new MutationObserver(checkNewTickets);\n\t\t\t\tobserver.observe(root, { childList: true, subtree: true });\n\t\t\t\twindow.setInterval(checkOverdue, 5000);\n\n\t\t\t\ttoggle.addEventListener(\"click\", () => {\n\t\t\t\t\tsoundOn = !soundOn;\n\t\t\t\t\twindow.localStorage.setItem(STORAGE_KEY, String(soundOn));\n\t\t\t\t\tsetIcon();\n\t\t\t\t\tif (soundOn) {\n\t\t\t\t\t\tconst ctx = ensureCtx();\n\t\t\t\t\t\tif (ctx && ctx.state === \"suspended\") ctx.resume().catch(() => {});\n\t\t\t\t\t\tplayBell();\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t})();\n\t\t</script> ")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "\">\n\t\t\t(function () {\n\t\t\t\t// Kitchen audio: short bell on new ticket, distinct chime on overdue (>12m).\n\t\t\t\t// Persist toggle per device. Honor autoplay. Dedup via BroadcastChannel.\n\t\t\t\tconst root = document.getElementById(\"kitchen-display\");\n\t\t\t\tconst toggle = document.getElementById(\"kitchen-sound-toggle\");\n\t\t\t\tif (!root || !toggle) return;\n\n\t\t\t\tconst STORAGE_KEY = \"kitchen-sound-on\";\n\t\t\t\tconst OVERDUE_MINUTES = parseInt(root.dataset.overdueMinutes || \"12\", 10) || 12;\n\t\t\t\tconst stored = window.localStorage.getItem(STORAGE_KEY);\n\t\t\t\tlet soundOn = stored === null ? true : stored === \"true\";\n\n\t\t\t\tfunction setIcon() {\n\t\t\t\t\tconst onIcon = toggle.querySelector('[data-sound-icon=\"on\"]');\n\t\t\t\t\tconst offIcon = toggle.querySelector('[data-sound-icon=\"off\"]');\n\t\t\t\t\tif (onIcon) onIcon.classList.toggle(\"hidden\", !soundOn);\n\t\t\t\t\tif (offIcon) offIcon.classList.toggle(\"hidden\", soundOn);\n\t\t\t\t\ttoggle.setAttribute(\"aria-pressed\", String(soundOn));\n\t\t\t\t\ttoggle.setAttribute(\"data-sound-state\", soundOn ? \"on\" : \"off\");\n\t\t\t\t\ttoggle.title = soundOn ? \"Mute alerts\" : \"Unmute alerts\";\n\t\t\t\t}\n\t\t\t\tsetIcon();\n\n\t\t\t\tlet audioCtx = null;\n\t\t\t\tfunction ensureCtx() {\n\t\t\t\t\tif (audioCtx) return audioCtx;\n\t\t\t\t\tconst Ctor = window.AudioContext || window.webkitAudioContext;\n\t\t\t\t\tif (!Ctor) return null;\n\t\t\t\t\ttry { audioCtx = new Ctor(); } catch (_) { audioCtx = null; }\n\t\t\t\t\treturn audioCtx;\n\t\t\t\t}\n\t\t\t\t// Unlock audio on the first interaction anywhere in the document.\n\t\t\t\tfunction unlock() {\n\t\t\t\t\tconst ctx = ensureCtx();\n\t\t\t\t\tif (ctx && ctx.state === \"suspended\") ctx.resume().catch(() => {});\n\t\t\t\t\tdocument.removeEventListener(\"click\", unlock, true);\n\t\t\t\t\tdocument.removeEventListener(\"keydown\", unlock, true);\n\t\t\t\t}\n\t\t\t\tdocument.addEventListener(\"click\", unlock, true);\n\t\t\t\tdocument.addEventListener(\"keydown\", unlock, true);\n\n\t\t\t\tfunction tone(freq, durationMs, type) {\n\t\t\t\t\tconst ctx = ensureCtx();\n\t\t\t\t\tif (!ctx) return;\n\t\t\t\t\tconst osc = ctx.createOscillator();\n\t\t\t\t\tconst gain = ctx.createGain();\n\t\t\t\t\tosc.type = type || \"sine\";\n\t\t\t\t\tosc.frequency.value = freq;\n\t\t\t\t\tgain.gain.value = 0.0001;\n\t\t\t\t\tosc.connect(gain).connect(ctx.destination);\n\t\t\t\t\tconst now = ctx.currentTime;\n\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.4, now + 0.01);\n\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.0001, now + durationMs / 1000);\n\t\t\t\t\tosc.start(now);\n\t\t\t\t\tosc.stop(now + durationMs / 1000 + 0.02);\n\t\t\t\t}\n\n\t\t\t\tfunction playBell() { tone(880, 150, \"sine\"); }\n\t\t\t\tfunction playChime() {\n\t\t\t\t\ttone(659, 180, \"triangle\");\n\t\t\t\t\tsetTimeout(() => tone(523, 220, \"triangle\"), 180);\n\t\t\t\t}\n\n\t\t\t\tlet channel = null;\n\t\t\t\ttry { channel = new BroadcastChannel(\"kitchen-audio\"); } catch (_) { channel = null; }\n\t\t\t\tconst recentlyPlayed = new Map();\n\t\t\t\tfunction shouldPlay(kind, key) {\n\t\t\t\t\tconst now = Date.now();\n\t\t\t\t\tconst id = kind + \":\" + key;\n\t\t\t\t\tconst last = recentlyPlayed.get(id) || 0;\n\t\t\t\t\tif (now - last < 1500) return false;\n\t\t\t\t\trecentlyPlayed.set(id, now);\n\t\t\t\t\treturn true;\n\t\t\t\t}\n\t\t\t\tif (channel) {\n\t\t\t\t\tchannel.onmessage = (ev) => {\n\t\t\t\t\t\tif (!ev.data || !ev.data.id) return;\n\t\t\t\t\t\trecentlyPlayed.set(ev.data.id, ev.data.ts || Date.now());\n\t\t\t\t\t};\n\t\t\t\t}\n\n\t\t\t\tfunction announce(kind, key) {\n\t\t\t\t\tconst id = kind + \":\" + key;\n\t\t\t\t\tif (channel) channel.postMessage({ id: id, ts: Date.now() });\n\t\t\t\t}\n\n\t\t\t\tfunction shouldEmit() {\n\t\t\t\t\tif (!soundOn) return false;\n\t\t\t\t\tif (document.hidden) return false;\n\t\t\t\t\treturn true;\n\t\t\t\t}\n\n\t\t\t\tconst seenOrders = new Set();\n\t\t\t\troot.querySelectorAll(\".kitchen-order-card\").forEach((c) => {\n\t\t\t\t\tconst id = c.getAttribute(\"data-order-number\");\n\t\t\t\t\tif (id) seenOrders.add(id);\n\t\t\t\t});\n\t\t\t\tconst overdueFired = new Set();\n\n\t\t\t\tfunction checkNewTickets() {\n\t\t\t\t\troot.querySelectorAll(\".kitchen-order-card\").forEach((c) => {\n\t\t\t\t\t\tconst id = c.getAttribute(\"data-order-number\");\n\t\t\t\t\t\tif (!id || seenOrders.has(id)) return;\n\t\t\t\t\t\tseenOrders.add(id);\n\t\t\t\t\t\tconst key = \"new:\" + id;\n\t\t\t\t\t\tif (!shouldEmit()) return;\n\t\t\t\t\t\tif (!shouldPlay(\"new\", id)) return;\n\t\t\t\t\t\tannounce(\"new\", id);\n\t\t\t\t\t\tplayBell();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction checkOverdue() {\n\t\t\t\t\tconst nowUnix = Math.floor(Date.now() / 1000);\n\t\t\t\t\troot.querySelectorAll(\".kitchen-order-card\").forEach((c) => {\n\t\t\t\t\t\tconst status = (c.getAttribute(\"data-kitchen-status\") || \"\").toLowerCase();\n\t\t\t\t\t\tif (status === \"ready\" || status === \"completed\") return;\n\t\t\t\t\t\tconst ts = Number.parseInt(c.getAttribute(\"data-order-created-at\") || \"0\", 10);\n\t\t\t\t\t\tif (!Number.isFinite(ts) || ts <= 0) return;\n\t\t\t\t\t\tconst minutes = Math.floor((nowUnix - ts) / 60);\n\t\t\t\t\t\tif (minutes < OVERDUE_MINUTES) return;\n\t\t\t\t\t\tconst id = c.getAttribute(\"data-order-number\");\n\t\t\t\t\t\tif (!id || overdueFired.has(id)) return;\n\t\t\t\t\t\toverdueFired.add(id);\n\t\t\t\t\t\tif (!shouldEmit()) return;\n\t\t\t\t\t\tif (!shouldPlay(\"overdue\", id)) return;\n\t\t\t\t\t\tannounce(\"overdue\", id);\n\t\t\t\t\t\tplayChime();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tconst observer = new MutationObserver(checkNewTickets);\n\t\t\t\tobserver.observe(root, { childList: true, subtree: true });\n\t\t\t\twindow.setInterval(checkOverdue, 5000);\n\n\t\t\t\ttoggle.addEventListener(\"click\", () => {\n\t\t\t\t\tsoundOn = !soundOn;\n\t\t\t\t\twindow.localStorage.setItem(STORAGE_KEY, String(soundOn));\n\t\t\t\t\tsetIcon();\n\t\t\t\t\tif (soundOn) {\n\t\t\t\t\t\tconst ctx = ensureCtx();\n\t\t\t\t\t\tif (ctx && ctx.state === \"suspended\") ctx.resume().catch(() => {});\n\t\t\t\t\t\tplayBell();\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t})();\n\t\t</script> ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if vapidPublicKey != "" {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "<div id=\"push-prompt\" hidden class=\"mt-4 space-y-2\"><button id=\"enable-notifications\" type=\"button\" hidden class=\"inline-flex w-full items-center justify-center gap-2 whitespace-nowrap rounded-md border bg-background text-sm font-medium shadow-xs transition-all hover:bg-accent hover:text-accent-foreground h-9 px-4 py-2 cursor-pointer disabled:pointer-events-none disabled:opacity-50\">Enable kitchen notifications</button><p id=\"ios-install-hint\" hidden class=\"text-sm text-muted-foreground text-center\">Tip: to get notified on iPhone, tap Share → Add to Home Screen, then open the app from your home screen.</p></div>  <div id=\"push-config\" data-vapid-key=\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "<div id=\"push-prompt\" hidden class=\"mt-4 space-y-2\"><button id=\"enable-notifications\" type=\"button\" hidden class=\"inline-flex w-full items-center justify-center gap-2 whitespace-nowrap rounded-md border bg-background text-sm font-medium shadow-xs transition-all hover:bg-accent hover:text-accent-foreground h-9 px-4 py-2 cursor-pointer disabled:pointer-events-none disabled:opacity-50\">Enable kitchen notifications</button><p id=\"ios-install-hint\" hidden class=\"text-sm text-muted-foreground text-center\">Tip: to get notified on iPhone, tap Share → Add to Home Screen, then open the app from your home screen.</p></div>  <div id=\"push-config\" data-vapid-key=\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				var templ_7745c5c3_Var24 string
-				templ_7745c5c3_Var24, templ_7745c5c3_Err = templ.JoinStringErrs(vapidPublicKey)
+				var templ_7745c5c3_Var26 string
+				templ_7745c5c3_Var26, templ_7745c5c3_Err = templ.JoinStringErrs(vapidPublicKey)
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 796, Col: 35}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 819, Col: 35}
 				}
-				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var24))
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var26))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\" hidden></div><script nonce=\"")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "\" hidden></div><script nonce=\"")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				var templ_7745c5c3_Var25 string
-				templ_7745c5c3_Var25, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
+				var templ_7745c5c3_Var27 string
+				templ_7745c5c3_Var27, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 				if templ_7745c5c3_Err != nil {
-					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 799, Col: 38}
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/kitchen.templ`, Line: 822, Col: 38}
 				}
-				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var25))
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var27))
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "\">\n\t\t\t\t(function() {\n\t\t\t\t\tvar cfg = document.getElementById('push-config').dataset;\n\t\t\t\t\tvar promptHost = document.getElementById('push-prompt');\n\t\t\t\t\tvar enableBtn = document.getElementById('enable-notifications');\n\t\t\t\t\tvar iosHint = document.getElementById('ios-install-hint');\n\t\t\t\t\tfunction urlBase64ToUint8Array(base64String) {\n\t\t\t\t\t\tvar padding = '='.repeat((4 - base64String.length % 4) % 4);\n\t\t\t\t\t\tvar base64 = (base64String + padding).replace(/-/g, '+').replace(/_/g, '/');\n\t\t\t\t\t\tvar rawData = atob(base64);\n\t\t\t\t\t\tvar outputArray = new Uint8Array(rawData.length);\n\t\t\t\t\t\tfor (var i = 0; i < rawData.length; ++i) { outputArray[i] = rawData.charCodeAt(i); }\n\t\t\t\t\t\treturn outputArray;\n\t\t\t\t\t}\n\t\t\t\t\tfunction isIOS() {\n\t\t\t\t\t\treturn /iPad|iPhone|iPod/.test(navigator.userAgent) ||\n\t\t\t\t\t\t\t(navigator.platform === 'MacIntel' && navigator.maxTouchPoints > 1);\n\t\t\t\t\t}\n\t\t\t\t\tfunction isStandalone() {\n\t\t\t\t\t\treturn navigator.standalone === true || (window.matchMedia && window.matchMedia('(display-mode: standalone)').matches);\n\t\t\t\t\t}\n\t\t\t\t\tfunction reveal(el) { if (el) el.hidden = false; }\n\t\t\t\t\tfunction hide(el) { if (el) el.hidden = true; }\n\t\t\t\t\t// iOS Safari only exposes Push/Notification APIs to installed (standalone)\n\t\t\t\t\t// web apps, so the capability gate below would bail before the install\n\t\t\t\t\t// hint ever shows. Surface the Add-to-Home-Screen hint first.\n\t\t\t\t\tif (isIOS() && !isStandalone()) {\n\t\t\t\t\t\tconsole.info('[push] iOS browser tab — showing Add to Home Screen hint');\n\t\t\t\t\t\treveal(promptHost);\n\t\t\t\t\t\treveal(iosHint);\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tif (!('serviceWorker' in navigator) || !('PushManager' in window) || !('Notification' in window)) {\n\t\t\t\t\t\tconsole.info('[push] browser does not support service workers or push notifications');\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tif (Notification.permission === 'denied') {\n\t\t\t\t\t\tconsole.info('[push] notifications denied — skipping subscribe; re-enable in browser settings');\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tfunction subscribeAndPost(reg) {\n\t\t\t\t\t\treturn reg.pushManager.subscribe({\n\t\t\t\t\t\t\tuserVisibleOnly: true,\n\t\t\t\t\t\t\tapplicationServerKey: urlBase64ToUint8Array(cfg.vapidKey),\n\t\t\t\t\t\t}).then(function(sub) {\n\t\t\t\t\t\t\tconsole.info('[push] POST /kitchen/push/subscribe', sub.endpoint);\n\t\t\t\t\t\t\treturn fetch('/kitchen/push/subscribe', {\n\t\t\t\t\t\t\t\tmethod: 'POST',\n\t\t\t\t\t\t\t\theaders: { 'Content-Type': 'application/json' },\n\t\t\t\t\t\t\t\tbody: JSON.stringify(sub.toJSON()),\n\t\t\t\t\t\t\t}).then(function(res) {\n\t\t\t\t\t\t\t\tconsole.info('[push] subscribe response', res.status);\n\t\t\t\t\t\t\t\tif (!res.ok) console.warn('[push] subscribe failed with status', res.status);\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\t\t\t\t\tnavigator.serviceWorker.ready.then(function(reg) {\n\t\t\t\t\t\treturn reg.pushManager.getSubscription().then(function(existing) {\n\t\t\t\t\t\t\tif (existing) {\n\t\t\t\t\t\t\t\tconsole.info('[push] reusing existing subscription', existing.endpoint);\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tif (Notification.permission === 'granted') {\n\t\t\t\t\t\t\t\tconsole.info('[push] permission already granted, subscribing');\n\t\t\t\t\t\t\t\treturn subscribeAndPost(reg);\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tif (isIOS() && !isStandalone()) {\n\t\t\t\t\t\t\t\tconsole.info('[push] iOS Safari — showing install hint (Add to Home Screen required)');\n\t\t\t\t\t\t\t\treveal(promptHost);\n\t\t\t\t\t\t\t\treveal(iosHint);\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tconsole.info('[push] showing enable button (waiting for user gesture)');\n\t\t\t\t\t\t\treveal(promptHost);\n\t\t\t\t\t\t\treveal(enableBtn);\n\t\t\t\t\t\t\tenableBtn.addEventListener('click', function() {\n\t\t\t\t\t\t\t\tenableBtn.disabled = true;\n\t\t\t\t\t\t\t\tconsole.info('[push] requesting permission');\n\t\t\t\t\t\t\t\tNotification.requestPermission().then(function(perm) {\n\t\t\t\t\t\t\t\t\tconsole.info('[push] permission =', perm);\n\t\t\t\t\t\t\t\t\tif (perm !== 'granted') {\n\t\t\t\t\t\t\t\t\t\tenableBtn.disabled = false;\n\t\t\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t\t\treturn subscribeAndPost(reg).finally(function() { hide(promptHost); });\n\t\t\t\t\t\t\t\t}).catch(function(err) {\n\t\t\t\t\t\t\t\t\tconsole.warn('[push] permission request failed:', err);\n\t\t\t\t\t\t\t\t\tenableBtn.disabled = false;\n\t\t\t\t\t\t\t\t});\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t}).catch(function(err) { console.warn('[push] subscription pipeline failed:', err); });\n\t\t\t\t})();\n\t\t\t</script>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "\">\n\t\t\t\t(function() {\n\t\t\t\t\tvar cfg = document.getElementById('push-config').dataset;\n\t\t\t\t\tvar promptHost = document.getElementById('push-prompt');\n\t\t\t\t\tvar enableBtn = document.getElementById('enable-notifications');\n\t\t\t\t\tvar iosHint = document.getElementById('ios-install-hint');\n\t\t\t\t\tfunction urlBase64ToUint8Array(base64String) {\n\t\t\t\t\t\tvar padding = '='.repeat((4 - base64String.length % 4) % 4);\n\t\t\t\t\t\tvar base64 = (base64String + padding).replace(/-/g, '+').replace(/_/g, '/');\n\t\t\t\t\t\tvar rawData = atob(base64);\n\t\t\t\t\t\tvar outputArray = new Uint8Array(rawData.length);\n\t\t\t\t\t\tfor (var i = 0; i < rawData.length; ++i) { outputArray[i] = rawData.charCodeAt(i); }\n\t\t\t\t\t\treturn outputArray;\n\t\t\t\t\t}\n\t\t\t\t\tfunction isIOS() {\n\t\t\t\t\t\treturn /iPad|iPhone|iPod/.test(navigator.userAgent) ||\n\t\t\t\t\t\t\t(navigator.platform === 'MacIntel' && navigator.maxTouchPoints > 1);\n\t\t\t\t\t}\n\t\t\t\t\tfunction isStandalone() {\n\t\t\t\t\t\treturn navigator.standalone === true || (window.matchMedia && window.matchMedia('(display-mode: standalone)').matches);\n\t\t\t\t\t}\n\t\t\t\t\tfunction reveal(el) { if (el) el.hidden = false; }\n\t\t\t\t\tfunction hide(el) { if (el) el.hidden = true; }\n\t\t\t\t\t// iOS Safari only exposes Push/Notification APIs to installed (standalone)\n\t\t\t\t\t// web apps, so the capability gate below would bail before the install\n\t\t\t\t\t// hint ever shows. Surface the Add-to-Home-Screen hint first.\n\t\t\t\t\tif (isIOS() && !isStandalone()) {\n\t\t\t\t\t\tconsole.info('[push] iOS browser tab — showing Add to Home Screen hint');\n\t\t\t\t\t\treveal(promptHost);\n\t\t\t\t\t\treveal(iosHint);\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tif (!('serviceWorker' in navigator) || !('PushManager' in window) || !('Notification' in window)) {\n\t\t\t\t\t\tconsole.info('[push] browser does not support service workers or push notifications');\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tif (Notification.permission === 'denied') {\n\t\t\t\t\t\tconsole.info('[push] notifications denied — skipping subscribe; re-enable in browser settings');\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tfunction subscribeAndPost(reg) {\n\t\t\t\t\t\treturn reg.pushManager.subscribe({\n\t\t\t\t\t\t\tuserVisibleOnly: true,\n\t\t\t\t\t\t\tapplicationServerKey: urlBase64ToUint8Array(cfg.vapidKey),\n\t\t\t\t\t\t}).then(function(sub) {\n\t\t\t\t\t\t\tconsole.info('[push] POST /kitchen/push/subscribe', sub.endpoint);\n\t\t\t\t\t\t\treturn fetch('/kitchen/push/subscribe', {\n\t\t\t\t\t\t\t\tmethod: 'POST',\n\t\t\t\t\t\t\t\theaders: { 'Content-Type': 'application/json' },\n\t\t\t\t\t\t\t\tbody: JSON.stringify(sub.toJSON()),\n\t\t\t\t\t\t\t}).then(function(res) {\n\t\t\t\t\t\t\t\tconsole.info('[push] subscribe response', res.status);\n\t\t\t\t\t\t\t\tif (!res.ok) console.warn('[push] subscribe failed with status', res.status);\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\t\t\t\t\tnavigator.serviceWorker.ready.then(function(reg) {\n\t\t\t\t\t\treturn reg.pushManager.getSubscription().then(function(existing) {\n\t\t\t\t\t\t\tif (existing) {\n\t\t\t\t\t\t\t\tconsole.info('[push] reusing existing subscription', existing.endpoint);\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tif (Notification.permission === 'granted') {\n\t\t\t\t\t\t\t\tconsole.info('[push] permission already granted, subscribing');\n\t\t\t\t\t\t\t\treturn subscribeAndPost(reg);\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tif (isIOS() && !isStandalone()) {\n\t\t\t\t\t\t\t\tconsole.info('[push] iOS Safari — showing install hint (Add to Home Screen required)');\n\t\t\t\t\t\t\t\treveal(promptHost);\n\t\t\t\t\t\t\t\treveal(iosHint);\n\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\tconsole.info('[push] showing enable button (waiting for user gesture)');\n\t\t\t\t\t\t\treveal(promptHost);\n\t\t\t\t\t\t\treveal(enableBtn);\n\t\t\t\t\t\t\tenableBtn.addEventListener('click', function() {\n\t\t\t\t\t\t\t\tenableBtn.disabled = true;\n\t\t\t\t\t\t\t\tconsole.info('[push] requesting permission');\n\t\t\t\t\t\t\t\tNotification.requestPermission().then(function(perm) {\n\t\t\t\t\t\t\t\t\tconsole.info('[push] permission =', perm);\n\t\t\t\t\t\t\t\t\tif (perm !== 'granted') {\n\t\t\t\t\t\t\t\t\t\tenableBtn.disabled = false;\n\t\t\t\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t\t\treturn subscribeAndPost(reg).finally(function() { hide(promptHost); });\n\t\t\t\t\t\t\t\t}).catch(function(err) {\n\t\t\t\t\t\t\t\t\tconsole.warn('[push] permission request failed:', err);\n\t\t\t\t\t\t\t\t\tenableBtn.disabled = false;\n\t\t\t\t\t\t\t\t});\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t}).catch(function(err) { console.warn('[push] subscription pipeline failed:', err); });\n\t\t\t\t})();\n\t\t\t</script>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = layouts.Dashboard("Kitchen Display", "/kitchen", activeRestaurantLabel, userDisplayName, userSubtitle, userInitials, csrfToken, switcherOptions, activeRestaurantRole, canCreateRestaurant).Render(templ.WithChildren(ctx, templ_7745c5c3_Var13), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = layouts.Dashboard("Kitchen Display", "/kitchen", activeRestaurantLabel, userDisplayName, userSubtitle, userInitials, csrfToken, switcherOptions, activeRestaurantRole, canCreateRestaurant).Render(templ.WithChildren(ctx, templ_7745c5c3_Var15), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 	"bitmerchant/internal/interfaces/templates/components"
 	"bitmerchant/internal/ordering/app/cart"
 	"bitmerchant/internal/ordering/app/query"
@@ -135,12 +137,12 @@ func TestIssue66_ConfirmTipIsReactiveAndExact(t *testing.T) {
 	c := &cart.Cart{
 		RestaurantID: common.RestaurantID("rest-1"),
 		Items: []cart.CartItem{
-			{ItemID: common.ItemID("i1"), Name: "Bao Bun", Quantity: 2, UnitPrice: 9.00, Subtotal: 18.00},
+			{ItemID: common.ItemID("i1"), Name: "Bao Bun", Quantity: 2, UnitPrice: 900, Subtotal: 1800},
 		},
-		Total: 18.00,
+		Total: 1800,
 	}
 	var sb strings.Builder
-	comp := OrderConfirmationPage(c, "rest-1", "Bao & Brew", "7", 0.08, 10*time.Minute, "tok", "")
+	comp := OrderConfirmationPage(c, "rest-1", "Bao & Brew", "7", tax.Exclusive(0.08), tip.Standard(), 0, 10*time.Minute, "tok", "", false, nil, ConfirmPromo{})
 	if err := comp.Render(context.Background(), &sb); err != nil {
 		t.Fatalf("render: %v", err)
 	}
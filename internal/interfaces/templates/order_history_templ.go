@@ -219,9 +219,9 @@ func OrderHistoryPage(orders []*order.Order) templ.Component {
 								return templ_7745c5c3_Err
 							}
 							var templ_7745c5c3_Var13 string
-							templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(order.Total().Format())
+							templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(order.Total().FormatContext(ctx))
 							if templ_7745c5c3_Err != nil {
-								return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/order_history.templ`, Line: 46, Col: 68}
+								return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/order_history.templ`, Line: 46, Col: 78}
 							}
 							_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
 							if templ_7745c5c3_Err != nil {
@@ -279,6 +279,8 @@ func getStatusBadgeVariant(status common.FulfillmentStatus) badge.Variant {
 		return badge.VariantDefault
 	case common.FulfillmentStatusPaid:
 		return badge.VariantSecondary
+	case common.FulfillmentStatusCancelled:
+		return badge.VariantDestructive
 	default:
 		return badge.VariantOutline
 	}
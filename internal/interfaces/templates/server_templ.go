@@ -61,17 +61,11 @@ func ServerPage(orders []*order.Order, csrfToken string, activeRestaurantLabel s
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</p></div></div></section><div id=\"service-requests\" class=\"space-y-2\" aria-live=\"polite\"></div><div id=\"server-orders\" class=\"server-orders space-y-3\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</p></div></div></section><div id=\"service-requests\" class=\"space-y-2\" aria-live=\"polite\"></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			for _, o := range orders {
-				templ_7745c5c3_Err = components.ServerOrderCard(o).Render(ctx, templ_7745c5c3_Buffer)
-				if templ_7745c5c3_Err != nil {
-					return templ_7745c5c3_Err
-				}
-			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</div>")
+			templ_7745c5c3_Err = ServerOrderList(orders).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -80,7 +74,7 @@ func ServerPage(orders []*order.Order, csrfToken string, activeRestaurantLabel s
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<p id=\"server-empty\" class=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<p id=\"server-empty\" class=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -93,33 +87,33 @@ func ServerPage(orders []*order.Order, csrfToken string, activeRestaurantLabel s
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\">No unpaid tickets. Nice work.</p></div><style nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "\">No unpaid tickets. Nice work.</p></div><style nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var6 string
 			templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/server.templ`, Line: 65, Col: 36}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/server.templ`, Line: 61, Col: 36}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\">\n\t\t\t.server-orders { display: grid; grid-template-columns: 1fr; gap: 0.75rem; }\n\t\t\t@media (min-width: 768px) { .server-orders { grid-template-columns: repeat(2, minmax(0, 1fr)); } }\n\t\t\t@media (min-width: 1280px) { .server-orders { grid-template-columns: repeat(3, minmax(0, 1fr)); } }\n\t\t</style> <script nonce=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\">\n\t\t\t.server-orders { display: grid; grid-template-columns: 1fr; gap: 0.75rem; }\n\t\t\t@media (min-width: 768px) { .server-orders { grid-template-columns: repeat(2, minmax(0, 1fr)); } }\n\t\t\t@media (min-width: 1280px) { .server-orders { grid-template-columns: repeat(3, minmax(0, 1fr)); } }\n\t\t</style> <script nonce=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var7 string
 			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(templ.GetNonce(ctx))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/server.templ`, Line: 70, Col: 37}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/interfaces/templates/server.templ`, Line: 66, Col: 37}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\">\n\t\t\t(function () {\n\t\t\t\tconst root = document.getElementById(\"server-display\");\n\t\t\t\tif (!root) return;\n\t\t\t\tconst list = document.getElementById(\"server-orders\");\n\t\t\t\tconst emptyEl = document.getElementById(\"server-empty\");\n\t\t\t\tconst countEl = root.querySelector('[data-server-count=\"unpaid\"]');\n\t\t\t\tconst streamDot = document.getElementById(\"server-stream-dot\");\n\t\t\t\tconst streamLabel = document.getElementById(\"server-stream-label\");\n\t\t\t\tconst refetchButton = document.getElementById(\"server-refetch\");\n\n\t\t\t\tfunction updateCount() {\n\t\t\t\t\tif (!list) return;\n\t\t\t\t\tconst n = list.querySelectorAll(\".server-order-card\").length;\n\t\t\t\t\tif (countEl) countEl.textContent = String(n);\n\t\t\t\t\tif (emptyEl) emptyEl.classList.toggle(\"hidden\", n > 0);\n\t\t\t\t}\n\n\t\t\t\tfunction ageLabel(minutes) {\n\t\t\t\t\tif (minutes < 1) return \"now\";\n\t\t\t\t\tif (minutes < 60) return `${minutes}m`;\n\t\t\t\t\tconst hours = Math.floor(minutes / 60);\n\t\t\t\t\tconst remaining = minutes % 60;\n\t\t\t\t\tif (hours < 24) return remaining === 0 ? `${hours}h` : `${hours}h ${remaining}m`;\n\t\t\t\t\treturn `${Math.floor(hours / 24)}d`;\n\t\t\t\t}\n\n\t\t\t\tfunction updateAges() {\n\t\t\t\t\tconst nowUnix = Math.floor(Date.now() / 1000);\n\t\t\t\t\troot.querySelectorAll(\".server-order-card\").forEach((card) => {\n\t\t\t\t\t\tconst ageNode = card.querySelector(\"[data-order-age]\");\n\t\t\t\t\t\tif (!ageNode) return;\n\t\t\t\t\t\tconst ts = Number.parseInt(card.getAttribute(\"data-order-created-at\") || \"0\", 10);\n\t\t\t\t\t\tif (!Number.isFinite(ts) || ts <= 0) {\n\t\t\t\t\t\t\tageNode.textContent = \"Unknown\";\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tconst minutes = Math.max(0, Math.floor((nowUnix - ts) / 60));\n\t\t\t\t\t\tageNode.textContent = ageLabel(minutes);\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction setStreamStatus(status) {\n\t\t\t\t\tif (!streamDot || !streamLabel) return;\n\t\t\t\t\tconst map = {\n\t\t\t\t\t\tconnected:    [\"connected\",    \"Live updates on\"],\n\t\t\t\t\t\tconnecting:   [\"connecting\",   \"Connecting live updates...\"],\n\t\t\t\t\t\tdisconnected: [\"disconnected\", \"Live updates offline\"],\n\t\t\t\t\t\tunsupported:  [\"unsupported\",  \"Live updates unavailable\"],\n\t\t\t\t\t};\n\t\t\t\t\tconst v = map[status] || map.unsupported;\n\t\t\t\t\tstreamDot.setAttribute(\"data-state\", v[0]);\n\t\t\t\t\tstreamLabel.textContent = v[1];\n\t\t\t\t}\n\n\t\t\t\tlet probe = null;\n\t\t\t\tfunction connect() {\n\t\t\t\t\tif (!window.EventSource) { setStreamStatus(\"unsupported\"); return; }\n\t\t\t\t\tif (probe) probe.close();\n\t\t\t\t\tsetStreamStatus(\"connecting\");\n\t\t\t\t\tprobe = new EventSource(\"/server/stream\");\n\t\t\t\t\tprobe.onopen = () => setStreamStatus(\"connected\");\n\t\t\t\t\tprobe.onerror = () => setStreamStatus(\"disconnected\");\n\t\t\t\t}\n\n\t\t\t\tif (refetchButton) refetchButton.addEventListener(\"click\", () => window.location.reload());\n\n\t\t\t\tconst observer = new MutationObserver(() => { updateCount(); updateAges(); });\n\t\t\t\tif (list) observer.observe(list, { childList: true, subtree: false });\n\n\t\t\t\t// ── Service requests (call server / request bill) ──────────────────\n\t\t\t\tconst requests = document.getElementById(\"service-requests\");\n\t\t\t\tlet audioCtx = null;\n\t\t\t\tfunction chime() {\n\t\t\t\t\ttry {\n\t\t\t\t\t\taudioCtx = audioCtx || new (window.AudioContext || window.webkitAudioContext)();\n\t\t\t\t\t\tif (audioCtx.state === \"suspended\") audioCtx.resume();\n\t\t\t\t\t\tconst now = audioCtx.currentTime;\n\t\t\t\t\t\t[880, 1320].forEach((freq, i) => {\n\t\t\t\t\t\t\tconst osc = audioCtx.createOscillator();\n\t\t\t\t\t\t\tconst gain = audioCtx.createGain();\n\t\t\t\t\t\t\tosc.type = \"sine\";\n\t\t\t\t\t\t\tosc.frequency.value = freq;\n\t\t\t\t\t\t\tconst t = now + i * 0.16;\n\t\t\t\t\t\t\tgain.gain.setValueAtTime(0.0001, t);\n\t\t\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.22, t + 0.02);\n\t\t\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.0001, t + 0.32);\n\t\t\t\t\t\t\tosc.connect(gain).connect(audioCtx.destination);\n\t\t\t\t\t\t\tosc.start(t);\n\t\t\t\t\t\t\tosc.stop(t + 0.34);\n\t\t\t\t\t\t});\n\t\t\t\t\t} catch (e) { /* audio unavailable — visual alert still shows */ }\n\t\t\t\t}\n\t\t\t\tif (requests) {\n\t\t\t\t\tnew MutationObserver((mutations) => {\n\t\t\t\t\t\tlet added = false;\n\t\t\t\t\t\tfor (const m of mutations) {\n\t\t\t\t\t\t\tm.addedNodes.forEach((n) => {\n\t\t\t\t\t\t\t\tif (n.nodeType === 1 && n.matches(\"[data-service-alert]\")) added = true;\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (added) chime();\n\t\t\t\t\t}).observe(requests, { childList: true });\n\n\t\t\t\t\trequests.addEventListener(\"click\", (e) => {\n\t\t\t\t\t\tconst btn = e.target.closest(\"[data-dismiss-service-alert]\");\n\t\t\t\t\t\tif (!btn) return;\n\t\t\t\t\t\tconst alert = btn.closest(\"[data-service-alert]\");\n\t\t\t\t\t\tif (alert) alert.remove();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tconnect();\n\t\t\t\tupdateCount();\n\t\t\t\tupdateAges();\n\t\t\t\twindow.setInterval(updateAges, 60000);\n\t\t\t\twindow.addEventListener(\"beforeunload\", () => { if (probe) probe.close(); });\n\t\t\t})();\n\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "\">\n\t\t\t(function () {\n\t\t\t\tconst root = document.getElementById(\"server-display\");\n\t\t\t\tif (!root) return;\n\t\t\t\tconst list = document.getElementById(\"server-orders\");\n\t\t\t\tconst emptyEl = document.getElementById(\"server-empty\");\n\t\t\t\tconst countEl = root.querySelector('[data-server-count=\"unpaid\"]');\n\t\t\t\tconst streamDot = document.getElementById(\"server-stream-dot\");\n\t\t\t\tconst streamLabel = document.getElementById(\"server-stream-label\");\n\t\t\t\tconst refetchButton = document.getElementById(\"server-refetch\");\n\n\t\t\t\tfunction updateCount() {\n\t\t\t\t\tif (!list) return;\n\t\t\t\t\tconst n = list.querySelectorAll(\".server-order-card\").length;\n\t\t\t\t\tif (countEl) countEl.textContent = String(n);\n\t\t\t\t\tif (emptyEl) emptyEl.classList.toggle(\"hidden\", n > 0);\n\t\t\t\t}\n\n\t\t\t\tfunction ageLabel(minutes) {\n\t\t\t\t\tif (minutes < 1) return \"now\";\n\t\t\t\t\tif (minutes < 60) return `${minutes}m`;\n\t\t\t\t\tconst hours = Math.floor(minutes / 60);\n\t\t\t\t\tconst remaining = minutes % 60;\n\t\t\t\t\tif (hours < 24) return remaining === 0 ? `${hours}h` : `${hours}h ${remaining}m`;\n\t\t\t\t\treturn `${Math.floor(hours / 24)}d`;\n\t\t\t\t}\n\n\t\t\t\tfunction updateAges() {\n\t\t\t\t\tconst nowUnix = Math.floor(Date.now() / 1000);\n\t\t\t\t\troot.querySelectorAll(\".server-order-card\").forEach((card) => {\n\t\t\t\t\t\tconst ageNode = card.querySelector(\"[data-order-age]\");\n\t\t\t\t\t\tif (!ageNode) return;\n\t\t\t\t\t\tconst ts = Number.parseInt(card.getAttribute(\"data-order-created-at\") || \"0\", 10);\n\t\t\t\t\t\tif (!Number.isFinite(ts) || ts <= 0) {\n\t\t\t\t\t\t\tageNode.textContent = \"Unknown\";\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tconst minutes = Math.max(0, Math.floor((nowUnix - ts) / 60));\n\t\t\t\t\t\tageNode.textContent = ageLabel(minutes);\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction setStreamStatus(status) {\n\t\t\t\t\tif (!streamDot || !streamLabel) return;\n\t\t\t\t\tconst map = {\n\t\t\t\t\t\tconnected:    [\"connected\",    \"Live updates on\"],\n\t\t\t\t\t\tconnecting:   [\"connecting\",   \"Connecting live updates...\"],\n\t\t\t\t\t\tdisconnected: [\"disconnected\", \"Live updates offline\"],\n\t\t\t\t\t\tunsupported:  [\"unsupported\",  \"Live updates unavailable\"],\n\t\t\t\t\t};\n\t\t\t\t\tconst v = map[status] || map.unsupported;\n\t\t\t\t\tstreamDot.setAttribute(\"data-state\", v[0]);\n\t\t\t\t\tstreamLabel.textContent = v[1];\n\t\t\t\t}\n\n\t\t\t\tlet probe = null;\n\t\t\t\tfunction connect() {\n\t\t\t\t\tif (!window.EventSource) { setStreamStatus(\"unsupported\"); return; }\n\t\t\t\t\tif (probe) probe.close();\n\t\t\t\t\tsetStreamStatus(\"connecting\");\n\t\t\t\t\tprobe = new EventSource(\"/server/stream\");\n\t\t\t\t\tprobe.onopen = () => setStreamStatus(\"connected\");\n\t\t\t\t\tprobe.onerror = () => setStreamStatus(\"disconnected\");\n\t\t\t\t}\n\n\t\t\t\tif (refetchButton) refetchButton.addEventListener(\"click\", () => window.location.reload());\n\n\t\t\t\tconst observer = new MutationObserver(() => { updateCount(); updateAges(); });\n\t\t\t\tif (list) observer.observe(list, { childList: true, subtree: false });\n\n\t\t\t\t// ── Service requests (call server / request bill) ──────────────────\n\t\t\t\tconst requests = document.getElementById(\"service-requests\");\n\t\t\t\tlet audioCtx = null;\n\t\t\t\tfunction chime() {\n\t\t\t\t\ttry {\n\t\t\t\t\t\taudioCtx = audioCtx || new (window.AudioContext || window.webkitAudioContext)();\n\t\t\t\t\t\tif (audioCtx.state === \"suspended\") audioCtx.resume();\n\t\t\t\t\t\tconst now = audioCtx.currentTime;\n\t\t\t\t\t\t[880, 1320].forEach((freq, i) => {\n\t\t\t\t\t\t\tconst osc = audioCtx.createOscillator();\n\t\t\t\t\t\t\tconst gain = audioCtx.createGain();\n\t\t\t\t\t\t\tosc.type = \"sine\";\n\t\t\t\t\t\t\tosc.frequency.value = freq;\n\t\t\t\t\t\t\tconst t = now + i * 0.16;\n\t\t\t\t\t\t\tgain.gain.setValueAtTime(0.0001, t);\n\t\t\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.22, t + 0.02);\n\t\t\t\t\t\t\tgain.gain.exponentialRampToValueAtTime(0.0001, t + 0.32);\n\t\t\t\t\t\t\tosc.connect(gain).connect(audioCtx.destination);\n\t\t\t\t\t\t\tosc.start(t);\n\t\t\t\t\t\t\tosc.stop(t + 0.34);\n\t\t\t\t\t\t});\n\t\t\t\t\t} catch (e) { /* audio unavailable — visual alert still shows */ }\n\t\t\t\t}\n\t\t\t\tif (requests) {\n\t\t\t\t\tnew MutationObserver((mutations) => {\n\t\t\t\t\t\tlet added = false;\n\t\t\t\t\t\tfor (const m of mutations) {\n\t\t\t\t\t\t\tm.addedNodes.forEach((n) => {\n\t\t\t\t\t\t\t\tif (n.nodeType === 1 && n.matches(\"[data-service-alert]\")) added = true;\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (added) chime();\n\t\t\t\t\t}).observe(requests, { childList: true });\n\n\t\t\t\t\trequests.addEventListener(\"click\", (e) => {\n\t\t\t\t\t\tconst btn = e.target.closest(\"[data-dismiss-service-alert]\");\n\t\t\t\t\t\tif (!btn) return;\n\t\t\t\t\t\tconst alert = btn.closest(\"[data-service-alert]\");\n\t\t\t\t\t\tif (alert) alert.remove();\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tconnect();\n\t\t\t\tupdateCount();\n\t\t\t\tupdateAges();\n\t\t\t\twindow.setInterval(updateAges, 60000);\n\t\t\t\twindow.addEventListener(\"beforeunload\", () => { if (probe) probe.close(); });\n\t\t\t})();\n\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -133,4 +127,44 @@ func ServerPage(orders []*order.Order, csrfToken string, activeRestaurantLabel s
 	})
 }
 
+// ServerOrderList is the FOH ticket list; stream snapshots morph it whole.
+func ServerOrderList(orders []*order.Order) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var8 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var8 == nil {
+			templ_7745c5c3_Var8 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "<div id=\"server-orders\" class=\"server-orders space-y-3\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		for _, o := range orders {
+			templ_7745c5c3_Err = components.ServerOrderCard(o).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "</div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
 var _ = templruntime.GeneratedTemplate
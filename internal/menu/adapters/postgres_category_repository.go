@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tax"
 	"bitmerchant/internal/menu/domain/menu"
 )
 
@@ -19,11 +20,12 @@ func NewPostgresCategoryRepository(db *sql.DB) *PostgresCategoryRepository {
 
 func (r *PostgresCategoryRepository) Save(category *menu.MenuCategory) error {
 	_, err := r.db.Exec(
-		`INSERT INTO menu_categories (id, restaurant_id, name, display_order, is_active, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`INSERT INTO menu_categories (id, restaurant_id, name, display_order, is_active, created_at, updated_at, tax_class)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		 ON CONFLICT (id) DO UPDATE
 		 SET name = EXCLUDED.name, display_order = EXCLUDED.display_order,
-		     is_active = EXCLUDED.is_active, updated_at = EXCLUDED.updated_at`,
+		     is_active = EXCLUDED.is_active, updated_at = EXCLUDED.updated_at,
+		     tax_class = EXCLUDED.tax_class`,
 		string(category.ID),
 		string(category.RestaurantID),
 		category.Name,
@@ -31,20 +33,21 @@ func (r *PostgresCategoryRepository) Save(category *menu.MenuCategory) error {
 		category.IsActive,
 		category.CreatedAt,
 		category.UpdatedAt,
+		string(category.TaxClass),
 	)
 	return err
 }
 
 func (r *PostgresCategoryRepository) FindByID(id common.CategoryID) (*menu.MenuCategory, error) {
 	row := r.db.QueryRow(
-		`SELECT id, restaurant_id, name, display_order, is_active, created_at, updated_at
+		`SELECT id, restaurant_id, name, display_order, is_active, created_at, updated_at, COALESCE(tax_class, '')
 		 FROM menu_categories WHERE id = $1`, string(id))
 	return scanCategory(row)
 }
 
 func (r *PostgresCategoryRepository) FindByRestaurantID(restaurantID common.RestaurantID) ([]*menu.MenuCategory, error) {
 	rows, err := r.db.Query(
-		`SELECT id, restaurant_id, name, display_order, is_active, created_at, updated_at
+		`SELECT id, restaurant_id, name, display_order, is_active, created_at, updated_at, COALESCE(tax_class, '')
 		 FROM menu_categories WHERE restaurant_id = $1`, string(restaurantID))
 	if err != nil {
 		return nil, err
@@ -63,9 +66,9 @@ func (r *PostgresCategoryRepository) FindByRestaurantID(restaurantID common.Rest
 
 func (r *PostgresCategoryRepository) Update(category *menu.MenuCategory) error {
 	result, err := r.db.Exec(
-		`UPDATE menu_categories SET name=$2, display_order=$3, is_active=$4, updated_at=$5
+		`UPDATE menu_categories SET name=$2, display_order=$3, is_active=$4, updated_at=$5, tax_class=$6
 		 WHERE id=$1`,
-		string(category.ID), category.Name, category.DisplayOrder, category.IsActive, category.UpdatedAt)
+		string(category.ID), category.Name, category.DisplayOrder, category.IsActive, category.UpdatedAt, string(category.TaxClass))
 	if err != nil {
 		return err
 	}
@@ -91,11 +94,12 @@ func (r *PostgresCategoryRepository) Delete(id common.CategoryID) error {
 func scanCategory(row *sql.Row) (*menu.MenuCategory, error) {
 	var (
 		id, restID, name     string
+		taxClass             string
 		displayOrder         int
 		isActive             bool
 		createdAt, updatedAt time.Time
 	)
-	if err := row.Scan(&id, &restID, &name, &displayOrder, &isActive, &createdAt, &updatedAt); err != nil {
+	if err := row.Scan(&id, &restID, &name, &displayOrder, &isActive, &createdAt, &updatedAt, &taxClass); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("menu category not found")
 		}
@@ -104,6 +108,7 @@ func scanCategory(row *sql.Row) (*menu.MenuCategory, error) {
 	return &menu.MenuCategory{
 		ID: common.CategoryID(id), RestaurantID: common.RestaurantID(restID),
 		Name: name, DisplayOrder: displayOrder, IsActive: isActive,
+		TaxClass:  tax.Class(taxClass),
 		CreatedAt: createdAt, UpdatedAt: updatedAt,
 	}, nil
 }
@@ -111,16 +116,18 @@ func scanCategory(row *sql.Row) (*menu.MenuCategory, error) {
 func scanCategoryRows(rows *sql.Rows) (*menu.MenuCategory, error) {
 	var (
 		id, restID, name     string
+		taxClass             string
 		displayOrder         int
 		isActive             bool
 		createdAt, updatedAt time.Time
 	)
-	if err := rows.Scan(&id, &restID, &name, &displayOrder, &isActive, &createdAt, &updatedAt); err != nil {
+	if err := rows.Scan(&id, &restID, &name, &displayOrder, &isActive, &createdAt, &updatedAt, &taxClass); err != nil {
 		return nil, err
 	}
 	return &menu.MenuCategory{
 		ID: common.CategoryID(id), RestaurantID: common.RestaurantID(restID),
 		Name: name, DisplayOrder: displayOrder, IsActive: isActive,
+		TaxClass:  tax.Class(taxClass),
 		CreatedAt: createdAt, UpdatedAt: updatedAt,
 	}, nil
 }
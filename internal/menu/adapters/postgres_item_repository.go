@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
 	"bitmerchant/internal/menu/domain/menu"
 )
 
@@ -19,10 +20,10 @@ func NewPostgresItemRepository(db *sql.DB) *PostgresItemRepository {
 	return &PostgresItemRepository{db: db}
 }
 
-const itemSelectCols = `id, category_id, restaurant_id, name, description, price, COALESCE(currency, 'USD'), COALESCE(price_minor, 0), photo_url, photo_original_url, is_available, display_order, created_at, updated_at, COALESCE(is_vegetarian, false), COALESCE(is_gluten_free, false), COALESCE(is_spicy, false), COALESCE(option_groups, '[]'::jsonb), COALESCE(spice_level, ''), COALESCE(sku, ''), COALESCE(schedule, 'ALL_DAY'), COALESCE(is_vegan, false), COALESCE(is_dairy_free, false), COALESCE(is_halal, false), COALESCE(is_nut_free, false), COALESCE(allergens, '[]'::jsonb), COALESCE(badges, '[]'::jsonb), COALESCE(allow_special_instructions, true), COALESCE(translations, '{}'::jsonb)`
+const itemSelectCols = `id, category_id, restaurant_id, name, description, COALESCE(currency, 'USD'), price_minor, photo_url, photo_original_url, is_available, display_order, created_at, updated_at, COALESCE(is_vegetarian, false), COALESCE(is_gluten_free, false), COALESCE(is_spicy, false), COALESCE(option_groups, '[]'::jsonb), COALESCE(spice_level, ''), COALESCE(sku, ''), COALESCE(schedule, 'ALL_DAY'), COALESCE(is_vegan, false), COALESCE(is_dairy_free, false), COALESCE(is_halal, false), COALESCE(is_nut_free, false), COALESCE(allergens, '[]'::jsonb), COALESCE(badges, '[]'::jsonb), COALESCE(allow_special_instructions, true), COALESCE(translations, '{}'::jsonb), COALESCE(tax_class, '')`
 
 func (r *PostgresItemRepository) Save(item *menu.MenuItem) error {
-	currency, priceMinor := itemCurrencyAndMinor(item)
+	currency := itemCurrency(item)
 	optionGroupsJSON, err := marshalOptionGroups(item.OptionGroups)
 	if err != nil {
 		return err
@@ -44,11 +45,11 @@ func (r *PostgresItemRepository) Save(item *menu.MenuItem) error {
 		schedule = menu.ScheduleAllDay
 	}
 	_, err = r.db.Exec(
-		`INSERT INTO menu_items (id, category_id, restaurant_id, name, description, price, currency, price_minor, photo_url, photo_original_url, is_available, display_order, created_at, updated_at, is_vegetarian, is_gluten_free, is_spicy, option_groups, spice_level, sku, schedule, is_vegan, is_dairy_free, is_halal, is_nut_free, allergens, badges, allow_special_instructions, translations)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, NULLIF($19, ''), $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
+		`INSERT INTO menu_items (id, category_id, restaurant_id, name, description, currency, price_minor, photo_url, photo_original_url, is_available, display_order, created_at, updated_at, is_vegetarian, is_gluten_free, is_spicy, option_groups, spice_level, sku, schedule, is_vegan, is_dairy_free, is_halal, is_nut_free, allergens, badges, allow_special_instructions, translations, tax_class)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NULLIF($18, ''), $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
 		 ON CONFLICT (id) DO UPDATE
 		 SET category_id = EXCLUDED.category_id, name = EXCLUDED.name,
-		     description = EXCLUDED.description, price = EXCLUDED.price,
+		     description = EXCLUDED.description,
 		     currency = EXCLUDED.currency, price_minor = EXCLUDED.price_minor,
 		     photo_url = EXCLUDED.photo_url, photo_original_url = EXCLUDED.photo_original_url,
 		     is_available = EXCLUDED.is_available, display_order = EXCLUDED.display_order,
@@ -60,16 +61,17 @@ func (r *PostgresItemRepository) Save(item *menu.MenuItem) error {
 		     allergens = EXCLUDED.allergens, badges = EXCLUDED.badges,
 		     allow_special_instructions = EXCLUDED.allow_special_instructions,
 		     translations = EXCLUDED.translations,
+		     tax_class = EXCLUDED.tax_class,
 		     updated_at = EXCLUDED.updated_at`,
 		string(item.ID), string(item.CategoryID), string(item.RestaurantID),
-		item.Name, item.Description, item.Price,
-		currency.Code, priceMinor,
+		item.Name, item.Description,
+		currency.Code, item.Price,
 		item.PhotoURL, item.PhotoOriginalURL, item.IsAvailable, item.DisplayOrder,
 		item.CreatedAt, item.UpdatedAt,
 		item.IsVegetarian, item.IsGlutenFree, item.IsSpicy, optionGroupsJSON,
 		item.SpiceLevel, item.SKU, schedule,
 		item.IsVegan, item.IsDairyFree, item.IsHalal, item.IsNutFree,
-		allergensJSON, badgesJSON, item.AllowSpecialInstructions, translationsJSON)
+		allergensJSON, badgesJSON, item.AllowSpecialInstructions, translationsJSON, string(item.TaxClass))
 	return err
 }
 
@@ -93,7 +95,7 @@ func (r *PostgresItemRepository) FindAvailableByRestaurantID(restaurantID common
 }
 
 func (r *PostgresItemRepository) Update(item *menu.MenuItem) error {
-	currency, priceMinor := itemCurrencyAndMinor(item)
+	currency := itemCurrency(item)
 	optionGroupsJSON, err := marshalOptionGroups(item.OptionGroups)
 	if err != nil {
 		return err
@@ -115,18 +117,18 @@ func (r *PostgresItemRepository) Update(item *menu.MenuItem) error {
 		schedule = menu.ScheduleAllDay
 	}
 	result, err := r.db.Exec(
-		`UPDATE menu_items SET category_id=$2, name=$3, description=$4, price=$5, currency=$6, price_minor=$7, photo_url=$8, photo_original_url=$9, is_available=$10, display_order=$11, is_vegetarian=$12, is_gluten_free=$13, is_spicy=$14, option_groups=$15, updated_at=$16,
-		     spice_level=NULLIF($17, ''), sku=$18, schedule=$19,
-		     is_vegan=$20, is_dairy_free=$21, is_halal=$22, is_nut_free=$23,
-		     allergens=$24, badges=$25, allow_special_instructions=$26, translations=$27
+		`UPDATE menu_items SET category_id=$2, name=$3, description=$4, currency=$5, price_minor=$6, photo_url=$7, photo_original_url=$8, is_available=$9, display_order=$10, is_vegetarian=$11, is_gluten_free=$12, is_spicy=$13, option_groups=$14, updated_at=$15,
+		     spice_level=NULLIF($16, ''), sku=$17, schedule=$18,
+		     is_vegan=$19, is_dairy_free=$20, is_halal=$21, is_nut_free=$22,
+		     allergens=$23, badges=$24, allow_special_instructions=$25, translations=$26, tax_class=$27
 		 WHERE id=$1`,
-		string(item.ID), string(item.CategoryID), item.Name, item.Description, item.Price,
-		currency.Code, priceMinor,
+		string(item.ID), string(item.CategoryID), item.Name, item.Description,
+		currency.Code, item.Price,
 		item.PhotoURL, item.PhotoOriginalURL, item.IsAvailable, item.DisplayOrder,
 		item.IsVegetarian, item.IsGlutenFree, item.IsSpicy, optionGroupsJSON, item.UpdatedAt,
 		item.SpiceLevel, item.SKU, schedule,
 		item.IsVegan, item.IsDairyFree, item.IsHalal, item.IsNutFree,
-		allergensJSON, badgesJSON, item.AllowSpecialInstructions, translationsJSON)
+		allergensJSON, badgesJSON, item.AllowSpecialInstructions, translationsJSON, string(item.TaxClass))
 	if err != nil {
 		return err
 	}
@@ -254,7 +256,6 @@ func (r *PostgresItemRepository) queryItems(query string, args ...interface{}) (
 type itemRowFields struct {
 	id, catID, restID, name  string
 	description              sql.NullString
-	price                    float64
 	currencyCode             string
 	priceMinor               int64
 	photoURL, photoOrigURL   sql.NullString
@@ -276,6 +277,7 @@ type itemRowFields struct {
 	badgesJSON               []byte
 	allowSpecialInstructions bool
 	translationsJSON         []byte
+	taxClass                 string
 }
 
 func (f *itemRowFields) toMenuItem() *menu.MenuItem {
@@ -290,7 +292,7 @@ func (f *itemRowFields) toMenuItem() *menu.MenuItem {
 	return &menu.MenuItem{
 		ID: common.ItemID(f.id), CategoryID: common.CategoryID(f.catID),
 		RestaurantID: common.RestaurantID(f.restID), Name: f.name,
-		Description: f.description.String, Price: f.price, Currency: currency,
+		Description: f.description.String, Price: f.priceMinor, Currency: currency,
 		PhotoURL: f.photoURL.String, PhotoOriginalURL: f.photoOrigURL.String,
 		IsAvailable: f.isAvailable, DisplayOrder: f.displayOrder,
 		IsVegetarian: f.isVegetarian, IsGlutenFree: f.isGlutenFree, IsSpicy: f.isSpicy,
@@ -300,18 +302,19 @@ func (f *itemRowFields) toMenuItem() *menu.MenuItem {
 		AllowSpecialInstructions: f.allowSpecialInstructions,
 		OptionGroups:             groups,
 		Translations:             translations,
+		TaxClass:                 tax.Class(f.taxClass),
 		CreatedAt:                f.createdAt, UpdatedAt: f.updatedAt,
 	}
 }
 
 func (f *itemRowFields) scanTargets() []any {
 	return []any{
-		&f.id, &f.catID, &f.restID, &f.name, &f.description, &f.price, &f.currencyCode, &f.priceMinor,
+		&f.id, &f.catID, &f.restID, &f.name, &f.description, &f.currencyCode, &f.priceMinor,
 		&f.photoURL, &f.photoOrigURL, &f.isAvailable, &f.displayOrder, &f.createdAt, &f.updatedAt,
 		&f.isVegetarian, &f.isGlutenFree, &f.isSpicy, &f.optionGroupsJSON,
 		&f.spiceLevel, &f.sku, &f.schedule,
 		&f.isVegan, &f.isDairyFree, &f.isHalal, &f.isNutFree,
-		&f.allergensJSON, &f.badgesJSON, &f.allowSpecialInstructions, &f.translationsJSON,
+		&f.allergensJSON, &f.badgesJSON, &f.allowSpecialInstructions, &f.translationsJSON, &f.taxClass,
 	}
 }
 
@@ -346,9 +349,9 @@ type jsonOptionGroup struct {
 }
 
 type jsonOption struct {
-	ID         string  `json:"id"`
-	Name       string  `json:"name"`
-	PriceDelta float64 `json:"price_delta"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	PriceDelta int64  `json:"price_delta"` // minor units of the item currency
 }
 
 func marshalOptionGroups(groups []menu.OptionGroup) ([]byte, error) {
@@ -442,11 +445,9 @@ func unmarshalStringList(data []byte) []string {
 	return out
 }
 
-func itemCurrencyAndMinor(item *menu.MenuItem) (money.Currency, int64) {
-	currency := item.Currency
-	if currency.IsZero() {
-		currency = money.USD
+func itemCurrency(item *menu.MenuItem) money.Currency {
+	if item.Currency.IsZero() {
+		return money.USD
 	}
-	priceMinor := money.FromMajor(item.Price, currency).Amount
-	return currency, priceMinor
+	return item.Currency
 }
@@ -13,13 +13,14 @@ import (
 )
 
 // CreateMenuItem creates a new menu item in a category. CurrencyCode is the
-// currency the price is denominated in; empty defaults to USD.
+// currency the price is denominated in; empty defaults to USD. Price is in
+// that currency's minor units.
 type CreateMenuItem struct {
 	RestaurantID common.RestaurantID
 	CategoryID   common.CategoryID
 	Name         string
 	Description  string
-	Price        float64
+	Price        int64
 	CurrencyCode string
 	Available    bool
 	IsVegetarian bool
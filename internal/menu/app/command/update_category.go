@@ -17,6 +17,8 @@ type UpdateMenuCategory struct {
 	Name         string
 	DisplayOrder int
 	IsActive     bool
+	// TaxClass is optional; nil leaves the category's class unchanged.
+	TaxClass *string
 }
 
 type UpdateMenuCategoryHandler decorator.CommandHandler[UpdateMenuCategory]
@@ -52,6 +54,11 @@ func (h updateMenuCategoryHandler) Handle(ctx context.Context, cmd UpdateMenuCat
 	cat.Name = cmd.Name
 	cat.DisplayOrder = cmd.DisplayOrder
 	cat.SetActive(cmd.IsActive)
+	if cmd.TaxClass != nil {
+		if err := cat.SetTaxClass(*cmd.TaxClass); err != nil {
+			return err
+		}
+	}
 
 	return h.repo.Update(cat)
 }
@@ -21,7 +21,7 @@ type UpdateMenuItem struct {
 	CategoryID   common.CategoryID
 	Name         string
 	Description  string
-	Price        float64
+	Price        int64 // minor units of the item's currency
 	Available    bool
 	IsVegetarian bool
 	IsGlutenFree bool
@@ -32,6 +32,7 @@ type UpdateMenuItem struct {
 	SpiceLevel               *string
 	Schedule                 *string
 	SKU                      *string
+	TaxClass                 *string
 	IsVegan                  *bool
 	IsDairyFree              *bool
 	IsHalal                  *bool
@@ -145,7 +146,7 @@ func optionalSetters(cmd UpdateMenuItem) []struct {
 	set func(*menu.MenuItem) error
 } {
 	type step = struct{ set func(*menu.MenuItem) error }
-	steps := []step{{}, {}, {}, {}, {}, {}, {}}
+	steps := []step{{}, {}, {}, {}, {}, {}, {}, {}}
 	if cmd.SpiceLevel != nil {
 		v := *cmd.SpiceLevel
 		steps[0].set = func(m *menu.MenuItem) error { return m.SetSpiceLevel(v) }
@@ -174,6 +175,10 @@ func optionalSetters(cmd UpdateMenuItem) []struct {
 		v := *cmd.Translations
 		steps[6].set = func(m *menu.MenuItem) error { return m.SetTranslations(v) }
 	}
+	if cmd.TaxClass != nil {
+		v := *cmd.TaxClass
+		steps[7].set = func(m *menu.MenuItem) error { return m.SetTaxClass(v) }
+	}
 	return steps
 }
 
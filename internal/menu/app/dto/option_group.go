@@ -3,17 +3,25 @@
 // drift between the form payload, the JSONB column, and integration fixtures.
 package dto
 
-import "bitmerchant/internal/menu/domain/menu"
+import (
+	"encoding/json"
+	"fmt"
 
-// OptionDTO is the JSON shape of a single option within a group.
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/menu/domain/menu"
+)
+
+// OptionDTO is the JSON shape of a single option within a group. The editor
+// works in major units, so PriceDelta is kept as the decimal the browser sent
+// and parsed exactly into minor units by ToDomain.
 type OptionDTO struct {
-	ID         string  `json:"id"`
-	Name       string  `json:"name"`
-	PriceDelta float64 `json:"price_delta"`
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	PriceDelta json.Number `json:"price_delta"`
 }
 
-// OptionGroupDTO is the JSON shape persisted in menu_items.option_groups and
-// posted by the admin editor form via the hidden option_groups_json field.
+// OptionGroupDTO is the JSON shape posted by the admin editor form via the
+// hidden option_groups_json field.
 type OptionGroupDTO struct {
 	ID              string      `json:"id"`
 	Name            string      `json:"name"`
@@ -24,16 +32,24 @@ type OptionGroupDTO struct {
 	Options         []OptionDTO `json:"options"`
 }
 
-// ToDomain converts a DTO slice into the domain type.
-func ToDomain(in []OptionGroupDTO) []menu.OptionGroup {
+// ToDomain converts a DTO slice into the domain type, reading price deltas
+// as major units of currency.
+func ToDomain(in []OptionGroupDTO, currency money.Currency) ([]menu.OptionGroup, error) {
 	if len(in) == 0 {
-		return nil
+		return nil, nil
 	}
 	out := make([]menu.OptionGroup, len(in))
 	for i, g := range in {
 		opts := make([]menu.Option, len(g.Options))
 		for j, o := range g.Options {
-			opts[j] = menu.Option{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta}
+			delta := money.New(0, currency)
+			if o.PriceDelta != "" {
+				var err error
+				if delta, err = money.ParseMajor(o.PriceDelta.String(), currency); err != nil {
+					return nil, fmt.Errorf("option %q: %w", o.Name, err)
+				}
+			}
+			opts[j] = menu.Option{ID: o.ID, Name: o.Name, PriceDelta: delta.Amount}
 		}
 		out[i] = menu.OptionGroup{
 			ID:              g.ID,
@@ -45,11 +61,12 @@ func ToDomain(in []OptionGroupDTO) []menu.OptionGroup {
 			Options:         opts,
 		}
 	}
-	return out
+	return out, nil
 }
 
-// FromDomain converts domain option groups into DTOs.
-func FromDomain(in []menu.OptionGroup) []OptionGroupDTO {
+// FromDomain converts domain option groups into DTOs priced in major units
+// of currency.
+func FromDomain(in []menu.OptionGroup, currency money.Currency) []OptionGroupDTO {
 	if len(in) == 0 {
 		return nil
 	}
@@ -57,7 +74,8 @@ func FromDomain(in []menu.OptionGroup) []OptionGroupDTO {
 	for i, g := range in {
 		opts := make([]OptionDTO, len(g.Options))
 		for j, o := range g.Options {
-			opts[j] = OptionDTO{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta}
+			delta := money.New(o.PriceDelta, currency).FormatNoSymbol()
+			opts[j] = OptionDTO{ID: o.ID, Name: o.Name, PriceDelta: json.Number(delta)}
 		}
 		out[i] = OptionGroupDTO{
 			ID:              g.ID,
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tax"
 )
 
 // MenuCategory represents a logical grouping of menu items.
@@ -14,8 +15,10 @@ type MenuCategory struct {
 	Name         string
 	DisplayOrder int
 	IsActive     bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// TaxClass applies to the category's items that have none of their own.
+	TaxClass  tax.Class
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 func NewMenuCategory(id common.CategoryID, restaurantID common.RestaurantID, name string, displayOrder int) (*MenuCategory, error) {
@@ -62,3 +65,14 @@ func (c *MenuCategory) SetActive(isActive bool) {
 	c.IsActive = isActive
 	c.UpdatedAt = time.Now()
 }
+
+// SetTaxClass validates and assigns the tax class; blank clears it.
+func (c *MenuCategory) SetTaxClass(raw string) error {
+	cl, err := tax.NormalizeClass(raw)
+	if err != nil {
+		return err
+	}
+	c.TaxClass = cl
+	c.UpdatedAt = time.Now()
+	return nil
+}
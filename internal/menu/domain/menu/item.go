@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
 )
 
 // ItemTranslation holds a localized name/description for a menu item.
@@ -20,7 +21,7 @@ type ItemTranslation struct {
 type Option struct {
 	ID         string
 	Name       string
-	PriceDelta float64 // additional cost; 0 means no surcharge
+	PriceDelta int64 // additional cost in the item currency's minor units; 0 means no surcharge
 }
 
 // OptionGroup is a set of choices attached to a menu item.
@@ -65,27 +66,30 @@ const MaxSKULength = 32
 
 // MenuItem represents a food/drink item.
 type MenuItem struct {
-	ID                       common.ItemID
-	CategoryID               common.CategoryID
-	RestaurantID             common.RestaurantID
-	Name                     string
-	Description              string
-	Price                    float64
-	Currency                 money.Currency
-	PhotoURL                 string
-	PhotoOriginalURL         string
-	IsAvailable              bool
-	DisplayOrder             int
-	IsVegetarian             bool
-	IsGlutenFree             bool
-	IsSpicy                  bool // deprecated; use SpiceLevel. Kept for one release.
-	IsVegan                  bool
-	IsDairyFree              bool
-	IsHalal                  bool
-	IsNutFree                bool
-	SpiceLevel               string
-	Schedule                 string
-	SKU                      string
+	ID               common.ItemID
+	CategoryID       common.CategoryID
+	RestaurantID     common.RestaurantID
+	Name             string
+	Description      string
+	Price            int64 // minor units of Currency
+	Currency         money.Currency
+	PhotoURL         string
+	PhotoOriginalURL string
+	IsAvailable      bool
+	DisplayOrder     int
+	IsVegetarian     bool
+	IsGlutenFree     bool
+	IsSpicy          bool // deprecated; use SpiceLevel. Kept for one release.
+	IsVegan          bool
+	IsDairyFree      bool
+	IsHalal          bool
+	IsNutFree        bool
+	SpiceLevel       string
+	Schedule         string
+	SKU              string
+	// TaxClass routes the item to the restaurant's taxes; standard ("")
+	// inherits the category's class.
+	TaxClass                 tax.Class
 	Allergens                []string
 	Badges                   []string
 	AllowSpecialInstructions bool
@@ -161,21 +165,29 @@ func (m *MenuItem) HasOptionGroups() bool {
 // Money returns the price as a money.Money value, falling back to USD when
 // the item was loaded from a row that predates currency support.
 func (m *MenuItem) Money() money.Money {
-	c := m.Currency
-	if c.IsZero() {
-		c = money.USD
+	return money.New(m.Price, m.currency())
+}
+
+// OptionMoney returns an option's surcharge in the item's currency.
+func (m *MenuItem) OptionMoney(o Option) money.Money {
+	return money.New(o.PriceDelta, m.currency())
+}
+
+func (m *MenuItem) currency() money.Currency {
+	if m.Currency.IsZero() {
+		return money.USD
 	}
-	return money.FromMajor(m.Price, c)
+	return m.Currency
 }
 
-func NewMenuItem(id common.ItemID, categoryID common.CategoryID, restaurantID common.RestaurantID, name string, price float64) (*MenuItem, error) {
+// NewMenuItem creates a USD menu item; price is in cents.
+func NewMenuItem(id common.ItemID, categoryID common.CategoryID, restaurantID common.RestaurantID, name string, price int64) (*MenuItem, error) {
 	return NewMenuItemWithCurrency(id, categoryID, restaurantID, name, price, money.USD)
 }
 
 // NewMenuItemWithCurrency creates a menu item priced in the given currency.
-// For SAT, price is the whole-sat count (no decimals) — pass e.g. 5000 for
-// 5,000 sats.
-func NewMenuItemWithCurrency(id common.ItemID, categoryID common.CategoryID, restaurantID common.RestaurantID, name string, price float64, currency money.Currency) (*MenuItem, error) {
+// price is in minor units — pass 1250 for $12.50, or 5000 for 5,000 sats.
+func NewMenuItemWithCurrency(id common.ItemID, categoryID common.CategoryID, restaurantID common.RestaurantID, name string, price int64, currency money.Currency) (*MenuItem, error) {
 	if err := ValidateItemName(name); err != nil {
 		return nil, err
 	}
@@ -227,21 +239,17 @@ func ValidateItemName(name string) error {
 	return nil
 }
 
-func ValidatePrice(price float64) error {
+func ValidatePrice(price int64) error {
 	return ValidatePriceForCurrency(price, money.USD)
 }
 
-// ValidatePriceForCurrency enforces sane bounds per currency. SAT prices
-// must be whole numbers (no fractional sats); fiat prices allow decimals
-// down to one minor unit.
-func ValidatePriceForCurrency(price float64, currency money.Currency) error {
+// ValidatePriceForCurrency enforces sane bounds per currency on a price in
+// minor units.
+func ValidatePriceForCurrency(price int64, currency money.Currency) error {
 	if price <= 0 {
 		return errors.New("price must be greater than 0")
 	}
 	if currency.Code == money.SAT.Code {
-		if price != float64(int64(price)) {
-			return errors.New("sat price must be a whole number")
-		}
 		// 21M BTC = 2.1e15 sats — well within int64 but cap at 1B sats per
 		// item as a sanity bound (~ $1M USD-equivalent at $100k/BTC).
 		if price > 1_000_000_000 {
@@ -249,7 +257,7 @@ func ValidatePriceForCurrency(price float64, currency money.Currency) error {
 		}
 		return nil
 	}
-	if price > 100_000_000 {
+	if price > money.FromMajor(100_000_000, currency).Amount {
 		return errors.New("price must be less than 100,000,000")
 	}
 	return nil
@@ -511,6 +519,26 @@ func (m *MenuItem) SetSKU(sku string) error {
 	return nil
 }
 
+// SetTaxClass validates and assigns the tax class; blank clears it.
+func (m *MenuItem) SetTaxClass(raw string) error {
+	c, err := tax.NormalizeClass(raw)
+	if err != nil {
+		return err
+	}
+	m.TaxClass = c
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// EffectiveTaxClass is the item's own class, or cat's when it has none.
+// cat may be nil.
+func (m *MenuItem) EffectiveTaxClass(cat *MenuCategory) tax.Class {
+	if m.TaxClass != tax.ClassStandard || cat == nil {
+		return m.TaxClass
+	}
+	return cat.TaxClass
+}
+
 // SetBadges normalises and assigns the badges.
 func (m *MenuItem) SetBadges(badges []string) error {
 	normalised, err := NormalizeBadges(badges)
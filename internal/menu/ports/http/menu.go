@@ -3,6 +3,7 @@ package http
 import (
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
 
 	"bitmerchant/internal/interfaces/templates"
 	menuQuery "bitmerchant/internal/menu/app/query"
@@ -48,14 +49,18 @@ type MenuHandler struct {
 	cartService   *cart.CartService
 	recordVisitUC placesCmd.RecordMenuVisitHandler
 	orderRepo     order.Repository
+	// converter quotes prices in sats next to the base currency; nil hides
+	// the sats hint.
+	converter money.Converter
 }
 
-func NewMenuHandler(getMenu menuQuery.MenuForCustomerHandler, cartService *cart.CartService, recordVisitUC placesCmd.RecordMenuVisitHandler, orderRepo order.Repository) *MenuHandler {
+func NewMenuHandler(getMenu menuQuery.MenuForCustomerHandler, cartService *cart.CartService, recordVisitUC placesCmd.RecordMenuVisitHandler, orderRepo order.Repository, converter money.Converter) *MenuHandler {
 	return &MenuHandler{
 		getMenu:       getMenu,
 		cartService:   cartService,
 		recordVisitUC: recordVisitUC,
 		orderRepo:     orderRepo,
+		converter:     converter,
 	}
 }
 
@@ -97,11 +102,11 @@ func (h *MenuHandler) GetMenu(c echo.Context) error {
 		}
 	}
 
-	locale := commonhttp.ResolveLocale(c)
+	locale := commonhttp.SwitchLocale(c)
 	locales := availableMenuLocales(menuData)
 
 	// Prevent caching so back button always fetches fresh state (updated cart)
 	c.Response().Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
 
-	return templates.MenuPage(menuData, cart, tableLabel, etaMinutes, locale, locales).Render(c.Request().Context(), c.Response())
+	return templates.MenuPage(menuData, cart, tableLabel, etaMinutes, locale, locales, money.SatsQuoter(c.Request().Context(), h.converter)).Render(c.Request().Context(), c.Response())
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/money"
 	menuCmd "bitmerchant/internal/menu/app/command"
 	menuQuery "bitmerchant/internal/menu/app/query"
 	"bitmerchant/internal/menu/domain/menu"
@@ -28,8 +30,10 @@ type Menu struct {
 // New wires menu bounded-context command/query handlers and the public menu HTTP handler.
 func New(
 	repos wiring.Repositories,
+	metrics decorator.MetricsClient,
 	photoStorage menu.PhotoStorage,
 	cfg wiring.Config,
+	converter money.Converter,
 	cartService *orderCart.CartService,
 	recordMenuVisitUC placesCmd.RecordMenuVisitHandler,
 ) Menu {
@@ -38,16 +42,16 @@ func New(
 		Endpoint:      cfg.S3Endpoint,
 		PublicBaseURL: cfg.S3PublicBaseURL,
 	}
-	getMenuUC := menuQuery.NewMenuForCustomerHandler(repos.MenuCategory, repos.MenuItem, repos.Restaurant, photoStorage, signer, nil, nil)
-	getMenuAdminUC := menuQuery.NewMenuForAdminHandler(repos.MenuCategory, repos.MenuItem, repos.Restaurant, photoStorage, signer, nil, nil)
-	updateMenuItemUC := menuCmd.NewUpdateMenuItemHandler(repos.MenuItem, repos.MenuCategory, nil, nil)
-	updateMenuCategoryUC := menuCmd.NewUpdateMenuCategoryHandler(repos.MenuCategory, nil, nil)
-	toggleItemAvailUC := menuCmd.NewToggleMenuItemAvailabilityHandler(repos.MenuItem, nil, nil)
-	createCatUC := menuCmd.NewCreateMenuCategoryHandler(repos.MenuCategory, nil, nil)
-	createItemUC := menuCmd.NewCreateMenuItemHandler(repos.MenuItem, nil, nil)
-	uploadPhotoUC := menuCmd.NewUploadMenuItemPhotoHandler(repos.MenuItem, photoStorage, nil, nil)
-	reorderCategoriesUC := menuCmd.NewReorderMenuCategoriesHandler(repos.MenuCategory, nil, nil)
-	reorderItemsUC := menuCmd.NewReorderMenuItemsHandler(repos.MenuItem, repos.MenuCategory, nil, nil)
+	getMenuUC := menuQuery.NewMenuForCustomerHandler(repos.MenuCategory, repos.MenuItem, repos.Restaurant, photoStorage, signer, nil, metrics)
+	getMenuAdminUC := menuQuery.NewMenuForAdminHandler(repos.MenuCategory, repos.MenuItem, repos.Restaurant, photoStorage, signer, nil, metrics)
+	updateMenuItemUC := menuCmd.NewUpdateMenuItemHandler(repos.MenuItem, repos.MenuCategory, nil, metrics)
+	updateMenuCategoryUC := menuCmd.NewUpdateMenuCategoryHandler(repos.MenuCategory, nil, metrics)
+	toggleItemAvailUC := menuCmd.NewToggleMenuItemAvailabilityHandler(repos.MenuItem, nil, metrics)
+	createCatUC := menuCmd.NewCreateMenuCategoryHandler(repos.MenuCategory, nil, metrics)
+	createItemUC := menuCmd.NewCreateMenuItemHandler(repos.MenuItem, nil, metrics)
+	uploadPhotoUC := menuCmd.NewUploadMenuItemPhotoHandler(repos.MenuItem, photoStorage, nil, metrics)
+	reorderCategoriesUC := menuCmd.NewReorderMenuCategoriesHandler(repos.MenuCategory, nil, metrics)
+	reorderItemsUC := menuCmd.NewReorderMenuItemsHandler(repos.MenuItem, repos.MenuCategory, nil, metrics)
 
 	return Menu{
 		GetMenu:                getMenuUC,
@@ -60,6 +64,6 @@ func New(
 		UploadMenuPhoto:        uploadPhotoUC,
 		ReorderMenuCategories:  reorderCategoriesUC,
 		ReorderMenuItems:       reorderItemsUC,
-		HTTP:                   menuhttp.NewMenuHandler(getMenuUC, cartService, recordMenuVisitUC, repos.Order),
+		HTTP:                   menuhttp.NewMenuHandler(getMenuUC, cartService, recordMenuVisitUC, repos.Order, converter),
 	}
 }
@@ -24,19 +24,31 @@ type VAPIDConfig struct {
 // Defaults to webpushlib.SendNotification; override in tests.
 type SendFunc func(message []byte, s *webpushlib.Subscription, options *webpushlib.Options) (*http.Response, error)
 
+// Metrics counts push deliveries by recipient role and outcome: accepted,
+// rejected, expired (the subscription is gone) or failed (never reached the
+// push service).
+type Metrics interface {
+	IncPush(role, outcome string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) IncPush(string, string) {}
+
 // Notifier implements notification.Notifier using the Web Push protocol.
 type Notifier struct {
-	repo   Repository
-	vapid  VAPIDConfig
-	send   SendFunc
-	logger *slog.Logger
+	repo    Repository
+	vapid   VAPIDConfig
+	send    SendFunc
+	logger  *slog.Logger
+	metrics Metrics
 }
 
 func NewNotifier(repo Repository, vapid VAPIDConfig, logger *slog.Logger) *Notifier {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	return &Notifier{repo: repo, vapid: vapid, send: webpushlib.SendNotification, logger: logger}
+	return &Notifier{repo: repo, vapid: vapid, send: webpushlib.SendNotification, logger: logger, metrics: noopMetrics{}}
 }
 
 func (n *Notifier) Name() string { return "web-push" }
@@ -109,9 +121,11 @@ func (n *Notifier) sendOne(sub *Subscription, payload []byte) error {
 		TTL: 3600,
 	})
 	if err != nil {
+		n.metrics.IncPush(sub.Role, "failed")
 		return fmt.Errorf("send push to %s: %w", sub.Endpoint, err)
 	}
 	defer resp.Body.Close()
+	n.metrics.IncPush(sub.Role, pushOutcome(resp.StatusCode))
 
 	// Surface non-2xx responses from the push service (FCM, Mozilla autopush,
 	// Apple Push Service). 2xx means the push service accepted the message
@@ -142,9 +156,25 @@ func (n *Notifier) sendOne(sub *Subscription, payload []byte) error {
 	return nil
 }
 
+func pushOutcome(status int) string {
+	switch {
+	case status == http.StatusGone:
+		return "expired"
+	case status >= 300:
+		return "rejected"
+	default:
+		return "accepted"
+	}
+}
+
 // WithSendFunc returns a copy of the Notifier with a custom send function (for testing).
 func (n *Notifier) WithSendFunc(fn SendFunc) *Notifier {
-	return &Notifier{repo: n.repo, vapid: n.vapid, send: fn, logger: n.logger}
+	return &Notifier{repo: n.repo, vapid: n.vapid, send: fn, logger: n.logger, metrics: n.metrics}
+}
+
+// WithMetrics returns a copy of the Notifier that counts delivery outcomes in m.
+func (n *Notifier) WithMetrics(m Metrics) *Notifier {
+	return &Notifier{repo: n.repo, vapid: n.vapid, send: n.send, logger: n.logger, metrics: m}
 }
 
 func (n *Notifier) subscriptionsFor(notif notification.Notification) ([]*Subscription, error) {
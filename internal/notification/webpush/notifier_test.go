@@ -149,3 +149,43 @@ func TestNotifier_Send_FailingEndpointDoesNotBlockOthers(t *testing.T) {
 		t.Fatalf("expected all 3 endpoints to be attempted, got %d calls", calls)
 	}
 }
+
+type pushCounts map[string]int
+
+func (c pushCounts) IncPush(role, outcome string) { c[role+"/"+outcome]++ }
+
+func TestNotifier_Send_CountsOutcomes(t *testing.T) {
+	repo := newStubRepo()
+	repo.byOrderNumber["ORD-4"] = []*webpush.Subscription{
+		{Endpoint: "https://example.com/push/ok", Role: "customer"},
+		{Endpoint: "https://example.com/push/gone", Role: "customer"},
+		{Endpoint: "https://example.com/push/down", Role: "customer"},
+		{Endpoint: "https://example.com/push/bad", Role: "customer"},
+	}
+
+	fn := func(_ []byte, sub *webpushlib.Subscription, _ *webpushlib.Options) (*http.Response, error) {
+		status := http.StatusCreated
+		switch sub.Endpoint {
+		case "https://example.com/push/gone":
+			status = http.StatusGone
+		case "https://example.com/push/bad":
+			status = http.StatusBadRequest
+		case "https://example.com/push/down":
+			return nil, errors.New("simulated network failure")
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	}
+	counts := pushCounts{}
+	n := webpush.NewNotifier(repo, webpush.VAPIDConfig{}, nil).WithMetrics(counts).WithSendFunc(fn)
+	_ = n.Send(context.Background(), makeNotif("customer", "ORD-4"))
+
+	want := pushCounts{"customer/accepted": 1, "customer/expired": 1, "customer/rejected": 1, "customer/failed": 1}
+	if len(counts) != len(want) {
+		t.Fatalf("expected %v, got %v", want, counts)
+	}
+	for k, v := range want {
+		if counts[k] != v {
+			t.Fatalf("expected %v, got %v", want, counts)
+		}
+	}
+}
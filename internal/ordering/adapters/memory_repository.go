@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/outbox"
 	"bitmerchant/internal/ordering/domain/order"
 )
 
@@ -12,15 +13,29 @@ type MemoryOrderRepository struct {
 	mu       sync.RWMutex
 	orders   map[common.OrderID]*order.Order
 	counters map[common.RestaurantID]int
+	outbox   *outbox.MemoryStore
 }
 
 func NewMemoryOrderRepository() *MemoryOrderRepository {
 	return &MemoryOrderRepository{
 		orders:   make(map[common.OrderID]*order.Order),
 		counters: make(map[common.RestaurantID]int),
+		outbox:   outbox.NewMemoryStore(),
 	}
 }
 
+// Outbox returns the store the events recorded on saved orders wait in.
+func (r *MemoryOrderRepository) Outbox() *outbox.MemoryStore {
+	return r.outbox
+}
+
+// commit writes o's encoded events to the outbox. Callers hold r.mu, so the
+// change and its events become visible together.
+func (r *MemoryOrderRepository) commit(o *order.Order, msgs []outbox.Message) {
+	r.outbox.Add(msgs...)
+	o.ClearEvents()
+}
+
 // NextOrderNumber mirrors the Postgres implementation's contract: monotonic
 // per restaurant, no duplicates under concurrent callers. Held under the same
 // write mutex that guards orders, so a Save() following NextOrderNumber()
@@ -33,9 +48,14 @@ func (r *MemoryOrderRepository) NextOrderNumber(restaurantID common.RestaurantID
 }
 
 func (r *MemoryOrderRepository) Save(o *order.Order) error {
+	msgs, err := recordedMessages(o)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.orders[o.ID] = o
+	r.commit(o, msgs)
 	return nil
 }
 
@@ -100,25 +120,39 @@ func (r *MemoryOrderRepository) FindBySessionID(sessionID string) ([]*order.Orde
 }
 
 func (r *MemoryOrderRepository) Update(o *order.Order) error {
+	msgs, err := recordedMessages(o)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if _, exists := r.orders[o.ID]; !exists {
 		return errors.New("order not found")
 	}
 	r.orders[o.ID] = o
+	r.commit(o, msgs)
 	return nil
 }
 
-func (r *MemoryOrderRepository) UpdateItemPrepComplete(orderID common.OrderID, itemID common.OrderItemID, complete bool) error {
+func (r *MemoryOrderRepository) UpdateItemPrepComplete(o *order.Order, itemID common.OrderItemID) error {
+	complete, found := o.ItemPrepComplete(itemID)
+	if !found {
+		return errors.New("order item not found")
+	}
+	msgs, err := recordedMessages(o)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	o, exists := r.orders[orderID]
+	stored, exists := r.orders[o.ID]
 	if !exists {
 		return errors.New("order not found")
 	}
-	for i := range o.Items {
-		if o.Items[i].ID == itemID {
-			o.Items[i].PrepComplete = complete
+	for i := range stored.Items {
+		if stored.Items[i].ID == itemID {
+			stored.Items[i].PrepComplete = complete
+			r.commit(o, msgs)
 			return nil
 		}
 	}
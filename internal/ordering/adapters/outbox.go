@@ -0,0 +1,13 @@
+package adapters
+
+import (
+	"bitmerchant/internal/common/outbox"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// recordedMessages encodes the events recorded on o for the outbox. It runs
+// before anything is written, so an event that cannot be encoded leaves the
+// order unsaved.
+func recordedMessages(o *order.Order) ([]outbox.Message, error) {
+	return outbox.NewMessages(o.Events())
+}
@@ -9,6 +9,8 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/outbox"
+	"bitmerchant/internal/common/tax"
 	"bitmerchant/internal/ordering/domain/order"
 )
 
@@ -22,11 +24,17 @@ func NewPostgresOrderRepository(db *sql.DB) *PostgresOrderRepository {
 
 const orderColumns = `id, order_number, restaurant_id, session_id,
 	COALESCE(subtotal_amount, total_amount), total_amount, COALESCE(tax_amount, 0), COALESCE(tip_amount, 0),
-	fiat_amount, COALESCE(currency, 'USD'),
+	COALESCE(currency, 'USD'),
 	COALESCE(customer_name, ''), COALESCE(table_label, ''),
 	payment_method, payment_status, fulfillment_status,
 	created_at, updated_at, paid_at, preparing_at, ready_at, completed_at,
-	server_called_at, bill_requested_at`
+	server_called_at, bill_requested_at,
+	cancelled_at, COALESCE(cancelled_by, ''), COALESCE(cancel_reason, ''), COALESCE(cancel_note, ''),
+	COALESCE(bill_parts, '[]'::jsonb),
+	COALESCE(fx_from, ''), COALESCE(fx_to, ''), COALESCE(fx_rate, 0), COALESCE(fx_source, ''), fx_as_of,
+	discount_amount, promotion_id, discount_label,
+	service_charge, tax_inclusive, COALESCE(tax_lines, '[]'::jsonb),
+	cash_rounding, late_tip_amount, late_tip_payment_id`
 
 // NextOrderNumber atomically allocates the next order number for restaurantID.
 // Race-free: the UPDATE in ON CONFLICT takes the row lock, so concurrent
@@ -48,6 +56,10 @@ func (r *PostgresOrderRepository) NextOrderNumber(restaurantID common.Restaurant
 }
 
 func (r *PostgresOrderRepository) Save(o *order.Order) error {
+	msgs, err := recordedMessages(o)
+	if err != nil {
+		return err
+	}
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
@@ -58,32 +70,57 @@ func (r *PostgresOrderRepository) Save(o *order.Order) error {
 	if currency.IsZero() {
 		currency = money.USD
 	}
+	billPartsJSON, err := marshalBillParts(o.BillParts)
+	if err != nil {
+		return err
+	}
+	taxLinesJSON, err := marshalTaxLines(o.TaxLines)
+	if err != nil {
+		return err
+	}
 	_, err = tx.Exec(
 		`INSERT INTO orders (id, order_number, restaurant_id, session_id,
-			subtotal_amount, total_amount, tax_amount, tip_amount, fiat_amount, currency,
+			subtotal_amount, total_amount, tax_amount, tip_amount, currency,
 			customer_name, table_label,
 			payment_method, payment_status, fulfillment_status,
 			created_at, updated_at, paid_at, preparing_at, ready_at, completed_at,
-			server_called_at, bill_requested_at)
-		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23)
+			server_called_at, bill_requested_at,
+			cancelled_at, cancelled_by, cancel_reason, cancel_note, bill_parts,
+			fx_from, fx_to, fx_rate, fx_source, fx_as_of,
+			discount_amount, promotion_id, discount_label,
+			service_charge, tax_inclusive, tax_lines, cash_rounding, late_tip_amount, late_tip_payment_id)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36,$37,$38,$39,$40,$41)
 		 ON CONFLICT (id) DO UPDATE SET
 		   order_number=EXCLUDED.order_number,
 		   subtotal_amount=EXCLUDED.subtotal_amount,
 		   total_amount=EXCLUDED.total_amount,
 		   tax_amount=EXCLUDED.tax_amount, tip_amount=EXCLUDED.tip_amount,
-		   fiat_amount=EXCLUDED.fiat_amount,
 		   currency=EXCLUDED.currency,
 		   customer_name=EXCLUDED.customer_name, table_label=EXCLUDED.table_label,
 		   payment_status=EXCLUDED.payment_status, fulfillment_status=EXCLUDED.fulfillment_status,
 		   updated_at=EXCLUDED.updated_at, paid_at=EXCLUDED.paid_at,
 		   preparing_at=EXCLUDED.preparing_at, ready_at=EXCLUDED.ready_at, completed_at=EXCLUDED.completed_at,
-		   server_called_at=EXCLUDED.server_called_at, bill_requested_at=EXCLUDED.bill_requested_at`,
+		   server_called_at=EXCLUDED.server_called_at, bill_requested_at=EXCLUDED.bill_requested_at,
+		   cancelled_at=EXCLUDED.cancelled_at, cancelled_by=EXCLUDED.cancelled_by,
+		   cancel_reason=EXCLUDED.cancel_reason, cancel_note=EXCLUDED.cancel_note,
+		   bill_parts=EXCLUDED.bill_parts,
+		   fx_from=EXCLUDED.fx_from, fx_to=EXCLUDED.fx_to, fx_rate=EXCLUDED.fx_rate,
+		   fx_source=EXCLUDED.fx_source, fx_as_of=EXCLUDED.fx_as_of,
+		   discount_amount=EXCLUDED.discount_amount, promotion_id=EXCLUDED.promotion_id,
+		   discount_label=EXCLUDED.discount_label,
+		   service_charge=EXCLUDED.service_charge, tax_inclusive=EXCLUDED.tax_inclusive,
+		   tax_lines=EXCLUDED.tax_lines, cash_rounding=EXCLUDED.cash_rounding,
+		   late_tip_amount=EXCLUDED.late_tip_amount, late_tip_payment_id=EXCLUDED.late_tip_payment_id`,
 		string(o.ID), string(o.OrderNumber), string(o.RestaurantID), o.SessionID,
-		o.Subtotal, o.TotalAmount, o.TaxAmount, o.TipAmount, o.FiatAmount, currency.Code,
+		o.Subtotal, o.TotalAmount, o.TaxAmount, o.TipAmount, currency.Code,
 		o.CustomerName, o.TableLabel,
 		string(o.PaymentMethod), string(o.PaymentStatus), string(o.FulfillmentStatus),
 		o.CreatedAt, o.UpdatedAt, o.PaidAt, o.PreparingAt, o.ReadyAt, o.CompletedAt,
-		o.ServerCalledAt, o.BillRequestedAt)
+		o.ServerCalledAt, o.BillRequestedAt,
+		o.CancelledAt, string(o.CancelledBy), string(o.CancelReason), o.CancelNote, billPartsJSON,
+		o.FXRate.From, o.FXRate.To, o.FXRate.Rate, o.FXRate.Source, fxAsOf(o.FXRate),
+		o.DiscountAmount, string(o.PromotionID), o.DiscountLabel,
+		o.ServiceCharge, o.TaxInclusive, taxLinesJSON, o.CashRounding, o.LateTipAmount, string(o.LateTipPaymentID))
 	if err != nil {
 		return err
 	}
@@ -93,26 +130,23 @@ func (r *PostgresOrderRepository) Save(o *order.Order) error {
 		if itemCur.IsZero() {
 			itemCur = currency
 		}
-		unitPriceMinor := money.FromMajor(item.UnitPrice, itemCur).Amount
-		subtotalMinor := money.FromMajor(item.Subtotal, itemCur).Amount
 		modifiersJSON, merr := marshalOrderModifiers(item.Modifiers)
 		if merr != nil {
 			return merr
 		}
 		_, err = tx.Exec(
-			`INSERT INTO order_items (id, order_id, menu_item_id, name, quantity, unit_price, subtotal, currency, unit_price_minor, subtotal_minor, modifiers, special_instructions, prep_complete)
-			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+			`INSERT INTO order_items (id, order_id, menu_item_id, name, quantity, unit_price_minor, subtotal_minor, currency, modifiers, special_instructions, prep_complete)
+			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
 			 ON CONFLICT (id) DO NOTHING`,
 			string(item.ID), string(item.OrderID), string(item.MenuItemID),
-			item.Name, item.Quantity, item.UnitPrice, item.Subtotal,
-			itemCur.Code, unitPriceMinor, subtotalMinor,
+			item.Name, item.Quantity, item.UnitPrice, item.Subtotal, itemCur.Code,
 			modifiersJSON, item.SpecialInstructions, item.PrepComplete)
 		if err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit()
+	return r.commit(tx, o, msgs)
 }
 
 func (r *PostgresOrderRepository) FindByID(id common.OrderID) (*order.Order, error) {
@@ -167,20 +201,42 @@ func (r *PostgresOrderRepository) Update(o *order.Order) error {
 	if currency.IsZero() {
 		currency = money.USD
 	}
-	result, err := r.db.Exec(
+	billPartsJSON, err := marshalBillParts(o.BillParts)
+	if err != nil {
+		return err
+	}
+	msgs, err := recordedMessages(o)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(
 		`UPDATE orders SET order_number=$2,
 		   subtotal_amount=$3, total_amount=$4, tax_amount=$5, tip_amount=$6,
-		   fiat_amount=$7, currency=$8,
-		   customer_name=$9, table_label=$10,
-		   payment_method=$11, payment_status=$12, fulfillment_status=$13,
-		   updated_at=$14, paid_at=$15, preparing_at=$16, ready_at=$17, completed_at=$18
+		   currency=$7,
+		   customer_name=$8, table_label=$9,
+		   payment_method=$10, payment_status=$11, fulfillment_status=$12,
+		   updated_at=$13, paid_at=$14, preparing_at=$15, ready_at=$16, completed_at=$17,
+		   cancelled_at=$18, cancelled_by=$19, cancel_reason=$20, cancel_note=$21,
+		   bill_parts=$22,
+		   fx_from=$23, fx_to=$24, fx_rate=$25, fx_source=$26, fx_as_of=$27,
+		   late_tip_amount=$28, late_tip_payment_id=$29
 		 WHERE id=$1`,
 		string(o.ID), string(o.OrderNumber),
 		o.Subtotal, o.TotalAmount, o.TaxAmount, o.TipAmount,
-		o.FiatAmount, currency.Code,
+		currency.Code,
 		o.CustomerName, o.TableLabel,
 		string(o.PaymentMethod), string(o.PaymentStatus), string(o.FulfillmentStatus),
-		o.UpdatedAt, o.PaidAt, o.PreparingAt, o.ReadyAt, o.CompletedAt)
+		o.UpdatedAt, o.PaidAt, o.PreparingAt, o.ReadyAt, o.CompletedAt,
+		o.CancelledAt, string(o.CancelledBy), string(o.CancelReason), o.CancelNote,
+		billPartsJSON,
+		o.FXRate.From, o.FXRate.To, o.FXRate.Rate, o.FXRate.Source, fxAsOf(o.FXRate),
+		o.LateTipAmount, string(o.LateTipPaymentID))
 	if err != nil {
 		return err
 	}
@@ -188,13 +244,27 @@ func (r *PostgresOrderRepository) Update(o *order.Order) error {
 	if affected == 0 {
 		return errors.New("order not found")
 	}
-	return nil
+	return r.commit(tx, o, msgs)
 }
 
-func (r *PostgresOrderRepository) UpdateItemPrepComplete(orderID common.OrderID, itemID common.OrderItemID, complete bool) error {
-	result, err := r.db.Exec(
+func (r *PostgresOrderRepository) UpdateItemPrepComplete(o *order.Order, itemID common.OrderItemID) error {
+	complete, found := o.ItemPrepComplete(itemID)
+	if !found {
+		return errors.New("order item not found")
+	}
+	msgs, err := recordedMessages(o)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(
 		`UPDATE order_items SET prep_complete = $3 WHERE id = $1 AND order_id = $2`,
-		string(itemID), string(orderID), complete)
+		string(itemID), string(o.ID), complete)
 	if err != nil {
 		return err
 	}
@@ -202,6 +272,18 @@ func (r *PostgresOrderRepository) UpdateItemPrepComplete(orderID common.OrderID,
 	if affected == 0 {
 		return errors.New("order item not found")
 	}
+	return r.commit(tx, o, msgs)
+}
+
+// commit writes o's encoded events to the outbox and commits tx with them.
+func (r *PostgresOrderRepository) commit(tx *sql.Tx, o *order.Order, msgs []outbox.Message) error {
+	if err := outbox.Insert(tx, msgs); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	o.ClearEvents()
 	return nil
 }
 
@@ -237,7 +319,7 @@ func (r *PostgresOrderRepository) queryOrders(query string, args ...interface{})
 
 func (r *PostgresOrderRepository) loadItems(orderID string) ([]order.OrderItem, error) {
 	rows, err := r.db.Query(
-		`SELECT id, order_id, menu_item_id, name, quantity, unit_price, subtotal, COALESCE(currency, 'USD'),
+		`SELECT id, order_id, menu_item_id, name, quantity, unit_price_minor, subtotal_minor, COALESCE(currency, 'USD'),
 		        COALESCE(modifiers, '[]'::jsonb), COALESCE(special_instructions, ''), COALESCE(prep_complete, false)
 		 FROM order_items WHERE order_id = $1`, orderID)
 	if err != nil {
@@ -250,7 +332,7 @@ func (r *PostgresOrderRepository) loadItems(orderID string) ([]order.OrderItem,
 		var (
 			id, oid, menuItemID, name string
 			quantity                  int
-			unitPrice, subtotal       float64
+			unitPrice, subtotal       int64
 			currencyCode              string
 			modifiersJSON             []byte
 			specialInstructions       string
@@ -282,9 +364,9 @@ func (r *PostgresOrderRepository) loadItems(orderID string) ([]order.OrderItem,
 
 // jsonOrderModifier mirrors order.OrderItemModifier for JSON.
 type jsonOrderModifier struct {
-	GroupName  string  `json:"group_name"`
-	OptionName string  `json:"option_name"`
-	PriceDelta float64 `json:"price_delta"`
+	GroupName  string `json:"group_name"`
+	OptionName string `json:"option_name"`
+	PriceDelta int64  `json:"price_delta"`
 }
 
 func marshalOrderModifiers(mods []order.OrderItemModifier) ([]byte, error) {
@@ -313,28 +395,161 @@ func unmarshalOrderModifiers(data []byte) []order.OrderItemModifier {
 	return mods
 }
 
+// jsonBillPart mirrors order.BillPart for JSON.
+type jsonBillPart struct {
+	ID        string      `json:"id"`
+	Label     string      `json:"label"`
+	Amount    int64       `json:"amount"`
+	ItemIDs   []string    `json:"item_ids,omitempty"`
+	PaymentID string      `json:"payment_id,omitempty"`
+	Method    string      `json:"method,omitempty"`
+	PaidAt    *time.Time  `json:"paid_at,omitempty"`
+	FXRate    *jsonFXRate `json:"fx_rate,omitempty"`
+}
+
+// jsonFXRate mirrors money.ExchangeRate for JSON.
+type jsonFXRate struct {
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Rate   float64   `json:"rate"`
+	Source string    `json:"source,omitempty"`
+	AsOf   time.Time `json:"as_of"`
+}
+
+func marshalBillParts(parts []order.BillPart) ([]byte, error) {
+	if len(parts) == 0 {
+		return []byte("[]"), nil
+	}
+	jps := make([]jsonBillPart, len(parts))
+	for i, p := range parts {
+		jp := jsonBillPart{
+			ID: string(p.ID), Label: p.Label, Amount: p.Amount,
+			PaymentID: string(p.PaymentID), Method: string(p.Method), PaidAt: p.PaidAt,
+		}
+		if !p.FXRate.IsZero() {
+			jp.FXRate = &jsonFXRate{From: p.FXRate.From, To: p.FXRate.To, Rate: p.FXRate.Rate, Source: p.FXRate.Source, AsOf: p.FXRate.AsOf}
+		}
+		for _, id := range p.ItemIDs {
+			jp.ItemIDs = append(jp.ItemIDs, string(id))
+		}
+		jps[i] = jp
+	}
+	return json.Marshal(jps)
+}
+
+func unmarshalBillParts(data []byte) []order.BillPart {
+	if len(data) == 0 {
+		return nil
+	}
+	var jps []jsonBillPart
+	if err := json.Unmarshal(data, &jps); err != nil || len(jps) == 0 {
+		return nil
+	}
+	parts := make([]order.BillPart, len(jps))
+	for i, jp := range jps {
+		p := order.BillPart{
+			ID: common.BillPartID(jp.ID), Label: jp.Label, Amount: jp.Amount,
+			PaymentID: common.PaymentID(jp.PaymentID), Method: common.PaymentMethodType(jp.Method), PaidAt: jp.PaidAt,
+		}
+		if jp.FXRate != nil {
+			p.FXRate = buildFXRate(jp.FXRate.From, jp.FXRate.To, jp.FXRate.Rate, jp.FXRate.Source, jp.FXRate.AsOf)
+		}
+		for _, id := range jp.ItemIDs {
+			p.ItemIDs = append(p.ItemIDs, common.OrderItemID(id))
+		}
+		parts[i] = p
+	}
+	return parts
+}
+
+// jsonTaxLine mirrors tax.Charge for JSON.
+type jsonTaxLine struct {
+	Name   string `json:"name"`
+	Rate   int64  `json:"rate"`
+	Amount int64  `json:"amount"`
+}
+
+func marshalTaxLines(lines []tax.Charge) ([]byte, error) {
+	if len(lines) == 0 {
+		return []byte("[]"), nil
+	}
+	jls := make([]jsonTaxLine, len(lines))
+	for i, l := range lines {
+		jls[i] = jsonTaxLine{Name: l.Name, Rate: l.Rate, Amount: l.Amount}
+	}
+	return json.Marshal(jls)
+}
+
+func unmarshalTaxLines(data []byte) []tax.Charge {
+	if len(data) == 0 {
+		return nil
+	}
+	var jls []jsonTaxLine
+	if err := json.Unmarshal(data, &jls); err != nil || len(jls) == 0 {
+		return nil
+	}
+	lines := make([]tax.Charge, len(jls))
+	for i, jl := range jls {
+		lines[i] = tax.Charge{Name: jl.Name, Rate: jl.Rate, Amount: jl.Amount}
+	}
+	return lines
+}
+
+// fxAsOf stores a missing snapshot as NULL rather than the zero time.
+func fxAsOf(r money.ExchangeRate) *time.Time {
+	if r.IsZero() {
+		return nil
+	}
+	return &r.AsOf
+}
+
+func buildFXRate(from, to string, rate float64, source string, asOf time.Time) money.ExchangeRate {
+	if from == "" || rate <= 0 {
+		return money.ExchangeRate{}
+	}
+	return money.ExchangeRate{From: from, To: to, Rate: rate, Source: source, AsOf: asOf}
+}
+
 type orderRow struct {
 	id, orderNum, restID, sessionID           string
 	subtotal, totalAmount, taxAmount          int64
 	tipAmount                                 int64
-	fiatAmount                                float64
 	currencyCode                              string
 	customerName, tableLabel                  string
 	payMethod, payStatus, fulStatus           string
 	createdAt, updatedAt                      time.Time
 	paidAt, preparingAt, readyAt, completedAt sql.NullTime
 	serverCalledAt, billRequestedAt           sql.NullTime
+	cancelledAt                               sql.NullTime
+	cancelledBy, cancelReason, cancelNote     string
+	billPartsJSON                             []byte
+	fxFrom, fxTo, fxSource                    string
+	fxRate                                    float64
+	fxAsOf                                    sql.NullTime
+	discountAmount                            int64
+	promotionID, discountLabel                string
+	serviceCharge                             int64
+	taxInclusive                              bool
+	taxLinesJSON                              []byte
+	cashRounding, lateTipAmount               int64
+	lateTipPaymentID                          string
 }
 
 func (r *orderRow) targets() []any {
 	return []any{
 		&r.id, &r.orderNum, &r.restID, &r.sessionID,
 		&r.subtotal, &r.totalAmount, &r.taxAmount, &r.tipAmount,
-		&r.fiatAmount, &r.currencyCode,
+		&r.currencyCode,
 		&r.customerName, &r.tableLabel,
 		&r.payMethod, &r.payStatus, &r.fulStatus,
 		&r.createdAt, &r.updatedAt, &r.paidAt, &r.preparingAt, &r.readyAt, &r.completedAt,
 		&r.serverCalledAt, &r.billRequestedAt,
+		&r.cancelledAt, &r.cancelledBy, &r.cancelReason, &r.cancelNote,
+		&r.billPartsJSON,
+		&r.fxFrom, &r.fxTo, &r.fxRate, &r.fxSource, &r.fxAsOf,
+		&r.discountAmount, &r.promotionID, &r.discountLabel,
+		&r.serviceCharge, &r.taxInclusive, &r.taxLinesJSON,
+		&r.cashRounding, &r.lateTipAmount, &r.lateTipPaymentID,
 	}
 }
 
@@ -368,10 +583,18 @@ func buildOrder(r orderRow) *order.Order {
 		RestaurantID:      common.RestaurantID(r.restID),
 		SessionID:         r.sessionID,
 		Subtotal:          r.subtotal,
+		DiscountAmount:    r.discountAmount,
+		PromotionID:       common.PromotionID(r.promotionID),
+		DiscountLabel:     r.discountLabel,
+		ServiceCharge:     r.serviceCharge,
 		TaxAmount:         r.taxAmount,
+		TaxLines:          unmarshalTaxLines(r.taxLinesJSON),
+		TaxInclusive:      r.taxInclusive,
 		TipAmount:         r.tipAmount,
+		CashRounding:      r.cashRounding,
+		LateTipAmount:     r.lateTipAmount,
+		LateTipPaymentID:  common.PaymentID(r.lateTipPaymentID),
 		TotalAmount:       r.totalAmount,
-		FiatAmount:        r.fiatAmount,
 		Currency:          currency,
 		CustomerName:      r.customerName,
 		TableLabel:        r.tableLabel,
@@ -380,6 +603,11 @@ func buildOrder(r orderRow) *order.Order {
 		FulfillmentStatus: common.FulfillmentStatus(r.fulStatus),
 		CreatedAt:         r.createdAt,
 		UpdatedAt:         r.updatedAt,
+		CancelledBy:       common.UserID(r.cancelledBy),
+		CancelReason:      order.CancelReason(r.cancelReason),
+		CancelNote:        r.cancelNote,
+		BillParts:         unmarshalBillParts(r.billPartsJSON),
+		FXRate:            buildFXRate(r.fxFrom, r.fxTo, r.fxRate, r.fxSource, r.fxAsOf.Time),
 	}
 	paidAt := r.paidAt
 	preparingAt := r.preparingAt
@@ -409,5 +637,9 @@ func buildOrder(r orderRow) *order.Order {
 		t := r.billRequestedAt.Time
 		o.BillRequestedAt = &t
 	}
+	if r.cancelledAt.Valid {
+		t := r.cancelledAt.Time
+		o.CancelledAt = &t
+	}
 	return o
 }
@@ -20,6 +20,17 @@ type Commands struct {
 	MarkOrderCompleted command.MarkOrderCompletedHandler
 }
 
+// Ports are what ordering calls in the payment and promotion contexts.
+// Pricer and Redeemer may be nil, which leaves promotions off.
+type Ports struct {
+	Payments  command.PaymentRecorder
+	BillParts command.BillPartRecorder
+	Canceller command.PaymentCanceller
+	Voider    command.OpenPaymentVoider
+	Pricer    command.DiscountPricer
+	Redeemer  command.DiscountRedeemer
+}
+
 // Queries are read-side handlers.
 type Queries struct {
 	OrderByNumberForRestaurant query.OrderByNumberForRestaurantHandler
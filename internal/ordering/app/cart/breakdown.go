@@ -1,28 +1,50 @@
 package cart
 
 import (
-	"math"
-
+	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 )
 
-// AllowedTipPercents are the four canonical tip tiers offered on confirm.
-var AllowedTipPercents = []int{0, 10, 15, 20}
+// AllowedTipPercents are the standard tip tiers, offered on confirm by
+// restaurants that have not set their own presets (see tip.Standard).
+var AllowedTipPercents = tip.Standard().Percents()
 
-// DefaultTipPercent is selected on first render of the confirm page.
-const DefaultTipPercent = 15
+// DefaultTipPercent is selected on first render of the confirm page under
+// the standard tiers.
+const DefaultTipPercent = tip.StandardDefault
 
-// Breakdown captures the four lines shown on the confirm page receipt:
-// Subtotal + Tax + Tip = Total. All amounts share the cart's currency.
+// Breakdown captures the lines shown on the confirm page receipt:
+// Subtotal - Discount + ServiceCharge + Tax + Tip + CashRounding = Total,
+// except that Tax is left out when TaxInclusive (menu prices already contain
+// it). Taxes lists the named taxes that make up Tax. All amounts share the
+// cart's currency; Discount is zero when no promotion applies, and
+// CashRounding unless the total is paid in cash (see WithCashRounding).
 type Breakdown struct {
-	Subtotal money.Money
-	Tax      money.Money
-	Tip      money.Money
-	Total    money.Money
-	Currency money.Currency
+	Subtotal      money.Money
+	Discount      money.Money
+	ServiceCharge money.Money
+	Tax           money.Money
+	Taxes         []tax.Charge
+	TaxInclusive  bool
+	Tip           money.Money
+	CashRounding  money.Money
+	Total         money.Money
+	Currency      money.Currency
+	Promotion     Discount
+}
+
+// Discount is a promotion priced against a cart. Amount, in the cart's minor
+// units, comes off the subtotal before tax; zero means none applies.
+type Discount struct {
+	PromotionID common.PromotionID
+	Code        string
+	Label       string
+	Amount      int64
 }
 
-// IsAllowedTipPercent reports whether p is one of the canonical tip tiers.
+// IsAllowedTipPercent reports whether p is one of the standard tip tiers.
 func IsAllowedTipPercent(p int) bool {
 	for _, allowed := range AllowedTipPercents {
 		if p == allowed {
@@ -32,35 +54,78 @@ func IsAllowedTipPercent(p int) bool {
 	return false
 }
 
-// ComputeBreakdown builds the Subtotal/Tax/Tip/Total breakdown for the cart.
-// Tax is computed against the pre-tip subtotal; tip is computed against the
-// pre-tax subtotal (US tipping convention). Both rounded to minor units.
+// ComputeBreakdown builds the breakdown for the cart without any promotion,
+// under a single exclusive tax at taxRate (0.08 = 8%).
 func ComputeBreakdown(c *Cart, taxRate float64, tipPercent int) Breakdown {
+	return ComputeBreakdownWithDiscount(c, tax.Exclusive(taxRate), tipPercent, Discount{})
+}
+
+// ComputeBreakdownWithDiscount builds the breakdown under the restaurant's tax
+// config with d taken off the subtotal, capped so the subtotal never goes
+// negative. The service charge and taxes are computed against the discounted
+// subtotal (see tax.Config.Apply); tip against the pre-discount, pre-tax
+// subtotal, so a promotion does not shrink the server's tip (US tipping
+// convention). Everything is worked out in integer minor units and rounded
+// half up.
+func ComputeBreakdownWithDiscount(c *Cart, cfg tax.Config, tipPercent int, d Discount) Breakdown {
 	cur := c.Currency
 	if cur.IsZero() {
 		cur = money.USD
 	}
-	subtotal := money.FromMajor(c.Total, cur)
-	tax := money.FromMajor(round(c.Total*taxRate, cur.Scale), cur)
-	tip := money.FromMajor(round(c.Total*float64(tipPercent)/100.0, cur.Scale), cur)
-
-	// Add is safe — all three share cur. Errors only on currency mismatch.
-	withTax, _ := subtotal.Add(tax)
-	total, _ := withTax.Add(tip)
+	d.Amount = min(max(d.Amount, 0), c.Total)
+	if d.Amount == 0 {
+		d = Discount{}
+	}
+	res := cfg.Apply(taxLines(c), d.Amount)
+	tipAmount := tip.Amount(c.Total, tipPercent)
 
 	return Breakdown{
-		Subtotal: subtotal,
-		Tax:      tax,
-		Tip:      tip,
-		Total:    total,
-		Currency: cur,
+		Subtotal:      money.New(c.Total, cur),
+		Discount:      money.New(d.Amount, cur),
+		ServiceCharge: money.New(res.ServiceCharge, cur),
+		Tax:           money.New(res.Tax, cur),
+		Taxes:         res.Charges,
+		TaxInclusive:  res.Inclusive,
+		Tip:           money.New(tipAmount, cur),
+		CashRounding:  money.New(0, cur),
+		Total:         money.New(res.Total+tipAmount, cur),
+		Currency:      cur,
+		Promotion:     d,
 	}
 }
 
-// round rounds f to `scale` decimal places to match minor-unit precision.
-// Without this, math.Round inside FromMajor can drift one minor unit due to
-// float64 representation (e.g. 22.25 * 0.08 == 1.7800000000000002).
-func round(f float64, scale int) float64 {
-	mult := math.Pow10(scale)
-	return math.Round(f*mult) / mult
+// WithTip replaces the tip with a custom amount in minor units, adjusting
+// Total to match. Apply it before WithCashRounding.
+func (b Breakdown) WithTip(amount int64) Breakdown {
+	b.Total = money.New(b.Total.Amount-b.Tip.Amount+amount, b.Currency)
+	b.Tip = money.New(amount, b.Currency)
+	return b
+}
+
+// WithCashRounding rounds Total to the nearest multiple of step minor units
+// for a cash payment, recording the signed difference as CashRounding. A
+// total that would round to nothing is left exact.
+func (b Breakdown) WithCashRounding(step int64) Breakdown {
+	rounded := b.Total.RoundTo(step)
+	if !rounded.IsPositive() {
+		return b
+	}
+	b.CashRounding = money.New(rounded.Amount-b.Total.Amount, b.Currency)
+	b.Total = rounded
+	return b
+}
+
+// taxLines splits the cart total by tax class. Any part of the total not
+// accounted for by items is taxed as standard.
+func taxLines(c *Cart) []tax.Line {
+	lines := make([]tax.Line, 0, len(c.Items)+1)
+	rest := c.Total
+	for _, item := range c.Items {
+		lines = append(lines, tax.Line{Class: item.TaxClass, Amount: item.Subtotal})
+		rest -= item.Subtotal
+	}
+	if rest > 0 {
+		lines = append(lines, tax.Line{Class: tax.ClassStandard, Amount: rest})
+	}
+	return lines
 }
@@ -2,10 +2,12 @@ package cart
 
 import (
 	"errors"
+	"strings"
 	"sync"
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
 	"bitmerchant/internal/menu/domain/menu"
 )
 
@@ -15,28 +17,33 @@ type CartItemModifier struct {
 	GroupName  string
 	OptionID   string
 	OptionName string
-	PriceDelta float64
+	PriceDelta int64
 }
 
 // CartItem represents an item in the cart.
 type CartItem struct {
 	ItemID              common.ItemID
+	CategoryID          common.CategoryID
 	Name                string
 	Quantity            int
-	UnitPrice           float64 // base item price (without modifiers)
-	ModifierPrice       float64 // sum of selected modifier PriceDeltas
-	Subtotal            float64 // (UnitPrice + ModifierPrice) * Quantity
+	UnitPrice           int64 // base item price (without modifiers), minor units
+	ModifierPrice       int64 // sum of selected modifier PriceDeltas
+	Subtotal            int64 // (UnitPrice + ModifierPrice) * Quantity
 	Modifiers           []CartItemModifier
 	SpecialInstructions string
+	TaxClass            tax.Class // the item's class, or its category's
 }
 
 // Cart represents a shopping cart. Currency is set from the first item added
-// (all items in a cart share the restaurant's base currency).
+// (all items in a cart share the restaurant's base currency); Total and the
+// item prices are in its minor units. PromoCode is the code the customer
+// entered at checkout, priced when the order is placed.
 type Cart struct {
 	RestaurantID common.RestaurantID
 	Items        []CartItem
-	Total        float64
+	Total        int64
 	Currency     money.Currency
+	PromoCode    string
 }
 
 // Money returns the cart total as money.Money.
@@ -45,7 +52,7 @@ func (c *Cart) Money() money.Money {
 	if cur.IsZero() {
 		cur = money.USD
 	}
-	return money.FromMajor(c.Total, cur)
+	return money.New(c.Total, cur)
 }
 
 // CartService manages session-based carts.
@@ -117,6 +124,7 @@ func (s *CartService) clearIfRestaurantChanged(cart *Cart, restaurantID common.R
 		cart.Total = 0
 		cart.RestaurantID = ""
 		cart.Currency = money.Currency{}
+		cart.PromoCode = ""
 	}
 }
 
@@ -125,32 +133,34 @@ func (s *CartService) incrementExisting(cart *Cart, itemID common.ItemID, quanti
 		if cart.Items[i].ItemID == itemID {
 			cart.Items[i].Quantity += quantity
 			effPrice := cart.Items[i].UnitPrice + cart.Items[i].ModifierPrice
-			cart.Items[i].Subtotal = float64(cart.Items[i].Quantity) * effPrice
+			cart.Items[i].Subtotal = int64(cart.Items[i].Quantity) * effPrice
 			return true
 		}
 	}
 	return false
 }
 
-func sumModifierPrices(modifiers []CartItemModifier) float64 {
-	total := 0.0
+func sumModifierPrices(modifiers []CartItemModifier) int64 {
+	var total int64
 	for _, m := range modifiers {
 		total += m.PriceDelta
 	}
 	return total
 }
 
-func newCartItem(item *menu.MenuItem, quantity int, modifiers []CartItemModifier, modifierTotal float64, specialInstructions string) CartItem {
+func newCartItem(item *menu.MenuItem, quantity int, modifiers []CartItemModifier, modifierTotal int64, specialInstructions string) CartItem {
 	effPrice := item.Price + modifierTotal
 	return CartItem{
 		ItemID:              item.ID,
+		CategoryID:          item.CategoryID,
 		Name:                item.Name,
 		Quantity:            quantity,
 		UnitPrice:           item.Price,
 		ModifierPrice:       modifierTotal,
-		Subtotal:            float64(quantity) * effPrice,
+		Subtotal:            int64(quantity) * effPrice,
 		Modifiers:           modifiers,
 		SpecialInstructions: specialInstructions,
+		TaxClass:            item.TaxClass,
 	}
 }
 
@@ -173,6 +183,7 @@ func (s *CartService) RemoveItem(sessionID string, itemID common.ItemID) error {
 	if len(cart.Items) == 0 {
 		cart.RestaurantID = ""
 		cart.Currency = money.Currency{}
+		cart.PromoCode = ""
 	}
 	s.recalculateTotal(cart)
 	return nil
@@ -195,7 +206,7 @@ func (s *CartService) DecrementItem(sessionID string, itemID common.ItemID) erro
 			if item.Quantity <= 0 {
 				continue
 			}
-			item.Subtotal = float64(item.Quantity) * (item.UnitPrice + item.ModifierPrice)
+			item.Subtotal = int64(item.Quantity) * (item.UnitPrice + item.ModifierPrice)
 		}
 		newItems = append(newItems, item)
 	}
@@ -203,11 +214,22 @@ func (s *CartService) DecrementItem(sessionID string, itemID common.ItemID) erro
 	if len(cart.Items) == 0 {
 		cart.RestaurantID = ""
 		cart.Currency = money.Currency{}
+		cart.PromoCode = ""
 	}
 	s.recalculateTotal(cart)
 	return nil
 }
 
+// SetPromoCode stores the promo code for the session's cart; an empty code
+// removes it. It is a no-op when the cart is empty.
+func (s *CartService) SetPromoCode(sessionID, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cart, exists := s.carts[sessionID]; exists && len(cart.Items) > 0 {
+		cart.PromoCode = strings.ToUpper(strings.TrimSpace(code))
+	}
+}
+
 func (s *CartService) ClearCart(sessionID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -215,7 +237,7 @@ func (s *CartService) ClearCart(sessionID string) {
 }
 
 func (s *CartService) recalculateTotal(cart *Cart) {
-	total := 0.0
+	var total int64
 	for _, item := range cart.Items {
 		total += item.Subtotal
 	}
@@ -227,6 +249,7 @@ func (s *CartService) copyCart(cart *Cart) *Cart {
 		RestaurantID: cart.RestaurantID,
 		Total:        cart.Total,
 		Currency:     cart.Currency,
+		PromoCode:    cart.PromoCode,
 		Items:        make([]CartItem, len(cart.Items)),
 	}
 	copy(newCart.Items, cart.Items)
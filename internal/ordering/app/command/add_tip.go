@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// AddTip adds a tip the guest paid after their order was settled. PaymentID
+// is the tip's own payment, already settled in the payment context; Amount
+// is what it was for. Adding the same payment again is a no-op.
+type AddTip struct {
+	OrderID   common.OrderID
+	PaymentID common.PaymentID
+	Amount    money.Money
+}
+
+type AddTipHandler decorator.CommandResultHandler[AddTip, *order.Order]
+
+type addTipHandler struct {
+	repo order.Repository
+}
+
+func NewAddTipHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) AddTipHandler {
+	if repo == nil {
+		panic("nil order.Repository")
+	}
+	h := addTipHandler{repo: repo}
+	return decorator.ApplyCommandResultDecorators[AddTip, *order.Order](h, log, metrics)
+}
+
+func (h addTipHandler) Handle(ctx context.Context, cmd AddTip) (*order.Order, error) {
+	o, err := h.repo.FindByID(cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, errors.New("order not found")
+	}
+	if o.HasLateTip(cmd.PaymentID) {
+		return o, nil
+	}
+	if !cmd.Amount.Currency.IsZero() && cmd.Amount.Currency.Code != o.Total().Currency.Code {
+		return nil, money.ErrCurrencyMismatch
+	}
+	if err := o.AddTip(cmd.PaymentID, cmd.Amount.Amount); err != nil {
+		return nil, err
+	}
+	ev := event.OrderTipAdded{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		OrderNumber:  o.OrderNumber,
+		PaymentID:    cmd.PaymentID,
+		Amount:       cmd.Amount.Amount,
+		TipAmount:    o.TipAmount,
+		TotalAmount:  o.TotalAmount,
+		AddedAt:      o.UpdatedAt,
+	}
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
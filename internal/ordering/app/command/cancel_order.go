@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// CancelOrder voids an unpaid order or cancels and refunds a paid one, and
+// publishes OrderCancelled. RestaurantID scopes the order to the staff
+// member's active restaurant.
+type CancelOrder struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	Reason       order.CancelReason
+	Note         string
+	CancelledBy  common.UserID
+}
+
+// PaymentCanceller reverses the order's payment in the payment context:
+// refund is true for a paid order and false for a void. It runs before the
+// order changes state, so a failed refund leaves the order as it was, and
+// must be idempotent so a retried cancellation does not refund twice.
+type PaymentCanceller interface {
+	CancelPayments(ctx context.Context, o *order.Order, refund bool, reason string, by common.UserID) error
+}
+
+// PaymentCancellerFunc adapts a function to a PaymentCanceller.
+type PaymentCancellerFunc func(ctx context.Context, o *order.Order, refund bool, reason string, by common.UserID) error
+
+// CancelPayments calls f.
+func (f PaymentCancellerFunc) CancelPayments(ctx context.Context, o *order.Order, refund bool, reason string, by common.UserID) error {
+	return f(ctx, o, refund, reason, by)
+}
+
+type CancelOrderHandler decorator.CommandResultHandler[CancelOrder, *order.Order]
+
+type cancelOrderHandler struct {
+	repo          order.Repository
+	cancelPayment PaymentCanceller
+}
+
+func NewCancelOrderHandler(repo order.Repository, cancelPayment PaymentCanceller, log *slog.Logger, metrics decorator.MetricsClient) CancelOrderHandler {
+	if repo == nil {
+		panic("nil order.Repository")
+	}
+	if cancelPayment == nil {
+		panic("nil PaymentCanceller")
+	}
+	h := cancelOrderHandler{repo: repo, cancelPayment: cancelPayment}
+	return decorator.ApplyCommandResultDecorators[CancelOrder, *order.Order](h, log, metrics)
+}
+
+func (h cancelOrderHandler) Handle(ctx context.Context, cmd CancelOrder) (*order.Order, error) {
+	o, err := h.repo.FindByID(cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || (cmd.RestaurantID != "" && o.RestaurantID != cmd.RestaurantID) {
+		return nil, errors.New("order not found")
+	}
+	if err := o.ValidateCancellation(cmd.Reason, cmd.CancelledBy, cmd.Note); err != nil {
+		return nil, err
+	}
+
+	refund := o.NeedsRefund()
+	summary := order.DescribeCancellation(cmd.Reason, cmd.Note)
+	if err := h.cancelPayment.CancelPayments(ctx, o, refund, summary, cmd.CancelledBy); err != nil {
+		return nil, err
+	}
+
+	if err := o.Cancel(cmd.Reason, cmd.CancelledBy, cmd.Note); err != nil {
+		return nil, err
+	}
+	ev := event.OrderCancelled{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		OrderNumber:  o.OrderNumber,
+		Reason:       string(o.CancelReason),
+		Refunded:     refund,
+		CancelledBy:  o.CancelledBy,
+		CancelledAt:  *o.CancelledAt,
+	}
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/ordering/app/cart"
 	"bitmerchant/internal/ordering/app/event"
@@ -23,7 +24,11 @@ type CreateOrder struct {
 	PaymentMethod common.PaymentMethodType
 	CustomerName  string
 	TableLabel    string
-	TipPercent    int // one of cart.AllowedTipPercents
+	// TipPercent is one of the restaurant's tip presets, or 0 for no tip.
+	// CustomTip, in minor units, replaces it when the guest entered their
+	// own amount.
+	TipPercent int
+	CustomTip  int64
 }
 
 // CreateOrderResult is returned after a successful create.
@@ -32,19 +37,62 @@ type CreateOrderResult struct {
 	OrderNumber common.OrderNumber
 }
 
+// DiscountPricer prices the best promotion for a session's cart in the
+// promotion context, including the cart's PromoCode. A code that cannot be
+// used fails with *PromotionRejectedError.
+type DiscountPricer interface {
+	PriceDiscount(ctx context.Context, sessionID string, c *cart.Cart) (cart.Discount, error)
+}
+
+// DiscountPricerFunc adapts a function to a DiscountPricer.
+type DiscountPricerFunc func(ctx context.Context, sessionID string, c *cart.Cart) (cart.Discount, error)
+
+// PriceDiscount calls f.
+func (f DiscountPricerFunc) PriceDiscount(ctx context.Context, sessionID string, c *cart.Cart) (cart.Discount, error) {
+	return f(ctx, sessionID, c)
+}
+
+// DiscountRedeemer records that orderID used the promotion in d. It fails
+// with *PromotionRejectedError when the promotion's usage caps were reached
+// since it was priced.
+type DiscountRedeemer interface {
+	RedeemDiscount(ctx context.Context, d cart.Discount, sessionID string, orderID common.OrderID) error
+}
+
+// DiscountRedeemerFunc adapts a function to a DiscountRedeemer.
+type DiscountRedeemerFunc func(ctx context.Context, d cart.Discount, sessionID string, orderID common.OrderID) error
+
+// RedeemDiscount calls f.
+func (f DiscountRedeemerFunc) RedeemDiscount(ctx context.Context, d cart.Discount, sessionID string, orderID common.OrderID) error {
+	return f(ctx, d, sessionID, orderID)
+}
+
+// PromotionRejectedError explains, in words fit for the customer, why a
+// promotion cannot be applied to their order.
+type PromotionRejectedError struct {
+	Reason string
+}
+
+func (e *PromotionRejectedError) Error() string { return e.Reason }
+
 type CreateOrderHandler decorator.CommandResultHandler[CreateOrder, *CreateOrderResult]
 
 type createOrderHandler struct {
-	orderRepo order.Repository
-	restRepo  restaurant.Repository
-	eventBus  common.EventBus
-	log       *slog.Logger
+	orderRepo      order.Repository
+	restRepo       restaurant.Repository
+	priceDiscount  DiscountPricer
+	redeemDiscount DiscountRedeemer
+	log            *slog.Logger
 }
 
+// NewCreateOrderHandler builds the checkout handler. priceDiscount and
+// redeemDiscount may be nil, in which case orders are placed without
+// promotions.
 func NewCreateOrderHandler(
 	orderRepo order.Repository,
 	restRepo restaurant.Repository,
-	eventBus common.EventBus,
+	priceDiscount DiscountPricer,
+	redeemDiscount DiscountRedeemer,
 	log *slog.Logger,
 	metrics decorator.MetricsClient,
 ) CreateOrderHandler {
@@ -52,10 +100,11 @@ func NewCreateOrderHandler(
 		panic("nil repository")
 	}
 	h := createOrderHandler{
-		orderRepo: orderRepo,
-		restRepo:  restRepo,
-		eventBus:  eventBus,
-		log:       log,
+		orderRepo:      orderRepo,
+		restRepo:       restRepo,
+		priceDiscount:  priceDiscount,
+		redeemDiscount: redeemDiscount,
+		log:            log,
 	}
 	return decorator.ApplyCommandResultDecorators[CreateOrder, *CreateOrderResult](h, log, metrics)
 }
@@ -70,7 +119,13 @@ func (h createOrderHandler) Handle(ctx context.Context, cmd CreateOrder) (*Creat
 	}
 
 	tipPercent := cmd.TipPercent
-	if !cart.IsAllowedTipPercent(tipPercent) {
+	tips := rest.EffectiveTipSettings()
+	if cmd.CustomTip != 0 {
+		if err := tips.CheckCustom(cmd.CustomTip, cmd.Cart.Total); err != nil {
+			return nil, err
+		}
+		tipPercent = 0
+	} else if !tips.Allows(tipPercent) {
 		return nil, fmt.Errorf("invalid tip percent: %d", tipPercent)
 	}
 
@@ -92,7 +147,19 @@ func (h createOrderHandler) Handle(ctx context.Context, cmd CreateOrder) (*Creat
 		return nil, err
 	}
 
-	bd := cart.ComputeBreakdown(cmd.Cart, rest.TaxRate, tipPercent)
+	var discount cart.Discount
+	if h.priceDiscount != nil {
+		if discount, err = h.priceDiscount.PriceDiscount(ctx, cmd.SessionID, cmd.Cart); err != nil {
+			return nil, err
+		}
+	}
+	bd := cart.ComputeBreakdownWithDiscount(cmd.Cart, rest.EffectiveTaxSettings(), tipPercent, discount)
+	if cmd.CustomTip != 0 {
+		bd = bd.WithTip(cmd.CustomTip)
+	}
+	if cmd.PaymentMethod == common.PaymentMethodTypeCash {
+		bd = bd.WithCashRounding(rest.CashRounding)
+	}
 
 	o, err := order.NewOrderWithCurrency(
 		orderID, orderNumber, cmd.RestaurantID, cmd.SessionID, orderItems,
@@ -103,15 +170,38 @@ func (h createOrderHandler) Handle(ctx context.Context, cmd CreateOrder) (*Creat
 	if err != nil {
 		return nil, err
 	}
-	o.FiatAmount = bd.Total.Major()
+	if err := o.SetTaxBreakdown(bd.ServiceCharge.Amount, bd.Taxes, bd.TaxInclusive); err != nil {
+		return nil, err
+	}
+	if err := o.SetCashRounding(bd.CashRounding.Amount); err != nil {
+		return nil, err
+	}
+	if d := bd.Promotion; d.Amount > 0 {
+		if err := o.ApplyDiscount(d.PromotionID, d.Label, d.Amount); err != nil {
+			return nil, err
+		}
+		// Redeem before saving so a promotion's last use cannot go to two
+		// orders; a failed save afterwards costs at most one use.
+		if h.redeemDiscount != nil {
+			if err := h.redeemDiscount.RedeemDiscount(ctx, d, cmd.SessionID, o.ID); err != nil {
+				return nil, err
+			}
+		}
+	}
 
+	o.Record(envelope.Stamp(ctx, event.OrderCreated{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		OrderNumber:  o.OrderNumber,
+		TotalAmount:  o.TotalAmount,
+		CreatedAt:    o.CreatedAt,
+	}))
 	if err := h.orderRepo.Save(o); err != nil {
 		return nil, err
 	}
 
-	h.publishOrderCreatedEvent(ctx, o)
 	if h.log != nil {
-		h.log.InfoContext(ctx, "Order created", "orderID", o.ID, "amount", o.FiatAmount)
+		h.log.InfoContext(ctx, "Order created", "orderID", o.ID, "amount", o.Total().Format())
 	}
 
 	return &CreateOrderResult{
@@ -141,16 +231,3 @@ func (h createOrderHandler) createOrderItems(cartItems []cart.CartItem, orderID
 	}
 	return orderItems, nil
 }
-
-func (h createOrderHandler) publishOrderCreatedEvent(ctx context.Context, o *order.Order) {
-	ev := event.OrderCreated{
-		OrderID:      o.ID,
-		RestaurantID: o.RestaurantID,
-		OrderNumber:  o.OrderNumber,
-		TotalAmount:  o.TotalAmount,
-		CreatedAt:    o.CreatedAt,
-	}
-	if err := h.eventBus.Publish(ctx, common.EventOrderCreated, ev); err != nil && h.log != nil {
-		h.log.WarnContext(ctx, "Failed to publish order created event", "orderID", o.ID, "error", err)
-	}
-}
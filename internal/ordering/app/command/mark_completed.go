@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -20,15 +21,14 @@ type MarkOrderCompleted struct {
 type MarkOrderCompletedHandler decorator.CommandResultHandler[MarkOrderCompleted, *order.Order]
 
 type markOrderCompletedHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo order.Repository
 }
 
-func NewMarkOrderCompletedHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderCompletedHandler {
+func NewMarkOrderCompletedHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderCompletedHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := markOrderCompletedHandler{repo: repo, eventBus: eventBus}
+	h := markOrderCompletedHandler{repo: repo}
 	return decorator.ApplyCommandResultDecorators[MarkOrderCompleted, *order.Order](h, log, metrics)
 }
 
@@ -45,17 +45,14 @@ func (h markOrderCompletedHandler) Handle(ctx context.Context, cmd MarkOrderComp
 		return nil, err
 	}
 
-	if err := h.repo.Update(o); err != nil {
-		return nil, err
-	}
-
 	ev := event.OrderCompleted{
 		OrderID:      o.ID,
 		RestaurantID: o.RestaurantID,
 		OrderNumber:  o.OrderNumber,
 		CompletedAt:  time.Now(),
 	}
-	if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
 		return nil, err
 	}
 
@@ -8,27 +8,75 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
 
 // MarkOrderPaid records payment for an order and publishes OrderPaid.
+// Method is how the customer paid (empty means the order's method).
+// Tendered is what the customer handed over in the order currency (zero
+// means the exact total); CollectedBy is the staff member who took it.
+// Settled is set when the payment context already settled the payment (a
+// paid Lightning invoice); the recorder is then skipped. A split bill is
+// settled part by part with PayBillPart instead.
 type MarkOrderPaid struct {
-	OrderID common.OrderID
+	OrderID     common.OrderID
+	Method      common.PaymentMethodType
+	Tendered    money.Money
+	CollectedBy common.UserID
+	Settled     *SettledPayment
+}
+
+// SettledPayment is what the payment context reports back once it has
+// settled a payment: the ledger payment ID and, when the payment was taken in
+// another unit, the exchange rate it was converted at.
+type SettledPayment struct {
+	PaymentID common.PaymentID
+	FXRate    money.ExchangeRate
+}
+
+// PaymentRecorder settles the order's payment in the payment context,
+// charging what o.ChargeFor(method) returns. It runs before the order is
+// marked paid, so a rejected tender leaves the order unpaid, and must be
+// idempotent for orders whose payment already settled.
+type PaymentRecorder interface {
+	RecordPayment(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (SettledPayment, error)
+}
+
+// PaymentRecorderFunc adapts a function to a PaymentRecorder.
+type PaymentRecorderFunc func(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (SettledPayment, error)
+
+// RecordPayment calls f.
+func (f PaymentRecorderFunc) RecordPayment(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (SettledPayment, error) {
+	return f(ctx, o, method, tendered, collectedBy)
+}
+
+// settlePayment returns settled when the payment context already settled
+// the payment, and records it otherwise.
+func settlePayment(settled *SettledPayment, record func() (SettledPayment, error)) (SettledPayment, error) {
+	if settled != nil {
+		return *settled, nil
+	}
+	return record()
 }
 
 type MarkOrderPaidHandler decorator.CommandResultHandler[MarkOrderPaid, *order.Order]
 
 type markOrderPaidHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo          order.Repository
+	recordPayment PaymentRecorder
 }
 
-func NewMarkOrderPaidHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderPaidHandler {
+func NewMarkOrderPaidHandler(repo order.Repository, recordPayment PaymentRecorder, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderPaidHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := markOrderPaidHandler{repo: repo, eventBus: eventBus}
+	if recordPayment == nil {
+		panic("nil PaymentRecorder")
+	}
+	h := markOrderPaidHandler{repo: repo, recordPayment: recordPayment}
 	return decorator.ApplyCommandResultDecorators[MarkOrderPaid, *order.Order](h, log, metrics)
 }
 
@@ -40,13 +88,32 @@ func (h markOrderPaidHandler) Handle(ctx context.Context, cmd MarkOrderPaid) (*o
 	if o == nil {
 		return nil, errors.New("order not found")
 	}
+	if o.IsCancelled() {
+		return nil, order.ErrOrderCancelled
+	}
+	// A double click or a client retry must not settle the order twice or
+	// announce it twice.
+	if o.PaymentStatus == common.PaymentStatusPaid {
+		return o, nil
+	}
+	if o.IsSplit() {
+		return nil, order.ErrBillIsSplit
+	}
 
-	o.MarkPaid()
-
-	if err := h.repo.Update(o); err != nil {
+	method := cmd.Method
+	if method == "" {
+		method = o.PaymentMethod
+	}
+	settled, err := settlePayment(cmd.Settled, func() (SettledPayment, error) {
+		return h.recordPayment.RecordPayment(ctx, o, method, cmd.Tendered, cmd.CollectedBy)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	o.RecordExchangeRate(settled.FXRate)
+	o.MarkPaid()
+
 	ev := event.OrderPaid{
 		OrderID:      o.ID,
 		RestaurantID: o.RestaurantID,
@@ -54,7 +121,8 @@ func (h markOrderPaidHandler) Handle(ctx context.Context, cmd MarkOrderPaid) (*o
 		TotalAmount:  o.TotalAmount,
 		PaidAt:       time.Now(),
 	}
-	if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
 		return nil, err
 	}
 
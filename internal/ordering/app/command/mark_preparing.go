@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -20,15 +21,14 @@ type MarkOrderPreparing struct {
 type MarkOrderPreparingHandler decorator.CommandResultHandler[MarkOrderPreparing, *order.Order]
 
 type markOrderPreparingHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo order.Repository
 }
 
-func NewMarkOrderPreparingHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderPreparingHandler {
+func NewMarkOrderPreparingHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderPreparingHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := markOrderPreparingHandler{repo: repo, eventBus: eventBus}
+	h := markOrderPreparingHandler{repo: repo}
 	return decorator.ApplyCommandResultDecorators[MarkOrderPreparing, *order.Order](h, log, metrics)
 }
 
@@ -45,17 +45,14 @@ func (h markOrderPreparingHandler) Handle(ctx context.Context, cmd MarkOrderPrep
 		return nil, err
 	}
 
-	if err := h.repo.Update(o); err != nil {
-		return nil, err
-	}
-
 	ev := event.OrderPreparing{
 		OrderID:      o.ID,
 		RestaurantID: o.RestaurantID,
 		OrderNumber:  o.OrderNumber,
 		PreparingAt:  time.Now(),
 	}
-	if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
 		return nil, err
 	}
 
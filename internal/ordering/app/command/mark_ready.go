@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -20,15 +21,14 @@ type MarkOrderReady struct {
 type MarkOrderReadyHandler decorator.CommandResultHandler[MarkOrderReady, *order.Order]
 
 type markOrderReadyHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo order.Repository
 }
 
-func NewMarkOrderReadyHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderReadyHandler {
+func NewMarkOrderReadyHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) MarkOrderReadyHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := markOrderReadyHandler{repo: repo, eventBus: eventBus}
+	h := markOrderReadyHandler{repo: repo}
 	return decorator.ApplyCommandResultDecorators[MarkOrderReady, *order.Order](h, log, metrics)
 }
 
@@ -45,17 +45,14 @@ func (h markOrderReadyHandler) Handle(ctx context.Context, cmd MarkOrderReady) (
 		return nil, err
 	}
 
-	if err := h.repo.Update(o); err != nil {
-		return nil, err
-	}
-
 	ev := event.OrderReady{
 		OrderID:      o.ID,
 		RestaurantID: o.RestaurantID,
 		OrderNumber:  o.OrderNumber,
 		ReadyAt:      time.Now(),
 	}
-	if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
 		return nil, err
 	}
 
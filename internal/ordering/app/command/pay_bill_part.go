@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// PayBillPart settles one part of a split bill. Method is how the guest paid
+// (empty means the order's method); Tendered, CollectedBy and Settled are as
+// for MarkOrderPaid. The part that clears the balance marks the order paid
+// and publishes OrderPaid; any other part publishes OrderBillPartPaid.
+type PayBillPart struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	PartID       common.BillPartID
+	Method       common.PaymentMethodType
+	Tendered     money.Money
+	CollectedBy  common.UserID
+	Settled      *SettledPayment
+}
+
+// BillPartRecorder settles one part's payment in the payment context,
+// charging what o.PartChargeFor(part, method) returns. Like PaymentRecorder
+// it runs before the order changes and must be idempotent for parts already
+// settled.
+type BillPartRecorder interface {
+	RecordBillPart(ctx context.Context, o *order.Order, part order.BillPart, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (SettledPayment, error)
+}
+
+// BillPartRecorderFunc adapts a function to a BillPartRecorder.
+type BillPartRecorderFunc func(ctx context.Context, o *order.Order, part order.BillPart, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (SettledPayment, error)
+
+// RecordBillPart calls f.
+func (f BillPartRecorderFunc) RecordBillPart(ctx context.Context, o *order.Order, part order.BillPart, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (SettledPayment, error) {
+	return f(ctx, o, part, method, tendered, collectedBy)
+}
+
+type PayBillPartHandler decorator.CommandResultHandler[PayBillPart, *order.Order]
+
+type payBillPartHandler struct {
+	repo       order.Repository
+	recordPart BillPartRecorder
+}
+
+func NewPayBillPartHandler(repo order.Repository, recordPart BillPartRecorder, log *slog.Logger, metrics decorator.MetricsClient) PayBillPartHandler {
+	if repo == nil {
+		panic("nil order.Repository")
+	}
+	if recordPart == nil {
+		panic("nil BillPartRecorder")
+	}
+	h := payBillPartHandler{repo: repo, recordPart: recordPart}
+	return decorator.ApplyCommandResultDecorators[PayBillPart, *order.Order](h, log, metrics)
+}
+
+func (h payBillPartHandler) Handle(ctx context.Context, cmd PayBillPart) (*order.Order, error) {
+	o, err := h.repo.FindByID(cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || (cmd.RestaurantID != "" && o.RestaurantID != cmd.RestaurantID) {
+		return nil, errors.New("order not found")
+	}
+	if o.IsCancelled() {
+		return nil, order.ErrOrderCancelled
+	}
+	part, ok := o.Part(cmd.PartID)
+	if !ok {
+		return nil, order.ErrBillPartNotFound
+	}
+	if part.IsPaid() {
+		return o, nil
+	}
+
+	method := cmd.Method
+	if method == "" {
+		method = o.PaymentMethod
+	}
+	settled, err := settlePayment(cmd.Settled, func() (SettledPayment, error) {
+		return h.recordPart.RecordBillPart(ctx, o, part, method, cmd.Tendered, cmd.CollectedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	covered, err := o.SettlePart(part.ID, settled.PaymentID, method, settled.FXRate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if covered {
+		o.Record(envelope.Stamp(ctx, event.OrderPaid{
+			OrderID:      o.ID,
+			RestaurantID: o.RestaurantID,
+			OrderNumber:  o.OrderNumber,
+			TotalAmount:  o.TotalAmount,
+			PaidAt:       now,
+		}))
+	} else {
+		o.Record(envelope.Stamp(ctx, event.OrderBillPartPaid{
+			OrderID:      o.ID,
+			RestaurantID: o.RestaurantID,
+			OrderNumber:  o.OrderNumber,
+			PartID:       part.ID,
+			PaymentID:    settled.PaymentID,
+			Amount:       part.Amount,
+			Outstanding:  o.Outstanding().Amount,
+			PaidAt:       now,
+		}))
+	}
+	if err := h.repo.Update(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -21,15 +22,14 @@ type RequestBill struct {
 type RequestBillHandler decorator.CommandResultHandler[RequestBill, *order.Order]
 
 type requestBillHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo order.Repository
 }
 
-func NewRequestBillHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) RequestBillHandler {
+func NewRequestBillHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) RequestBillHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := requestBillHandler{repo: repo, eventBus: eventBus}
+	h := requestBillHandler{repo: repo}
 	return decorator.ApplyCommandResultDecorators[RequestBill, *order.Order](h, log, metrics)
 }
 
@@ -47,23 +47,18 @@ func (h requestBillHandler) Handle(ctx context.Context, cmd RequestBill) (*order
 		return o, nil
 	}
 
+	ev := event.BillRequested{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		OrderNumber:  o.OrderNumber,
+		TableLabel:   o.TableLabel,
+		CustomerName: o.CustomerName,
+		RequestedAt:  *o.BillRequestedAt,
+	}
+	o.Record(envelope.Stamp(ctx, ev))
 	if err := h.repo.Update(o); err != nil {
 		return nil, err
 	}
 
-	if h.eventBus != nil {
-		ev := event.BillRequested{
-			OrderID:      o.ID,
-			RestaurantID: o.RestaurantID,
-			OrderNumber:  o.OrderNumber,
-			TableLabel:   o.TableLabel,
-			CustomerName: o.CustomerName,
-			RequestedAt:  *o.BillRequestedAt,
-		}
-		if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
-			return nil, err
-		}
-	}
-
 	return o, nil
 }
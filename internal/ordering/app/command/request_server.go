@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -21,15 +22,14 @@ type RequestServer struct {
 type RequestServerHandler decorator.CommandResultHandler[RequestServer, *order.Order]
 
 type requestServerHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo order.Repository
 }
 
-func NewRequestServerHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) RequestServerHandler {
+func NewRequestServerHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) RequestServerHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := requestServerHandler{repo: repo, eventBus: eventBus}
+	h := requestServerHandler{repo: repo}
 	return decorator.ApplyCommandResultDecorators[RequestServer, *order.Order](h, log, metrics)
 }
 
@@ -47,23 +47,18 @@ func (h requestServerHandler) Handle(ctx context.Context, cmd RequestServer) (*o
 		return o, nil
 	}
 
+	ev := event.ServerCalled{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		OrderNumber:  o.OrderNumber,
+		TableLabel:   o.TableLabel,
+		CustomerName: o.CustomerName,
+		CalledAt:     *o.ServerCalledAt,
+	}
+	o.Record(envelope.Stamp(ctx, ev))
 	if err := h.repo.Update(o); err != nil {
 		return nil, err
 	}
 
-	if h.eventBus != nil {
-		ev := event.ServerCalled{
-			OrderID:      o.ID,
-			RestaurantID: o.RestaurantID,
-			OrderNumber:  o.OrderNumber,
-			TableLabel:   o.TableLabel,
-			CustomerName: o.CustomerName,
-			CalledAt:     *o.ServerCalledAt,
-		}
-		if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
-			return nil, err
-		}
-	}
-
 	return o, nil
 }
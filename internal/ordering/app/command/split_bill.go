@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// SplitBill divides what is left to pay on an order between guests and
+// publishes OrderBillSplit. Mode picks which of Ways (even), ItemGuests
+// (line item to guest number) or Amounts (custom, in the order currency) is
+// used. Parts already paid are kept, so a partly paid bill can be split again.
+type SplitBill struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	Mode         order.SplitMode
+	Ways         int
+	ItemGuests   map[common.OrderItemID]int
+	Amounts      []money.Money
+}
+
+// OpenPaymentVoider voids the order's pending payments in the payment
+// context, leaving settled ones alone. It runs before the split is saved so
+// a Lightning invoice for the old amount cannot settle a part it no longer
+// matches.
+type OpenPaymentVoider interface {
+	VoidOpenPayments(ctx context.Context, o *order.Order, reason string) error
+}
+
+// OpenPaymentVoiderFunc adapts a function to an OpenPaymentVoider.
+type OpenPaymentVoiderFunc func(ctx context.Context, o *order.Order, reason string) error
+
+// VoidOpenPayments calls f.
+func (f OpenPaymentVoiderFunc) VoidOpenPayments(ctx context.Context, o *order.Order, reason string) error {
+	return f(ctx, o, reason)
+}
+
+type SplitBillHandler decorator.CommandResultHandler[SplitBill, *order.Order]
+
+type splitBillHandler struct {
+	repo     order.Repository
+	voidOpen OpenPaymentVoider
+}
+
+func NewSplitBillHandler(repo order.Repository, voidOpen OpenPaymentVoider, log *slog.Logger, metrics decorator.MetricsClient) SplitBillHandler {
+	if repo == nil {
+		panic("nil order.Repository")
+	}
+	if voidOpen == nil {
+		panic("nil OpenPaymentVoider")
+	}
+	h := splitBillHandler{repo: repo, voidOpen: voidOpen}
+	return decorator.ApplyCommandResultDecorators[SplitBill, *order.Order](h, log, metrics)
+}
+
+func (h splitBillHandler) Handle(ctx context.Context, cmd SplitBill) (*order.Order, error) {
+	o, err := h.repo.FindByID(cmd.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || (cmd.RestaurantID != "" && o.RestaurantID != cmd.RestaurantID) {
+		return nil, errors.New("order not found")
+	}
+
+	var parts []order.BillPart
+	switch cmd.Mode {
+	case order.SplitEvenly:
+		parts, err = o.PlanEvenSplit(cmd.Ways)
+	case order.SplitByItem:
+		parts, err = o.PlanItemSplit(cmd.ItemGuests)
+	case order.SplitCustom:
+		parts, err = o.PlanCustomSplit(cmd.Amounts)
+	default:
+		err = order.ErrInvalidSplitMode
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range parts {
+		parts[i].ID = common.BillPartID(fmt.Sprintf("part_%d_%d", now.UnixNano(), i+1))
+	}
+	if err := o.Split(parts); err != nil {
+		return nil, err
+	}
+
+	if err := h.voidOpen.VoidOpenPayments(ctx, o, "bill split"); err != nil {
+		return nil, err
+	}
+	ev := event.OrderBillSplit{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		OrderNumber:  o.OrderNumber,
+		Mode:         string(cmd.Mode),
+		Parts:        len(o.BillParts),
+		Outstanding:  o.Outstanding().Amount,
+		SplitAt:      now,
+	}
+	o.Record(envelope.Stamp(ctx, ev))
+	if err := h.repo.Update(o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
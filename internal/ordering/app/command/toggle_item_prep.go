@@ -8,6 +8,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/app/event"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -22,15 +23,14 @@ type ToggleOrderItemPrep struct {
 type ToggleOrderItemPrepHandler decorator.CommandResultHandler[ToggleOrderItemPrep, *order.Order]
 
 type toggleOrderItemPrepHandler struct {
-	repo     order.Repository
-	eventBus common.EventBus
+	repo order.Repository
 }
 
-func NewToggleOrderItemPrepHandler(repo order.Repository, eventBus common.EventBus, log *slog.Logger, metrics decorator.MetricsClient) ToggleOrderItemPrepHandler {
+func NewToggleOrderItemPrepHandler(repo order.Repository, log *slog.Logger, metrics decorator.MetricsClient) ToggleOrderItemPrepHandler {
 	if repo == nil {
 		panic("nil order.Repository")
 	}
-	h := toggleOrderItemPrepHandler{repo: repo, eventBus: eventBus}
+	h := toggleOrderItemPrepHandler{repo: repo}
 	return decorator.ApplyCommandResultDecorators[ToggleOrderItemPrep, *order.Order](h, log, metrics)
 }
 
@@ -50,19 +50,15 @@ func (h toggleOrderItemPrepHandler) Handle(ctx context.Context, cmd ToggleOrderI
 	if !o.SetItemPrepComplete(cmd.ItemID, next) {
 		return nil, errors.New("order item not found")
 	}
-	if err := h.repo.UpdateItemPrepComplete(o.ID, cmd.ItemID, next); err != nil {
-		return nil, err
-	}
-
-	ev := event.OrderItemPrepToggled{
+	o.Record(envelope.Stamp(ctx, event.OrderItemPrepToggled{
 		OrderID:      o.ID,
 		RestaurantID: o.RestaurantID,
 		OrderNumber:  o.OrderNumber,
 		ItemID:       cmd.ItemID,
 		PrepComplete: next,
 		ToggledAt:    time.Now(),
-	}
-	if err := h.eventBus.Publish(ctx, ev.EventName(), ev); err != nil {
+	}))
+	if err := h.repo.UpdateItemPrepComplete(o, cmd.ItemID); err != nil {
 		return nil, err
 	}
 
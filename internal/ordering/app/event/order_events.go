@@ -15,8 +15,9 @@ type OrderCreated struct {
 	CreatedAt    time.Time
 }
 
-func (e OrderCreated) EventName() string     { return common.EventOrderCreated }
-func (e OrderCreated) OccurredAt() time.Time { return e.CreatedAt }
+func (e OrderCreated) EventName() string                      { return common.EventOrderCreated }
+func (e OrderCreated) OccurredAt() time.Time                  { return e.CreatedAt }
+func (e OrderCreated) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // OrderPaid is published when payment is marked received.
 type OrderPaid struct {
@@ -27,8 +28,9 @@ type OrderPaid struct {
 	PaidAt       time.Time
 }
 
-func (e OrderPaid) EventName() string     { return common.EventOrderPaid }
-func (e OrderPaid) OccurredAt() time.Time { return e.PaidAt }
+func (e OrderPaid) EventName() string                      { return common.EventOrderPaid }
+func (e OrderPaid) OccurredAt() time.Time                  { return e.PaidAt }
+func (e OrderPaid) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // OrderPreparing is published when the kitchen starts preparing an order.
 type OrderPreparing struct {
@@ -38,8 +40,9 @@ type OrderPreparing struct {
 	PreparingAt  time.Time
 }
 
-func (e OrderPreparing) EventName() string     { return common.EventOrderPreparing }
-func (e OrderPreparing) OccurredAt() time.Time { return e.PreparingAt }
+func (e OrderPreparing) EventName() string                      { return common.EventOrderPreparing }
+func (e OrderPreparing) OccurredAt() time.Time                  { return e.PreparingAt }
+func (e OrderPreparing) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // OrderReady is published when an order is ready for pickup / service.
 type OrderReady struct {
@@ -49,8 +52,9 @@ type OrderReady struct {
 	ReadyAt      time.Time
 }
 
-func (e OrderReady) EventName() string     { return common.EventOrderReady }
-func (e OrderReady) OccurredAt() time.Time { return e.ReadyAt }
+func (e OrderReady) EventName() string                      { return common.EventOrderReady }
+func (e OrderReady) OccurredAt() time.Time                  { return e.ReadyAt }
+func (e OrderReady) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // OrderCompleted is reserved for a future completed lifecycle event.
 type OrderCompleted struct {
@@ -60,8 +64,25 @@ type OrderCompleted struct {
 	CompletedAt  time.Time
 }
 
-func (e OrderCompleted) EventName() string     { return common.EventOrderCompleted }
-func (e OrderCompleted) OccurredAt() time.Time { return e.CompletedAt }
+func (e OrderCompleted) EventName() string                      { return common.EventOrderCompleted }
+func (e OrderCompleted) OccurredAt() time.Time                  { return e.CompletedAt }
+func (e OrderCompleted) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
+
+// OrderCancelled is published when staff void an unpaid order or cancel and
+// refund a paid one. Refunded tells the two apart.
+type OrderCancelled struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	OrderNumber  common.OrderNumber
+	Reason       string
+	Refunded     bool
+	CancelledBy  common.UserID
+	CancelledAt  time.Time
+}
+
+func (e OrderCancelled) EventName() string                      { return common.EventOrderCancelled }
+func (e OrderCancelled) OccurredAt() time.Time                  { return e.CancelledAt }
+func (e OrderCancelled) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // OrderItemPrepToggled is published when a kitchen toggles a line item's prep_complete flag.
 type OrderItemPrepToggled struct {
@@ -73,8 +94,9 @@ type OrderItemPrepToggled struct {
 	ToggledAt    time.Time
 }
 
-func (e OrderItemPrepToggled) EventName() string     { return common.EventOrderItemPrepToggled }
-func (e OrderItemPrepToggled) OccurredAt() time.Time { return e.ToggledAt }
+func (e OrderItemPrepToggled) EventName() string                      { return common.EventOrderItemPrepToggled }
+func (e OrderItemPrepToggled) OccurredAt() time.Time                  { return e.ToggledAt }
+func (e OrderItemPrepToggled) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // ServerCalled is published when a customer taps "Call server" on the status screen.
 type ServerCalled struct {
@@ -86,8 +108,9 @@ type ServerCalled struct {
 	CalledAt     time.Time
 }
 
-func (e ServerCalled) EventName() string     { return common.EventServerCalled }
-func (e ServerCalled) OccurredAt() time.Time { return e.CalledAt }
+func (e ServerCalled) EventName() string                      { return common.EventServerCalled }
+func (e ServerCalled) OccurredAt() time.Time                  { return e.CalledAt }
+func (e ServerCalled) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
 
 // BillRequested is published when a customer taps "Request bill" on the status screen.
 type BillRequested struct {
@@ -99,5 +122,58 @@ type BillRequested struct {
 	RequestedAt  time.Time
 }
 
-func (e BillRequested) EventName() string     { return common.EventBillRequested }
-func (e BillRequested) OccurredAt() time.Time { return e.RequestedAt }
+func (e BillRequested) EventName() string                      { return common.EventBillRequested }
+func (e BillRequested) OccurredAt() time.Time                  { return e.RequestedAt }
+func (e BillRequested) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
+
+// OrderBillSplit is published when staff split an order's bill into parts.
+// Parts counts every part, including ones already paid.
+type OrderBillSplit struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	OrderNumber  common.OrderNumber
+	Mode         string
+	Parts        int
+	Outstanding  int64
+	SplitAt      time.Time
+}
+
+func (e OrderBillSplit) EventName() string                      { return common.EventOrderBillSplit }
+func (e OrderBillSplit) OccurredAt() time.Time                  { return e.SplitAt }
+func (e OrderBillSplit) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
+
+// OrderBillPartPaid is published when one part of a split bill is paid but
+// the order still has a balance outstanding. The part that clears the bill
+// publishes OrderPaid instead.
+type OrderBillPartPaid struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	OrderNumber  common.OrderNumber
+	PartID       common.BillPartID
+	PaymentID    common.PaymentID
+	Amount       int64
+	Outstanding  int64
+	PaidAt       time.Time
+}
+
+func (e OrderBillPartPaid) EventName() string                      { return common.EventOrderBillPartPaid }
+func (e OrderBillPartPaid) OccurredAt() time.Time                  { return e.PaidAt }
+func (e OrderBillPartPaid) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
+
+// OrderTipAdded is published when a guest adds a tip after their order was
+// paid. Amount is this tip; TipAmount and TotalAmount are the order's new
+// figures.
+type OrderTipAdded struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	OrderNumber  common.OrderNumber
+	PaymentID    common.PaymentID
+	Amount       int64
+	TipAmount    int64
+	TotalAmount  int64
+	AddedAt      time.Time
+}
+
+func (e OrderTipAdded) EventName() string                      { return common.EventOrderTipAdded }
+func (e OrderTipAdded) OccurredAt() time.Time                  { return e.AddedAt }
+func (e OrderTipAdded) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"sort"
 
+	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/decorator"
 	"bitmerchant/internal/ordering/domain/order"
 )
@@ -38,10 +39,14 @@ func (h customerOrdersForSessionHandler) Handle(ctx context.Context, q CustomerO
 	return orders, nil
 }
 
-// CustomerOrderByLookup finds an order by session and human-readable order number.
+// CustomerOrderByLookup finds an order by session and human-readable order
+// number. Numbers are only unique within a restaurant, so when the session
+// has orders with the same number at several restaurants the one at
+// RestaurantID, the session's active restaurant, wins.
 type CustomerOrderByLookup struct {
-	SessionID   string
-	OrderNumber string
+	SessionID    string
+	OrderNumber  string
+	RestaurantID common.RestaurantID
 }
 
 type CustomerOrderByLookupHandler decorator.QueryHandler[CustomerOrderByLookup, *order.Order]
@@ -64,10 +69,20 @@ func (h customerOrderByLookupHandler) Handle(ctx context.Context, q CustomerOrde
 	if err != nil {
 		return nil, err
 	}
+	var found *order.Order
 	for _, o := range orders {
-		if string(o.OrderNumber) == q.OrderNumber {
+		if string(o.OrderNumber) != q.OrderNumber {
+			continue
+		}
+		if o.RestaurantID == q.RestaurantID {
 			return o, nil
 		}
+		if found == nil || o.CreatedAt.After(found.CreatedAt) {
+			found = o
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("order not found")
 	}
-	return nil, fmt.Errorf("order not found")
+	return found, nil
 }
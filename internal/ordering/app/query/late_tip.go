@@ -0,0 +1,42 @@
+package query
+
+import (
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tip"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+)
+
+// LateTipLookup returns the tip choices a restaurant offers once an order
+// has been paid and served, or zero settings when it cannot take a tip then.
+type LateTipLookup func(restaurantID common.RestaurantID) tip.Settings
+
+// NewLateTipLookup offers a restaurant's tip settings after payment when it
+// can take the tip over Lightning, through the platform node
+// (lightningEnabled) or its own Lightning address. Cash tips are left to the
+// table.
+func NewLateTipLookup(rests restaurant.Repository, lightningEnabled bool) LateTipLookup {
+	if rests == nil {
+		panic("nil restaurant.Repository")
+	}
+	return func(restaurantID common.RestaurantID) tip.Settings {
+		rest, err := rests.FindByID(restaurantID)
+		if err != nil || rest == nil || !lightningEnabled && rest.LightningAddress == "" {
+			return tip.Settings{}
+		}
+		return rest.EffectiveTipSettings()
+	}
+}
+
+// OfferLateTip fills in LateTip when the order can still take a tip. A nil
+// lookup offers none.
+func (v *OrderStatusView) OfferLateTip(lookup LateTipLookup) {
+	if lookup == nil || v.Order == nil || !v.Order.AcceptsLateTip() {
+		return
+	}
+	v.LateTip = lookup(v.Order.RestaurantID)
+}
+
+// OffersLateTip reports whether the status page asks for a tip.
+func (v OrderStatusView) OffersLateTip() bool {
+	return v.Order != nil && v.Order.AcceptsLateTip() && v.LateTip.Enabled()
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tip"
 	"bitmerchant/internal/ordering/domain/order"
 )
 
@@ -25,6 +26,9 @@ type OrderStatusView struct {
 	QueueAhead       int
 	EstimatedReadyAt time.Time
 	PrepTarget       time.Duration
+	// LateTip is what the guest is offered to tip once the order is paid
+	// and served; zero offers nothing. See OfferLateTip.
+	LateTip tip.Settings
 }
 
 // PositionLabel is the 1-indexed queue slot ("#3"). Once the order is
@@ -41,15 +45,16 @@ func (v OrderStatusView) PositionLabel() int {
 	}
 }
 
-// IsTerminal reports whether the order has reached ready or completed —
-// at which point the timeline shows the final tick and the ETA is the actual
-// ReadyAt/CompletedAt timestamp, not a projection.
+// IsTerminal reports whether the order has reached ready or completed (or
+// was cancelled) — at which point the timeline shows the final tick and the
+// ETA is the actual ReadyAt/CompletedAt timestamp, not a projection.
 func (v OrderStatusView) IsTerminal() bool {
 	if v.Order == nil {
 		return false
 	}
 	return v.Order.FulfillmentStatus == common.FulfillmentStatusReady ||
-		v.Order.FulfillmentStatus == common.FulfillmentStatusCompleted
+		v.Order.FulfillmentStatus == common.FulfillmentStatusCompleted ||
+		v.Order.FulfillmentStatus == common.FulfillmentStatusCancelled
 }
 
 // BuildOrderStatusView projects an order plus its current queue context.
@@ -131,6 +136,11 @@ func computeETA(o *order.Order, queueAhead int, prepTarget time.Duration) time.T
 			return *o.CompletedAt
 		}
 		return time.Now()
+	case common.FulfillmentStatusCancelled:
+		if o.CancelledAt != nil {
+			return *o.CancelledAt
+		}
+		return time.Now()
 	case common.FulfillmentStatusPreparing:
 		base := o.CreatedAt
 		if o.PreparingAt != nil {
@@ -0,0 +1,143 @@
+package order
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/common"
+)
+
+var (
+	ErrOrderCancelled      = errors.New("order is cancelled")
+	ErrInvalidCancelReason = errors.New("invalid cancellation reason")
+	ErrCancelNoteRequired  = errors.New("a note is required when the reason is other")
+	ErrCancelNotPermitted  = errors.New("order can no longer be cancelled")
+	ErrCancellerRequired   = errors.New("cancelling staff member is required")
+)
+
+// CancelReason is the reason code recorded when staff void or cancel an order.
+type CancelReason string
+
+const (
+	CancelReasonCustomerRequest CancelReason = "customer_request"
+	CancelReasonOutOfStock      CancelReason = "out_of_stock"
+	CancelReasonKitchenError    CancelReason = "kitchen_error"
+	CancelReasonDuplicate       CancelReason = "duplicate"
+	CancelReasonPaymentIssue    CancelReason = "payment_issue"
+	CancelReasonOther           CancelReason = "other"
+)
+
+// CancelReasons lists the reason codes in the order staff pick them from.
+var CancelReasons = []CancelReason{
+	CancelReasonCustomerRequest,
+	CancelReasonOutOfStock,
+	CancelReasonKitchenError,
+	CancelReasonDuplicate,
+	CancelReasonPaymentIssue,
+	CancelReasonOther,
+}
+
+// ParseCancelReason validates a reason code submitted from a form.
+func ParseCancelReason(raw string) (CancelReason, error) {
+	r := CancelReason(strings.TrimSpace(raw))
+	for _, known := range CancelReasons {
+		if r == known {
+			return r, nil
+		}
+	}
+	return "", ErrInvalidCancelReason
+}
+
+// Label is the human-readable form shown to staff and customers.
+func (r CancelReason) Label() string {
+	switch r {
+	case CancelReasonCustomerRequest:
+		return "Customer request"
+	case CancelReasonOutOfStock:
+		return "Out of stock"
+	case CancelReasonKitchenError:
+		return "Kitchen error"
+	case CancelReasonDuplicate:
+		return "Duplicate order"
+	case CancelReasonPaymentIssue:
+		return "Payment issue"
+	case CancelReasonOther:
+		return "Other"
+	default:
+		return string(r)
+	}
+}
+
+// IsCancelled reports whether the order was voided or cancelled.
+func (o *Order) IsCancelled() bool {
+	return o.FulfillmentStatus == common.FulfillmentStatusCancelled
+}
+
+// CanCancel reports whether staff may still cancel the order. Completed
+// orders stay cancellable so a served-but-disputed order can be refunded.
+func (o *Order) CanCancel() bool {
+	return !o.IsCancelled()
+}
+
+// NeedsRefund reports whether cancelling the order has to return money, as
+// opposed to simply voiding an unpaid order. A split bill with some parts
+// settled needs a refund too.
+func (o *Order) NeedsRefund() bool {
+	return o.PaymentStatus == common.PaymentStatusPaid || o.PaymentStatus == common.PaymentStatusPartiallyPaid
+}
+
+// ValidateCancellation checks a cancellation without applying it, so the
+// payment can be reversed before the order changes state.
+func (o *Order) ValidateCancellation(reason CancelReason, by common.UserID, note string) error {
+	if !o.CanCancel() {
+		return ErrCancelNotPermitted
+	}
+	if _, err := ParseCancelReason(string(reason)); err != nil {
+		return err
+	}
+	if reason == CancelReasonOther && strings.TrimSpace(note) == "" {
+		return ErrCancelNoteRequired
+	}
+	if by == "" {
+		return ErrCancellerRequired
+	}
+	return nil
+}
+
+// Cancel moves the order to the terminal cancelled state. An unpaid order is
+// voided; a paid one is marked refunded, so the payment context must have
+// returned the money before this is called.
+func (o *Order) Cancel(reason CancelReason, by common.UserID, note string) error {
+	if err := o.ValidateCancellation(reason, by, note); err != nil {
+		return err
+	}
+	if o.NeedsRefund() {
+		o.PaymentStatus = common.PaymentStatusRefunded
+	} else {
+		o.PaymentStatus = common.PaymentStatusVoided
+	}
+	now := time.Now()
+	o.FulfillmentStatus = common.FulfillmentStatusCancelled
+	o.CancelledAt = &now
+	o.CancelledBy = by
+	o.CancelReason = reason
+	o.CancelNote = strings.TrimSpace(note)
+	o.UpdatedAt = now
+	return nil
+}
+
+// CancelSummary is the reason label plus any staff note, for ledgers and
+// notifications.
+func (o *Order) CancelSummary() string {
+	return DescribeCancellation(o.CancelReason, o.CancelNote)
+}
+
+// DescribeCancellation formats a reason code and optional note as one line.
+func DescribeCancellation(reason CancelReason, note string) string {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return reason.Label()
+	}
+	return reason.Label() + ": " + note
+}
@@ -0,0 +1,22 @@
+package order
+
+import "bitmerchant/internal/common"
+
+// Record queues ev to be published once the order is saved. Repositories
+// write recorded events to the outbox in the same transaction as the order,
+// so an event goes out if and only if the change it describes was stored.
+func (o *Order) Record(ev common.DomainEvent) {
+	o.events = append(o.events, ev)
+}
+
+// Events returns the events recorded since the order was last saved, in the
+// order they were recorded.
+func (o *Order) Events() []common.DomainEvent {
+	return o.events
+}
+
+// ClearEvents forgets recorded events; repositories call it once they have
+// been stored.
+func (o *Order) ClearEvents() {
+	o.events = nil
+}
@@ -0,0 +1,52 @@
+package order
+
+import (
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+)
+
+// RecordExchangeRate keeps the rate the order total was converted at when it
+// was paid in another unit. Zero rates and rates that do not start from the
+// order currency are ignored.
+func (o *Order) RecordExchangeRate(rate money.ExchangeRate) {
+	if o.acceptsRate(rate) {
+		o.FXRate = rate
+	}
+}
+
+// SatsAtSale is what the order was worth in sats when it was paid: its own
+// total when priced in sats, otherwise the share paid in sats valued with
+// the rate snapshots taken at the time. Fiat settled in fiat counts zero,
+// and so does an order that is not paid yet.
+func (o *Order) SatsAtSale() money.Money {
+	total := o.Total()
+	if total.Currency.Code == money.SAT.Code {
+		return o.AmountPaid()
+	}
+	var sats int64
+	if !o.IsSplit() {
+		if o.PaymentStatus == common.PaymentStatusPaid {
+			sats = satsAt(o.FXRate, total)
+		}
+		return money.New(sats, money.SAT)
+	}
+	for _, p := range o.PaidParts() {
+		sats += satsAt(p.FXRate, o.PartAmount(p))
+	}
+	return money.New(sats, money.SAT)
+}
+
+func satsAt(rate money.ExchangeRate, m money.Money) int64 {
+	if rate.To != money.SAT.Code {
+		return 0
+	}
+	sats, err := rate.Apply(m)
+	if err != nil {
+		return 0
+	}
+	return sats.Amount
+}
+
+func (o *Order) acceptsRate(rate money.ExchangeRate) bool {
+	return !rate.IsZero() && rate.From == o.Total().Currency.Code && rate.To != rate.From
+}
@@ -6,20 +6,40 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
 )
 
 // Order represents a customer purchase record.
 type Order struct {
-	ID                common.OrderID
-	OrderNumber       common.OrderNumber
-	RestaurantID      common.RestaurantID
-	SessionID         string
-	Items             []OrderItem
-	Subtotal          int64 // pre-tax, pre-tip; minor units
-	TaxAmount         int64
-	TipAmount         int64
+	ID           common.OrderID
+	OrderNumber  common.OrderNumber
+	RestaurantID common.RestaurantID
+	SessionID    string
+	Items        []OrderItem
+	Subtotal     int64 // pre-discount, pre-tax, pre-tip; minor units
+	// DiscountAmount is what a promotion took off the subtotal, so
+	// Subtotal - DiscountAmount + ServiceCharge + TaxAmount + TipAmount +
+	// CashRounding = TotalAmount, leaving out TaxAmount when TaxInclusive.
+	// PromotionID and DiscountLabel are empty when none applied.
+	DiscountAmount int64
+	PromotionID    common.PromotionID
+	DiscountLabel  string
+	ServiceCharge  int64
+	TaxAmount      int64
+	// TaxLines itemises TaxAmount per named tax. Empty on orders placed
+	// before named taxes, which show a single tax line.
+	TaxLines []tax.Charge
+	// TaxInclusive records that the menu prices already contained the tax.
+	TaxInclusive bool
+	TipAmount    int64
+	// CashRounding is the signed adjustment that rounded a cash order's
+	// total to the restaurant's smallest coin; zero otherwise.
+	CashRounding int64
+	// LateTipAmount is the part of TipAmount added after the order was
+	// paid, settled as a payment of its own (LateTipPaymentID); see AddTip.
+	LateTipAmount     int64
+	LateTipPaymentID  common.PaymentID
 	TotalAmount       int64
-	FiatAmount        float64
 	Currency          money.Currency
 	CustomerName      string
 	TableLabel        string
@@ -36,6 +56,20 @@ type Order struct {
 	// front-of-house for service. Used for 60s tap throttling (see ServiceRequestThrottle).
 	ServerCalledAt  *time.Time
 	BillRequestedAt *time.Time
+	// CancelledAt is set once staff void or cancel the order; see Cancel.
+	CancelledAt  *time.Time
+	CancelledBy  common.UserID
+	CancelReason CancelReason
+	CancelNote   string
+	// BillParts is set when the table pays separately; see Split.
+	BillParts []BillPart
+	// FXRate is the rate the total was converted at when the order was paid
+	// in another unit; split bills keep one per part instead.
+	FXRate money.ExchangeRate
+
+	// events are recorded by Record and written to the outbox with the
+	// order; see Events.
+	events []common.DomainEvent
 }
 
 // ServiceRequestThrottle is the window during which a repeated call-server /
@@ -108,6 +142,65 @@ func NewOrderWithCurrency(
 	}, nil
 }
 
+// ApplyDiscount records the promotion priced into the order's total. The
+// amount must be positive and no larger than the subtotal.
+func (o *Order) ApplyDiscount(promotionID common.PromotionID, label string, amount int64) error {
+	if amount <= 0 || amount > o.Subtotal {
+		return errors.New("discount must be positive and at most the subtotal")
+	}
+	o.PromotionID = promotionID
+	o.DiscountLabel = label
+	o.DiscountAmount = amount
+	return nil
+}
+
+// SetTaxBreakdown records the service charge and the named taxes priced into
+// the order's total; TaxAmount becomes their sum.
+func (o *Order) SetTaxBreakdown(serviceCharge int64, lines []tax.Charge, inclusive bool) error {
+	if serviceCharge < 0 {
+		return errors.New("service charge must not be negative")
+	}
+	var sum int64
+	for _, l := range lines {
+		if l.Amount < 0 {
+			return errors.New("tax amounts must not be negative")
+		}
+		sum += l.Amount
+	}
+	o.ServiceCharge = serviceCharge
+	o.TaxLines = lines
+	o.TaxInclusive = inclusive
+	o.TaxAmount = sum
+	return nil
+}
+
+// SetCashRounding records the adjustment already included in TotalAmount
+// when a cash order's total was rounded. Only cash orders are rounded.
+func (o *Order) SetCashRounding(adjustment int64) error {
+	if adjustment != 0 && o.PaymentMethod != common.PaymentMethodTypeCash {
+		return errors.New("only cash orders are rounded")
+	}
+	if o.TotalAmount-adjustment <= 0 {
+		return errors.New("cash rounding exceeds the order total")
+	}
+	o.CashRounding = adjustment
+	return nil
+}
+
+// ChargeFor returns what a payment by method settles the order for and the
+// cash rounding included in it. Only cash is rounded: paid any other way,
+// the order costs its total before rounding.
+func (o *Order) ChargeFor(method common.PaymentMethodType) (money.Money, int64) {
+	return o.chargeFor(o.Total(), method)
+}
+
+func (o *Order) chargeFor(amount money.Money, method common.PaymentMethodType) (money.Money, int64) {
+	if method == common.PaymentMethodTypeCash {
+		return amount, o.CashRounding
+	}
+	return money.New(amount.Amount-o.CashRounding, amount.Currency), 0
+}
+
 // AllItemsPrepComplete reports whether every line item is marked prep complete.
 // An order with no items returns false (defensive — should not occur in practice).
 func (o *Order) AllItemsPrepComplete() bool {
@@ -146,7 +239,8 @@ func (o *Order) SetItemPrepComplete(itemID common.OrderItemID, complete bool) bo
 	return false
 }
 
-// MarkPaid transitions payment status to paid.
+// MarkPaid transitions payment status to paid. Callers must check
+// IsCancelled first; a cancelled order never becomes paid again.
 func (o *Order) MarkPaid() {
 	o.PaymentStatus = common.PaymentStatusPaid
 	now := time.Now()
@@ -234,6 +328,7 @@ func isValidStatusTransition(current, next common.FulfillmentStatus) bool {
 		common.FulfillmentStatusPreparing: {common.FulfillmentStatusReady},
 		common.FulfillmentStatusReady:     {common.FulfillmentStatusCompleted},
 		common.FulfillmentStatusCompleted: {},
+		common.FulfillmentStatusCancelled: {},
 	}
 
 	allowed, exists := validTransitions[current]
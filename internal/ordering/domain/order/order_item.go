@@ -11,7 +11,7 @@ import (
 type OrderItemModifier struct {
 	GroupName  string
 	OptionName string
-	PriceDelta float64
+	PriceDelta int64 // minor units
 }
 
 // OrderItem represents an individual item within an order.
@@ -21,21 +21,23 @@ type OrderItem struct {
 	MenuItemID          common.ItemID
 	Name                string
 	Quantity            int
-	UnitPrice           float64
-	Subtotal            float64
+	UnitPrice           int64 // minor units, modifiers included
+	Subtotal            int64
 	Currency            money.Currency
 	Modifiers           []OrderItemModifier
 	SpecialInstructions string
 	PrepComplete        bool
 }
 
-// NewOrderItem creates a new OrderItem. Currency defaults to USD.
-func NewOrderItem(id common.OrderItemID, orderID common.OrderID, menuItemID common.ItemID, name string, quantity int, unitPrice float64) (*OrderItem, error) {
+// NewOrderItem creates a new OrderItem. Currency defaults to USD, so
+// unitPrice is in cents.
+func NewOrderItem(id common.OrderItemID, orderID common.OrderID, menuItemID common.ItemID, name string, quantity int, unitPrice int64) (*OrderItem, error) {
 	return NewOrderItemWithCurrency(id, orderID, menuItemID, name, quantity, unitPrice, money.USD, nil, "")
 }
 
-// NewOrderItemWithCurrency creates an OrderItem pinned to the order's currency.
-func NewOrderItemWithCurrency(id common.OrderItemID, orderID common.OrderID, menuItemID common.ItemID, name string, quantity int, unitPrice float64, currency money.Currency, modifiers []OrderItemModifier, specialInstructions string) (*OrderItem, error) {
+// NewOrderItemWithCurrency creates an OrderItem pinned to the order's currency,
+// with unitPrice in its minor units.
+func NewOrderItemWithCurrency(id common.OrderItemID, orderID common.OrderID, menuItemID common.ItemID, name string, quantity int, unitPrice int64, currency money.Currency, modifiers []OrderItemModifier, specialInstructions string) (*OrderItem, error) {
 	if quantity <= 0 {
 		return nil, errors.New("quantity must be greater than 0")
 	}
@@ -49,7 +51,7 @@ func NewOrderItemWithCurrency(id common.OrderItemID, orderID common.OrderID, men
 		currency = money.USD
 	}
 
-	subtotal := float64(quantity) * unitPrice
+	subtotal := int64(quantity) * unitPrice
 	return &OrderItem{
 		ID:                  id,
 		OrderID:             orderID,
@@ -2,7 +2,9 @@ package order
 
 import "bitmerchant/internal/common"
 
-// Repository defines operations for Order persistence.
+// Repository defines operations for Order persistence. Save, Update and
+// UpdateItemPrepComplete also store the events recorded on the order, in the
+// same transaction, and clear them; see Order.Record.
 type Repository interface {
 	Save(order *Order) error
 	FindByID(id common.OrderID) (*Order, error)
@@ -11,9 +13,9 @@ type Repository interface {
 	FindActiveByRestaurantID(restaurantID common.RestaurantID) ([]*Order, error)
 	FindBySessionID(sessionID string) ([]*Order, error)
 	Update(order *Order) error
-	// UpdateItemPrepComplete persists the prep_complete flag for a single line
-	// item. Returns an error if the item is not found.
-	UpdateItemPrepComplete(orderID common.OrderID, itemID common.OrderItemID, complete bool) error
+	// UpdateItemPrepComplete persists the prep_complete flag of a single line
+	// item of o. Returns an error if the item is not found.
+	UpdateItemPrepComplete(o *Order, itemID common.OrderItemID) error
 	// NextOrderNumber atomically allocates the next order number for the given
 	// restaurant. The returned value is monotonically increasing within a
 	// restaurant and is safe to call concurrently — implementations must
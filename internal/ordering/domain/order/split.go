@@ -0,0 +1,325 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+)
+
+var (
+	ErrBillIsSplit         = errors.New("bill is split; settle each part instead")
+	ErrInvalidSplit        = errors.New("a split needs at least two parts")
+	ErrSplitMismatch       = errors.New("split parts must add up to the outstanding balance")
+	ErrSplitItemUnassigned = errors.New("every unpaid item must be assigned to a guest")
+	ErrInvalidSplitMode    = errors.New("invalid split mode")
+	ErrBillPartNotFound    = errors.New("bill part not found")
+	ErrNothingOutstanding  = errors.New("nothing left to pay on this order")
+)
+
+// SplitMode is how staff divide the outstanding balance between guests.
+type SplitMode string
+
+const (
+	SplitEvenly SplitMode = "even"
+	SplitByItem SplitMode = "items"
+	SplitCustom SplitMode = "custom"
+)
+
+// ParseSplitMode validates a split mode submitted from a form.
+func ParseSplitMode(raw string) (SplitMode, error) {
+	switch m := SplitMode(strings.TrimSpace(raw)); m {
+	case SplitEvenly, SplitByItem, SplitCustom:
+		return m, nil
+	}
+	return "", ErrInvalidSplitMode
+}
+
+// BillPart is one guest's share of a split bill. Amount is in minor units of
+// the order currency; ItemIDs is set when the bill was split by line item.
+// PaymentID, Method and PaidAt record the ledger payment that settled it,
+// and FXRate the rate it was converted at when paid in another unit.
+type BillPart struct {
+	ID        common.BillPartID
+	Label     string
+	Amount    int64
+	ItemIDs   []common.OrderItemID
+	PaymentID common.PaymentID
+	Method    common.PaymentMethodType
+	PaidAt    *time.Time
+	FXRate    money.ExchangeRate
+}
+
+// IsPaid reports whether a payment has settled this part.
+func (p BillPart) IsPaid() bool { return p.PaidAt != nil }
+
+// IsSplit reports whether the bill is being paid in parts.
+func (o *Order) IsSplit() bool { return len(o.BillParts) > 0 }
+
+// PartAmount returns a part's amount as money.Money in the order currency.
+func (o *Order) PartAmount(p BillPart) money.Money {
+	return money.New(p.Amount, o.Total().Currency)
+}
+
+// PartChargeFor is ChargeFor for one part of a split bill. The cash rounding
+// rides on the part that clears the balance, so it is settled once; the
+// other parts are charged their amount.
+func (o *Order) PartChargeFor(p BillPart, method common.PaymentMethodType) (money.Money, int64) {
+	amount := o.PartAmount(p)
+	if p.Amount < o.Outstanding().Amount {
+		return amount, 0
+	}
+	return o.chargeFor(amount, method)
+}
+
+// Part looks up a bill part by ID.
+func (o *Order) Part(id common.BillPartID) (BillPart, bool) {
+	for _, p := range o.BillParts {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return BillPart{}, false
+}
+
+// PaidParts returns the settled parts in the order they were planned.
+func (o *Order) PaidParts() []BillPart {
+	var paid []BillPart
+	for _, p := range o.BillParts {
+		if p.IsPaid() {
+			paid = append(paid, p)
+		}
+	}
+	return paid
+}
+
+// AmountPaid is how much of the total has been settled. An unsplit order is
+// all or nothing; a split order counts its settled parts and any late tips.
+func (o *Order) AmountPaid() money.Money {
+	total := o.Total()
+	if !o.IsSplit() {
+		if o.PaymentStatus == common.PaymentStatusPaid || o.PaymentStatus == common.PaymentStatusRefunded {
+			return total
+		}
+		return money.New(0, total.Currency)
+	}
+	paid := o.LateTipAmount
+	for _, p := range o.PaidParts() {
+		paid += p.Amount
+	}
+	return money.New(paid, total.Currency)
+}
+
+// Outstanding is what the table still owes. Cancelled and fully paid orders
+// owe nothing.
+func (o *Order) Outstanding() money.Money {
+	total := o.Total()
+	if o.IsCancelled() || o.PaymentStatus == common.PaymentStatusPaid {
+		return money.New(0, total.Currency)
+	}
+	owed := total.Amount - o.AmountPaid().Amount
+	if owed < 0 {
+		owed = 0
+	}
+	return money.New(owed, total.Currency)
+}
+
+// PlanEvenSplit divides the outstanding balance into ways equal parts. Any
+// leftover minor units go to the first guests.
+func (o *Order) PlanEvenSplit(ways int) ([]BillPart, error) {
+	if ways < 2 {
+		return nil, ErrInvalidSplit
+	}
+	outstanding, err := o.splittable()
+	if err != nil {
+		return nil, err
+	}
+	if int64(ways) > outstanding.Amount {
+		return nil, ErrInvalidSplit
+	}
+	weights := make([]int64, ways)
+	for i := range weights {
+		weights[i] = 1
+	}
+	shares, err := outstanding.Allocate(weights)
+	if err != nil {
+		return nil, err
+	}
+	offset := len(o.PaidParts())
+	parts := make([]BillPart, ways)
+	for i, share := range shares {
+		parts[i] = BillPart{
+			Label:  fmt.Sprintf("Guest %d of %d", offset+i+1, offset+ways),
+			Amount: share.Amount,
+		}
+	}
+	return parts, nil
+}
+
+// PlanItemSplit assigns every unpaid line item to a guest number and shares
+// the outstanding balance in proportion to each guest's item subtotals, so
+// tax and tip follow the food. Items already covered by a paid part are left
+// out.
+func (o *Order) PlanItemSplit(guests map[common.OrderItemID]int) ([]BillPart, error) {
+	outstanding, err := o.splittable()
+	if err != nil {
+		return nil, err
+	}
+	covered := make(map[common.OrderItemID]bool)
+	for _, p := range o.PaidParts() {
+		for _, id := range p.ItemIDs {
+			covered[id] = true
+		}
+	}
+
+	groups := make(map[int][]OrderItem)
+	for _, item := range o.Items {
+		if covered[item.ID] {
+			continue
+		}
+		guest := guests[item.ID]
+		if guest < 1 {
+			return nil, ErrSplitItemUnassigned
+		}
+		groups[guest] = append(groups[guest], item)
+	}
+	if len(groups) == 0 {
+		return nil, ErrSplitItemUnassigned
+	}
+
+	numbers := make([]int, 0, len(groups))
+	for n := range groups {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+
+	weights := make([]int64, len(numbers))
+	for i, n := range numbers {
+		for _, item := range groups[n] {
+			weights[i] += item.Subtotal
+		}
+	}
+	shares, err := outstanding.Allocate(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := len(o.PaidParts())
+	parts := make([]BillPart, len(numbers))
+	for i, n := range numbers {
+		part := BillPart{Label: fmt.Sprintf("Guest %d", offset+i+1), Amount: shares[i].Amount}
+		for _, item := range groups[n] {
+			part.ItemIDs = append(part.ItemIDs, item.ID)
+		}
+		parts[i] = part
+	}
+	return parts, nil
+}
+
+// PlanCustomSplit turns staff-entered amounts into parts. Whatever the
+// amounts leave uncovered becomes a final "Remaining balance" part.
+func (o *Order) PlanCustomSplit(amounts []money.Money) ([]BillPart, error) {
+	outstanding, err := o.splittable()
+	if err != nil {
+		return nil, err
+	}
+	offset := len(o.PaidParts())
+	var parts []BillPart
+	left := outstanding
+	for i, a := range amounts {
+		if !a.IsPositive() {
+			return nil, ErrSplitMismatch
+		}
+		if left, err = left.Sub(a); err != nil {
+			return nil, err
+		}
+		if left.Amount < 0 {
+			return nil, ErrSplitMismatch
+		}
+		parts = append(parts, BillPart{Label: fmt.Sprintf("Guest %d", offset+i+1), Amount: a.Amount})
+	}
+	if left.IsPositive() {
+		parts = append(parts, BillPart{Label: "Remaining balance", Amount: left.Amount})
+	}
+	return parts, nil
+}
+
+// Split replaces the unpaid parts of the bill with parts, which must cover
+// the outstanding balance exactly. Settled parts are kept, so a partly paid
+// bill can be split again. Every part needs an ID.
+func (o *Order) Split(parts []BillPart) error {
+	outstanding, err := o.splittable()
+	if err != nil {
+		return err
+	}
+	paid := o.PaidParts()
+	if len(paid)+len(parts) < 2 {
+		return ErrInvalidSplit
+	}
+	var sum int64
+	for _, p := range parts {
+		if p.ID == "" || p.Amount <= 0 || p.IsPaid() {
+			return ErrSplitMismatch
+		}
+		sum += p.Amount
+	}
+	if sum != outstanding.Amount {
+		return ErrSplitMismatch
+	}
+	o.BillParts = append(paid, parts...)
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
+// SettlePart records the payment that paid a part, with the rate it was
+// converted at (zero when paid in the order currency). Once the settled
+// parts cover the total the order is marked paid and covered is true; until
+// then it is partially paid. Settling a part twice is a no-op.
+func (o *Order) SettlePart(id common.BillPartID, paymentID common.PaymentID, method common.PaymentMethodType, rate money.ExchangeRate, at time.Time) (covered bool, err error) {
+	if o.IsCancelled() {
+		return false, ErrOrderCancelled
+	}
+	idx := -1
+	for i := range o.BillParts {
+		if o.BillParts[i].ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return false, ErrBillPartNotFound
+	}
+	if o.BillParts[idx].IsPaid() {
+		return o.PaymentStatus == common.PaymentStatusPaid, nil
+	}
+	o.BillParts[idx].PaymentID = paymentID
+	o.BillParts[idx].Method = method
+	o.BillParts[idx].PaidAt = &at
+	if o.acceptsRate(rate) {
+		o.BillParts[idx].FXRate = rate
+	}
+	if o.Outstanding().IsZero() {
+		o.MarkPaid()
+		return true, nil
+	}
+	o.PaymentStatus = common.PaymentStatusPartiallyPaid
+	o.UpdatedAt = at
+	return false, nil
+}
+
+// splittable returns the outstanding balance, or why the bill cannot be
+// split right now.
+func (o *Order) splittable() (money.Money, error) {
+	if o.IsCancelled() {
+		return money.Money{}, ErrOrderCancelled
+	}
+	outstanding := o.Outstanding()
+	if !outstanding.IsPositive() {
+		return money.Money{}, ErrNothingOutstanding
+	}
+	return outstanding, nil
+}
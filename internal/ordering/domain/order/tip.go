@@ -0,0 +1,47 @@
+package order
+
+import (
+	"errors"
+	"time"
+
+	"bitmerchant/internal/common"
+)
+
+var (
+	ErrTipNotOpen = errors.New("tips can be added once the order is paid and served")
+	ErrInvalidTip = errors.New("tip must be greater than 0")
+)
+
+// AcceptsLateTip reports whether the guest can still add a tip: the order is
+// paid, has been served (ready or completed), was not cancelled, and has no
+// late tip yet. One late tip per order keeps the prompt from coming back.
+func (o *Order) AcceptsLateTip() bool {
+	if o.IsCancelled() || o.PaymentStatus != common.PaymentStatusPaid || o.LateTipAmount > 0 {
+		return false
+	}
+	return o.FulfillmentStatus == common.FulfillmentStatusReady || o.FulfillmentStatus == common.FulfillmentStatusCompleted
+}
+
+// HasLateTip reports whether the late tip settled by paymentID is already on
+// the order.
+func (o *Order) HasLateTip(paymentID common.PaymentID) bool {
+	return paymentID != "" && o.LateTipPaymentID == paymentID
+}
+
+// AddTip records a tip the guest paid after the order was settled. The tip
+// was taken as paymentID, a payment of its own, so TipAmount and TotalAmount
+// grow by amount and LateTipAmount keeps the part that came late.
+func (o *Order) AddTip(paymentID common.PaymentID, amount int64) error {
+	if amount <= 0 {
+		return ErrInvalidTip
+	}
+	if !o.AcceptsLateTip() {
+		return ErrTipNotOpen
+	}
+	o.TipAmount += amount
+	o.LateTipAmount += amount
+	o.LateTipPaymentID = paymentID
+	o.TotalAmount += amount
+	o.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	orderCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/domain/order"
+
+	"github.com/labstack/echo/v4"
+)
+
+// handleCancel runs CancelOrder for the :id order using the reason and note
+// form values. Kitchen and FOH share it; both get an empty 200 on success
+// and let the order.cancelled broadcast remove the card.
+func handleCancel(c echo.Context, cancelUC orderCmd.CancelOrderHandler) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	cmd, err := cancelOrderFromForm(c, common.OrderID(c.Param("id")), restaurantID)
+	if err != nil {
+		return c.String(http.StatusUnprocessableEntity, err.Error())
+	}
+	if _, err := cancelUC.Handle(c.Request().Context(), cmd); err != nil {
+		return c.String(cancelErrorStatus(err), err.Error())
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// cancelOrderFromForm builds a CancelOrder from the "reason" and "note"
+// form values, attributing it to the signed-in staff member.
+func cancelOrderFromForm(c echo.Context, orderID common.OrderID, restaurantID common.RestaurantID) (orderCmd.CancelOrder, error) {
+	reason, err := order.ParseCancelReason(c.FormValue("reason"))
+	if err != nil {
+		return orderCmd.CancelOrder{}, err
+	}
+	cmd := orderCmd.CancelOrder{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+		Reason:       reason,
+		Note:         strings.TrimSpace(c.FormValue("note")),
+	}
+	if u, ok := commonhttp.GetAuthenticatedUser(c); ok && u != nil {
+		cmd.CancelledBy = u.ID
+	}
+	return cmd, nil
+}
+
+// cancelErrorStatus maps cancellation failures to HTTP status codes.
+func cancelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrInvalidCancelReason),
+		errors.Is(err, order.ErrCancelNoteRequired),
+		errors.Is(err, order.ErrCancellerRequired):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, order.ErrCancelNotPermitted):
+		return http.StatusConflict
+	case err.Error() == "order not found":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
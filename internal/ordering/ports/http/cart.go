@@ -3,6 +3,7 @@ package http
 import (
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/tax"
 
 	"bitmerchant/internal/interfaces/templates"
 	"bitmerchant/internal/interfaces/templates/components"
@@ -24,24 +25,43 @@ import (
 type CartHandler struct {
 	cartService    *cart.CartService
 	itemRepo       menu.ItemRepository
+	categoryRepo   menu.CategoryRepository
 	photos         menu.PhotoStorage
 	photoSignerCfg menuQuery.PhotoSignerConfig
 }
 
 // NewCartHandler creates a new CartHandler.
 //
-// photos and photoSignerCfg may be zero values; when photos is nil, item-detail
+// categoryRepo supplies the tax class of items that have none of their own;
+// when nil such items are taxed as standard. photos and photoSignerCfg may be
+// zero values; when photos is nil, item-detail
 // renders fall back to the raw PhotoURL stored on the item (e.g. local dev
 // without S3).
-func NewCartHandler(cartService *cart.CartService, itemRepo menu.ItemRepository, photos menu.PhotoStorage, photoSignerCfg menuQuery.PhotoSignerConfig) *CartHandler {
+func NewCartHandler(cartService *cart.CartService, itemRepo menu.ItemRepository, categoryRepo menu.CategoryRepository, photos menu.PhotoStorage, photoSignerCfg menuQuery.PhotoSignerConfig) *CartHandler {
 	return &CartHandler{
 		cartService:    cartService,
 		itemRepo:       itemRepo,
+		categoryRepo:   categoryRepo,
 		photos:         photos,
 		photoSignerCfg: photoSignerCfg,
 	}
 }
 
+// withTaxClass returns item carrying its effective tax class, inheriting the
+// category's when the item has none. The repository's item is not modified.
+func (h *CartHandler) withTaxClass(item *menu.MenuItem) *menu.MenuItem {
+	if item.TaxClass != tax.ClassStandard || h.categoryRepo == nil {
+		return item
+	}
+	cat, err := h.categoryRepo.FindByID(item.CategoryID)
+	if err != nil {
+		return item
+	}
+	resolved := *item
+	resolved.TaxClass = item.EffectiveTaxClass(cat)
+	return &resolved
+}
+
 // writeCartSSE writes a Datastar SSE response with updated cart fragments + per-item qty signals.
 // zeroedIDs are item IDs that were just removed; they are emitted with qty=0 so the menu CTA resets.
 func (h *CartHandler) writeCartSSE(c echo.Context, updatedCart *cart.Cart, zeroedIDs ...common.ItemID) error {
@@ -131,7 +151,7 @@ func (h *CartHandler) AddToCartAndRedirect(c echo.Context) error {
 
 	modifiers := parseModifiers(c, item)
 
-	if err := h.cartService.AddItemWithModifiers(sessionID, item, quantity, modifiers, specialInstructions); err != nil {
+	if err := h.cartService.AddItemWithModifiers(sessionID, h.withTaxClass(item), quantity, modifiers, specialInstructions); err != nil {
 		return c.String(http.StatusInternalServerError, err.Error())
 	}
 
@@ -168,7 +188,7 @@ func (h *CartHandler) AddToCart(c echo.Context) error {
 
 	modifiers := parseModifiers(c, item)
 
-	if err := h.cartService.AddItemWithModifiers(sessionID, item, quantity, modifiers, specialInstructions); err != nil {
+	if err := h.cartService.AddItemWithModifiers(sessionID, h.withTaxClass(item), quantity, modifiers, specialInstructions); err != nil {
 		return c.String(http.StatusInternalServerError, err.Error())
 	}
 
@@ -188,7 +208,7 @@ func parseModifiers(c echo.Context, item *menu.MenuItem) []cart.CartItemModifier
 	type optKey struct{ groupID, optionID string }
 	type optInfo struct {
 		groupName, optName string
-		delta              float64
+		delta              int64
 	}
 	byOpt := map[optKey]optInfo{}
 	for _, g := range item.OptionGroups {
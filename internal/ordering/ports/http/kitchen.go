@@ -1,6 +1,9 @@
 package http
 
 import (
+	"bytes"
+	"context"
+
 	"bitmerchant/internal/auth/domain/membership"
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
@@ -9,6 +12,7 @@ import (
 	"bitmerchant/internal/interfaces/templates/components"
 	orderCmd "bitmerchant/internal/ordering/app/command"
 	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 
 	"github.com/labstack/echo/v4"
@@ -22,9 +26,11 @@ type KitchenHandler struct {
 	markReadyUC      orderCmd.MarkOrderReadyHandler
 	markCompletedUC  orderCmd.MarkOrderCompletedHandler
 	toggleItemPrepUC orderCmd.ToggleOrderItemPrepHandler
+	cancelUC         orderCmd.CancelOrderHandler
 	restaurantRepo   restaurant.Repository
 	membershipRepo   membership.Repository
 	vapidPublicKey   string
+	sse              *commonhttp.SSEHandler
 }
 
 func NewKitchenHandler(
@@ -34,9 +40,11 @@ func NewKitchenHandler(
 	markReadyUC orderCmd.MarkOrderReadyHandler,
 	markCompletedUC orderCmd.MarkOrderCompletedHandler,
 	toggleItemPrepUC orderCmd.ToggleOrderItemPrepHandler,
+	cancelUC orderCmd.CancelOrderHandler,
 	restaurantRepo restaurant.Repository,
 	membershipRepo membership.Repository,
 	vapidPublicKey string,
+	sse *commonhttp.SSEHandler,
 ) *KitchenHandler {
 	return &KitchenHandler{
 		getOrdersUC:      getOrdersUC,
@@ -45,9 +53,11 @@ func NewKitchenHandler(
 		markReadyUC:      markReadyUC,
 		markCompletedUC:  markCompletedUC,
 		toggleItemPrepUC: toggleItemPrepUC,
+		cancelUC:         cancelUC,
 		restaurantRepo:   restaurantRepo,
 		membershipRepo:   membershipRepo,
 		vapidPublicKey:   vapidPublicKey,
+		sse:              sse,
 	}
 }
 
@@ -77,6 +87,40 @@ func (h *KitchenHandler) GetKitchen(c echo.Context) error {
 	return templates.KitchenPage(orders, commonhttp.CSRFToken(c), label, dn, st, ini, switchOpts, activeRole, canCreate, h.vapidPublicKey, warningMinutes, overdueMinutes).Render(c.Request().Context(), c.Response())
 }
 
+// Stream handles GET /kitchen/stream for the active restaurant. A tablet
+// that can't be caught up is resynced with the whole board.
+func (h *KitchenHandler) Stream(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	return h.sse.Stream(c, commonhttp.KitchenTopic(restaurantID), func(ctx context.Context) ([]byte, error) {
+		orders, err := h.getOrdersUC.Handle(ctx, orderQuery.ActiveKitchenOrders{RestaurantID: restaurantID})
+		if err != nil {
+			return nil, err
+		}
+		return kitchenSnapshot(ctx, orders)
+	})
+}
+
+// kitchenSnapshot morphs every lane to hold exactly the active orders and
+// empties the staging list.
+func kitchenSnapshot(ctx context.Context, orders []*order.Order) ([]byte, error) {
+	var patch []byte
+	for _, key := range templates.KitchenLaneKeys {
+		var buf bytes.Buffer
+		if err := templates.KitchenLane(key, orders).Render(ctx, &buf); err != nil {
+			return nil, err
+		}
+		patch = append(patch, commonhttp.FormatDatastarPatch(buf.String(), "#lane-"+key, "outer")...)
+	}
+	var buf bytes.Buffer
+	if err := templates.KitchenOrdersList(nil).Render(ctx, &buf); err != nil {
+		return nil, err
+	}
+	return append(patch, commonhttp.FormatDatastarPatch(buf.String(), "#orders-list", "outer")...), nil
+}
+
 func (h *KitchenHandler) MarkPreparing(c echo.Context) error {
 	id := c.Param("id")
 	order, err := h.markPreparingUC.Handle(c.Request().Context(), orderCmd.MarkOrderPreparing{OrderID: common.OrderID(id)})
@@ -116,3 +160,8 @@ func (h *KitchenHandler) ToggleItemPrep(c echo.Context) error {
 	}
 	return components.OrderCard(order).Render(c.Request().Context(), c.Response())
 }
+
+// CancelOrder handles POST /kitchen/order/:id/cancel.
+func (h *KitchenHandler) CancelOrder(c echo.Context) error {
+	return handleCancel(c, h.cancelUC)
+}
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/qr"
+	"bitmerchant/internal/interfaces/templates"
+	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
+	payCmd "bitmerchant/internal/payment/app/command"
+	"bitmerchant/internal/payment/domain/payment"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LightningPayHandler serves the customer-facing invoice page for orders
+// placed with the Lightning payment method, and for tips added after any
+// order is paid.
+type LightningPayHandler struct {
+	getCustomerOrderByLookup orderQuery.CustomerOrderByLookupHandler
+	requestInvoice           payCmd.RequestLightningInvoiceHandler
+	lateTips                 orderQuery.LateTipLookup
+	qrService                *qr.QRCodeService
+}
+
+// NewLightningPayHandler creates a new LightningPayHandler. lateTips may be
+// nil, which turns tips after payment away.
+func NewLightningPayHandler(
+	getCustomerOrderByLookup orderQuery.CustomerOrderByLookupHandler,
+	requestInvoice payCmd.RequestLightningInvoiceHandler,
+	lateTips orderQuery.LateTipLookup,
+	qrService *qr.QRCodeService,
+) *LightningPayHandler {
+	return &LightningPayHandler{
+		getCustomerOrderByLookup: getCustomerOrderByLookup,
+		requestInvoice:           requestInvoice,
+		lateTips:                 lateTips,
+		qrService:                qrService,
+	}
+}
+
+// GetPay handles GET /order/:orderNumber/pay. Paid or cancelled orders (and
+// orders not placed with Lightning) go straight back to the status page. A
+// split bill is paid one part at a time, picked with ?part=. ?tip= pays a
+// tip, in major units, on a paid and served order instead.
+func (h *LightningPayHandler) GetPay(c echo.Context) error {
+	o, p, err := h.resolveInvoice(c)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return c.Redirect(http.StatusFound, "/order/"+string(o.OrderNumber))
+	}
+	var tipAmount money.Money
+	if p.IsTip() {
+		tipAmount, _ = h.parseTip(c, o)
+	}
+	return templates.OrderLightningPayPage(o, p, common.BillPartID(c.QueryParam("part")), tipAmount).Render(c.Request().Context(), c.Response())
+}
+
+// GetPayQR handles GET /order/:orderNumber/pay/qr.png — the invoice as a
+// lightning: URI so wallet camera scanners open it directly.
+func (h *LightningPayHandler) GetPayQR(c echo.Context) error {
+	_, p, err := h.resolveInvoice(c)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	png, qerr := h.qrService.GeneratePNG("lightning:"+p.Invoice, 320)
+	if qerr != nil {
+		return c.String(http.StatusInternalServerError, qerr.Error())
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.Blob(http.StatusOK, "image/png", png)
+}
+
+// resolveInvoice loads the session's order and its current invoice. The
+// payment is nil when there is nothing left to pay, or when the bill is split
+// and the requested part is unknown or already paid.
+func (h *LightningPayHandler) resolveInvoice(c echo.Context) (*order.Order, *payment.Payment, error) {
+	orderNumber := c.Param("orderNumber")
+	if orderNumber == "" {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "Order number required")
+	}
+	sessionID, _ := c.Get("sessionID").(string)
+	restaurantID, _ := commonhttp.RestaurantIDFromContext(c)
+	o, err := h.getCustomerOrderByLookup.Handle(c.Request().Context(), orderQuery.CustomerOrderByLookup{
+		SessionID:    sessionID,
+		OrderNumber:  orderNumber,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		if err.Error() == "order not found" {
+			return nil, nil, echo.NewHTTPError(http.StatusNotFound, "Order not found")
+		}
+		return nil, nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if c.QueryParam("tip") != "" {
+		return h.resolveTipInvoice(c, o)
+	}
+	if o.PaymentMethod != common.PaymentMethodTypeLightning || o.PaymentStatus == common.PaymentStatusPaid || o.IsCancelled() {
+		return o, nil, nil
+	}
+
+	req := payCmd.RequestLightningInvoice{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		Amount:       o.Total(),
+		Memo:         "Order #" + string(o.OrderNumber),
+	}
+	if o.IsSplit() {
+		part, ok := o.Part(common.BillPartID(c.QueryParam("part")))
+		if !ok || part.IsPaid() {
+			return o, nil, nil
+		}
+		req.BillPartID = part.ID
+		req.Amount = o.PartAmount(part)
+		req.Memo += " · " + part.Label
+	}
+
+	p, err := h.requestInvoice.Handle(c.Request().Context(), req)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadGateway, "Could not create Lightning invoice: "+err.Error())
+	}
+	if p.Status == common.PaymentStatusPaid {
+		return o, nil, nil
+	}
+	return o, p, nil
+}
+
+// resolveTipInvoice returns the invoice for the tip in ?tip=. It is nil once
+// the order takes no more tips, which includes after this one is paid.
+func (h *LightningPayHandler) resolveTipInvoice(c echo.Context, o *order.Order) (*order.Order, *payment.Payment, error) {
+	if h.lateTips == nil || !o.AcceptsLateTip() {
+		return o, nil, nil
+	}
+	amount, err := h.parseTip(c, o)
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	p, err := h.requestInvoice.Handle(c.Request().Context(), payCmd.RequestLightningInvoice{
+		OrderID:      o.ID,
+		RestaurantID: o.RestaurantID,
+		Amount:       amount,
+		Memo:         "Tip · Order #" + string(o.OrderNumber),
+		Tip:          true,
+	})
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadGateway, "Could not create Lightning invoice: "+err.Error())
+	}
+	if p.Status == common.PaymentStatusPaid {
+		return o, nil, nil
+	}
+	return o, p, nil
+}
+
+// parseTip reads ?tip= in major units of the order currency and checks it
+// against what the restaurant offers.
+func (h *LightningPayHandler) parseTip(c echo.Context, o *order.Order) (money.Money, error) {
+	amount, err := money.ParseMajor(c.QueryParam("tip"), o.Total().Currency)
+	if err != nil {
+		return money.Money{}, errors.New("enter your tip as an amount, such as 2.50")
+	}
+	if err := h.lateTips(o.RestaurantID).CheckAmount(amount.Amount, o.Subtotal); err != nil {
+		return money.Money{}, err
+	}
+	return amount, nil
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tip"
 
 	"bitmerchant/internal/interfaces/templates"
 	"bitmerchant/internal/ordering/app/cart"
@@ -12,7 +14,11 @@ import (
 	orderQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
 	"bitmerchant/internal/restaurant/domain/restaurant"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -30,6 +36,15 @@ type OrderHandler struct {
 	restRepo                 restaurant.Repository
 	cartService              *cart.CartService
 	vapidPublicKey           string
+	// lightningEnabled is true when a platform Lightning node is configured;
+	// restaurants with their own Lightning address offer it regardless.
+	lightningEnabled bool
+	// converter quotes checkout totals in sats; nil hides the sats hint.
+	converter money.Converter
+	// priceDiscount prices promotions on the confirm page; nil hides the
+	// promo code form.
+	priceDiscount orderCmd.DiscountPricer
+	sse           *commonhttp.SSEHandler
 }
 
 // NewOrderHandler creates a new OrderHandler
@@ -43,6 +58,10 @@ func NewOrderHandler(
 	restRepo restaurant.Repository,
 	cartService *cart.CartService,
 	vapidPublicKey string,
+	lightningEnabled bool,
+	converter money.Converter,
+	priceDiscount orderCmd.DiscountPricer,
+	sse *commonhttp.SSEHandler,
 ) *OrderHandler {
 	return &OrderHandler{
 		createOrder:              createOrder,
@@ -54,6 +73,10 @@ func NewOrderHandler(
 		restRepo:                 restRepo,
 		cartService:              cartService,
 		vapidPublicKey:           vapidPublicKey,
+		lightningEnabled:         lightningEnabled,
+		converter:                converter,
+		priceDiscount:            priceDiscount,
+		sse:                      sse,
 	}
 }
 
@@ -78,13 +101,74 @@ func (h *OrderHandler) GetConfirmOrder(c echo.Context) error {
 		string(cart.RestaurantID),
 		rest.Name,
 		tableLabel,
-		rest.TaxRate,
+		rest.EffectiveTaxSettings(),
+		rest.EffectiveTipSettings(),
+		rest.CashRounding,
 		orderQuery.DefaultPrepTarget,
 		commonhttp.CSRFToken(c),
 		"",
+		h.lightningAvailable(rest),
+		money.SatsQuoter(c.Request().Context(), h.converter),
+		h.confirmPromo(c, sessionID, cart),
 	).Render(c.Request().Context(), c.Response())
 }
 
+// PostPromoCode handles POST /order/confirm/promo. The code is checked
+// against the cart before it is stored, so a code that does not apply is
+// explained on the confirm page; action=remove clears the current code.
+func (h *OrderHandler) PostPromoCode(c echo.Context) error {
+	sessionID := c.Get("sessionID").(string)
+	currentCart := h.cartService.GetCart(sessionID)
+	if len(currentCart.Items) == 0 || currentCart.RestaurantID == "" {
+		return c.Redirect(http.StatusFound, "/menu")
+	}
+
+	code := strings.TrimSpace(c.FormValue("promoCode"))
+	if c.FormValue("action") == "remove" {
+		code = ""
+	}
+	if code != "" && h.priceDiscount != nil {
+		trial := *currentCart
+		trial.PromoCode = strings.ToUpper(code)
+		if _, err := h.priceDiscount.PriceDiscount(c.Request().Context(), sessionID, &trial); err != nil {
+			return h.rerenderConfirmWithError(c, &trial, currentCart.RestaurantID, "")
+		}
+	}
+	h.cartService.SetPromoCode(sessionID, code)
+
+	href := "/order/confirm"
+	if table := strings.TrimSpace(c.FormValue("table")); table != "" {
+		href += "?table=" + url.QueryEscape(table)
+	}
+	return c.Redirect(http.StatusSeeOther, href)
+}
+
+// confirmPromo prices the cart's promotion for the confirm page. A code that
+// no longer applies is shown with its reason rather than failing the page.
+func (h *OrderHandler) confirmPromo(c echo.Context, sessionID string, currentCart *cart.Cart) templates.ConfirmPromo {
+	promo := templates.ConfirmPromo{Enabled: h.priceDiscount != nil, Code: currentCart.PromoCode}
+	if h.priceDiscount == nil {
+		return promo
+	}
+	d, err := h.priceDiscount.PriceDiscount(c.Request().Context(), sessionID, currentCart)
+	if err != nil {
+		promo.Error = promotionErrorMessage(err)
+		return promo
+	}
+	promo.Discount = d
+	return promo
+}
+
+// promotionErrorMessage shows a rejection's reason; lookup failures get a
+// generic message so internals never reach the customer.
+func promotionErrorMessage(err error) string {
+	var rejected *orderCmd.PromotionRejectedError
+	if errors.As(err, &rejected) {
+		return rejected.Reason
+	}
+	return "We could not check this promo code right now. Please try again."
+}
+
 // CreateOrder handles POST /order/create
 func (h *OrderHandler) CreateOrder(c echo.Context) error {
 	sessionID := c.Get("sessionID").(string)
@@ -94,18 +178,31 @@ func (h *OrderHandler) CreateOrder(c echo.Context) error {
 		return c.Redirect(http.StatusFound, "/menu")
 	}
 
-	req, ferr := parseCreateOrderForm(c, currentCart, sessionID)
+	lightningAvailable := false
+	tips := tip.Standard()
+	if rest, err := h.restRepo.FindByID(currentCart.RestaurantID); err == nil {
+		lightningAvailable = h.lightningAvailable(rest)
+		tips = rest.EffectiveTipSettings()
+	}
+	req, ferr := parseCreateOrderForm(c, currentCart, sessionID, lightningAvailable, tips)
 	if ferr != nil {
 		return h.handleCreateOrderFormError(c, currentCart, ferr)
 	}
 
 	resp, err := h.createOrder.Handle(c.Request().Context(), *req)
+	var rejected *orderCmd.PromotionRejectedError
+	if errors.As(err, &rejected) {
+		return h.rerenderConfirmWithError(c, currentCart, req.RestaurantID, rejected.Reason)
+	}
 	if err != nil {
 		return c.String(http.StatusInternalServerError, "Failed to create order: "+err.Error())
 	}
 
 	h.cartService.ClearCart(sessionID)
 
+	if req.PaymentMethod == common.PaymentMethodTypeLightning {
+		return c.Redirect(http.StatusFound, "/order/"+string(resp.OrderNumber)+"/pay")
+	}
 	return c.Redirect(http.StatusFound, "/order/"+string(resp.OrderNumber))
 }
 
@@ -123,7 +220,7 @@ func (e *createOrderFormError) Error() string { return e.publicMessage }
 // parseCreateOrderForm validates the confirm-page form and returns the command
 // payload. Returns a structured *createOrderFormError so the handler can decide
 // between rendering an inline page error and a plain status response.
-func parseCreateOrderForm(c echo.Context, currentCart *cart.Cart, sessionID string) (*orderCmd.CreateOrder, *createOrderFormError) {
+func parseCreateOrderForm(c echo.Context, currentCart *cart.Cart, sessionID string, lightningEnabled bool, tips tip.Settings) (*orderCmd.CreateOrder, *createOrderFormError) {
 	restaurantID := common.RestaurantID(c.FormValue("restaurantID"))
 	if restaurantID == "" || currentCart.RestaurantID != restaurantID {
 		return nil, &createOrderFormError{httpStatus: http.StatusBadRequest, publicMessage: "Invalid restaurant for this order"}
@@ -134,12 +231,27 @@ func parseCreateOrderForm(c echo.Context, currentCart *cart.Cart, sessionID stri
 		return nil, &createOrderFormError{httpStatus: http.StatusBadRequest, publicMessage: "Name for pickup is required.", rerender: true, restaurantID: restaurantID}
 	}
 
-	tipPercent, terr := parseTipPercent(c.FormValue("tipPercent"))
+	var customTip int64
+	rawTip := c.FormValue("tipPercent")
+	if rawTip == "custom" {
+		amount, err := parseCustomTip(c.FormValue("customTip"), currentCart, tips)
+		if err != nil {
+			return nil, &createOrderFormError{httpStatus: http.StatusBadRequest, publicMessage: err.Error(), rerender: true, restaurantID: restaurantID}
+		}
+		customTip = amount
+		rawTip = "0"
+	}
+	tipPercent, terr := parseTipPercent(rawTip, tips)
 	if terr != nil {
 		return nil, &createOrderFormError{httpStatus: http.StatusBadRequest, publicMessage: terr.Error()}
 	}
 
-	if v := c.FormValue("paymentMethod"); v != "" && v != "cash" {
+	paymentMethod := common.PaymentMethodTypeCash
+	switch v := c.FormValue("paymentMethod"); {
+	case v == "" || v == "cash":
+	case v == "lightning" && lightningEnabled:
+		paymentMethod = common.PaymentMethodTypeLightning
+	default:
 		return nil, &createOrderFormError{httpStatus: http.StatusBadRequest, publicMessage: "Unsupported payment method"}
 	}
 
@@ -147,24 +259,49 @@ func parseCreateOrderForm(c echo.Context, currentCart *cart.Cart, sessionID stri
 		RestaurantID:  restaurantID,
 		SessionID:     sessionID,
 		Cart:          currentCart,
-		PaymentMethod: common.PaymentMethodTypeCash,
+		PaymentMethod: paymentMethod,
 		CustomerName:  customerName,
 		TableLabel:    strings.TrimSpace(c.FormValue("table")),
 		TipPercent:    tipPercent,
+		CustomTip:     customTip,
 	}, nil
 }
 
-func parseTipPercent(raw string) (int, error) {
+// lightningAvailable reports whether checkout can offer Lightning for rest.
+func (h *OrderHandler) lightningAvailable(rest *restaurant.Restaurant) bool {
+	return h.lightningEnabled || (rest != nil && rest.LightningAddress != "")
+}
+
+// parseTipPercent reads the chosen tip chip; blank picks the restaurant's
+// default.
+func parseTipPercent(raw string, tips tip.Settings) (int, error) {
 	if raw == "" {
-		return cart.DefaultTipPercent, nil
+		return tips.Default, nil
 	}
 	n, err := strconv.Atoi(raw)
-	if err != nil || !cart.IsAllowedTipPercent(n) {
+	if err != nil || !tips.Allows(n) {
 		return 0, fmt.Errorf("invalid tip percent")
 	}
 	return n, nil
 }
 
+// parseCustomTip reads a tip the guest typed in major units of the cart's
+// currency.
+func parseCustomTip(raw string, currentCart *cart.Cart, tips tip.Settings) (int64, error) {
+	cur := currentCart.Currency
+	if cur.IsZero() {
+		cur = money.USD
+	}
+	m, err := money.ParseMajor(raw, cur)
+	if err != nil {
+		return 0, errors.New("enter your tip as an amount, such as 2.50")
+	}
+	if err := tips.CheckCustom(m.Amount, currentCart.Total); err != nil {
+		return 0, err
+	}
+	return m.Amount, nil
+}
+
 func (h *OrderHandler) handleCreateOrderFormError(c echo.Context, currentCart *cart.Cart, ferr *createOrderFormError) error {
 	if ferr.rerender {
 		return h.rerenderConfirmWithError(c, currentCart, ferr.restaurantID, ferr.publicMessage)
@@ -179,16 +316,23 @@ func (h *OrderHandler) rerenderConfirmWithError(c echo.Context, currentCart *car
 		return c.String(http.StatusBadRequest, errMsg)
 	}
 	tableLabel := strings.TrimSpace(c.FormValue("table"))
+	sessionID, _ := c.Get("sessionID").(string)
+	promo := h.confirmPromo(c, sessionID, currentCart)
 	c.Response().WriteHeader(http.StatusBadRequest)
 	return templates.OrderConfirmationPage(
 		currentCart,
 		string(restaurantID),
 		rest.Name,
 		tableLabel,
-		rest.TaxRate,
+		rest.EffectiveTaxSettings(),
+		rest.EffectiveTipSettings(),
+		rest.CashRounding,
 		orderQuery.DefaultPrepTarget,
 		commonhttp.CSRFToken(c),
 		errMsg,
+		h.lightningAvailable(rest),
+		money.SatsQuoter(c.Request().Context(), h.converter),
+		promo,
 	).Render(c.Request().Context(), c.Response())
 }
 
@@ -200,9 +344,11 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 	}
 
 	sessionID, _ := c.Get("sessionID").(string)
+	restaurantID, _ := commonhttp.RestaurantIDFromContext(c)
 	result, cerr := h.getCustomerOrderByLookup.Handle(c.Request().Context(), orderQuery.CustomerOrderByLookup{
-		SessionID:   sessionID,
-		OrderNumber: orderNumber,
+		SessionID:    sessionID,
+		OrderNumber:  orderNumber,
+		RestaurantID: restaurantID,
 	})
 	if cerr != nil {
 		if cerr.Error() == "order not found" {
@@ -215,10 +361,40 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 	if verr != nil {
 		return c.String(http.StatusInternalServerError, verr.Error())
 	}
+	if h.restRepo != nil {
+		view.OfferLateTip(orderQuery.NewLateTipLookup(h.restRepo, h.lightningEnabled))
+	}
 
 	return templates.OrderStatusPage(view, h.vapidPublicKey).Render(c.Request().Context(), c.Response())
 }
 
+// StatusStream handles GET /order/:orderNumber/stream, the live status page
+// of the session's own order.
+func (h *OrderHandler) StatusStream(c echo.Context) error {
+	o, err := h.resolveCustomerOrder(c)
+	if err != nil {
+		return err
+	}
+	return h.sse.Stream(c, commonhttp.OrderTopic(o.RestaurantID, o.ID), func(ctx context.Context) ([]byte, error) {
+		current, err := h.orderRepo.FindByID(o.ID)
+		if err != nil {
+			return nil, err
+		}
+		view, err := orderQuery.BuildOrderStatusView(h.orderRepo, current, orderQuery.DefaultPrepTarget)
+		if err != nil {
+			return nil, err
+		}
+		if h.restRepo != nil {
+			view.OfferLateTip(orderQuery.NewLateTipLookup(h.restRepo, h.lightningEnabled))
+		}
+		var buf bytes.Buffer
+		if err := templates.OrderStatus(view).Render(ctx, &buf); err != nil {
+			return nil, err
+		}
+		return commonhttp.FormatDatastarEvent(buf.String()), nil
+	})
+}
+
 // resolveCustomerOrder loads the order for the requesting session by order number.
 // Scoping by sessionID ensures only the customer who placed the order can act on it.
 func (h *OrderHandler) resolveCustomerOrder(c echo.Context) (*order.Order, error) {
@@ -227,9 +403,11 @@ func (h *OrderHandler) resolveCustomerOrder(c echo.Context) (*order.Order, error
 		return nil, echo.NewHTTPError(http.StatusBadRequest, "Order number required")
 	}
 	sessionID, _ := c.Get("sessionID").(string)
+	restaurantID, _ := commonhttp.RestaurantIDFromContext(c)
 	result, err := h.getCustomerOrderByLookup.Handle(c.Request().Context(), orderQuery.CustomerOrderByLookup{
-		SessionID:   sessionID,
-		OrderNumber: orderNumber,
+		SessionID:    sessionID,
+		OrderNumber:  orderNumber,
+		RestaurantID: restaurantID,
 	})
 	if err != nil {
 		if err.Error() == "order not found" {
@@ -1,40 +1,59 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"net/http"
+	"strings"
 
 	"bitmerchant/internal/auth/domain/membership"
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/interfaces/templates"
 	orderCmd "bitmerchant/internal/ordering/app/command"
 	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
+	"bitmerchant/internal/payment/domain/payment"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 
 	"github.com/labstack/echo/v4"
 )
 
 // ServerHandler renders the front-of-house tablet view (unpaid orders) and
-// owns the Mark Paid transition. Cooks are intentionally not authorized for
+// owns the Mark Paid, split bill and void transitions. Cooks are intentionally not authorized for
 // this surface.
 type ServerHandler struct {
 	getUnpaidUC    orderQuery.UnpaidServerOrdersHandler
 	markPaidUC     orderCmd.MarkOrderPaidHandler
+	cancelUC       orderCmd.CancelOrderHandler
+	splitUC        orderCmd.SplitBillHandler
+	payPartUC      orderCmd.PayBillPartHandler
 	restaurantRepo restaurant.Repository
 	membershipRepo membership.Repository
+	sse            *commonhttp.SSEHandler
 }
 
 func NewServerHandler(
 	getUnpaidUC orderQuery.UnpaidServerOrdersHandler,
 	markPaidUC orderCmd.MarkOrderPaidHandler,
+	cancelUC orderCmd.CancelOrderHandler,
+	splitUC orderCmd.SplitBillHandler,
+	payPartUC orderCmd.PayBillPartHandler,
 	restaurantRepo restaurant.Repository,
 	membershipRepo membership.Repository,
+	sse *commonhttp.SSEHandler,
 ) *ServerHandler {
 	return &ServerHandler{
 		getUnpaidUC:    getUnpaidUC,
 		markPaidUC:     markPaidUC,
+		cancelUC:       cancelUC,
+		splitUC:        splitUC,
+		payPartUC:      payPartUC,
 		restaurantRepo: restaurantRepo,
 		membershipRepo: membershipRepo,
+		sse:            sse,
 	}
 }
 
@@ -56,12 +75,91 @@ func (h *ServerHandler) GetServer(c echo.Context) error {
 	return templates.ServerPage(orders, commonhttp.CSRFToken(c), label, dn, st, ini, switchOpts, activeRole, canCreate).Render(c.Request().Context(), c.Response())
 }
 
-// MarkPaid handles POST /server/order/:id/mark-paid. Returns an empty 200 — the
-// SSE broadcast removes the card from the FOH view.
+// Stream handles GET /server/stream for the active restaurant. A tablet that
+// can't be caught up is resynced with the whole ticket list.
+func (h *ServerHandler) Stream(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	return h.sse.Stream(c, commonhttp.ServerTopic(restaurantID), func(ctx context.Context) ([]byte, error) {
+		orders, err := h.getUnpaidUC.Handle(ctx, orderQuery.UnpaidServerOrders{RestaurantID: restaurantID})
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := templates.ServerOrderList(orders).Render(ctx, &buf); err != nil {
+			return nil, err
+		}
+		return commonhttp.FormatDatastarPatch(buf.String(), "#server-orders", "outer"), nil
+	})
+}
+
+// MarkPaid handles POST /server/order/:id/mark-paid. The optional "method"
+// form value is the tender staff took, cash when blank; "tendered" is the
+// cash handed over, blank for the exact total. Returns an empty 200 — the SSE
+// broadcast removes the card from the FOH view.
 func (h *ServerHandler) MarkPaid(c echo.Context) error {
 	id := c.Param("id")
-	if _, err := h.markPaidUC.Handle(c.Request().Context(), orderCmd.MarkOrderPaid{OrderID: common.OrderID(id)}); err != nil {
+	tendered, err := parseTendered(c.FormValue("tendered"), h.restaurantCurrency(c))
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	cmd := orderCmd.MarkOrderPaid{OrderID: common.OrderID(id), Method: tenderMethod(c.FormValue("method")), Tendered: tendered}
+	if u, ok := commonhttp.GetAuthenticatedUser(c); ok && u != nil {
+		cmd.CollectedBy = u.ID
+	}
+	if _, err := h.markPaidUC.Handle(c.Request().Context(), cmd); err != nil {
+		if errors.Is(err, payment.ErrInsufficientTender) {
+			return c.String(http.StatusUnprocessableEntity, err.Error())
+		}
+		if errors.Is(err, order.ErrOrderCancelled) || errors.Is(err, order.ErrBillIsSplit) || errors.Is(err, payment.ErrSettledByInvoice) {
+			return c.String(http.StatusConflict, err.Error())
+		}
 		return c.String(http.StatusInternalServerError, err.Error())
 	}
 	return c.NoContent(http.StatusOK)
 }
+
+// CancelOrder handles POST /server/order/:id/cancel with reason and note
+// form values. Unpaid orders are voided; paid ones are refunded.
+func (h *ServerHandler) CancelOrder(c echo.Context) error {
+	return handleCancel(c, h.cancelUC)
+}
+
+// restaurantCurrency is the currency staff type amounts in on this surface,
+// falling back to USD when the restaurant cannot be loaded.
+func (h *ServerHandler) restaurantCurrency(c echo.Context) money.Currency {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil || h.restaurantRepo == nil {
+		return money.USD
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil || rest == nil || rest.BaseCurrency.IsZero() {
+		return money.USD
+	}
+	return rest.BaseCurrency
+}
+
+// tenderMethod reads the tender staff took at the till. Blank means cash:
+// staff never settle a Lightning invoice by hand.
+func tenderMethod(raw string) common.PaymentMethodType {
+	if m := common.PaymentMethodType(strings.TrimSpace(raw)); m != "" {
+		return m
+	}
+	return common.PaymentMethodTypeCash
+}
+
+// parseTendered reads an optional cash amount typed in major units; blank
+// means the exact total.
+func parseTendered(raw string, currency money.Currency) (money.Money, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return money.New(0, currency), nil
+	}
+	m, err := money.ParseMajor(raw, currency)
+	if err != nil {
+		return money.Money{}, errors.New("invalid tendered amount")
+	}
+	return m, nil
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	orderCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/domain/order"
+	"bitmerchant/internal/payment/domain/payment"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SplitBill handles POST /server/order/:id/split. The "mode" form value picks
+// even ("ways"), by item ("guest_<itemID>" per line item) or custom
+// ("amounts", comma separated). Returns an empty 200 — the bill-split
+// broadcast redraws the card.
+func (h *ServerHandler) SplitBill(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	cmd, err := splitBillFromForm(c, common.OrderID(c.Param("id")), restaurantID, h.restaurantCurrency(c))
+	if err != nil {
+		return c.String(http.StatusUnprocessableEntity, err.Error())
+	}
+	if _, err := h.splitUC.Handle(c.Request().Context(), cmd); err != nil {
+		return c.String(splitErrorStatus(err), err.Error())
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// PayBillPart handles POST /server/order/:id/parts/:partId/pay with optional
+// "method" and "tendered" values, as for MarkPaid.
+func (h *ServerHandler) PayBillPart(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	tendered, err := parseTendered(c.FormValue("tendered"), h.restaurantCurrency(c))
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	cmd := orderCmd.PayBillPart{
+		OrderID:      common.OrderID(c.Param("id")),
+		RestaurantID: restaurantID,
+		PartID:       common.BillPartID(c.Param("partId")),
+		Method:       tenderMethod(c.FormValue("method")),
+		Tendered:     tendered,
+	}
+	if u, ok := commonhttp.GetAuthenticatedUser(c); ok && u != nil {
+		cmd.CollectedBy = u.ID
+	}
+	if _, err := h.payPartUC.Handle(c.Request().Context(), cmd); err != nil {
+		if errors.Is(err, payment.ErrInsufficientTender) {
+			return c.String(http.StatusUnprocessableEntity, err.Error())
+		}
+		return c.String(splitErrorStatus(err), err.Error())
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// splitBillFromForm builds a SplitBill from the split form values. Custom
+// amounts are typed in major units of currency.
+func splitBillFromForm(c echo.Context, orderID common.OrderID, restaurantID common.RestaurantID, currency money.Currency) (orderCmd.SplitBill, error) {
+	mode, err := order.ParseSplitMode(c.FormValue("mode"))
+	if err != nil {
+		return orderCmd.SplitBill{}, err
+	}
+	cmd := orderCmd.SplitBill{OrderID: orderID, RestaurantID: restaurantID, Mode: mode}
+	switch mode {
+	case order.SplitEvenly:
+		ways, err := strconv.Atoi(strings.TrimSpace(c.FormValue("ways")))
+		if err != nil {
+			return orderCmd.SplitBill{}, order.ErrInvalidSplit
+		}
+		cmd.Ways = ways
+	case order.SplitByItem:
+		form, err := c.FormParams()
+		if err != nil {
+			return orderCmd.SplitBill{}, err
+		}
+		cmd.ItemGuests = make(map[common.OrderItemID]int)
+		for key, values := range form {
+			itemID, ok := strings.CutPrefix(key, "guest_")
+			if !ok || len(values) == 0 {
+				continue
+			}
+			guest, err := strconv.Atoi(strings.TrimSpace(values[0]))
+			if err != nil {
+				return orderCmd.SplitBill{}, order.ErrSplitItemUnassigned
+			}
+			cmd.ItemGuests[common.OrderItemID(itemID)] = guest
+		}
+	case order.SplitCustom:
+		for _, raw := range strings.Split(c.FormValue("amounts"), ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			v, err := money.ParseMajor(raw, currency)
+			if err != nil {
+				return orderCmd.SplitBill{}, errors.New("invalid split amount")
+			}
+			cmd.Amounts = append(cmd.Amounts, v)
+		}
+	}
+	return cmd, nil
+}
+
+// splitErrorStatus maps split and part-payment failures to HTTP status codes.
+func splitErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, order.ErrInvalidSplit),
+		errors.Is(err, order.ErrSplitMismatch),
+		errors.Is(err, order.ErrSplitItemUnassigned),
+		errors.Is(err, order.ErrInvalidSplitMode):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, order.ErrOrderCancelled),
+		errors.Is(err, order.ErrNothingOutstanding),
+		errors.Is(err, payment.ErrSettledByInvoice):
+		return http.StatusConflict
+	case errors.Is(err, order.ErrBillPartNotFound),
+		err.Error() == "order not found":
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,11 +1,11 @@
 package notification
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 
 	"bitmerchant/internal/common"
-	"bitmerchant/internal/infrastructure/logging"
+	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/notification"
 	orderevent "bitmerchant/internal/ordering/app/event"
 
@@ -15,20 +15,17 @@ import (
 // RegisterOrderNotificationHandlers wires push notification handlers into the Watermill router.
 // Handler names use the "notif_order_*" prefix — distinct from the "sse_order_*" SSE handlers,
 // allowing Watermill to fan-out each event to both handler sets independently.
+// Sending a push is not idempotent, so each handler runs under middleware,
+// typically events.Deduplicate.
 func RegisterOrderNotificationHandlers(
 	router *message.Router,
 	subscriber message.Subscriber,
-	logger *logging.Logger,
 	svc *notification.Service,
+	middleware ...message.HandlerMiddleware,
 ) {
 	router.AddConsumerHandler("notif_order_created", common.EventOrderCreated, subscriber,
-		func(msg *message.Message) error {
-			var ev orderevent.OrderCreated
-			if err := json.Unmarshal(msg.Payload, &ev); err != nil {
-				logger.Warn("skipping malformed order created event (notification)", "error", err)
-				return nil
-			}
-			svc.Send(msg.Context(), notification.Notification{
+		events.Handle(func(ctx context.Context, ev orderevent.OrderCreated) error {
+			svc.Send(ctx, notification.Notification{
 				Title: "New Order Received",
 				Body:  fmt.Sprintf("Order #%s received", ev.OrderNumber),
 				URL:   "/kitchen",
@@ -38,17 +35,12 @@ func RegisterOrderNotificationHandlers(
 				},
 			})
 			return nil
-		},
-	)
+		}),
+	).AddMiddleware(middleware...)
 
 	router.AddConsumerHandler("notif_order_preparing", common.EventOrderPreparing, subscriber,
-		func(msg *message.Message) error {
-			var ev orderevent.OrderPreparing
-			if err := json.Unmarshal(msg.Payload, &ev); err != nil {
-				logger.Warn("skipping malformed order preparing event (notification)", "error", err)
-				return nil
-			}
-			svc.Send(msg.Context(), notification.Notification{
+		events.Handle(func(ctx context.Context, ev orderevent.OrderPreparing) error {
+			svc.Send(ctx, notification.Notification{
 				Title: "Order Update",
 				Body:  "Your order is being prepared",
 				URL:   fmt.Sprintf("/order/%s", ev.OrderNumber),
@@ -58,17 +50,12 @@ func RegisterOrderNotificationHandlers(
 				},
 			})
 			return nil
-		},
-	)
+		}),
+	).AddMiddleware(middleware...)
 
 	router.AddConsumerHandler("notif_order_ready", common.EventOrderReady, subscriber,
-		func(msg *message.Message) error {
-			var ev orderevent.OrderReady
-			if err := json.Unmarshal(msg.Payload, &ev); err != nil {
-				logger.Warn("skipping malformed order ready event (notification)", "error", err)
-				return nil
-			}
-			svc.Send(msg.Context(), notification.Notification{
+		events.Handle(func(ctx context.Context, ev orderevent.OrderReady) error {
+			svc.Send(ctx, notification.Notification{
 				Title: "Order Ready",
 				Body:  "Your order is ready for pickup! 🎉",
 				URL:   fmt.Sprintf("/order/%s", ev.OrderNumber),
@@ -78,17 +65,12 @@ func RegisterOrderNotificationHandlers(
 				},
 			})
 			return nil
-		},
-	)
+		}),
+	).AddMiddleware(middleware...)
 
 	router.AddConsumerHandler("notif_order_completed", common.EventOrderCompleted, subscriber,
-		func(msg *message.Message) error {
-			var ev orderevent.OrderCompleted
-			if err := json.Unmarshal(msg.Payload, &ev); err != nil {
-				logger.Warn("skipping malformed order completed event (notification)", "error", err)
-				return nil
-			}
-			svc.Send(msg.Context(), notification.Notification{
+		events.Handle(func(ctx context.Context, ev orderevent.OrderCompleted) error {
+			svc.Send(ctx, notification.Notification{
 				Title: "Order Complete",
 				Body:  "Thank you for your order!",
 				URL:   fmt.Sprintf("/order/%s", ev.OrderNumber),
@@ -98,6 +80,25 @@ func RegisterOrderNotificationHandlers(
 				},
 			})
 			return nil
-		},
-	)
+		}),
+	).AddMiddleware(middleware...)
+
+	router.AddConsumerHandler("notif_order_cancelled", common.EventOrderCancelled, subscriber,
+		events.Handle(func(ctx context.Context, ev orderevent.OrderCancelled) error {
+			body := "Your order was cancelled by the restaurant."
+			if ev.Refunded {
+				body = "Your order was cancelled and your payment refunded."
+			}
+			svc.Send(ctx, notification.Notification{
+				Title: "Order Cancelled",
+				Body:  body,
+				URL:   fmt.Sprintf("/order/%s", ev.OrderNumber),
+				Metadata: map[string]string{
+					"role":         "customer",
+					"order_number": string(ev.OrderNumber),
+				},
+			})
+			return nil
+		}),
+	).AddMiddleware(middleware...)
 }
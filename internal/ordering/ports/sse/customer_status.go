@@ -3,7 +3,6 @@ package sse
 import (
 	"bytes"
 	"context"
-	"fmt"
 
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
@@ -16,12 +15,13 @@ import (
 // broadcastCustomerStatus renders OrderStatus for a single order and pushes the
 // result on the order's SSE topic. It also rebroadcasts every active order in
 // the same restaurant created after `o`, because their queue position drops
-// by one when `o` advances.
-func broadcastCustomerStatus(ctx context.Context, logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, o *order.Order) {
+// by one when `o` advances. lateTips supplies the tip prompt for served
+// orders; nil leaves it out.
+func broadcastCustomerStatus(ctx context.Context, logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup, o *order.Order) {
 	if o == nil {
 		return
 	}
-	pushView(ctx, logger, sse, repo, o)
+	pushView(ctx, logger, sse, repo, lateTips, o)
 
 	if !shouldRebroadcastQueue(o) {
 		return
@@ -36,34 +36,37 @@ func broadcastCustomerStatus(ctx context.Context, logger *logging.Logger, sse *c
 			continue
 		}
 		if peer.CreatedAt.After(o.CreatedAt) && peer.FulfillmentStatus == common.FulfillmentStatusPaid {
-			pushView(ctx, logger, sse, repo, peer)
+			pushView(ctx, logger, sse, repo, lateTips, peer)
 		}
 	}
 }
 
-func pushView(ctx context.Context, logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, o *order.Order) {
+func pushView(ctx context.Context, logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup, o *order.Order) {
 	view, err := orderQuery.BuildOrderStatusView(repo, o, orderQuery.DefaultPrepTarget)
 	if err != nil {
 		logger.Error("status view: build failed", "error", err, "orderNumber", o.OrderNumber)
 		return
 	}
+	view.OfferLateTip(lateTips)
 	var buf bytes.Buffer
 	if err := templates.OrderStatus(view).Render(ctx, &buf); err != nil {
 		logger.Error("status view: render failed", "error", err, "orderNumber", o.OrderNumber)
 		return
 	}
 	msg := commonhttp.FormatDatastarEvent(buf.String())
-	sse.Broadcast(fmt.Sprintf(commonhttp.TopicOrder, o.OrderNumber), msg)
+	sse.Broadcast(commonhttp.OrderTopic(o.RestaurantID, o.ID), msg)
 }
 
 // shouldRebroadcastQueue returns true when an order's transition shifts the
 // queue (i.e. when it leaves the "paid, waiting to start" position). A paid
-// order moving to preparing/ready/completed bumps everyone behind it forward.
+// order moving to preparing/ready/completed/cancelled bumps everyone behind
+// it forward.
 func shouldRebroadcastQueue(o *order.Order) bool {
 	switch o.FulfillmentStatus {
 	case common.FulfillmentStatusPreparing,
 		common.FulfillmentStatusReady,
-		common.FulfillmentStatusCompleted:
+		common.FulfillmentStatusCompleted,
+		common.FulfillmentStatusCancelled:
 		return true
 	}
 	return false
@@ -0,0 +1,91 @@
+package sse
+
+import (
+	"bytes"
+	"context"
+
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/infrastructure/logging"
+	"bitmerchant/internal/interfaces/templates/components"
+	"bitmerchant/internal/ordering/app/event"
+	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// OrderBillSplitHandler redraws the FOH card and the customer status page
+// when a bill is split into parts.
+type OrderBillSplitHandler struct {
+	logger   *logging.Logger
+	sse      *commonhttp.SSEHandler
+	repo     order.Repository
+	lateTips orderQuery.LateTipLookup
+}
+
+func NewOrderBillSplitHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup) *OrderBillSplitHandler {
+	return &OrderBillSplitHandler{logger: logger, sse: sse, repo: repo, lateTips: lateTips}
+}
+
+func (h *OrderBillSplitHandler) Handle(ctx context.Context, ev event.OrderBillSplit) error {
+	h.logger.Info("Order bill split", "orderID", ev.OrderID, "parts", ev.Parts)
+	return broadcastBillProgress(ctx, h.logger, h.sse, h.repo, h.lateTips, ev.OrderID)
+}
+
+// OrderBillPartPaidHandler redraws the FOH card, kitchen ticket and customer
+// status page when one guest pays their part. The part that clears the bill
+// goes through OrderPaidHandler instead.
+type OrderBillPartPaidHandler struct {
+	logger   *logging.Logger
+	sse      *commonhttp.SSEHandler
+	repo     order.Repository
+	lateTips orderQuery.LateTipLookup
+}
+
+func NewOrderBillPartPaidHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup) *OrderBillPartPaidHandler {
+	return &OrderBillPartPaidHandler{logger: logger, sse: sse, repo: repo, lateTips: lateTips}
+}
+
+func (h *OrderBillPartPaidHandler) Handle(ctx context.Context, ev event.OrderBillPartPaid) error {
+	h.logger.Info("Order bill part paid", "orderID", ev.OrderID, "partID", ev.PartID)
+	return broadcastBillProgress(ctx, h.logger, h.sse, h.repo, h.lateTips, ev.OrderID)
+}
+
+// OrderTipAddedHandler redraws the FOH card and the customer status page
+// when a guest adds a tip after paying.
+type OrderTipAddedHandler struct {
+	logger   *logging.Logger
+	sse      *commonhttp.SSEHandler
+	repo     order.Repository
+	lateTips orderQuery.LateTipLookup
+}
+
+func NewOrderTipAddedHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup) *OrderTipAddedHandler {
+	return &OrderTipAddedHandler{logger: logger, sse: sse, repo: repo, lateTips: lateTips}
+}
+
+func (h *OrderTipAddedHandler) Handle(ctx context.Context, ev event.OrderTipAdded) error {
+	h.logger.Info("Order tip added", "orderID", ev.OrderID, "amount", ev.Amount)
+	return broadcastBillProgress(ctx, h.logger, h.sse, h.repo, h.lateTips, ev.OrderID)
+}
+
+// broadcastBillProgress re-renders a partly paid order on the server and
+// kitchen boards and pushes the customer's status view.
+func broadcastBillProgress(ctx context.Context, logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup, orderID common.OrderID) error {
+	o, err := repo.FindByID(orderID)
+	if err != nil || o == nil {
+		logger.Error("Order not found for broadcasting", "orderID", orderID)
+		return err
+	}
+
+	var bufServer bytes.Buffer
+	if err := components.ServerOrderCard(o).Render(ctx, &bufServer); err == nil {
+		sse.Broadcast(commonhttp.ServerTopic(o.RestaurantID), commonhttp.FormatDatastarEvent(bufServer.String()))
+	}
+	var bufKitchen bytes.Buffer
+	if err := components.OrderCard(o).Render(ctx, &bufKitchen); err == nil {
+		sse.Broadcast(commonhttp.KitchenTopic(o.RestaurantID), commonhttp.FormatDatastarEvent(bufKitchen.String()))
+	}
+
+	pushView(ctx, logger, sse, repo, lateTips, o)
+	return nil
+}
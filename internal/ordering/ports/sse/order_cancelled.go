@@ -0,0 +1,45 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/infrastructure/logging"
+	"bitmerchant/internal/ordering/app/event"
+	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
+)
+
+// OrderCancelledHandler removes a cancelled order's card from the kitchen
+// and FOH boards and pushes the cancelled state to the customer.
+type OrderCancelledHandler struct {
+	logger   *logging.Logger
+	sse      *commonhttp.SSEHandler
+	repo     order.Repository
+	lateTips orderQuery.LateTipLookup
+}
+
+func NewOrderCancelledHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup) *OrderCancelledHandler {
+	return &OrderCancelledHandler{
+		logger:   logger,
+		sse:      sse,
+		repo:     repo,
+		lateTips: lateTips,
+	}
+}
+
+func (h *OrderCancelledHandler) Handle(ctx context.Context, ev event.OrderCancelled) error {
+	h.logger.Info("Order Cancelled", "orderID", ev.OrderID, "reason", ev.Reason, "refunded", ev.Refunded)
+
+	h.sse.Broadcast(commonhttp.KitchenTopic(ev.RestaurantID), commonhttp.FormatDatastarPatch("", fmt.Sprintf("#order-%s", ev.OrderID), "remove"))
+	h.sse.Broadcast(commonhttp.ServerTopic(ev.RestaurantID), commonhttp.FormatDatastarPatch("", fmt.Sprintf("#server-order-%s", ev.OrderID), "remove"))
+
+	order, err := h.repo.FindByID(ev.OrderID)
+	if err != nil || order == nil {
+		h.logger.Error("Order not found for broadcasting", "orderID", ev.OrderID)
+		return err
+	}
+	broadcastCustomerStatus(ctx, h.logger, h.sse, h.repo, h.lateTips, order)
+	return nil
+}
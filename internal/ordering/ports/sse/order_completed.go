@@ -8,21 +8,24 @@ import (
 	"bitmerchant/internal/infrastructure/logging"
 	"bitmerchant/internal/interfaces/templates/components"
 	"bitmerchant/internal/ordering/app/event"
+	orderQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
 )
 
 // OrderCompletedHandler broadcasts completed state to kitchen and customer streams.
 type OrderCompletedHandler struct {
-	logger *logging.Logger
-	sse    *commonhttp.SSEHandler
-	repo   order.Repository
+	logger   *logging.Logger
+	sse      *commonhttp.SSEHandler
+	repo     order.Repository
+	lateTips orderQuery.LateTipLookup
 }
 
-func NewOrderCompletedHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository) *OrderCompletedHandler {
+func NewOrderCompletedHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup) *OrderCompletedHandler {
 	return &OrderCompletedHandler{
-		logger: logger,
-		sse:    sse,
-		repo:   repo,
+		logger:   logger,
+		sse:      sse,
+		repo:     repo,
+		lateTips: lateTips,
 	}
 }
 
@@ -38,9 +41,9 @@ func (h *OrderCompletedHandler) Handle(ctx context.Context, ev event.OrderComple
 	var bufCard bytes.Buffer
 	if err := components.OrderCard(order).Render(ctx, &bufCard); err == nil {
 		msg := commonhttp.FormatDatastarEvent(bufCard.String())
-		h.sse.Broadcast(commonhttp.TopicKitchen, msg)
+		h.sse.Broadcast(commonhttp.KitchenTopic(order.RestaurantID), msg)
 	}
 
-	broadcastCustomerStatus(ctx, h.logger, h.sse, h.repo, order)
+	broadcastCustomerStatus(ctx, h.logger, h.sse, h.repo, h.lateTips, order)
 	return nil
 }
@@ -47,13 +47,13 @@ func (h *OrderCreatedHandler) Handle(ctx context.Context, ev event.OrderCreated)
 	}
 
 	msg := commonhttp.FormatDatastarPatch(buf.String(), "#orders-list", "prepend")
-	h.sse.Broadcast(commonhttp.TopicKitchen, msg)
+	h.sse.Broadcast(commonhttp.KitchenTopic(order.RestaurantID), msg)
 
 	if order.PaymentStatus != common.PaymentStatusPaid {
 		var serverBuf bytes.Buffer
 		if err := components.ServerOrderCard(order).Render(ctx, &serverBuf); err == nil {
 			serverMsg := commonhttp.FormatDatastarPatch(serverBuf.String(), "#server-orders", "prepend")
-			h.sse.Broadcast(commonhttp.TopicServer, serverMsg)
+			h.sse.Broadcast(commonhttp.ServerTopic(order.RestaurantID), serverMsg)
 		}
 	}
 
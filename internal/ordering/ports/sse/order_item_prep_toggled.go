@@ -35,7 +35,7 @@ func (h *OrderItemPrepToggledHandler) Handle(ctx context.Context, ev event.Order
 	var buf bytes.Buffer
 	if err := components.OrderCard(o).Render(ctx, &buf); err == nil {
 		msg := commonhttp.FormatDatastarEvent(buf.String())
-		h.sse.Broadcast(commonhttp.TopicKitchen, msg)
+		h.sse.Broadcast(commonhttp.KitchenTopic(o.RestaurantID), msg)
 	}
 	return nil
 }
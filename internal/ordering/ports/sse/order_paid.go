@@ -9,21 +9,24 @@ import (
 	"bitmerchant/internal/infrastructure/logging"
 	"bitmerchant/internal/interfaces/templates/components"
 	"bitmerchant/internal/ordering/app/event"
+	orderQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
 )
 
 // OrderPaidHandler broadcasts paid state to kitchen and customer streams.
 type OrderPaidHandler struct {
-	logger *logging.Logger
-	sse    *commonhttp.SSEHandler
-	repo   order.Repository
+	logger   *logging.Logger
+	sse      *commonhttp.SSEHandler
+	repo     order.Repository
+	lateTips orderQuery.LateTipLookup
 }
 
-func NewOrderPaidHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository) *OrderPaidHandler {
+func NewOrderPaidHandler(logger *logging.Logger, sse *commonhttp.SSEHandler, repo order.Repository, lateTips orderQuery.LateTipLookup) *OrderPaidHandler {
 	return &OrderPaidHandler{
-		logger: logger,
-		sse:    sse,
-		repo:   repo,
+		logger:   logger,
+		sse:      sse,
+		repo:     repo,
+		lateTips: lateTips,
 	}
 }
 
@@ -39,14 +42,14 @@ func (h *OrderPaidHandler) Handle(ctx context.Context, ev event.OrderPaid) error
 	var bufCard bytes.Buffer
 	if err := components.OrderCard(order).Render(ctx, &bufCard); err == nil {
 		msg := commonhttp.FormatDatastarEvent(bufCard.String())
-		h.sse.Broadcast(commonhttp.TopicKitchen, msg)
+		h.sse.Broadcast(commonhttp.KitchenTopic(order.RestaurantID), msg)
 	}
 
 	// Remove the now-paid card from the FOH/server view.
 	removalSelector := fmt.Sprintf("#server-order-%s", order.ID)
 	removalMsg := commonhttp.FormatDatastarPatch("", removalSelector, "remove")
-	h.sse.Broadcast(commonhttp.TopicServer, removalMsg)
+	h.sse.Broadcast(commonhttp.ServerTopic(order.RestaurantID), removalMsg)
 
-	broadcastCustomerStatus(ctx, h.logger, h.sse, h.repo, order)
+	broadcastCustomerStatus(ctx, h.logger, h.sse, h.repo, h.lateTips, order)
 	return nil
 }
@@ -1,16 +1,17 @@
 package service
 
 import (
-	"encoding/json"
-
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
 	menuQuery "bitmerchant/internal/menu/app/query"
 	"bitmerchant/internal/menu/domain/menu"
+	orderingapp "bitmerchant/internal/ordering/app"
 	orderCart "bitmerchant/internal/ordering/app/cart"
 	orderCmd "bitmerchant/internal/ordering/app/command"
-	orderevent "bitmerchant/internal/ordering/app/event"
 	orderQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
 	orderinghttp "bitmerchant/internal/ordering/ports/http"
@@ -32,11 +33,17 @@ type Ordering struct {
 	ToggleOrderItemPrep orderCmd.ToggleOrderItemPrepHandler
 	RequestServer       orderCmd.RequestServerHandler
 	RequestBill         orderCmd.RequestBillHandler
+	CancelOrder         orderCmd.CancelOrderHandler
+	SplitBill           orderCmd.SplitBillHandler
+	PayBillPart         orderCmd.PayBillPartHandler
+	AddTip              orderCmd.AddTipHandler
 
 	GetCustomerOrder  orderQuery.CustomerOrderByLookupHandler
 	GetCustomerOrders orderQuery.CustomerOrdersForSessionHandler
 	GetKitchenOrders  orderQuery.ActiveKitchenOrdersHandler
 	GetUnpaidServer   orderQuery.UnpaidServerOrdersHandler
+	// LateTips offers served guests a Lightning tip on their status page.
+	LateTips orderQuery.LateTipLookup
 
 	CartHandler    *orderinghttp.CartHandler
 	OrderHandler   *orderinghttp.OrderHandler
@@ -48,27 +55,40 @@ type Ordering struct {
 //
 // photoStorage may be nil; when missing, the customer item-detail page falls
 // back to rendering raw PhotoURLs (e.g. dev environments without S3).
+// ports.Payments settles the payment ledger whenever an order is marked
+// paid; ports.Canceller voids or refunds it when staff cancel an order.
+// ports.BillParts settles one part of a split bill, and ports.Voider drops
+// pending invoices before a bill is (re)split. ports.Pricer and
+// ports.Redeemer apply promotions at checkout; nil leaves them off.
+// sseHandler serves each customer's order status stream.
 func New(
 	repos wiring.Repositories,
-	eventBus common.EventBus,
 	logger *logging.Logger,
+	metrics decorator.MetricsClient,
+	sseHandler *commonhttp.SSEHandler,
 	vapidPublicKey string,
 	photoStorage menu.PhotoStorage,
 	cfg wiring.Config,
+	converter money.Converter,
+	ports orderingapp.Ports,
 ) Ordering {
 	cartService := orderCart.NewCartService()
-	createOrderUC := orderCmd.NewCreateOrderHandler(repos.Order, repos.Restaurant, eventBus, logger.Logger, nil)
-	getCustomerOrderByNumberUC := orderQuery.NewCustomerOrderByLookupHandler(repos.Order, nil, nil)
-	getCustomerOrdersUC := orderQuery.NewCustomerOrdersForSessionHandler(repos.Order, nil, nil)
-	getKitchenOrdersUC := orderQuery.NewActiveKitchenOrdersHandler(repos.Order, nil, nil)
-	getUnpaidServerUC := orderQuery.NewUnpaidServerOrdersHandler(repos.Order, nil, nil)
-	markPaidUC := orderCmd.NewMarkOrderPaidHandler(repos.Order, eventBus, logger.Logger, nil)
-	markPreparingUC := orderCmd.NewMarkOrderPreparingHandler(repos.Order, eventBus, logger.Logger, nil)
-	markReadyUC := orderCmd.NewMarkOrderReadyHandler(repos.Order, eventBus, logger.Logger, nil)
-	markCompletedUC := orderCmd.NewMarkOrderCompletedHandler(repos.Order, eventBus, logger.Logger, nil)
-	toggleItemPrepUC := orderCmd.NewToggleOrderItemPrepHandler(repos.Order, eventBus, logger.Logger, nil)
-	requestServerUC := orderCmd.NewRequestServerHandler(repos.Order, eventBus, logger.Logger, nil)
-	requestBillUC := orderCmd.NewRequestBillHandler(repos.Order, eventBus, logger.Logger, nil)
+	createOrderUC := orderCmd.NewCreateOrderHandler(repos.Order, repos.Restaurant, ports.Pricer, ports.Redeemer, logger.Logger, metrics)
+	getCustomerOrderByNumberUC := orderQuery.NewCustomerOrderByLookupHandler(repos.Order, nil, metrics)
+	getCustomerOrdersUC := orderQuery.NewCustomerOrdersForSessionHandler(repos.Order, nil, metrics)
+	getKitchenOrdersUC := orderQuery.NewActiveKitchenOrdersHandler(repos.Order, nil, metrics)
+	getUnpaidServerUC := orderQuery.NewUnpaidServerOrdersHandler(repos.Order, nil, metrics)
+	markPaidUC := orderCmd.NewMarkOrderPaidHandler(repos.Order, ports.Payments, logger.Logger, metrics)
+	markPreparingUC := orderCmd.NewMarkOrderPreparingHandler(repos.Order, logger.Logger, metrics)
+	markReadyUC := orderCmd.NewMarkOrderReadyHandler(repos.Order, logger.Logger, metrics)
+	markCompletedUC := orderCmd.NewMarkOrderCompletedHandler(repos.Order, logger.Logger, metrics)
+	toggleItemPrepUC := orderCmd.NewToggleOrderItemPrepHandler(repos.Order, logger.Logger, metrics)
+	requestServerUC := orderCmd.NewRequestServerHandler(repos.Order, logger.Logger, metrics)
+	requestBillUC := orderCmd.NewRequestBillHandler(repos.Order, logger.Logger, metrics)
+	cancelOrderUC := orderCmd.NewCancelOrderHandler(repos.Order, ports.Canceller, logger.Logger, metrics)
+	splitBillUC := orderCmd.NewSplitBillHandler(repos.Order, ports.Voider, logger.Logger, metrics)
+	payBillPartUC := orderCmd.NewPayBillPartHandler(repos.Order, ports.BillParts, logger.Logger, metrics)
+	addTipUC := orderCmd.NewAddTipHandler(repos.Order, logger.Logger, metrics)
 
 	return Ordering{
 		CartService:         cartService,
@@ -80,101 +100,52 @@ func New(
 		ToggleOrderItemPrep: toggleItemPrepUC,
 		RequestServer:       requestServerUC,
 		RequestBill:         requestBillUC,
+		CancelOrder:         cancelOrderUC,
+		SplitBill:           splitBillUC,
+		PayBillPart:         payBillPartUC,
+		AddTip:              addTipUC,
 		GetCustomerOrder:    getCustomerOrderByNumberUC,
 		GetCustomerOrders:   getCustomerOrdersUC,
 		GetKitchenOrders:    getKitchenOrdersUC,
 		GetUnpaidServer:     getUnpaidServerUC,
-		CartHandler: orderinghttp.NewCartHandler(cartService, repos.MenuItem, photoStorage, menuQuery.PhotoSignerConfig{
+		LateTips:            orderQuery.NewLateTipLookup(repos.Restaurant, cfg.LightningBackend != ""),
+		CartHandler: orderinghttp.NewCartHandler(cartService, repos.MenuItem, repos.MenuCategory, photoStorage, menuQuery.PhotoSignerConfig{
 			Bucket:        cfg.S3BucketName,
 			Endpoint:      cfg.S3Endpoint,
 			PublicBaseURL: cfg.S3PublicBaseURL,
 		}),
-		OrderHandler:   orderinghttp.NewOrderHandler(createOrderUC, getCustomerOrderByNumberUC, getCustomerOrdersUC, requestServerUC, requestBillUC, repos.Order, repos.Restaurant, cartService, vapidPublicKey),
-		KitchenHandler: orderinghttp.NewKitchenHandler(getKitchenOrdersUC, markPaidUC, markPreparingUC, markReadyUC, markCompletedUC, toggleItemPrepUC, repos.Restaurant, repos.Membership, vapidPublicKey),
-		ServerHandler:  orderinghttp.NewServerHandler(getUnpaidServerUC, markPaidUC, repos.Restaurant, repos.Membership),
+		OrderHandler:   orderinghttp.NewOrderHandler(createOrderUC, getCustomerOrderByNumberUC, getCustomerOrdersUC, requestServerUC, requestBillUC, repos.Order, repos.Restaurant, cartService, vapidPublicKey, cfg.LightningBackend != "", converter, ports.Pricer, sseHandler),
+		KitchenHandler: orderinghttp.NewKitchenHandler(getKitchenOrdersUC, markPaidUC, markPreparingUC, markReadyUC, markCompletedUC, toggleItemPrepUC, cancelOrderUC, repos.Restaurant, repos.Membership, vapidPublicKey, sseHandler),
+		ServerHandler:  orderinghttp.NewServerHandler(getUnpaidServerUC, markPaidUC, cancelOrderUC, splitBillUC, payBillPartUC, repos.Restaurant, repos.Membership, sseHandler),
 	}
 }
 
 // RegisterOrderSSEHandlers connects order domain events to SSE projection handlers through Watermill Router.
-func RegisterOrderSSEHandlers(router *message.Router, subscriber message.Subscriber, logger *logging.Logger, sseHandler *commonhttp.SSEHandler, orderRepo order.Repository) {
+// lateTips may be nil, which leaves the tip prompt off pushed status pages.
+func RegisterOrderSSEHandlers(router *message.Router, subscriber message.Subscriber, logger *logging.Logger, sseHandler *commonhttp.SSEHandler, orderRepo order.Repository, lateTips orderQuery.LateTipLookup) {
 	orderCreatedHandler := ordersse.NewOrderCreatedHandler(logger, sseHandler, orderRepo)
-	orderPaidHandler := ordersse.NewOrderPaidHandler(logger, sseHandler, orderRepo)
-	orderPreparingHandler := ordersse.NewOrderPreparingHandler(logger, sseHandler, orderRepo)
-	orderReadyHandler := ordersse.NewOrderReadyHandler(logger, sseHandler, orderRepo)
-	orderCompletedHandler := ordersse.NewOrderCompletedHandler(logger, sseHandler, orderRepo)
+	orderPaidHandler := ordersse.NewOrderPaidHandler(logger, sseHandler, orderRepo, lateTips)
+	orderPreparingHandler := ordersse.NewOrderPreparingHandler(logger, sseHandler, orderRepo, lateTips)
+	orderReadyHandler := ordersse.NewOrderReadyHandler(logger, sseHandler, orderRepo, lateTips)
+	orderCompletedHandler := ordersse.NewOrderCompletedHandler(logger, sseHandler, orderRepo, lateTips)
+	orderCancelledHandler := ordersse.NewOrderCancelledHandler(logger, sseHandler, orderRepo, lateTips)
 	orderItemPrepToggledHandler := ordersse.NewOrderItemPrepToggledHandler(logger, sseHandler, orderRepo)
-	serverCalledHandler := ordersse.NewServerCalledHandler(logger, sseHandler, orderRepo)
-	billRequestedHandler := ordersse.NewBillRequestedHandler(logger, sseHandler, orderRepo)
-
-	router.AddConsumerHandler("sse_order_created", common.EventOrderCreated, subscriber, func(msg *message.Message) error {
-		var event orderevent.OrderCreated
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed order created event", "error", err)
-			return nil
-		}
-		return orderCreatedHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_order_paid", common.EventOrderPaid, subscriber, func(msg *message.Message) error {
-		var event orderevent.OrderPaid
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed order paid event", "error", err)
-			return nil
-		}
-		return orderPaidHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_order_preparing", common.EventOrderPreparing, subscriber, func(msg *message.Message) error {
-		var event orderevent.OrderPreparing
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed order preparing event", "error", err)
-			return nil
-		}
-		return orderPreparingHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_order_ready", common.EventOrderReady, subscriber, func(msg *message.Message) error {
-		var event orderevent.OrderReady
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed order ready event", "error", err)
-			return nil
-		}
-		return orderReadyHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_order_completed", common.EventOrderCompleted, subscriber, func(msg *message.Message) error {
-		var event orderevent.OrderCompleted
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed order completed event", "error", err)
-			return nil
-		}
-		return orderCompletedHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_order_item_prep_toggled", common.EventOrderItemPrepToggled, subscriber, func(msg *message.Message) error {
-		var event orderevent.OrderItemPrepToggled
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed order item prep toggled event", "error", err)
-			return nil
-		}
-		return orderItemPrepToggledHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_server_called", common.EventServerCalled, subscriber, func(msg *message.Message) error {
-		var event orderevent.ServerCalled
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed server called event", "error", err)
-			return nil
-		}
-		return serverCalledHandler.Handle(msg.Context(), event)
-	})
-
-	router.AddConsumerHandler("sse_bill_requested", common.EventBillRequested, subscriber, func(msg *message.Message) error {
-		var event orderevent.BillRequested
-		if err := json.Unmarshal(msg.Payload, &event); err != nil {
-			logger.Warn("Skipping malformed bill requested event", "error", err)
-			return nil
-		}
-		return billRequestedHandler.Handle(msg.Context(), event)
-	})
+	serverCalledHandler := ordersse.NewServerCalledHandler(logger, sseHandler, orderRepo, lateTips)
+	billRequestedHandler := ordersse.NewBillRequestedHandler(logger, sseHandler, orderRepo, lateTips)
+	billSplitHandler := ordersse.NewOrderBillSplitHandler(logger, sseHandler, orderRepo, lateTips)
+	billPartPaidHandler := ordersse.NewOrderBillPartPaidHandler(logger, sseHandler, orderRepo, lateTips)
+	tipAddedHandler := ordersse.NewOrderTipAddedHandler(logger, sseHandler, orderRepo, lateTips)
+
+	router.AddConsumerHandler("sse_order_created", common.EventOrderCreated, subscriber, events.Handle(orderCreatedHandler.Handle))
+	router.AddConsumerHandler("sse_order_paid", common.EventOrderPaid, subscriber, events.Handle(orderPaidHandler.Handle))
+	router.AddConsumerHandler("sse_order_preparing", common.EventOrderPreparing, subscriber, events.Handle(orderPreparingHandler.Handle))
+	router.AddConsumerHandler("sse_order_ready", common.EventOrderReady, subscriber, events.Handle(orderReadyHandler.Handle))
+	router.AddConsumerHandler("sse_order_completed", common.EventOrderCompleted, subscriber, events.Handle(orderCompletedHandler.Handle))
+	router.AddConsumerHandler("sse_order_cancelled", common.EventOrderCancelled, subscriber, events.Handle(orderCancelledHandler.Handle))
+	router.AddConsumerHandler("sse_order_item_prep_toggled", common.EventOrderItemPrepToggled, subscriber, events.Handle(orderItemPrepToggledHandler.Handle))
+	router.AddConsumerHandler("sse_server_called", common.EventServerCalled, subscriber, events.Handle(serverCalledHandler.Handle))
+	router.AddConsumerHandler("sse_bill_requested", common.EventBillRequested, subscriber, events.Handle(billRequestedHandler.Handle))
+	router.AddConsumerHandler("sse_order_bill_split", common.EventOrderBillSplit, subscriber, events.Handle(billSplitHandler.Handle))
+	router.AddConsumerHandler("sse_order_bill_part_paid", common.EventOrderBillPartPaid, subscriber, events.Handle(billPartPaidHandler.Handle))
+	router.AddConsumerHandler("sse_order_tip_added", common.EventOrderTipAdded, subscriber, events.Handle(tipAddedHandler.Handle))
 }
@@ -18,10 +18,10 @@ func NewCashPaymentMethod() *CashPaymentMethod {
 
 func (p *CashPaymentMethod) ProcessPayment(ctx context.Context, orderID common.OrderID, restaurantID common.RestaurantID, amount money.Money) (*payment.Payment, error) {
 	paymentID := common.PaymentID(fmt.Sprintf("pay_%d", time.Now().UnixNano()))
-	return payment.NewPaymentWithCurrency(paymentID, orderID, restaurantID, common.PaymentMethodTypeCash, amount.Major(), amount.Currency)
+	return payment.NewPaymentWithCurrency(paymentID, orderID, restaurantID, common.PaymentMethodTypeCash, amount.Amount, amount.Currency)
 }
 
-func (p *CashPaymentMethod) ValidatePayment(ctx context.Context, orderID common.OrderID) error {
+func (p *CashPaymentMethod) ValidatePayment(ctx context.Context, paymentID common.PaymentID) error {
 	return nil
 }
 
@@ -0,0 +1,101 @@
+package adapters
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// FakeLightningNode is an in-process payment.LightningNode for tests and
+// local checkout. Invoices are well-formed regtest BOLT11 strings with a
+// zeroed signature, so real wallets reject them; settle them with Settle
+// (tests) or enable AutoSettleAfter so the dev flow completes on its own.
+type FakeLightningNode struct {
+	mu       sync.Mutex
+	invoices map[string]*fakeInvoice
+
+	// AutoSettleAfter, when positive, reports invoices as settled once they
+	// are at least this old. Zero means invoices only settle via Settle.
+	AutoSettleAfter time.Duration
+}
+
+type fakeInvoice struct {
+	invoice   payment.Invoice
+	state     payment.InvoiceState
+	createdAt time.Time
+}
+
+var ErrFakeInvoiceNotFound = errors.New("fake lightning node: invoice not found")
+
+func NewFakeLightningNode() *FakeLightningNode {
+	return &FakeLightningNode{invoices: make(map[string]*fakeInvoice)}
+}
+
+func (n *FakeLightningNode) CreateInvoice(_ context.Context, amountSats int64, _ string, expiry time.Duration) (payment.Invoice, error) {
+	if amountSats <= 0 {
+		return payment.Invoice{}, errors.New("fake lightning node: amount must be positive")
+	}
+	preimage := make([]byte, 32)
+	if _, err := rand.Read(preimage); err != nil {
+		return payment.Invoice{}, err
+	}
+	sum := sha256.Sum256(preimage)
+	hash := hex.EncodeToString(sum[:])
+
+	now := time.Now()
+	inv := payment.Invoice{
+		PaymentRequest: encodeFakeBolt11(sum[:], amountSats, now.Unix()),
+		PaymentHash:    hash,
+		AmountSats:     amountSats,
+		ExpiresAt:      now.Add(expiry),
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.invoices[hash] = &fakeInvoice{invoice: inv, state: payment.InvoiceStateOpen, createdAt: now}
+	return inv, nil
+}
+
+func (n *FakeLightningNode) LookupInvoice(_ context.Context, paymentHash string) (payment.InvoiceState, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	inv, ok := n.invoices[paymentHash]
+	if !ok {
+		return "", ErrFakeInvoiceNotFound
+	}
+	if inv.state == payment.InvoiceStateOpen && n.AutoSettleAfter > 0 && time.Since(inv.createdAt) >= n.AutoSettleAfter {
+		inv.state = payment.InvoiceStateSettled
+	}
+	return inv.state, nil
+}
+
+// Settle marks an open invoice as paid, as if a wallet had paid it.
+func (n *FakeLightningNode) Settle(paymentHash string) error {
+	return n.setState(paymentHash, payment.InvoiceStateSettled)
+}
+
+// Cancel marks an open invoice as canceled.
+func (n *FakeLightningNode) Cancel(paymentHash string) error {
+	return n.setState(paymentHash, payment.InvoiceStateCanceled)
+}
+
+func (n *FakeLightningNode) setState(paymentHash string, state payment.InvoiceState) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	inv, ok := n.invoices[paymentHash]
+	if !ok {
+		return ErrFakeInvoiceNotFound
+	}
+	if inv.state != payment.InvoiceStateOpen {
+		return fmt.Errorf("fake lightning node: invoice is %s", inv.state)
+	}
+	inv.state = state
+	return nil
+}
@@ -0,0 +1,123 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// DefaultInvoiceExpiry is how long a checkout invoice stays payable when no
+// explicit expiry is configured.
+const DefaultInvoiceExpiry = 15 * time.Minute
+
+// LightningPaymentMethod issues a BOLT11 invoice for the order total through a
+// payment.LightningNode. Fiat totals are converted to sats with the configured
+// money.Converter; settlement is picked up by LightningSettlementWatcher.
+type LightningPaymentMethod struct {
+	node      payment.LightningNode
+	converter money.Converter
+	repo      payment.Repository
+	expiry    time.Duration
+}
+
+func NewLightningPaymentMethod(node payment.LightningNode, converter money.Converter, repo payment.Repository, expiry time.Duration) *LightningPaymentMethod {
+	if node == nil {
+		panic("nil payment.LightningNode")
+	}
+	if converter == nil {
+		converter = money.NoopConverter{}
+	}
+	if expiry <= 0 {
+		expiry = DefaultInvoiceExpiry
+	}
+	return &LightningPaymentMethod{node: node, converter: converter, repo: repo, expiry: expiry}
+}
+
+func (p *LightningPaymentMethod) ProcessPayment(ctx context.Context, orderID common.OrderID, restaurantID common.RestaurantID, amount money.Money) (*payment.Payment, error) {
+	return p.IssueInvoice(ctx, payment.InvoiceRequest{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+		Amount:       amount,
+		Memo:         fmt.Sprintf("BitMerchant order %s", orderID),
+	})
+}
+
+// IssueInvoice creates an invoice on the node for req.Amount in sats.
+func (p *LightningPaymentMethod) IssueInvoice(ctx context.Context, req payment.InvoiceRequest) (*payment.Payment, error) {
+	sats, rate, err := toSats(ctx, p.converter, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := p.node.CreateInvoice(ctx, sats, req.Memo, p.expiry)
+	if err != nil {
+		return nil, fmt.Errorf("create invoice: %w", err)
+	}
+	return newLightningPayment(req, sats, rate, inv)
+}
+
+// toSats converts a checkout total to whole sats and returns the rate it
+// used. The rate is zero when the total is already in sats.
+func toSats(ctx context.Context, converter money.Converter, amount money.Money) (int64, money.ExchangeRate, error) {
+	var rate money.ExchangeRate
+	sats := amount
+	if amount.Currency.Code != money.SAT.Code {
+		var err error
+		if rate, err = money.SnapshotRate(ctx, converter, amount.Currency, money.SAT); err == nil {
+			sats, err = rate.Apply(amount)
+		}
+		if err != nil {
+			return 0, money.ExchangeRate{}, fmt.Errorf("convert %s to sats: %w", amount.Currency.Code, err)
+		}
+	}
+	if !sats.IsPositive() {
+		return 0, money.ExchangeRate{}, errors.New("invoice amount must be at least 1 sat")
+	}
+	return sats.Amount, rate, nil
+}
+
+// newLightningPayment records a pending SAT payment for an issued invoice,
+// keeping the rate the checkout total was converted at.
+func newLightningPayment(req payment.InvoiceRequest, sats int64, rate money.ExchangeRate, inv payment.Invoice) (*payment.Payment, error) {
+	paymentID := common.PaymentID(fmt.Sprintf("pay_%d", time.Now().UnixNano()))
+	pay, err := payment.NewPaymentWithCurrency(paymentID, req.OrderID, req.RestaurantID, common.PaymentMethodTypeLightning, sats, money.SAT)
+	if err != nil {
+		return nil, err
+	}
+	if err := pay.AttachInvoice(inv); err != nil {
+		return nil, err
+	}
+	pay.FXRate = rate
+	return pay, nil
+}
+
+// ValidatePayment asks the node whether the charge's invoice has settled.
+// Returns payment.ErrInvoiceNotSettled while it is still open.
+func (p *LightningPaymentMethod) ValidatePayment(ctx context.Context, paymentID common.PaymentID) error {
+	if p.repo == nil {
+		return errors.New("lightning payment method has no repository")
+	}
+	pay, err := p.repo.FindByID(paymentID)
+	if err != nil {
+		return err
+	}
+	if pay.Status == common.PaymentStatusPaid {
+		return nil
+	}
+	state, err := p.node.LookupInvoice(ctx, pay.PaymentHash)
+	if err != nil {
+		return err
+	}
+	if state != payment.InvoiceStateSettled {
+		return payment.ErrInvoiceNotSettled
+	}
+	return nil
+}
+
+func (p *LightningPaymentMethod) GetPaymentMethodType() common.PaymentMethodType {
+	return common.PaymentMethodTypeLightning
+}
@@ -0,0 +1,129 @@
+package adapters
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// DefaultSettlementPollInterval is how often pending invoices are checked
+// against the node when no interval is configured.
+const DefaultSettlementPollInterval = 3 * time.Second
+
+// SettledFunc is invoked with a payment the watcher has marked paid, before
+// it is persisted. The composition root uses it to mark the order paid. When
+// it fails the payment stays pending and is settled again on a later pass,
+// so it must be idempotent.
+type SettledFunc func(ctx context.Context, p *payment.Payment) error
+
+// LightningSettlementWatcher polls every pending Lightning payment, settles
+// the ones that were paid and expires the ones whose invoice lapsed. Node
+// invoices are looked up by payment hash; invoices fetched from a Lightning
+// address are checked through their LUD-21 verify URL. Polling (rather than a
+// node subscription) keeps every backend behind the same small ports and
+// survives restarts, since the work queue is the payments table itself.
+type LightningSettlementWatcher struct {
+	repo      payment.Repository
+	node      payment.LightningNode
+	lnurl     payment.LNURLPayer
+	interval  time.Duration
+	onSettled SettledFunc
+	logger    *slog.Logger
+}
+
+// NewLightningSettlementWatcher builds a watcher. Either node or lnurl may be
+// nil when that flavour of Lightning checkout is not in use.
+func NewLightningSettlementWatcher(repo payment.Repository, node payment.LightningNode, lnurl payment.LNURLPayer, interval time.Duration, onSettled SettledFunc, logger *slog.Logger) *LightningSettlementWatcher {
+	if repo == nil {
+		panic("nil payment.Repository")
+	}
+	if node == nil && lnurl == nil {
+		panic("nil payment.LightningNode and payment.LNURLPayer")
+	}
+	if interval <= 0 {
+		interval = DefaultSettlementPollInterval
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LightningSettlementWatcher{repo: repo, node: node, lnurl: lnurl, interval: interval, onSettled: onSettled, logger: logger}
+}
+
+// Run polls until ctx is cancelled.
+func (w *LightningSettlementWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.CheckPending(ctx)
+		}
+	}
+}
+
+// CheckPending runs a single reconciliation pass. Errors are logged per
+// payment so one unreachable invoice does not block the rest.
+func (w *LightningSettlementWatcher) CheckPending(ctx context.Context) {
+	pending, err := w.repo.FindPendingByMethod(common.PaymentMethodTypeLightning)
+	if err != nil {
+		w.logger.ErrorContext(ctx, "lightning watcher: list pending payments", "error", err)
+		return
+	}
+	for _, p := range pending {
+		if err := w.check(ctx, p); err != nil {
+			w.logger.WarnContext(ctx, "lightning watcher: check payment", "paymentID", p.ID, "orderID", p.OrderID, "error", err)
+		}
+	}
+}
+
+func (w *LightningSettlementWatcher) check(ctx context.Context, p *payment.Payment) error {
+	state, err := w.lookup(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case state == payment.InvoiceStateSettled:
+		// The order is settled first: once the payment is stored as paid it
+		// is no longer polled, so a failure there would leave a paid
+		// invoice on an unpaid order. The copy keeps a failed attempt from
+		// touching the stored payment, which the memory repository shares.
+		settled := *p
+		if err := settled.MarkPaid(p.OrderID); err != nil {
+			return err
+		}
+		if w.onSettled != nil {
+			if err := w.onSettled(ctx, &settled); err != nil {
+				return err
+			}
+		}
+		if err := w.repo.Update(&settled); err != nil {
+			return err
+		}
+		w.logger.InfoContext(ctx, "lightning invoice settled", "paymentID", p.ID, "orderID", p.OrderID)
+	case state == payment.InvoiceStateCanceled || p.InvoiceExpiredAt(time.Now()):
+		if err := p.MarkExpired(); err != nil {
+			return err
+		}
+		return w.repo.Update(p)
+	}
+	return nil
+}
+
+// lookup returns "" for payments the watcher has no way to check; those
+// still expire with their invoice.
+func (w *LightningSettlementWatcher) lookup(ctx context.Context, p *payment.Payment) (payment.InvoiceState, error) {
+	switch {
+	case p.VerifyURL != "" && w.lnurl != nil:
+		return w.lnurl.Verify(ctx, p.VerifyURL)
+	case p.VerifyURL == "" && p.PaymentHash != "" && w.node != nil:
+		return w.node.LookupInvoice(ctx, p.PaymentHash)
+	default:
+		return "", nil
+	}
+}
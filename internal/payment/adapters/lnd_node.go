@@ -0,0 +1,171 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// LNDConfig points the LND REST adapter at a node. MacaroonHex should be an
+// invoice macaroon (create + read invoices only), never admin.
+type LNDConfig struct {
+	BaseURL     string
+	MacaroonHex string
+	// TLSCertPath is LND's tls.cert. Leave empty when the REST endpoint sits
+	// behind a publicly trusted certificate.
+	TLSCertPath string
+}
+
+// LNDNode implements payment.LightningNode against LND's REST gateway.
+type LNDNode struct {
+	baseURL  string
+	macaroon string
+	client   *http.Client
+}
+
+func NewLNDNode(cfg LNDConfig) (*LNDNode, error) {
+	if strings.TrimSpace(cfg.BaseURL) == "" {
+		return nil, errors.New("lnd: base URL is required")
+	}
+	client, err := newNodeHTTPClient(cfg.TLSCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("lnd: %w", err)
+	}
+	return &LNDNode{
+		baseURL:  strings.TrimRight(cfg.BaseURL, "/"),
+		macaroon: strings.TrimSpace(cfg.MacaroonHex),
+		client:   client,
+	}, nil
+}
+
+type lndAddInvoiceRequest struct {
+	Value  string `json:"value"`
+	Memo   string `json:"memo"`
+	Expiry string `json:"expiry"`
+}
+
+type lndAddInvoiceResponse struct {
+	RHash          string `json:"r_hash"`
+	PaymentRequest string `json:"payment_request"`
+}
+
+type lndInvoice struct {
+	State string `json:"state"`
+}
+
+func (n *LNDNode) CreateInvoice(ctx context.Context, amountSats int64, memo string, expiry time.Duration) (payment.Invoice, error) {
+	body := lndAddInvoiceRequest{
+		Value:  strconv.FormatInt(amountSats, 10),
+		Memo:   memo,
+		Expiry: strconv.FormatInt(int64(expiry.Seconds()), 10),
+	}
+	var resp lndAddInvoiceResponse
+	if err := n.do(ctx, http.MethodPost, "/v1/invoices", body, &resp); err != nil {
+		return payment.Invoice{}, err
+	}
+	// grpc-gateway encodes bytes fields as standard base64.
+	rawHash, err := base64.StdEncoding.DecodeString(resp.RHash)
+	if err != nil {
+		return payment.Invoice{}, fmt.Errorf("lnd: decode r_hash: %w", err)
+	}
+	return payment.Invoice{
+		PaymentRequest: resp.PaymentRequest,
+		PaymentHash:    hex.EncodeToString(rawHash),
+		AmountSats:     amountSats,
+		ExpiresAt:      time.Now().Add(expiry),
+	}, nil
+}
+
+func (n *LNDNode) LookupInvoice(ctx context.Context, paymentHash string) (payment.InvoiceState, error) {
+	var inv lndInvoice
+	if err := n.do(ctx, http.MethodGet, "/v1/invoice/"+paymentHash, nil, &inv); err != nil {
+		return "", err
+	}
+	switch inv.State {
+	case "SETTLED":
+		return payment.InvoiceStateSettled, nil
+	case "CANCELED":
+		return payment.InvoiceStateCanceled, nil
+	default:
+		// OPEN and ACCEPTED (held HTLC) are both still awaiting settlement.
+		return payment.InvoiceStateOpen, nil
+	}
+}
+
+func (n *LNDNode) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, n.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.macaroon != "" {
+		req.Header.Set("Grpc-Metadata-macaroon", n.macaroon)
+	}
+	return doNodeRequest(n.client, req, "lnd", out)
+}
+
+// doNodeRequest executes req and decodes a JSON body into out, turning any
+// non-2xx status into an error that carries the node's message.
+func doNodeRequest(client *http.Client, req *http.Request, backend string, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", backend, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s %s: status %d: %s", backend, req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s: decode response: %w", backend, err)
+	}
+	return nil
+}
+
+// newNodeHTTPClient returns an HTTP client that trusts certPath in addition
+// to the system roots. Lightning nodes typically serve REST over a
+// self-signed certificate.
+func newNodeHTTPClient(certPath string) (*http.Client, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	if strings.TrimSpace(certPath) == "" {
+		return client, nil
+	}
+	pem, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("read tls cert: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", certPath)
+	}
+	client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}
+	return client, nil
+}
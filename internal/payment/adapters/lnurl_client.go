@@ -0,0 +1,287 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// lnurlInvoiceExpiry is assumed for fetched invoices: LNURL responses do not
+// carry an expiry and we do not decode the BOLT11 expiry tag.
+const lnurlInvoiceExpiry = 10 * time.Minute
+
+var lightningAddressPattern = regexp.MustCompile(`^[a-z0-9\-_.+]+@[a-z0-9\-.]+(:[0-9]+)?$`)
+
+// errLocalDestination is returned when a wallet URL resolves to an address
+// inside our own network.
+var errLocalDestination = errors.New("lnurl: refusing to connect to a local or private address")
+
+// LNURLClient implements payment.LNURLPayer. It never holds funds: invoices
+// are issued by the merchant's wallet and paid directly by the customer.
+//
+// Every URL it fetches comes from a merchant or from their wallet, so it
+// refuses loopback, private and link-local destinations, checked on the
+// resolved address when dialling so DNS cannot point it inward. allowLocal
+// lifts that, along with the LUD-01 plain-http exception for loopback
+// hosts, for development against a wallet on this machine.
+type LNURLClient struct {
+	client     *http.Client
+	allowLocal bool
+}
+
+func NewLNURLClient(allowLocal bool) *LNURLClient {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowLocal {
+		dialer.Control = refuseLocalAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialled instead of the wallet, hiding its address.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	c := &LNURLClient{allowLocal: allowLocal}
+	c.client = &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("lnurl: too many redirects")
+			}
+			_, err := c.checkURL(req.URL.String())
+			return err
+		},
+	}
+	return c
+}
+
+// refuseLocalAddress is a net.Dialer Control that rejects any destination
+// that is not a public address.
+func refuseLocalAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return errLocalDestination
+	}
+	return nil
+}
+
+// lnurlPayParams is the LUD-06 payRequest response.
+type lnurlPayParams struct {
+	Tag            string `json:"tag"`
+	Callback       string `json:"callback"`
+	MinSendable    int64  `json:"minSendable"`
+	MaxSendable    int64  `json:"maxSendable"`
+	CommentAllowed int    `json:"commentAllowed"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+}
+
+type lnurlInvoiceResponse struct {
+	PR     string `json:"pr"`
+	Verify string `json:"verify"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type lnurlVerifyResponse struct {
+	Status   string `json:"status"`
+	Reason   string `json:"reason"`
+	Settled  bool   `json:"settled"`
+	Preimage string `json:"preimage"`
+}
+
+// ResolvePayURL turns a Lightning address (LUD-16), a bech32 LNURL (LUD-01)
+// or a plain LNURL-pay URL into the URL of the payRequest endpoint.
+func ResolvePayURL(target string) (string, error) {
+	target = strings.TrimPrefix(strings.TrimSpace(target), "lightning:")
+	lower := strings.ToLower(target)
+	switch {
+	case lightningAddressPattern.MatchString(lower):
+		user, domain, _ := strings.Cut(lower, "@")
+		return lnurlScheme(domain) + "://" + domain + "/.well-known/lnurlp/" + user, nil
+	case strings.HasPrefix(lower, "lnurl1"):
+		hrp, data, err := bech32Decode(lower)
+		if err != nil || hrp != "lnurl" {
+			return "", fmt.Errorf("lnurl: invalid bech32 LNURL: %w", err)
+		}
+		raw, err := convertBits(data, 5, 8, false)
+		if err != nil {
+			return "", fmt.Errorf("lnurl: invalid bech32 LNURL: %w", err)
+		}
+		return checkLNURL(string(raw))
+	case strings.HasPrefix(lower, "https://"), strings.HasPrefix(lower, "http://"):
+		return checkLNURL(target)
+	default:
+		return "", fmt.Errorf("lnurl: %q is not a Lightning address or LNURL", target)
+	}
+}
+
+// checkLNURL enforces LUD-01: https everywhere except onion and loopback
+// hosts, where plain http is allowed.
+func checkLNURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("lnurl: invalid URL %q", raw)
+	}
+	if u.Scheme != "https" && lnurlScheme(u.Host) != "http" {
+		return "", fmt.Errorf("lnurl: %q must use https", raw)
+	}
+	return u.String(), nil
+}
+
+// checkURL is checkLNURL for a URL about to be fetched: plain http to a
+// loopback host is only allowed with allowLocal.
+func (c *LNURLClient) checkURL(raw string) (string, error) {
+	checked, err := checkLNURL(raw)
+	if err != nil {
+		return "", err
+	}
+	u, _ := url.Parse(checked)
+	if !c.allowLocal && u.Scheme != "https" && !strings.HasSuffix(u.Hostname(), ".onion") {
+		return "", fmt.Errorf("lnurl: %q must use https", raw)
+	}
+	return checked, nil
+}
+
+func lnurlScheme(hostport string) string {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".onion") {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return "https"
+}
+
+// FetchInvoice resolves target, requests an invoice for amountSats and
+// checks the returned invoice bills exactly that amount (LUD-06 requires the
+// wallet side to verify this; here the server is that side).
+func (c *LNURLClient) FetchInvoice(ctx context.Context, target string, amountSats int64, comment string) (payment.Invoice, error) {
+	payURL, err := ResolvePayURL(target)
+	if err != nil {
+		return payment.Invoice{}, err
+	}
+	var params lnurlPayParams
+	if err := c.getJSON(ctx, payURL, &params); err != nil {
+		return payment.Invoice{}, err
+	}
+	if params.Status == "ERROR" {
+		return payment.Invoice{}, fmt.Errorf("lnurl: %s", params.Reason)
+	}
+	if params.Tag != "payRequest" || params.Callback == "" {
+		return payment.Invoice{}, errors.New("lnurl: endpoint is not a payRequest")
+	}
+	amountMsat := amountSats * 1000
+	if amountMsat < params.MinSendable || (params.MaxSendable > 0 && amountMsat > params.MaxSendable) {
+		return payment.Invoice{}, fmt.Errorf("lnurl: amount %d sats outside the wallet's limits (%d–%d msat)", amountSats, params.MinSendable, params.MaxSendable)
+	}
+
+	callback, err := url.Parse(params.Callback)
+	if err != nil {
+		return payment.Invoice{}, fmt.Errorf("lnurl: invalid callback: %w", err)
+	}
+	if _, err := c.checkURL(callback.String()); err != nil {
+		return payment.Invoice{}, err
+	}
+	q := callback.Query()
+	q.Set("amount", strconv.FormatInt(amountMsat, 10))
+	if comment != "" && params.CommentAllowed > 0 {
+		if len(comment) > params.CommentAllowed {
+			comment = comment[:params.CommentAllowed]
+		}
+		q.Set("comment", comment)
+	}
+	callback.RawQuery = q.Encode()
+
+	var resp lnurlInvoiceResponse
+	if err := c.getJSON(ctx, callback.String(), &resp); err != nil {
+		return payment.Invoice{}, err
+	}
+	if resp.Status == "ERROR" {
+		return payment.Invoice{}, fmt.Errorf("lnurl: %s", resp.Reason)
+	}
+	if resp.Verify == "" {
+		return payment.Invoice{}, errors.New("lnurl: wallet does not support LUD-21 verify, payments cannot be confirmed")
+	}
+	if _, err := c.checkURL(resp.Verify); err != nil {
+		return payment.Invoice{}, err
+	}
+	decoded, err := decodeBolt11(resp.PR)
+	if err != nil {
+		return payment.Invoice{}, fmt.Errorf("lnurl: %w", err)
+	}
+	if decoded.AmountMsat != amountMsat {
+		return payment.Invoice{}, fmt.Errorf("lnurl: invoice amount %d msat does not match requested %d msat", decoded.AmountMsat, amountMsat)
+	}
+	return payment.Invoice{
+		PaymentRequest: resp.PR,
+		PaymentHash:    decoded.PaymentHash,
+		AmountSats:     amountSats,
+		ExpiresAt:      time.Now().Add(lnurlInvoiceExpiry),
+		VerifyURL:      resp.Verify,
+	}, nil
+}
+
+// Verify polls a LUD-21 verify URL.
+func (c *LNURLClient) Verify(ctx context.Context, verifyURL string) (payment.InvoiceState, error) {
+	var resp lnurlVerifyResponse
+	if err := c.getJSON(ctx, verifyURL, &resp); err != nil {
+		return "", err
+	}
+	if resp.Status == "ERROR" {
+		return "", fmt.Errorf("lnurl verify: %s", resp.Reason)
+	}
+	if resp.Settled {
+		return payment.InvoiceStateSettled, nil
+	}
+	return payment.InvoiceStateOpen, nil
+}
+
+func (c *LNURLClient) getJSON(ctx context.Context, rawURL string, out any) error {
+	checked, err := c.checkURL(rawURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checked, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("lnurl: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return fmt.Errorf("lnurl: %w", err)
+	}
+	// LUD-06 errors come back as {"status":"ERROR"} with any status code, so
+	// decode first and only then fall back to the HTTP status.
+	if err := json.Unmarshal(body, out); err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("lnurl: GET %s: status %d", req.URL.Path, resp.StatusCode)
+		}
+		return fmt.Errorf("lnurl: decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// LightningAddressLookup returns the Lightning address or LNURL-pay endpoint
+// a restaurant has linked, or "" when none is set.
+type LightningAddressLookup func(ctx context.Context, restaurantID common.RestaurantID) (string, error)
+
+// LNURLPayMethod bills orders through the restaurant's own Lightning address
+// (non-custodial): the invoice is fetched from the merchant's wallet and
+// settlement is confirmed through the wallet's LUD-21 verify URL.
+type LNURLPayMethod struct {
+	payer     payment.LNURLPayer
+	converter money.Converter
+	repo      payment.Repository
+	addresses LightningAddressLookup
+}
+
+func NewLNURLPayMethod(payer payment.LNURLPayer, converter money.Converter, repo payment.Repository, addresses LightningAddressLookup) *LNURLPayMethod {
+	if payer == nil {
+		panic("nil payment.LNURLPayer")
+	}
+	if addresses == nil {
+		panic("nil LightningAddressLookup")
+	}
+	if converter == nil {
+		converter = money.NoopConverter{}
+	}
+	return &LNURLPayMethod{payer: payer, converter: converter, repo: repo, addresses: addresses}
+}
+
+func (p *LNURLPayMethod) ProcessPayment(ctx context.Context, orderID common.OrderID, restaurantID common.RestaurantID, amount money.Money) (*payment.Payment, error) {
+	return p.IssueInvoice(ctx, payment.InvoiceRequest{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+		Amount:       amount,
+		Memo:         fmt.Sprintf("Order %s", orderID),
+	})
+}
+
+// IssueInvoice fetches an invoice from the restaurant's Lightning address with
+// req.Memo as the LUD-12 comment. Returns payment.ErrNoLightningAddress when
+// the restaurant has none.
+func (p *LNURLPayMethod) IssueInvoice(ctx context.Context, req payment.InvoiceRequest) (*payment.Payment, error) {
+	address, err := p.addresses(ctx, req.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(address) == "" {
+		return nil, payment.ErrNoLightningAddress
+	}
+	sats, rate, err := toSats(ctx, p.converter, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := p.payer.FetchInvoice(ctx, address, sats, req.Memo)
+	if err != nil {
+		return nil, fmt.Errorf("fetch invoice: %w", err)
+	}
+	return newLightningPayment(req, sats, rate, inv)
+}
+
+// ValidatePayment checks the charge's invoice against its verify URL.
+// Returns payment.ErrInvoiceNotSettled while it is unpaid.
+func (p *LNURLPayMethod) ValidatePayment(ctx context.Context, paymentID common.PaymentID) error {
+	if p.repo == nil {
+		return errors.New("lnurl payment method has no repository")
+	}
+	pay, err := p.repo.FindByID(paymentID)
+	if err != nil {
+		return err
+	}
+	if pay.Status == common.PaymentStatusPaid {
+		return nil
+	}
+	if pay.VerifyURL == "" {
+		return errors.New("payment has no verify URL")
+	}
+	state, err := p.payer.Verify(ctx, pay.VerifyURL)
+	if err != nil {
+		return err
+	}
+	if state != payment.InvoiceStateSettled {
+		return payment.ErrInvoiceNotSettled
+	}
+	return nil
+}
+
+func (p *LNURLPayMethod) GetPaymentMethodType() common.PaymentMethodType {
+	return common.PaymentMethodTypeLightning
+}
@@ -0,0 +1,93 @@
+package adapters
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// LNURLStandIn is a local stand-in for a merchant's Lightning address server
+// (LUD-06/16/21) backed by a payment.LightningNode, usually the fake node.
+// Serve it with httptest or on localhost and point a restaurant at
+// "<user>@127.0.0.1:<port>" to exercise the non-custodial checkout without
+// any network.
+type LNURLStandIn struct {
+	node payment.LightningNode
+	mux  *http.ServeMux
+
+	mu       sync.Mutex
+	comments map[string]string
+}
+
+func NewLNURLStandIn(node payment.LightningNode) *LNURLStandIn {
+	s := &LNURLStandIn{node: node, mux: http.NewServeMux(), comments: make(map[string]string)}
+	s.mux.HandleFunc("GET /.well-known/lnurlp/{user}", s.payRequest)
+	s.mux.HandleFunc("GET /lnurlp/{user}/callback", s.callback)
+	s.mux.HandleFunc("GET /lnurlp/{user}/verify/{hash}", s.verify)
+	return s
+}
+
+func (s *LNURLStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Comment returns the payer comment sent with the invoice for paymentHash.
+func (s *LNURLStandIn) Comment(paymentHash string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.comments[paymentHash]
+}
+
+func (s *LNURLStandIn) payRequest(w http.ResponseWriter, r *http.Request) {
+	user := r.PathValue("user")
+	writeLNURLJSON(w, map[string]any{
+		"tag":            "payRequest",
+		"callback":       "http://" + r.Host + "/lnurlp/" + user + "/callback",
+		"minSendable":    1000,
+		"maxSendable":    100_000_000_000,
+		"metadata":       `[["text/plain","Pay to ` + user + `"]]`,
+		"commentAllowed": 140,
+	})
+}
+
+func (s *LNURLStandIn) callback(w http.ResponseWriter, r *http.Request) {
+	amountMsat, err := strconv.ParseInt(r.URL.Query().Get("amount"), 10, 64)
+	if err != nil || amountMsat < 1000 || amountMsat%1000 != 0 {
+		writeLNURLJSON(w, map[string]string{"status": "ERROR", "reason": "invalid amount"})
+		return
+	}
+	inv, err := s.node.CreateInvoice(r.Context(), amountMsat/1000, r.URL.Query().Get("comment"), time.Hour)
+	if err != nil {
+		writeLNURLJSON(w, map[string]string{"status": "ERROR", "reason": err.Error()})
+		return
+	}
+	s.mu.Lock()
+	s.comments[inv.PaymentHash] = r.URL.Query().Get("comment")
+	s.mu.Unlock()
+	writeLNURLJSON(w, map[string]any{
+		"pr":     inv.PaymentRequest,
+		"routes": []any{},
+		"verify": "http://" + r.Host + "/lnurlp/" + r.PathValue("user") + "/verify/" + inv.PaymentHash,
+	})
+}
+
+func (s *LNURLStandIn) verify(w http.ResponseWriter, r *http.Request) {
+	state, err := s.node.LookupInvoice(r.Context(), r.PathValue("hash"))
+	if err != nil {
+		writeLNURLJSON(w, map[string]string{"status": "ERROR", "reason": "Not found"})
+		return
+	}
+	writeLNURLJSON(w, map[string]any{
+		"status":  "OK",
+		"settled": state == payment.InvoiceStateSettled,
+	})
+}
+
+func writeLNURLJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
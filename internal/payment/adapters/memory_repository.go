@@ -2,27 +2,54 @@ package adapters
 
 import (
 	"errors"
+	"sort"
 	"sync"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/outbox"
 	"bitmerchant/internal/payment/domain/payment"
 )
 
 type MemoryPaymentRepository struct {
 	mu       sync.RWMutex
 	payments map[common.PaymentID]*payment.Payment
+	outbox   *outbox.MemoryStore
 }
 
 func NewMemoryPaymentRepository() *MemoryPaymentRepository {
+	return NewMemoryPaymentRepositoryWithOutbox(outbox.NewMemoryStore())
+}
+
+// NewMemoryPaymentRepositoryWithOutbox writes recorded events to store, so
+// one relay can drain payment and order events together.
+func NewMemoryPaymentRepositoryWithOutbox(store *outbox.MemoryStore) *MemoryPaymentRepository {
 	return &MemoryPaymentRepository{
 		payments: make(map[common.PaymentID]*payment.Payment),
+		outbox:   store,
 	}
 }
 
+// Outbox returns the store the events recorded on saved payments wait in.
+func (r *MemoryPaymentRepository) Outbox() *outbox.MemoryStore {
+	return r.outbox
+}
+
+// commit writes p's encoded events to the outbox. Callers hold r.mu, so the
+// change and its events become visible together.
+func (r *MemoryPaymentRepository) commit(p *payment.Payment, msgs []outbox.Message) {
+	r.outbox.Add(msgs...)
+	p.ClearEvents()
+}
+
 func (r *MemoryPaymentRepository) Save(p *payment.Payment) error {
+	msgs, err := recordedMessages(p)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.payments[p.ID] = p
+	r.commit(p, msgs)
 	return nil
 }
 
@@ -39,12 +66,33 @@ func (r *MemoryPaymentRepository) FindByID(id common.PaymentID) (*payment.Paymen
 func (r *MemoryPaymentRepository) FindByOrderID(orderID common.OrderID) (*payment.Payment, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	// An order can accumulate several payments (e.g. an expired Lightning
+	// invoice followed by a fresh one); the most recent charge is
+	// authoritative. Refund rows hang off their charge and late tips stand
+	// apart from it; both are skipped.
+	var latest *payment.Payment
 	for _, p := range r.payments {
-		if p.OrderID == orderID {
-			return p, nil
+		if p.OrderID == orderID && !p.IsRefund() && !p.IsTip() && (latest == nil || p.CreatedAt.After(latest.CreatedAt)) {
+			latest = p
 		}
 	}
-	return nil, errors.New("payment not found")
+	if latest == nil {
+		return nil, errors.New("payment not found")
+	}
+	return latest, nil
+}
+
+func (r *MemoryPaymentRepository) FindChargesByOrderID(orderID common.OrderID) ([]*payment.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*payment.Payment
+	for _, p := range r.payments {
+		if p.OrderID == orderID && !p.IsRefund() {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
 }
 
 func (r *MemoryPaymentRepository) FindByRestaurantID(restaurantID common.RestaurantID) ([]*payment.Payment, error) {
@@ -59,12 +107,40 @@ func (r *MemoryPaymentRepository) FindByRestaurantID(restaurantID common.Restaur
 	return result, nil
 }
 
+func (r *MemoryPaymentRepository) FindByPaymentHash(paymentHash string) (*payment.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.payments {
+		if p.PaymentHash != "" && p.PaymentHash == paymentHash {
+			return p, nil
+		}
+	}
+	return nil, errors.New("payment not found")
+}
+
+func (r *MemoryPaymentRepository) FindPendingByMethod(method common.PaymentMethodType) ([]*payment.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*payment.Payment
+	for _, p := range r.payments {
+		if p.Method == method && p.Status == common.PaymentStatusPending {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
 func (r *MemoryPaymentRepository) Update(p *payment.Payment) error {
+	msgs, err := recordedMessages(p)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if _, exists := r.payments[p.ID]; !exists {
 		return errors.New("payment not found")
 	}
 	r.payments[p.ID] = p
+	r.commit(p, msgs)
 	return nil
 }
@@ -0,0 +1,79 @@
+package adapters
+
+import (
+	"sort"
+	"sync"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/payment/domain/shift"
+)
+
+type MemoryShiftRepository struct {
+	mu     sync.RWMutex
+	shifts map[common.ShiftID]*shift.Shift
+}
+
+func NewMemoryShiftRepository() *MemoryShiftRepository {
+	return &MemoryShiftRepository{shifts: make(map[common.ShiftID]*shift.Shift)}
+}
+
+func (r *MemoryShiftRepository) Save(s *shift.Shift) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shifts[s.ID] = s
+	return nil
+}
+
+func (r *MemoryShiftRepository) Update(s *shift.Shift) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.shifts[s.ID]; !ok {
+		return shift.ErrShiftNotFound
+	}
+	r.shifts[s.ID] = s
+	return nil
+}
+
+func (r *MemoryShiftRepository) FindByID(id common.ShiftID) (*shift.Shift, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.shifts[id]
+	if !ok {
+		return nil, shift.ErrShiftNotFound
+	}
+	return s, nil
+}
+
+func (r *MemoryShiftRepository) FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*shift.Shift, error) {
+	open, _ := r.FindOpenByRestaurant(restaurantID)
+	for _, s := range open {
+		if s.StaffID == staffID {
+			return s, nil
+		}
+	}
+	return nil, shift.ErrShiftNotFound
+}
+
+func (r *MemoryShiftRepository) FindOpenByRestaurant(restaurantID common.RestaurantID) ([]*shift.Shift, error) {
+	all, _ := r.FindByRestaurant(restaurantID)
+	var open []*shift.Shift
+	for _, s := range all {
+		if s.IsOpen() {
+			open = append(open, s)
+		}
+	}
+	return open, nil
+}
+
+func (r *MemoryShiftRepository) FindByRestaurant(restaurantID common.RestaurantID) ([]*shift.Shift, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*shift.Shift
+	for _, s := range r.shifts {
+		if s.RestaurantID == restaurantID {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].OpenedAt.After(result[j].OpenedAt) })
+	return result, nil
+}
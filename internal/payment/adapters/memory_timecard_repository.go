@@ -0,0 +1,79 @@
+package adapters
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/payment/domain/timecard"
+)
+
+type MemoryTimecardRepository struct {
+	mu    sync.RWMutex
+	cards map[common.TimecardID]*timecard.Timecard
+}
+
+func NewMemoryTimecardRepository() *MemoryTimecardRepository {
+	return &MemoryTimecardRepository{cards: make(map[common.TimecardID]*timecard.Timecard)}
+}
+
+func (r *MemoryTimecardRepository) Save(t *timecard.Timecard) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cards[t.ID] = t
+	return nil
+}
+
+func (r *MemoryTimecardRepository) Update(t *timecard.Timecard) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.cards[t.ID]; !ok {
+		return timecard.ErrNotFound
+	}
+	r.cards[t.ID] = t
+	return nil
+}
+
+func (r *MemoryTimecardRepository) FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*timecard.Timecard, error) {
+	cards, _ := r.FindByStaff(restaurantID, staffID, 0)
+	for _, t := range cards {
+		if t.IsOpen() {
+			return t, nil
+		}
+	}
+	return nil, timecard.ErrNotFound
+}
+
+func (r *MemoryTimecardRepository) FindByRestaurant(restaurantID common.RestaurantID, from, to time.Time) ([]*timecard.Timecard, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*timecard.Timecard
+	for _, t := range r.cards {
+		if t.RestaurantID != restaurantID || !t.ClockedInAt.Before(to) {
+			continue
+		}
+		if t.ClockedOutAt != nil && !t.ClockedOutAt.After(from) {
+			continue
+		}
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ClockedInAt.Before(result[j].ClockedInAt) })
+	return result, nil
+}
+
+func (r *MemoryTimecardRepository) FindByStaff(restaurantID common.RestaurantID, staffID common.UserID, limit int) ([]*timecard.Timecard, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*timecard.Timecard
+	for _, t := range r.cards {
+		if t.RestaurantID == restaurantID && t.StaffID == staffID {
+			result = append(result, t)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ClockedInAt.After(result[j].ClockedInAt) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
@@ -0,0 +1,13 @@
+package adapters
+
+import (
+	"bitmerchant/internal/common/outbox"
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// recordedMessages encodes the events recorded on p for the outbox. It runs
+// before anything is written, so an event that cannot be encoded leaves the
+// payment unsaved.
+func recordedMessages(p *payment.Payment) ([]outbox.Message, error) {
+	return outbox.NewMessages(p.Events())
+}
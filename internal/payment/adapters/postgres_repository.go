@@ -7,6 +7,7 @@ import (
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/outbox"
 	"bitmerchant/internal/payment/domain/payment"
 )
 
@@ -18,24 +19,50 @@ func NewPostgresPaymentRepository(db *sql.DB) *PostgresPaymentRepository {
 	return &PostgresPaymentRepository{db: db}
 }
 
-const paymentSelectCols = `id, order_id, restaurant_id, method, amount, COALESCE(currency, 'USD'), status, created_at, paid_at, failed_at, failure_reason`
+const paymentSelectCols = `id, order_id, restaurant_id, method, amount_minor, COALESCE(currency, 'USD'), status, created_at, paid_at, failed_at, failure_reason, payment_hash, invoice, invoice_expires_at, verify_url, tendered_amount, change_given, collected_by,
+	COALESCE(kind, 'charge'), COALESCE(refund_of, ''), COALESCE(reason, ''), refunded_at, COALESCE(bill_part_id, ''),
+	COALESCE(fx_from, ''), COALESCE(fx_to, ''), COALESCE(fx_rate, 0), COALESCE(fx_source, ''), fx_as_of,
+	COALESCE(rounding_adjustment, 0)`
 
 func (r *PostgresPaymentRepository) Save(p *payment.Payment) error {
 	currency := p.Currency
 	if currency.IsZero() {
 		currency = money.USD
 	}
-	amountMinor := money.FromMajor(p.Amount, currency).Amount
-	_, err := r.db.Exec(
-		`INSERT INTO payments (id, order_id, restaurant_id, method, amount, currency, amount_minor, status, created_at, paid_at, failed_at, failure_reason)
-		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+	msgs, err := recordedMessages(p)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(
+		`INSERT INTO payments (id, order_id, restaurant_id, method, currency, amount_minor, status, created_at, paid_at, failed_at, failure_reason, payment_hash, invoice, invoice_expires_at, verify_url, tendered_amount, change_given, collected_by, kind, refund_of, reason, refunded_at, bill_part_id,
+		   fx_from, fx_to, fx_rate, fx_source, fx_as_of, rounding_adjustment)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29)
 		 ON CONFLICT (id) DO UPDATE SET
 		   order_id=EXCLUDED.order_id, status=EXCLUDED.status, paid_at=EXCLUDED.paid_at,
-		   failed_at=EXCLUDED.failed_at, failure_reason=EXCLUDED.failure_reason`,
+		   failed_at=EXCLUDED.failed_at, failure_reason=EXCLUDED.failure_reason,
+		   payment_hash=EXCLUDED.payment_hash, invoice=EXCLUDED.invoice, invoice_expires_at=EXCLUDED.invoice_expires_at,
+		   verify_url=EXCLUDED.verify_url, tendered_amount=EXCLUDED.tendered_amount,
+		   change_given=EXCLUDED.change_given, collected_by=EXCLUDED.collected_by,
+		   reason=EXCLUDED.reason, refunded_at=EXCLUDED.refunded_at,
+		   rounding_adjustment=EXCLUDED.rounding_adjustment`,
 		string(p.ID), string(p.OrderID), string(p.RestaurantID),
-		string(p.Method), p.Amount, currency.Code, amountMinor, string(p.Status),
-		p.CreatedAt, p.PaidAt, p.FailedAt, p.FailureReason)
-	return err
+		string(p.Method), currency.Code, p.Amount, string(p.Status),
+		p.CreatedAt, p.PaidAt, p.FailedAt, p.FailureReason,
+		p.PaymentHash, p.Invoice, p.InvoiceExpiresAt, p.VerifyURL,
+		p.TenderedAmount, p.ChangeGiven, string(p.CollectedBy),
+		string(paymentKind(p)), string(p.RefundOf), p.Reason, p.RefundedAt, string(p.BillPartID),
+		p.FXRate.From, p.FXRate.To, p.FXRate.Rate, p.FXRate.Source, fxAsOf(p.FXRate),
+		p.RoundingAdjustment)
+	if err != nil {
+		return err
+	}
+	return r.commit(tx, p, msgs)
 }
 
 func (r *PostgresPaymentRepository) FindByID(id common.PaymentID) (*payment.Payment, error) {
@@ -48,14 +75,26 @@ func (r *PostgresPaymentRepository) FindByID(id common.PaymentID) (*payment.Paym
 func (r *PostgresPaymentRepository) FindByOrderID(orderID common.OrderID) (*payment.Payment, error) {
 	row := r.db.QueryRow(
 		`SELECT `+paymentSelectCols+`
-		 FROM payments WHERE order_id = $1 LIMIT 1`, string(orderID))
+		 FROM payments WHERE order_id = $1 AND COALESCE(kind, 'charge') = 'charge'
+		 ORDER BY created_at DESC LIMIT 1`, string(orderID))
 	return scanPayment(row)
 }
 
+func (r *PostgresPaymentRepository) FindChargesByOrderID(orderID common.OrderID) ([]*payment.Payment, error) {
+	return r.queryPayments(
+		`SELECT `+paymentSelectCols+`
+		 FROM payments WHERE order_id = $1 AND COALESCE(kind, 'charge') IN ('charge', 'tip')
+		 ORDER BY created_at`, string(orderID))
+}
+
 func (r *PostgresPaymentRepository) FindByRestaurantID(restaurantID common.RestaurantID) ([]*payment.Payment, error) {
-	rows, err := r.db.Query(
+	return r.queryPayments(
 		`SELECT `+paymentSelectCols+`
 		 FROM payments WHERE restaurant_id = $1`, string(restaurantID))
+}
+
+func (r *PostgresPaymentRepository) queryPayments(query string, args ...any) ([]*payment.Payment, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +110,40 @@ func (r *PostgresPaymentRepository) FindByRestaurantID(restaurantID common.Resta
 	return result, rows.Err()
 }
 
+func (r *PostgresPaymentRepository) FindByPaymentHash(paymentHash string) (*payment.Payment, error) {
+	row := r.db.QueryRow(
+		`SELECT `+paymentSelectCols+`
+		 FROM payments WHERE payment_hash = $1 AND payment_hash <> '' LIMIT 1`, paymentHash)
+	return scanPayment(row)
+}
+
+func (r *PostgresPaymentRepository) FindPendingByMethod(method common.PaymentMethodType) ([]*payment.Payment, error) {
+	return r.queryPayments(
+		`SELECT `+paymentSelectCols+`
+		 FROM payments WHERE method = $1 AND status = $2 ORDER BY created_at`,
+		string(method), string(common.PaymentStatusPending))
+}
+
 func (r *PostgresPaymentRepository) Update(p *payment.Payment) error {
-	result, err := r.db.Exec(
-		`UPDATE payments SET order_id=$2, status=$3, paid_at=$4, failed_at=$5, failure_reason=$6 WHERE id=$1`,
-		string(p.ID), string(p.OrderID), string(p.Status), p.PaidAt, p.FailedAt, p.FailureReason)
+	msgs, err := recordedMessages(p)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(
+		`UPDATE payments SET order_id=$2, status=$3, paid_at=$4, failed_at=$5, failure_reason=$6,
+		   payment_hash=$7, invoice=$8, invoice_expires_at=$9, verify_url=$10,
+		   tendered_amount=$11, change_given=$12, collected_by=$13,
+		   reason=$14, refunded_at=$15, rounding_adjustment=$16 WHERE id=$1`,
+		string(p.ID), string(p.OrderID), string(p.Status), p.PaidAt, p.FailedAt, p.FailureReason,
+		p.PaymentHash, p.Invoice, p.InvoiceExpiresAt, p.VerifyURL,
+		p.TenderedAmount, p.ChangeGiven, string(p.CollectedBy),
+		p.Reason, p.RefundedAt, p.RoundingAdjustment)
 	if err != nil {
 		return err
 	}
@@ -82,43 +151,138 @@ func (r *PostgresPaymentRepository) Update(p *payment.Payment) error {
 	if affected == 0 {
 		return errors.New("payment not found")
 	}
+	return r.commit(tx, p, msgs)
+}
+
+// commit writes p's encoded events to the outbox and commits tx with them.
+func (r *PostgresPaymentRepository) commit(tx *sql.Tx, p *payment.Payment, msgs []outbox.Message) error {
+	if err := outbox.Insert(tx, msgs); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	p.ClearEvents()
 	return nil
 }
 
 func scanPayment(row *sql.Row) (*payment.Payment, error) {
 	var (
 		id, orderID, restID, method, status string
-		amount                              float64
+		amount                              int64
 		currencyCode                        string
 		createdAt                           time.Time
-		paidAt, failedAt                    sql.NullTime
+		paidAt, failedAt, invoiceExpiresAt  sql.NullTime
 		failureReason                       sql.NullString
+		paymentHash, invoice, verifyURL     string
+		tendered, change                    int64
+		collectedBy                         string
+		kind, refundOf, reason              string
+		refundedAt                          sql.NullTime
+		billPartID                          string
+		fxFrom, fxTo, fxSource              string
+		fxRate                              float64
+		fxAsOf                              sql.NullTime
+		rounding                            int64
 	)
-	if err := row.Scan(&id, &orderID, &restID, &method, &amount, &currencyCode, &status, &createdAt, &paidAt, &failedAt, &failureReason); err != nil {
+	if err := row.Scan(&id, &orderID, &restID, &method, &amount, &currencyCode, &status, &createdAt, &paidAt, &failedAt, &failureReason, &paymentHash, &invoice, &invoiceExpiresAt, &verifyURL, &tendered, &change, &collectedBy, &kind, &refundOf, &reason, &refundedAt, &billPartID, &fxFrom, &fxTo, &fxRate, &fxSource, &fxAsOf, &rounding); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("payment not found")
 		}
 		return nil, err
 	}
-	return buildPayment(id, orderID, restID, method, amount, currencyCode, status, createdAt, paidAt, failedAt, failureReason), nil
+	p := buildPayment(id, orderID, restID, method, amount, currencyCode, status, createdAt, paidAt, failedAt, failureReason)
+	applyInvoice(p, paymentHash, invoice, invoiceExpiresAt, verifyURL)
+	applyCollection(p, tendered, change, collectedBy)
+	applyRefund(p, kind, refundOf, reason, refundedAt)
+	p.BillPartID = common.BillPartID(billPartID)
+	p.FXRate = buildFXRate(fxFrom, fxTo, fxRate, fxSource, fxAsOf.Time)
+	p.RoundingAdjustment = rounding
+	return p, nil
 }
 
 func scanPaymentRows(rows *sql.Rows) (*payment.Payment, error) {
 	var (
 		id, orderID, restID, method, status string
-		amount                              float64
+		amount                              int64
 		currencyCode                        string
 		createdAt                           time.Time
-		paidAt, failedAt                    sql.NullTime
+		paidAt, failedAt, invoiceExpiresAt  sql.NullTime
 		failureReason                       sql.NullString
+		paymentHash, invoice, verifyURL     string
+		tendered, change                    int64
+		collectedBy                         string
+		kind, refundOf, reason              string
+		refundedAt                          sql.NullTime
+		billPartID                          string
+		fxFrom, fxTo, fxSource              string
+		fxRate                              float64
+		fxAsOf                              sql.NullTime
+		rounding                            int64
 	)
-	if err := rows.Scan(&id, &orderID, &restID, &method, &amount, &currencyCode, &status, &createdAt, &paidAt, &failedAt, &failureReason); err != nil {
+	if err := rows.Scan(&id, &orderID, &restID, &method, &amount, &currencyCode, &status, &createdAt, &paidAt, &failedAt, &failureReason, &paymentHash, &invoice, &invoiceExpiresAt, &verifyURL, &tendered, &change, &collectedBy, &kind, &refundOf, &reason, &refundedAt, &billPartID, &fxFrom, &fxTo, &fxRate, &fxSource, &fxAsOf, &rounding); err != nil {
 		return nil, err
 	}
-	return buildPayment(id, orderID, restID, method, amount, currencyCode, status, createdAt, paidAt, failedAt, failureReason), nil
+	p := buildPayment(id, orderID, restID, method, amount, currencyCode, status, createdAt, paidAt, failedAt, failureReason)
+	applyInvoice(p, paymentHash, invoice, invoiceExpiresAt, verifyURL)
+	applyCollection(p, tendered, change, collectedBy)
+	applyRefund(p, kind, refundOf, reason, refundedAt)
+	p.BillPartID = common.BillPartID(billPartID)
+	p.FXRate = buildFXRate(fxFrom, fxTo, fxRate, fxSource, fxAsOf.Time)
+	p.RoundingAdjustment = rounding
+	return p, nil
+}
+
+func applyInvoice(p *payment.Payment, paymentHash, invoice string, expiresAt sql.NullTime, verifyURL string) {
+	p.PaymentHash = paymentHash
+	p.Invoice = invoice
+	p.VerifyURL = verifyURL
+	if expiresAt.Valid {
+		t := expiresAt.Time
+		p.InvoiceExpiresAt = &t
+	}
+}
+
+func applyCollection(p *payment.Payment, tendered, change int64, collectedBy string) {
+	p.TenderedAmount = tendered
+	p.ChangeGiven = change
+	p.CollectedBy = common.UserID(collectedBy)
+}
+
+func applyRefund(p *payment.Payment, kind, refundOf, reason string, refundedAt sql.NullTime) {
+	p.Kind = payment.Kind(kind)
+	p.RefundOf = common.PaymentID(refundOf)
+	p.Reason = reason
+	if refundedAt.Valid {
+		t := refundedAt.Time
+		p.RefundedAt = &t
+	}
+}
+
+// fxAsOf stores a missing snapshot as NULL rather than the zero time.
+func fxAsOf(r money.ExchangeRate) *time.Time {
+	if r.IsZero() {
+		return nil
+	}
+	return &r.AsOf
+}
+
+func buildFXRate(from, to string, rate float64, source string, asOf time.Time) money.ExchangeRate {
+	if from == "" || rate <= 0 {
+		return money.ExchangeRate{}
+	}
+	return money.ExchangeRate{From: from, To: to, Rate: rate, Source: source, AsOf: asOf}
+}
+
+// paymentKind defaults rows built before refunds existed to a charge.
+func paymentKind(p *payment.Payment) payment.Kind {
+	if p.Kind == "" {
+		return payment.KindCharge
+	}
+	return p.Kind
 }
 
-func buildPayment(id, orderID, restID, method string, amount float64, currencyCode, status string, createdAt time.Time, paidAt, failedAt sql.NullTime, failureReason sql.NullString) *payment.Payment {
+func buildPayment(id, orderID, restID, method string, amount int64, currencyCode, status string, createdAt time.Time, paidAt, failedAt sql.NullTime, failureReason sql.NullString) *payment.Payment {
 	currency, err := money.Parse(currencyCode)
 	if err != nil {
 		currency = money.USD
@@ -0,0 +1,143 @@
+package adapters
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/domain/shift"
+)
+
+type PostgresShiftRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresShiftRepository(db *sql.DB) *PostgresShiftRepository {
+	return &PostgresShiftRepository{db: db}
+}
+
+const shiftSelectCols = `id, restaurant_id, staff_id, currency, opening_float, movements, status, opened_at, closed_at, closed_by, counted_amount, expected_amount, note`
+
+func (r *PostgresShiftRepository) Save(s *shift.Shift) error {
+	movements, err := json.Marshal(shiftMovements(s))
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(
+		`INSERT INTO cash_shifts (`+shiftSelectCols+`)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`,
+		string(s.ID), string(s.RestaurantID), string(s.StaffID), s.Currency.Code, s.OpeningFloat, movements,
+		string(s.Status), s.OpenedAt, s.ClosedAt, string(s.ClosedBy), s.CountedAmount, s.ExpectedAmount, s.Note)
+	return err
+}
+
+func (r *PostgresShiftRepository) Update(s *shift.Shift) error {
+	movements, err := json.Marshal(shiftMovements(s))
+	if err != nil {
+		return err
+	}
+	result, err := r.db.Exec(
+		`UPDATE cash_shifts SET movements=$2, status=$3, closed_at=$4, closed_by=$5,
+		   counted_amount=$6, expected_amount=$7, note=$8 WHERE id=$1`,
+		string(s.ID), movements, string(s.Status), s.ClosedAt, string(s.ClosedBy),
+		s.CountedAmount, s.ExpectedAmount, s.Note)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return shift.ErrShiftNotFound
+	}
+	return nil
+}
+
+func (r *PostgresShiftRepository) FindByID(id common.ShiftID) (*shift.Shift, error) {
+	shifts, err := r.query(`SELECT `+shiftSelectCols+` FROM cash_shifts WHERE id = $1`, string(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(shifts) == 0 {
+		return nil, shift.ErrShiftNotFound
+	}
+	return shifts[0], nil
+}
+
+func (r *PostgresShiftRepository) FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*shift.Shift, error) {
+	shifts, err := r.query(
+		`SELECT `+shiftSelectCols+` FROM cash_shifts
+		 WHERE restaurant_id = $1 AND staff_id = $2 AND status = $3 ORDER BY opened_at DESC LIMIT 1`,
+		string(restaurantID), string(staffID), string(shift.StatusOpen))
+	if err != nil {
+		return nil, err
+	}
+	if len(shifts) == 0 {
+		return nil, shift.ErrShiftNotFound
+	}
+	return shifts[0], nil
+}
+
+func (r *PostgresShiftRepository) FindOpenByRestaurant(restaurantID common.RestaurantID) ([]*shift.Shift, error) {
+	return r.query(
+		`SELECT `+shiftSelectCols+` FROM cash_shifts
+		 WHERE restaurant_id = $1 AND status = $2 ORDER BY opened_at DESC`,
+		string(restaurantID), string(shift.StatusOpen))
+}
+
+func (r *PostgresShiftRepository) FindByRestaurant(restaurantID common.RestaurantID) ([]*shift.Shift, error) {
+	return r.query(
+		`SELECT `+shiftSelectCols+` FROM cash_shifts
+		 WHERE restaurant_id = $1 ORDER BY opened_at DESC`,
+		string(restaurantID))
+}
+
+func (r *PostgresShiftRepository) query(query string, args ...any) ([]*shift.Shift, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*shift.Shift
+	for rows.Next() {
+		var (
+			id, restID, staffID, currencyCode, status, closedBy, note string
+			openingFloat, counted, expected                           int64
+			movements                                                 []byte
+			openedAt                                                  time.Time
+			closedAt                                                  sql.NullTime
+		)
+		if err := rows.Scan(&id, &restID, &staffID, &currencyCode, &openingFloat, &movements, &status, &openedAt, &closedAt, &closedBy, &counted, &expected, &note); err != nil {
+			return nil, err
+		}
+		currency, err := money.Parse(currencyCode)
+		if err != nil {
+			currency = money.USD
+		}
+		s := &shift.Shift{
+			ID: common.ShiftID(id), RestaurantID: common.RestaurantID(restID), StaffID: common.UserID(staffID),
+			Currency: currency, OpeningFloat: openingFloat, Status: shift.Status(status), OpenedAt: openedAt,
+			ClosedBy: common.UserID(closedBy), CountedAmount: counted, ExpectedAmount: expected, Note: note,
+		}
+		if closedAt.Valid {
+			t := closedAt.Time
+			s.ClosedAt = &t
+		}
+		if len(movements) > 0 {
+			if err := json.Unmarshal(movements, &s.Movements); err != nil {
+				return nil, errors.New("decode shift movements: " + err.Error())
+			}
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// shiftMovements never returns nil so the JSONB column always holds an array.
+func shiftMovements(s *shift.Shift) []shift.Movement {
+	if s.Movements == nil {
+		return []shift.Movement{}
+	}
+	return s.Movements
+}
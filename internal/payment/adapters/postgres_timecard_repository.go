@@ -0,0 +1,100 @@
+package adapters
+
+import (
+	"database/sql"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/payment/domain/timecard"
+)
+
+type PostgresTimecardRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTimecardRepository(db *sql.DB) *PostgresTimecardRepository {
+	return &PostgresTimecardRepository{db: db}
+}
+
+const timecardSelectCols = `id, restaurant_id, staff_id, role, clocked_in_at, clocked_out_at`
+
+func (r *PostgresTimecardRepository) Save(t *timecard.Timecard) error {
+	_, err := r.db.Exec(
+		`INSERT INTO staff_timecards (`+timecardSelectCols+`) VALUES ($1,$2,$3,$4,$5,$6)`,
+		string(t.ID), string(t.RestaurantID), string(t.StaffID), string(t.Role), t.ClockedInAt, t.ClockedOutAt)
+	return err
+}
+
+func (r *PostgresTimecardRepository) Update(t *timecard.Timecard) error {
+	result, err := r.db.Exec(`UPDATE staff_timecards SET clocked_out_at=$2 WHERE id=$1`, string(t.ID), t.ClockedOutAt)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return timecard.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresTimecardRepository) FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*timecard.Timecard, error) {
+	cards, err := r.query(
+		`SELECT `+timecardSelectCols+` FROM staff_timecards
+		 WHERE restaurant_id = $1 AND staff_id = $2 AND clocked_out_at IS NULL ORDER BY clocked_in_at DESC LIMIT 1`,
+		string(restaurantID), string(staffID))
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, timecard.ErrNotFound
+	}
+	return cards[0], nil
+}
+
+func (r *PostgresTimecardRepository) FindByRestaurant(restaurantID common.RestaurantID, from, to time.Time) ([]*timecard.Timecard, error) {
+	return r.query(
+		`SELECT `+timecardSelectCols+` FROM staff_timecards
+		 WHERE restaurant_id = $1 AND clocked_in_at < $3 AND (clocked_out_at IS NULL OR clocked_out_at > $2)
+		 ORDER BY clocked_in_at`,
+		string(restaurantID), from, to)
+}
+
+func (r *PostgresTimecardRepository) FindByStaff(restaurantID common.RestaurantID, staffID common.UserID, limit int) ([]*timecard.Timecard, error) {
+	q := `SELECT ` + timecardSelectCols + ` FROM staff_timecards
+		 WHERE restaurant_id = $1 AND staff_id = $2 ORDER BY clocked_in_at DESC`
+	args := []any{string(restaurantID), string(staffID)}
+	if limit > 0 {
+		q += ` LIMIT $3`
+		args = append(args, limit)
+	}
+	return r.query(q, args...)
+}
+
+func (r *PostgresTimecardRepository) query(query string, args ...any) ([]*timecard.Timecard, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*timecard.Timecard
+	for rows.Next() {
+		var (
+			id, restID, staffID, role string
+			clockedIn                 time.Time
+			clockedOut                sql.NullTime
+		)
+		if err := rows.Scan(&id, &restID, &staffID, &role, &clockedIn, &clockedOut); err != nil {
+			return nil, err
+		}
+		t := &timecard.Timecard{
+			ID: common.TimecardID(id), RestaurantID: common.RestaurantID(restID), StaffID: common.UserID(staffID),
+			Role: common.MemberRole(role), ClockedInAt: clockedIn,
+		}
+		if clockedOut.Valid {
+			out := clockedOut.Time
+			t.ClockedOutAt = &out
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
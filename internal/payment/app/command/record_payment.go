@@ -0,0 +1,203 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/app/event"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/shift"
+)
+
+// RecordPayment settles the order's payment when staff mark it paid and
+// records PaymentCompleted with it for the outbox. A pending payment of the
+// same method is settled in place; anything else gets a fresh payment. Only
+// a paid invoice settles Lightning, so recording an unpaid one fails with
+// ErrSettledByInvoice. Tendered is what the customer handed over in Amount's
+// currency (zero means exact). Cash payments are also booked into the
+// collector's open drawer shift. BillPartID is set when the payment covers
+// one part of a split bill; each part gets its own charge.
+// RoundingAdjustment is the cash rounding already included in Amount.
+type RecordPayment struct {
+	OrderID            common.OrderID
+	RestaurantID       common.RestaurantID
+	BillPartID         common.BillPartID
+	Method             common.PaymentMethodType
+	Amount             money.Money
+	Tendered           money.Money
+	CollectedBy        common.UserID
+	RoundingAdjustment int64
+}
+
+type RecordPaymentHandler decorator.CommandResultHandler[RecordPayment, *payment.Payment]
+
+type recordPaymentHandler struct {
+	repo   payment.Repository
+	shifts shift.Repository
+}
+
+// NewRecordPaymentHandler wires the ledger command. shifts may be nil, in
+// which case cash is not tracked against drawer shifts.
+func NewRecordPaymentHandler(repo payment.Repository, shifts shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) RecordPaymentHandler {
+	if repo == nil {
+		panic("nil payment.Repository")
+	}
+	h := recordPaymentHandler{repo: repo, shifts: shifts}
+	return decorator.ApplyCommandResultDecorators[RecordPayment, *payment.Payment](h, log, metrics)
+}
+
+func (h recordPaymentHandler) Handle(ctx context.Context, cmd RecordPayment) (*payment.Payment, error) {
+	if !cmd.Amount.IsPositive() {
+		return nil, errors.New("payment amount must be greater than 0")
+	}
+	method := cmd.Method
+	if method == "" {
+		method = common.PaymentMethodTypeCash
+	}
+
+	existing, err := latestCharge(h.repo, cmd.OrderID, cmd.BillPartID)
+	if err == nil && existing.Status == common.PaymentStatusPaid {
+		// Already in the ledger (e.g. a settled Lightning invoice). Booking
+		// again is a no-op unless the drawer update failed last time, in
+		// which case this retry is what puts the sale in the drawer.
+		if err := h.bookIntoDrawer(existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+	if method == common.PaymentMethodTypeLightning {
+		return nil, payment.ErrSettledByInvoice
+	}
+
+	var p *payment.Payment
+	if err == nil && existing.Status == common.PaymentStatusPending {
+		if existing.Method == method {
+			p = existing
+		} else {
+			// Customer switched tender, e.g. paid cash instead of the invoice.
+			if err := existing.MarkExpired(); err != nil {
+				return nil, err
+			}
+			if err := h.repo.Update(existing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	isNew := p == nil
+	if isNew {
+		paymentID := common.PaymentID(fmt.Sprintf("pay_%d", time.Now().UnixNano()))
+		created, err := payment.NewPaymentWithCurrency(paymentID, cmd.OrderID, cmd.RestaurantID, method, cmd.Amount.Amount, cmd.Amount.Currency)
+		if err != nil {
+			return nil, err
+		}
+		created.BillPartID = cmd.BillPartID
+		p = created
+	}
+
+	p.RoundingAdjustment = cmd.RoundingAdjustment
+	if err := p.Collect(cmd.Tendered, cmd.CollectedBy); err != nil {
+		return nil, err
+	}
+	ev := event.PaymentCompleted{
+		PaymentID:      p.ID,
+		OrderID:        p.OrderID,
+		RestaurantID:   p.RestaurantID,
+		Method:         p.Method,
+		Amount:         p.Amount,
+		Currency:       p.Money().Currency.Code,
+		TenderedAmount: p.TenderedAmount,
+		ChangeGiven:    p.ChangeGiven,
+		CollectedBy:    p.CollectedBy,
+		PaidAt:         *p.PaidAt,
+	}
+	p.Record(envelope.Stamp(ctx, ev))
+	if isNew {
+		if err := h.repo.Save(p); err != nil {
+			return nil, err
+		}
+	} else if err := h.repo.Update(p); err != nil {
+		return nil, err
+	}
+	if err := h.bookIntoDrawer(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// latestCharge returns the most recent charge for the order, or for one part
+// of its split bill when partID is set.
+func latestCharge(repo payment.Repository, orderID common.OrderID, partID common.BillPartID) (*payment.Payment, error) {
+	if partID == "" {
+		return repo.FindByOrderID(orderID)
+	}
+	charges, err := repo.FindChargesByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(charges) - 1; i >= 0; i-- {
+		if charges[i].BillPartID == partID {
+			return charges[i], nil
+		}
+	}
+	return nil, errors.New("payment not found")
+}
+
+// latestTip returns the most recent late tip on the order.
+func latestTip(repo payment.Repository, orderID common.OrderID) (*payment.Payment, error) {
+	charges, err := repo.FindChargesByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(charges) - 1; i >= 0; i-- {
+		if charges[i].IsTip() {
+			return charges[i], nil
+		}
+	}
+	return nil, errors.New("payment not found")
+}
+
+// bookIntoDrawer adds a cash payment to the collector's open shift, once per
+// payment. Cash taken with no drawer open is left untracked, and so is cash
+// taken before the open drawer was opened: it belonged to an earlier shift.
+func (h recordPaymentHandler) bookIntoDrawer(p *payment.Payment) error {
+	if h.shifts == nil || p.Method != common.PaymentMethodTypeCash {
+		return nil
+	}
+	s, err := findDrawer(h.shifts, p.RestaurantID, p.CollectedBy)
+	if err != nil || s == nil {
+		return err
+	}
+	if p.PaidAt == nil || p.PaidAt.Before(s.OpenedAt) {
+		return nil
+	}
+	if err := s.AddCashSale(p.ID, p.Money(), p.CollectedBy, *p.PaidAt); err != nil {
+		return err
+	}
+	return h.shifts.Update(s)
+}
+
+// findDrawer returns the staff member's open shift, falling back to the
+// restaurant's only open shift (e.g. an owner marking paid on a
+// single-drawer till). It returns nil when no drawer can be picked.
+func findDrawer(shifts shift.Repository, restaurantID common.RestaurantID, staffID common.UserID) (*shift.Shift, error) {
+	s, err := shifts.FindOpenByStaff(restaurantID, staffID)
+	if errors.Is(err, shift.ErrShiftNotFound) {
+		open, ferr := shifts.FindOpenByRestaurant(restaurantID)
+		if ferr != nil {
+			return nil, ferr
+		}
+		if len(open) != 1 {
+			return nil, nil
+		}
+		return open[0], nil
+	}
+	return s, err
+}
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/payment/app/event"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/shift"
+)
+
+// RefundPayment returns every settled charge on an order in full and
+// records PaymentRefunded on each for the outbox; a split bill has one charge per part.
+// Cash refunds leave the drawer of the staff member handing the money back.
+// Orders with no ledger charge (paid before the ledger existed) and charges
+// already refunded are a no-op.
+type RefundPayment struct {
+	OrderID    common.OrderID
+	Reason     string
+	RefundedBy common.UserID
+}
+
+type RefundPaymentHandler decorator.CommandHandler[RefundPayment]
+
+type refundPaymentHandler struct {
+	repo   payment.Repository
+	shifts shift.Repository
+}
+
+// NewRefundPaymentHandler wires the refund command. shifts may be nil, in
+// which case cash refunds are not tracked against drawer shifts.
+func NewRefundPaymentHandler(repo payment.Repository, shifts shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) RefundPaymentHandler {
+	if repo == nil {
+		panic("nil payment.Repository")
+	}
+	h := refundPaymentHandler{repo: repo, shifts: shifts}
+	return decorator.ApplyCommandDecorators[RefundPayment](h, log, metrics)
+}
+
+func (h refundPaymentHandler) Handle(ctx context.Context, cmd RefundPayment) error {
+	charges, err := h.repo.FindChargesByOrderID(cmd.OrderID)
+	if err != nil {
+		return err
+	}
+	for _, charge := range charges {
+		if charge.Status != common.PaymentStatusPaid {
+			continue
+		}
+		if err := h.refund(ctx, charge, cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h refundPaymentHandler) refund(ctx context.Context, charge *payment.Payment, cmd RefundPayment) error {
+	refundID := common.PaymentID(fmt.Sprintf("ref_%d", time.Now().UnixNano()))
+	refund, err := payment.NewRefund(refundID, charge, cmd.Reason, cmd.RefundedBy)
+	if err != nil {
+		return err
+	}
+	if err := charge.MarkRefunded(cmd.Reason, *refund.PaidAt); err != nil {
+		return err
+	}
+	if err := h.repo.Save(refund); err != nil {
+		return err
+	}
+
+	// Recorded on the charge: once it is stored as refunded a retry skips
+	// it, so the event has to be written with it.
+	charge.Record(envelope.Stamp(ctx, event.PaymentRefunded{
+		RefundID:     refund.ID,
+		PaymentID:    charge.ID,
+		OrderID:      refund.OrderID,
+		RestaurantID: refund.RestaurantID,
+		Method:       refund.Method,
+		Amount:       refund.Amount,
+		Currency:     refund.Money().Currency.Code,
+		Reason:       refund.Reason,
+		RefundedBy:   refund.CollectedBy,
+		RefundedAt:   *refund.PaidAt,
+	}))
+	if err := h.repo.Update(charge); err != nil {
+		return err
+	}
+	return h.bookOutOfDrawer(refund)
+}
+
+func (h refundPaymentHandler) bookOutOfDrawer(refund *payment.Payment) error {
+	if h.shifts == nil || refund.Method != common.PaymentMethodTypeCash {
+		return nil
+	}
+	s, err := findDrawer(h.shifts, refund.RestaurantID, refund.CollectedBy)
+	if err != nil || s == nil {
+		return err
+	}
+	if err := s.AddCashRefund(refund.ID, refund.Money(), refund.Reason, refund.CollectedBy, *refund.PaidAt); err != nil {
+		return err
+	}
+	return h.shifts.Update(s)
+}
+
+// VoidPayment cancels the open payments on an unpaid order so a pending
+// Lightning invoice is no longer watched and cannot settle the order later.
+// A settled charge is rejected; it must be refunded instead. KeepSettled
+// skips that check and voids only what is still pending, which is what
+// re-splitting a partly paid bill needs.
+type VoidPayment struct {
+	OrderID     common.OrderID
+	Reason      string
+	KeepSettled bool
+}
+
+type VoidPaymentHandler decorator.CommandHandler[VoidPayment]
+
+type voidPaymentHandler struct {
+	repo payment.Repository
+}
+
+func NewVoidPaymentHandler(repo payment.Repository, log *slog.Logger, metrics decorator.MetricsClient) VoidPaymentHandler {
+	if repo == nil {
+		panic("nil payment.Repository")
+	}
+	return decorator.ApplyCommandDecorators[VoidPayment](voidPaymentHandler{repo: repo}, log, metrics)
+}
+
+func (h voidPaymentHandler) Handle(ctx context.Context, cmd VoidPayment) error {
+	_ = ctx
+	charges, err := h.repo.FindChargesByOrderID(cmd.OrderID)
+	if err != nil {
+		return err
+	}
+	if !cmd.KeepSettled {
+		for _, p := range charges {
+			if p.Status == common.PaymentStatusPaid {
+				return payment.ErrAlreadySettled
+			}
+		}
+	}
+	for _, p := range charges {
+		if p.Status != common.PaymentStatusPending {
+			continue
+		}
+		if err := p.Void(cmd.Reason); err != nil {
+			return err
+		}
+		if err := h.repo.Update(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/domain/payment"
+)
+
+// RequestLightningInvoice returns a payable Lightning invoice for an order.
+// Repeated requests reuse the pending invoice until it expires, so reloading
+// the pay page never mints a second invoice for the same order. Memo is
+// shown in the payer's wallet. BillPartID scopes the invoice to one part of a
+// split bill. Tip asks for a tip on an order that is already paid; a pending
+// tip invoice for a different amount is dropped for a fresh one.
+type RequestLightningInvoice struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	BillPartID   common.BillPartID
+	Amount       money.Money
+	Memo         string
+	Tip          bool
+}
+
+type RequestLightningInvoiceHandler decorator.CommandResultHandler[RequestLightningInvoice, *payment.Payment]
+
+type requestLightningInvoiceHandler struct {
+	repo    payment.Repository
+	address payment.InvoiceIssuer
+	node    payment.InvoiceIssuer
+	now     func() time.Time
+}
+
+// NewRequestLightningInvoiceHandler prefers the restaurant's own Lightning
+// address and falls back to our node when the restaurant has none. Either
+// issuer may be nil, but not both.
+func NewRequestLightningInvoiceHandler(repo payment.Repository, address, node payment.InvoiceIssuer, log *slog.Logger, metrics decorator.MetricsClient) RequestLightningInvoiceHandler {
+	if repo == nil {
+		panic("nil payment.Repository")
+	}
+	if address == nil && node == nil {
+		panic("nil payment.InvoiceIssuer")
+	}
+	h := requestLightningInvoiceHandler{repo: repo, address: address, node: node, now: time.Now}
+	return decorator.ApplyCommandResultDecorators[RequestLightningInvoice, *payment.Payment](h, log, metrics)
+}
+
+func (h requestLightningInvoiceHandler) Handle(ctx context.Context, cmd RequestLightningInvoice) (*payment.Payment, error) {
+	if !cmd.Amount.IsPositive() {
+		return nil, errors.New("invoice amount must be greater than 0")
+	}
+
+	existing, err := latestCharge(h.repo, cmd.OrderID, cmd.BillPartID)
+	if cmd.Tip {
+		existing, err = latestTip(h.repo, cmd.OrderID)
+	}
+	if err == nil && existing.Method == common.PaymentMethodTypeLightning {
+		switch existing.Status {
+		case common.PaymentStatusPaid:
+			return existing, nil
+		case common.PaymentStatusPending:
+			if !existing.InvoiceExpiredAt(h.now()) && (!cmd.Tip || existing.InvoicedFor(cmd.Amount)) {
+				return existing, nil
+			}
+			if err := existing.MarkExpired(); err != nil {
+				return nil, err
+			}
+			if err := h.repo.Update(existing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	p, err := h.issue(ctx, payment.InvoiceRequest{
+		OrderID:      cmd.OrderID,
+		RestaurantID: cmd.RestaurantID,
+		Amount:       cmd.Amount,
+		Memo:         cmd.Memo,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.BillPartID = cmd.BillPartID
+	if cmd.Tip {
+		p.Kind = payment.KindTip
+	}
+	if err := h.repo.Save(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (h requestLightningInvoiceHandler) issue(ctx context.Context, req payment.InvoiceRequest) (*payment.Payment, error) {
+	if h.address != nil {
+		p, err := h.address.IssueInvoice(ctx, req)
+		if !errors.Is(err, payment.ErrNoLightningAddress) || h.node == nil {
+			return p, err
+		}
+	}
+	if h.node == nil {
+		return nil, payment.ErrNoLightningAddress
+	}
+	return h.node.IssueInvoice(ctx, req)
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/domain/shift"
+)
+
+// OpenShift starts a cash drawer shift for a staff member with a starting
+// float in minor units of the restaurant's currency. A staff member has at
+// most one open shift per restaurant.
+type OpenShift struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Currency     money.Currency
+	OpeningFloat int64
+}
+
+type OpenShiftHandler decorator.CommandResultHandler[OpenShift, *shift.Shift]
+
+type openShiftHandler struct {
+	repo shift.Repository
+}
+
+func NewOpenShiftHandler(repo shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) OpenShiftHandler {
+	if repo == nil {
+		panic("nil shift.Repository")
+	}
+	h := openShiftHandler{repo: repo}
+	return decorator.ApplyCommandResultDecorators[OpenShift, *shift.Shift](h, log, metrics)
+}
+
+func (h openShiftHandler) Handle(ctx context.Context, cmd OpenShift) (*shift.Shift, error) {
+	_ = ctx
+	if _, err := h.repo.FindOpenByStaff(cmd.RestaurantID, cmd.StaffID); err == nil {
+		return nil, shift.ErrShiftAlreadyOpen
+	} else if !errors.Is(err, shift.ErrShiftNotFound) {
+		return nil, err
+	}
+	id := common.ShiftID(fmt.Sprintf("shift_%d", time.Now().UnixNano()))
+	s, err := shift.Open(id, cmd.RestaurantID, cmd.StaffID, cmd.Currency, cmd.OpeningFloat)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.repo.Save(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// RecordDrawerMovement records a pay-in or pay-out against the staff
+// member's open shift. Amount must be in the shift currency.
+type RecordDrawerMovement struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Kind         shift.MovementKind
+	Amount       money.Money
+	Reason       string
+}
+
+type RecordDrawerMovementHandler decorator.CommandResultHandler[RecordDrawerMovement, *shift.Shift]
+
+type recordDrawerMovementHandler struct {
+	repo shift.Repository
+}
+
+func NewRecordDrawerMovementHandler(repo shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) RecordDrawerMovementHandler {
+	if repo == nil {
+		panic("nil shift.Repository")
+	}
+	h := recordDrawerMovementHandler{repo: repo}
+	return decorator.ApplyCommandResultDecorators[RecordDrawerMovement, *shift.Shift](h, log, metrics)
+}
+
+func (h recordDrawerMovementHandler) Handle(ctx context.Context, cmd RecordDrawerMovement) (*shift.Shift, error) {
+	_ = ctx
+	s, err := h.repo.FindOpenByStaff(cmd.RestaurantID, cmd.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	switch cmd.Kind {
+	case shift.MovementPayIn:
+		err = s.PayIn(cmd.Amount, cmd.Reason, cmd.StaffID)
+	case shift.MovementPayOut:
+		err = s.PayOut(cmd.Amount, cmd.Reason, cmd.StaffID)
+	default:
+		err = fmt.Errorf("unknown drawer movement %q", cmd.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := h.repo.Update(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CloseShift ends the staff member's open shift with the counted cash, in
+// the shift currency. The returned shift carries the expected amount and
+// over/short variance.
+type CloseShift struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Counted      money.Money
+	Note         string
+}
+
+type CloseShiftHandler decorator.CommandResultHandler[CloseShift, *shift.Shift]
+
+type closeShiftHandler struct {
+	repo shift.Repository
+}
+
+func NewCloseShiftHandler(repo shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) CloseShiftHandler {
+	if repo == nil {
+		panic("nil shift.Repository")
+	}
+	h := closeShiftHandler{repo: repo}
+	return decorator.ApplyCommandResultDecorators[CloseShift, *shift.Shift](h, log, metrics)
+}
+
+func (h closeShiftHandler) Handle(ctx context.Context, cmd CloseShift) (*shift.Shift, error) {
+	_ = ctx
+	s, err := h.repo.FindOpenByStaff(cmd.RestaurantID, cmd.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Close(cmd.Counted, cmd.StaffID, cmd.Note); err != nil {
+		return nil, err
+	}
+	if err := h.repo.Update(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
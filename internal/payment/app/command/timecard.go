@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/payment/domain/timecard"
+)
+
+// ClockIn puts a staff member on the clock under the role they hold in the
+// restaurant. A staff member has at most one open timecard per restaurant.
+type ClockIn struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Role         common.MemberRole
+}
+
+type ClockInHandler decorator.CommandResultHandler[ClockIn, *timecard.Timecard]
+
+type clockInHandler struct {
+	repo timecard.Repository
+}
+
+func NewClockInHandler(repo timecard.Repository, log *slog.Logger, metrics decorator.MetricsClient) ClockInHandler {
+	if repo == nil {
+		panic("nil timecard.Repository")
+	}
+	h := clockInHandler{repo: repo}
+	return decorator.ApplyCommandResultDecorators[ClockIn, *timecard.Timecard](h, log, metrics)
+}
+
+func (h clockInHandler) Handle(ctx context.Context, cmd ClockIn) (*timecard.Timecard, error) {
+	_ = ctx
+	if _, err := h.repo.FindOpenByStaff(cmd.RestaurantID, cmd.StaffID); err == nil {
+		return nil, timecard.ErrAlreadyClockedIn
+	} else if !errors.Is(err, timecard.ErrNotFound) {
+		return nil, err
+	}
+	id := common.TimecardID(fmt.Sprintf("tc_%d", time.Now().UnixNano()))
+	t, err := timecard.ClockIn(id, cmd.RestaurantID, cmd.StaffID, cmd.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.repo.Save(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ClockOut takes a staff member off the clock.
+type ClockOut struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+}
+
+type ClockOutHandler decorator.CommandResultHandler[ClockOut, *timecard.Timecard]
+
+type clockOutHandler struct {
+	repo timecard.Repository
+}
+
+func NewClockOutHandler(repo timecard.Repository, log *slog.Logger, metrics decorator.MetricsClient) ClockOutHandler {
+	if repo == nil {
+		panic("nil timecard.Repository")
+	}
+	h := clockOutHandler{repo: repo}
+	return decorator.ApplyCommandResultDecorators[ClockOut, *timecard.Timecard](h, log, metrics)
+}
+
+func (h clockOutHandler) Handle(ctx context.Context, cmd ClockOut) (*timecard.Timecard, error) {
+	_ = ctx
+	t, err := h.repo.FindOpenByStaff(cmd.RestaurantID, cmd.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.ClockOut(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := h.repo.Update(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
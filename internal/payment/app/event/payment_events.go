@@ -0,0 +1,46 @@
+package event
+
+import (
+	"time"
+
+	"bitmerchant/internal/common"
+)
+
+// PaymentCompleted is published when a payment is settled and recorded in
+// the ledger. Amounts are in Currency's minor units.
+type PaymentCompleted struct {
+	PaymentID      common.PaymentID
+	OrderID        common.OrderID
+	RestaurantID   common.RestaurantID
+	Method         common.PaymentMethodType
+	Amount         int64
+	Currency       string
+	TenderedAmount int64
+	ChangeGiven    int64
+	CollectedBy    common.UserID
+	PaidAt         time.Time
+}
+
+func (e PaymentCompleted) EventName() string                      { return common.EventPaymentCompleted }
+func (e PaymentCompleted) OccurredAt() time.Time                  { return e.PaidAt }
+func (e PaymentCompleted) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
+
+// PaymentRefunded is published when a settled charge is returned to the
+// customer. RefundID is the new ledger row; PaymentID the charge it reverses.
+// Amount is in Currency's minor units.
+type PaymentRefunded struct {
+	RefundID     common.PaymentID
+	PaymentID    common.PaymentID
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	Method       common.PaymentMethodType
+	Amount       int64
+	Currency     string
+	Reason       string
+	RefundedBy   common.UserID
+	RefundedAt   time.Time
+}
+
+func (e PaymentRefunded) EventName() string                      { return common.EventPaymentRefunded }
+func (e PaymentRefunded) OccurredAt() time.Time                  { return e.RefundedAt }
+func (e PaymentRefunded) EventRestaurantID() common.RestaurantID { return e.RestaurantID }
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/payment/domain/shift"
+)
+
+// CurrentShift returns the staff member's open drawer shift, or
+// shift.ErrShiftNotFound when their drawer is closed.
+type CurrentShift struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+}
+
+type CurrentShiftHandler decorator.QueryHandler[CurrentShift, *shift.Shift]
+
+type currentShiftHandler struct {
+	repo shift.Repository
+}
+
+func NewCurrentShiftHandler(repo shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) CurrentShiftHandler {
+	if repo == nil {
+		panic("nil shift.Repository")
+	}
+	h := currentShiftHandler{repo: repo}
+	return decorator.ApplyQueryDecorators[CurrentShift, *shift.Shift](h, log, metrics)
+}
+
+func (h currentShiftHandler) Handle(ctx context.Context, q CurrentShift) (*shift.Shift, error) {
+	_ = ctx
+	return h.repo.FindOpenByStaff(q.RestaurantID, q.StaffID)
+}
+
+// ShiftHistory lists a restaurant's shifts, newest first. A non-empty
+// StaffID narrows it to one staff member.
+type ShiftHistory struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+}
+
+type ShiftHistoryHandler decorator.QueryHandler[ShiftHistory, []*shift.Shift]
+
+type shiftHistoryHandler struct {
+	repo shift.Repository
+}
+
+func NewShiftHistoryHandler(repo shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) ShiftHistoryHandler {
+	if repo == nil {
+		panic("nil shift.Repository")
+	}
+	h := shiftHistoryHandler{repo: repo}
+	return decorator.ApplyQueryDecorators[ShiftHistory, []*shift.Shift](h, log, metrics)
+}
+
+func (h shiftHistoryHandler) Handle(ctx context.Context, q ShiftHistory) ([]*shift.Shift, error) {
+	_ = ctx
+	shifts, err := h.repo.FindByRestaurant(q.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if q.StaffID == "" {
+		return shifts, nil
+	}
+	var filtered []*shift.Shift
+	for _, s := range shifts {
+		if s.StaffID == q.StaffID {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
+
+// ShiftReport loads one shift for the report page. Shifts belonging to
+// another restaurant are reported as not found.
+type ShiftReport struct {
+	RestaurantID common.RestaurantID
+	ShiftID      common.ShiftID
+}
+
+type ShiftReportHandler decorator.QueryHandler[ShiftReport, *shift.Shift]
+
+type shiftReportHandler struct {
+	repo shift.Repository
+}
+
+func NewShiftReportHandler(repo shift.Repository, log *slog.Logger, metrics decorator.MetricsClient) ShiftReportHandler {
+	if repo == nil {
+		panic("nil shift.Repository")
+	}
+	h := shiftReportHandler{repo: repo}
+	return decorator.ApplyQueryDecorators[ShiftReport, *shift.Shift](h, log, metrics)
+}
+
+func (h shiftReportHandler) Handle(ctx context.Context, q ShiftReport) (*shift.Shift, error) {
+	_ = ctx
+	s, err := h.repo.FindByID(q.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+	if s.RestaurantID != q.RestaurantID {
+		return nil, shift.ErrShiftNotFound
+	}
+	return s, nil
+}
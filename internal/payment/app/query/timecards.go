@@ -0,0 +1,37 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/payment/domain/timecard"
+)
+
+// StaffTimecards lists a staff member's most recent timecards, newest first;
+// the first is still open when they are on the clock. Limit 0 means all.
+type StaffTimecards struct {
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Limit        int
+}
+
+type StaffTimecardsHandler decorator.QueryHandler[StaffTimecards, []*timecard.Timecard]
+
+type staffTimecardsHandler struct {
+	repo timecard.Repository
+}
+
+func NewStaffTimecardsHandler(repo timecard.Repository, log *slog.Logger, metrics decorator.MetricsClient) StaffTimecardsHandler {
+	if repo == nil {
+		panic("nil timecard.Repository")
+	}
+	h := staffTimecardsHandler{repo: repo}
+	return decorator.ApplyQueryDecorators[StaffTimecards, []*timecard.Timecard](h, log, metrics)
+}
+
+func (h staffTimecardsHandler) Handle(ctx context.Context, q StaffTimecards) ([]*timecard.Timecard, error) {
+	_ = ctx
+	return h.repo.FindByStaff(q.RestaurantID, q.StaffID, q.Limit)
+}
@@ -0,0 +1,23 @@
+package payment
+
+import "bitmerchant/internal/common"
+
+// Record queues ev to be published once the payment is saved. Repositories
+// write recorded events to the outbox in the same transaction as the
+// payment, so a retry that finds the payment already settled or refunded
+// cannot lose the event that announced it.
+func (p *Payment) Record(ev common.DomainEvent) {
+	p.events = append(p.events, ev)
+}
+
+// Events returns the events recorded since the payment was last saved, in
+// the order they were recorded.
+func (p *Payment) Events() []common.DomainEvent {
+	return p.events
+}
+
+// ClearEvents forgets recorded events; repositories call it once they have
+// been stored.
+func (p *Payment) ClearEvents() {
+	p.events = nil
+}
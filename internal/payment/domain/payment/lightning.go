@@ -0,0 +1,74 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+)
+
+var (
+	// ErrInvoiceNotSettled is returned by the Lightning payment method when the
+	// invoice for an order has not been paid yet.
+	ErrInvoiceNotSettled = errors.New("lightning invoice not settled")
+	// ErrNoLightningAddress is returned when a restaurant has not linked a
+	// Lightning address or LNURL-pay endpoint.
+	ErrNoLightningAddress = errors.New("restaurant has no lightning address")
+	// ErrSettledByInvoice is returned when staff try to record a Lightning
+	// payment by hand. Only a paid invoice settles one; staff record the
+	// tender the customer actually used instead.
+	ErrSettledByInvoice = errors.New("lightning payments settle when their invoice is paid")
+)
+
+// Invoice is a BOLT11 payment request issued by a Lightning node.
+type Invoice struct {
+	PaymentRequest string
+	PaymentHash    string
+	AmountSats     int64
+	ExpiresAt      time.Time
+	// VerifyURL is the LUD-21 endpoint for invoices fetched from a Lightning
+	// address; empty for invoices issued by our own node.
+	VerifyURL string
+}
+
+// InvoiceState is the node's view of an invoice.
+type InvoiceState string
+
+const (
+	InvoiceStateOpen     InvoiceState = "open"
+	InvoiceStateSettled  InvoiceState = "settled"
+	InvoiceStateCanceled InvoiceState = "canceled"
+)
+
+// LightningNode is the port to a Lightning node that can issue and look up
+// invoices. Implementations live in adapters (LND REST, Core Lightning REST,
+// and an in-process fake for tests and local checkout).
+type LightningNode interface {
+	CreateInvoice(ctx context.Context, amountSats int64, memo string, expiry time.Duration) (Invoice, error)
+	LookupInvoice(ctx context.Context, paymentHash string) (InvoiceState, error)
+}
+
+// LNURLPayer fetches invoices from a merchant's own Lightning address
+// (LUD-16) or LNURL-pay endpoint (LUD-06) and checks them through the
+// LUD-21 verify URL. Funds go straight to the merchant's wallet.
+type LNURLPayer interface {
+	FetchInvoice(ctx context.Context, target string, amountSats int64, comment string) (Invoice, error)
+	Verify(ctx context.Context, verifyURL string) (InvoiceState, error)
+}
+
+// InvoiceRequest describes the invoice to issue for an order. Memo is shown
+// in the payer's wallet (the order number).
+type InvoiceRequest struct {
+	OrderID      common.OrderID
+	RestaurantID common.RestaurantID
+	Amount       money.Money
+	Memo         string
+}
+
+// InvoiceIssuer is implemented by Lightning payment methods. It is
+// ProcessPayment with a caller-chosen memo.
+type InvoiceIssuer interface {
+	IssueInvoice(ctx context.Context, req InvoiceRequest) (*Payment, error)
+}
@@ -15,15 +15,71 @@ type Payment struct {
 	OrderID       common.OrderID
 	RestaurantID  common.RestaurantID
 	Method        common.PaymentMethodType
-	Amount        float64
+	Amount        int64 // minor units of Currency
 	Currency      money.Currency
 	Status        common.PaymentStatus
 	CreatedAt     time.Time
 	PaidAt        *time.Time
 	FailedAt      *time.Time
 	FailureReason string
+	// PaymentHash / Invoice are set for Lightning payments: the hex payment
+	// hash identifies the invoice on the node, Invoice is the BOLT11 string
+	// shown to the customer. Both are empty for cash.
+	PaymentHash      string
+	Invoice          string
+	InvoiceExpiresAt *time.Time
+	// VerifyURL is the LUD-21 verify endpoint when the invoice came from
+	// the restaurant's Lightning address rather than our node.
+	VerifyURL string
+	// TenderedAmount / ChangeGiven record what the customer handed over and
+	// what went back, in minor units of the payment currency. CollectedBy is
+	// the staff member who took the money; empty when it settled without staff.
+	TenderedAmount int64
+	ChangeGiven    int64
+	CollectedBy    common.UserID
+	// Kind separates charges from the refund rows that reverse them and
+	// from tips added after the order was paid. A refund points at its charge through RefundOf; Reason is the staff
+	// explanation for a refund or void. RefundedAt is set on the charge.
+	Kind       Kind
+	RefundOf   common.PaymentID
+	Reason     string
+	RefundedAt *time.Time
+	// BillPartID is set when the charge pays one part of a split bill.
+	BillPartID common.BillPartID
+	// FXRate is the rate the order total was converted at when the payment
+	// is in another unit (e.g. a fiat bill paid in sats); zero otherwise.
+	FXRate money.ExchangeRate
+	// RoundingAdjustment is the signed cash rounding included in Amount, so
+	// Amount - RoundingAdjustment is what the order came to before rounding.
+	RoundingAdjustment int64
+
+	// events are recorded by Record and written to the outbox with the
+	// payment; see Events.
+	events []common.DomainEvent
 }
 
+// Kind classifies a ledger row.
+type Kind string
+
+const (
+	KindCharge Kind = "charge"
+	KindRefund Kind = "refund"
+	// KindTip is a tip the guest paid after the order was settled; it is
+	// refunded with the order's charges but never settles the order itself.
+	KindTip Kind = "tip"
+)
+
+var (
+	// ErrNotRefundable is returned when refunding a payment that never settled.
+	ErrNotRefundable = errors.New("only settled charges can be refunded")
+	// ErrAlreadySettled is returned when voiding a payment that has been paid.
+	ErrAlreadySettled = errors.New("payment has already settled; refund it instead")
+)
+
+// ErrInsufficientTender is returned when the amount handed over does not
+// cover the payment.
+var ErrInsufficientTender = errors.New("tendered amount is less than the amount due")
+
 // Money returns the payment amount as money.Money. Falls back to USD when
 // the row predates currency support.
 func (p *Payment) Money() money.Money {
@@ -31,7 +87,21 @@ func (p *Payment) Money() money.Money {
 	if c.IsZero() {
 		c = money.USD
 	}
-	return money.FromMajor(p.Amount, c)
+	return money.New(p.Amount, c)
+}
+
+// ValueAtSale is what the payment was worth in the order currency when it
+// was taken, priced with FXRate rather than today's rate. Payments in the
+// order currency are worth their own amount.
+func (p *Payment) ValueAtSale() money.Money {
+	if p.FXRate.IsZero() {
+		return p.Money()
+	}
+	v, err := p.FXRate.Inverse().Apply(p.Money())
+	if err != nil {
+		return p.Money()
+	}
+	return v
 }
 
 // PaymentMethod defines the interface for payment processing. The amount is
@@ -39,17 +109,21 @@ func (p *Payment) Money() money.Money {
 // Lightning, fiat for Cash).
 type PaymentMethod interface {
 	ProcessPayment(ctx context.Context, orderID common.OrderID, restaurantID common.RestaurantID, amount money.Money) (*Payment, error)
-	ValidatePayment(ctx context.Context, orderID common.OrderID) error
+	// ValidatePayment checks one charge by ID: an order can hold several
+	// (split-bill parts, late tips), so the order alone does not say which.
+	ValidatePayment(ctx context.Context, paymentID common.PaymentID) error
 	GetPaymentMethodType() common.PaymentMethodType
 }
 
-// NewPayment creates a Payment in the legacy USD-only path.
-func NewPayment(id common.PaymentID, orderID common.OrderID, restaurantID common.RestaurantID, method common.PaymentMethodType, amount float64) (*Payment, error) {
+// NewPayment creates a Payment in the legacy USD-only path; amount is in
+// cents.
+func NewPayment(id common.PaymentID, orderID common.OrderID, restaurantID common.RestaurantID, method common.PaymentMethodType, amount int64) (*Payment, error) {
 	return NewPaymentWithCurrency(id, orderID, restaurantID, method, amount, money.USD)
 }
 
-// NewPaymentWithCurrency creates a Payment in the given currency.
-func NewPaymentWithCurrency(id common.PaymentID, orderID common.OrderID, restaurantID common.RestaurantID, method common.PaymentMethodType, amount float64, currency money.Currency) (*Payment, error) {
+// NewPaymentWithCurrency creates a Payment for amount minor units of
+// currency.
+func NewPaymentWithCurrency(id common.PaymentID, orderID common.OrderID, restaurantID common.RestaurantID, method common.PaymentMethodType, amount int64, currency money.Currency) (*Payment, error) {
 	if amount <= 0 {
 		return nil, errors.New("amount must be greater than 0")
 	}
@@ -62,9 +136,104 @@ func NewPaymentWithCurrency(id common.PaymentID, orderID common.OrderID, restaur
 		Method: method, Amount: amount, Currency: currency,
 		Status:    common.PaymentStatusPending,
 		CreatedAt: now,
+		Kind:      KindCharge,
 	}, nil
 }
 
+// NewRefund creates the ledger row that returns a settled charge in full.
+// The refund is complete when created: the money goes back over the counter
+// (cash) or off-platform to the customer's wallet (Lightning), so PaidAt
+// records when it left and CollectedBy the staff member who handed it back.
+func NewRefund(id common.PaymentID, charge *Payment, reason string, by common.UserID) (*Payment, error) {
+	if charge.IsRefund() || charge.Status != common.PaymentStatusPaid {
+		return nil, ErrNotRefundable
+	}
+	now := time.Now()
+	return &Payment{
+		ID: id, OrderID: charge.OrderID, RestaurantID: charge.RestaurantID,
+		Method: charge.Method, Amount: charge.Amount, Currency: charge.Currency,
+		Status:             common.PaymentStatusRefunded,
+		CreatedAt:          now,
+		PaidAt:             &now,
+		CollectedBy:        by,
+		Kind:               KindRefund,
+		RefundOf:           charge.ID,
+		Reason:             reason,
+		BillPartID:         charge.BillPartID,
+		FXRate:             charge.FXRate,
+		RoundingAdjustment: charge.RoundingAdjustment,
+	}, nil
+}
+
+// InvoicedFor reports whether the payment was raised for amount, given in
+// the order currency. Converted payments are checked with their own FXRate,
+// so the answer does not drift with today's rate.
+func (p *Payment) InvoicedFor(amount money.Money) bool {
+	want := amount
+	if !p.FXRate.IsZero() {
+		converted, err := p.FXRate.Apply(amount)
+		if err != nil {
+			return false
+		}
+		want = converted
+	}
+	got := p.Money()
+	return got.Amount == want.Amount && got.Currency.Code == want.Currency.Code
+}
+
+// IsRefund reports whether this row reverses another payment.
+func (p *Payment) IsRefund() bool { return p.Kind == KindRefund }
+
+// IsTip reports whether this row is a tip added after payment.
+func (p *Payment) IsTip() bool { return p.Kind == KindTip }
+
+// AttachInvoice records the Lightning invoice that settles this payment.
+func (p *Payment) AttachInvoice(inv Invoice) error {
+	if inv.PaymentHash == "" || inv.PaymentRequest == "" {
+		return errors.New("invoice must have a payment hash and payment request")
+	}
+	p.PaymentHash = inv.PaymentHash
+	p.Invoice = inv.PaymentRequest
+	p.VerifyURL = inv.VerifyURL
+	if !inv.ExpiresAt.IsZero() {
+		expiresAt := inv.ExpiresAt
+		p.InvoiceExpiresAt = &expiresAt
+	}
+	return nil
+}
+
+// InvoiceExpiredAt reports whether the attached invoice can no longer be paid
+// at the given instant. Payments without an expiry never expire.
+func (p *Payment) InvoiceExpiredAt(now time.Time) bool {
+	return p.InvoiceExpiresAt != nil && !now.Before(*p.InvoiceExpiresAt)
+}
+
+// Collect settles a pending payment taken by staff. tendered is what the
+// customer handed over, in the payment currency (zero means the exact
+// amount); the difference is recorded as change.
+func (p *Payment) Collect(tendered money.Money, collectedBy common.UserID) error {
+	if p.Status != common.PaymentStatusPending {
+		return errors.New("payment is not in pending status")
+	}
+	due := p.Money()
+	paid := due
+	if !tendered.IsZero() {
+		paid = tendered
+	}
+	change, err := paid.Sub(due)
+	if err != nil {
+		return err
+	}
+	if change.Amount < 0 {
+		return ErrInsufficientTender
+	}
+	p.TenderedAmount = paid.Amount
+	p.ChangeGiven = change.Amount
+	p.CollectedBy = collectedBy
+	p.MarkAsPaid()
+	return nil
+}
+
 func (p *Payment) MarkAsPaid() {
 	p.Status = common.PaymentStatusPaid
 	now := time.Now()
@@ -93,6 +262,31 @@ func (p *Payment) MarkFailed(reason string) error {
 	return nil
 }
 
+// MarkRefunded flags a settled charge as returned to the customer.
+func (p *Payment) MarkRefunded(reason string, at time.Time) error {
+	if p.IsRefund() || p.Status != common.PaymentStatusPaid {
+		return ErrNotRefundable
+	}
+	p.Status = common.PaymentStatusRefunded
+	p.Reason = reason
+	p.RefundedAt = &at
+	return nil
+}
+
+// Void cancels a payment that was never settled, e.g. an open Lightning
+// invoice on an order staff cancelled. A voided invoice is no longer watched.
+func (p *Payment) Void(reason string) error {
+	switch p.Status {
+	case common.PaymentStatusPaid, common.PaymentStatusRefunded:
+		return ErrAlreadySettled
+	case common.PaymentStatusVoided:
+		return nil
+	}
+	p.Status = common.PaymentStatusVoided
+	p.Reason = reason
+	return nil
+}
+
 func (p *Payment) MarkExpired() error {
 	if p.Status != common.PaymentStatusPending {
 		return errors.New("payment is not in pending status")
@@ -6,7 +6,18 @@ import "bitmerchant/internal/common"
 type Repository interface {
 	Save(payment *Payment) error
 	FindByID(id common.PaymentID) (*Payment, error)
+	// FindByOrderID returns the order's most recent charge, not counting
+	// late tips.
 	FindByOrderID(orderID common.OrderID) (*Payment, error)
+	// FindChargesByOrderID returns every charge on the order, oldest first,
+	// late tips included. Refund rows are excluded. A split bill has one
+	// charge per part.
+	FindChargesByOrderID(orderID common.OrderID) ([]*Payment, error)
 	FindByRestaurantID(restaurantID common.RestaurantID) ([]*Payment, error)
+	// FindByPaymentHash returns the Lightning payment for the given invoice hash.
+	FindByPaymentHash(paymentHash string) (*Payment, error)
+	// FindPendingByMethod returns every payment of the given method still in
+	// pending status — the settlement watcher's work queue.
+	FindPendingByMethod(method common.PaymentMethodType) ([]*Payment, error)
 	Update(payment *Payment) error
 }
@@ -0,0 +1,16 @@
+package shift
+
+import "bitmerchant/internal/common"
+
+// Repository defines operations for Shift persistence.
+type Repository interface {
+	Save(s *Shift) error
+	Update(s *Shift) error
+	FindByID(id common.ShiftID) (*Shift, error)
+	// FindOpenByStaff returns the staff member's open shift or ErrShiftNotFound.
+	FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*Shift, error)
+	// FindOpenByRestaurant returns every open shift in the restaurant.
+	FindOpenByRestaurant(restaurantID common.RestaurantID) ([]*Shift, error)
+	// FindByRestaurant returns all shifts, most recently opened first.
+	FindByRestaurant(restaurantID common.RestaurantID) ([]*Shift, error)
+}
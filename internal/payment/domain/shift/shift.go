@@ -0,0 +1,233 @@
+package shift
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+)
+
+// Status is the lifecycle state of a drawer shift.
+type Status string
+
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+
+// MovementKind classifies cash entering or leaving the drawer.
+type MovementKind string
+
+const (
+	// MovementSale is a cash payment collected during the shift.
+	MovementSale MovementKind = "sale"
+	// MovementPayIn is cash added to the drawer outside a sale (e.g. change top-up).
+	MovementPayIn MovementKind = "pay_in"
+	// MovementPayOut is cash taken out outside a sale (e.g. supplier paid in cash).
+	MovementPayOut MovementKind = "pay_out"
+	// MovementRefund is cash handed back to a customer for a cancelled order.
+	MovementRefund MovementKind = "refund"
+)
+
+var (
+	ErrShiftClosed      = errors.New("shift is closed")
+	ErrShiftNotFound    = errors.New("shift not found")
+	ErrShiftAlreadyOpen = errors.New("a shift is already open for this staff member")
+	ErrInvalidAmount    = errors.New("amount must be a positive number")
+	ErrReasonRequired   = errors.New("pay-ins and pay-outs need a reason")
+)
+
+// Movement is one line in the drawer ledger. Amount is always positive and
+// in the shift currency's minor units; Kind decides the direction.
+type Movement struct {
+	Kind      MovementKind     `json:"kind"`
+	Amount    int64            `json:"amount"`
+	PaymentID common.PaymentID `json:"payment_id,omitempty"`
+	Reason    string           `json:"reason,omitempty"`
+	By        common.UserID    `json:"by,omitempty"`
+	At        time.Time        `json:"at"`
+}
+
+// Shift is one staff member's cash drawer session: it opens with a float,
+// accumulates cash sales and pay-ins/outs, and closes with a counted amount
+// whose difference from the expected cash is the over/short variance. All
+// amounts are minor units of Currency.
+type Shift struct {
+	ID           common.ShiftID
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Currency     money.Currency
+	OpeningFloat int64
+	Movements    []Movement
+	Status       Status
+	OpenedAt     time.Time
+	// Closing fields are zero while the shift is open. ExpectedAmount is
+	// snapshotted at close so the report does not drift if movements are
+	// ever corrected afterwards.
+	ClosedAt       *time.Time
+	ClosedBy       common.UserID
+	CountedAmount  int64
+	ExpectedAmount int64
+	Note           string
+}
+
+// Open starts a shift with the given starting float in minor units of
+// currency (zero is allowed).
+func Open(id common.ShiftID, restaurantID common.RestaurantID, staffID common.UserID, currency money.Currency, openingFloat int64) (*Shift, error) {
+	if id == "" || restaurantID == "" || staffID == "" {
+		return nil, errors.New("shift needs an id, restaurant and staff member")
+	}
+	if openingFloat < 0 {
+		return nil, errors.New("opening float cannot be negative")
+	}
+	if currency.IsZero() {
+		currency = money.USD
+	}
+	return &Shift{
+		ID:           id,
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+		Currency:     currency,
+		OpeningFloat: openingFloat,
+		Status:       StatusOpen,
+		OpenedAt:     time.Now(),
+	}, nil
+}
+
+// IsOpen reports whether the shift still accepts movements.
+func (s *Shift) IsOpen() bool { return s.Status == StatusOpen }
+
+// AddCashSale records a cash payment taken during the shift. Recording the
+// same payment twice is a no-op.
+func (s *Shift) AddCashSale(paymentID common.PaymentID, amount money.Money, by common.UserID, at time.Time) error {
+	minor, err := s.amount(amount)
+	if err != nil {
+		return err
+	}
+	for _, m := range s.Movements {
+		if m.Kind == MovementSale && m.PaymentID == paymentID {
+			return nil
+		}
+	}
+	s.Movements = append(s.Movements, Movement{Kind: MovementSale, Amount: minor, PaymentID: paymentID, By: by, At: at})
+	return nil
+}
+
+// AddCashRefund records cash returned to a customer, keyed by the refund
+// payment. Recording the same refund twice is a no-op.
+func (s *Shift) AddCashRefund(refundID common.PaymentID, amount money.Money, reason string, by common.UserID, at time.Time) error {
+	minor, err := s.amount(amount)
+	if err != nil {
+		return err
+	}
+	for _, m := range s.Movements {
+		if m.Kind == MovementRefund && m.PaymentID == refundID {
+			return nil
+		}
+	}
+	s.Movements = append(s.Movements, Movement{Kind: MovementRefund, Amount: minor, PaymentID: refundID, Reason: strings.TrimSpace(reason), By: by, At: at})
+	return nil
+}
+
+// PayIn records cash added to the drawer outside a sale.
+func (s *Shift) PayIn(amount money.Money, reason string, by common.UserID) error {
+	return s.addManual(MovementPayIn, amount, reason, by)
+}
+
+// PayOut records cash removed from the drawer outside a sale.
+func (s *Shift) PayOut(amount money.Money, reason string, by common.UserID) error {
+	return s.addManual(MovementPayOut, amount, reason, by)
+}
+
+func (s *Shift) addManual(kind MovementKind, amount money.Money, reason string, by common.UserID) error {
+	minor, err := s.amount(amount)
+	if err != nil {
+		return err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReasonRequired
+	}
+	s.Movements = append(s.Movements, Movement{Kind: kind, Amount: minor, Reason: reason, By: by, At: time.Now()})
+	return nil
+}
+
+// Total sums movements of one kind.
+func (s *Shift) Total(kind MovementKind) int64 {
+	var total int64
+	for _, m := range s.Movements {
+		if m.Kind == kind {
+			total += m.Amount
+		}
+	}
+	return total
+}
+
+// ExpectedCash is what should be in the drawer: float + cash sales +
+// pay-ins - pay-outs - refunds. Closed shifts return the snapshot taken at
+// close.
+func (s *Shift) ExpectedCash() int64 {
+	if !s.IsOpen() {
+		return s.ExpectedAmount
+	}
+	return s.OpeningFloat + s.Total(MovementSale) + s.Total(MovementPayIn) - s.Total(MovementPayOut) - s.Total(MovementRefund)
+}
+
+// Close ends the shift with the cash actually counted in the drawer.
+func (s *Shift) Close(counted money.Money, by common.UserID, note string) error {
+	if !s.IsOpen() {
+		return ErrShiftClosed
+	}
+	if err := s.sameCurrency(counted); err != nil {
+		return err
+	}
+	if counted.Amount < 0 {
+		return errors.New("counted amount cannot be negative")
+	}
+	s.ExpectedAmount = s.ExpectedCash()
+	s.CountedAmount = counted.Amount
+	s.ClosedBy = by
+	s.Note = strings.TrimSpace(note)
+	now := time.Now()
+	s.ClosedAt = &now
+	s.Status = StatusClosed
+	return nil
+}
+
+// Variance is counted minus expected: positive means the drawer is over,
+// negative means it is short. Zero while the shift is open.
+func (s *Shift) Variance() int64 {
+	if s.IsOpen() {
+		return 0
+	}
+	return s.CountedAmount - s.ExpectedAmount
+}
+
+// Money wraps a minor-unit amount in the shift currency for display.
+func (s *Shift) Money(amount int64) money.Money {
+	return money.New(amount, s.Currency)
+}
+
+// amount checks a movement can be recorded and returns it in minor units.
+func (s *Shift) amount(m money.Money) (int64, error) {
+	if !s.IsOpen() {
+		return 0, ErrShiftClosed
+	}
+	if err := s.sameCurrency(m); err != nil {
+		return 0, err
+	}
+	if !m.IsPositive() {
+		return 0, ErrInvalidAmount
+	}
+	return m.Amount, nil
+}
+
+func (s *Shift) sameCurrency(m money.Money) error {
+	if m.Currency.Code != s.Currency.Code {
+		return fmt.Errorf("%w: drawer counts %s, got %s", money.ErrCurrencyMismatch, s.Currency.Code, m.Currency.Code)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package timecard
+
+import (
+	"time"
+
+	"bitmerchant/internal/common"
+)
+
+// Repository defines operations for Timecard persistence.
+type Repository interface {
+	Save(t *Timecard) error
+	Update(t *Timecard) error
+	// FindOpenByStaff returns the staff member's open timecard or ErrNotFound.
+	FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*Timecard, error)
+	// FindByRestaurant returns timecards overlapping [from, to), including
+	// ones still open, earliest clock-in first.
+	FindByRestaurant(restaurantID common.RestaurantID, from, to time.Time) ([]*Timecard, error)
+	// FindByStaff returns the staff member's most recent timecards, newest
+	// first, up to limit.
+	FindByStaff(restaurantID common.RestaurantID, staffID common.UserID, limit int) ([]*Timecard, error)
+}
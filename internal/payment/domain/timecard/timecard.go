@@ -0,0 +1,79 @@
+package timecard
+
+import (
+	"errors"
+	"time"
+
+	"bitmerchant/internal/common"
+)
+
+var (
+	ErrNotFound         = errors.New("timecard not found")
+	ErrAlreadyClockedIn = errors.New("already clocked in")
+	ErrClockedOut       = errors.New("already clocked out")
+)
+
+// Timecard is one stretch of a staff member's time on the clock, from
+// clock-in to clock-out. Role is the role they held when clocking in, which
+// decides the tip pool the time counts towards.
+type Timecard struct {
+	ID           common.TimecardID
+	RestaurantID common.RestaurantID
+	StaffID      common.UserID
+	Role         common.MemberRole
+	ClockedInAt  time.Time
+	// ClockedOutAt is nil while the staff member is still on the clock.
+	ClockedOutAt *time.Time
+}
+
+// ClockIn starts a timecard now.
+func ClockIn(id common.TimecardID, restaurantID common.RestaurantID, staffID common.UserID, role common.MemberRole) (*Timecard, error) {
+	if id == "" || restaurantID == "" || staffID == "" {
+		return nil, errors.New("timecard needs an id, restaurant and staff member")
+	}
+	if role == "" || role == common.RoleCustomer {
+		return nil, errors.New("only staff can clock in")
+	}
+	return &Timecard{
+		ID:           id,
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+		Role:         role,
+		ClockedInAt:  time.Now(),
+	}, nil
+}
+
+// IsOpen reports whether the staff member is still on the clock.
+func (t *Timecard) IsOpen() bool { return t.ClockedOutAt == nil }
+
+// ClockOut ends the timecard at at.
+func (t *Timecard) ClockOut(at time.Time) error {
+	if !t.IsOpen() {
+		return ErrClockedOut
+	}
+	if at.Before(t.ClockedInAt) {
+		at = t.ClockedInAt
+	}
+	t.ClockedOutAt = &at
+	return nil
+}
+
+// Worked is how much of the timecard falls between from and to. A card
+// still open counts up to now.
+func (t *Timecard) Worked(from, to, now time.Time) time.Duration {
+	end := now
+	if t.ClockedOutAt != nil {
+		end = *t.ClockedOutAt
+	}
+	start := t.ClockedInAt
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"bitmerchant/internal/auth/domain/membership"
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/interfaces/templates"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/shift"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DrawerHandler is the staff-facing cash drawer: open with a float, record
+// pay-ins/outs and close with a counted amount. Each staff member only ever
+// sees their own drawer.
+type DrawerHandler struct {
+	currentShiftUC   payQuery.CurrentShiftHandler
+	shiftReportUC    payQuery.ShiftReportHandler
+	openShiftUC      payCmd.OpenShiftHandler
+	recordMovementUC payCmd.RecordDrawerMovementHandler
+	closeShiftUC     payCmd.CloseShiftHandler
+	restaurantRepo   restaurant.Repository
+	membershipRepo   membership.Repository
+}
+
+func NewDrawerHandler(
+	currentShiftUC payQuery.CurrentShiftHandler,
+	shiftReportUC payQuery.ShiftReportHandler,
+	openShiftUC payCmd.OpenShiftHandler,
+	recordMovementUC payCmd.RecordDrawerMovementHandler,
+	closeShiftUC payCmd.CloseShiftHandler,
+	restaurantRepo restaurant.Repository,
+	membershipRepo membership.Repository,
+) *DrawerHandler {
+	return &DrawerHandler{
+		currentShiftUC:   currentShiftUC,
+		shiftReportUC:    shiftReportUC,
+		openShiftUC:      openShiftUC,
+		recordMovementUC: recordMovementUC,
+		closeShiftUC:     closeShiftUC,
+		restaurantRepo:   restaurantRepo,
+		membershipRepo:   membershipRepo,
+	}
+}
+
+// GetDrawer handles GET /server/drawer. ?closed=<id> shows the summary of a
+// shift the user just closed; ?error= carries a failed form submission.
+func (h *DrawerHandler) GetDrawer(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+
+	current, err := h.currentShiftUC.Handle(ctx, payQuery.CurrentShift{RestaurantID: restaurantID, StaffID: staffID})
+	if err != nil && !errors.Is(err, shift.ErrShiftNotFound) {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	var justClosed *shift.Shift
+	if closedID := c.QueryParam("closed"); closedID != "" {
+		s, err := h.shiftReportUC.Handle(ctx, payQuery.ShiftReport{RestaurantID: restaurantID, ShiftID: common.ShiftID(closedID)})
+		if err == nil && s.StaffID == staffID && !s.IsOpen() {
+			justClosed = s
+		}
+	}
+
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(ctx, restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	return templates.DrawerPage(templates.DrawerView{
+		Shift:        current,
+		JustClosed:   justClosed,
+		CurrencyCode: rest.BaseCurrency.Code,
+		Error:        c.QueryParam("error"),
+		CSRFToken:    commonhttp.CSRFToken(c),
+		ActiveLabel:  label,
+		DisplayName:  dn,
+		Subtitle:     st,
+		Initials:     ini,
+		Switcher:     switchOpts,
+		ActiveRole:   activeRole,
+		CanCreate:    canCreate,
+	}).Render(ctx, c.Response())
+}
+
+// PostOpen handles POST /server/drawer/open.
+func (h *DrawerHandler) PostOpen(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	float, err := parseDrawerAmount(c.FormValue("float"), rest.BaseCurrency, true)
+	if err != nil {
+		return redirectDrawerError(c, err)
+	}
+	if _, err := h.openShiftUC.Handle(c.Request().Context(), payCmd.OpenShift{
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+		Currency:     rest.BaseCurrency,
+		OpeningFloat: float.Amount,
+	}); err != nil {
+		return redirectDrawerError(c, err)
+	}
+	return c.Redirect(http.StatusSeeOther, "/server/drawer")
+}
+
+// PostMovement handles POST /server/drawer/movement (kind=pay_in|pay_out).
+func (h *DrawerHandler) PostMovement(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	amount, err := parseDrawerAmount(c.FormValue("amount"), rest.BaseCurrency, false)
+	if err != nil {
+		return redirectDrawerError(c, err)
+	}
+	if _, err := h.recordMovementUC.Handle(c.Request().Context(), payCmd.RecordDrawerMovement{
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+		Kind:         shift.MovementKind(c.FormValue("kind")),
+		Amount:       amount,
+		Reason:       c.FormValue("reason"),
+	}); err != nil {
+		return redirectDrawerError(c, err)
+	}
+	return c.Redirect(http.StatusSeeOther, "/server/drawer")
+}
+
+// PostClose handles POST /server/drawer/close.
+func (h *DrawerHandler) PostClose(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	counted, err := parseDrawerAmount(c.FormValue("counted"), rest.BaseCurrency, true)
+	if err != nil {
+		return redirectDrawerError(c, err)
+	}
+	s, err := h.closeShiftUC.Handle(c.Request().Context(), payCmd.CloseShift{
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+		Counted:      counted,
+		Note:         c.FormValue("note"),
+	})
+	if err != nil {
+		return redirectDrawerError(c, err)
+	}
+	return c.Redirect(http.StatusSeeOther, "/server/drawer?closed="+url.QueryEscape(string(s.ID)))
+}
+
+func drawerActor(c echo.Context) (common.RestaurantID, common.UserID, error) {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return "", "", c.String(http.StatusUnauthorized, err.Error())
+	}
+	u, ok := commonhttp.GetAuthenticatedUser(c)
+	if !ok || u == nil {
+		return "", "", c.String(http.StatusUnauthorized, "authentication required")
+	}
+	return restaurantID, u.ID, nil
+}
+
+var errInvalidDrawerAmount = errors.New("enter an amount like 12.50")
+
+// parseDrawerAmount reads a major-unit amount in currency; blank is zero
+// when allowed.
+func parseDrawerAmount(raw string, currency money.Currency, blankIsZero bool) (money.Money, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" && blankIsZero {
+		return money.New(0, currency), nil
+	}
+	m, err := money.ParseMajor(raw, currency)
+	if err != nil {
+		return money.Money{}, errInvalidDrawerAmount
+	}
+	return m, nil
+}
+
+// redirectDrawerError sends the user back to the drawer with a message the
+// page shows as a toast.
+func redirectDrawerError(c echo.Context, err error) error {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, shift.ErrShiftNotFound):
+		msg = "Your drawer is not open."
+	case errors.Is(err, shift.ErrShiftAlreadyOpen):
+		msg = "Your drawer is already open."
+	}
+	return c.Redirect(http.StatusSeeOther, "/server/drawer?error="+url.QueryEscape(msg))
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"bitmerchant/internal/auth/domain/membership"
+	"bitmerchant/internal/auth/domain/user"
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/interfaces/templates"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/shift"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ShiftsHandler renders the owner's shift history and end-of-shift reports
+// under /dashboard/shifts.
+type ShiftsHandler struct {
+	historyUC      payQuery.ShiftHistoryHandler
+	reportUC       payQuery.ShiftReportHandler
+	restaurantRepo restaurant.Repository
+	membershipRepo membership.Repository
+	userRepo       user.Repository
+}
+
+func NewShiftsHandler(
+	historyUC payQuery.ShiftHistoryHandler,
+	reportUC payQuery.ShiftReportHandler,
+	restaurantRepo restaurant.Repository,
+	membershipRepo membership.Repository,
+	userRepo user.Repository,
+) *ShiftsHandler {
+	return &ShiftsHandler{
+		historyUC:      historyUC,
+		reportUC:       reportUC,
+		restaurantRepo: restaurantRepo,
+		membershipRepo: membershipRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// GetShifts handles GET /dashboard/shifts[?staff=<userID>].
+func (h *ShiftsHandler) GetShifts(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	ctx := c.Request().Context()
+	staffFilter := common.UserID(c.QueryParam("staff"))
+	shifts, err := h.historyUC.Handle(ctx, payQuery.ShiftHistory{RestaurantID: restaurantID, StaffID: staffFilter})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load shifts: "+err.Error())
+	}
+	names, staff := h.staffDirectory(restaurantID)
+
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(ctx, restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	return templates.ShiftsPage(templates.ShiftsView{
+		Shifts:      shifts,
+		Staff:       staff,
+		StaffFilter: staffFilter,
+		StaffNames:  names,
+		CSRFToken:   commonhttp.CSRFToken(c),
+		ActiveLabel: label,
+		DisplayName: dn,
+		Subtitle:    st,
+		Initials:    ini,
+		Switcher:    switchOpts,
+		ActiveRole:  activeRole,
+		CanCreate:   canCreate,
+	}).Render(ctx, c.Response())
+}
+
+// GetShift handles GET /dashboard/shifts/:id.
+func (h *ShiftsHandler) GetShift(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	ctx := c.Request().Context()
+	s, err := h.reportUC.Handle(ctx, payQuery.ShiftReport{RestaurantID: restaurantID, ShiftID: common.ShiftID(c.Param("id"))})
+	if err != nil {
+		if errors.Is(err, shift.ErrShiftNotFound) {
+			return c.String(http.StatusNotFound, "Shift not found")
+		}
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	names, _ := h.staffDirectory(restaurantID)
+
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(ctx, restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	return templates.ShiftReportPage(templates.ShiftReportView{
+		Shift:       s,
+		StaffNames:  names,
+		CSRFToken:   commonhttp.CSRFToken(c),
+		ActiveLabel: label,
+		DisplayName: dn,
+		Subtitle:    st,
+		Initials:    ini,
+		Switcher:    switchOpts,
+		ActiveRole:  activeRole,
+		CanCreate:   canCreate,
+	}).Render(ctx, c.Response())
+}
+
+// staffDirectory resolves display names for the restaurant's members. Lookup
+// failures fall back to showing raw IDs rather than failing the page.
+func (h *ShiftsHandler) staffDirectory(restaurantID common.RestaurantID) (map[common.UserID]string, []templates.ShiftStaffOption) {
+	names := map[common.UserID]string{}
+	var staff []templates.ShiftStaffOption
+	members, err := h.membershipRepo.FindByRestaurantID(restaurantID)
+	if err != nil {
+		return names, nil
+	}
+	for _, m := range members {
+		name := string(m.UserID)
+		if u, err := h.userRepo.FindByID(m.UserID); err == nil && u.DisplayName != "" {
+			name = u.DisplayName
+		}
+		names[m.UserID] = name
+		staff = append(staff, templates.ShiftStaffOption{ID: m.UserID, Name: name})
+	}
+	sort.Slice(staff, func(i, j int) bool { return staff[i].Name < staff[j].Name })
+	return names, staff
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"bitmerchant/internal/auth/domain/membership"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/interfaces/templates"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/timecard"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+)
+
+// recentTimecards is how many past timecards the time clock lists.
+const recentTimecards = 10
+
+// TimeClockHandler lets staff clock in and out. The hours it records are
+// what tip pools are shared out by.
+type TimeClockHandler struct {
+	timecardsUC    payQuery.StaffTimecardsHandler
+	clockInUC      payCmd.ClockInHandler
+	clockOutUC     payCmd.ClockOutHandler
+	restaurantRepo restaurant.Repository
+	membershipRepo membership.Repository
+}
+
+func NewTimeClockHandler(
+	timecardsUC payQuery.StaffTimecardsHandler,
+	clockInUC payCmd.ClockInHandler,
+	clockOutUC payCmd.ClockOutHandler,
+	restaurantRepo restaurant.Repository,
+	membershipRepo membership.Repository,
+) *TimeClockHandler {
+	return &TimeClockHandler{
+		timecardsUC:    timecardsUC,
+		clockInUC:      clockInUC,
+		clockOutUC:     clockOutUC,
+		restaurantRepo: restaurantRepo,
+		membershipRepo: membershipRepo,
+	}
+}
+
+// GetTimeClock handles GET /staff/clock. ?error= carries a failed clock in
+// or out.
+func (h *TimeClockHandler) GetTimeClock(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	cards, err := h.timecardsUC.Handle(ctx, payQuery.StaffTimecards{RestaurantID: restaurantID, StaffID: staffID, Limit: recentTimecards})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(ctx, restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	return templates.TimeClockPage(templates.TimeClockView{
+		Timecards:   cards,
+		Error:       c.QueryParam("error"),
+		CSRFToken:   commonhttp.CSRFToken(c),
+		ActiveLabel: label,
+		DisplayName: dn,
+		Subtitle:    st,
+		Initials:    ini,
+		Switcher:    switchOpts,
+		ActiveRole:  activeRole,
+		CanCreate:   canCreate,
+	}).Render(ctx, c.Response())
+}
+
+// PostClockIn handles POST /staff/clock/in. The timecard records the role
+// the staff member holds in the restaurant right now.
+func (h *TimeClockHandler) PostClockIn(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	m, err := h.membershipRepo.FindByUserAndRestaurant(staffID, restaurantID)
+	if err != nil {
+		return c.String(http.StatusForbidden, "not a member of this restaurant")
+	}
+	if _, err := h.clockInUC.Handle(c.Request().Context(), payCmd.ClockIn{
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+		Role:         m.Role,
+	}); err != nil {
+		return redirectTimeClockError(c, err)
+	}
+	return c.Redirect(http.StatusSeeOther, "/staff/clock")
+}
+
+// PostClockOut handles POST /staff/clock/out.
+func (h *TimeClockHandler) PostClockOut(c echo.Context) error {
+	restaurantID, staffID, err := drawerActor(c)
+	if err != nil {
+		return err
+	}
+	if _, err := h.clockOutUC.Handle(c.Request().Context(), payCmd.ClockOut{
+		RestaurantID: restaurantID,
+		StaffID:      staffID,
+	}); err != nil {
+		return redirectTimeClockError(c, err)
+	}
+	return c.Redirect(http.StatusSeeOther, "/staff/clock")
+}
+
+// redirectTimeClockError sends the user back to the time clock with a
+// message the page shows as a toast.
+func redirectTimeClockError(c echo.Context, err error) error {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, timecard.ErrNotFound):
+		msg = "You are not clocked in."
+	case errors.Is(err, timecard.ErrAlreadyClockedIn):
+		msg = "You are already clocked in."
+	}
+	return c.Redirect(http.StatusSeeOther, "/staff/clock?error="+url.QueryEscape(msg))
+}
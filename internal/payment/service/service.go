@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/money"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/payment"
+	paymenthttp "bitmerchant/internal/payment/ports/http"
+	"bitmerchant/internal/wiring"
+)
+
+// Lightning backends accepted by LIGHTNING_BACKEND. Empty disables Lightning.
+const (
+	LightningBackendFake = "fake"
+	LightningBackendLND  = "lnd"
+	LightningBackendCLN  = "cln"
+)
+
+// Payment bundles payment methods, the payment ledger commands, cash drawer
+// shifts, the staff time clock and the Lightning settlement watcher.
+// Checkout through a restaurant's own Lightning address is always available;
+// the node-backed method is nil when no Lightning backend is configured.
+type Payment struct {
+	Cash *payAdapters.CashPaymentMethod
+
+	RecordPayment payCmd.RecordPaymentHandler
+	RefundPayment payCmd.RefundPaymentHandler
+	VoidPayment   payCmd.VoidPaymentHandler
+
+	OpenShift            payCmd.OpenShiftHandler
+	RecordDrawerMovement payCmd.RecordDrawerMovementHandler
+	CloseShift           payCmd.CloseShiftHandler
+	CurrentShift         payQuery.CurrentShiftHandler
+	ShiftHistory         payQuery.ShiftHistoryHandler
+	ShiftReport          payQuery.ShiftReportHandler
+	Drawer               *paymenthttp.DrawerHandler
+	Shifts               *paymenthttp.ShiftsHandler
+
+	ClockIn        payCmd.ClockInHandler
+	ClockOut       payCmd.ClockOutHandler
+	StaffTimecards payQuery.StaffTimecardsHandler
+	TimeClock      *paymenthttp.TimeClockHandler
+
+	LNURLPay                *payAdapters.LNURLPayMethod
+	LightningNode           payment.LightningNode
+	Lightning               *payAdapters.LightningPaymentMethod
+	RequestLightningInvoice payCmd.RequestLightningInvoiceHandler
+	LightningWatcher        *payAdapters.LightningSettlementWatcher
+}
+
+// LightningEnabled reports whether a platform Lightning node is configured,
+// i.e. whether restaurants without their own address can offer Lightning.
+func (p Payment) LightningEnabled() bool {
+	return p.Lightning != nil
+}
+
+// New wires payment methods. converter prices Lightning invoices in sats.
+// onSettled runs when the watcher finds a Lightning invoice paid, before the
+// payment is stored (the composition root marks the order paid there).
+func New(repos wiring.Repositories, cfg wiring.Config, converter money.Converter, logger *slog.Logger, metrics decorator.MetricsClient, onSettled payAdapters.SettledFunc) (Payment, error) {
+	svc := Payment{
+		Cash:                 payAdapters.NewCashPaymentMethod(),
+		RecordPayment:        payCmd.NewRecordPaymentHandler(repos.Payment, repos.Shift, logger, metrics),
+		RefundPayment:        payCmd.NewRefundPaymentHandler(repos.Payment, repos.Shift, logger, metrics),
+		VoidPayment:          payCmd.NewVoidPaymentHandler(repos.Payment, logger, metrics),
+		OpenShift:            payCmd.NewOpenShiftHandler(repos.Shift, logger, metrics),
+		RecordDrawerMovement: payCmd.NewRecordDrawerMovementHandler(repos.Shift, logger, metrics),
+		CloseShift:           payCmd.NewCloseShiftHandler(repos.Shift, logger, metrics),
+		CurrentShift:         payQuery.NewCurrentShiftHandler(repos.Shift, logger, metrics),
+		ShiftHistory:         payQuery.NewShiftHistoryHandler(repos.Shift, logger, metrics),
+		ShiftReport:          payQuery.NewShiftReportHandler(repos.Shift, logger, metrics),
+		ClockIn:              payCmd.NewClockInHandler(repos.Timecard, logger, metrics),
+		ClockOut:             payCmd.NewClockOutHandler(repos.Timecard, logger, metrics),
+		StaffTimecards:       payQuery.NewStaffTimecardsHandler(repos.Timecard, logger, metrics),
+	}
+	svc.Drawer = paymenthttp.NewDrawerHandler(svc.CurrentShift, svc.ShiftReport, svc.OpenShift, svc.RecordDrawerMovement, svc.CloseShift, repos.Restaurant, repos.Membership)
+	svc.Shifts = paymenthttp.NewShiftsHandler(svc.ShiftHistory, svc.ShiftReport, repos.Restaurant, repos.Membership, repos.User)
+	svc.TimeClock = paymenthttp.NewTimeClockHandler(svc.StaffTimecards, svc.ClockIn, svc.ClockOut, repos.Restaurant, repos.Membership)
+
+	node, err := NewLightningNode(cfg)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	lnurl := payAdapters.NewLNURLClient(cfg.LNURLAllowLocal)
+	svc.LNURLPay = payAdapters.NewLNURLPayMethod(lnurl, converter, repos.Payment, func(_ context.Context, id common.RestaurantID) (string, error) {
+		rest, err := repos.Restaurant.FindByID(id)
+		if err != nil {
+			return "", err
+		}
+		return rest.LightningAddress, nil
+	})
+
+	var nodeIssuer payment.InvoiceIssuer
+	if node != nil {
+		svc.LightningNode = node
+		svc.Lightning = payAdapters.NewLightningPaymentMethod(node, converter, repos.Payment, cfg.LightningInvoiceExpiry)
+		nodeIssuer = svc.Lightning
+	}
+	svc.RequestLightningInvoice = payCmd.NewRequestLightningInvoiceHandler(repos.Payment, svc.LNURLPay, nodeIssuer, logger, metrics)
+	svc.LightningWatcher = payAdapters.NewLightningSettlementWatcher(repos.Payment, node, lnurl, cfg.LightningPollInterval, onSettled, logger)
+	return svc, nil
+}
+
+// NewLightningNode builds the node adapter selected by cfg.LightningBackend.
+// Returns (nil, nil) when Lightning is disabled.
+func NewLightningNode(cfg wiring.Config) (payment.LightningNode, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.LightningBackend)) {
+	case "":
+		return nil, nil
+	case LightningBackendFake:
+		node := payAdapters.NewFakeLightningNode()
+		node.AutoSettleAfter = cfg.LightningFakeAutoSettle
+		return node, nil
+	case LightningBackendLND:
+		return payAdapters.NewLNDNode(payAdapters.LNDConfig{
+			BaseURL:     cfg.LNDRESTURL,
+			MacaroonHex: cfg.LNDMacaroonHex,
+			TLSCertPath: cfg.LNDTLSCertPath,
+		})
+	case LightningBackendCLN:
+		return payAdapters.NewCLNNode(payAdapters.CLNConfig{
+			BaseURL:     cfg.CLNRESTURL,
+			Rune:        cfg.CLNRune,
+			TLSCertPath: cfg.CLNTLSCertPath,
+		})
+	default:
+		return nil, fmt.Errorf("unknown lightning backend %q (want fake, lnd or cln)", cfg.LightningBackend)
+	}
+}
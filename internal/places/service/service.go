@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bitmerchant/internal/common/decorator"
 	placesCmd "bitmerchant/internal/places/app/command"
 	placesQuery "bitmerchant/internal/places/app/query"
 	placeshttp "bitmerchant/internal/places/ports/http"
@@ -15,9 +16,9 @@ type Places struct {
 }
 
 // New wires places bounded-context handlers and HTTP port.
-func New(repos wiring.Repositories) Places {
-	recordMenuVisitUC := placesCmd.NewRecordMenuVisitHandler(repos.Restaurant, repos.SessionRestaurantVisits, nil, nil)
-	listVisitedUC := placesQuery.NewSessionVisitedPlacesHandler(repos.SessionRestaurantVisits, repos.Restaurant, repos.Order, nil, nil)
+func New(repos wiring.Repositories, metrics decorator.MetricsClient) Places {
+	recordMenuVisitUC := placesCmd.NewRecordMenuVisitHandler(repos.Restaurant, repos.SessionRestaurantVisits, nil, metrics)
+	listVisitedUC := placesQuery.NewSessionVisitedPlacesHandler(repos.SessionRestaurantVisits, repos.Restaurant, repos.Order, nil, metrics)
 	return Places{
 		RecordMenuVisit:        recordMenuVisitUC,
 		ListVisitedRestaurants: listVisitedUC,
@@ -0,0 +1,133 @@
+package adapters
+
+import (
+	"sort"
+	"sync"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/promotion/domain/promotion"
+)
+
+type MemoryPromotionRepository struct {
+	mu          sync.RWMutex
+	promotions  map[common.PromotionID]*promotion.Promotion
+	redemptions []promotion.Redemption
+}
+
+func NewMemoryPromotionRepository() *MemoryPromotionRepository {
+	return &MemoryPromotionRepository{
+		promotions: make(map[common.PromotionID]*promotion.Promotion),
+	}
+}
+
+func (r *MemoryPromotionRepository) Save(p *promotion.Promotion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.codeTaken(p) {
+		return promotion.ErrCodeTaken
+	}
+	r.promotions[p.ID] = p.Clone()
+	return nil
+}
+
+func (r *MemoryPromotionRepository) Update(p *promotion.Promotion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.promotions[p.ID]
+	if !ok {
+		return promotion.ErrPromotionNotFound
+	}
+	if r.codeTaken(p) {
+		return promotion.ErrCodeTaken
+	}
+	cp := p.Clone()
+	cp.UsesCount = existing.UsesCount
+	r.promotions[p.ID] = cp
+	return nil
+}
+
+func (r *MemoryPromotionRepository) codeTaken(p *promotion.Promotion) bool {
+	if p.Code == "" {
+		return false
+	}
+	for _, other := range r.promotions {
+		if other.ID != p.ID && other.RestaurantID == p.RestaurantID && other.Code == p.Code {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryPromotionRepository) FindByID(id common.PromotionID) (*promotion.Promotion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.promotions[id]
+	if !ok {
+		return nil, promotion.ErrPromotionNotFound
+	}
+	return p.Clone(), nil
+}
+
+func (r *MemoryPromotionRepository) FindByRestaurantID(restaurantID common.RestaurantID) ([]*promotion.Promotion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []*promotion.Promotion
+	for _, p := range r.promotions {
+		if p.RestaurantID == restaurantID {
+			result = append(result, p.Clone())
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *MemoryPromotionRepository) FindByCode(restaurantID common.RestaurantID, code string) (*promotion.Promotion, error) {
+	code = promotion.NormalizeCode(code)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if code == "" {
+		return nil, promotion.ErrPromotionNotFound
+	}
+	for _, p := range r.promotions {
+		if p.RestaurantID == restaurantID && p.Code == code {
+			return p.Clone(), nil
+		}
+	}
+	return nil, promotion.ErrPromotionNotFound
+}
+
+func (r *MemoryPromotionRepository) CountSessionRedemptions(id common.PromotionID, sessionID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sessionRedemptions(id, sessionID), nil
+}
+
+func (r *MemoryPromotionRepository) sessionRedemptions(id common.PromotionID, sessionID string) int {
+	n := 0
+	for _, red := range r.redemptions {
+		if red.PromotionID == id && red.SessionID == sessionID {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *MemoryPromotionRepository) Redeem(red promotion.Redemption) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.promotions[red.PromotionID]
+	if !ok {
+		return promotion.ErrPromotionNotFound
+	}
+	if p.MaxUses > 0 && p.UsesCount >= p.MaxUses {
+		return promotion.ErrUsageLimitReached
+	}
+	if p.MaxUsesPerSession > 0 && r.sessionRedemptions(red.PromotionID, red.SessionID) >= p.MaxUsesPerSession {
+		return promotion.ErrSessionLimitReached
+	}
+	p.UsesCount++
+	r.redemptions = append(r.redemptions, red)
+	return nil
+}
@@ -0,0 +1,239 @@
+package adapters
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/promotion/domain/promotion"
+)
+
+type PostgresPromotionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPromotionRepository(db *sql.DB) *PostgresPromotionRepository {
+	return &PostgresPromotionRepository{db: db}
+}
+
+const promotionColumns = `id, restaurant_id, name, code, kind, percent_off, amount_off, buy_quantity, get_quantity,
+	category_id, min_subtotal, currency, starts_at, ends_at, max_uses, max_uses_per_session, uses_count,
+	is_active, created_at, updated_at`
+
+func (r *PostgresPromotionRepository) Save(p *promotion.Promotion) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := checkCodeFree(tx, p); err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`INSERT INTO promotions (`+promotionColumns+`)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20)`,
+		string(p.ID), string(p.RestaurantID), p.Name, p.Code, string(p.Kind),
+		p.PercentOff, p.AmountOff, p.BuyQuantity, p.GetQuantity,
+		string(p.CategoryID), p.MinSubtotal, p.Currency.Code, p.StartsAt, p.EndsAt,
+		p.MaxUses, p.MaxUsesPerSession, p.UsesCount,
+		p.Active, p.CreatedAt, p.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresPromotionRepository) Update(p *promotion.Promotion) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := checkCodeFree(tx, p); err != nil {
+		return err
+	}
+	result, err := tx.Exec(
+		`UPDATE promotions SET name=$2, code=$3, kind=$4, percent_off=$5, amount_off=$6,
+		   buy_quantity=$7, get_quantity=$8, category_id=$9, min_subtotal=$10,
+		   starts_at=$11, ends_at=$12, max_uses=$13, max_uses_per_session=$14,
+		   is_active=$15, updated_at=$16
+		 WHERE id=$1`,
+		string(p.ID), p.Name, p.Code, string(p.Kind), p.PercentOff, p.AmountOff,
+		p.BuyQuantity, p.GetQuantity, string(p.CategoryID), p.MinSubtotal,
+		p.StartsAt, p.EndsAt, p.MaxUses, p.MaxUsesPerSession,
+		p.Active, p.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return promotion.ErrPromotionNotFound
+	}
+	return tx.Commit()
+}
+
+// checkCodeFree rejects a code already used by another of the restaurant's
+// promotions. The partial unique index backs this up under concurrency.
+func checkCodeFree(tx *sql.Tx, p *promotion.Promotion) error {
+	if p.Code == "" {
+		return nil
+	}
+	var taken bool
+	err := tx.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM promotions WHERE restaurant_id = $1 AND code = $2 AND id <> $3)`,
+		string(p.RestaurantID), p.Code, string(p.ID)).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return promotion.ErrCodeTaken
+	}
+	return nil
+}
+
+func (r *PostgresPromotionRepository) FindByID(id common.PromotionID) (*promotion.Promotion, error) {
+	return scanPromotion(r.db.QueryRow(`SELECT `+promotionColumns+` FROM promotions WHERE id = $1`, string(id)))
+}
+
+func (r *PostgresPromotionRepository) FindByRestaurantID(restaurantID common.RestaurantID) ([]*promotion.Promotion, error) {
+	rows, err := r.db.Query(
+		`SELECT `+promotionColumns+` FROM promotions WHERE restaurant_id = $1 ORDER BY created_at DESC`,
+		string(restaurantID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []*promotion.Promotion
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
+
+func (r *PostgresPromotionRepository) FindByCode(restaurantID common.RestaurantID, code string) (*promotion.Promotion, error) {
+	code = promotion.NormalizeCode(code)
+	if code == "" {
+		return nil, promotion.ErrPromotionNotFound
+	}
+	return scanPromotion(r.db.QueryRow(
+		`SELECT `+promotionColumns+` FROM promotions WHERE restaurant_id = $1 AND code = $2`,
+		string(restaurantID), code))
+}
+
+func (r *PostgresPromotionRepository) CountSessionRedemptions(id common.PromotionID, sessionID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(
+		`SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND session_id = $2`,
+		string(id), sessionID).Scan(&n)
+	return n, err
+}
+
+// Redeem bumps uses_count only while it is under max_uses. The UPDATE holds
+// the promotion's row lock until commit, so concurrent redemptions of the
+// same promotion also serialize on the per-session count.
+func (r *PostgresPromotionRepository) Redeem(red promotion.Redemption) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var perSession int
+	err = tx.QueryRow(
+		`UPDATE promotions SET uses_count = uses_count + 1
+		 WHERE id = $1 AND (max_uses = 0 OR uses_count < max_uses)
+		 RETURNING max_uses_per_session`,
+		string(red.PromotionID)).Scan(&perSession)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, ferr := r.FindByID(red.PromotionID); ferr != nil {
+			return ferr
+		}
+		return promotion.ErrUsageLimitReached
+	}
+	if err != nil {
+		return err
+	}
+	if perSession > 0 {
+		var used int
+		if err := tx.QueryRow(
+			`SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND session_id = $2`,
+			string(red.PromotionID), red.SessionID).Scan(&used); err != nil {
+			return err
+		}
+		if used >= perSession {
+			return promotion.ErrSessionLimitReached
+		}
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO promotion_redemptions (promotion_id, session_id, order_id, amount, redeemed_at)
+		 VALUES ($1, $2, $3, $4, $5)`,
+		string(red.PromotionID), red.SessionID, string(red.OrderID), red.Amount, red.RedeemedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPromotion(row rowScanner) (*promotion.Promotion, error) {
+	var (
+		id, restID, name, code, kind, categoryID, currencyCode string
+		percentOff, buyQty, getQty                             int
+		amountOff, minSubtotal                                 int64
+		startsAt, endsAt                                       sql.NullTime
+		maxUses, maxPerSession, usesCount                      int
+		isActive                                               bool
+		createdAt, updatedAt                                   time.Time
+	)
+	if err := row.Scan(&id, &restID, &name, &code, &kind, &percentOff, &amountOff, &buyQty, &getQty,
+		&categoryID, &minSubtotal, &currencyCode, &startsAt, &endsAt, &maxUses, &maxPerSession, &usesCount,
+		&isActive, &createdAt, &updatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, promotion.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	currency, err := money.Parse(currencyCode)
+	if err != nil {
+		currency = money.USD
+	}
+	p := &promotion.Promotion{
+		ID:           common.PromotionID(id),
+		RestaurantID: common.RestaurantID(restID),
+		Currency:     currency,
+		Terms: promotion.Terms{
+			Name:              name,
+			Code:              code,
+			Kind:              promotion.Kind(kind),
+			PercentOff:        percentOff,
+			AmountOff:         amountOff,
+			BuyQuantity:       buyQty,
+			GetQuantity:       getQty,
+			CategoryID:        common.CategoryID(categoryID),
+			MinSubtotal:       minSubtotal,
+			MaxUses:           maxUses,
+			MaxUsesPerSession: maxPerSession,
+		},
+		UsesCount: usesCount,
+		Active:    isActive,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+	if startsAt.Valid {
+		t := startsAt.Time
+		p.StartsAt = &t
+	}
+	if endsAt.Valid {
+		t := endsAt.Time
+		p.EndsAt = &t
+	}
+	return p, nil
+}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/promotion/domain/promotion"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+)
+
+// CreatePromotion adds a promotion priced in the restaurant's base currency.
+type CreatePromotion struct {
+	RestaurantID common.RestaurantID
+	Terms        promotion.Terms
+}
+
+type CreatePromotionHandler decorator.CommandResultHandler[CreatePromotion, *promotion.Promotion]
+
+type createPromotionHandler struct {
+	repo     promotion.Repository
+	restRepo restaurant.Repository
+}
+
+func NewCreatePromotionHandler(repo promotion.Repository, restRepo restaurant.Repository, log *slog.Logger, metrics decorator.MetricsClient) CreatePromotionHandler {
+	if repo == nil {
+		panic("nil promotion.Repository")
+	}
+	if restRepo == nil {
+		panic("nil restaurant.Repository")
+	}
+	h := createPromotionHandler{repo: repo, restRepo: restRepo}
+	return decorator.ApplyCommandResultDecorators[CreatePromotion, *promotion.Promotion](h, log, metrics)
+}
+
+func (h createPromotionHandler) Handle(ctx context.Context, cmd CreatePromotion) (*promotion.Promotion, error) {
+	_ = ctx
+	rest, err := h.restRepo.FindByID(cmd.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	id := common.PromotionID(fmt.Sprintf("promo_%d", time.Now().UnixNano()))
+	p, err := promotion.New(id, cmd.RestaurantID, rest.BaseCurrency, cmd.Terms)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.repo.Save(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// UpdatePromotion replaces the terms of one of the restaurant's promotions.
+type UpdatePromotion struct {
+	RestaurantID common.RestaurantID
+	PromotionID  common.PromotionID
+	Terms        promotion.Terms
+}
+
+type UpdatePromotionHandler decorator.CommandHandler[UpdatePromotion]
+
+type updatePromotionHandler struct {
+	repo promotion.Repository
+}
+
+func NewUpdatePromotionHandler(repo promotion.Repository, log *slog.Logger, metrics decorator.MetricsClient) UpdatePromotionHandler {
+	if repo == nil {
+		panic("nil promotion.Repository")
+	}
+	h := updatePromotionHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[UpdatePromotion](h, log, metrics)
+}
+
+func (h updatePromotionHandler) Handle(ctx context.Context, cmd UpdatePromotion) error {
+	_ = ctx
+	p, err := findOwned(h.repo, cmd.RestaurantID, cmd.PromotionID)
+	if err != nil {
+		return err
+	}
+	if err := p.Update(cmd.Terms); err != nil {
+		return err
+	}
+	return h.repo.Update(p)
+}
+
+// SetPromotionActive pauses or resumes one of the restaurant's promotions.
+type SetPromotionActive struct {
+	RestaurantID common.RestaurantID
+	PromotionID  common.PromotionID
+	Active       bool
+}
+
+type SetPromotionActiveHandler decorator.CommandHandler[SetPromotionActive]
+
+type setPromotionActiveHandler struct {
+	repo promotion.Repository
+}
+
+func NewSetPromotionActiveHandler(repo promotion.Repository, log *slog.Logger, metrics decorator.MetricsClient) SetPromotionActiveHandler {
+	if repo == nil {
+		panic("nil promotion.Repository")
+	}
+	h := setPromotionActiveHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[SetPromotionActive](h, log, metrics)
+}
+
+func (h setPromotionActiveHandler) Handle(ctx context.Context, cmd SetPromotionActive) error {
+	_ = ctx
+	p, err := findOwned(h.repo, cmd.RestaurantID, cmd.PromotionID)
+	if err != nil {
+		return err
+	}
+	p.SetActive(cmd.Active)
+	return h.repo.Update(p)
+}
+
+// RedeemPromotion records that an order used a promotion. It fails with a
+// promotion rejection when the usage caps were reached since pricing.
+type RedeemPromotion struct {
+	PromotionID common.PromotionID
+	SessionID   string
+	OrderID     common.OrderID
+	Amount      int64
+}
+
+type RedeemPromotionHandler decorator.CommandHandler[RedeemPromotion]
+
+type redeemPromotionHandler struct {
+	repo promotion.Repository
+}
+
+func NewRedeemPromotionHandler(repo promotion.Repository, log *slog.Logger, metrics decorator.MetricsClient) RedeemPromotionHandler {
+	if repo == nil {
+		panic("nil promotion.Repository")
+	}
+	h := redeemPromotionHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[RedeemPromotion](h, log, metrics)
+}
+
+func (h redeemPromotionHandler) Handle(ctx context.Context, cmd RedeemPromotion) error {
+	_ = ctx
+	return h.repo.Redeem(promotion.Redemption{
+		PromotionID: cmd.PromotionID,
+		SessionID:   cmd.SessionID,
+		OrderID:     cmd.OrderID,
+		Amount:      cmd.Amount,
+		RedeemedAt:  time.Now(),
+	})
+}
+
+// findOwned loads a promotion, treating another restaurant's as not found.
+func findOwned(repo promotion.Repository, restaurantID common.RestaurantID, id common.PromotionID) (*promotion.Promotion, error) {
+	p, err := repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.RestaurantID != restaurantID {
+		return nil, promotion.ErrPromotionNotFound
+	}
+	return p, nil
+}
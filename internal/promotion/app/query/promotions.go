@@ -0,0 +1,132 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/promotion/domain/promotion"
+)
+
+// RestaurantPromotions lists every promotion of a restaurant, newest first.
+type RestaurantPromotions struct {
+	RestaurantID common.RestaurantID
+}
+
+type RestaurantPromotionsHandler decorator.QueryHandler[RestaurantPromotions, []*promotion.Promotion]
+
+type restaurantPromotionsHandler struct {
+	repo promotion.Repository
+}
+
+func NewRestaurantPromotionsHandler(repo promotion.Repository, log *slog.Logger, metrics decorator.MetricsClient) RestaurantPromotionsHandler {
+	if repo == nil {
+		panic("nil promotion.Repository")
+	}
+	h := restaurantPromotionsHandler{repo: repo}
+	return decorator.ApplyQueryDecorators[RestaurantPromotions, []*promotion.Promotion](h, log, metrics)
+}
+
+func (h restaurantPromotionsHandler) Handle(ctx context.Context, q RestaurantPromotions) ([]*promotion.Promotion, error) {
+	_ = ctx
+	return h.repo.FindByRestaurantID(q.RestaurantID)
+}
+
+// OrderDiscount prices the best promotion for an order. Code is what the
+// customer entered, if anything. Subtotal is the whole order's, in minor
+// units of the restaurant's currency.
+type OrderDiscount struct {
+	RestaurantID common.RestaurantID
+	SessionID    string
+	Code         string
+	Lines        []promotion.Line
+	Subtotal     int64
+}
+
+// Discount is the promotion chosen for an order and what it takes off. A
+// nil Promotion means none applies.
+type Discount struct {
+	Promotion *promotion.Promotion
+	Amount    int64
+}
+
+type OrderDiscountHandler decorator.QueryHandler[OrderDiscount, Discount]
+
+type orderDiscountHandler struct {
+	repo promotion.Repository
+	now  func() time.Time
+}
+
+func NewOrderDiscountHandler(repo promotion.Repository, log *slog.Logger, metrics decorator.MetricsClient) OrderDiscountHandler {
+	if repo == nil {
+		panic("nil promotion.Repository")
+	}
+	h := orderDiscountHandler{repo: repo, now: time.Now}
+	return decorator.ApplyQueryDecorators[OrderDiscount, Discount](h, log, metrics)
+}
+
+// Handle applies one promotion per order, never stacking them: the entered
+// code when it is valid, unless an automatic deal saves the customer more.
+// An unusable code is reported as a promotion rejection rather than quietly
+// falling back, so the customer learns why it did not apply.
+func (h orderDiscountHandler) Handle(ctx context.Context, q OrderDiscount) (Discount, error) {
+	_ = ctx
+	now := h.now()
+	var best Discount
+	if code := promotion.NormalizeCode(q.Code); code != "" {
+		p, err := h.repo.FindByCode(q.RestaurantID, code)
+		if errors.Is(err, promotion.ErrPromotionNotFound) {
+			return Discount{}, promotion.ErrUnknownCode
+		}
+		if err != nil {
+			return Discount{}, err
+		}
+		if best, err = h.price(p, q, now); err != nil {
+			return Discount{}, err
+		}
+	}
+
+	all, err := h.repo.FindByRestaurantID(q.RestaurantID)
+	if err != nil {
+		return Discount{}, err
+	}
+	for _, p := range all {
+		if !p.IsAutomatic() {
+			continue
+		}
+		d, err := h.price(p, q, now)
+		if promotion.IsRejection(err) {
+			continue
+		}
+		if err != nil {
+			return Discount{}, err
+		}
+		if d.Amount > best.Amount {
+			best = d
+		}
+	}
+	return best, nil
+}
+
+func (h orderDiscountHandler) price(p *promotion.Promotion, q OrderDiscount, now time.Time) (Discount, error) {
+	if err := p.CheckAvailable(now); err != nil {
+		return Discount{}, err
+	}
+	if p.MaxUsesPerSession > 0 && q.SessionID != "" {
+		used, err := h.repo.CountSessionRedemptions(p.ID, q.SessionID)
+		if err != nil {
+			return Discount{}, err
+		}
+		if used >= p.MaxUsesPerSession {
+			return Discount{}, promotion.ErrSessionLimitReached
+		}
+	}
+	amount, err := p.Discount(q.Lines, q.Subtotal)
+	if err != nil {
+		return Discount{}, err
+	}
+	return Discount{Promotion: p, Amount: amount}, nil
+}
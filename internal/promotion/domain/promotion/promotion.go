@@ -0,0 +1,301 @@
+package promotion
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+)
+
+// Kind selects how a promotion prices its discount.
+type Kind string
+
+const (
+	// KindPercentage takes PercentOff percent off the eligible items.
+	KindPercentage Kind = "percentage"
+	// KindFixed takes AmountOff off the eligible items.
+	KindFixed Kind = "fixed"
+	// KindBuyXGetY gives GetQuantity units free for every BuyQuantity bought;
+	// the free units are always the cheapest eligible ones.
+	KindBuyXGetY Kind = "buy_x_get_y"
+)
+
+// Kinds lists the promotion kinds in the order the admin form offers them.
+var Kinds = []Kind{KindPercentage, KindFixed, KindBuyXGetY}
+
+const (
+	maxNameLength = 60
+	maxCodeLength = 32
+)
+
+var (
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrCodeTaken         = errors.New("another promotion already uses this code")
+)
+
+// Rejections are the reasons a promotion cannot be applied to an order. Their
+// messages are written for the customer; IsRejection tells them apart from
+// storage failures.
+var (
+	ErrUnknownCode         = rejection("this promo code is not valid here")
+	ErrInactive            = rejection("this promotion is no longer running")
+	ErrNotStarted          = rejection("this promotion has not started yet")
+	ErrExpired             = rejection("this promotion has expired")
+	ErrUsageLimitReached   = rejection("this promotion has been fully redeemed")
+	ErrSessionLimitReached = rejection("you have already used this promotion")
+	ErrBelowMinimum        = rejection("your order is below this promotion's minimum")
+	ErrNotApplicable       = rejection("this promotion does not apply to the items in your order")
+)
+
+type rejectionError struct{ msg string }
+
+func (e *rejectionError) Error() string { return e.msg }
+
+func rejection(msg string) error { return &rejectionError{msg: msg} }
+
+// IsRejection reports whether err explains why a promotion does not apply,
+// as opposed to a failure to look it up.
+func IsRejection(err error) bool {
+	var r *rejectionError
+	return errors.As(err, &r)
+}
+
+// Terms are the owner-editable parts of a promotion.
+type Terms struct {
+	Name string
+	// Code is what customers type at checkout. Empty makes the promotion an
+	// automatic deal applied to every qualifying order.
+	Code        string
+	Kind        Kind
+	PercentOff  int
+	AmountOff   int64
+	BuyQuantity int
+	GetQuantity int
+	// CategoryID limits the discount to one menu category; empty covers the
+	// whole menu.
+	CategoryID common.CategoryID
+	// MinSubtotal is the whole-order subtotal required, in minor units.
+	MinSubtotal int64
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	// MaxUses caps redemptions across all customers; MaxUsesPerSession caps
+	// them per browser session. Zero means unlimited.
+	MaxUses           int
+	MaxUsesPerSession int
+}
+
+// Promotion is a discount a restaurant offers at checkout. Amounts are minor
+// units of Currency, the restaurant's base currency.
+type Promotion struct {
+	ID           common.PromotionID
+	RestaurantID common.RestaurantID
+	Currency     money.Currency
+	Terms
+	UsesCount int
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Line is one cart line as promotions see it. UnitPrice includes modifiers.
+type Line struct {
+	CategoryID common.CategoryID
+	Quantity   int
+	UnitPrice  int64
+}
+
+// Redemption records one order that used a promotion.
+type Redemption struct {
+	PromotionID common.PromotionID
+	SessionID   string
+	OrderID     common.OrderID
+	Amount      int64
+	RedeemedAt  time.Time
+}
+
+// New creates an active promotion after validating its terms.
+func New(id common.PromotionID, restaurantID common.RestaurantID, currency money.Currency, terms Terms) (*Promotion, error) {
+	if id == "" || restaurantID == "" {
+		return nil, errors.New("promotion and restaurant IDs are required")
+	}
+	terms, err := normalizeTerms(terms)
+	if err != nil {
+		return nil, err
+	}
+	if currency.IsZero() {
+		currency = money.USD
+	}
+	now := time.Now()
+	return &Promotion{
+		ID:           id,
+		RestaurantID: restaurantID,
+		Currency:     currency,
+		Terms:        terms,
+		Active:       true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Update replaces the promotion's terms. Redemptions so far still count
+// towards the new limits.
+func (p *Promotion) Update(terms Terms) error {
+	terms, err := normalizeTerms(terms)
+	if err != nil {
+		return err
+	}
+	p.Terms = terms
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetActive pauses or resumes the promotion.
+func (p *Promotion) SetActive(active bool) {
+	p.Active = active
+	p.UpdatedAt = time.Now()
+}
+
+// IsAutomatic reports whether the promotion applies without a code.
+func (p *Promotion) IsAutomatic() bool {
+	return p.Code == ""
+}
+
+// NormalizeCode is how codes are stored and compared: trimmed, upper case.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func normalizeTerms(t Terms) (Terms, error) {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Code = NormalizeCode(t.Code)
+	if t.Name == "" || len(t.Name) > maxNameLength {
+		return Terms{}, errors.New("promotion name must be between 1 and 60 characters")
+	}
+	if len(t.Code) > maxCodeLength || strings.ContainsAny(t.Code, " \t") {
+		return Terms{}, errors.New("promo code must be at most 32 characters with no spaces")
+	}
+	switch t.Kind {
+	case KindPercentage:
+		if t.PercentOff < 1 || t.PercentOff > 100 {
+			return Terms{}, errors.New("percentage off must be between 1 and 100")
+		}
+		t.AmountOff, t.BuyQuantity, t.GetQuantity = 0, 0, 0
+	case KindFixed:
+		if t.AmountOff <= 0 {
+			return Terms{}, errors.New("amount off must be greater than 0")
+		}
+		t.PercentOff, t.BuyQuantity, t.GetQuantity = 0, 0, 0
+	case KindBuyXGetY:
+		if t.BuyQuantity < 1 || t.GetQuantity < 1 {
+			return Terms{}, errors.New("buy and get quantities must be at least 1")
+		}
+		t.PercentOff, t.AmountOff = 0, 0
+	default:
+		return Terms{}, errors.New("unknown promotion kind")
+	}
+	if t.MinSubtotal < 0 {
+		return Terms{}, errors.New("minimum subtotal cannot be negative")
+	}
+	if t.MaxUses < 0 || t.MaxUsesPerSession < 0 {
+		return Terms{}, errors.New("usage limits cannot be negative")
+	}
+	if t.StartsAt != nil && t.EndsAt != nil && !t.EndsAt.After(*t.StartsAt) {
+		return Terms{}, errors.New("promotion must end after it starts")
+	}
+	return t, nil
+}
+
+// CheckAvailable reports why the promotion cannot be redeemed at now, if it
+// cannot: paused, outside its validity window, or fully redeemed.
+func (p *Promotion) CheckAvailable(now time.Time) error {
+	switch {
+	case !p.Active:
+		return ErrInactive
+	case p.StartsAt != nil && now.Before(*p.StartsAt):
+		return ErrNotStarted
+	case p.EndsAt != nil && !now.Before(*p.EndsAt):
+		return ErrExpired
+	case p.MaxUses > 0 && p.UsesCount >= p.MaxUses:
+		return ErrUsageLimitReached
+	}
+	return nil
+}
+
+// Discount prices the promotion against lines, never exceeding the eligible
+// items' value. subtotal is the whole order's, checked against MinSubtotal.
+func (p *Promotion) Discount(lines []Line, subtotal int64) (int64, error) {
+	if subtotal < p.MinSubtotal {
+		return 0, ErrBelowMinimum
+	}
+	var eligible int64
+	var units []int64
+	for _, l := range lines {
+		if l.Quantity <= 0 || (p.CategoryID != "" && l.CategoryID != p.CategoryID) {
+			continue
+		}
+		eligible += int64(l.Quantity) * l.UnitPrice
+		for i := 0; i < l.Quantity; i++ {
+			units = append(units, l.UnitPrice)
+		}
+	}
+	if eligible <= 0 {
+		return 0, ErrNotApplicable
+	}
+
+	var amount int64
+	switch p.Kind {
+	case KindPercentage:
+		amount = (2*eligible*int64(p.PercentOff) + 100) / 200
+	case KindFixed:
+		amount = min(p.AmountOff, eligible)
+	case KindBuyXGetY:
+		amount = freeUnits(units, p.BuyQuantity, p.GetQuantity)
+	}
+	if amount <= 0 {
+		return 0, ErrNotApplicable
+	}
+	return amount, nil
+}
+
+// freeUnits sorts unit prices high to low and, in each full group of buy+get
+// units, makes the get cheapest ones free.
+func freeUnits(units []int64, buy, get int) int64 {
+	sort.Slice(units, func(i, j int) bool { return units[i] > units[j] })
+	group := buy + get
+	var free int64
+	for start := 0; start+group <= len(units); start += group {
+		for _, u := range units[start+buy : start+group] {
+			free += u
+		}
+	}
+	return free
+}
+
+// Label is how the discount line reads on the bill.
+func (p *Promotion) Label() string {
+	if p.Code != "" {
+		return p.Name + " (" + p.Code + ")"
+	}
+	return p.Name
+}
+
+// Clone returns a deep copy, so repositories can hand out promotions
+// without sharing the validity window pointers.
+func (p *Promotion) Clone() *Promotion {
+	if p == nil {
+		return nil
+	}
+	cp := *p
+	if p.StartsAt != nil {
+		t := *p.StartsAt
+		cp.StartsAt = &t
+	}
+	if p.EndsAt != nil {
+		t := *p.EndsAt
+		cp.EndsAt = &t
+	}
+	return &cp
+}
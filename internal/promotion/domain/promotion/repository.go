@@ -0,0 +1,25 @@
+package promotion
+
+import "bitmerchant/internal/common"
+
+// Repository defines operations for Promotion persistence.
+type Repository interface {
+	// Save inserts a promotion, or returns ErrCodeTaken when another
+	// promotion of the restaurant already uses its code.
+	Save(p *Promotion) error
+	// Update stores new terms and the active flag; UsesCount is owned by
+	// Redeem and never overwritten.
+	Update(p *Promotion) error
+	FindByID(id common.PromotionID) (*Promotion, error)
+	// FindByRestaurantID returns all promotions, newest first.
+	FindByRestaurantID(restaurantID common.RestaurantID) ([]*Promotion, error)
+	// FindByCode returns the restaurant's promotion with code or
+	// ErrPromotionNotFound. Codes compare after NormalizeCode.
+	FindByCode(restaurantID common.RestaurantID, code string) (*Promotion, error)
+	// CountSessionRedemptions returns how often sessionID used the promotion.
+	CountSessionRedemptions(id common.PromotionID, sessionID string) (int, error)
+	// Redeem records r and bumps the promotion's UsesCount in one step,
+	// failing with ErrUsageLimitReached or ErrSessionLimitReached when a
+	// concurrent order used up the last redemption first.
+	Redeem(r Redemption) error
+}
@@ -0,0 +1,276 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitmerchant/internal/auth/domain/membership"
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/interfaces/templates/admin"
+	"bitmerchant/internal/menu/domain/menu"
+	promoCmd "bitmerchant/internal/promotion/app/command"
+	promoQuery "bitmerchant/internal/promotion/app/query"
+	"bitmerchant/internal/promotion/domain/promotion"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+)
+
+const promotionsPath = "/admin/promotions"
+
+// PromotionsHandler is the owner's promotion management under
+// /admin/promotions: percentage and fixed discounts, buy-X-get-Y deals and
+// promo codes.
+type PromotionsHandler struct {
+	listUC         promoQuery.RestaurantPromotionsHandler
+	createUC       promoCmd.CreatePromotionHandler
+	updateUC       promoCmd.UpdatePromotionHandler
+	setActiveUC    promoCmd.SetPromotionActiveHandler
+	categoryRepo   menu.CategoryRepository
+	restaurantRepo restaurant.Repository
+	membershipRepo membership.Repository
+}
+
+func NewPromotionsHandler(
+	listUC promoQuery.RestaurantPromotionsHandler,
+	createUC promoCmd.CreatePromotionHandler,
+	updateUC promoCmd.UpdatePromotionHandler,
+	setActiveUC promoCmd.SetPromotionActiveHandler,
+	categoryRepo menu.CategoryRepository,
+	restaurantRepo restaurant.Repository,
+	membershipRepo membership.Repository,
+) *PromotionsHandler {
+	return &PromotionsHandler{
+		listUC:         listUC,
+		createUC:       createUC,
+		updateUC:       updateUC,
+		setActiveUC:    setActiveUC,
+		categoryRepo:   categoryRepo,
+		restaurantRepo: restaurantRepo,
+		membershipRepo: membershipRepo,
+	}
+}
+
+// GetPromotions handles GET /admin/promotions. ?edit=<id> loads a promotion
+// into the form; ?error= carries a failed submission.
+func (h *PromotionsHandler) GetPromotions(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	ctx := c.Request().Context()
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	promotions, err := h.listUC.Handle(ctx, promoQuery.RestaurantPromotions{RestaurantID: restaurantID})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load promotions: "+err.Error())
+	}
+	categories, err := h.categoryRepo.FindByRestaurantID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load categories")
+	}
+	var editing *promotion.Promotion
+	if id := common.PromotionID(c.QueryParam("edit")); id != "" {
+		for _, p := range promotions {
+			if p.ID == id {
+				editing = p
+			}
+		}
+	}
+
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(ctx, restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	return admin.PromotionsPage(admin.PromotionsView{
+		Promotions:  promotions,
+		Editing:     editing,
+		Categories:  categories,
+		Currency:    rest.BaseCurrency,
+		Error:       c.QueryParam("error"),
+		CSRFToken:   commonhttp.CSRFToken(c),
+		ActiveLabel: label,
+		DisplayName: dn,
+		Subtitle:    st,
+		Initials:    ini,
+		Switcher:    switchOpts,
+		ActiveRole:  activeRole,
+		CanCreate:   canCreate,
+	}).Render(ctx, c.Response())
+}
+
+// PostCreate handles POST /admin/promotions.
+func (h *PromotionsHandler) PostCreate(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	terms, err := parseTerms(c, rest.BaseCurrency)
+	if err != nil {
+		return redirectPromotionsError(c, "", err)
+	}
+	if _, err := h.createUC.Handle(c.Request().Context(), promoCmd.CreatePromotion{
+		RestaurantID: restaurantID,
+		Terms:        terms,
+	}); err != nil {
+		return redirectPromotionsError(c, "", err)
+	}
+	return c.Redirect(http.StatusSeeOther, promotionsPath)
+}
+
+// PostUpdate handles POST /admin/promotions/:id/update.
+func (h *PromotionsHandler) PostUpdate(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	id := common.PromotionID(c.Param("id"))
+	terms, err := parseTerms(c, rest.BaseCurrency)
+	if err != nil {
+		return redirectPromotionsError(c, id, err)
+	}
+	if err := h.updateUC.Handle(c.Request().Context(), promoCmd.UpdatePromotion{
+		RestaurantID: restaurantID,
+		PromotionID:  id,
+		Terms:        terms,
+	}); err != nil {
+		return redirectPromotionsError(c, id, err)
+	}
+	return c.Redirect(http.StatusSeeOther, promotionsPath)
+}
+
+// PostToggle handles POST /admin/promotions/:id/toggle (active=true|false).
+func (h *PromotionsHandler) PostToggle(c echo.Context) error {
+	restaurantID, err := commonhttp.RestaurantIDFromContext(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	if err := h.setActiveUC.Handle(c.Request().Context(), promoCmd.SetPromotionActive{
+		RestaurantID: restaurantID,
+		PromotionID:  common.PromotionID(c.Param("id")),
+		Active:       c.FormValue("active") == "true",
+	}); err != nil {
+		return redirectPromotionsError(c, "", err)
+	}
+	return c.Redirect(http.StatusSeeOther, promotionsPath)
+}
+
+var (
+	errInvalidPromotionAmount = errors.New("enter amounts like 12.50")
+	errInvalidPromotionNumber = errors.New("quantities and limits must be whole numbers")
+	errInvalidPromotionDate   = errors.New("enter dates as YYYY-MM-DD")
+)
+
+// dateLayout is what <input type="date"> submits. Dates are whole days in
+// the server's time zone; the end date is inclusive.
+const dateLayout = "2006-01-02"
+
+// parseTerms reads the promotion form. Amounts are major units of currency;
+// only the fields of the chosen kind are read.
+func parseTerms(c echo.Context, currency money.Currency) (promotion.Terms, error) {
+	t := promotion.Terms{
+		Name:       c.FormValue("name"),
+		Code:       c.FormValue("code"),
+		Kind:       promotion.Kind(c.FormValue("kind")),
+		CategoryID: common.CategoryID(strings.TrimSpace(c.FormValue("categoryID"))),
+	}
+	var err error
+	switch t.Kind {
+	case promotion.KindPercentage:
+		t.PercentOff, err = formInt(c, "percentOff")
+	case promotion.KindFixed:
+		t.AmountOff, err = formAmount(c, "amountOff", currency)
+	case promotion.KindBuyXGetY:
+		if t.BuyQuantity, err = formInt(c, "buyQuantity"); err == nil {
+			t.GetQuantity, err = formInt(c, "getQuantity")
+		}
+	}
+	if err != nil {
+		return promotion.Terms{}, err
+	}
+	if t.MinSubtotal, err = formAmount(c, "minSubtotal", currency); err != nil {
+		return promotion.Terms{}, err
+	}
+	if t.MaxUses, err = formInt(c, "maxUses"); err != nil {
+		return promotion.Terms{}, err
+	}
+	if t.MaxUsesPerSession, err = formInt(c, "maxUsesPerSession"); err != nil {
+		return promotion.Terms{}, err
+	}
+	if t.StartsAt, err = formDate(c, "startsAt", 0); err != nil {
+		return promotion.Terms{}, err
+	}
+	if t.EndsAt, err = formDate(c, "endsAt", 1); err != nil {
+		return promotion.Terms{}, err
+	}
+	return t, nil
+}
+
+// formInt reads a whole number; blank is zero.
+func formInt(c echo.Context, name string) (int, error) {
+	raw := strings.TrimSpace(c.FormValue(name))
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errInvalidPromotionNumber
+	}
+	return n, nil
+}
+
+// formAmount reads a major-unit amount in currency; blank is zero.
+func formAmount(c echo.Context, name string, currency money.Currency) (int64, error) {
+	raw := strings.TrimSpace(c.FormValue(name))
+	if raw == "" {
+		return 0, nil
+	}
+	m, err := money.ParseMajor(raw, currency)
+	if err != nil {
+		return 0, errInvalidPromotionAmount
+	}
+	return m.Amount, nil
+}
+
+// formDate reads a date as local midnight, moved by addDays so an inclusive
+// end date becomes the exclusive instant the promotion stops.
+func formDate(c echo.Context, name string, addDays int) (*time.Time, error) {
+	raw := strings.TrimSpace(c.FormValue(name))
+	if raw == "" {
+		return nil, nil
+	}
+	d, err := time.ParseInLocation(dateLayout, raw, time.Local)
+	if err != nil {
+		return nil, errInvalidPromotionDate
+	}
+	d = d.AddDate(0, 0, addDays)
+	return &d, nil
+}
+
+// redirectPromotionsError sends the owner back to the form with a message
+// the page shows as a toast, keeping the promotion being edited open.
+func redirectPromotionsError(c echo.Context, editing common.PromotionID, err error) error {
+	q := url.Values{"error": {err.Error()}}
+	if editing != "" {
+		q.Set("edit", string(editing))
+	}
+	return c.Redirect(http.StatusSeeOther, promotionsPath+"?"+q.Encode())
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"log/slog"
+
+	"bitmerchant/internal/common/decorator"
+	promoCmd "bitmerchant/internal/promotion/app/command"
+	promoQuery "bitmerchant/internal/promotion/app/query"
+	promotionhttp "bitmerchant/internal/promotion/ports/http"
+	"bitmerchant/internal/wiring"
+)
+
+// Promotions bundles promotion management, checkout pricing and redemption.
+// Ordering reaches pricing and redemption through adapters built at the
+// composition root.
+type Promotions struct {
+	RestaurantPromotions promoQuery.RestaurantPromotionsHandler
+	OrderDiscount        promoQuery.OrderDiscountHandler
+	CreatePromotion      promoCmd.CreatePromotionHandler
+	UpdatePromotion      promoCmd.UpdatePromotionHandler
+	SetPromotionActive   promoCmd.SetPromotionActiveHandler
+	RedeemPromotion      promoCmd.RedeemPromotionHandler
+
+	HTTP *promotionhttp.PromotionsHandler
+}
+
+func New(repos wiring.Repositories, logger *slog.Logger, metrics decorator.MetricsClient) Promotions {
+	svc := Promotions{
+		RestaurantPromotions: promoQuery.NewRestaurantPromotionsHandler(repos.Promotion, logger, metrics),
+		OrderDiscount:        promoQuery.NewOrderDiscountHandler(repos.Promotion, logger, metrics),
+		CreatePromotion:      promoCmd.NewCreatePromotionHandler(repos.Promotion, repos.Restaurant, logger, metrics),
+		UpdatePromotion:      promoCmd.NewUpdatePromotionHandler(repos.Promotion, logger, metrics),
+		SetPromotionActive:   promoCmd.NewSetPromotionActiveHandler(repos.Promotion, logger, metrics),
+		RedeemPromotion:      promoCmd.NewRedeemPromotionHandler(repos.Promotion, logger, metrics),
+	}
+	svc.HTTP = promotionhttp.NewPromotionsHandler(
+		svc.RestaurantPromotions,
+		svc.CreatePromotion,
+		svc.UpdatePromotion,
+		svc.SetPromotionActive,
+		repos.MenuCategory,
+		repos.Restaurant,
+		repos.Membership,
+	)
+	return svc
+}
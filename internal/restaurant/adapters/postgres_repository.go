@@ -2,11 +2,14 @@ package adapters
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 )
 
@@ -27,9 +30,21 @@ func (r *PostgresRestaurantRepository) Save(rest *restaurant.Restaurant) error {
 	if rest.PausedUntil != nil {
 		pausedUntil = *rest.PausedUntil
 	}
-	_, err := r.db.Exec(
-		`INSERT INTO restaurants (id, name, base_currency, tax_rate, table_count, is_open, closed_message, reopening_hours, kitchen_warning_minutes, kitchen_overdue_minutes, paused_until, created_at, updated_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	taxConfig, err := marshalTaxConfig(rest.TaxSettings)
+	if err != nil {
+		return err
+	}
+	tipConfig, err := marshalTipConfig(rest.TipSettings)
+	if err != nil {
+		return err
+	}
+	tipPooling, err := marshalTipPooling(rest.TipPooling)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(
+		`INSERT INTO restaurants (id, name, base_currency, tax_rate, table_count, is_open, closed_message, reopening_hours, kitchen_warning_minutes, kitchen_overdue_minutes, lightning_address, paused_until, created_at, updated_at, tax_config, cash_rounding, tip_config, tip_pooling)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		 ON CONFLICT (id) DO UPDATE
 		 SET name = EXCLUDED.name,
 		     base_currency = EXCLUDED.base_currency,
@@ -40,8 +55,13 @@ func (r *PostgresRestaurantRepository) Save(rest *restaurant.Restaurant) error {
 		     reopening_hours = EXCLUDED.reopening_hours,
 		     kitchen_warning_minutes = EXCLUDED.kitchen_warning_minutes,
 		     kitchen_overdue_minutes = EXCLUDED.kitchen_overdue_minutes,
+		     lightning_address = EXCLUDED.lightning_address,
 		     paused_until = EXCLUDED.paused_until,
-		     updated_at = EXCLUDED.updated_at`,
+		     updated_at = EXCLUDED.updated_at,
+		     tax_config = EXCLUDED.tax_config,
+		     cash_rounding = EXCLUDED.cash_rounding,
+		     tip_config = EXCLUDED.tip_config,
+		     tip_pooling = EXCLUDED.tip_pooling`,
 		string(rest.ID),
 		rest.Name,
 		currency.Code,
@@ -52,16 +72,21 @@ func (r *PostgresRestaurantRepository) Save(rest *restaurant.Restaurant) error {
 		rest.ReopeningHours,
 		rest.EffectiveKitchenWarningMinutes(),
 		rest.EffectiveKitchenOverdueMinutes(),
+		rest.LightningAddress,
 		pausedUntil,
 		rest.CreatedAt,
 		rest.UpdatedAt,
+		taxConfig,
+		rest.CashRounding,
+		tipConfig,
+		tipPooling,
 	)
 	return err
 }
 
 func (r *PostgresRestaurantRepository) FindByID(id common.RestaurantID) (*restaurant.Restaurant, error) {
 	row := r.db.QueryRow(
-		`SELECT id, name, COALESCE(base_currency, 'USD'), COALESCE(tax_rate, 0.08), table_count, is_open, closed_message, reopening_hours, COALESCE(kitchen_warning_minutes, 8), COALESCE(kitchen_overdue_minutes, 12), paused_until, created_at, updated_at
+		`SELECT id, name, COALESCE(base_currency, 'USD'), COALESCE(tax_rate, 0.08), table_count, is_open, closed_message, reopening_hours, COALESCE(kitchen_warning_minutes, 8), COALESCE(kitchen_overdue_minutes, 12), COALESCE(lightning_address, ''), paused_until, created_at, updated_at, tax_config, COALESCE(cash_rounding, 0), tip_config, tip_pooling
 		 FROM restaurants WHERE id = $1`,
 		string(id),
 	)
@@ -77,12 +102,17 @@ func (r *PostgresRestaurantRepository) FindByID(id common.RestaurantID) (*restau
 		reopeningHours sql.NullString
 		warningMinutes int
 		overdueMinutes int
+		lightningAddr  string
 		pausedUntil    sql.NullTime
 		createdAt      time.Time
 		updatedAt      time.Time
+		taxConfig      []byte
+		cashRounding   int64
+		tipConfig      []byte
+		tipPooling     []byte
 	)
 
-	if err := row.Scan(&rid, &name, &baseCurrency, &taxRate, &tableCount, &isOpen, &closedMessage, &reopeningHours, &warningMinutes, &overdueMinutes, &pausedUntil, &createdAt, &updatedAt); err != nil {
+	if err := row.Scan(&rid, &name, &baseCurrency, &taxRate, &tableCount, &isOpen, &closedMessage, &reopeningHours, &warningMinutes, &overdueMinutes, &lightningAddr, &pausedUntil, &createdAt, &updatedAt, &taxConfig, &cashRounding, &tipConfig, &tipPooling); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("restaurant not found")
 		}
@@ -99,12 +129,17 @@ func (r *PostgresRestaurantRepository) FindByID(id common.RestaurantID) (*restau
 		Name:                  name,
 		BaseCurrency:          currency,
 		TaxRate:               taxRate,
+		TaxSettings:           unmarshalTaxConfig(taxConfig),
+		CashRounding:          cashRounding,
+		TipSettings:           unmarshalTipConfig(tipConfig),
+		TipPooling:            unmarshalTipPooling(tipPooling),
 		TableCount:            tableCount,
 		IsOpen:                isOpen,
 		ClosedMessage:         closedMessage.String,
 		ReopeningHours:        reopeningHours.String,
 		KitchenWarningMinutes: warningMinutes,
 		KitchenOverdueMinutes: overdueMinutes,
+		LightningAddress:      lightningAddr,
 		CreatedAt:             createdAt,
 		UpdatedAt:             updatedAt,
 	}
@@ -120,8 +155,20 @@ func (r *PostgresRestaurantRepository) Update(rest *restaurant.Restaurant) error
 	if rest.PausedUntil != nil {
 		pausedUntil = *rest.PausedUntil
 	}
+	taxConfig, err := marshalTaxConfig(rest.TaxSettings)
+	if err != nil {
+		return err
+	}
+	tipConfig, err := marshalTipConfig(rest.TipSettings)
+	if err != nil {
+		return err
+	}
+	tipPooling, err := marshalTipPooling(rest.TipPooling)
+	if err != nil {
+		return err
+	}
 	result, err := r.db.Exec(
-		`UPDATE restaurants SET name=$2, tax_rate=$3, table_count=$4, is_open=$5, closed_message=$6, reopening_hours=$7, kitchen_warning_minutes=$8, kitchen_overdue_minutes=$9, paused_until=$10, updated_at=$11 WHERE id=$1`,
+		`UPDATE restaurants SET name=$2, tax_rate=$3, table_count=$4, is_open=$5, closed_message=$6, reopening_hours=$7, kitchen_warning_minutes=$8, kitchen_overdue_minutes=$9, paused_until=$10, updated_at=$11, lightning_address=$12, tax_config=$13, cash_rounding=$14, tip_config=$15, tip_pooling=$16 WHERE id=$1`,
 		string(rest.ID),
 		rest.Name,
 		rest.TaxRate,
@@ -133,6 +180,11 @@ func (r *PostgresRestaurantRepository) Update(rest *restaurant.Restaurant) error
 		rest.EffectiveKitchenOverdueMinutes(),
 		pausedUntil,
 		rest.UpdatedAt,
+		rest.LightningAddress,
+		taxConfig,
+		rest.CashRounding,
+		tipConfig,
+		tipPooling,
 	)
 	if err != nil {
 		return err
@@ -146,3 +198,128 @@ func (r *PostgresRestaurantRepository) Update(rest *restaurant.Restaurant) error
 	}
 	return nil
 }
+
+// jsonTaxConfig mirrors tax.Config for JSON.
+type jsonTaxConfig struct {
+	Mode                 string    `json:"mode"`
+	Taxes                []jsonTax `json:"taxes,omitempty"`
+	ServiceChargeRate    int64     `json:"service_charge_rate,omitempty"`
+	ServiceChargeTaxable bool      `json:"service_charge_taxable,omitempty"`
+}
+
+type jsonTax struct {
+	Name    string   `json:"name"`
+	Rate    int64    `json:"rate"`
+	Classes []string `json:"classes,omitempty"`
+}
+
+// marshalTaxConfig stores an unset config as NULL so the restaurant keeps
+// falling back to its legacy tax_rate.
+func marshalTaxConfig(cfg tax.Config) ([]byte, error) {
+	if cfg.IsZero() {
+		return nil, nil
+	}
+	jc := jsonTaxConfig{Mode: string(cfg.Mode), ServiceChargeRate: cfg.ServiceChargeRate, ServiceChargeTaxable: cfg.ServiceChargeTaxable}
+	for _, t := range cfg.Taxes {
+		jt := jsonTax{Name: t.Name, Rate: t.Rate}
+		for _, c := range t.Classes {
+			jt.Classes = append(jt.Classes, string(c))
+		}
+		jc.Taxes = append(jc.Taxes, jt)
+	}
+	return json.Marshal(jc)
+}
+
+func unmarshalTaxConfig(data []byte) tax.Config {
+	if len(data) == 0 {
+		return tax.Config{}
+	}
+	var jc jsonTaxConfig
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return tax.Config{}
+	}
+	cfg := tax.Config{Mode: tax.Mode(jc.Mode), ServiceChargeRate: jc.ServiceChargeRate, ServiceChargeTaxable: jc.ServiceChargeTaxable}
+	for _, jt := range jc.Taxes {
+		t := tax.Tax{Name: jt.Name, Rate: jt.Rate}
+		for _, c := range jt.Classes {
+			t.Classes = append(t.Classes, tax.Class(c))
+		}
+		cfg.Taxes = append(cfg.Taxes, t)
+	}
+	return cfg
+}
+
+// jsonTipConfig mirrors tip.Settings for JSON.
+type jsonTipConfig struct {
+	Off     bool  `json:"off,omitempty"`
+	Presets []int `json:"presets,omitempty"`
+	Default int   `json:"default,omitempty"`
+	Custom  bool  `json:"custom,omitempty"`
+}
+
+// marshalTipConfig stores unset settings as NULL so the restaurant keeps
+// the standard presets.
+func marshalTipConfig(s tip.Settings) ([]byte, error) {
+	if s.IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(jsonTipConfig{Off: s.Off, Presets: s.Presets, Default: s.Default, Custom: s.Custom})
+}
+
+func unmarshalTipConfig(data []byte) tip.Settings {
+	if len(data) == 0 {
+		return tip.Settings{}
+	}
+	var jc jsonTipConfig
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return tip.Settings{}
+	}
+	return tip.Settings{Off: jc.Off, Presets: jc.Presets, Default: jc.Default, Custom: jc.Custom}
+}
+
+// jsonTipPooling mirrors tip.Pooling for JSON.
+type jsonTipPooling struct {
+	Pools []jsonTipPool `json:"pools"`
+	Split string        `json:"split"`
+}
+
+type jsonTipPool struct {
+	Name  string   `json:"name"`
+	Share int      `json:"share"`
+	Roles []string `json:"roles"`
+}
+
+// marshalTipPooling stores a restaurant without pools as NULL.
+func marshalTipPooling(p tip.Pooling) ([]byte, error) {
+	if !p.Enabled() {
+		return nil, nil
+	}
+	jp := jsonTipPooling{Split: string(p.Split)}
+	for _, pool := range p.Pools {
+		roles := make([]string, len(pool.Roles))
+		for i, r := range pool.Roles {
+			roles[i] = string(r)
+		}
+		jp.Pools = append(jp.Pools, jsonTipPool{Name: pool.Name, Share: pool.Share, Roles: roles})
+	}
+	return json.Marshal(jp)
+}
+
+func unmarshalTipPooling(data []byte) tip.Pooling {
+	if len(data) == 0 {
+		return tip.Pooling{}
+	}
+	var jp jsonTipPooling
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return tip.Pooling{}
+	}
+	p := tip.Pooling{Split: tip.Split(jp.Split)}
+	for _, pool := range jp.Pools {
+		roles := make([]common.MemberRole, len(pool.Roles))
+		for i, r := range pool.Roles {
+			roles[i] = common.MemberRole(r)
+		}
+		p.Pools = append(p.Pools, tip.Pool{Name: pool.Name, Share: pool.Share, Roles: roles})
+	}
+	return p
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+)
+
+// UpdateCashRounding sets the step cash totals are rounded to. Step is in
+// major units of the restaurant's currency ("0.05"); blank turns rounding
+// off.
+type UpdateCashRounding struct {
+	RestaurantID common.RestaurantID
+	Step         string
+}
+
+type UpdateCashRoundingHandler decorator.CommandHandler[UpdateCashRounding]
+
+type updateCashRoundingHandler struct {
+	repo restaurant.Repository
+}
+
+func NewUpdateCashRoundingHandler(repo restaurant.Repository, log *slog.Logger, metrics decorator.MetricsClient) UpdateCashRoundingHandler {
+	if repo == nil {
+		panic("nil restaurant.Repository")
+	}
+	h := updateCashRoundingHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[UpdateCashRounding](h, log, metrics)
+}
+
+func (h updateCashRoundingHandler) Handle(ctx context.Context, cmd UpdateCashRounding) error {
+	_ = ctx
+	rest, err := h.repo.FindByID(cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+	if err := rest.SetCashRounding(cmd.Step); err != nil {
+		return err
+	}
+	return h.repo.Update(rest)
+}
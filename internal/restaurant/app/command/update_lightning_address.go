@@ -0,0 +1,43 @@
+package command
+
+import (
+	"context"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+	"log/slog"
+)
+
+// UpdateLightningAddress links (or, when empty, unlinks) the merchant's own
+// Lightning address / LNURL-pay endpoint used for non-custodial checkout.
+type UpdateLightningAddress struct {
+	RestaurantID     common.RestaurantID
+	LightningAddress string
+}
+
+type UpdateLightningAddressHandler decorator.CommandHandler[UpdateLightningAddress]
+
+type updateLightningAddressHandler struct {
+	repo restaurant.Repository
+}
+
+func NewUpdateLightningAddressHandler(repo restaurant.Repository, log *slog.Logger, metrics decorator.MetricsClient) UpdateLightningAddressHandler {
+	if repo == nil {
+		panic("nil restaurant.Repository")
+	}
+	h := updateLightningAddressHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[UpdateLightningAddress](h, log, metrics)
+}
+
+func (h updateLightningAddressHandler) Handle(ctx context.Context, cmd UpdateLightningAddress) error {
+	_ = ctx
+	rest, err := h.repo.FindByID(cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+	if err := rest.SetLightningAddress(cmd.LightningAddress); err != nil {
+		return err
+	}
+	return h.repo.Update(rest)
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+	"log/slog"
+)
+
+// UpdateTaxSettings replaces the restaurant's named taxes, pricing mode and
+// service charge. Orders already placed keep the taxes they were priced with.
+type UpdateTaxSettings struct {
+	RestaurantID common.RestaurantID
+	Settings     tax.Config
+}
+
+type UpdateTaxSettingsHandler decorator.CommandHandler[UpdateTaxSettings]
+
+type updateTaxSettingsHandler struct {
+	repo restaurant.Repository
+}
+
+func NewUpdateTaxSettingsHandler(repo restaurant.Repository, log *slog.Logger, metrics decorator.MetricsClient) UpdateTaxSettingsHandler {
+	if repo == nil {
+		panic("nil restaurant.Repository")
+	}
+	h := updateTaxSettingsHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[UpdateTaxSettings](h, log, metrics)
+}
+
+func (h updateTaxSettingsHandler) Handle(ctx context.Context, cmd UpdateTaxSettings) error {
+	_ = ctx
+	rest, err := h.repo.FindByID(cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+	if err := rest.SetTaxSettings(cmd.Settings); err != nil {
+		return err
+	}
+	return h.repo.Update(rest)
+}
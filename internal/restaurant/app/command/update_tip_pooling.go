@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/tip"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+)
+
+// UpdateTipPooling replaces the restaurant's tip pools. Tip-out reports
+// share out every period with the pools in force when they are run.
+type UpdateTipPooling struct {
+	RestaurantID common.RestaurantID
+	Pooling      tip.Pooling
+}
+
+type UpdateTipPoolingHandler decorator.CommandHandler[UpdateTipPooling]
+
+type updateTipPoolingHandler struct {
+	repo restaurant.Repository
+}
+
+func NewUpdateTipPoolingHandler(repo restaurant.Repository, log *slog.Logger, metrics decorator.MetricsClient) UpdateTipPoolingHandler {
+	if repo == nil {
+		panic("nil restaurant.Repository")
+	}
+	h := updateTipPoolingHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[UpdateTipPooling](h, log, metrics)
+}
+
+func (h updateTipPoolingHandler) Handle(ctx context.Context, cmd UpdateTipPooling) error {
+	_ = ctx
+	rest, err := h.repo.FindByID(cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+	if err := rest.SetTipPooling(cmd.Pooling); err != nil {
+		return err
+	}
+	return h.repo.Update(rest)
+}
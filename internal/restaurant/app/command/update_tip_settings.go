@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/decorator"
+	"bitmerchant/internal/common/tip"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+)
+
+// UpdateTipSettings replaces the tip presets offered at checkout and after
+// the meal. Orders already placed keep the tip they were placed with.
+type UpdateTipSettings struct {
+	RestaurantID common.RestaurantID
+	Settings     tip.Settings
+}
+
+type UpdateTipSettingsHandler decorator.CommandHandler[UpdateTipSettings]
+
+type updateTipSettingsHandler struct {
+	repo restaurant.Repository
+}
+
+func NewUpdateTipSettingsHandler(repo restaurant.Repository, log *slog.Logger, metrics decorator.MetricsClient) UpdateTipSettingsHandler {
+	if repo == nil {
+		panic("nil restaurant.Repository")
+	}
+	h := updateTipSettingsHandler{repo: repo}
+	return decorator.ApplyCommandDecorators[UpdateTipSettings](h, log, metrics)
+}
+
+func (h updateTipSettingsHandler) Handle(ctx context.Context, cmd UpdateTipSettings) error {
+	_ = ctx
+	rest, err := h.repo.FindByID(cmd.RestaurantID)
+	if err != nil {
+		return err
+	}
+	if err := rest.SetTipSettings(cmd.Settings); err != nil {
+		return err
+	}
+	return h.repo.Update(rest)
+}
@@ -2,10 +2,15 @@ package restaurant
 
 import (
 	"errors"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 )
 
 const (
@@ -22,20 +27,42 @@ const (
 	DefaultKitchenOverdueMinutes = 12
 	MinKitchenThresholdMinutes   = 1
 	MaxKitchenThresholdMinutes   = 120
+
+	// MaxCashRoundingMajor caps the cash rounding step at this many major
+	// units of the base currency (e.g. 100 CLP).
+	MaxCashRoundingMajor = 100
 )
 
 var (
 	ErrInvalidTableCount        = errors.New("invalid table count")
 	ErrInvalidTaxRate           = errors.New("invalid tax rate")
 	ErrInvalidKitchenThresholds = errors.New("kitchen thresholds must satisfy 1 <= warning < overdue <= 120 minutes")
+	ErrInvalidLightningAddress  = errors.New("lightning address must look like name@example.com or be an LNURL-pay link")
+	ErrInvalidCashRounding      = errors.New("cash rounding must be an amount from 0 to 100 in the restaurant's currency")
 )
 
+var lightningAddressPattern = regexp.MustCompile(`^[a-z0-9\-_.+]+@[a-z0-9\-.]+(:[0-9]+)?$`)
+
 // Restaurant represents a single restaurant tenant.
 type Restaurant struct {
-	ID             common.RestaurantID
-	Name           string
-	BaseCurrency   money.Currency
-	TaxRate        float64 // 0.08 = 8%
+	ID           common.RestaurantID
+	Name         string
+	BaseCurrency money.Currency
+	TaxRate      float64 // 0.08 = 8%
+	// TaxSettings holds named taxes, inclusive pricing and the service
+	// charge. Zero for restaurants that never configured them; see
+	// EffectiveTaxSettings.
+	TaxSettings tax.Config
+	// CashRounding is the step, in minor units of BaseCurrency, that totals
+	// paid in cash are rounded to (25 for the nearest 0.25 THB). Zero leaves
+	// cash totals exact.
+	CashRounding int64
+	// TipSettings holds the tip presets offered to guests. Zero for
+	// restaurants that never configured them; see EffectiveTipSettings.
+	TipSettings tip.Settings
+	// TipPooling shares the period's tips among staff by pool. Zero leaves
+	// tips with whoever collected them.
+	TipPooling     tip.Pooling
 	TableCount     int
 	IsOpen         bool
 	ClosedMessage  string
@@ -44,6 +71,10 @@ type Restaurant struct {
 	// escalation tiers (nominal / warning / overdue). Configurable per restaurant.
 	KitchenWarningMinutes int
 	KitchenOverdueMinutes int
+	// LightningAddress is the merchant's own Lightning address (LUD-16) or
+	// LNURL-pay link. Customers pay it directly; we never hold the funds.
+	// Empty means Lightning checkout falls back to the platform node, if any.
+	LightningAddress string
 	// PausedUntil is non-nil when the owner has applied a quick-pause
 	// (rush). The restaurant auto-resumes once now passes this timestamp;
 	// readers should call AcceptingOrdersAt to apply that lazily.
@@ -105,6 +136,37 @@ func (r *Restaurant) SetKitchenThresholds(warningMinutes, overdueMinutes int) er
 	return nil
 }
 
+// NormalizeLightningAddress trims and lower-cases a Lightning address, bech32
+// LNURL or LNURL-pay URL, rejecting anything else. Empty is allowed and
+// clears the setting.
+func NormalizeLightningAddress(raw string) (string, error) {
+	v := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(raw), "lightning:"))
+	if v == "" {
+		return "", nil
+	}
+	lower := strings.ToLower(v)
+	switch {
+	case lightningAddressPattern.MatchString(lower), strings.HasPrefix(lower, "lnurl1"):
+		return lower, nil
+	case strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://"):
+		if u, err := url.Parse(v); err == nil && u.Host != "" {
+			return v, nil
+		}
+	}
+	return "", ErrInvalidLightningAddress
+}
+
+// SetLightningAddress validates and applies the merchant's payout address.
+func (r *Restaurant) SetLightningAddress(raw string) error {
+	addr, err := NormalizeLightningAddress(raw)
+	if err != nil {
+		return err
+	}
+	r.LightningAddress = addr
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
 // EffectiveKitchenWarningMinutes returns the configured warning threshold,
 // falling back to the default for legacy rows that stored zero.
 func (r *Restaurant) EffectiveKitchenWarningMinutes() int {
@@ -123,6 +185,89 @@ func (r *Restaurant) EffectiveKitchenOverdueMinutes() int {
 	return r.KitchenOverdueMinutes
 }
 
+// EffectiveTaxSettings returns the configured taxes, falling back to the
+// legacy single exclusive TaxRate for restaurants that never set any.
+func (r *Restaurant) EffectiveTaxSettings() tax.Config {
+	if r.TaxSettings.IsZero() {
+		return tax.Exclusive(r.TaxRate)
+	}
+	return r.TaxSettings
+}
+
+// SetTaxSettings validates and applies the restaurant's tax setup.
+func (r *Restaurant) SetTaxSettings(cfg tax.Config) error {
+	cfg, err := cfg.Normalize()
+	if err != nil {
+		return err
+	}
+	r.TaxSettings = cfg
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// EffectiveTipSettings returns the configured tip presets, falling back to
+// the standard 10/15/20% for restaurants that never set any.
+func (r *Restaurant) EffectiveTipSettings() tip.Settings {
+	if r.TipSettings.IsZero() {
+		return tip.Standard()
+	}
+	return r.TipSettings
+}
+
+// SetTipSettings validates and applies the restaurant's tip presets.
+func (r *Restaurant) SetTipSettings(s tip.Settings) error {
+	s, err := s.Normalize()
+	if err != nil {
+		return err
+	}
+	r.TipSettings = s
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetTipPooling validates and applies the restaurant's tip pools.
+func (r *Restaurant) SetTipPooling(p tip.Pooling) error {
+	p, err := p.Normalize()
+	if err != nil {
+		return err
+	}
+	r.TipPooling = p
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetCashRounding validates and applies the cash rounding step, given in
+// major units of the base currency ("0.25"). Blank turns rounding off.
+func (r *Restaurant) SetCashRounding(raw string) error {
+	var step int64
+	if raw = strings.TrimSpace(raw); raw != "" {
+		m, err := money.ParseMajor(raw, r.BaseCurrency)
+		if err != nil {
+			return ErrInvalidCashRounding
+		}
+		step = m.Amount
+	}
+	if step < 0 || step > MaxCashRoundingMajor*pow10(r.BaseCurrency.Scale) {
+		return ErrInvalidCashRounding
+	}
+	r.CashRounding = step
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// CashRoundingStep returns CashRounding as money in the base currency.
+func (r *Restaurant) CashRoundingStep() money.Money {
+	return money.New(r.CashRounding, r.BaseCurrency)
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
 // ValidateTaxRate rejects negative rates and rates >= 1 (i.e., >= 100%).
 func ValidateTaxRate(rate float64) error {
 	if rate < 0 || rate >= 1 {
@@ -4,6 +4,8 @@ import (
 	"bitmerchant/internal/auth/domain/membership"
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tip"
 
 	"bitmerchant/internal/interfaces/templates/admin"
 	menuCmd "bitmerchant/internal/menu/app/command"
@@ -18,11 +20,13 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 const adminMenuDashboardPath = "/admin/dashboard"
 const adminQRPath = "/admin/qr"
 const adminKitchenPath = "/admin/kitchen"
+const adminPaymentsPath = "/admin/payments"
 
 const (
 	adminFlashMenuActionFailed     = "menu_action_failed"
@@ -32,6 +36,11 @@ const (
 	adminFlashQRSettingsSaved      = "qr_settings_saved"
 	adminFlashKitchenSettingsSaved = "kitchen_settings_saved"
 	adminFlashKitchenInvalid       = "kitchen_settings_invalid"
+	adminFlashPaymentsSaved        = "payment_settings_saved"
+	adminFlashPaymentsInvalid      = "payment_settings_invalid"
+	adminFlashCashRoundingInvalid  = "cash_rounding_invalid"
+	adminFlashTipsInvalid          = "tip_settings_invalid"
+	adminFlashTipPoolingInvalid    = "tip_pooling_invalid"
 )
 
 func adminMenuRedirect(flashCode string) string {
@@ -90,6 +99,30 @@ func adminKitchenFlashState(flashCode string) (kitchenError string, saved bool)
 	}
 }
 
+func adminPaymentsRedirect(flashCode string) string {
+	if flashCode == "" {
+		return adminPaymentsPath
+	}
+	return adminPaymentsPath + "?flash=" + url.QueryEscape(flashCode)
+}
+
+func adminPaymentsFlashState(flashCode string) (paymentsError string, saved bool) {
+	switch flashCode {
+	case adminFlashPaymentsSaved:
+		return "", true
+	case adminFlashPaymentsInvalid:
+		return "Enter a Lightning address like name@wallet.com, or an LNURL-pay link.", false
+	case adminFlashCashRoundingInvalid:
+		return "Enter a rounding step such as 0.25 or 0.05, at most 100; leave it empty to turn rounding off.", false
+	case adminFlashTipsInvalid:
+		return "Enter up to 4 tip presets from 1% to 50%, such as 10, 15, 20, and pick one of them (or no tip) as the default.", false
+	case adminFlashTipPoolingInvalid:
+		return "Give each tip pool a name and at least one role, put each role in one pool only, and make the shares add up to 100%.", false
+	default:
+		return "", false
+	}
+}
+
 func (h *AdminHandler) restaurantID(c echo.Context) (common.RestaurantID, error) {
 	return commonhttp.RestaurantIDFromContext(c)
 }
@@ -124,6 +157,11 @@ type AdminHandler struct {
 	photoSignerCfg      menuQuery.PhotoSignerConfig
 	updateTableCountUC  restaurantCmd.UpdateRestaurantTableCountHandler
 	updateKitchenUC     restaurantCmd.UpdateKitchenThresholdsHandler
+	updateLightningUC   restaurantCmd.UpdateLightningAddressHandler
+	updateTaxUC         restaurantCmd.UpdateTaxSettingsHandler
+	updateCashRoundUC   restaurantCmd.UpdateCashRoundingHandler
+	updateTipsUC        restaurantCmd.UpdateTipSettingsHandler
+	updateTipPoolingUC  restaurantCmd.UpdateTipPoolingHandler
 	generateQRUC        restaurantQuery.RestaurantTableQRImageHandler
 	membershipRepo      membership.Repository
 	restaurantRepo      restaurant.Repository
@@ -146,6 +184,11 @@ func NewAdminHandler(
 	photoSignerCfg menuQuery.PhotoSignerConfig,
 	updateTableCountUC restaurantCmd.UpdateRestaurantTableCountHandler,
 	updateKitchenUC restaurantCmd.UpdateKitchenThresholdsHandler,
+	updateLightningUC restaurantCmd.UpdateLightningAddressHandler,
+	updateTaxUC restaurantCmd.UpdateTaxSettingsHandler,
+	updateCashRoundUC restaurantCmd.UpdateCashRoundingHandler,
+	updateTipsUC restaurantCmd.UpdateTipSettingsHandler,
+	updateTipPoolingUC restaurantCmd.UpdateTipPoolingHandler,
 	generateQRUC restaurantQuery.RestaurantTableQRImageHandler,
 	membershipRepo membership.Repository,
 	restaurantRepo restaurant.Repository,
@@ -166,6 +209,11 @@ func NewAdminHandler(
 		photoSignerCfg:      photoSignerCfg,
 		updateTableCountUC:  updateTableCountUC,
 		updateKitchenUC:     updateKitchenUC,
+		updateLightningUC:   updateLightningUC,
+		updateTaxUC:         updateTaxUC,
+		updateCashRoundUC:   updateCashRoundUC,
+		updateTipsUC:        updateTipsUC,
+		updateTipPoolingUC:  updateTipPoolingUC,
 		generateQRUC:        generateQRUC,
 		membershipRepo:      membershipRepo,
 		restaurantRepo:      restaurantRepo,
@@ -232,6 +280,10 @@ func (h *AdminHandler) UpdateCategory(c echo.Context) error {
 		DisplayOrder: displayOrder,
 		IsActive:     isActive,
 	}
+	if _, ok := c.Request().Form["taxClass"]; ok {
+		taxClass := c.FormValue("taxClass")
+		req.TaxClass = &taxClass
+	}
 	if err := h.updateCategoryUC.Handle(c.Request().Context(), req); err != nil {
 		return c.Redirect(http.StatusFound, adminMenuRedirect(adminFlashMenuActionFailed))
 	}
@@ -247,15 +299,14 @@ func (h *AdminHandler) CreateItem(c echo.Context) error {
 	categoryID := common.CategoryID(c.FormValue("categoryID"))
 	name := c.FormValue("name")
 	description := c.FormValue("description")
-	price, _ := strconv.ParseFloat(c.FormValue("price"), 64)
+	currencyCode := h.restaurantCurrencyCode(restaurantID)
+	price := parseItemPrice(c.FormValue("price"), currencyFromCode(currencyCode))
 
 	available := c.FormValue("available") == "on"
 	isVegetarian := c.FormValue("is_vegetarian") == "on"
 	isGlutenFree := c.FormValue("is_gluten_free") == "on"
 	isSpicy := c.FormValue("is_spicy") == "on"
 
-	currencyCode := h.restaurantCurrencyCode(restaurantID)
-
 	req := menuCmd.CreateMenuItem{
 		RestaurantID: restaurantID,
 		CategoryID:   categoryID,
@@ -290,6 +341,36 @@ func (h *AdminHandler) restaurantCurrencyCode(restaurantID common.RestaurantID)
 	return rest.BaseCurrency.Code
 }
 
+// itemCurrency returns the currency an existing item is priced in, falling
+// back to the restaurant's base currency when the item cannot be loaded.
+func (h *AdminHandler) itemCurrency(restaurantID common.RestaurantID, itemID common.ItemID) money.Currency {
+	if h.itemRepo != nil {
+		item, err := h.itemRepo.FindByID(itemID)
+		if err == nil && item != nil && item.RestaurantID == restaurantID && !item.Currency.IsZero() {
+			return item.Currency
+		}
+	}
+	return currencyFromCode(h.restaurantCurrencyCode(restaurantID))
+}
+
+func currencyFromCode(code string) money.Currency {
+	currency, err := money.Parse(code)
+	if err != nil {
+		return money.USD
+	}
+	return currency
+}
+
+// parseItemPrice reads a price typed in major units. Unparseable input gives
+// zero, which the menu commands reject as an invalid price.
+func parseItemPrice(raw string, currency money.Currency) int64 {
+	m, err := money.ParseMajor(raw, currency)
+	if err != nil {
+		return 0
+	}
+	return m.Amount
+}
+
 // UpdateItem handles POST /admin/item/:id/update
 func (h *AdminHandler) UpdateItem(c echo.Context) error {
 	restaurantID, err := h.restaurantID(c)
@@ -300,7 +381,7 @@ func (h *AdminHandler) UpdateItem(c echo.Context) error {
 	categoryID := common.CategoryID(c.FormValue("categoryID"))
 	name := c.FormValue("name")
 	description := c.FormValue("description")
-	price, _ := strconv.ParseFloat(c.FormValue("price"), 64)
+	price := parseItemPrice(c.FormValue("price"), h.itemCurrency(restaurantID, itemID))
 	available := c.FormValue("available") == "on"
 	isVegetarian := c.FormValue("is_vegetarian") == "on"
 	isGlutenFree := c.FormValue("is_gluten_free") == "on"
@@ -529,6 +610,126 @@ func (h *AdminHandler) PostKitchenSettings(c echo.Context) error {
 	return c.Redirect(http.StatusFound, adminKitchenRedirect(adminFlashKitchenSettingsSaved))
 }
 
+// GetPaymentSettings handles GET /admin/payments
+func (h *AdminHandler) GetPaymentSettings(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(c.Request().Context(), restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	paymentsError, saved := adminPaymentsFlashState(c.QueryParam("flash"))
+	cashRounding := ""
+	if rest.CashRounding > 0 {
+		cashRounding = rest.CashRoundingStep().FormatNoSymbol()
+	}
+	return admin.PaymentSettingsPage(
+		commonhttp.CSRFToken(c), label, dn, st, ini, switchOpts, activeRole, canCreate,
+		rest.LightningAddress, cashRounding, rest.BaseCurrency.Code, rest.EffectiveTipSettings(), rest.TipPooling, paymentsError, saved,
+	).Render(c.Request().Context(), c.Response())
+}
+
+// PostPaymentSettings handles POST /admin/payments/settings
+func (h *AdminHandler) PostPaymentSettings(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	if err := h.updateLightningUC.Handle(c.Request().Context(), restaurantCmd.UpdateLightningAddress{
+		RestaurantID:     restaurantID,
+		LightningAddress: c.FormValue("lightningAddress"),
+	}); err != nil {
+		return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashPaymentsInvalid))
+	}
+	return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashPaymentsSaved))
+}
+
+// PostCashRounding handles POST /admin/payments/cash-rounding
+func (h *AdminHandler) PostCashRounding(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	if err := h.updateCashRoundUC.Handle(c.Request().Context(), restaurantCmd.UpdateCashRounding{
+		RestaurantID: restaurantID,
+		Step:         c.FormValue("cashRounding"),
+	}); err != nil {
+		return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashCashRoundingInvalid))
+	}
+	return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashPaymentsSaved))
+}
+
+// PostTipSettings handles POST /admin/payments/tips
+func (h *AdminHandler) PostTipSettings(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	presets, err := tip.ParsePresets(c.FormValue("tipPresets"))
+	if err == nil {
+		def, _ := strconv.Atoi(c.FormValue("tipDefault"))
+		err = h.updateTipsUC.Handle(c.Request().Context(), restaurantCmd.UpdateTipSettings{
+			RestaurantID: restaurantID,
+			Settings: tip.Settings{
+				Off:     c.FormValue("tipsOff") == "on",
+				Presets: presets,
+				Default: def,
+				Custom:  c.FormValue("tipCustom") == "on",
+			},
+		})
+	}
+	if err != nil {
+		return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashTipsInvalid))
+	}
+	return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashPaymentsSaved))
+}
+
+// PostTipPooling handles POST /admin/payments/tip-pools. Each pool is a
+// row of poolName<i>, poolShare<i> and poolRoles<i> fields; rows left
+// without a name are dropped.
+func (h *AdminHandler) PostTipPooling(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	form, err := c.FormParams()
+	if err != nil {
+		return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashTipPoolingInvalid))
+	}
+	pooling := tip.Pooling{Split: tip.Split(form.Get("tipSplit"))}
+	for i := range tip.MaxPools {
+		n := strconv.Itoa(i)
+		name := strings.TrimSpace(form.Get("poolName" + n))
+		if name == "" {
+			continue
+		}
+		share, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(form.Get("poolShare"+n)), "%"))
+		if err != nil {
+			return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashTipPoolingInvalid))
+		}
+		pool := tip.Pool{Name: name, Share: share}
+		for _, r := range form["poolRoles"+n] {
+			pool.Roles = append(pool.Roles, common.MemberRole(r))
+		}
+		pooling.Pools = append(pooling.Pools, pool)
+	}
+	if err := h.updateTipPoolingUC.Handle(c.Request().Context(), restaurantCmd.UpdateTipPooling{
+		RestaurantID: restaurantID,
+		Pooling:      pooling,
+	}); err != nil {
+		return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashTipPoolingInvalid))
+	}
+	return c.Redirect(http.StatusFound, adminPaymentsRedirect(adminFlashPaymentsSaved))
+}
+
 // GetQRTablePNG handles GET /admin/qr/table/:table
 func (h *AdminHandler) GetQRTablePNG(c echo.Context) error {
 	restaurantID, err := h.restaurantID(c)
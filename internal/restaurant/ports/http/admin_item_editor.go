@@ -4,11 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/interfaces/templates/admin"
 	menuCmd "bitmerchant/internal/menu/app/command"
 	"bitmerchant/internal/menu/app/dto"
@@ -99,7 +99,7 @@ func (h *AdminHandler) GetItemEditor(c echo.Context) error {
 		CSRFToken:       commonhttp.CSRFToken(c),
 		FlashMessage:    msg,
 		FlashIsSuccess:  success,
-		OptionGroupsDTO: dto.FromDomain(item.OptionGroups),
+		OptionGroupsDTO: dto.FromDomain(item.OptionGroups, item.Currency),
 		AllergenKeys:    menu.AllergenKeys,
 	}
 	return admin.ItemEditorPage(page, activeLabel, dn, st, ini, switchOpts, activeRole, canCreate).Render(c.Request().Context(), c.Response())
@@ -118,11 +118,12 @@ func (h *AdminHandler) PostItemEditor(c echo.Context) error {
 	}
 	form := c.Request().PostForm
 
-	price, _ := strconv.ParseFloat(form.Get("price"), 64)
+	currency := h.itemCurrency(restaurantID, itemID)
+	price := parseItemPrice(form.Get("price"), currency)
 
 	allergens := splitAllergens(form["allergens"])
 	badges := splitBadgesCSV(form.Get("badges_csv"))
-	groups, err := parseOptionGroups(form.Get("option_groups_json"))
+	groups, err := parseOptionGroups(form.Get("option_groups_json"), currency)
 	if err != nil {
 		return c.Redirect(http.StatusFound, editorItemPath(itemID, adminFlashItemSaveFailed))
 	}
@@ -137,6 +138,7 @@ func (h *AdminHandler) PostItemEditor(c echo.Context) error {
 		schedule = menu.ScheduleAllDay
 	}
 	sku := strings.TrimSpace(form.Get("sku"))
+	taxClass := form.Get("tax_class")
 	allow := form.Get("allow_special_instructions") == "on"
 	available := form.Get("available") == "on"
 
@@ -155,6 +157,7 @@ func (h *AdminHandler) PostItemEditor(c echo.Context) error {
 	cmd.SpiceLevel = &spice
 	cmd.Schedule = &schedule
 	cmd.SKU = &sku
+	cmd.TaxClass = &taxClass
 	vegan := form.Get("is_vegan") == "on"
 	cmd.IsVegan = &vegan
 	dairyFree := form.Get("is_dairy_free") == "on"
@@ -208,8 +211,9 @@ func splitBadgesCSV(csv string) []string {
 }
 
 // parseOptionGroups unmarshals the editor's hidden option_groups_json field
-// into domain types. An empty payload means "clear all groups".
-func parseOptionGroups(raw string) ([]menu.OptionGroup, error) {
+// into domain types, reading price deltas in currency. An empty payload means
+// "clear all groups".
+func parseOptionGroups(raw string, currency money.Currency) ([]menu.OptionGroup, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" || raw == "[]" {
 		return nil, nil
@@ -218,7 +222,7 @@ func parseOptionGroups(raw string) ([]menu.OptionGroup, error) {
 	if err := json.Unmarshal([]byte(raw), &dtos); err != nil {
 		return nil, err
 	}
-	return dto.ToDomain(dtos), nil
+	return dto.ToDomain(dtos, currency)
 }
 
 // parseTranslations decodes the editor's translations JSON textarea into a
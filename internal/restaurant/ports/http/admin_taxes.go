@@ -0,0 +1,126 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/interfaces/templates/admin"
+	restaurantCmd "bitmerchant/internal/restaurant/app/command"
+
+	"github.com/labstack/echo/v4"
+)
+
+const adminTaxesPath = "/admin/taxes"
+
+const (
+	adminFlashTaxesSaved   = "tax_settings_saved"
+	adminFlashTaxesInvalid = "tax_settings_invalid"
+)
+
+func adminTaxesRedirect(flashCode string) string {
+	return adminTaxesPath + "?flash=" + url.QueryEscape(flashCode)
+}
+
+func adminTaxesFlashState(flashCode string) (taxesError string, saved bool) {
+	switch flashCode {
+	case adminFlashTaxesSaved:
+		return "", true
+	case adminFlashTaxesInvalid:
+		return "Each tax needs a name and a rate from 0 to 99.99%; classes are letters, digits, - or _.", false
+	default:
+		return "", false
+	}
+}
+
+// GetTaxSettings handles GET /admin/taxes
+func (h *AdminHandler) GetTaxSettings(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	rest, err := h.restaurantRepo.FindByID(restaurantID)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load restaurant")
+	}
+	dn, st, ini := commonhttp.LayoutUserStringsFromContext(c)
+	label := commonhttp.ActiveRestaurantLabel(c.Request().Context(), restaurantID, h.restaurantRepo)
+	switchOpts, activeRole, canCreate, sErr := commonhttp.RestaurantSwitcherData(c, h.membershipRepo, h.restaurantRepo)
+	if sErr != nil {
+		return c.String(http.StatusInternalServerError, "Failed to load navigation")
+	}
+	taxesError, saved := adminTaxesFlashState(c.QueryParam("flash"))
+	return admin.TaxSettingsPage(admin.TaxSettingsView{
+		Settings:    rest.EffectiveTaxSettings(),
+		Error:       taxesError,
+		Saved:       saved,
+		CSRFToken:   commonhttp.CSRFToken(c),
+		ActiveLabel: label,
+		DisplayName: dn,
+		Subtitle:    st,
+		Initials:    ini,
+		Switcher:    switchOpts,
+		ActiveRole:  activeRole,
+		CanCreate:   canCreate,
+	}).Render(c.Request().Context(), c.Response())
+}
+
+// PostTaxSettings handles POST /admin/taxes/settings
+func (h *AdminHandler) PostTaxSettings(c echo.Context) error {
+	restaurantID, err := h.restaurantID(c)
+	if err != nil {
+		return c.String(http.StatusUnauthorized, err.Error())
+	}
+	cfg, err := parseTaxSettings(c)
+	if err == nil {
+		err = h.updateTaxUC.Handle(c.Request().Context(), restaurantCmd.UpdateTaxSettings{
+			RestaurantID: restaurantID,
+			Settings:     cfg,
+		})
+	}
+	if err != nil {
+		return c.Redirect(http.StatusFound, adminTaxesRedirect(adminFlashTaxesInvalid))
+	}
+	return c.Redirect(http.StatusFound, adminTaxesRedirect(adminFlashTaxesSaved))
+}
+
+// parseTaxSettings reads the fixed tax rows of the settings form, skipping
+// rows with neither a name nor a rate. A rate without a name is an error.
+func parseTaxSettings(c echo.Context) (tax.Config, error) {
+	sc, err := tax.ParseRate(c.FormValue("serviceCharge"))
+	if err != nil {
+		return tax.Config{}, err
+	}
+	cfg := tax.Config{
+		Mode:                 tax.Mode(c.FormValue("mode")),
+		ServiceChargeRate:    sc,
+		ServiceChargeTaxable: c.FormValue("serviceChargeTaxable") == "on",
+	}
+	for i := range tax.MaxTaxes {
+		n := strconv.Itoa(i)
+		name := strings.TrimSpace(c.FormValue("taxName" + n))
+		rawRate := strings.TrimSpace(c.FormValue("taxRate" + n))
+		if name == "" && rawRate == "" {
+			continue
+		}
+		if rawRate == "" {
+			return tax.Config{}, errors.New("tax rate required")
+		}
+		rate, err := tax.ParseRate(rawRate)
+		if err != nil {
+			return tax.Config{}, err
+		}
+		t := tax.Tax{Name: name, Rate: rate}
+		for _, raw := range strings.Split(c.FormValue("taxClasses"+n), ",") {
+			if raw = strings.TrimSpace(raw); raw != "" {
+				t.Classes = append(t.Classes, tax.Class(raw))
+			}
+		}
+		cfg.Taxes = append(cfg.Taxes, t)
+	}
+	return cfg, nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bitmerchant/internal/common/decorator"
 	"bitmerchant/internal/infrastructure/qr"
 	menuQuery "bitmerchant/internal/menu/app/query"
 	"bitmerchant/internal/menu/domain/menu"
@@ -18,6 +19,11 @@ type Restaurant struct {
 	PauseRestaurant         restaurantCmd.PauseRestaurantHandler
 	UpdateTableCount        restaurantCmd.UpdateRestaurantTableCountHandler
 	UpdateKitchenThresholds restaurantCmd.UpdateKitchenThresholdsHandler
+	UpdateLightningAddress  restaurantCmd.UpdateLightningAddressHandler
+	UpdateTaxSettings       restaurantCmd.UpdateTaxSettingsHandler
+	UpdateCashRounding      restaurantCmd.UpdateCashRoundingHandler
+	UpdateTipSettings       restaurantCmd.UpdateTipSettingsHandler
+	UpdateTipPooling        restaurantCmd.UpdateTipPoolingHandler
 	GenerateRestaurantQR    restaurantQuery.RestaurantTableQRImageHandler
 	Admin                   *restauranthttp.AdminHandler
 	Owner                   *restauranthttp.OwnerHandler
@@ -26,17 +32,23 @@ type Restaurant struct {
 // New wires restaurant bounded-context handlers and admin/owner HTTP adapters.
 func New(
 	repos wiring.Repositories,
+	metrics decorator.MetricsClient,
 	cfg wiring.Config,
 	qrService *qr.QRCodeService,
 	menuSvc menuservice.Menu,
 	photoStorage menu.PhotoStorage,
 ) Restaurant {
-	createRestUC := restaurantCmd.NewCreateRestaurantHandler(repos.Restaurant, nil, nil)
-	toggleOpenUC := restaurantCmd.NewToggleRestaurantOpenHandler(repos.Restaurant, nil, nil)
-	pauseRestUC := restaurantCmd.NewPauseRestaurantHandler(repos.Restaurant, nil, nil)
-	updateTableCountUC := restaurantCmd.NewUpdateRestaurantTableCountHandler(repos.Restaurant, nil, nil)
-	updateKitchenThresholdsUC := restaurantCmd.NewUpdateKitchenThresholdsHandler(repos.Restaurant, nil, nil)
-	generateQRUC := restaurantQuery.NewRestaurantTableQRImageHandler(qrService, cfg.CustomerBaseURL, repos.Restaurant, nil, nil)
+	createRestUC := restaurantCmd.NewCreateRestaurantHandler(repos.Restaurant, nil, metrics)
+	toggleOpenUC := restaurantCmd.NewToggleRestaurantOpenHandler(repos.Restaurant, nil, metrics)
+	pauseRestUC := restaurantCmd.NewPauseRestaurantHandler(repos.Restaurant, nil, metrics)
+	updateTableCountUC := restaurantCmd.NewUpdateRestaurantTableCountHandler(repos.Restaurant, nil, metrics)
+	updateKitchenThresholdsUC := restaurantCmd.NewUpdateKitchenThresholdsHandler(repos.Restaurant, nil, metrics)
+	updateLightningAddressUC := restaurantCmd.NewUpdateLightningAddressHandler(repos.Restaurant, nil, metrics)
+	updateTaxSettingsUC := restaurantCmd.NewUpdateTaxSettingsHandler(repos.Restaurant, nil, metrics)
+	updateCashRoundingUC := restaurantCmd.NewUpdateCashRoundingHandler(repos.Restaurant, nil, metrics)
+	updateTipSettingsUC := restaurantCmd.NewUpdateTipSettingsHandler(repos.Restaurant, nil, metrics)
+	updateTipPoolingUC := restaurantCmd.NewUpdateTipPoolingHandler(repos.Restaurant, nil, metrics)
+	generateQRUC := restaurantQuery.NewRestaurantTableQRImageHandler(qrService, cfg.CustomerBaseURL, repos.Restaurant, nil, metrics)
 
 	adminHandler := restauranthttp.NewAdminHandler(
 		createRestUC,
@@ -58,6 +70,11 @@ func New(
 		},
 		updateTableCountUC,
 		updateKitchenThresholdsUC,
+		updateLightningAddressUC,
+		updateTaxSettingsUC,
+		updateCashRoundingUC,
+		updateTipSettingsUC,
+		updateTipPoolingUC,
 		generateQRUC,
 		repos.Membership,
 		repos.Restaurant,
@@ -70,6 +87,11 @@ func New(
 		PauseRestaurant:         pauseRestUC,
 		UpdateTableCount:        updateTableCountUC,
 		UpdateKitchenThresholds: updateKitchenThresholdsUC,
+		UpdateLightningAddress:  updateLightningAddressUC,
+		UpdateTaxSettings:       updateTaxSettingsUC,
+		UpdateCashRounding:      updateCashRoundingUC,
+		UpdateTipSettings:       updateTipSettingsUC,
+		UpdateTipPooling:        updateTipPoolingUC,
 		GenerateRestaurantQR:    generateQRUC,
 		Admin:                   adminHandler,
 		Owner:                   ownerHandler,
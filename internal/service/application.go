@@ -7,20 +7,22 @@ import (
 	"bitmerchant/internal/auth/domain/session"
 	"bitmerchant/internal/auth/domain/user"
 	authhttp "bitmerchant/internal/auth/ports/http"
-	commonhttp "bitmerchant/internal/common/http"
 	"bitmerchant/internal/common/http/middleware"
 	dashboardhttp "bitmerchant/internal/dashboard/ports/http"
 	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
+	"bitmerchant/internal/infrastructure/metrics"
 	menuCmd "bitmerchant/internal/menu/app/command"
 	menuQuery "bitmerchant/internal/menu/app/query"
 	menuhttp "bitmerchant/internal/menu/ports/http"
 	orderCmd "bitmerchant/internal/ordering/app/command"
 	orderQuery "bitmerchant/internal/ordering/app/query"
 	orderinghttp "bitmerchant/internal/ordering/ports/http"
+	paymenthttp "bitmerchant/internal/payment/ports/http"
 	placesCmd "bitmerchant/internal/places/app/command"
 	placesQuery "bitmerchant/internal/places/app/query"
 	placeshttp "bitmerchant/internal/places/ports/http"
+	promotionhttp "bitmerchant/internal/promotion/ports/http"
 	restCmd "bitmerchant/internal/restaurant/app/command"
 	restQuery "bitmerchant/internal/restaurant/app/query"
 	restauranthttp "bitmerchant/internal/restaurant/ports/http"
@@ -33,6 +35,9 @@ type Commands struct {
 	MarkOrderPreparing orderCmd.MarkOrderPreparingHandler
 	MarkOrderReady     orderCmd.MarkOrderReadyHandler
 	MarkOrderCompleted orderCmd.MarkOrderCompletedHandler
+	CancelOrder        orderCmd.CancelOrderHandler
+	SplitBill          orderCmd.SplitBillHandler
+	PayBillPart        orderCmd.PayBillPartHandler
 
 	CreateRestaurant     restCmd.CreateRestaurantHandler
 	ToggleRestaurantOpen restCmd.ToggleRestaurantOpenHandler
@@ -63,18 +68,24 @@ type Queries struct {
 
 // Ports groups inbound adapters used by HTTP routing.
 type Ports struct {
-	Menu      *menuhttp.MenuHandler
-	Cart      *orderinghttp.CartHandler
-	Order     *orderinghttp.OrderHandler
-	Places    *placeshttp.PlacesHandler
-	Kitchen   *orderinghttp.KitchenHandler
-	Server    *orderinghttp.ServerHandler
-	Push      *orderinghttp.PushHandler
-	Admin     *restauranthttp.AdminHandler
-	Owner     *restauranthttp.OwnerHandler
-	Dashboard *dashboardhttp.DashboardHandler
-	Auth      *authhttp.AuthHandler
-	SSE       *commonhttp.SSEHandler
+	Menu  *menuhttp.MenuHandler
+	Cart  *orderinghttp.CartHandler
+	Order *orderinghttp.OrderHandler
+	// LightningPay is nil when no Lightning backend is configured.
+	LightningPay *orderinghttp.LightningPayHandler
+	Places       *placeshttp.PlacesHandler
+	Kitchen      *orderinghttp.KitchenHandler
+	Server       *orderinghttp.ServerHandler
+	Drawer       *paymenthttp.DrawerHandler
+	Shifts       *paymenthttp.ShiftsHandler
+	TimeClock    *paymenthttp.TimeClockHandler
+	Promotions   *promotionhttp.PromotionsHandler
+	Push         *orderinghttp.PushHandler
+	Admin        *restauranthttp.AdminHandler
+	Owner        *restauranthttp.OwnerHandler
+	Dashboard    *dashboardhttp.DashboardHandler
+	TipOut       *dashboardhttp.TipOutHandler
+	Auth         *authhttp.AuthHandler
 
 	MembershipRepo membership.Repository
 	SessionRepo    session.Repository
@@ -87,6 +98,7 @@ type Infra struct {
 	Logger   *logging.Logger
 	EventBus *events.EventBus
 	DB       *sql.DB
+	Metrics  *metrics.Prometheus
 }
 
 // Application is the composed runtime application.
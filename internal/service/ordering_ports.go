@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/ordering/app/cart"
+	orderCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/domain/order"
+	payCmd "bitmerchant/internal/payment/app/command"
+	promoCmd "bitmerchant/internal/promotion/app/command"
+	promoQuery "bitmerchant/internal/promotion/app/query"
+	"bitmerchant/internal/promotion/domain/promotion"
+)
+
+// paymentRecorder settles a mark-paid in the payment ledger.
+type paymentRecorder struct {
+	record payCmd.RecordPaymentHandler
+}
+
+func (r paymentRecorder) RecordPayment(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (orderCmd.SettledPayment, error) {
+	amount, rounding := o.ChargeFor(method)
+	p, err := r.record.Handle(ctx, payCmd.RecordPayment{
+		OrderID:            o.ID,
+		RestaurantID:       o.RestaurantID,
+		Method:             method,
+		Amount:             amount,
+		Tendered:           tendered,
+		CollectedBy:        collectedBy,
+		RoundingAdjustment: rounding,
+	})
+	if err != nil {
+		return orderCmd.SettledPayment{}, err
+	}
+	return orderCmd.SettledPayment{PaymentID: p.ID, FXRate: p.FXRate}, nil
+}
+
+// billPartRecorder settles one part of a split bill in the payment ledger.
+type billPartRecorder struct {
+	record payCmd.RecordPaymentHandler
+}
+
+func (r billPartRecorder) RecordBillPart(ctx context.Context, o *order.Order, part order.BillPart, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (orderCmd.SettledPayment, error) {
+	amount, rounding := o.PartChargeFor(part, method)
+	p, err := r.record.Handle(ctx, payCmd.RecordPayment{
+		OrderID:            o.ID,
+		RestaurantID:       o.RestaurantID,
+		BillPartID:         part.ID,
+		Method:             method,
+		Amount:             amount,
+		Tendered:           tendered,
+		CollectedBy:        collectedBy,
+		RoundingAdjustment: rounding,
+	})
+	if err != nil {
+		return orderCmd.SettledPayment{}, err
+	}
+	return orderCmd.SettledPayment{PaymentID: p.ID, FXRate: p.FXRate}, nil
+}
+
+// paymentCanceller refunds or voids a cancelled order's payments.
+type paymentCanceller struct {
+	refund payCmd.RefundPaymentHandler
+	void   payCmd.VoidPaymentHandler
+}
+
+func (c paymentCanceller) CancelPayments(ctx context.Context, o *order.Order, refund bool, reason string, by common.UserID) error {
+	if !refund {
+		return c.void.Handle(ctx, payCmd.VoidPayment{OrderID: o.ID, Reason: reason})
+	}
+	if err := c.refund.Handle(ctx, payCmd.RefundPayment{OrderID: o.ID, Reason: reason, RefundedBy: by}); err != nil {
+		return err
+	}
+	// A partly paid split bill can still have open invoices.
+	return c.void.Handle(ctx, payCmd.VoidPayment{OrderID: o.ID, Reason: reason, KeepSettled: true})
+}
+
+// openPaymentVoider voids an order's pending payments before its bill is
+// split.
+type openPaymentVoider struct {
+	void payCmd.VoidPaymentHandler
+}
+
+func (v openPaymentVoider) VoidOpenPayments(ctx context.Context, o *order.Order, reason string) error {
+	return v.void.Handle(ctx, payCmd.VoidPayment{OrderID: o.ID, Reason: reason, KeepSettled: true})
+}
+
+// discountPricer prices a cart's best promotion at checkout.
+type discountPricer struct {
+	orderDiscount promoQuery.OrderDiscountHandler
+}
+
+func (p discountPricer) PriceDiscount(ctx context.Context, sessionID string, c *cart.Cart) (cart.Discount, error) {
+	lines := make([]promotion.Line, 0, len(c.Items))
+	for _, item := range c.Items {
+		lines = append(lines, promotion.Line{
+			CategoryID: item.CategoryID,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice + item.ModifierPrice,
+		})
+	}
+	d, err := p.orderDiscount.Handle(ctx, promoQuery.OrderDiscount{
+		RestaurantID: c.RestaurantID,
+		SessionID:    sessionID,
+		Code:         c.PromoCode,
+		Lines:        lines,
+		Subtotal:     c.Total,
+	})
+	if err != nil || d.Promotion == nil {
+		return cart.Discount{}, promotionRejected(err)
+	}
+	return cart.Discount{PromotionID: d.Promotion.ID, Code: d.Promotion.Code, Label: d.Promotion.Label(), Amount: d.Amount}, nil
+}
+
+// discountRedeemer records a placed order's use of its promotion.
+type discountRedeemer struct {
+	redeem promoCmd.RedeemPromotionHandler
+}
+
+func (r discountRedeemer) RedeemDiscount(ctx context.Context, d cart.Discount, sessionID string, orderID common.OrderID) error {
+	return promotionRejected(r.redeem.Handle(ctx, promoCmd.RedeemPromotion{
+		PromotionID: d.PromotionID,
+		SessionID:   sessionID,
+		OrderID:     orderID,
+		Amount:      d.Amount,
+	}))
+}
+
+// promotionRejected turns a promotion rejection (bad code, limit reached)
+// into the error checkout shows the customer; other errors pass through.
+func promotionRejected(err error) error {
+	if promotion.IsRejection(err) {
+		return &orderCmd.PromotionRejectedError{Reason: err.Error()}
+	}
+	return err
+}
@@ -8,19 +8,28 @@ import (
 
 	authInfra "bitmerchant/internal/auth/adapters"
 	authservice "bitmerchant/internal/auth/service"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/outbox"
 	dashboardservice "bitmerchant/internal/dashboard/service"
 	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
+	infraMetrics "bitmerchant/internal/infrastructure/metrics"
 	"bitmerchant/internal/infrastructure/qr"
+	"bitmerchant/internal/infrastructure/tracing"
 	menuservice "bitmerchant/internal/menu/service"
 	"bitmerchant/internal/notification"
 	notifwebpush "bitmerchant/internal/notification/webpush"
+	orderingapp "bitmerchant/internal/ordering/app"
+	orderCmd "bitmerchant/internal/ordering/app/command"
+	orderQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
 	orderinghttp "bitmerchant/internal/ordering/ports/http"
 	ordernotif "bitmerchant/internal/ordering/ports/notification"
 	orderingservice "bitmerchant/internal/ordering/service"
-	payAdapters "bitmerchant/internal/payment/adapters"
+	"bitmerchant/internal/payment/domain/payment"
+	paymentservice "bitmerchant/internal/payment/service"
 	placeservice "bitmerchant/internal/places/service"
+	promotionservice "bitmerchant/internal/promotion/service"
 	restaurantservice "bitmerchant/internal/restaurant/service"
 
 	commonhttp "bitmerchant/internal/common/http"
@@ -29,7 +38,6 @@ import (
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
-	wmmiddleware "github.com/ThreeDotsLabs/watermill/message/router/middleware"
 )
 
 // Config mirrors runtime configuration required by the composition root (alias for wiring.Config).
@@ -47,23 +55,39 @@ func NewComponentTestApplication(ctx context.Context) Application {
 }
 
 func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Application, func(), error) {
-	eventBus, err := events.NewEventBusWithConfig(eventBusConfig(cfg))
-	if err != nil {
-		return Application{}, nil, fmt.Errorf("init event bus: %w", err)
+	if err := money.Register(cfg.Currencies...); err != nil {
+		return Application{}, nil, fmt.Errorf("register currencies: %w", err)
 	}
 
 	var db *sql.DB
+	var eventBus *events.EventBus
 	var orderEventsRouter *message.Router
+	shutdownTracing := func(context.Context) error { return nil }
+	watcherCtx, stopWatcher := context.WithCancel(ctx)
 	cleanupResources := func() {
+		stopWatcher()
 		if orderEventsRouter != nil {
 			_ = orderEventsRouter.Close()
 		}
-		_ = eventBus.Close()
+		if eventBus != nil {
+			_ = eventBus.Close()
+		}
 		if db != nil {
 			_ = db.Close()
 		}
+		// Last, so the spans of everything above are flushed.
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = shutdownTracing(shutdownCtx)
 	}
 
+	shutdown, err := tracing.Setup(ctx, tracing.Config{Exporter: cfg.TracesExporter, ServiceName: "bitmerchant"})
+	if err != nil {
+		cleanupResources()
+		return Application{}, nil, fmt.Errorf("init tracing: %w", err)
+	}
+	shutdownTracing = shutdown
+
 	photoStorage, err := wiring.InitPhotoStorage(cfg, logger)
 	if err != nil {
 		cleanupResources()
@@ -76,6 +100,17 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 		return Application{}, nil, fmt.Errorf("connect database: %w", err)
 	}
 
+	// One Prometheus registry per application: its metrics are served on
+	// /metrics by cmd/server.
+	metrics := infraMetrics.NewPrometheus()
+
+	// The postgres event backend keeps its messages in the same database.
+	eventBus, err = events.NewEventBusWithConfig(eventBusConfig(cfg, db, metrics))
+	if err != nil {
+		cleanupResources()
+		return Application{}, nil, fmt.Errorf("init event bus: %w", err)
+	}
+
 	repos := wiring.NewMemoryRepositories()
 	if db != nil {
 		repos = wiring.NewPostgresRepositories(db)
@@ -89,14 +124,66 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 	wiring.SeedData(ctx, repos)
 
 	qrService := qr.NewQRCodeService()
-	_ = payAdapters.NewCashPaymentMethod()
+	// With NATS the SSE hub broadcasts through the bus so a board open on
+	// one replica sees orders placed on another; in memory it stays local.
 	sseHandler := commonhttp.NewSSEHandler()
+	if relay := eventBus.SSERelay(); relay != nil {
+		sseHandler, err = commonhttp.NewRelayedSSEHandler(relay)
+		if err != nil {
+			cleanupResources()
+			return Application{}, nil, fmt.Errorf("init sse relay: %w", err)
+		}
+	}
+	metrics.ObserveSSEClients(sseHandler.ClientCounts)
 
-	placesSvc := placeservice.New(repos)
-	orderingSvc := orderingservice.New(repos, eventBus, logger, cfg.VAPIDPublicKey, photoStorage, cfg)
-	menuSvc := menuservice.New(repos, photoStorage, cfg, orderingSvc.CartService, placesSvc.RecordMenuVisit)
-	restaurantSvc := restaurantservice.New(repos, cfg, qrService, menuSvc, photoStorage)
-	dashboardSvc := dashboardservice.New(repos, restaurantSvc.ToggleRestaurantOpen, restaurantSvc.PauseRestaurant, photoStorage, cfg, logger.Logger)
+	placesSvc := placeservice.New(repos, metrics)
+	converter, err := wiring.NewFXConverter(cfg)
+	if err != nil {
+		cleanupResources()
+		return Application{}, nil, fmt.Errorf("init fx rates: %w", err)
+	}
+	if len(cfg.FXProviders) > 0 && len(cfg.LightningBTCRates) > 0 {
+		logger.Warn("LIGHTNING_BTC_RATES ignored — live FX_PROVIDERS are configured", "providers", cfg.FXProviders)
+	}
+	// Payments and ordering call into each other: a settled Lightning invoice
+	// marks the order paid (settles its part of a split bill, or adds the late
+	// tip it was raised for), every mark-paid settles the payment ledger, and
+	// cancelling an order voids or refunds its payments.
+	var orderingSvc orderingservice.Ordering
+	promotionSvc := promotionservice.New(repos, logger.Logger, metrics)
+	paymentSvc, err := paymentservice.New(repos, cfg, converter, logger.Logger, metrics, func(ctx context.Context, p *payment.Payment) error {
+		if p.IsTip() {
+			_, err := orderingSvc.AddTip.Handle(ctx, orderCmd.AddTip{OrderID: p.OrderID, PaymentID: p.ID, Amount: p.ValueAtSale()})
+			return err
+		}
+		settled := &orderCmd.SettledPayment{PaymentID: p.ID, FXRate: p.FXRate}
+		if p.BillPartID != "" {
+			_, err := orderingSvc.PayBillPart.Handle(ctx, orderCmd.PayBillPart{OrderID: p.OrderID, PartID: p.BillPartID, Method: p.Method, Settled: settled})
+			return err
+		}
+		_, err := orderingSvc.MarkOrderPaid.Handle(ctx, orderCmd.MarkOrderPaid{OrderID: p.OrderID, Method: p.Method, Settled: settled})
+		return err
+	})
+	if err != nil {
+		cleanupResources()
+		return Application{}, nil, fmt.Errorf("init payments: %w", err)
+	}
+	orderingSvc = orderingservice.New(repos, logger, metrics, sseHandler, cfg.VAPIDPublicKey, photoStorage, cfg, converter, orderingapp.Ports{
+		Payments:  paymentRecorder{record: paymentSvc.RecordPayment},
+		BillParts: billPartRecorder{record: paymentSvc.RecordPayment},
+		Canceller: paymentCanceller{refund: paymentSvc.RefundPayment, void: paymentSvc.VoidPayment},
+		Voider:    openPaymentVoider{void: paymentSvc.VoidPayment},
+		Pricer:    discountPricer{orderDiscount: promotionSvc.OrderDiscount},
+		Redeemer:  discountRedeemer{redeem: promotionSvc.RedeemPromotion},
+	})
+	lightningPay := orderinghttp.NewLightningPayHandler(orderingSvc.GetCustomerOrder, paymentSvc.RequestLightningInvoice, orderingSvc.LateTips, qrService)
+	go paymentSvc.LightningWatcher.Run(watcherCtx)
+	if paymentSvc.LightningEnabled() {
+		logger.Info("lightning node checkout enabled", "backend", cfg.LightningBackend)
+	}
+	menuSvc := menuservice.New(repos, metrics, photoStorage, cfg, converter, orderingSvc.CartService, placesSvc.RecordMenuVisit)
+	restaurantSvc := restaurantservice.New(repos, metrics, cfg, qrService, menuSvc, photoStorage)
+	dashboardSvc := dashboardservice.New(repos, restaurantSvc.ToggleRestaurantOpen, restaurantSvc.PauseRestaurant, orderingSvc.CancelOrder, photoStorage, cfg, logger.Logger, metrics)
 
 	sessionOpts := newSessionOptions(cfg)
 	webauthnSvc, err := authInfra.NewWebAuthnService(cfg.RPID, "BitMerchant", []string{cfg.MerchantBaseURL})
@@ -105,7 +192,7 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 		return Application{}, nil, fmt.Errorf("init webauthn: %w", err)
 	}
 
-	authSvc := authservice.New(repos, webauthnSvc, logger.Logger, sessionOpts, restaurantSvc.CreateRestaurant, cfg.MerchantBaseURL)
+	authSvc := authservice.New(repos, webauthnSvc, logger.Logger, metrics, sessionOpts, restaurantSvc.CreateRestaurant, cfg.MerchantBaseURL)
 
 	vapidCfg := notifwebpush.VAPIDConfig{
 		PublicKey:  cfg.VAPIDPublicKey,
@@ -113,11 +200,16 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 		Subject:    cfg.VAPIDSubject,
 	}
 	warnIfVAPIDIncomplete(logger, vapidCfg)
-	orderEventsRouter, err = startOrderEventsRouter(ctx, cfg, eventBus, logger, sseHandler, repos.Order, pushRepo, vapidCfg)
+	orderEventsRouter, err = startOrderEventsRouter(ctx, cfg, eventBus, logger, sseHandler, repos.Order, orderingSvc.LateTips, pushRepo, vapidCfg, repos.DeadLetters, repos.ProcessedEvents, metrics)
 	if err != nil {
 		cleanupResources()
 		return Application{}, nil, fmt.Errorf("init order events router: %w", err)
 	}
+	// Relay the outbox once the router is subscribed: the in-memory bus
+	// drops messages nobody is subscribed to yet.
+	go outbox.NewRelay(repos.Outbox, eventBus, logger.Logger).Run(watcherCtx)
+	go events.PurgeDedup(logging.ToContext(watcherCtx, logger.Logger), repos.ProcessedEvents, resolveDedupTTL(cfg), time.Hour)
+	go events.PurgeEvents(logging.ToContext(watcherCtx, logger.Logger), eventBus, resolveEventRetention(cfg), time.Hour)
 
 	application := Application{
 		Commands: Commands{
@@ -126,6 +218,9 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 			MarkOrderPreparing:      orderingSvc.MarkOrderPreparing,
 			MarkOrderReady:          orderingSvc.MarkOrderReady,
 			MarkOrderCompleted:      orderingSvc.MarkOrderCompleted,
+			CancelOrder:             orderingSvc.CancelOrder,
+			SplitBill:               orderingSvc.SplitBill,
+			PayBillPart:             orderingSvc.PayBillPart,
 			CreateRestaurant:        restaurantSvc.CreateRestaurant,
 			ToggleRestaurantOpen:    restaurantSvc.ToggleRestaurantOpen,
 			UpdateTableCount:        restaurantSvc.UpdateTableCount,
@@ -152,15 +247,20 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 			Menu:           menuSvc.HTTP,
 			Cart:           orderingSvc.CartHandler,
 			Order:          orderingSvc.OrderHandler,
+			LightningPay:   lightningPay,
 			Places:         placesSvc.HTTP,
 			Kitchen:        orderingSvc.KitchenHandler,
 			Server:         orderingSvc.ServerHandler,
+			Drawer:         paymentSvc.Drawer,
+			Shifts:         paymentSvc.Shifts,
+			TimeClock:      paymentSvc.TimeClock,
+			Promotions:     promotionSvc.HTTP,
 			Push:           orderinghttp.NewPushHandler(pushRepo, logger.Logger),
 			Admin:          restaurantSvc.Admin,
 			Owner:          restaurantSvc.Owner,
 			Dashboard:      dashboardSvc.HTTP,
+			TipOut:         dashboardSvc.TipOut,
 			Auth:           authSvc.HTTP,
-			SSE:            sseHandler,
 			MembershipRepo: repos.Membership,
 			SessionRepo:    repos.Session,
 			UserRepo:       repos.User,
@@ -170,6 +270,7 @@ func newApplication(ctx context.Context, cfg Config, logger *logging.Logger) (Ap
 			Logger:   logger,
 			EventBus: eventBus,
 			DB:       db,
+			Metrics:  metrics,
 		},
 	}
 
@@ -205,7 +306,7 @@ func warnIfVAPIDIncomplete(logger *logging.Logger, v notifwebpush.VAPIDConfig) {
 	)
 }
 
-func eventBusConfig(cfg Config) events.Config {
+func eventBusConfig(cfg Config, db *sql.DB, metrics events.Metrics) events.Config {
 	return events.Config{
 		Backend:           cfg.EventBusBackend,
 		NATSURL:           cfg.NATSURL,
@@ -214,6 +315,8 @@ func eventBusConfig(cfg Config) events.Config {
 		NATSCloseTimeout:  cfg.NATSCloseTimeout,
 		NATSSubscribers:   cfg.NATSSubscribersCount,
 		NATSInstanceID:    cfg.NATSInstanceID,
+		DB:                db,
+		Metrics:           metrics,
 	}
 }
 
@@ -224,6 +327,38 @@ func resolveRouterCloseTimeout(cfg Config) time.Duration {
 	return cfg.NATSCloseTimeout
 }
 
+func resolveRetryPolicy(cfg Config) events.RetryPolicy {
+	policy := events.RetryPolicy{
+		MaxRetries:      cfg.EventRetryMax,
+		InitialInterval: cfg.EventRetryInitialInterval,
+		MaxInterval:     cfg.EventRetryMaxInterval,
+	}
+	if policy.MaxRetries <= 0 {
+		policy.MaxRetries = 3
+	}
+	if policy.InitialInterval <= 0 {
+		policy.InitialInterval = 100 * time.Millisecond
+	}
+	if policy.MaxInterval <= 0 {
+		policy.MaxInterval = time.Second
+	}
+	return policy
+}
+
+func resolveDedupTTL(cfg Config) time.Duration {
+	if cfg.EventDedupTTL <= 0 {
+		return 24 * time.Hour
+	}
+	return cfg.EventDedupTTL
+}
+
+func resolveEventRetention(cfg Config) time.Duration {
+	if cfg.EventRetention <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return cfg.EventRetention
+}
+
 func newSessionOptions(cfg Config) middleware.SessionOptions {
 	secureCookie := middleware.ShouldUseSecureCookies(cfg.PublicBaseURL, cfg.ForceSecureCookie) ||
 		middleware.ShouldUseSecureCookies(cfg.CustomerBaseURL, cfg.ForceSecureCookie) ||
@@ -245,8 +380,12 @@ func startOrderEventsRouter(
 	logger *logging.Logger,
 	sseHandler *commonhttp.SSEHandler,
 	orderRepo order.Repository,
+	lateTips orderQuery.LateTipLookup,
 	pushRepo notifwebpush.Repository,
 	vapidCfg notifwebpush.VAPIDConfig,
+	deadLetters events.DeadLetterStore,
+	processed events.DedupStore,
+	pushMetrics notifwebpush.Metrics,
 ) (*message.Router, error) {
 	wmLogger := watermill.NewStdLogger(false, false)
 	orderEventsRouter, err := message.NewRouter(message.RouterConfig{
@@ -256,24 +395,32 @@ func startOrderEventsRouter(
 		return nil, err
 	}
 
-	orderEventsRouter.AddMiddleware(
-		wmmiddleware.Recoverer,
-		wmmiddleware.Retry{
-			MaxRetries:      3,
-			InitialInterval: 100 * time.Millisecond,
-			MaxInterval:     1 * time.Second,
-			Multiplier:      2.0,
-			Logger:          wmLogger,
-		}.Middleware,
-	)
-	orderingservice.RegisterOrderSSEHandlers(orderEventsRouter, eventBus.Subscriber(), logger, sseHandler, orderRepo)
+	// Handlers that keep failing are dead-lettered rather than redelivered
+	// forever; one replica stores each dead letter for cmd/deadletters.
+	handlerMiddleware, err := eventBus.HandlerMiddleware(resolveRetryPolicy(cfg), wmLogger)
+	if err != nil {
+		return nil, err
+	}
+	orderEventsRouter.AddMiddleware(handlerMiddleware...)
+	events.RegisterDeadLetterHandler(orderEventsRouter, eventBus.SharedSubscriberForGroup("deadletters"), deadLetters)
+
+	// SSE projections render each event once across the cluster; the relayed
+	// SSE hub then fans the frame out to every replica's clients. Without a
+	// relay (Postgres) each replica renders every event for its own clients.
+	sseSubscriber := eventBus.SubscriberForGroup("sse")
+	if eventBus.SSERelay() != nil {
+		sseSubscriber = eventBus.SharedSubscriberForGroup("sse")
+	}
+	orderingservice.RegisterOrderSSEHandlers(orderEventsRouter, sseSubscriber, logger, sseHandler, orderRepo, lateTips)
 
-	webPushNotifier := notifwebpush.NewNotifier(pushRepo, vapidCfg, logger.Logger)
+	webPushNotifier := notifwebpush.NewNotifier(pushRepo, vapidCfg, logger.Logger).WithMetrics(pushMetrics)
 	notifSvc := notification.NewService(logger, webPushNotifier)
 	// Register notification handlers in a separate consumer group from SSE
 	// handlers — without this, NATS load-balances each event between the two
 	// sets and only one fires per message (see EventBus.SubscriberForGroup).
-	ordernotif.RegisterOrderNotificationHandlers(orderEventsRouter, eventBus.SubscriberForGroup("notif"), logger, notifSvc)
+	// A redelivered event must not push the customer twice.
+	ordernotif.RegisterOrderNotificationHandlers(orderEventsRouter, eventBus.SubscriberForGroup("notif"), notifSvc,
+		events.Deduplicate(processed, resolveDedupTTL(cfg)))
 
 	routerErrors := make(chan error, 1)
 	go func() {
@@ -1,6 +1,10 @@
 package wiring
 
-import "time"
+import (
+	"time"
+
+	"bitmerchant/internal/common/money"
+)
 
 // Config is runtime configuration for the composition root (DB, URLs, S3, WebAuthn, cookies).
 type Config struct {
@@ -25,7 +29,55 @@ type Config struct {
 	NATSSubscribersCount int
 	NATSInstanceID       string
 
+	// EventRetryMax is how many times a failing event handler is retried
+	// before its message is dead-lettered; the backoff between attempts
+	// doubles from EventRetryInitialInterval up to EventRetryMaxInterval.
+	EventRetryMax             int
+	EventRetryInitialInterval time.Duration
+	EventRetryMaxInterval     time.Duration
+	// EventDedupTTL is how long a side-effecting handler remembers the
+	// messages it processed, so redeliveries within it are skipped.
+	EventDedupTTL time.Duration
+	// EventRetention is how long the postgres event backend keeps messages
+	// before purging them.
+	EventRetention time.Duration
+
+	// TracesExporter sends OpenTelemetry spans to "otlp" or "stdout"; empty
+	// or "none" turns tracing off.
+	TracesExporter string
+
 	VAPIDPublicKey  string
 	VAPIDPrivateKey string
 	VAPIDSubject    string
+
+	// LightningBackend selects the Lightning node adapter: "", "fake", "lnd"
+	// or "cln". Empty disables Lightning checkout.
+	LightningBackend        string
+	LNDRESTURL              string
+	LNDMacaroonHex          string
+	LNDTLSCertPath          string
+	CLNRESTURL              string
+	CLNRune                 string
+	CLNTLSCertPath          string
+	LightningInvoiceExpiry  time.Duration
+	LightningPollInterval   time.Duration
+	LightningFakeAutoSettle time.Duration
+	// LNURLAllowLocal lets Lightning address lookups reach loopback and
+	// private hosts, for a wallet running next to a dev server. Never set it
+	// in production: addresses come from merchants.
+	LNURLAllowLocal bool
+	// LightningBTCRates prices one bitcoin in each fiat currency code. It is
+	// used only when no FXProviders are configured.
+	LightningBTCRates map[string]float64
+
+	// FXProviders lists live bitcoin price feeds ("coingecko", "coinbase")
+	// in fallback order. FXCacheTTL is how long a fetched rate is reused;
+	// FXMaxStaleness is how old it may get while every feed is down.
+	FXProviders    []string
+	FXCacheTTL     time.Duration
+	FXMaxStaleness time.Duration
+
+	// Currencies are offered alongside the built-in USD, THB and SAT; they
+	// are registered with the money package before anything parses a code.
+	Currencies []money.Currency
 }
@@ -0,0 +1,40 @@
+package wiring
+
+import (
+	"net/http"
+
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/fx"
+)
+
+// NewFXConverter builds the converter used to quote and invoice in sats: the
+// configured live feeds in order, or LightningBTCRates when there are none.
+// The fixed rates are never a fallback behind live feeds, where they would
+// be served as fresh and FXMaxStaleness would never apply. With neither
+// configured it returns money.NoopConverter.
+func NewFXConverter(cfg Config) (money.Converter, error) {
+	return newFXConverter(cfg, nil)
+}
+
+// newFXConverter is NewFXConverter with the live feeds reached through
+// client; nil leaves each provider its default client.
+func newFXConverter(cfg Config, client *http.Client) (money.Converter, error) {
+	var providers []money.RateProvider
+	for _, name := range cfg.FXProviders {
+		p, err := fx.NewProvider(name, client)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	if len(providers) == 0 && len(cfg.LightningBTCRates) > 0 {
+		providers = append(providers, money.FixtureRates(cfg.LightningBTCRates))
+	}
+	if len(providers) == 0 {
+		return money.NoopConverter{}, nil
+	}
+	return money.NewRateConverter(money.RateConverterOptions{
+		TTL:    cfg.FXCacheTTL,
+		MaxAge: cfg.FXMaxStaleness,
+	}, providers...), nil
+}
@@ -0,0 +1,50 @@
+package wiring
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"bitmerchant/internal/common/money"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewFXConverter(t *testing.T) {
+	fixture := map[string]float64{"USD": 65000}
+
+	t.Run("fixture rates price when no live feed is configured", func(t *testing.T) {
+		c, err := NewFXConverter(Config{LightningBTCRates: fixture})
+		require.NoError(t, err)
+
+		r, err := money.SnapshotRate(context.Background(), c, money.USD, money.SAT)
+		require.NoError(t, err)
+		assert.Equal(t, "fixture", r.Source)
+	})
+
+	t.Run("fixture rates are ignored when a live feed is configured", func(t *testing.T) {
+		calls := 0
+		down := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("feed down")
+		})}
+		c, err := newFXConverter(Config{FXProviders: []string{"coinbase"}, LightningBTCRates: fixture}, down)
+		require.NoError(t, err)
+
+		_, err = money.SnapshotRate(context.Background(), c, money.USD, money.SAT)
+		assert.ErrorIs(t, err, money.ErrRateUnavailable)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("nothing configured converts nothing", func(t *testing.T) {
+		c, err := NewFXConverter(Config{})
+		require.NoError(t, err)
+		assert.Equal(t, money.NoopConverter{}, c)
+	})
+}
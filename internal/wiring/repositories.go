@@ -10,10 +10,15 @@ import (
 	"bitmerchant/internal/auth/domain/session"
 	"bitmerchant/internal/auth/domain/user"
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/outbox"
+	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/menu/domain/menu"
 	"bitmerchant/internal/ordering/domain/order"
 	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/shift"
+	"bitmerchant/internal/payment/domain/timecard"
 	"bitmerchant/internal/places/domain/visit"
+	"bitmerchant/internal/promotion/domain/promotion"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 
 	authAdapters "bitmerchant/internal/auth/adapters"
@@ -21,6 +26,7 @@ import (
 	orderAdapters "bitmerchant/internal/ordering/adapters"
 	payAdapters "bitmerchant/internal/payment/adapters"
 	placesAdapters "bitmerchant/internal/places/adapters"
+	promoAdapters "bitmerchant/internal/promotion/adapters"
 	restAdapters "bitmerchant/internal/restaurant/adapters"
 )
 
@@ -31,28 +37,46 @@ type Repositories struct {
 	MenuItem                menu.ItemRepository
 	Order                   order.Repository
 	Payment                 payment.Repository
+	Shift                   shift.Repository
+	Timecard                timecard.Repository
 	User                    user.Repository
 	Membership              membership.Repository
 	Invitation              invitation.Repository
 	Session                 session.Repository
 	SessionRestaurantVisits visit.Repository
 	PasswordResetToken      passwordreset.Repository
+	Promotion               promotion.Repository
+	// Outbox holds the domain events repositories wrote alongside their
+	// aggregates until the relay publishes them.
+	Outbox outbox.Store
+	// DeadLetters keeps the messages event handlers gave up on.
+	DeadLetters events.DeadLetterStore
+	// ProcessedEvents remembers which side-effecting handlers already
+	// handled which messages.
+	ProcessedEvents events.DedupStore
 }
 
 // NewMemoryRepositories wires in-memory repositories (tests and local dev without Postgres).
 func NewMemoryRepositories() Repositories {
+	orders := orderAdapters.NewMemoryOrderRepository()
 	return Repositories{
 		Restaurant:              restAdapters.NewMemoryRestaurantRepository(),
 		MenuCategory:            menuAdapters.NewMemoryCategoryRepository(),
 		MenuItem:                menuAdapters.NewMemoryItemRepository(),
-		Order:                   orderAdapters.NewMemoryOrderRepository(),
-		Payment:                 payAdapters.NewMemoryPaymentRepository(),
+		Order:                   orders,
+		Payment:                 payAdapters.NewMemoryPaymentRepositoryWithOutbox(orders.Outbox()),
+		Shift:                   payAdapters.NewMemoryShiftRepository(),
+		Timecard:                payAdapters.NewMemoryTimecardRepository(),
 		User:                    authAdapters.NewMemoryUserRepository(),
 		Membership:              authAdapters.NewMemoryMembershipRepository(),
 		Invitation:              authAdapters.NewMemoryInvitationRepository(),
 		Session:                 authAdapters.NewMemorySessionRepository(),
 		SessionRestaurantVisits: placesAdapters.NewMemoryVisitRepository(),
 		PasswordResetToken:      authAdapters.NewMemoryPasswordResetTokenRepository(),
+		Promotion:               promoAdapters.NewMemoryPromotionRepository(),
+		Outbox:                  orders.Outbox(),
+		DeadLetters:             events.NewMemoryDeadLetters(),
+		ProcessedEvents:         events.NewMemoryDedupStore(),
 	}
 }
 
@@ -64,12 +88,18 @@ func NewPostgresRepositories(db *sql.DB) Repositories {
 		MenuItem:                menuAdapters.NewPostgresItemRepository(db),
 		Order:                   orderAdapters.NewPostgresOrderRepository(db),
 		Payment:                 payAdapters.NewPostgresPaymentRepository(db),
+		Shift:                   payAdapters.NewPostgresShiftRepository(db),
+		Timecard:                payAdapters.NewPostgresTimecardRepository(db),
 		User:                    authAdapters.NewPostgresUserRepository(db),
 		Membership:              authAdapters.NewPostgresMembershipRepository(db),
 		Invitation:              authAdapters.NewPostgresInvitationRepository(db),
 		Session:                 authAdapters.NewPostgresSessionRepository(db),
 		SessionRestaurantVisits: placesAdapters.NewPostgresVisitRepository(db),
 		PasswordResetToken:      authAdapters.NewPostgresPasswordResetTokenRepository(db),
+		Promotion:               promoAdapters.NewPostgresPromotionRepository(db),
+		Outbox:                  outbox.NewPostgresStore(db),
+		DeadLetters:             events.NewPostgresDeadLetters(db),
+		ProcessedEvents:         events.NewPostgresDedupStore(db),
 	}
 }
 
@@ -88,14 +118,14 @@ func SeedData(ctx context.Context, repos Repositories) {
 	_ = repos.MenuCategory.Save(cat2)
 	_ = repos.MenuCategory.Save(cat3)
 
-	item1, _ := menu.NewMenuItem("item_1", "cat_1", restaurantID, "Bruschetta", 8.50)
+	item1, _ := menu.NewMenuItem("item_1", "cat_1", restaurantID, "Bruschetta", 850)
 	_ = item1.SetDescription("Toasted bread with tomatoes and basil")
 	_ = repos.MenuItem.Save(item1)
 
-	item2, _ := menu.NewMenuItem("item_2", "cat_2", restaurantID, "Bitcoin Burger", 15.00)
+	item2, _ := menu.NewMenuItem("item_2", "cat_2", restaurantID, "Bitcoin Burger", 1500)
 	_ = item2.SetDescription("Premium beef patty with cheese")
 	_ = repos.MenuItem.Save(item2)
 
-	item3, _ := menu.NewMenuItem("item_3", "cat_3", restaurantID, "Satoshi Soda", 3.00)
+	item3, _ := menu.NewMenuItem("item_3", "cat_3", restaurantID, "Satoshi Soda", 300)
 	_ = repos.MenuItem.Save(item3)
 }
@@ -2,6 +2,8 @@ package http_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 
 	httpMiddleware "bitmerchant/internal/common/http/middleware"
 	"bitmerchant/internal/infrastructure/qr"
@@ -46,6 +48,11 @@ func TestAdminEndpoints(t *testing.T) {
 	reorderItemUC := menuCmd.NewReorderMenuItemsHandler(repoItem, repoCat, nil, nil)
 	updateTableUC := restaurantCmd.NewUpdateRestaurantTableCountHandler(repoRest, nil, nil)
 	updateKitchenUC := restaurantCmd.NewUpdateKitchenThresholdsHandler(repoRest, nil, nil)
+	updateLightningUC := restaurantCmd.NewUpdateLightningAddressHandler(repoRest, nil, nil)
+	updateTaxUC := restaurantCmd.NewUpdateTaxSettingsHandler(repoRest, nil, nil)
+	updateCashRoundUC := restaurantCmd.NewUpdateCashRoundingHandler(repoRest, nil, nil)
+	updateTipsUC := restaurantCmd.NewUpdateTipSettingsHandler(repoRest, nil, nil)
+	updateTipPoolingUC := restaurantCmd.NewUpdateTipPoolingHandler(repoRest, nil, nil)
 	generateQRUC := restaurantQuery.NewRestaurantTableQRImageHandler(qr.NewQRCodeService(), "http://localhost", repoRest, nil, nil)
 
 	membershipRepo := memory.NewMemoryMembershipRepository()
@@ -65,6 +72,11 @@ func TestAdminEndpoints(t *testing.T) {
 		menuQuery.PhotoSignerConfig{},
 		updateTableUC,
 		updateKitchenUC,
+		updateLightningUC,
+		updateTaxUC,
+		updateCashRoundUC,
+		updateTipsUC,
+		updateTipPoolingUC,
 		generateQRUC,
 		membershipRepo,
 		repoRest,
@@ -133,7 +145,7 @@ func TestAdminEndpoints(t *testing.T) {
 			RestaurantID: restID, Name: "Editor cat",
 		})
 		item, _ := createItemUC.Handle(context.Background(), menuCmd.CreateMenuItem{
-			RestaurantID: restID, CategoryID: cat.ID, Name: "Pork Belly Bao", Price: 6.50,
+			RestaurantID: restID, CategoryID: cat.ID, Name: "Pork Belly Bao", Price: 650,
 		})
 		req := httptest.NewRequest(http.MethodGet, "/admin/items/"+string(item.ID)+"/edit", nil)
 		rec := httptest.NewRecorder()
@@ -156,7 +168,7 @@ func TestAdminEndpoints(t *testing.T) {
 			RestaurantID: restID, Name: "Save cat",
 		})
 		item, _ := createItemUC.Handle(context.Background(), menuCmd.CreateMenuItem{
-			RestaurantID: restID, CategoryID: cat.ID, Name: "Save target", Price: 5.00,
+			RestaurantID: restID, CategoryID: cat.ID, Name: "Save target", Price: 500,
 		})
 
 		ogJSON := `[{"id":"g1","name":"Sauce","required":true,"min_selections":1,"max_selections":1,"default_option_id":"o1","options":[{"id":"o1","name":"Hoisin","price_delta":0},{"id":"o2","name":"Mayo","price_delta":0.5}]}]`
@@ -169,6 +181,7 @@ func TestAdminEndpoints(t *testing.T) {
 		form.Set("spice_level", "MEDIUM")
 		form.Set("schedule", "LUNCH")
 		form.Set("sku", "BAO-001")
+		form.Set("tax_class", "Takeaway")
 		form.Set("badges_csv", "Popular, New")
 		form.Set("is_vegan", "on")
 		form.Set("allow_special_instructions", "on")
@@ -193,6 +206,7 @@ func TestAdminEndpoints(t *testing.T) {
 		assert.Equal(t, "MEDIUM", saved.SpiceLevel)
 		assert.Equal(t, "LUNCH", saved.Schedule)
 		assert.Equal(t, "BAO-001", saved.SKU)
+		assert.Equal(t, tax.Class("takeaway"), saved.TaxClass)
 		assert.True(t, saved.IsVegan)
 		assert.True(t, saved.AllowSpecialInstructions)
 		assert.Equal(t, []string{"Popular", "New"}, saved.Badges)
@@ -208,7 +222,7 @@ func TestAdminEndpoints(t *testing.T) {
 			RestaurantID: restID, Name: "Invalid spice cat",
 		})
 		item, _ := createItemUC.Handle(context.Background(), menuCmd.CreateMenuItem{
-			RestaurantID: restID, CategoryID: cat.ID, Name: "Spice target", Price: 5.00,
+			RestaurantID: restID, CategoryID: cat.ID, Name: "Spice target", Price: 500,
 		})
 		form := url.Values{}
 		form.Set("name", "Spice target")
@@ -235,7 +249,7 @@ func TestAdminEndpoints(t *testing.T) {
 			RestaurantID: restID, Name: "Default cat",
 		})
 		item, _ := createItemUC.Handle(context.Background(), menuCmd.CreateMenuItem{
-			RestaurantID: restID, CategoryID: cat.ID, Name: "Default target", Price: 5.00,
+			RestaurantID: restID, CategoryID: cat.ID, Name: "Default target", Price: 500,
 		})
 		ogJSON := `[{"id":"g1","name":"Sauce","required":true,"min_selections":1,"max_selections":1,"default_option_id":"o99","options":[{"id":"o1","name":"Only","price_delta":0}]}]`
 		form := url.Values{}
@@ -262,7 +276,7 @@ func TestAdminEndpoints(t *testing.T) {
 			RestaurantID: restID, Name: "Empty groups cat",
 		})
 		item, _ := createItemUC.Handle(context.Background(), menuCmd.CreateMenuItem{
-			RestaurantID: restID, CategoryID: cat.ID, Name: "Empty target", Price: 5.00,
+			RestaurantID: restID, CategoryID: cat.ID, Name: "Empty target", Price: 500,
 		})
 
 		// First, set some groups.
@@ -296,4 +310,215 @@ func TestAdminEndpoints(t *testing.T) {
 		require.NoError(t, err)
 		assert.Empty(t, saved.OptionGroups, "groups should be cleared")
 	})
+
+	t.Run("POST /admin/payments/settings saves lightning address", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("lightningAddress", " Owner@Wallet.Example ")
+		req := httptest.NewRequest(http.MethodPost, "/admin/payments/settings", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+
+		assert.NoError(t, adminHandler.PostPaymentSettings(c))
+		assert.Contains(t, rec.Header().Get("Location"), "flash=payment_settings_saved")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, "owner@wallet.example", saved.LightningAddress)
+
+		req = httptest.NewRequest(http.MethodGet, "/admin/payments", nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		assert.NoError(t, adminHandler.GetPaymentSettings(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "owner@wallet.example")
+	})
+
+	t.Run("POST /admin/payments/settings rejects invalid address", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("lightningAddress", "not-an-address")
+		req := httptest.NewRequest(http.MethodPost, "/admin/payments/settings", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+
+		assert.NoError(t, adminHandler.PostPaymentSettings(c))
+		assert.Contains(t, rec.Header().Get("Location"), "flash=payment_settings_invalid")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, "owner@wallet.example", saved.LightningAddress)
+	})
+
+	t.Run("POST /admin/taxes/settings saves named taxes", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("mode", "inclusive")
+		form.Set("serviceCharge", "10")
+		form.Set("serviceChargeTaxable", "on")
+		form.Set("taxName0", "VAT")
+		form.Set("taxRate0", "7")
+		form.Set("taxName1", "Excise")
+		form.Set("taxRate1", "2.5")
+		form.Set("taxClasses1", "alcohol, Tobacco")
+		req := httptest.NewRequest(http.MethodPost, "/admin/taxes/settings", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+
+		assert.NoError(t, adminHandler.PostTaxSettings(c))
+		assert.Contains(t, rec.Header().Get("Location"), "flash=tax_settings_saved")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, tax.Config{
+			Mode: tax.ModeInclusive,
+			Taxes: []tax.Tax{
+				{Name: "VAT", Rate: 700},
+				{Name: "Excise", Rate: 250, Classes: []tax.Class{"alcohol", "tobacco"}},
+			},
+			ServiceChargeRate:    1000,
+			ServiceChargeTaxable: true,
+		}, saved.TaxSettings)
+
+		req = httptest.NewRequest(http.MethodGet, "/admin/taxes", nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		assert.NoError(t, adminHandler.GetTaxSettings(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `value="2.5"`)
+		assert.Contains(t, rec.Body.String(), "alcohol, tobacco")
+	})
+
+	t.Run("POST /admin/taxes/settings rejects a rate without a name", func(t *testing.T) {
+		form := url.Values{}
+		form.Set("taxRate0", "7")
+		req := httptest.NewRequest(http.MethodPost, "/admin/taxes/settings", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+
+		assert.NoError(t, adminHandler.PostTaxSettings(c))
+		assert.Contains(t, rec.Header().Get("Location"), "flash=tax_settings_invalid")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.True(t, saved.TaxSettings.Inclusive(), "settings unchanged")
+	})
+
+	t.Run("POST /admin/payments/cash-rounding saves the rounding step", func(t *testing.T) {
+		post := func(step string) string {
+			form := url.Values{}
+			form.Set("cashRounding", step)
+			req := httptest.NewRequest(http.MethodPost, "/admin/payments/cash-rounding", strings.NewReader(form.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(httpMiddleware.ContextRestaurantID, restID)
+			assert.NoError(t, adminHandler.PostCashRounding(c))
+			return rec.Header().Get("Location")
+		}
+
+		assert.Contains(t, post("0.05"), "flash=payment_settings_saved")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), saved.CashRounding)
+
+		assert.Contains(t, post("0.001"), "flash=cash_rounding_invalid")
+		saved, err = repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), saved.CashRounding, "step unchanged")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/payments", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		assert.NoError(t, adminHandler.GetPaymentSettings(c))
+		assert.Contains(t, rec.Body.String(), `value="0.05"`)
+	})
+
+	t.Run("POST /admin/payments/tips saves the tip presets", func(t *testing.T) {
+		post := func(form url.Values) string {
+			req := httptest.NewRequest(http.MethodPost, "/admin/payments/tips", strings.NewReader(form.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(httpMiddleware.ContextRestaurantID, restID)
+			assert.NoError(t, adminHandler.PostTipSettings(c))
+			return rec.Header().Get("Location")
+		}
+
+		loc := post(url.Values{"tipPresets": {"18, 22%, 25"}, "tipDefault": {"22"}, "tipCustom": {"on"}})
+		assert.Contains(t, loc, "flash=payment_settings_saved")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, []int{18, 22, 25}, saved.EffectiveTipSettings().Presets)
+		assert.Equal(t, 22, saved.EffectiveTipSettings().Default)
+		assert.True(t, saved.EffectiveTipSettings().Custom)
+
+		assert.Contains(t, post(url.Values{"tipPresets": {"18, 60"}}), "flash=tip_settings_invalid")
+		assert.Contains(t, post(url.Values{"tipPresets": {"18"}, "tipDefault": {"20"}}), "flash=tip_settings_invalid")
+		saved, err = repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Equal(t, []int{18, 22, 25}, saved.EffectiveTipSettings().Presets, "presets unchanged")
+
+		assert.Contains(t, post(url.Values{"tipsOff": {"on"}, "tipPresets": {"18"}}), "flash=payment_settings_saved")
+		saved, err = repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.False(t, saved.EffectiveTipSettings().Enabled())
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/payments", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		assert.NoError(t, adminHandler.GetPaymentSettings(c))
+		assert.Contains(t, rec.Body.String(), `name="tipsOff"`)
+	})
+
+	t.Run("POST /admin/payments/tip-pools saves the tip pools", func(t *testing.T) {
+		post := func(form url.Values) string {
+			req := httptest.NewRequest(http.MethodPost, "/admin/payments/tip-pools", strings.NewReader(form.Encode()))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set(httpMiddleware.ContextRestaurantID, restID)
+			assert.NoError(t, adminHandler.PostTipPooling(c))
+			return rec.Header().Get("Location")
+		}
+
+		loc := post(url.Values{
+			"poolName0": {"Front of house"}, "poolShare0": {"60"}, "poolRoles0": {"server", "owner"},
+			"poolName1": {"Kitchen"}, "poolShare1": {"40"}, "poolRoles1": {"kitchen_staff"},
+			"poolName2": {""}, "poolShare2": {""},
+			"tipSplit": {"equal"},
+		})
+		assert.Contains(t, loc, "flash=payment_settings_saved")
+		saved, err := repoRest.FindByID(restID)
+		require.NoError(t, err)
+		require.Len(t, saved.TipPooling.Pools, 2)
+		assert.Equal(t, "Kitchen", saved.TipPooling.PoolFor(common.RoleKitchenStaff))
+		assert.Equal(t, 60, saved.TipPooling.Pools[0].Share)
+		assert.Equal(t, tip.SplitEqual, saved.TipPooling.Split)
+
+		assert.Contains(t, post(url.Values{
+			"poolName0": {"Front of house"}, "poolShare0": {"60"}, "poolRoles0": {"server"},
+			"poolName1": {"Kitchen"}, "poolShare1": {"30"}, "poolRoles1": {"kitchen_staff"},
+		}), "flash=tip_pooling_invalid")
+		saved, err = repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.Len(t, saved.TipPooling.Pools, 2, "pools unchanged")
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/payments", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		assert.NoError(t, adminHandler.GetPaymentSettings(c))
+		assert.Contains(t, rec.Body.String(), `value="Front of house"`)
+
+		assert.Contains(t, post(url.Values{"poolName0": {""}}), "flash=payment_settings_saved")
+		saved, err = repoRest.FindByID(restID)
+		require.NoError(t, err)
+		assert.False(t, saved.TipPooling.Enabled(), "clearing every pool turns pooling off")
+	})
 }
@@ -21,10 +21,10 @@ func TestCartEndpoints(t *testing.T) {
 	// Setup
 	cartService := cart.NewCartService()
 	itemRepo := memory.NewMemoryMenuItemRepository()
-	item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 10.0)
+	item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
 	require.NoError(t, itemRepo.Save(item))
 
-	h := orderinghttp.NewCartHandler(cartService, itemRepo, nil, menuQuery.PhotoSignerConfig{})
+	h := orderinghttp.NewCartHandler(cartService, itemRepo, nil, nil, menuQuery.PhotoSignerConfig{})
 	e := echo.New()
 
 	t.Run("Add Item", func(t *testing.T) {
@@ -44,7 +44,7 @@ func TestCartEndpoints(t *testing.T) {
 		// Verify cart state
 		cart := cartService.GetCart("sess_1")
 		assert.Len(t, cart.Items, 1)
-		assert.Equal(t, 20.0, cart.Total)
+		assert.Equal(t, int64(2000), cart.Total)
 	})
 
 	t.Run("Add Item via Query Params", func(t *testing.T) {
@@ -61,7 +61,7 @@ func TestCartEndpoints(t *testing.T) {
 
 		cart := cartService.GetCart("sess_qp")
 		assert.Len(t, cart.Items, 1)
-		assert.Equal(t, 10.0, cart.Total)
+		assert.Equal(t, int64(1000), cart.Total)
 	})
 
 	t.Run("Remove Item", func(t *testing.T) {
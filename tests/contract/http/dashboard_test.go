@@ -32,10 +32,9 @@ func TestDashboardHandler(t *testing.T) {
 	_ = restaurantRepo.Save(r)
 
 	// Seed orders for stats
-	items := []order.OrderItem{{MenuItemID: "i1", Name: "Item 1", Quantity: 1, UnitPrice: 10.0, Subtotal: 10.0}}
+	items := []order.OrderItem{{MenuItemID: "i1", Name: "Item 1", Quantity: 1, UnitPrice: 1000, Subtotal: 1000}}
 	o1, _ := order.NewOrder("o1", "1001", "restaurant_1", "session_1", items, 1000, common.PaymentMethodTypeCash)
 	o1.PaymentStatus = common.PaymentStatusPaid
-	o1.FiatAmount = 10.0
 	_ = orderRepo.Save(o1)
 
 	// Use Cases
@@ -47,7 +46,7 @@ func TestDashboardHandler(t *testing.T) {
 	toggleOpenUC := restaurantCmd.NewToggleRestaurantOpenHandler(restaurantRepo, nil, nil)
 	pauseUC := restaurantCmd.NewPauseRestaurantHandler(restaurantRepo, nil, nil)
 
-	h := dashboardhttp.NewDashboardHandler(getStatsUC, getHistoryUC, getTopItemsUC, getStalledUC, getByHourUC, toggleOpenUC, pauseUC, restaurantRepo, orderRepo, nil, slog.Default())
+	h := dashboardhttp.NewDashboardHandler(getStatsUC, getHistoryUC, getTopItemsUC, getStalledUC, getByHourUC, toggleOpenUC, pauseUC, nil, restaurantRepo, orderRepo, nil, slog.Default())
 
 	e := echo.New()
 
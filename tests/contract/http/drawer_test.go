@@ -0,0 +1,133 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"bitmerchant/internal/auth/domain/membership"
+	"bitmerchant/internal/auth/domain/user"
+	"bitmerchant/internal/common"
+	httpMiddleware "bitmerchant/internal/common/http/middleware"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/shift"
+	paymenthttp "bitmerchant/internal/payment/ports/http"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrawerAndShiftEndpoints(t *testing.T) {
+	e := echo.New()
+
+	restRepo := memory.NewMemoryRestaurantRepository()
+	membershipRepo := memory.NewMemoryMembershipRepository()
+	userRepo := memory.NewMemoryUserRepository()
+	shifts := memory.NewMemoryShiftRepository()
+
+	restID := common.RestaurantID("restaurant_1")
+	rest, _ := restaurant.NewRestaurant(restID, "Drawer Test")
+	require.NoError(t, restRepo.Save(rest))
+	server, _ := user.NewUser("user-server", "Sam Server")
+	require.NoError(t, userRepo.Save(server))
+	require.NoError(t, membershipRepo.Save(&membership.Membership{ID: "m-1", UserID: server.ID, RestaurantID: restID, Role: common.RoleServer}))
+
+	currentUC := payQuery.NewCurrentShiftHandler(shifts, nil, nil)
+	reportUC := payQuery.NewShiftReportHandler(shifts, nil, nil)
+	drawer := paymenthttp.NewDrawerHandler(
+		currentUC,
+		reportUC,
+		payCmd.NewOpenShiftHandler(shifts, nil, nil),
+		payCmd.NewRecordDrawerMovementHandler(shifts, nil, nil),
+		payCmd.NewCloseShiftHandler(shifts, nil, nil),
+		restRepo,
+		membershipRepo,
+	)
+	shiftsHandler := paymenthttp.NewShiftsHandler(payQuery.NewShiftHistoryHandler(shifts, nil, nil), reportUC, restRepo, membershipRepo, userRepo)
+
+	post := func(t *testing.T, handler echo.HandlerFunc, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		c.Set(httpMiddleware.ContextAuthUser, server)
+		require.NoError(t, handler(c))
+		return rec
+	}
+
+	t.Run("open, pay out and close the drawer", func(t *testing.T) {
+		rec := post(t, drawer.PostOpen, url.Values{"float": {"100"}})
+		assert.Equal(t, http.StatusSeeOther, rec.Code)
+		assert.Equal(t, "/server/drawer", rec.Header().Get("Location"))
+
+		rec = post(t, drawer.PostOpen, url.Values{"float": {"100"}})
+		assert.Contains(t, rec.Header().Get("Location"), "error=")
+
+		open, err := shifts.FindOpenByStaff(restID, server.ID)
+		require.NoError(t, err)
+		require.NoError(t, open.AddCashSale("pay-1", money.New(3000, money.USD), server.ID, open.OpenedAt))
+		require.NoError(t, shifts.Update(open))
+
+		rec = post(t, drawer.PostMovement, url.Values{"kind": {"pay_out"}, "amount": {"10"}, "reason": {""}})
+		assert.Contains(t, rec.Header().Get("Location"), "error=")
+		rec = post(t, drawer.PostMovement, url.Values{"kind": {"pay_out"}, "amount": {"10"}, "reason": {"Bread"}})
+		assert.Equal(t, "/server/drawer", rec.Header().Get("Location"))
+
+		req := httptest.NewRequest(http.MethodGet, "/server/drawer", nil)
+		getRec := httptest.NewRecorder()
+		c := e.NewContext(req, getRec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		c.Set(httpMiddleware.ContextAuthUser, server)
+		require.NoError(t, drawer.GetDrawer(c))
+		assert.Equal(t, http.StatusOK, getRec.Code)
+		assert.Contains(t, getRec.Body.String(), "$120.00")
+
+		rec = post(t, drawer.PostClose, url.Values{"counted": {"118.00"}})
+		assert.Contains(t, rec.Header().Get("Location"), "/server/drawer?closed=")
+		closed, err := shifts.FindByID(open.ID)
+		require.NoError(t, err)
+		assert.Equal(t, shift.StatusClosed, closed.Status)
+		assert.Equal(t, int64(-200), closed.Variance())
+	})
+
+	t.Run("GET /dashboard/shifts lists history and report", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard/shifts?staff=user-server", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		require.NoError(t, shiftsHandler.GetShifts(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, "Sam Server")
+		assert.Contains(t, body, "Short $2.00")
+
+		all, _ := shifts.FindByRestaurant(restID)
+		require.Len(t, all, 1)
+		req = httptest.NewRequest(http.MethodGet, "/dashboard/shifts/"+string(all[0].ID), nil)
+		rec = httptest.NewRecorder()
+		c = e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		c.SetParamNames("id")
+		c.SetParamValues(string(all[0].ID))
+		require.NoError(t, shiftsHandler.GetShift(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Bread")
+
+		rec = httptest.NewRecorder()
+		c = e.NewContext(httptest.NewRequest(http.MethodGet, "/dashboard/shifts/missing", nil), rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		c.SetParamNames("id")
+		c.SetParamValues("missing")
+		require.NoError(t, shiftsHandler.GetShift(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
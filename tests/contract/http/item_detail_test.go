@@ -23,7 +23,7 @@ func newItemDetailContext(t *testing.T, item *menu.MenuItem) (*orderinghttp.Cart
 	itemRepo := memory.NewMemoryMenuItemRepository()
 	require.NoError(t, itemRepo.Save(item))
 
-	h := orderinghttp.NewCartHandler(cartService, itemRepo, nil, menuQuery.PhotoSignerConfig{})
+	h := orderinghttp.NewCartHandler(cartService, itemRepo, nil, nil, menuQuery.PhotoSignerConfig{})
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/menu/item/"+string(item.ID)+"?restaurantID="+string(item.RestaurantID), nil)
 	rec := httptest.NewRecorder()
@@ -36,7 +36,7 @@ func newItemDetailContext(t *testing.T, item *menu.MenuItem) (*orderinghttp.Cart
 }
 
 func TestItemDetail_RendersBadgesAllergensSpice(t *testing.T) {
-	item, _ := menu.NewMenuItem("i_detail_1", "c1", "r1", "Pork Belly Bao", 6.50)
+	item, _ := menu.NewMenuItem("i_detail_1", "c1", "r1", "Pork Belly Bao", 650)
 	require.NoError(t, item.SetBadges([]string{"Popular"}))
 	require.NoError(t, item.SetAllergens([]string{"Gluten", "Soy"}))
 	require.NoError(t, item.SetSpiceLevel(menu.SpiceLevelMedium))
@@ -54,7 +54,7 @@ func TestItemDetail_RendersBadgesAllergensSpice(t *testing.T) {
 }
 
 func TestItemDetail_HidesSpiceChipWhenEmpty(t *testing.T) {
-	item, _ := menu.NewMenuItem("i_detail_2", "c1", "r1", "Plain Rice", 2.50)
+	item, _ := menu.NewMenuItem("i_detail_2", "c1", "r1", "Plain Rice", 250)
 	// No SpiceLevel set
 	h, _, rec, c := newItemDetailContext(t, item)
 	assert.NoError(t, h.GetItemDetail(c))
@@ -65,7 +65,7 @@ func TestItemDetail_HidesSpiceChipWhenEmpty(t *testing.T) {
 }
 
 func TestItemDetail_GatesSpecialInstructionsTextarea(t *testing.T) {
-	item, _ := menu.NewMenuItem("i_detail_3", "c1", "r1", "No-notes item", 4.00)
+	item, _ := menu.NewMenuItem("i_detail_3", "c1", "r1", "No-notes item", 400)
 	item.SetAllowSpecialInstructions(false)
 
 	h, _, rec, c := newItemDetailContext(t, item)
@@ -76,7 +76,7 @@ func TestItemDetail_GatesSpecialInstructionsTextarea(t *testing.T) {
 }
 
 func TestItemDetail_AllowsSpecialInstructionsByDefault(t *testing.T) {
-	item, _ := menu.NewMenuItem("i_detail_4", "c1", "r1", "With-notes item", 4.00)
+	item, _ := menu.NewMenuItem("i_detail_4", "c1", "r1", "With-notes item", 400)
 	// NewMenuItem defaults AllowSpecialInstructions = true.
 
 	h, _, rec, c := newItemDetailContext(t, item)
@@ -2,18 +2,23 @@ package http_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
 
+	"bitmerchant/internal/auth/domain/user"
 	httpMiddleware "bitmerchant/internal/common/http/middleware"
 	kitchenCmd "bitmerchant/internal/ordering/app/command"
 	kitchenQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
 	orderinghttp "bitmerchant/internal/ordering/ports/http"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
 	"context"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -61,25 +66,8 @@ func (m *mockKitchenOrderRepo) FindBySessionID(sessionID string) ([]*order.Order
 func (m *mockKitchenOrderRepo) NextOrderNumber(rid common.RestaurantID) (int, error) {
 	return 1, nil
 }
-func (m *mockKitchenOrderRepo) UpdateItemPrepComplete(orderID common.OrderID, itemID common.OrderItemID, complete bool) error {
-	for _, o := range m.orders {
-		if o.ID == orderID {
-			for i := range o.Items {
-				if o.Items[i].ID == itemID {
-					o.Items[i].PrepComplete = complete
-					return nil
-				}
-			}
-		}
-	}
-	return nil
-}
-
-// Mock EventBus
-type mockKitchenEventBus struct{}
-
-func (m *mockKitchenEventBus) Publish(ctx context.Context, topic string, event interface{}) error {
-	return nil
+func (m *mockKitchenOrderRepo) UpdateItemPrepComplete(o *order.Order, itemID common.OrderItemID) error {
+	return m.Update(o)
 }
 
 func TestKitchenEndpoints(t *testing.T) {
@@ -102,20 +90,38 @@ func TestKitchenEndpoints(t *testing.T) {
 			},
 		},
 	}
-	mockBus := &mockKitchenEventBus{}
 
 	// Setup Use Cases
 	getOrdersUC := kitchenQuery.NewActiveKitchenOrdersHandler(mockRepo, nil, nil)
-	markPaidUC := kitchenCmd.NewMarkOrderPaidHandler(mockRepo, mockBus, nil, nil)
-	markPreparingUC := kitchenCmd.NewMarkOrderPreparingHandler(mockRepo, mockBus, nil, nil)
-	markReadyUC := kitchenCmd.NewMarkOrderReadyHandler(mockRepo, mockBus, nil, nil)
-	markCompletedUC := kitchenCmd.NewMarkOrderCompletedHandler(mockRepo, mockBus, nil, nil)
-	toggleItemPrepUC := kitchenCmd.NewToggleOrderItemPrepHandler(mockRepo, mockBus, nil, nil)
+	paymentRepo := payAdapters.NewMemoryPaymentRepository()
+	recordPaymentUC := payCmd.NewRecordPaymentHandler(paymentRepo, nil, nil, nil)
+	markPaidUC := kitchenCmd.NewMarkOrderPaidHandler(mockRepo, kitchenCmd.PaymentRecorderFunc(func(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (kitchenCmd.SettledPayment, error) {
+		p, err := recordPaymentUC.Handle(ctx, payCmd.RecordPayment{
+			OrderID: o.ID, RestaurantID: o.RestaurantID, Method: method,
+			Amount: o.Total(), Tendered: tendered, CollectedBy: collectedBy,
+		})
+		if err != nil {
+			return kitchenCmd.SettledPayment{}, err
+		}
+		return kitchenCmd.SettledPayment{PaymentID: p.ID, FXRate: p.FXRate}, nil
+	}), nil, nil)
+	markPreparingUC := kitchenCmd.NewMarkOrderPreparingHandler(mockRepo, nil, nil)
+	markReadyUC := kitchenCmd.NewMarkOrderReadyHandler(mockRepo, nil, nil)
+	markCompletedUC := kitchenCmd.NewMarkOrderCompletedHandler(mockRepo, nil, nil)
+	toggleItemPrepUC := kitchenCmd.NewToggleOrderItemPrepHandler(mockRepo, nil, nil)
 	getUnpaidServerUC := kitchenQuery.NewUnpaidServerOrdersHandler(mockRepo, nil, nil)
+	refundUC := payCmd.NewRefundPaymentHandler(paymentRepo, nil, nil, nil)
+	voidUC := payCmd.NewVoidPaymentHandler(paymentRepo, nil, nil)
+	cancelUC := kitchenCmd.NewCancelOrderHandler(mockRepo, kitchenCmd.PaymentCancellerFunc(func(ctx context.Context, o *order.Order, refund bool, reason string, by common.UserID) error {
+		if refund {
+			return refundUC.Handle(ctx, payCmd.RefundPayment{OrderID: o.ID, Reason: reason, RefundedBy: by})
+		}
+		return voidUC.Handle(ctx, payCmd.VoidPayment{OrderID: o.ID, Reason: reason})
+	}), nil, nil)
 
 	// Setup Handler
-	h := orderinghttp.NewKitchenHandler(getOrdersUC, markPaidUC, markPreparingUC, markReadyUC, markCompletedUC, toggleItemPrepUC, nil, nil, "")
-	srv := orderinghttp.NewServerHandler(getUnpaidServerUC, markPaidUC, nil, nil)
+	h := orderinghttp.NewKitchenHandler(getOrdersUC, markPaidUC, markPreparingUC, markReadyUC, markCompletedUC, toggleItemPrepUC, cancelUC, nil, nil, "", nil)
+	srv := orderinghttp.NewServerHandler(getUnpaidServerUC, markPaidUC, cancelUC, nil, nil, nil, nil, nil)
 
 	// Routes
 	e.GET("/kitchen", h.GetKitchen)
@@ -123,6 +129,7 @@ func TestKitchenEndpoints(t *testing.T) {
 	e.POST("/kitchen/order/:id/mark-ready", h.MarkReady)
 	e.POST("/kitchen/order/:id/mark-completed", h.MarkCompleted)
 	e.POST("/server/order/:id/mark-paid", srv.MarkPaid)
+	e.POST("/server/order/:id/cancel", srv.CancelOrder)
 
 	t.Run("GET /kitchen returns orders", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/kitchen", nil)
@@ -135,6 +142,36 @@ func TestKitchenEndpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("POST /server/order/:id/mark-paid rejects short tender", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/server/order/order-1/mark-paid", strings.NewReader("tendered=5"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/server/order/:id/mark-paid")
+		c.SetParamNames("id")
+		c.SetParamValues("order-1")
+
+		if assert.NoError(t, srv.MarkPaid(c)) {
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			order, _ := mockRepo.FindByID("order-1")
+			assert.Equal(t, common.PaymentStatusPending, order.PaymentStatus)
+		}
+	})
+
+	t.Run("POST /server/order/:id/mark-paid rejects invalid tender", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/server/order/order-1/mark-paid", strings.NewReader("tendered=lots"))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/server/order/:id/mark-paid")
+		c.SetParamNames("id")
+		c.SetParamValues("order-1")
+
+		if assert.NoError(t, srv.MarkPaid(c)) {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		}
+	})
+
 	t.Run("POST /server/order/:id/mark-paid updates status", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPost, "/server/order/order-1/mark-paid", nil)
 		rec := httptest.NewRecorder()
@@ -148,6 +185,182 @@ func TestKitchenEndpoints(t *testing.T) {
 			// Verify repo update
 			order, _ := mockRepo.FindByID("order-1")
 			assert.Equal(t, common.PaymentStatusPaid, order.PaymentStatus)
+
+			ledger, err := paymentRepo.FindByOrderID("order-1")
+			if assert.NoError(t, err) {
+				assert.Equal(t, common.PaymentStatusPaid, ledger.Status)
+				assert.Equal(t, int64(1000), ledger.TenderedAmount)
+				assert.Zero(t, ledger.ChangeGiven)
+			}
+		}
+	})
+
+	t.Run("POST /server/order/:id/cancel requires a note for other", func(t *testing.T) {
+		rec := postCancel(e, srv.CancelOrder, "order-1", "reason=other")
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		order, _ := mockRepo.FindByID("order-1")
+		assert.False(t, order.IsCancelled())
+	})
+
+	t.Run("POST /server/order/:id/cancel refunds a paid order", func(t *testing.T) {
+		rec := postCancel(e, srv.CancelOrder, "order-1", "reason=kitchen_error")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		order, _ := mockRepo.FindByID("order-1")
+		assert.Equal(t, common.FulfillmentStatusCancelled, order.FulfillmentStatus)
+		assert.Equal(t, common.PaymentStatusRefunded, order.PaymentStatus)
+		assert.Equal(t, common.UserID("staff-1"), order.CancelledBy)
+
+		ledger, err := paymentRepo.FindByOrderID("order-1")
+		if assert.NoError(t, err) {
+			assert.Equal(t, common.PaymentStatusRefunded, ledger.Status)
+		}
+	})
+
+	t.Run("POST /server/order/:id/cancel rejects a cancelled order", func(t *testing.T) {
+		rec := postCancel(e, srv.CancelOrder, "order-1", "reason=duplicate")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestServerSplitBillEndpoints(t *testing.T) {
+	e := echo.New()
+	mockRepo := &mockKitchenOrderRepo{
+		orders: []*order.Order{
+			{
+				ID:                "order-2",
+				OrderNumber:       "102",
+				RestaurantID:      "rest-1",
+				PaymentMethod:     common.PaymentMethodTypeCash,
+				PaymentStatus:     common.PaymentStatusPending,
+				FulfillmentStatus: common.FulfillmentStatusPaid,
+				TotalAmount:       3000,
+				Items: []order.OrderItem{
+					{ID: "item-1", MenuItemID: "burger-1", Quantity: 1, Subtotal: 2000},
+					{ID: "item-2", MenuItemID: "fries-1", Quantity: 1, Subtotal: 1000},
+				},
+				CreatedAt: time.Now(),
+			},
+		},
+	}
+	paymentRepo := payAdapters.NewMemoryPaymentRepository()
+	recordPaymentUC := payCmd.NewRecordPaymentHandler(paymentRepo, nil, nil, nil)
+	refundUC := payCmd.NewRefundPaymentHandler(paymentRepo, nil, nil, nil)
+	voidUC := payCmd.NewVoidPaymentHandler(paymentRepo, nil, nil)
+
+	markPaidUC := kitchenCmd.NewMarkOrderPaidHandler(mockRepo, kitchenCmd.PaymentRecorderFunc(func(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (kitchenCmd.SettledPayment, error) {
+		return kitchenCmd.SettledPayment{}, nil
+	}), nil, nil)
+	cancelUC := kitchenCmd.NewCancelOrderHandler(mockRepo, kitchenCmd.PaymentCancellerFunc(func(ctx context.Context, o *order.Order, refund bool, reason string, by common.UserID) error {
+		if refund {
+			return refundUC.Handle(ctx, payCmd.RefundPayment{OrderID: o.ID, Reason: reason, RefundedBy: by})
 		}
+		return voidUC.Handle(ctx, payCmd.VoidPayment{OrderID: o.ID, Reason: reason})
+	}), nil, nil)
+	splitUC := kitchenCmd.NewSplitBillHandler(mockRepo, kitchenCmd.OpenPaymentVoiderFunc(func(ctx context.Context, o *order.Order, reason string) error {
+		return voidUC.Handle(ctx, payCmd.VoidPayment{OrderID: o.ID, Reason: reason, KeepSettled: true})
+	}), nil, nil)
+	payPartUC := kitchenCmd.NewPayBillPartHandler(mockRepo, kitchenCmd.BillPartRecorderFunc(func(ctx context.Context, o *order.Order, part order.BillPart, method common.PaymentMethodType, tendered money.Money, by common.UserID) (kitchenCmd.SettledPayment, error) {
+		p, err := recordPaymentUC.Handle(ctx, payCmd.RecordPayment{
+			OrderID: o.ID, RestaurantID: o.RestaurantID, BillPartID: part.ID, Method: method,
+			Amount: o.PartAmount(part), Tendered: tendered, CollectedBy: by,
+		})
+		if err != nil {
+			return kitchenCmd.SettledPayment{}, err
+		}
+		return kitchenCmd.SettledPayment{PaymentID: p.ID, FXRate: p.FXRate}, nil
+	}), nil, nil)
+	srv := orderinghttp.NewServerHandler(nil, markPaidUC, cancelUC, splitUC, payPartUC, nil, nil, nil)
+
+	t.Run("POST /server/order/:id/split rejects a one-way split", func(t *testing.T) {
+		rec := postServerForm(e, srv.SplitBill, "/server/order/:id/split", []string{"id"}, []string{"order-2"}, "mode=even&ways=1")
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		o, _ := mockRepo.FindByID("order-2")
+		assert.False(t, o.IsSplit())
 	})
+
+	t.Run("POST /server/order/:id/split divides the bill evenly", func(t *testing.T) {
+		rec := postServerForm(e, srv.SplitBill, "/server/order/:id/split", []string{"id"}, []string{"order-2"}, "mode=even&ways=3")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		o, _ := mockRepo.FindByID("order-2")
+		if assert.Len(t, o.BillParts, 3) {
+			for _, p := range o.BillParts {
+				assert.Equal(t, int64(1000), p.Amount)
+			}
+		}
+	})
+
+	t.Run("POST /server/order/:id/mark-paid is refused once split", func(t *testing.T) {
+		rec := postServerForm(e, srv.MarkPaid, "/server/order/:id/mark-paid", []string{"id"}, []string{"order-2"}, "")
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("POST /server/order/:id/parts/:partId/pay settles parts until the bill is covered", func(t *testing.T) {
+		o, _ := mockRepo.FindByID("order-2")
+		parts := append([]order.BillPart(nil), o.BillParts...)
+
+		rec := postServerForm(e, srv.PayBillPart, "/server/order/:id/parts/:partId/pay", []string{"id", "partId"}, []string{"order-2", string(parts[0].ID)}, "tendered=20")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		o, _ = mockRepo.FindByID("order-2")
+		assert.Equal(t, common.PaymentStatusPartiallyPaid, o.PaymentStatus)
+		assert.Equal(t, int64(2000), o.Outstanding().Amount)
+
+		charges, err := paymentRepo.FindChargesByOrderID("order-2")
+		if assert.NoError(t, err) && assert.Len(t, charges, 1) {
+			assert.Equal(t, parts[0].ID, charges[0].BillPartID)
+			assert.Equal(t, int64(1000), charges[0].ChangeGiven)
+		}
+
+		for _, p := range parts[1:] {
+			rec = postServerForm(e, srv.PayBillPart, "/server/order/:id/parts/:partId/pay", []string{"id", "partId"}, []string{"order-2", string(p.ID)}, "")
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+		o, _ = mockRepo.FindByID("order-2")
+		assert.Equal(t, common.PaymentStatusPaid, o.PaymentStatus)
+		assert.True(t, o.Outstanding().IsZero())
+	})
+
+	t.Run("POST /server/order/:id/parts/:partId/pay rejects an unknown part", func(t *testing.T) {
+		rec := postServerForm(e, srv.PayBillPart, "/server/order/:id/parts/:partId/pay", []string{"id", "partId"}, []string{"order-2", "part-missing"}, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("POST /server/order/:id/cancel refunds every part", func(t *testing.T) {
+		rec := postCancel(e, srv.CancelOrder, "order-2", "reason=customer_request")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		charges, err := paymentRepo.FindChargesByOrderID("order-2")
+		if assert.NoError(t, err) && assert.Len(t, charges, 3) {
+			for _, c := range charges {
+				assert.Equal(t, common.PaymentStatusRefunded, c.Status)
+			}
+		}
+	})
+}
+
+func postServerForm(e *echo.Echo, handler echo.HandlerFunc, path string, names, values []string, form string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath(path)
+	c.SetParamNames(names...)
+	c.SetParamValues(values...)
+	c.Set(httpMiddleware.ContextRestaurantID, common.RestaurantID("rest-1"))
+	c.Set(httpMiddleware.ContextAuthUser, &user.User{ID: "staff-1"})
+	_ = handler(c)
+	return rec
+}
+
+func postCancel(e *echo.Echo, handler echo.HandlerFunc, orderID, form string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/server/order/"+orderID+"/cancel", strings.NewReader(form))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/server/order/:id/cancel")
+	c.SetParamNames("id")
+	c.SetParamValues(orderID)
+	c.Set(httpMiddleware.ContextRestaurantID, common.RestaurantID("rest-1"))
+	c.Set(httpMiddleware.ContextAuthUser, &user.User{ID: "staff-1"})
+	_ = handler(c)
+	return rec
 }
@@ -0,0 +1,110 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/qr"
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
+	orderinghttp "bitmerchant/internal/ordering/ports/http"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLightningPayEndpoints(t *testing.T) {
+	orderRepo := memory.NewMemoryOrderRepository()
+	paymentRepo := memory.NewMemoryPaymentRepository()
+	node := payAdapters.NewFakeLightningNode()
+	method := payAdapters.NewLightningPaymentMethod(node, money.StaticConverter{PerBTC: map[string]float64{"USD": 50000}}, paymentRepo, 0)
+	restRepo := memory.NewMemoryRestaurantRepository()
+	rest, rerr := restaurant.NewRestaurant("restaurant_1", "Cafe")
+	require.NoError(t, rerr)
+	require.NoError(t, restRepo.Save(rest))
+
+	h := orderinghttp.NewLightningPayHandler(
+		orderQuery.NewCustomerOrderByLookupHandler(orderRepo, nil, nil),
+		payCmd.NewRequestLightningInvoiceHandler(paymentRepo, nil, method, nil, nil),
+		orderQuery.NewLateTipLookup(restRepo, true),
+		qr.NewQRCodeService(),
+	)
+	e := echo.New()
+
+	item, _ := order.NewOrderItem("oi1", "o-ln", "mi1", "Burger", 1, 1000)
+	o, err := order.NewOrder("o-ln", "4242", "restaurant_1", "session_1", []order.OrderItem{*item}, 1000, common.PaymentMethodTypeLightning)
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(o))
+
+	newCtx := func(path string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("sessionID", "session_1")
+		c.SetParamNames("orderNumber")
+		c.SetParamValues("4242")
+		return c, rec
+	}
+
+	t.Run("Pay page shows invoice", func(t *testing.T) {
+		c, rec := newCtx("/order/4242/pay")
+		require.NoError(t, h.GetPay(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		p, err := paymentRepo.FindByOrderID("o-ln")
+		require.NoError(t, err)
+		assert.Contains(t, rec.Body.String(), p.Invoice)
+		assert.Contains(t, rec.Body.String(), "/order/4242/pay/qr.png")
+	})
+
+	t.Run("QR is a PNG", func(t *testing.T) {
+		c, rec := newCtx("/order/4242/pay/qr.png")
+		require.NoError(t, h.GetPayQR(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("Paid order redirects to status", func(t *testing.T) {
+		o.MarkPaid()
+		require.NoError(t, orderRepo.Update(o))
+		c, rec := newCtx("/order/4242/pay")
+		require.NoError(t, h.GetPay(c))
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/order/4242", rec.Header().Get("Location"))
+	})
+
+	t.Run("Served order takes a tip", func(t *testing.T) {
+		c, rec := newCtx("/order/4242/pay?tip=1.50")
+		require.NoError(t, h.GetPay(c))
+		assert.Equal(t, http.StatusFound, rec.Code, "not served yet")
+
+		require.NoError(t, o.StartPreparing())
+		require.NoError(t, o.MarkReady())
+		require.NoError(t, orderRepo.Update(o))
+
+		c, _ = newCtx("/order/4242/pay?tip=1.23")
+		err := h.GetPay(c)
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Code, "1.23 is no preset and custom tips are off")
+
+		c, rec = newCtx("/order/4242/pay?tip=1.50")
+		require.NoError(t, h.GetPay(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Tip · $1.50")
+		assert.Contains(t, rec.Body.String(), "/order/4242/pay/qr.png?tip=1.50")
+
+		charges, err := paymentRepo.FindChargesByOrderID("o-ln")
+		require.NoError(t, err)
+		tipPayment := charges[len(charges)-1]
+		assert.True(t, tipPayment.IsTip())
+		assert.Equal(t, money.New(150, money.USD), tipPayment.ValueAtSale())
+	})
+}
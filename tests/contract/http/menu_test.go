@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/infrastructure/repositories/memory"
 	menuQuery "bitmerchant/internal/menu/app/query"
 	"bitmerchant/internal/menu/domain/menu"
@@ -35,7 +36,7 @@ func TestGetMenu(t *testing.T) {
 	visitRepo := memory.NewMemorySessionRestaurantVisitRepository()
 	recordVisitUC := placesCmd.NewRecordMenuVisitHandler(restRepo, visitRepo, nil, nil)
 	uc := menuQuery.NewMenuForCustomerHandler(catRepo, itemRepo, restRepo, nil, menuQuery.PhotoSignerConfig{}, nil, nil)
-	h := menuhttp.NewMenuHandler(uc, cartService, recordVisitUC, nil)
+	h := menuhttp.NewMenuHandler(uc, cartService, recordVisitUC, nil, nil)
 
 	// Setup Echo
 	e := echo.New()
@@ -56,6 +57,37 @@ func TestGetMenu(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "Menu")
 }
 
+func TestGetMenu_ShowsSatsEquivalent(t *testing.T) {
+	catRepo := memory.NewMemoryMenuCategoryRepository()
+	itemRepo := memory.NewMemoryMenuItemRepository()
+	restRepo := memory.NewMemoryRestaurantRepository()
+
+	rest, _ := restaurant.NewRestaurant("r1", "Test Restaurant")
+	require.NoError(t, restRepo.Save(rest))
+	cat, _ := menu.NewMenuCategory("c1", "r1", "Starters", 1)
+	require.NoError(t, catRepo.Save(cat))
+	item, _ := menu.NewMenuItem("i1", "c1", "r1", "Dumplings", 1000)
+	require.NoError(t, itemRepo.Save(item))
+
+	uc := menuQuery.NewMenuForCustomerHandler(catRepo, itemRepo, restRepo, nil, menuQuery.PhotoSignerConfig{}, nil, nil)
+	rates := money.NewRateConverter(money.RateConverterOptions{}, money.FixtureRates{"USD": 50000})
+
+	render := func(converter money.Converter) string {
+		h := menuhttp.NewMenuHandler(uc, cart.NewCartService(), nil, nil, converter)
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/menu?restaurantID=r1", nil), rec)
+		c.Set("sessionID", "session-sats")
+		require.NoError(t, h.GetMenu(c))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	body := render(rates)
+	assert.Contains(t, body, "$10.00")
+	assert.Contains(t, body, "≈ 20,000 sats")
+	assert.NotContains(t, render(money.NoopConverter{}), "sats")
+}
+
 func TestGetMenu_MissingRestaurantIDRedirectsToEntry(t *testing.T) {
 	catRepo := memory.NewMemoryMenuCategoryRepository()
 	itemRepo := memory.NewMemoryMenuItemRepository()
@@ -65,7 +97,7 @@ func TestGetMenu_MissingRestaurantIDRedirectsToEntry(t *testing.T) {
 	visitRepo := memory.NewMemorySessionRestaurantVisitRepository()
 	recordVisitUC := placesCmd.NewRecordMenuVisitHandler(restRepo, visitRepo, nil, nil)
 	uc := menuQuery.NewMenuForCustomerHandler(catRepo, itemRepo, restRepo, nil, menuQuery.PhotoSignerConfig{}, nil, nil)
-	h := menuhttp.NewMenuHandler(uc, cartService, recordVisitUC, nil)
+	h := menuhttp.NewMenuHandler(uc, cartService, recordVisitUC, nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/menu", nil)
@@ -89,7 +121,7 @@ func TestGetMenu_UnknownRestaurantIDRedirectsToEntry(t *testing.T) {
 	visitRepo := memory.NewMemorySessionRestaurantVisitRepository()
 	recordVisitUC := placesCmd.NewRecordMenuVisitHandler(restRepo, visitRepo, nil, nil)
 	uc := menuQuery.NewMenuForCustomerHandler(catRepo, itemRepo, restRepo, nil, menuQuery.PhotoSignerConfig{}, nil, nil)
-	h := menuhttp.NewMenuHandler(uc, cartService, recordVisitUC, nil)
+	h := menuhttp.NewMenuHandler(uc, cartService, recordVisitUC, nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/menu?restaurantID=unknown", nil)
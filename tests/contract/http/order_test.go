@@ -2,8 +2,8 @@ package http_test
 
 import (
 	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
 
-	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
 	"bitmerchant/internal/infrastructure/payment/cash"
 	"bitmerchant/internal/infrastructure/repositories/memory"
@@ -29,7 +29,6 @@ func TestOrderEndpoints(t *testing.T) {
 	orderRepo := memory.NewMemoryOrderRepository()
 	paymentRepo := memory.NewMemoryPaymentRepository()
 	restRepo := memory.NewMemoryRestaurantRepository()
-	eventBus := events.NewEventBus()
 	paymentMethod := cash.NewCashPaymentMethod()
 	logger := logging.NewLogger()
 
@@ -39,20 +38,20 @@ func TestOrderEndpoints(t *testing.T) {
 
 	_ = paymentRepo
 	_ = paymentMethod
-	createUC := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, eventBus, logger.Logger, nil)
+	createUC := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logger.Logger, nil)
 	getCustomerOrderUC := orderQuery.NewCustomerOrderByLookupHandler(orderRepo, nil, nil)
 	getCustomerOrdersUC := orderQuery.NewCustomerOrdersForSessionHandler(orderRepo, nil, nil)
 	cartService := cart.NewCartService()
-	requestServerUC := orderCmd.NewRequestServerHandler(orderRepo, eventBus, logger.Logger, nil)
-	requestBillUC := orderCmd.NewRequestBillHandler(orderRepo, eventBus, logger.Logger, nil)
+	requestServerUC := orderCmd.NewRequestServerHandler(orderRepo, logger.Logger, nil)
+	requestBillUC := orderCmd.NewRequestBillHandler(orderRepo, logger.Logger, nil)
 
-	h := orderinghttp.NewOrderHandler(createUC, getCustomerOrderUC, getCustomerOrdersUC, requestServerUC, requestBillUC, orderRepo, restRepo, cartService, "")
+	h := orderinghttp.NewOrderHandler(createUC, getCustomerOrderUC, getCustomerOrdersUC, requestServerUC, requestBillUC, orderRepo, restRepo, cartService, "", false, nil, nil, commonhttp.NewSSEHandler())
 
 	e := echo.New()
 
 	t.Run("Get Order", func(t *testing.T) {
 		// Setup existing order
-		item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Burger", 1, 10.0)
+		item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Burger", 1, 1000)
 		existingOrder, _ := order.NewOrder(
 			"o1",
 			"1234",
@@ -62,7 +61,6 @@ func TestOrderEndpoints(t *testing.T) {
 			1000,
 			common.PaymentMethodTypeCash,
 		)
-		existingOrder.FiatAmount = 10.0
 		require.NoError(t, orderRepo.Save(existingOrder))
 
 		req := httptest.NewRequest(http.MethodGet, "/order/1234", nil)
@@ -0,0 +1,189 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	commonhttp "bitmerchant/internal/common/http"
+	httpMiddleware "bitmerchant/internal/common/http/middleware"
+	"bitmerchant/internal/infrastructure/logging"
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	orderevent "bitmerchant/internal/ordering/app/event"
+	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
+	orderinghttp "bitmerchant/internal/ordering/ports/http"
+	ordersse "bitmerchant/internal/ordering/ports/sse"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamRecorder collects what an SSE stream writes.
+type streamRecorder struct {
+	header http.Header
+	writes chan string
+}
+
+func newStreamRecorder() *streamRecorder {
+	return &streamRecorder{header: make(http.Header), writes: make(chan string, 16)}
+}
+
+func (w *streamRecorder) Header() http.Header { return w.header }
+func (w *streamRecorder) WriteHeader(int)     {}
+func (w *streamRecorder) Flush()              {}
+func (w *streamRecorder) Write(p []byte) (int, error) {
+	w.writes <- string(p)
+	return len(p), nil
+}
+
+// next returns the next message, skipping the cursor a stream opens with.
+func (w *streamRecorder) next(t *testing.T) string {
+	t.Helper()
+	for {
+		select {
+		case msg := <-w.writes:
+			if strings.HasPrefix(msg, ": connected") {
+				continue
+			}
+			return msg
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for an SSE message")
+			return ""
+		}
+	}
+}
+
+func (w *streamRecorder) assertQuiet(t *testing.T) {
+	t.Helper()
+	deadline := time.After(100 * time.Millisecond)
+	for {
+		select {
+		case msg := <-w.writes:
+			if !strings.HasPrefix(msg, ": connected") {
+				t.Fatalf("unexpected SSE message: %s", msg)
+			}
+		case <-deadline:
+			return
+		}
+	}
+}
+
+func TestSSETopicsAreScopedByRestaurant(t *testing.T) {
+	e := echo.New()
+	logger := logging.NewLogger()
+	orderRepo := memory.NewMemoryOrderRepository()
+	hub := commonhttp.NewSSEHandler()
+
+	// Both restaurants hand out order number 1001 to the same guest session.
+	mkOrder := func(id common.OrderID, restID common.RestaurantID) *order.Order {
+		item, _ := order.NewOrderItem(common.OrderItemID("oi-"+string(id)), id, "mi1", "Burger", 1, 1000)
+		o, err := order.NewOrder(id, "1001", restID, "session_1", []order.OrderItem{*item}, 1000, common.PaymentMethodTypeCash)
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.Save(o))
+		return o
+	}
+	mine := mkOrder("o-r1", "restaurant_1")
+	theirs := mkOrder("o-r2", "restaurant_2")
+
+	open := func(handler echo.HandlerFunc, setup func(echo.Context)) (*streamRecorder, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		rec := newStreamRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), rec)
+		setup(c)
+		go func() { _ = handler(c) }()
+		return rec, cancel
+	}
+	atRestaurant := func(id common.RestaurantID) func(echo.Context) {
+		return func(c echo.Context) { c.Set(httpMiddleware.ContextRestaurantID, id) }
+	}
+
+	kitchen := orderinghttp.NewKitchenHandler(orderQuery.NewActiveKitchenOrdersHandler(orderRepo, nil, nil), nil, nil, nil, nil, nil, nil, nil, nil, "", hub)
+	server := orderinghttp.NewServerHandler(orderQuery.NewUnpaidServerOrdersHandler(orderRepo, nil, nil), nil, nil, nil, nil, nil, nil, hub)
+
+	kitchen1, stop := open(kitchen.Stream, atRestaurant("restaurant_1"))
+	defer stop()
+	kitchen2, stop := open(kitchen.Stream, atRestaurant("restaurant_2"))
+	defer stop()
+	server2, stop := open(server.Stream, atRestaurant("restaurant_2"))
+	defer stop()
+
+	orders := orderinghttp.NewOrderHandler(nil, orderQuery.NewCustomerOrderByLookupHandler(orderRepo, nil, nil), nil, nil, nil, orderRepo, nil, nil, "", false, nil, nil, hub)
+	status, stop := open(orders.StatusStream, func(c echo.Context) {
+		c.Set(httpMiddleware.ContextRestaurantID, common.RestaurantID("restaurant_1"))
+		c.Set("sessionID", "session_1")
+		c.SetParamNames("orderNumber")
+		c.SetParamValues("1001")
+	})
+	defer stop()
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, ordersse.NewOrderCreatedHandler(logger, hub, orderRepo).Handle(context.Background(), orderevent.OrderCreated{OrderID: mine.ID, RestaurantID: mine.RestaurantID}))
+	assert.Contains(t, kitchen1.next(t), "#orders-list")
+	kitchen2.assertQuiet(t)
+	server2.assertQuiet(t)
+
+	hub.Broadcast(commonhttp.OrderTopic(theirs.RestaurantID, theirs.ID), []byte("theirs"))
+	status.assertQuiet(t)
+	hub.Broadcast(commonhttp.OrderTopic(mine.RestaurantID, mine.ID), []byte("mine"))
+	assert.Contains(t, status.next(t), "mine")
+
+	t.Run("status stream only serves the session's own order", func(t *testing.T) {
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/order/1001/stream", nil), httptest.NewRecorder())
+		c.Set("sessionID", "someone_else")
+		c.SetParamNames("orderNumber")
+		c.SetParamValues("1001")
+		var he *echo.HTTPError
+		require.ErrorAs(t, orders.StatusStream(c), &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+	})
+
+	t.Run("boards need a restaurant", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/kitchen/stream", nil), rec)
+		require.NoError(t, kitchen.Stream(c))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestBoardStreamsResyncWithSnapshot(t *testing.T) {
+	e := echo.New()
+	orderRepo := memory.NewMemoryOrderRepository()
+	hub := commonhttp.NewSSEHandler()
+
+	item, _ := order.NewOrderItem("oi-1", "o-1", "mi1", "Burger", 1, 1000)
+	o, err := order.NewOrder("o-1", "1001", "restaurant_1", "session_1", []order.OrderItem{*item}, 1000, common.PaymentMethodTypeCash)
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(o))
+
+	kitchen := orderinghttp.NewKitchenHandler(orderQuery.NewActiveKitchenOrdersHandler(orderRepo, nil, nil), nil, nil, nil, nil, nil, nil, nil, nil, "", hub)
+	server := orderinghttp.NewServerHandler(orderQuery.NewUnpaidServerOrdersHandler(orderRepo, nil, nil), nil, nil, nil, nil, nil, nil, hub)
+
+	// A tablet comes back with an ID the server no longer has.
+	resume := func(handler echo.HandlerFunc) string {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+		req.Header.Set("Last-Event-ID", "evicted-9")
+		rec := newStreamRecorder()
+		c := e.NewContext(req, rec)
+		c.Set(httpMiddleware.ContextRestaurantID, common.RestaurantID("restaurant_1"))
+		go func() { _ = handler(c) }()
+		return rec.next(t)
+	}
+
+	board := resume(kitchen.Stream)
+	assert.Contains(t, board, "data: selector #lane-waiting-start")
+	assert.Contains(t, board, "data: selector #lane-ready")
+	assert.Contains(t, board, "data: selector #orders-list")
+	assert.Contains(t, board, string(o.ID), "the order is on the board")
+
+	tickets := resume(server.Stream)
+	assert.Contains(t, tickets, "data: selector #server-orders")
+	assert.Contains(t, tickets, "server-order-"+string(o.ID))
+}
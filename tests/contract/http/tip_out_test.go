@@ -0,0 +1,144 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/auth/domain/membership"
+	"bitmerchant/internal/auth/domain/user"
+	"bitmerchant/internal/common"
+	httpMiddleware "bitmerchant/internal/common/http/middleware"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tip"
+	dashboardQuery "bitmerchant/internal/dashboard/app/query"
+	dashboardhttp "bitmerchant/internal/dashboard/ports/http"
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	"bitmerchant/internal/ordering/domain/order"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/timecard"
+	paymenthttp "bitmerchant/internal/payment/ports/http"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeClockAndTipOutEndpoints(t *testing.T) {
+	e := echo.New()
+
+	restRepo := memory.NewMemoryRestaurantRepository()
+	membershipRepo := memory.NewMemoryMembershipRepository()
+	userRepo := memory.NewMemoryUserRepository()
+	orderRepo := memory.NewMemoryOrderRepository()
+	paymentRepo := memory.NewMemoryPaymentRepository()
+	cards := memory.NewMemoryTimecardRepository()
+
+	restID := common.RestaurantID("restaurant_1")
+	rest, _ := restaurant.NewRestaurant(restID, "Tips Test")
+	require.NoError(t, restRepo.Save(rest))
+	server, _ := user.NewUser("user-server", "Sam Server")
+	require.NoError(t, userRepo.Save(server))
+	cook, _ := user.NewUser("user-cook", "Kit Cook")
+	require.NoError(t, userRepo.Save(cook))
+	require.NoError(t, membershipRepo.Save(&membership.Membership{ID: "m-1", UserID: server.ID, RestaurantID: restID, Role: common.RoleServer}))
+	require.NoError(t, membershipRepo.Save(&membership.Membership{ID: "m-2", UserID: cook.ID, RestaurantID: restID, Role: common.RoleKitchenStaff}))
+
+	clock := paymenthttp.NewTimeClockHandler(
+		payQuery.NewStaffTimecardsHandler(cards, nil, nil),
+		payCmd.NewClockInHandler(cards, nil, nil),
+		payCmd.NewClockOutHandler(cards, nil, nil),
+		restRepo,
+		membershipRepo,
+	)
+	tipOut := dashboardhttp.NewTipOutHandler(
+		dashboardQuery.NewTipOutReportHandler(orderRepo, paymentRepo, cards, restRepo, nil, nil),
+		restRepo,
+		membershipRepo,
+		userRepo,
+	)
+
+	serve := func(t *testing.T, handler echo.HandlerFunc, method, target string, as *user.User) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(method, target, nil), rec)
+		c.Set(httpMiddleware.ContextRestaurantID, restID)
+		c.Set(httpMiddleware.ContextAuthUser, as)
+		require.NoError(t, handler(c))
+		return rec
+	}
+
+	t.Run("staff clock in and out", func(t *testing.T) {
+		rec := serve(t, clock.PostClockOut, http.MethodPost, "/staff/clock/out", server)
+		assert.Contains(t, rec.Header().Get("Location"), "error=")
+
+		rec = serve(t, clock.PostClockIn, http.MethodPost, "/staff/clock/in", server)
+		assert.Equal(t, http.StatusSeeOther, rec.Code)
+		assert.Equal(t, "/staff/clock", rec.Header().Get("Location"))
+		open, err := cards.FindOpenByStaff(restID, server.ID)
+		require.NoError(t, err)
+		assert.Equal(t, common.RoleServer, open.Role, "the role comes from the membership")
+
+		rec = serve(t, clock.GetTimeClock, http.MethodGet, "/staff/clock", server)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Clocked in")
+		assert.Contains(t, rec.Body.String(), `action="/staff/clock/out"`)
+
+		rec = serve(t, clock.PostClockIn, http.MethodPost, "/staff/clock/in", server)
+		assert.Contains(t, rec.Header().Get("Location"), "error=")
+		rec = serve(t, clock.PostClockOut, http.MethodPost, "/staff/clock/out", server)
+		assert.Equal(t, "/staff/clock", rec.Header().Get("Location"))
+		_, err = cards.FindOpenByStaff(restID, server.ID)
+		assert.ErrorIs(t, err, timecard.ErrNotFound)
+	})
+
+	t.Run("GET /dashboard/tips shares pooled tips and exports CSV", func(t *testing.T) {
+		now := time.Now()
+		out := now
+		require.NoError(t, cards.Save(&timecard.Timecard{ID: "tc-s", RestaurantID: restID, StaffID: server.ID, Role: common.RoleServer, ClockedInAt: now.Add(-3 * time.Hour), ClockedOutAt: &out}))
+		require.NoError(t, cards.Save(&timecard.Timecard{ID: "tc-k", RestaurantID: restID, StaffID: cook.ID, Role: common.RoleKitchenStaff, ClockedInAt: now.Add(-3 * time.Hour), ClockedOutAt: &out}))
+		require.NoError(t, orderRepo.Save(&order.Order{
+			ID: "o1", RestaurantID: restID, PaymentStatus: common.PaymentStatusPaid, CreatedAt: now.Add(-time.Hour),
+			TotalAmount: 2500, TipAmount: 500, Currency: rest.BaseCurrency,
+		}))
+		require.NoError(t, paymentRepo.Save(&payment.Payment{
+			ID: "pay-1", OrderID: "o1", RestaurantID: restID, Amount: 2500, Currency: rest.BaseCurrency,
+			Kind: payment.KindCharge, Status: common.PaymentStatusPaid, CollectedBy: server.ID, CreatedAt: now.Add(-time.Hour),
+		}))
+
+		rec := serve(t, tipOut.GetTipOut, http.MethodGet, "/dashboard/tips", server)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, "Staff keep the tips they collect.")
+		assert.Contains(t, body, "Sam Server")
+		assert.Contains(t, body, money.New(500, rest.BaseCurrency).Format())
+
+		require.NoError(t, rest.SetTipPooling(tip.Pooling{
+			Pools: []tip.Pool{
+				{Name: "Front of house", Share: 60, Roles: []common.MemberRole{common.RoleServer}},
+				{Name: "Kitchen", Share: 40, Roles: []common.MemberRole{common.RoleKitchenStaff}},
+			},
+			Split: tip.SplitHours,
+		}))
+		require.NoError(t, restRepo.Save(rest))
+
+		rec = serve(t, tipOut.GetTipOutCSV, http.MethodGet, "/dashboard/tips/export", server)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "attachment")
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "staff,staff_id,role,pool,hours,tips_collected,tip_out,currency", lines[0])
+		assert.Equal(t, "Sam Server,user-server,server,Front of house,3.00,5.00,3.00,"+rest.BaseCurrency.Code, lines[1])
+		assert.Equal(t, "Kit Cook,user-cook,kitchen_staff,Kitchen,3.00,0.00,2.00,"+rest.BaseCurrency.Code, lines[2])
+
+		rec = serve(t, tipOut.GetTipOut, http.MethodGet, "/dashboard/tips?from=2026-01-10&to=2026-01-01", server)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		rec = serve(t, tipOut.GetTipOut, http.MethodGet, "/dashboard/tips?from=yesterday", server)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -49,6 +49,11 @@ func TestAdminMenuDashboard_ShowsUnavailableItemsAndEmptyCategory(t *testing.T)
 	reorderItemUC := menuCmd.NewReorderMenuItemsHandler(repoItem, repoCat, nil, nil)
 	updateTableUC := restaurantCmd.NewUpdateRestaurantTableCountHandler(repoRest, nil, nil)
 	updateKitchenUC := restaurantCmd.NewUpdateKitchenThresholdsHandler(repoRest, nil, nil)
+	updateLightningUC := restaurantCmd.NewUpdateLightningAddressHandler(repoRest, nil, nil)
+	updateTaxUC := restaurantCmd.NewUpdateTaxSettingsHandler(repoRest, nil, nil)
+	updateCashRoundUC := restaurantCmd.NewUpdateCashRoundingHandler(repoRest, nil, nil)
+	updateTipsUC := restaurantCmd.NewUpdateTipSettingsHandler(repoRest, nil, nil)
+	updateTipPoolingUC := restaurantCmd.NewUpdateTipPoolingHandler(repoRest, nil, nil)
 	generateQRUC := restaurantQuery.NewRestaurantTableQRImageHandler(qr.NewQRCodeService(), "http://localhost", repoRest, nil, nil)
 
 	adminHandler := restauranthttp.NewAdminHandler(
@@ -67,6 +72,11 @@ func TestAdminMenuDashboard_ShowsUnavailableItemsAndEmptyCategory(t *testing.T)
 		menuQuery.PhotoSignerConfig{},
 		updateTableUC,
 		updateKitchenUC,
+		updateLightningUC,
+		updateTaxUC,
+		updateCashRoundUC,
+		updateTipsUC,
+		updateTipPoolingUC,
 		generateQRUC,
 		membershipRepo,
 		repoRest,
@@ -91,7 +101,7 @@ func TestAdminMenuDashboard_ShowsUnavailableItemsAndEmptyCategory(t *testing.T)
 		CategoryID:   cat2.ID,
 		Name:         "Special",
 		Description:  "",
-		Price:        4,
+		Price:        400,
 		Available:    false,
 	})
 	require.NoError(t, err)
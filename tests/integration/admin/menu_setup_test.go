@@ -57,7 +57,7 @@ func TestMenuSetupWorkflow(t *testing.T) {
 		RestaurantID: rest.ID,
 		CategoryID:   cat.ID,
 		Name:         "Whopper",
-		Price:        5.99,
+		Price:        599,
 		Available:    true,
 	}
 	item, err := createItemUC.Handle(context.Background(), itemReq)
@@ -46,6 +46,11 @@ func TestAdminQR_TableCountAndPrint(t *testing.T) {
 	reorderItemUC := menuCmd.NewReorderMenuItemsHandler(repoItem, repoCat, nil, nil)
 	updateTableUC := restaurantCmd.NewUpdateRestaurantTableCountHandler(repoRest, nil, nil)
 	updateKitchenUC := restaurantCmd.NewUpdateKitchenThresholdsHandler(repoRest, nil, nil)
+	updateLightningUC := restaurantCmd.NewUpdateLightningAddressHandler(repoRest, nil, nil)
+	updateTaxUC := restaurantCmd.NewUpdateTaxSettingsHandler(repoRest, nil, nil)
+	updateCashRoundUC := restaurantCmd.NewUpdateCashRoundingHandler(repoRest, nil, nil)
+	updateTipsUC := restaurantCmd.NewUpdateTipSettingsHandler(repoRest, nil, nil)
+	updateTipPoolingUC := restaurantCmd.NewUpdateTipPoolingHandler(repoRest, nil, nil)
 	generateQRUC := restaurantQuery.NewRestaurantTableQRImageHandler(qr.NewQRCodeService(), "http://localhost", repoRest, nil, nil)
 
 	adminHandler := restauranthttp.NewAdminHandler(
@@ -54,7 +59,7 @@ func TestAdminQR_TableCountAndPrint(t *testing.T) {
 		reorderCatUC, reorderItemUC,
 		repoItem,
 		nil, menuQuery.PhotoSignerConfig{},
-		updateTableUC, updateKitchenUC, generateQRUC, membershipRepo, repoRest,
+		updateTableUC, updateKitchenUC, updateLightningUC, updateTaxUC, updateCashRoundUC, updateTipsUC, updateTipPoolingUC, generateQRUC, membershipRepo, repoRest,
 	)
 
 	require.NoError(t, updateTableUC.Handle(context.Background(), restaurantCmd.UpdateRestaurantTableCount{
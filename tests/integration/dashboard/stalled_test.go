@@ -18,7 +18,7 @@ func TestStalledOrdersHandler_Integration(t *testing.T) {
 	orderRepo := memory.NewMemoryOrderRepository()
 	handler := dashboard.NewStalledOrdersHandler(orderRepo, nil, nil)
 
-	items := []order.OrderItem{{MenuItemID: "i1", Name: "X", Quantity: 1, UnitPrice: 1.0, Subtotal: 1.0}}
+	items := []order.OrderItem{{MenuItemID: "i1", Name: "X", Quantity: 1, UnitPrice: 100, Subtotal: 100}}
 
 	stale, err := order.NewOrder("stale", "S001", "restaurant_1", "sess_a", items, 100, common.PaymentMethodTypeCash)
 	require.NoError(t, err)
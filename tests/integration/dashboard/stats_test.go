@@ -4,7 +4,6 @@ import (
 	"bitmerchant/internal/common"
 	dashboard "bitmerchant/internal/dashboard/app/query"
 
-	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
 	"bitmerchant/internal/infrastructure/payment/cash"
 	"bitmerchant/internal/infrastructure/repositories/memory"
@@ -28,7 +27,6 @@ func TestDashboardIntegration(t *testing.T) {
 	orderRepo := memory.NewMemoryOrderRepository()
 	paymentRepo := memory.NewMemoryPaymentRepository()
 	restRepo := memory.NewMemoryRestaurantRepository()
-	eventBus := events.NewEventBus()
 	paymentMethod := cash.NewCashPaymentMethod()
 	logger := logging.NewLogger()
 
@@ -39,14 +37,14 @@ func TestDashboardIntegration(t *testing.T) {
 	// Use Cases
 	_ = paymentRepo
 	_ = paymentMethod
-	createOrderUC := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, eventBus, logger.Logger, nil)
+	createOrderUC := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logger.Logger, nil)
 	getStatsUC := dashboard.NewRestaurantDashboardStatsHandler(orderRepo, nil, nil)
 
 	t.Run("Order Creation Reflected in Stats", func(t *testing.T) {
 		// 1. Create an Order
 		cartSvc := cart.NewCartService()
 		sessionID := "sess_integration"
-		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 15.0)
+		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1500)
 		require.NoError(t, cartSvc.AddItem(sessionID, item, 2)) // 2 Burgers = $30
 		userCart := cartSvc.GetCart(sessionID)
 
@@ -77,26 +75,24 @@ func TestDashboardIntegration(t *testing.T) {
 		// Subtotal $30 + 8% tax = $32.40. CreateOrder now persists the full
 		// charged total, which is what dashboard stats sum.
 		assert.Equal(t, 1, stats.OrderCount)
-		assert.InDelta(t, 32.40, stats.TotalSales, 0.001)
-		assert.InDelta(t, 32.40, stats.AverageOrderValue, 0.001)
+		assert.Equal(t, int64(3240), stats.TotalSales)
+		assert.Equal(t, int64(3240), stats.AverageOrderValue)
 	})
 
 	t.Run("Weekly Stats Include Recent Paid Orders", func(t *testing.T) {
 		items := []order.OrderItem{
-			{MenuItemID: "i2", Name: "Fries", Quantity: 1, UnitPrice: 12.0, Subtotal: 12.0},
+			{MenuItemID: "i2", Name: "Fries", Quantity: 1, UnitPrice: 1200, Subtotal: 1200},
 		}
 
 		recentPaid, err := order.NewOrder("recent_paid", "2001", "restaurant_1", "sess_week", items, 1200, common.PaymentMethodTypeCash)
 		assert.NoError(t, err)
 		recentPaid.PaymentStatus = common.PaymentStatusPaid
-		recentPaid.FiatAmount = 12.0
 		recentPaid.CreatedAt = time.Now().AddDate(0, 0, -3)
 		_ = orderRepo.Save(recentPaid)
 
 		oldPaid, err := order.NewOrder("old_paid", "2002", "restaurant_1", "sess_week", items, 900, common.PaymentMethodTypeCash)
 		assert.NoError(t, err)
 		oldPaid.PaymentStatus = common.PaymentStatusPaid
-		oldPaid.FiatAmount = 9.0
 		oldPaid.CreatedAt = time.Now().AddDate(0, 0, -10)
 		_ = orderRepo.Save(oldPaid)
 
@@ -109,7 +105,7 @@ func TestDashboardIntegration(t *testing.T) {
 		// Previously created paid order ($32.40 incl tax) + recent paid order ($12),
 		// excludes oldPaid ($9). recentPaid uses NewOrder which bypasses tax math.
 		assert.Equal(t, 2, stats.OrderCount)
-		assert.InDelta(t, 44.40, stats.TotalSales, 0.001)
-		assert.InDelta(t, 22.20, stats.AverageOrderValue, 0.001)
+		assert.Equal(t, int64(4440), stats.TotalSales)
+		assert.Equal(t, int64(2220), stats.AverageOrderValue)
 	})
 }
@@ -13,11 +13,14 @@ import (
 
 	"bitmerchant/internal/common"
 	commonhttp "bitmerchant/internal/common/http"
+	httpMiddleware "bitmerchant/internal/common/http/middleware"
 	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
 	"bitmerchant/internal/infrastructure/repositories/memory"
 	orderevent "bitmerchant/internal/ordering/app/event"
+	orderQuery "bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
+	orderinghttp "bitmerchant/internal/ordering/ports/http"
 	orderingservice "bitmerchant/internal/ordering/service"
 
 	"github.com/ThreeDotsLabs/watermill"
@@ -42,7 +45,7 @@ func TestNATSOrderCreatedBroadcastsToKitchenSSE(t *testing.T) {
 	})
 
 	orderID := common.OrderID("order_sse_1")
-	orderItem, err := order.NewOrderItem("oi_sse_1", orderID, "item_1", "Burger", 1, 10)
+	orderItem, err := order.NewOrderItem("oi_sse_1", orderID, "item_1", "Burger", 1, 1000)
 	require.NoError(t, err)
 	createdOrder, err := order.NewOrder(
 		orderID,
@@ -54,7 +57,6 @@ func TestNATSOrderCreatedBroadcastsToKitchenSSE(t *testing.T) {
 		common.PaymentMethodTypeCash,
 	)
 	require.NoError(t, err)
-	createdOrder.FiatAmount = 10
 	require.NoError(t, orderRepo.Save(createdOrder))
 
 	router := newRouter(t, 5*time.Second)
@@ -68,7 +70,7 @@ func TestNATSOrderCreatedBroadcastsToKitchenSSE(t *testing.T) {
 			Logger:          watermill.NewStdLogger(false, false),
 		}.Middleware,
 	)
-	orderingservice.RegisterOrderSSEHandlers(router, eventBus.Subscriber(), logger, sseHandler, orderRepo)
+	orderingservice.RegisterOrderSSEHandlers(router, eventBus.Subscriber(), logger, sseHandler, orderRepo, nil)
 	runRouter(t, router)
 
 	writer := newSSECaptureWriter()
@@ -78,10 +80,11 @@ func TestNATSOrderCreatedBroadcastsToKitchenSSE(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/kitchen/stream", nil).WithContext(reqCtx)
 	e := echo.New()
 	ctx := e.NewContext(req, writer)
+	ctx.Set(httpMiddleware.ContextRestaurantID, createdOrder.RestaurantID)
 
 	streamDone := make(chan error, 1)
 	go func() {
-		streamDone <- sseHandler.KitchenStream(ctx)
+		streamDone <- newKitchenStream(orderRepo, sseHandler).Stream(ctx)
 	}()
 
 	// Ensure stream subscriber registration before publishing.
@@ -96,8 +99,7 @@ func TestNATSOrderCreatedBroadcastsToKitchenSSE(t *testing.T) {
 	}))
 
 	select {
-	case chunk := <-writer.writes:
-		payload := string(chunk)
+	case payload := <-writer.events():
 		assert.Contains(t, payload, "event: datastar-patch-elements")
 		assert.Contains(t, payload, "#orders-list")
 	case <-time.After(6 * time.Second):
@@ -194,6 +196,70 @@ func TestNATSBroadcastAcrossTwoInstances(t *testing.T) {
 	assert.Contains(t, gotB, "ord_broadcast_1")
 }
 
+func TestNATSSSEFansOutAcrossReplicas(t *testing.T) {
+	natsURL := setupNATSServer(t)
+	logger := logging.NewLogger()
+	orderRepo := memory.NewMemoryOrderRepository()
+
+	orderID := common.OrderID("order_fanout_1")
+	orderItem, err := order.NewOrderItem("oi_fanout_1", orderID, "item_1", "Burger", 1, 1000)
+	require.NoError(t, err)
+	createdOrder, err := order.NewOrder(orderID, "1201", "restaurant_1", "session_1", []order.OrderItem{*orderItem}, 1000, common.PaymentMethodTypeCash)
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(createdOrder))
+
+	// Two replicas, each with its own bus, relayed SSE hub and projection
+	// router, sharing one order store.
+	replica := func(instanceID string) (*events.EventBus, *commonhttp.SSEHandler) {
+		bus := newNATSEventBus(t, natsURL, instanceID, 1, 1*time.Second)
+		t.Cleanup(func() { _ = bus.Close() })
+		hub, err := commonhttp.NewRelayedSSEHandler(bus.SSERelay())
+		require.NoError(t, err)
+		router := newRouter(t, 5*time.Second)
+		orderingservice.RegisterOrderSSEHandlers(router, bus.SharedSubscriberForGroup("sse"), logger, hub, orderRepo, nil)
+		runRouter(t, router)
+		return bus, hub
+	}
+	busA, _ := replica("instance-a")
+	_, hubB := replica("instance-b")
+
+	writer := newSSECaptureWriter()
+	reqCtx, cancelReq := context.WithCancel(context.Background())
+	defer cancelReq()
+	ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/kitchen/stream", nil).WithContext(reqCtx), writer)
+	ctx.Set(httpMiddleware.ContextRestaurantID, createdOrder.RestaurantID)
+	go func() { _ = newKitchenStream(orderRepo, hubB).Stream(ctx) }()
+	time.Sleep(200 * time.Millisecond)
+
+	require.NoError(t, busA.Publish(context.Background(), common.EventOrderCreated, orderevent.OrderCreated{
+		OrderID:      createdOrder.ID,
+		RestaurantID: createdOrder.RestaurantID,
+		OrderNumber:  createdOrder.OrderNumber,
+		TotalAmount:  createdOrder.TotalAmount,
+		CreatedAt:    createdOrder.CreatedAt,
+	}))
+
+	events := writer.events()
+	select {
+	case payload := <-events:
+		assert.Contains(t, payload, "#orders-list")
+	case <-time.After(6 * time.Second):
+		t.Fatal("kitchen on instance B did not see the order published on instance A")
+	}
+
+	// The projection runs once across the cluster, so the tablet gets the
+	// order once rather than once per replica.
+	select {
+	case payload := <-events:
+		assert.NotContains(t, payload, "#orders-list", "order rendered twice")
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func newKitchenStream(orderRepo order.Repository, hub *commonhttp.SSEHandler) *orderinghttp.KitchenHandler {
+	return orderinghttp.NewKitchenHandler(orderQuery.NewActiveKitchenOrdersHandler(orderRepo, nil, nil), nil, nil, nil, nil, nil, nil, nil, nil, "", hub)
+}
+
 func newNATSEventBus(t *testing.T, natsURL, instanceID string, subscribers int, ackWait time.Duration) *events.EventBus {
 	t.Helper()
 	eventBus, err := events.NewEventBusWithConfig(events.Config{
@@ -304,6 +370,19 @@ func (w *sseCaptureWriter) Write(data []byte) (int, error) {
 	return len(data), nil
 }
 
+// events relays captured writes, minus the cursor every stream opens with.
+func (w *sseCaptureWriter) events() <-chan string {
+	out := make(chan string, 32)
+	go func() {
+		for data := range w.writes {
+			if !strings.HasPrefix(string(data), ": connected") {
+				out <- string(data)
+			}
+		}
+	}()
+	return out
+}
+
 func (w *sseCaptureWriter) WriteHeader(statusCode int) {
 	w.status = statusCode
 }
@@ -0,0 +1,242 @@
+package events_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/infrastructure/events"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestPostgresGroupsEachReceiveEveryMessage(t *testing.T) {
+	db := setupPostgresDatabase(t)
+	eventBus := newPostgresEventBus(t, db, "groups-instance")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	defaultCh, err := eventBus.Subscriber().Subscribe(ctx, "test.pg.groups")
+	require.NoError(t, err)
+	notifCh, err := eventBus.SubscriberForGroup("notif").Subscribe(ctx, "test.pg.groups")
+	require.NoError(t, err)
+
+	require.NoError(t, eventBus.Publish(context.Background(), "test.pg.groups", map[string]string{"order_id": "ord_pg_1"}))
+
+	for name, ch := range map[string]<-chan *message.Message{"default": defaultCh, "notif": notifCh} {
+		select {
+		case msg := <-ch:
+			assert.Contains(t, string(msg.Payload), "ord_pg_1", name)
+			msg.Ack()
+		case <-time.After(6 * time.Second):
+			t.Fatalf("%s group did not receive the message", name)
+		}
+	}
+}
+
+func TestPostgresRedeliveryAfterHandlerError(t *testing.T) {
+	db := setupPostgresDatabase(t)
+	eventBus := newPostgresEventBus(t, db, "retry-instance")
+
+	router := newRouter(t, 5*time.Second)
+	var attempts atomic.Int32
+	processed := make(chan struct{}, 1)
+
+	router.AddConsumerHandler("retry-once", "test.pg.retry", eventBus.Subscriber(), func(msg *message.Message) error {
+		attempt := attempts.Add(1)
+		if attempt == 1 {
+			return errors.New("fail first delivery")
+		}
+		if attempt == 2 {
+			processed <- struct{}{}
+		}
+		return nil
+	})
+	runRouter(t, router)
+
+	require.NoError(t, eventBus.Publish(context.Background(), "test.pg.retry", map[string]string{"event": "retry"}))
+
+	select {
+	case <-processed:
+	case <-time.After(8 * time.Second):
+		t.Fatal("timed out waiting for redelivery")
+	}
+	assert.GreaterOrEqual(t, attempts.Load(), int32(2))
+}
+
+func TestPostgresGroupsAcrossTwoInstances(t *testing.T) {
+	db := setupPostgresDatabase(t)
+	busA := newPostgresEventBus(t, db, "instance-a")
+	busB := newPostgresEventBus(t, db, "instance-b")
+
+	const (
+		topic    = "test.pg.replicas"
+		messages = 10
+	)
+	var perInstance, shared [2]atomic.Int32
+	router := newRouter(t, 5*time.Second)
+	for i, bus := range []*events.EventBus{busA, busB} {
+		router.AddConsumerHandler(fmt.Sprintf("notif-%d", i), topic, bus.SubscriberForGroup("notif"), func(*message.Message) error {
+			perInstance[i].Add(1)
+			return nil
+		})
+		router.AddConsumerHandler(fmt.Sprintf("sse-%d", i), topic, bus.SharedSubscriberForGroup("sse"), func(*message.Message) error {
+			shared[i].Add(1)
+			return nil
+		})
+	}
+	runRouter(t, router)
+
+	for n := range messages {
+		require.NoError(t, busA.Publish(context.Background(), topic, map[string]int{"n": n}))
+	}
+
+	require.Eventually(t, func() bool {
+		return perInstance[0].Load() == messages && perInstance[1].Load() == messages
+	}, 10*time.Second, 50*time.Millisecond, "every instance's own group sees every message")
+	require.Eventually(t, func() bool {
+		return shared[0].Load()+shared[1].Load() == messages
+	}, 10*time.Second, 50*time.Millisecond, "the shared group sees every message")
+
+	// Give a stray second delivery time to show up.
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(messages), shared[0].Load()+shared[1].Load(), "the shared group handles each message once across instances")
+	assert.Nil(t, busA.SSERelay(), "the postgres backend has no SSE relay")
+}
+
+func TestPostgresNewGroupStartsAtNewestMessage(t *testing.T) {
+	db := setupPostgresDatabase(t)
+	const topic = "test.pg.newgroup"
+
+	first := newPostgresEventBus(t, db, "before-redeploy")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := first.SubscriberForGroup("notif").Subscribe(ctx, topic)
+	require.NoError(t, err)
+	require.NoError(t, first.Publish(context.Background(), topic, map[string]string{"order_id": "ord_old"}))
+
+	// A redeployed instance comes back under a new host name.
+	second := newPostgresEventBus(t, db, "after-redeploy")
+	ch, err := second.SubscriberForGroup("notif").Subscribe(ctx, topic)
+	require.NoError(t, err)
+	require.NoError(t, second.Publish(context.Background(), topic, map[string]string{"order_id": "ord_new"}))
+
+	select {
+	case msg := <-ch:
+		assert.Contains(t, string(msg.Payload), "ord_new", "history published before the group existed is not replayed")
+		msg.Ack()
+	case <-time.After(6 * time.Second):
+		t.Fatal("new group did not receive the new message")
+	}
+}
+
+func TestPostgresPurgeDeletesOldMessagesAndStaleGroups(t *testing.T) {
+	db := setupPostgresDatabase(t)
+	const topic = "test.pg.purge"
+
+	// The gone instance subscribed once and stopped before anything was
+	// published.
+	gone := newPostgresEventBus(t, db, "gone-instance")
+	goneCtx, stop := context.WithCancel(context.Background())
+	_, err := gone.SubscriberForGroup("notif").Subscribe(goneCtx, topic)
+	require.NoError(t, err)
+	stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	live := newPostgresEventBus(t, db, "live-instance")
+	ch, err := live.SubscriberForGroup("notif").Subscribe(ctx, topic)
+	require.NoError(t, err)
+
+	require.NoError(t, live.Publish(context.Background(), topic, map[string]string{"order_id": "ord_purge"}))
+	select {
+	case msg := <-ch:
+		msg.Ack()
+	case <-time.After(6 * time.Second):
+		t.Fatal("live group did not receive the message")
+	}
+	require.Eventually(t, func() bool {
+		var acked sql.NullInt64
+		err := db.QueryRow(`SELECT offset_acked FROM "watermill_offsets_` + topic + `" WHERE consumer_group = 'bitmerchant_live-instance_notif'`).Scan(&acked)
+		return err == nil && acked.Int64 > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	_, err = db.Exec(`UPDATE "watermill_` + topic + `" SET created_at = created_at - INTERVAL '8 days'`)
+	require.NoError(t, err)
+
+	purged, err := live.Purge(context.Background(), 7*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var groups []string
+	rows, err := db.Query(`SELECT consumer_group FROM "watermill_offsets_` + topic + `" ORDER BY consumer_group`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var group string
+		require.NoError(t, rows.Scan(&group))
+		groups = append(groups, group)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"bitmerchant_live-instance_notif"}, groups, "the group that never read the old message is dropped")
+}
+
+func newPostgresEventBus(t *testing.T, db *sql.DB, instanceID string) *events.EventBus {
+	t.Helper()
+	eventBus, err := events.NewEventBusWithConfig(events.Config{
+		Backend:        "postgres",
+		NATSAckWait:    time.Second,
+		NATSInstanceID: instanceID,
+		DB:             db,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, eventBus.Close()) })
+	return eventBus
+}
+
+func setupPostgresDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	pgContainer, err := postgres.Run(ctx,
+		"postgres:17-alpine",
+		postgres.WithDatabase("bitmerchant_events"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second),
+		),
+	)
+	if err != nil {
+		t.Fatalf("failed to start postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := pgContainer.Terminate(context.Background()); err != nil {
+			t.Logf("failed to terminate postgres container: %v", err)
+		}
+	})
+
+	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	db, err := sql.Open("pgx", connStr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.PingContext(ctx))
+	return db
+}
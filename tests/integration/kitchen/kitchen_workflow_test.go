@@ -2,7 +2,10 @@ package kitchen_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/envelope"
 	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/outbox"
 
 	"bitmerchant/internal/common/http/middleware"
 	"bitmerchant/internal/infrastructure/events"
@@ -16,12 +19,13 @@ import (
 	orderCmd "bitmerchant/internal/ordering/app/command"
 	orderevent "bitmerchant/internal/ordering/app/event"
 	orderQuery "bitmerchant/internal/ordering/app/query"
+	"bitmerchant/internal/ordering/domain/order"
 	orderinghttp "bitmerchant/internal/ordering/ports/http"
 	ordersse "bitmerchant/internal/ordering/ports/sse"
+	payCmd "bitmerchant/internal/payment/app/command"
 	placesCmd "bitmerchant/internal/places/app/command"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 	"context"
-	"encoding/json"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -58,68 +62,87 @@ func TestKitchenWorkflow(t *testing.T) {
 	cat1, _ := menu.NewMenuCategory("cat_1", restaurantID, "Mains", 1)
 	_ = menuCatRepo.Save(cat1)
 
-	item1, _ := menu.NewMenuItem("item_1", "cat_1", restaurantID, "Burger", 10.00)
+	item1, _ := menu.NewMenuItem("item_1", "cat_1", restaurantID, "Burger", 1000)
 	_ = menuItemRepo.Save(item1)
 
 	// Use Cases
-	_ = paymentRepo
 	_ = paymentMethod
-	createOrderUC := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, eventBus, logger.Logger, nil)
+	recordPaymentUC := payCmd.NewRecordPaymentHandler(paymentRepo, nil, logger.Logger, nil)
+	recordPayment := func(ctx context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (orderCmd.SettledPayment, error) {
+		p, err := recordPaymentUC.Handle(ctx, payCmd.RecordPayment{
+			OrderID: o.ID, RestaurantID: o.RestaurantID, Method: method,
+			Amount: o.Total(), Tendered: tendered, CollectedBy: collectedBy,
+		})
+		if err != nil {
+			return orderCmd.SettledPayment{}, err
+		}
+		return orderCmd.SettledPayment{PaymentID: p.ID, FXRate: p.FXRate}, nil
+	}
+	createOrderUC := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logger.Logger, nil)
 	getCustomerOrderUC := orderQuery.NewCustomerOrderByLookupHandler(orderRepo, nil, nil)
 	getCustomerOrdersUC := orderQuery.NewCustomerOrdersForSessionHandler(orderRepo, nil, nil)
 	getKitchenOrdersUC := orderQuery.NewActiveKitchenOrdersHandler(orderRepo, nil, nil)
-	markPaidUC := orderCmd.NewMarkOrderPaidHandler(orderRepo, eventBus, logger.Logger, nil)
-	markPreparingUC := orderCmd.NewMarkOrderPreparingHandler(orderRepo, eventBus, logger.Logger, nil)
-	markReadyUC := orderCmd.NewMarkOrderReadyHandler(orderRepo, eventBus, logger.Logger, nil)
-	markCompletedUC := orderCmd.NewMarkOrderCompletedHandler(orderRepo, eventBus, logger.Logger, nil)
-	toggleItemPrepUC := orderCmd.NewToggleOrderItemPrepHandler(orderRepo, eventBus, logger.Logger, nil)
+	markPaidUC := orderCmd.NewMarkOrderPaidHandler(orderRepo, orderCmd.PaymentRecorderFunc(recordPayment), logger.Logger, nil)
+	markPreparingUC := orderCmd.NewMarkOrderPreparingHandler(orderRepo, logger.Logger, nil)
+	markReadyUC := orderCmd.NewMarkOrderReadyHandler(orderRepo, logger.Logger, nil)
+	markCompletedUC := orderCmd.NewMarkOrderCompletedHandler(orderRepo, logger.Logger, nil)
+	toggleItemPrepUC := orderCmd.NewToggleOrderItemPrepHandler(orderRepo, logger.Logger, nil)
 	getMenuUC := menuQuery.NewMenuForCustomerHandler(menuCatRepo, menuItemRepo, restRepo, nil, menuQuery.PhotoSignerConfig{}, nil, nil)
 
 	getUnpaidServerUC := orderQuery.NewUnpaidServerOrdersHandler(orderRepo, nil, nil)
 
 	// Handlers
-	kitchenHandler := orderinghttp.NewKitchenHandler(getKitchenOrdersUC, markPaidUC, markPreparingUC, markReadyUC, markCompletedUC, toggleItemPrepUC, nil, nil, "")
-	serverHandler := orderinghttp.NewServerHandler(getUnpaidServerUC, markPaidUC, nil, nil)
-	requestServerUC := orderCmd.NewRequestServerHandler(orderRepo, eventBus, logger.Logger, nil)
-	requestBillUC := orderCmd.NewRequestBillHandler(orderRepo, eventBus, logger.Logger, nil)
-	orderHandler := orderinghttp.NewOrderHandler(createOrderUC, getCustomerOrderUC, getCustomerOrdersUC, requestServerUC, requestBillUC, orderRepo, restRepo, cartService, "")
+	kitchenHandler := orderinghttp.NewKitchenHandler(getKitchenOrdersUC, markPaidUC, markPreparingUC, markReadyUC, markCompletedUC, toggleItemPrepUC, nil, nil, nil, "", sseHandler)
+	serverHandler := orderinghttp.NewServerHandler(getUnpaidServerUC, markPaidUC, nil, nil, nil, nil, nil, sseHandler)
+	requestServerUC := orderCmd.NewRequestServerHandler(orderRepo, logger.Logger, nil)
+	requestBillUC := orderCmd.NewRequestBillHandler(orderRepo, logger.Logger, nil)
+	orderHandler := orderinghttp.NewOrderHandler(createOrderUC, getCustomerOrderUC, getCustomerOrdersUC, requestServerUC, requestBillUC, orderRepo, restRepo, cartService, "", false, nil, nil, sseHandler)
 	visitRepo := memory.NewMemorySessionRestaurantVisitRepository()
 	recordVisitUC := placesCmd.NewRecordMenuVisitHandler(restRepo, visitRepo, nil, nil)
-	_ = menuhttp.NewMenuHandler(getMenuUC, cartService, recordVisitUC, orderRepo)
+	_ = menuhttp.NewMenuHandler(getMenuUC, cartService, recordVisitUC, orderRepo, nil)
 
 	// Event Handlers
 	orderCreatedHandler := ordersse.NewOrderCreatedHandler(logger, sseHandler, orderRepo)
-	orderPaidHandler := ordersse.NewOrderPaidHandler(logger, sseHandler, orderRepo)
-	orderPreparingHandler := ordersse.NewOrderPreparingHandler(logger, sseHandler, orderRepo)
-	orderReadyHandler := ordersse.NewOrderReadyHandler(logger, sseHandler, orderRepo)
-	orderCompletedHandler := ordersse.NewOrderCompletedHandler(logger, sseHandler, orderRepo)
+	orderPaidHandler := ordersse.NewOrderPaidHandler(logger, sseHandler, orderRepo, nil)
+	orderPreparingHandler := ordersse.NewOrderPreparingHandler(logger, sseHandler, orderRepo, nil)
+	orderReadyHandler := ordersse.NewOrderReadyHandler(logger, sseHandler, orderRepo, nil)
+	orderCompletedHandler := ordersse.NewOrderCompletedHandler(logger, sseHandler, orderRepo, nil)
 
 	// Subscriptions
 	subscribe(t, eventBus, common.EventOrderCreated, func(msg []byte) {
 		var event orderevent.OrderCreated
-		require.NoError(t, json.Unmarshal(msg, &event))
+		_, err := envelope.Decode(msg, &event)
+		require.NoError(t, err)
 		require.NoError(t, orderCreatedHandler.Handle(context.Background(), event))
 	})
 	subscribe(t, eventBus, common.EventOrderPaid, func(msg []byte) {
 		var event orderevent.OrderPaid
-		require.NoError(t, json.Unmarshal(msg, &event))
+		_, err := envelope.Decode(msg, &event)
+		require.NoError(t, err)
 		require.NoError(t, orderPaidHandler.Handle(context.Background(), event))
 	})
 	subscribe(t, eventBus, common.EventOrderPreparing, func(msg []byte) {
 		var event orderevent.OrderPreparing
-		require.NoError(t, json.Unmarshal(msg, &event))
+		_, err := envelope.Decode(msg, &event)
+		require.NoError(t, err)
 		require.NoError(t, orderPreparingHandler.Handle(context.Background(), event))
 	})
 	subscribe(t, eventBus, common.EventOrderReady, func(msg []byte) {
 		var event orderevent.OrderReady
-		require.NoError(t, json.Unmarshal(msg, &event))
+		_, err := envelope.Decode(msg, &event)
+		require.NoError(t, err)
 		require.NoError(t, orderReadyHandler.Handle(context.Background(), event))
 	})
 	subscribe(t, eventBus, common.EventOrderCompleted, func(msg []byte) {
 		var event orderevent.OrderCompleted
-		require.NoError(t, json.Unmarshal(msg, &event))
+		_, err := envelope.Decode(msg, &event)
+		require.NoError(t, err)
 		require.NoError(t, orderCompletedHandler.Handle(context.Background(), event))
 	})
+	// Order events reach the bus through the outbox relay, as in the app.
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	go outbox.NewRelay(orderRepo.Outbox(), eventBus, logger.Logger).Run(relayCtx)
 
 	// Echo Setup
 	e := echo.New()
@@ -187,8 +210,9 @@ func TestKitchenWorkflow(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "UNPAID")
 	assert.NotContains(t, rec.Body.String(), "Mark Paid", "kitchen view must not expose Mark Paid")
 
-	// 3. FOH (server) Marks Paid
-	req = httptest.NewRequest(http.MethodPost, "/server/order/"+string(orderID)+"/mark-paid", nil)
+	// 3. FOH (server) Marks Paid, taking 50 in cash
+	req = httptest.NewRequest(http.MethodPost, "/server/order/"+string(orderID)+"/mark-paid", strings.NewReader("tendered=50"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
 	rec = httptest.NewRecorder()
 	c = e.NewContext(req, rec)
 	c.SetPath("/server/order/:id/mark-paid")
@@ -202,6 +226,13 @@ func TestKitchenWorkflow(t *testing.T) {
 	updatedOrder, _ := orderRepo.FindByID(orderID)
 	assert.Equal(t, common.PaymentStatusPaid, updatedOrder.PaymentStatus)
 
+	ledger, err := paymentRepo.FindByOrderID(orderID)
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPaid, ledger.Status)
+	assert.Equal(t, updatedOrder.TotalAmount, ledger.Amount)
+	assert.Equal(t, int64(5000), ledger.TenderedAmount)
+	assert.Equal(t, 5000-updatedOrder.TotalAmount, ledger.ChangeGiven)
+
 	time.Sleep(100 * time.Millisecond)
 
 	// 4. Kitchen Marks Preparing
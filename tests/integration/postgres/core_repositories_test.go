@@ -11,14 +11,21 @@ import (
 	"bitmerchant/internal/auth/domain/session"
 	"bitmerchant/internal/auth/domain/user"
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 	menuAdapters "bitmerchant/internal/menu/adapters"
 	"bitmerchant/internal/menu/domain/menu"
 	orderAdapters "bitmerchant/internal/ordering/adapters"
 	"bitmerchant/internal/ordering/domain/order"
 	payAdapters "bitmerchant/internal/payment/adapters"
 	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/shift"
+	"bitmerchant/internal/payment/domain/timecard"
 	placesAdapters "bitmerchant/internal/places/adapters"
 	"bitmerchant/internal/places/domain/visit"
+	promoAdapters "bitmerchant/internal/promotion/adapters"
+	"bitmerchant/internal/promotion/domain/promotion"
 	restAdapters "bitmerchant/internal/restaurant/adapters"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 
@@ -56,6 +63,73 @@ func TestRestaurantRepository(t *testing.T) {
 		assert.Equal(t, "Next Monday", found.ReopeningHours)
 	})
 
+	t.Run("Tax settings roundtrip", func(t *testing.T) {
+		found, err := repo.FindByID(id)
+		require.NoError(t, err)
+		assert.True(t, found.TaxSettings.IsZero(), "unset until configured")
+
+		cfg := tax.Config{
+			Mode:                 tax.ModeInclusive,
+			Taxes:                []tax.Tax{{Name: "VAT", Rate: 700}, {Name: "Excise", Rate: 250, Classes: []tax.Class{"alcohol"}}},
+			ServiceChargeRate:    1000,
+			ServiceChargeTaxable: true,
+		}
+		require.NoError(t, r.SetTaxSettings(cfg))
+		require.NoError(t, repo.Update(r))
+
+		found, err = repo.FindByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, found.TaxSettings)
+	})
+
+	t.Run("Cash rounding roundtrip", func(t *testing.T) {
+		require.NoError(t, r.SetCashRounding("0.05"))
+		require.NoError(t, repo.Update(r))
+
+		found, err := repo.FindByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), found.CashRounding)
+	})
+
+	t.Run("Tip settings roundtrip", func(t *testing.T) {
+		found, err := repo.FindByID(id)
+		require.NoError(t, err)
+		assert.True(t, found.TipSettings.IsZero(), "unset until configured")
+
+		cfg := tip.Settings{Presets: []int{18, 20, 25}, Default: 20, Custom: true}
+		require.NoError(t, r.SetTipSettings(cfg))
+		require.NoError(t, repo.Update(r))
+
+		found, err = repo.FindByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, cfg, found.TipSettings)
+	})
+
+	t.Run("Tip pooling roundtrip", func(t *testing.T) {
+		found, err := repo.FindByID(id)
+		require.NoError(t, err)
+		assert.False(t, found.TipPooling.Enabled(), "unset until configured")
+
+		require.NoError(t, r.SetTipPooling(tip.Pooling{
+			Pools: []tip.Pool{
+				{Name: "Front of house", Share: 60, Roles: []common.MemberRole{common.RoleServer, common.RoleOwner}},
+				{Name: "Kitchen", Share: 40, Roles: []common.MemberRole{common.RoleKitchenStaff}},
+			},
+			Split: tip.SplitEqual,
+		}))
+		require.NoError(t, repo.Update(r))
+
+		found, err = repo.FindByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, r.TipPooling, found.TipPooling)
+
+		require.NoError(t, r.SetTipPooling(tip.Pooling{}))
+		require.NoError(t, repo.Update(r))
+		found, err = repo.FindByID(id)
+		require.NoError(t, err)
+		assert.False(t, found.TipPooling.Enabled())
+	})
+
 	t.Run("FindByID not found", func(t *testing.T) {
 		_, err := repo.FindByID("nonexistent")
 		assert.Error(t, err)
@@ -94,9 +168,13 @@ func TestMenuCategoryRepository(t *testing.T) {
 		cat.SetActive(false)
 		require.NoError(t, repo.Update(cat))
 
+		require.NoError(t, cat.SetTaxClass("alcohol"))
+		require.NoError(t, repo.Update(cat))
+
 		found, err := repo.FindByID("cat-1")
 		require.NoError(t, err)
 		assert.False(t, found.IsActive)
+		assert.Equal(t, tax.Class("alcohol"), found.TaxClass)
 	})
 
 	t.Run("Delete", func(t *testing.T) {
@@ -120,7 +198,7 @@ func TestMenuItemRepository(t *testing.T) {
 	cat, _ := menu.NewMenuCategory("cat-item-1", restID, "Mains", 1)
 	require.NoError(t, catRepo.Save(cat))
 
-	item, err := menu.NewMenuItem("item-1", "cat-item-1", restID, "Burger", 12.50)
+	item, err := menu.NewMenuItem("item-1", "cat-item-1", restID, "Burger", 1250)
 	require.NoError(t, err)
 
 	t.Run("Save and FindByID", func(t *testing.T) {
@@ -148,6 +226,15 @@ func TestMenuItemRepository(t *testing.T) {
 		assert.Empty(t, items)
 	})
 
+	t.Run("Tax class roundtrip", func(t *testing.T) {
+		require.NoError(t, item.SetTaxClass("takeaway"))
+		require.NoError(t, repo.Update(item))
+
+		found, err := repo.FindByID("item-1")
+		require.NoError(t, err)
+		assert.Equal(t, tax.Class("takeaway"), found.TaxClass)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		require.NoError(t, repo.Delete("item-1"))
 
@@ -170,16 +257,16 @@ func TestOrderRepository(t *testing.T) {
 	cat, _ := menu.NewMenuCategory("cat-ord-1", restID, "Food", 1)
 	require.NoError(t, catRepo.Save(cat))
 
-	menuItem, _ := menu.NewMenuItem("mi-ord-1", "cat-ord-1", restID, "Pizza", 15.00)
+	menuItem, _ := menu.NewMenuItem("mi-ord-1", "cat-ord-1", restID, "Pizza", 1500)
 	require.NoError(t, itemRepo.Save(menuItem))
 
-	orderItem, err := order.NewOrderItem("oi-1", "ord-1", "mi-ord-1", "Pizza", 2, 15.00)
+	orderItem, err := order.NewOrderItem("oi-1", "ord-1", "mi-ord-1", "Pizza", 2, 1500)
 	require.NoError(t, err)
 
 	o, err := order.NewOrder("ord-1", "0001", restID, "sess-1",
 		[]order.OrderItem{*orderItem}, 3000, common.PaymentMethodTypeCash)
 	require.NoError(t, err)
-	o.FiatAmount = 30.00
+	usdToSats := money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coingecko", AsOf: time.Now().UTC().Truncate(time.Second)}
 
 	t.Run("Save and FindByID with items", func(t *testing.T) {
 		require.NoError(t, repo.Save(o))
@@ -214,7 +301,74 @@ func TestOrderRepository(t *testing.T) {
 		assert.Len(t, orders, 1)
 	})
 
+	t.Run("Tax breakdown roundtrip", func(t *testing.T) {
+		taxItem, err := order.NewOrderItem("oi-tax-1", "ord-tax-1", "mi-ord-1", "Pizza", 1, 1500)
+		require.NoError(t, err)
+		taxed, err := order.NewOrderWithCurrency("ord-tax-1", "0002", restID, "sess-tax",
+			[]order.OrderItem{*taxItem}, 1500, 1650, 0, 0, "", "", common.PaymentMethodTypeCash, money.THB)
+		require.NoError(t, err)
+		lines := []tax.Charge{{Name: "VAT", Rate: 700, Amount: 108}}
+		require.NoError(t, taxed.SetTaxBreakdown(150, lines, true))
+		require.NoError(t, taxed.SetCashRounding(-5))
+		require.NoError(t, repo.Save(taxed))
+
+		found, err := repo.FindByID("ord-tax-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(150), found.ServiceCharge)
+		assert.Equal(t, int64(108), found.TaxAmount)
+		assert.Equal(t, lines, found.TaxLines)
+		assert.True(t, found.TaxInclusive)
+		assert.Equal(t, int64(-5), found.CashRounding)
+
+		legacy, err := repo.FindByID("ord-1")
+		require.NoError(t, err)
+		assert.Empty(t, legacy.TaxLines)
+		assert.False(t, legacy.TaxInclusive)
+	})
+
+	t.Run("Late tip roundtrip", func(t *testing.T) {
+		found, err := repo.FindByID("ord-tax-1")
+		require.NoError(t, err)
+		found.MarkPaid()
+		require.NoError(t, found.StartPreparing())
+		require.NoError(t, found.MarkReady())
+		require.NoError(t, found.AddTip("pay-tip-1", 200))
+		require.NoError(t, repo.Update(found))
+
+		found, err = repo.FindByID("ord-tax-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(200), found.LateTipAmount)
+		assert.Equal(t, common.PaymentID("pay-tip-1"), found.LateTipPaymentID)
+		assert.Equal(t, int64(200), found.TipAmount)
+		assert.Equal(t, int64(1850), found.TotalAmount)
+	})
+
+	t.Run("Split bill roundtrip", func(t *testing.T) {
+		require.NoError(t, o.Split([]order.BillPart{
+			{ID: "part-1", Label: "Guest 1", Amount: 2000, ItemIDs: []common.OrderItemID{"oi-1"}},
+			{ID: "part-2", Label: "Guest 2", Amount: 1000},
+		}))
+		_, err := o.SettlePart("part-1", "pay-part-1", common.PaymentMethodTypeLightning, usdToSats, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(o))
+
+		found, err := repo.FindByID("ord-1")
+		require.NoError(t, err)
+		assert.Equal(t, common.PaymentStatusPartiallyPaid, found.PaymentStatus)
+		require.Len(t, found.BillParts, 2)
+		assert.Equal(t, "Guest 1", found.BillParts[0].Label)
+		assert.Equal(t, []common.OrderItemID{"oi-1"}, found.BillParts[0].ItemIDs)
+		assert.Equal(t, common.PaymentID("pay-part-1"), found.BillParts[0].PaymentID)
+		assert.True(t, found.BillParts[0].IsPaid())
+		assert.Equal(t, usdToSats.Rate, found.BillParts[0].FXRate.Rate)
+		assert.True(t, usdToSats.AsOf.Equal(found.BillParts[0].FXRate.AsOf))
+		assert.True(t, found.BillParts[1].FXRate.IsZero())
+		assert.False(t, found.BillParts[1].IsPaid())
+		assert.Equal(t, int64(1000), found.Outstanding().Amount)
+	})
+
 	t.Run("Update status", func(t *testing.T) {
+		o.RecordExchangeRate(usdToSats)
 		o.MarkPaid()
 		require.NoError(t, o.StartPreparing())
 		require.NoError(t, repo.Update(o))
@@ -225,6 +379,23 @@ func TestOrderRepository(t *testing.T) {
 		assert.Equal(t, common.FulfillmentStatusPreparing, found.FulfillmentStatus)
 		assert.NotNil(t, found.PaidAt)
 		assert.NotNil(t, found.PreparingAt)
+		assert.Equal(t, "SAT", found.FXRate.To)
+		assert.Equal(t, "coingecko", found.FXRate.Source)
+		assert.True(t, usdToSats.AsOf.Equal(found.FXRate.AsOf))
+	})
+
+	t.Run("Cancel roundtrip", func(t *testing.T) {
+		require.NoError(t, o.Cancel(order.CancelReasonKitchenError, "user-1", "dropped"))
+		require.NoError(t, repo.Update(o))
+
+		found, err := repo.FindByID("ord-1")
+		require.NoError(t, err)
+		assert.Equal(t, common.FulfillmentStatusCancelled, found.FulfillmentStatus)
+		assert.Equal(t, common.PaymentStatusRefunded, found.PaymentStatus)
+		assert.NotNil(t, found.CancelledAt)
+		assert.Equal(t, common.UserID("user-1"), found.CancelledBy)
+		assert.Equal(t, order.CancelReasonKitchenError, found.CancelReason)
+		assert.Equal(t, "dropped", found.CancelNote)
 	})
 
 	t.Run("FindByID not found", func(t *testing.T) {
@@ -248,15 +419,15 @@ func TestPaymentRepository(t *testing.T) {
 	cat, _ := menu.NewMenuCategory("cat-pay-1", restID, "Food", 1)
 	require.NoError(t, catRepo.Save(cat))
 
-	menuItem, _ := menu.NewMenuItem("mi-pay-1", "cat-pay-1", restID, "Salad", 10.00)
+	menuItem, _ := menu.NewMenuItem("mi-pay-1", "cat-pay-1", restID, "Salad", 1000)
 	require.NoError(t, itemRepo.Save(menuItem))
 
-	oi, _ := order.NewOrderItem("oi-pay-1", "ord-pay-1", "mi-pay-1", "Salad", 1, 10.00)
+	oi, _ := order.NewOrderItem("oi-pay-1", "ord-pay-1", "mi-pay-1", "Salad", 1, 1000)
 	o, _ := order.NewOrder("ord-pay-1", "P001", restID, "sess-pay-1",
 		[]order.OrderItem{*oi}, 1000, common.PaymentMethodTypeCash)
 	require.NoError(t, orderRepo.Save(o))
 
-	pay, err := payment.NewPayment("pay-1", "ord-pay-1", restID, common.PaymentMethodTypeCash, 10.00)
+	pay, err := payment.NewPayment("pay-1", "ord-pay-1", restID, common.PaymentMethodTypeCash, 1000)
 	require.NoError(t, err)
 
 	t.Run("Save and FindByID", func(t *testing.T) {
@@ -284,6 +455,261 @@ func TestPaymentRepository(t *testing.T) {
 		assert.Equal(t, common.PaymentStatusPaid, found.Status)
 		assert.NotNil(t, found.PaidAt)
 	})
+
+	t.Run("Collection roundtrip", func(t *testing.T) {
+		cashPay, err := payment.NewPayment("pay-2", "ord-pay-1", restID, common.PaymentMethodTypeCash, 1000)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(cashPay))
+		cashPay.RoundingAdjustment = 2
+		require.NoError(t, cashPay.Collect(money.New(1500, money.USD), "user-1"))
+		require.NoError(t, repo.Update(cashPay))
+
+		found, err := repo.FindByID("pay-2")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1500), found.TenderedAmount)
+		assert.Equal(t, int64(500), found.ChangeGiven)
+		assert.Equal(t, common.UserID("user-1"), found.CollectedBy)
+		assert.Equal(t, int64(2), found.RoundingAdjustment)
+	})
+
+	t.Run("Bill part charges", func(t *testing.T) {
+		partPay, err := payment.NewPayment("pay-3", "ord-pay-1", restID, common.PaymentMethodTypeLightning, 500)
+		require.NoError(t, err)
+		partPay.BillPartID = "part-1"
+		partPay.FXRate = money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coinbase", AsOf: time.Now().UTC().Truncate(time.Second)}
+		require.NoError(t, repo.Save(partPay))
+
+		found, err := repo.FindByID("pay-3")
+		require.NoError(t, err)
+		assert.Equal(t, common.BillPartID("part-1"), found.BillPartID)
+		assert.Equal(t, partPay.FXRate.Rate, found.FXRate.Rate)
+		assert.Equal(t, "coinbase", found.FXRate.Source)
+		assert.True(t, partPay.FXRate.AsOf.Equal(found.FXRate.AsOf))
+
+		charges, err := repo.FindChargesByOrderID("ord-pay-1")
+		require.NoError(t, err)
+		require.Len(t, charges, 3)
+		assert.Equal(t, common.PaymentID("pay-1"), charges[0].ID)
+		assert.Equal(t, common.PaymentID("pay-3"), charges[2].ID)
+	})
+
+	t.Run("Refund roundtrip", func(t *testing.T) {
+		charge, err := repo.FindByID("pay-2")
+		require.NoError(t, err)
+		refund, err := payment.NewRefund("ref-1", charge, "Duplicate order", "user-1")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(refund))
+		require.NoError(t, charge.MarkRefunded("Duplicate order", *refund.PaidAt))
+		require.NoError(t, repo.Update(charge))
+
+		found, err := repo.FindByID("ref-1")
+		require.NoError(t, err)
+		assert.True(t, found.IsRefund())
+		assert.Equal(t, common.PaymentID("pay-2"), found.RefundOf)
+		assert.Equal(t, "Duplicate order", found.Reason)
+
+		found, err = repo.FindByID("pay-2")
+		require.NoError(t, err)
+		assert.Equal(t, common.PaymentStatusRefunded, found.Status)
+		assert.NotNil(t, found.RefundedAt)
+
+		byOrder, err := repo.FindByOrderID("ord-pay-1")
+		require.NoError(t, err)
+		assert.False(t, byOrder.IsRefund())
+	})
+
+	t.Run("Late tip charges", func(t *testing.T) {
+		tipPay, err := payment.NewPayment("pay-tip-1", "ord-pay-1", restID, common.PaymentMethodTypeLightning, 200)
+		require.NoError(t, err)
+		tipPay.Kind = payment.KindTip
+		require.NoError(t, repo.Save(tipPay))
+
+		found, err := repo.FindByID("pay-tip-1")
+		require.NoError(t, err)
+		assert.True(t, found.IsTip())
+
+		byOrder, err := repo.FindByOrderID("ord-pay-1")
+		require.NoError(t, err)
+		assert.NotEqual(t, common.PaymentID("pay-tip-1"), byOrder.ID, "a late tip is not the order's payment")
+
+		charges, err := repo.FindChargesByOrderID("ord-pay-1")
+		require.NoError(t, err)
+		assert.Equal(t, common.PaymentID("pay-tip-1"), charges[len(charges)-1].ID)
+	})
+}
+
+func TestShiftRepository(t *testing.T) {
+	db := setupPostgresContainer(t)
+	restRepo := restAdapters.NewPostgresRestaurantRepository(db)
+	repo := payAdapters.NewPostgresShiftRepository(db)
+
+	restID := common.RestaurantID("rest-shift-1")
+	r, _ := restaurant.NewRestaurant(restID, "Shift Test")
+	require.NoError(t, restRepo.Save(r))
+
+	s, err := shift.Open("shift-1", restID, "user-1", money.USD, 10000)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(s))
+
+	t.Run("FindOpenByStaff", func(t *testing.T) {
+		found, err := repo.FindOpenByStaff(restID, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, common.ShiftID("shift-1"), found.ID)
+		assert.Equal(t, int64(10000), found.OpeningFloat)
+		assert.Empty(t, found.Movements)
+
+		_, err = repo.FindOpenByStaff(restID, "user-2")
+		assert.ErrorIs(t, err, shift.ErrShiftNotFound)
+	})
+
+	t.Run("Movements and close roundtrip", func(t *testing.T) {
+		require.NoError(t, s.AddCashSale("pay-1", money.New(1250, money.USD), "user-1", time.Now()))
+		require.NoError(t, s.PayOut(money.New(2000, money.USD), "Milk", "user-1"))
+		require.NoError(t, s.Close(money.New(9200, money.USD), "user-1", "coins short"))
+		require.NoError(t, repo.Update(s))
+
+		found, err := repo.FindByID("shift-1")
+		require.NoError(t, err)
+		assert.Equal(t, shift.StatusClosed, found.Status)
+		require.Len(t, found.Movements, 2)
+		assert.Equal(t, common.PaymentID("pay-1"), found.Movements[0].PaymentID)
+		assert.Equal(t, "Milk", found.Movements[1].Reason)
+		assert.Equal(t, int64(9250), found.ExpectedCash())
+		assert.Equal(t, int64(-50), found.Variance())
+		require.NotNil(t, found.ClosedAt)
+
+		open, err := repo.FindOpenByRestaurant(restID)
+		require.NoError(t, err)
+		assert.Empty(t, open)
+		all, err := repo.FindByRestaurant(restID)
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+}
+
+func TestTimecardRepository(t *testing.T) {
+	db := setupPostgresContainer(t)
+	restRepo := restAdapters.NewPostgresRestaurantRepository(db)
+	repo := payAdapters.NewPostgresTimecardRepository(db)
+
+	restID := common.RestaurantID("rest-timecard-1")
+	r, _ := restaurant.NewRestaurant(restID, "Timecard Test")
+	require.NoError(t, restRepo.Save(r))
+
+	tc, err := timecard.ClockIn("tc-1", restID, "user-1", common.RoleServer)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(tc))
+
+	t.Run("FindOpenByStaff", func(t *testing.T) {
+		found, err := repo.FindOpenByStaff(restID, "user-1")
+		require.NoError(t, err)
+		assert.Equal(t, common.TimecardID("tc-1"), found.ID)
+		assert.Equal(t, common.RoleServer, found.Role)
+		assert.True(t, found.IsOpen())
+
+		_, err = repo.FindOpenByStaff(restID, "user-2")
+		assert.ErrorIs(t, err, timecard.ErrNotFound)
+	})
+
+	t.Run("Clock out and find by period", func(t *testing.T) {
+		require.NoError(t, tc.ClockOut(tc.ClockedInAt.Add(2*time.Hour)))
+		require.NoError(t, repo.Update(tc))
+
+		_, err := repo.FindOpenByStaff(restID, "user-1")
+		assert.ErrorIs(t, err, timecard.ErrNotFound)
+
+		mine, err := repo.FindByStaff(restID, "user-1", 5)
+		require.NoError(t, err)
+		require.Len(t, mine, 1)
+		require.NotNil(t, mine[0].ClockedOutAt)
+		assert.WithinDuration(t, tc.ClockedInAt.Add(2*time.Hour), *mine[0].ClockedOutAt, time.Millisecond)
+
+		during, err := repo.FindByRestaurant(restID, tc.ClockedInAt.Add(time.Hour), tc.ClockedInAt.Add(3*time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, during, 1, "cards overlapping the period count")
+		after, err := repo.FindByRestaurant(restID, tc.ClockedInAt.Add(3*time.Hour), tc.ClockedInAt.Add(4*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, after)
+	})
+}
+
+func TestPromotionRepository(t *testing.T) {
+	db := setupPostgresContainer(t)
+	restRepo := restAdapters.NewPostgresRestaurantRepository(db)
+	orderRepo := orderAdapters.NewPostgresOrderRepository(db)
+	repo := promoAdapters.NewPostgresPromotionRepository(db)
+
+	restID := common.RestaurantID("rest-promo-1")
+	r, _ := restaurant.NewRestaurant(restID, "Promo Test")
+	require.NoError(t, restRepo.Save(r))
+
+	ends := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	p, err := promotion.New("promo-1", restID, money.USD, promotion.Terms{
+		Name: "Launch", Code: "launch", Kind: promotion.KindPercentage, PercentOff: 20,
+		EndsAt: &ends, MaxUses: 2, MaxUsesPerSession: 1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(p))
+
+	t.Run("Save and FindByCode", func(t *testing.T) {
+		found, err := repo.FindByCode(restID, " Launch ")
+		require.NoError(t, err)
+		assert.Equal(t, common.PromotionID("promo-1"), found.ID)
+		assert.Equal(t, promotion.KindPercentage, found.Kind)
+		assert.Equal(t, 20, found.PercentOff)
+		require.NotNil(t, found.EndsAt)
+		assert.True(t, ends.Equal(*found.EndsAt))
+		assert.Nil(t, found.StartsAt)
+
+		dup, _ := promotion.New("promo-2", restID, money.USD, promotion.Terms{Name: "Copy", Code: "LAUNCH", Kind: promotion.KindFixed, AmountOff: 100})
+		assert.ErrorIs(t, repo.Save(dup), promotion.ErrCodeTaken)
+
+		_, err = repo.FindByCode(restID, "NOPE")
+		assert.ErrorIs(t, err, promotion.ErrPromotionNotFound)
+	})
+
+	t.Run("Redeem enforces limits", func(t *testing.T) {
+		redeem := func(session string, orderID common.OrderID) error {
+			return repo.Redeem(promotion.Redemption{PromotionID: "promo-1", SessionID: session, OrderID: orderID, Amount: 300, RedeemedAt: time.Now()})
+		}
+		require.NoError(t, redeem("sess-1", "ord-a"))
+		assert.ErrorIs(t, redeem("sess-1", "ord-b"), promotion.ErrSessionLimitReached)
+		require.NoError(t, redeem("sess-2", "ord-c"))
+		assert.ErrorIs(t, redeem("sess-3", "ord-d"), promotion.ErrUsageLimitReached)
+
+		n, err := repo.CountSessionRedemptions("promo-1", "sess-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		// Editing the terms never resets the usage counter.
+		p.SetActive(false)
+		require.NoError(t, repo.Update(p))
+		found, err := repo.FindByID("promo-1")
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.UsesCount)
+		assert.False(t, found.Active)
+	})
+
+	t.Run("Order discount roundtrip", func(t *testing.T) {
+		cat, _ := menu.NewMenuCategory("cat-promo-1", restID, "Food", 1)
+		require.NoError(t, menuAdapters.NewPostgresCategoryRepository(db).Save(cat))
+		menuItem, _ := menu.NewMenuItem("mi-promo-1", "cat-promo-1", restID, "Pizza", 1500)
+		require.NoError(t, menuAdapters.NewPostgresItemRepository(db).Save(menuItem))
+
+		item, err := order.NewOrderItem("oi-promo-1", "ord-promo-1", "mi-promo-1", "Pizza", 1, 1500)
+		require.NoError(t, err)
+		o, err := order.NewOrderWithCurrency("ord-promo-1", "0001", restID, "sess-1",
+			[]order.OrderItem{*item}, 1500, 1296, 96, 0, "Maya", "", common.PaymentMethodTypeCash, money.USD)
+		require.NoError(t, err)
+		require.NoError(t, o.ApplyDiscount("promo-1", "Launch (LAUNCH)", 300))
+		require.NoError(t, orderRepo.Save(o))
+
+		found, err := orderRepo.FindByID("ord-promo-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(300), found.DiscountAmount)
+		assert.Equal(t, common.PromotionID("promo-1"), found.PromotionID)
+		assert.Equal(t, "Launch (LAUNCH)", found.DiscountLabel)
+	})
 }
 
 func TestUserRepository(t *testing.T) {
@@ -0,0 +1,80 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common/outbox"
+	"bitmerchant/internal/infrastructure/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLettersReplayThroughTheOutbox(t *testing.T) {
+	db := setupPostgresContainer(t)
+	ctx := context.Background()
+	store := events.NewPostgresDeadLetters(db)
+
+	payload := []byte(`{"id":"msg-dead-1","type":"order.paid","version":1,"data":{}}`)
+	require.NoError(t, store.Add(ctx, events.DeadLetter{
+		MessageID: "msg-dead-1",
+		Topic:     "order.paid",
+		Handler:   "order_paid_sse",
+		Reason:    "render failed",
+		Payload:   payload,
+		DeadAt:    time.Now(),
+	}))
+	require.NoError(t, store.Add(ctx, events.DeadLetter{
+		MessageID: "msg-dead-2",
+		Topic:     "order.ready",
+		Handler:   "order_ready_notif",
+		Reason:    "push gateway down",
+		Payload:   []byte(`not json`),
+		DeadAt:    time.Now(),
+	}))
+
+	letters, err := store.List(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "msg-dead-1", letters[0].MessageID, "oldest first")
+	assert.Equal(t, "render failed", letters[0].Reason)
+	assert.Nil(t, letters[0].ReplayedAt)
+
+	t.Run("replay republishes under the original ID", func(t *testing.T) {
+		dl, err := store.Replay(ctx, letters[0].Seq)
+		require.NoError(t, err)
+		assert.Equal(t, "order.paid", dl.Topic)
+
+		var published []outbox.Message
+		_, err = outbox.NewPostgresStore(db).Dispatch(ctx, 10, func(m outbox.Message) error {
+			published = append(published, m)
+			return nil
+		}, func(int) time.Time { return time.Now() })
+		require.NoError(t, err)
+		require.Len(t, published, 1)
+		assert.Equal(t, "msg-dead-1", published[0].ID)
+		assert.Equal(t, "order.paid", published[0].Topic)
+		assert.JSONEq(t, string(payload), string(published[0].Payload))
+
+		_, err = store.Replay(ctx, letters[0].Seq)
+		assert.ErrorIs(t, err, events.ErrDeadLetterNotFound, "a dead letter is replayed once")
+
+		pending, err := store.List(ctx, false)
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+		all, err := store.List(ctx, true)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.NotNil(t, all[0].ReplayedAt)
+	})
+
+	t.Run("discard deletes without replaying", func(t *testing.T) {
+		require.NoError(t, store.Discard(ctx, letters[1].Seq))
+		assert.ErrorIs(t, store.Discard(ctx, letters[1].Seq), events.ErrDeadLetterNotFound)
+		pending, err := store.List(ctx, false)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+	})
+}
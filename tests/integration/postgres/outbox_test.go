@@ -0,0 +1,129 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/outbox"
+	orderAdapters "bitmerchant/internal/ordering/adapters"
+	orderevent "bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	restAdapters "bitmerchant/internal/restaurant/adapters"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderEventsGoThroughTheOutbox(t *testing.T) {
+	db := setupPostgresContainer(t)
+	ctx := context.Background()
+	restRepo := restAdapters.NewPostgresRestaurantRepository(db)
+	repo := orderAdapters.NewPostgresOrderRepository(db)
+	store := outbox.NewPostgresStore(db)
+
+	restID := common.RestaurantID("rest-outbox-1")
+	r, _ := restaurant.NewRestaurant(restID, "Outbox Test")
+	require.NoError(t, restRepo.Save(r))
+
+	item, err := order.NewOrderItem("oi-outbox-1", "ord-outbox-1", "mi-1", "Pizza", 1, 1500)
+	require.NoError(t, err)
+	o, err := order.NewOrder("ord-outbox-1", "0001", restID, "sess-1", []order.OrderItem{*item}, 1500, common.PaymentMethodTypeCash)
+	require.NoError(t, err)
+	o.Record(orderevent.OrderCreated{OrderID: o.ID, RestaurantID: restID, OrderNumber: o.OrderNumber, CreatedAt: o.CreatedAt})
+	require.NoError(t, repo.Save(o))
+	assert.Empty(t, o.Events(), "stored events are cleared")
+
+	pending := func() int {
+		var n int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM event_outbox`).Scan(&n))
+		return n
+	}
+	assert.Equal(t, 1, pending())
+
+	t.Run("a failed update stores no events", func(t *testing.T) {
+		ghost := *o
+		ghost.ID = "ord-missing"
+		ghost.Record(orderevent.OrderPaid{OrderID: ghost.ID, RestaurantID: restID, PaidAt: time.Now()})
+		require.Error(t, repo.Update(&ghost))
+		assert.Equal(t, 1, pending())
+		assert.Len(t, ghost.Events(), 1, "kept for the caller")
+	})
+
+	t.Run("prep toggles are stored with their event", func(t *testing.T) {
+		require.True(t, o.SetItemPrepComplete(item.ID, true))
+		o.Record(orderevent.OrderItemPrepToggled{OrderID: o.ID, RestaurantID: restID, ItemID: item.ID, PrepComplete: true})
+		require.NoError(t, repo.UpdateItemPrepComplete(o, item.ID))
+		assert.Equal(t, 2, pending())
+	})
+
+	t.Run("failed publishes are retried later; published ones leave", func(t *testing.T) {
+		fail := func(outbox.Message) error { return errors.New("broker unavailable") }
+		later := func(int) time.Time { return time.Now().Add(time.Hour) }
+		n, err := store.Dispatch(ctx, 10, fail, later)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+		var attempts int
+		var lastError string
+		require.NoError(t, db.QueryRow(`SELECT MIN(attempts), MIN(last_error) FROM event_outbox`).Scan(&attempts, &lastError))
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, "broker unavailable", lastError)
+
+		_, err = db.Exec(`UPDATE event_outbox SET next_attempt_at = NOW()`)
+		require.NoError(t, err)
+		var topics []string
+		n, err = store.Dispatch(ctx, 10, func(m outbox.Message) error {
+			topics = append(topics, m.Topic)
+			return nil
+		}, later)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, []string{common.EventOrderCreated, common.EventOrderItemPrepToggled}, topics, "in the order they were written")
+		assert.Zero(t, pending())
+	})
+}
+
+func TestPaymentEventsGoThroughTheOutbox(t *testing.T) {
+	db := setupPostgresContainer(t)
+	ctx := context.Background()
+	restRepo := restAdapters.NewPostgresRestaurantRepository(db)
+	orderRepo := orderAdapters.NewPostgresOrderRepository(db)
+	repo := payAdapters.NewPostgresPaymentRepository(db)
+
+	restID := common.RestaurantID("rest-outbox-2")
+	r, _ := restaurant.NewRestaurant(restID, "Payment Outbox Test")
+	require.NoError(t, restRepo.Save(r))
+	item, err := order.NewOrderItem("oi-outbox-2", "ord-outbox-2", "mi-1", "Pizza", 1, 1500)
+	require.NoError(t, err)
+	o, err := order.NewOrder("ord-outbox-2", "0002", restID, "sess-2", []order.OrderItem{*item}, 1500, common.PaymentMethodTypeCash)
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.Save(o))
+
+	topics := func() []string {
+		rows, err := db.Query(`SELECT topic FROM event_outbox ORDER BY seq`)
+		require.NoError(t, err)
+		defer rows.Close()
+		var out []string
+		for rows.Next() {
+			var topic string
+			require.NoError(t, rows.Scan(&topic))
+			out = append(out, topic)
+		}
+		require.NoError(t, rows.Err())
+		return out
+	}
+
+	record := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	refund := payCmd.NewRefundPaymentHandler(repo, nil, nil, nil)
+	_, err = record.Handle(ctx, payCmd.RecordPayment{OrderID: o.ID, RestaurantID: restID, Method: common.PaymentMethodTypeCash, Amount: money.New(1500, money.USD)})
+	require.NoError(t, err)
+	require.NoError(t, refund.Handle(ctx, payCmd.RefundPayment{OrderID: o.ID, Reason: "Duplicate order", RefundedBy: "user-1"}))
+
+	assert.Equal(t, []string{common.EventPaymentCompleted, common.EventPaymentRefunded}, topics())
+}
@@ -0,0 +1,41 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/infrastructure/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresDedupStore(t *testing.T) {
+	db := setupPostgresContainer(t)
+	ctx := context.Background()
+	store := events.NewPostgresDedupStore(db)
+
+	claim := func(handler, id string, ttl time.Duration) bool {
+		ok, err := store.Claim(ctx, handler, id, ttl)
+		require.NoError(t, err)
+		return ok
+	}
+
+	assert.True(t, claim("notif_order_ready", "msg-1", time.Hour))
+	assert.False(t, claim("notif_order_ready", "msg-1", time.Hour), "a redelivery is skipped")
+	assert.True(t, claim("notif_order_created", "msg-1", time.Hour), "claims are per handler")
+
+	require.NoError(t, store.Release(ctx, "notif_order_ready", "msg-1"))
+	assert.True(t, claim("notif_order_ready", "msg-1", time.Hour), "a released claim can be retaken")
+
+	_, err := db.Exec(`UPDATE event_processed SET processed_at = NOW() - INTERVAL '2 hours' WHERE handler = 'notif_order_created'`)
+	require.NoError(t, err)
+	assert.True(t, claim("notif_order_created", "msg-1", time.Hour), "an expired claim is taken over")
+
+	_, err = db.Exec(`UPDATE event_processed SET processed_at = NOW() - INTERVAL '2 hours'`)
+	require.NoError(t, err)
+	n, err := store.Purge(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+}
@@ -4,13 +4,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
 	"bitmerchant/internal/ordering/app/cart"
 )
 
 func TestComputeBreakdown_USDDefaultTip(t *testing.T) {
-	c := &cart.Cart{Total: 22.25, Currency: money.USD}
+	c := &cart.Cart{Total: 2225, Currency: money.USD}
 
 	bd := cart.ComputeBreakdown(c, 0.08, 15)
 
@@ -26,7 +28,7 @@ func TestComputeBreakdown_USDDefaultTip(t *testing.T) {
 }
 
 func TestComputeBreakdown_NoTip(t *testing.T) {
-	c := &cart.Cart{Total: 22.25, Currency: money.USD}
+	c := &cart.Cart{Total: 2225, Currency: money.USD}
 
 	bd := cart.ComputeBreakdown(c, 0.08, 0)
 
@@ -36,11 +38,11 @@ func TestComputeBreakdown_NoTip(t *testing.T) {
 }
 
 func TestComputeBreakdown_AllTipTiers(t *testing.T) {
-	c := &cart.Cart{Total: 22.25, Currency: money.USD}
+	c := &cart.Cart{Total: 2225, Currency: money.USD}
 
 	cases := map[int]int64{
 		0:  0,
-		10: 223, // round(22.25 * 0.10 * 100) = 222.5 → 223 (banker's: round-half-away)
+		10: 223, // 2225 * 10 / 100 = 222.5 → 223 (half rounds up)
 		15: 334,
 		20: 445,
 	}
@@ -63,6 +65,17 @@ func TestComputeBreakdown_SATCurrency(t *testing.T) {
 	assert.Equal(t, "18,450 sats", bd.Total.Format())
 }
 
+func TestComputeBreakdown_HalfCentRoundsUpExactly(t *testing.T) {
+	// $10.00 at 8.25% is exactly 82.5 cents; integer math rounds it up
+	// instead of depending on how 0.0825 happens to land in binary.
+	c := &cart.Cart{Total: 1000, Currency: money.USD}
+
+	bd := cart.ComputeBreakdown(c, 0.0825, 0)
+
+	assert.Equal(t, int64(83), bd.Tax.Amount)
+	assert.Equal(t, int64(1083), bd.Total.Amount)
+}
+
 func TestComputeBreakdown_ZeroCart(t *testing.T) {
 	c := &cart.Cart{Total: 0, Currency: money.USD}
 
@@ -82,3 +95,83 @@ func TestIsAllowedTipPercent(t *testing.T) {
 		assert.False(t, cart.IsAllowedTipPercent(bad))
 	}
 }
+
+func TestComputeBreakdownWithDiscount_TaxOnDiscountedTipOnFull(t *testing.T) {
+	c := &cart.Cart{Total: 2500, Currency: money.USD}
+	d := cart.Discount{PromotionID: "promo-1", Code: "FIVE", Label: "Five off (FIVE)", Amount: 500}
+
+	bd := cart.ComputeBreakdownWithDiscount(c, tax.Exclusive(0.08), 10, d)
+
+	// $25.00 − $5.00 = $20.00 taxable; tax $1.60; tip 10% of $25.00 = $2.50.
+	assert.Equal(t, int64(2500), bd.Subtotal.Amount)
+	assert.Equal(t, int64(500), bd.Discount.Amount)
+	assert.Equal(t, int64(160), bd.Tax.Amount)
+	assert.Equal(t, int64(250), bd.Tip.Amount)
+	assert.Equal(t, int64(2410), bd.Total.Amount)
+	assert.Equal(t, "Five off (FIVE)", bd.Promotion.Label)
+
+	bd = cart.ComputeBreakdownWithDiscount(c, tax.Exclusive(0.08), 0, cart.Discount{PromotionID: "promo-1", Amount: 9000})
+	assert.Equal(t, int64(2500), bd.Discount.Amount, "discount capped at the subtotal")
+	assert.Equal(t, int64(0), bd.Total.Amount)
+}
+
+func TestComputeBreakdownWithDiscount_InclusiveVATAndServiceCharge(t *testing.T) {
+	// Thai style: prices include 7% VAT, 10% service charge is itself subject
+	// to VAT. Beer is also taxed as alcohol.
+	c := &cart.Cart{Total: 1000_00, Currency: money.THB, Items: []cart.CartItem{
+		{Name: "Pad thai", Subtotal: 800_00},
+		{Name: "Beer", Subtotal: 200_00, TaxClass: "alcohol"},
+	}}
+	cfg := tax.Config{
+		Mode: tax.ModeInclusive,
+		Taxes: []tax.Tax{
+			{Name: "VAT", Rate: 700},
+			{Name: "Excise", Rate: 1000, Classes: []tax.Class{"alcohol"}},
+		},
+		ServiceChargeRate:    1000,
+		ServiceChargeTaxable: true,
+	}
+
+	bd := cart.ComputeBreakdownWithDiscount(c, cfg, 0, cart.Discount{})
+
+	assert.True(t, bd.TaxInclusive)
+	assert.Equal(t, int64(100_00), bd.ServiceCharge.Amount)
+	require.Len(t, bd.Taxes, 2)
+	// VAT: (800 + 100 service) * 7/107 + 200 * 7/117.
+	assert.Equal(t, tax.Charge{Name: "VAT", Rate: 700, Amount: 58_88 + 11_97}, bd.Taxes[0])
+	assert.Equal(t, tax.Charge{Name: "Excise", Rate: 1000, Amount: 17_09}, bd.Taxes[1])
+	assert.Equal(t, int64(58_88+11_97+17_09), bd.Tax.Amount)
+	assert.Equal(t, int64(1100_00), bd.Total.Amount, "inclusive tax is already in the prices")
+}
+
+func TestBreakdownWithCashRounding(t *testing.T) {
+	c := &cart.Cart{Total: 1000, Currency: money.THB}
+
+	// ฿10.00 + 7% = ฿10.70; tip 15% = ฿1.50; ฿12.20 rounds to ฿12.25.
+	bd := cart.ComputeBreakdownWithDiscount(c, tax.Exclusive(0.07), 15, cart.Discount{}).WithCashRounding(25)
+	assert.Equal(t, int64(5), bd.CashRounding.Amount)
+	assert.Equal(t, int64(1225), bd.Total.Amount)
+	assert.Equal(t, bd.Subtotal.Amount+bd.Tax.Amount+bd.Tip.Amount+bd.CashRounding.Amount, bd.Total.Amount, "the rounding line reconciles")
+
+	// ฿10.80 rounds down to ฿10.75.
+	bd = cart.ComputeBreakdownWithDiscount(c, tax.Exclusive(0.08), 0, cart.Discount{}).WithCashRounding(25)
+	assert.Equal(t, int64(-5), bd.CashRounding.Amount)
+	assert.Equal(t, int64(1075), bd.Total.Amount)
+
+	bd = cart.ComputeBreakdownWithDiscount(&cart.Cart{Total: 10, Currency: money.THB}, tax.Exclusive(0), 0, cart.Discount{}).WithCashRounding(25)
+	assert.Zero(t, bd.CashRounding.Amount, "a total is never rounded away")
+	assert.Equal(t, int64(10), bd.Total.Amount)
+}
+
+func TestBreakdownWithTip(t *testing.T) {
+	c := &cart.Cart{Total: 1000, Currency: money.THB}
+
+	// ฿10.00 + 7% = ฿10.70 with a ฿2.35 tip typed in; cash rounds ฿13.05 to ฿13.00.
+	bd := cart.ComputeBreakdownWithDiscount(c, tax.Exclusive(0.07), 0, cart.Discount{}).WithTip(235)
+	assert.Equal(t, int64(235), bd.Tip.Amount)
+	assert.Equal(t, int64(1305), bd.Total.Amount)
+
+	bd = bd.WithCashRounding(25)
+	assert.Equal(t, int64(-5), bd.CashRounding.Amount)
+	assert.Equal(t, bd.Subtotal.Amount+bd.Tax.Amount+bd.Tip.Amount+bd.CashRounding.Amount, bd.Total.Amount)
+}
@@ -19,7 +19,7 @@ func TestCartService(t *testing.T) {
 	// Wait, checking implementation of AddItem:
 	// func (s *CartService) AddItem(sessionID string, item *menu.MenuItem, quantity int) error
 
-	item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 10.0)
+	item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
 
 	t.Run("AddItem", func(t *testing.T) {
 		err := s.AddItem(sessionID, item, 2)
@@ -28,8 +28,8 @@ func TestCartService(t *testing.T) {
 		c := s.GetCart(sessionID)
 		assert.Len(t, c.Items, 1)
 		assert.Equal(t, 2, c.Items[0].Quantity)
-		assert.Equal(t, 20.0, c.Items[0].Subtotal)
-		assert.Equal(t, 20.0, c.Total)
+		assert.Equal(t, int64(2000), c.Items[0].Subtotal)
+		assert.Equal(t, int64(2000), c.Total)
 	})
 
 	t.Run("AddItem existing", func(t *testing.T) {
@@ -39,7 +39,7 @@ func TestCartService(t *testing.T) {
 		c := s.GetCart(sessionID)
 		assert.Len(t, c.Items, 1)
 		assert.Equal(t, 3, c.Items[0].Quantity) // 2 + 1
-		assert.Equal(t, 30.0, c.Total)
+		assert.Equal(t, int64(3000), c.Total)
 	})
 
 	t.Run("RemoveItem", func(t *testing.T) {
@@ -48,7 +48,7 @@ func TestCartService(t *testing.T) {
 
 		c := s.GetCart(sessionID)
 		assert.Empty(t, c.Items)
-		assert.Equal(t, 0.0, c.Total)
+		assert.Equal(t, int64(0), c.Total)
 	})
 
 	t.Run("ClearCart", func(t *testing.T) {
@@ -62,33 +62,33 @@ func TestCartService(t *testing.T) {
 	t.Run("DecrementItem reduces qty by 1", func(t *testing.T) {
 		s3 := cart.NewCartService()
 		sid := "session_dec"
-		item3, _ := menu.NewMenuItem("id3", "c1", "r1", "Pizza", 12.0)
+		item3, _ := menu.NewMenuItem("id3", "c1", "r1", "Pizza", 1200)
 		require.NoError(t, s3.AddItem(sid, item3, 3))
 
 		require.NoError(t, s3.DecrementItem(sid, "id3"))
 		c := s3.GetCart(sid)
 		assert.Len(t, c.Items, 1)
 		assert.Equal(t, 2, c.Items[0].Quantity)
-		assert.InDelta(t, 24.0, c.Total, 0.001)
+		assert.Equal(t, int64(2400), c.Total)
 	})
 
 	t.Run("DecrementItem removes item at zero", func(t *testing.T) {
 		s4 := cart.NewCartService()
 		sid := "session_dec2"
-		item4, _ := menu.NewMenuItem("id4", "c1", "r1", "Salad", 8.0)
+		item4, _ := menu.NewMenuItem("id4", "c1", "r1", "Salad", 800)
 		require.NoError(t, s4.AddItem(sid, item4, 1))
 
 		require.NoError(t, s4.DecrementItem(sid, "id4"))
 		c := s4.GetCart(sid)
 		assert.Empty(t, c.Items)
-		assert.Equal(t, 0.0, c.Total)
+		assert.Equal(t, int64(0), c.Total)
 	})
 
 	t.Run("switch restaurant clears cart", func(t *testing.T) {
 		s2 := cart.NewCartService()
 		sid := "session_switch"
-		a, _ := menu.NewMenuItem("ia", "c1", "ra", "A", 5)
-		b, _ := menu.NewMenuItem("ib", "c2", "rb", "B", 7)
+		a, _ := menu.NewMenuItem("ia", "c1", "ra", "A", 500)
+		b, _ := menu.NewMenuItem("ib", "c2", "rb", "B", 700)
 		require.NoError(t, s2.AddItem(sid, a, 1))
 		c := s2.GetCart(sid)
 		assert.Equal(t, common.RestaurantID("ra"), c.RestaurantID)
@@ -19,7 +19,7 @@ func TestPaidOrdersForRestaurantHandler(t *testing.T) {
 	restaurantID := common.RestaurantID("r1")
 
 	// Seed orders
-	items := []order.OrderItem{{MenuItemID: "i1", Name: "Item 1", Quantity: 1, UnitPrice: 10.0, Subtotal: 10.0}}
+	items := []order.OrderItem{{MenuItemID: "i1", Name: "Item 1", Quantity: 1, UnitPrice: 1000, Subtotal: 1000}}
 
 	o1, _ := order.NewOrder("o1", "1001", restaurantID, "session_1", items, 1000, common.PaymentMethodTypeCash)
 	o1.PaymentStatus = common.PaymentStatusPaid
@@ -2,6 +2,7 @@ package dashboard_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
 	dashboard "bitmerchant/internal/dashboard/app/query"
 
 	"bitmerchant/internal/infrastructure/repositories/memory"
@@ -23,41 +24,37 @@ func TestRestaurantDashboardStatsHandler(t *testing.T) {
 	prevMonth := startOfMonth.AddDate(0, -1, 0).Add(12 * time.Hour)
 
 	// Seed orders
-	items := []order.OrderItem{{MenuItemID: "i1", Name: "Item 1", Quantity: 1, UnitPrice: 10.0, Subtotal: 10.0}}
+	items := []order.OrderItem{{MenuItemID: "i1", Name: "Item 1", Quantity: 1, UnitPrice: 1000, Subtotal: 1000}}
 
 	// Order 1: Today, Paid, $10. CreatedAt pinned to start-of-today so the
 	// fixture stays "today" but never future-dated when the suite runs near
 	// midnight (pre-PR-#114 the window was open-ended; now end == now).
 	o1, _ := order.NewOrder("o1", "1001", restaurantID, "session_1", items, 1000, common.PaymentMethodTypeCash)
-	o1.FiatAmount = 10.0
 	o1.PaymentStatus = common.PaymentStatusPaid
 	o1.CreatedAt = startOfToday
+	o1.RecordExchangeRate(money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coingecko", AsOf: startOfToday})
 	_ = orderRepo.Save(o1)
 
 	// Order 2: 2 days ago, Paid, $20 (in week and month)
 	o2, _ := order.NewOrder("o2", "1002", restaurantID, "session_1", items, 2000, common.PaymentMethodTypeCash)
-	o2.FiatAmount = 20.0
 	o2.PaymentStatus = common.PaymentStatusPaid
 	o2.CreatedAt = startOfToday.AddDate(0, 0, -2)
 	_ = orderRepo.Save(o2)
 
 	// Order 3: 10 days ago, Paid, $50 (excluded from week, included in month only if still same month)
 	o3, _ := order.NewOrder("o3", "1003", restaurantID, "session_1", items, 5000, common.PaymentMethodTypeCash)
-	o3.FiatAmount = 50.0
 	o3.PaymentStatus = common.PaymentStatusPaid
 	o3.CreatedAt = startOfToday.AddDate(0, 0, -10)
 	_ = orderRepo.Save(o3)
 
 	// Order 4: Today, Pending (always excluded)
 	o4, _ := order.NewOrder("o4", "1004", restaurantID, "session_1", items, 500, common.PaymentMethodTypeCash)
-	o4.FiatAmount = 5.0
 	o4.PaymentStatus = common.PaymentStatusPending
 	o4.CreatedAt = startOfToday
 	_ = orderRepo.Save(o4)
 
 	// Order 5: Previous month, Paid, $80 (excluded from month and week/today)
 	o5, _ := order.NewOrder("o5", "1005", restaurantID, "session_1", items, 8000, common.PaymentMethodTypeCash)
-	o5.FiatAmount = 80.0
 	o5.PaymentStatus = common.PaymentStatusPaid
 	o5.CreatedAt = prevMonth
 	_ = orderRepo.Save(o5)
@@ -70,8 +67,9 @@ func TestRestaurantDashboardStatsHandler(t *testing.T) {
 		assert.NoError(t, err)
 
 		assert.Equal(t, 1, stats.OrderCount)
-		assert.Equal(t, 10.0, stats.TotalSales)
-		assert.Equal(t, 10.0, stats.AverageOrderValue)
+		assert.Equal(t, int64(1000), stats.TotalSales)
+		assert.Equal(t, int64(1000), stats.AverageOrderValue)
+		assert.Equal(t, int64(20000), stats.SatsAtSale)
 	})
 
 	t.Run("Get Weekly Stats", func(t *testing.T) {
@@ -82,8 +80,9 @@ func TestRestaurantDashboardStatsHandler(t *testing.T) {
 		assert.NoError(t, err)
 
 		assert.Equal(t, 2, stats.OrderCount)
-		assert.Equal(t, 30.0, stats.TotalSales)
-		assert.Equal(t, 15.0, stats.AverageOrderValue)
+		assert.Equal(t, int64(3000), stats.TotalSales)
+		assert.Equal(t, int64(1500), stats.AverageOrderValue)
+		assert.Equal(t, money.USD, stats.Currency)
 	})
 
 	t.Run("Get Monthly Stats", func(t *testing.T) {
@@ -94,15 +93,17 @@ func TestRestaurantDashboardStatsHandler(t *testing.T) {
 		assert.NoError(t, err)
 
 		expectedCount := 2 // o1 + o2
-		expectedSales := 30.0
+		expectedSales := int64(3000)
+		expectedAvg := int64(1500)
 		if !o3.CreatedAt.Before(startOfMonth) {
 			expectedCount++
-			expectedSales += 50.0
+			expectedSales += 5000
+			expectedAvg = 2667 // $80.00 / 3 rounds to the nearest cent
 		}
 
 		assert.Equal(t, expectedCount, stats.OrderCount)
 		assert.Equal(t, expectedSales, stats.TotalSales)
-		assert.Equal(t, expectedSales/float64(expectedCount), stats.AverageOrderValue)
+		assert.Equal(t, expectedAvg, stats.AverageOrderValue)
 	})
 
 	t.Run("Invalid Range Defaults To Today", func(t *testing.T) {
@@ -113,7 +114,7 @@ func TestRestaurantDashboardStatsHandler(t *testing.T) {
 		assert.NoError(t, err)
 
 		assert.Equal(t, 1, stats.OrderCount)
-		assert.Equal(t, 10.0, stats.TotalSales)
-		assert.Equal(t, 10.0, stats.AverageOrderValue)
+		assert.Equal(t, int64(1000), stats.TotalSales)
+		assert.Equal(t, int64(1000), stats.AverageOrderValue)
 	})
 }
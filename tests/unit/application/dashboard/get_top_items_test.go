@@ -20,8 +20,8 @@ func TestTopSellingMenuItemsHandler(t *testing.T) {
 
 	// Order 1: 2 Burgers, 1 Soda
 	items1 := []order.OrderItem{
-		{MenuItemID: "burger", Name: "Burger", Quantity: 2, UnitPrice: 10.0, Subtotal: 20.0},
-		{MenuItemID: "soda", Name: "Soda", Quantity: 1, UnitPrice: 3.0, Subtotal: 3.0},
+		{MenuItemID: "burger", Name: "Burger", Quantity: 2, UnitPrice: 1000, Subtotal: 2000},
+		{MenuItemID: "soda", Name: "Soda", Quantity: 1, UnitPrice: 300, Subtotal: 300},
 	}
 	o1, _ := order.NewOrder("o1", "1001", restaurantID, "session_1", items1, 2300, common.PaymentMethodTypeCash)
 	o1.PaymentStatus = common.PaymentStatusPaid
@@ -29,7 +29,7 @@ func TestTopSellingMenuItemsHandler(t *testing.T) {
 
 	// Order 2: 1 Burger
 	items2 := []order.OrderItem{
-		{MenuItemID: "burger", Name: "Burger", Quantity: 1, UnitPrice: 10.0, Subtotal: 10.0},
+		{MenuItemID: "burger", Name: "Burger", Quantity: 1, UnitPrice: 1000, Subtotal: 1000},
 	}
 	o2, _ := order.NewOrder("o2", "1002", restaurantID, "session_1", items2, 1000, common.PaymentMethodTypeCash)
 	o2.PaymentStatus = common.PaymentStatusPaid
@@ -37,7 +37,7 @@ func TestTopSellingMenuItemsHandler(t *testing.T) {
 
 	// Order 3 (Unpaid): 10 Steaks (Should be excluded)
 	items3 := []order.OrderItem{
-		{MenuItemID: "steak", Name: "Steak", Quantity: 10, UnitPrice: 50.0, Subtotal: 500.0},
+		{MenuItemID: "steak", Name: "Steak", Quantity: 10, UnitPrice: 5000, Subtotal: 50000},
 	}
 	o3, _ := order.NewOrder("o3", "1003", restaurantID, "session_1", items3, 50000, common.PaymentMethodTypeCash)
 	o3.PaymentStatus = common.PaymentStatusPending
@@ -54,10 +54,10 @@ func TestTopSellingMenuItemsHandler(t *testing.T) {
 
 		assert.Equal(t, "Burger", items[0].Name)
 		assert.Equal(t, 3, items[0].Quantity)
-		assert.Equal(t, 30.0, items[0].Revenue)
+		assert.Equal(t, int64(3000), items[0].Revenue)
 
 		assert.Equal(t, "Soda", items[1].Name)
 		assert.Equal(t, 1, items[1].Quantity)
-		assert.Equal(t, 3.0, items[1].Revenue)
+		assert.Equal(t, int64(300), items[1].Revenue)
 	})
 }
@@ -0,0 +1,114 @@
+package kitchen_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	kitchenCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelOrderHandler_Handle(t *testing.T) {
+	t.Run("refunds a paid order and publishes order.cancelled", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusPreparing, common.PaymentStatusPaid)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		var gotRefund bool
+		var gotReason string
+		canceller := func(_ context.Context, o *order.Order, refund bool, reason string, _ common.UserID) error {
+			assert.False(t, o.IsCancelled(), "payment is reversed before the order changes")
+			gotRefund, gotReason = refund, reason
+			return nil
+		}
+
+		uc := kitchenCmd.NewCancelOrderHandler(repo, kitchenCmd.PaymentCancellerFunc(canceller), nil, nil)
+		o, err := uc.Handle(context.Background(), kitchenCmd.CancelOrder{
+			OrderID: "order-1", RestaurantID: "rest-1", Reason: order.CancelReasonKitchenError, Note: "dropped", CancelledBy: "user-1",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, gotRefund)
+		assert.Equal(t, "Kitchen error: dropped", gotReason)
+		assert.Equal(t, common.PaymentStatusRefunded, o.PaymentStatus)
+		require.Len(t, repo.published, 1)
+		ev := repo.published[0].(event.OrderCancelled)
+		assert.Equal(t, common.EventOrderCancelled, ev.EventName())
+		assert.True(t, ev.Refunded)
+		assert.Equal(t, "kitchen_error", ev.Reason)
+	})
+
+	t.Run("voids an unpaid order", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		gotRefund := true
+		canceller := func(_ context.Context, _ *order.Order, refund bool, _ string, _ common.UserID) error {
+			gotRefund = refund
+			return nil
+		}
+
+		uc := kitchenCmd.NewCancelOrderHandler(repo, kitchenCmd.PaymentCancellerFunc(canceller), nil, nil)
+		o, err := uc.Handle(context.Background(), kitchenCmd.CancelOrder{OrderID: "order-1", Reason: order.CancelReasonDuplicate, CancelledBy: "user-1"})
+
+		require.NoError(t, err)
+		assert.False(t, gotRefund)
+		assert.Equal(t, common.PaymentStatusVoided, o.PaymentStatus)
+	})
+
+	t.Run("leaves the order alone when the refund fails", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusReady, common.PaymentStatusPaid)
+		updated := false
+		repo := &mockOrderRepo{
+			findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil },
+			updateFn: func(*order.Order) error {
+				updated = true
+				return nil
+			},
+		}
+		canceller := func(context.Context, *order.Order, bool, string, common.UserID) error {
+			return errors.New("drawer unavailable")
+		}
+
+		uc := kitchenCmd.NewCancelOrderHandler(repo, kitchenCmd.PaymentCancellerFunc(canceller), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.CancelOrder{OrderID: "order-1", Reason: order.CancelReasonOutOfStock, CancelledBy: "user-1"})
+
+		assert.Error(t, err)
+		assert.False(t, updated)
+		assert.False(t, existing.IsCancelled())
+		assert.Equal(t, common.PaymentStatusPaid, existing.PaymentStatus)
+	})
+
+	t.Run("scopes the order to the restaurant", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+
+		uc := kitchenCmd.NewCancelOrderHandler(repo, kitchenCmd.PaymentCancellerFunc(cancelNothing), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.CancelOrder{OrderID: "order-1", RestaurantID: "rest-2", Reason: order.CancelReasonDuplicate, CancelledBy: "user-1"})
+
+		assert.EqualError(t, err, "order not found")
+	})
+}
+
+func TestMarkOrderPaidHandler_RejectsCancelledOrder(t *testing.T) {
+	existing := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+	require.NoError(t, existing.Cancel(order.CancelReasonDuplicate, "user-1", ""))
+	recorded := false
+	record := func(context.Context, *order.Order, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+		recorded = true
+		return kitchenCmd.SettledPayment{}, nil
+	}
+	repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+
+	uc := kitchenCmd.NewMarkOrderPaidHandler(repo, kitchenCmd.PaymentRecorderFunc(record), nil, nil)
+	_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-1"})
+
+	assert.ErrorIs(t, err, order.ErrOrderCancelled)
+	assert.False(t, recorded)
+}
+
+func cancelNothing(context.Context, *order.Order, bool, string, common.UserID) error { return nil }
@@ -2,9 +2,9 @@ package kitchen_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/envelope"
 	"bitmerchant/internal/ordering/domain/order"
 
-	"context"
 	"time"
 )
 
@@ -16,13 +16,29 @@ type mockOrderRepo struct {
 	findActiveByRestaurantIDFn func(restaurantID common.RestaurantID) ([]*order.Order, error)
 	findBySessionIDFn          func(sessionID string) ([]*order.Order, error)
 	updateFn                   func(order *order.Order) error
+
+	// published collects the events stored with saved orders, as the
+	// outbox would.
+	published []common.DomainEvent
+}
+
+// store mimics a repository writing o's events to the outbox.
+func (m *mockOrderRepo) store(o *order.Order, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, ev := range o.Events() {
+		m.published = append(m.published, envelope.Unstamp(ev))
+	}
+	o.ClearEvents()
+	return nil
 }
 
 func (m *mockOrderRepo) Save(order *order.Order) error {
 	if m.saveFn != nil {
-		return m.saveFn(order)
+		return m.store(order, m.saveFn(order))
 	}
-	return nil
+	return m.store(order, nil)
 }
 
 func (m *mockOrderRepo) FindByID(id common.OrderID) (*order.Order, error) {
@@ -62,28 +78,17 @@ func (m *mockOrderRepo) FindBySessionID(sessionID string) ([]*order.Order, error
 
 func (m *mockOrderRepo) Update(order *order.Order) error {
 	if m.updateFn != nil {
-		return m.updateFn(order)
+		return m.store(order, m.updateFn(order))
 	}
-	return nil
+	return m.store(order, nil)
 }
 
 func (m *mockOrderRepo) NextOrderNumber(restaurantID common.RestaurantID) (int, error) {
 	return 1, nil
 }
 
-func (m *mockOrderRepo) UpdateItemPrepComplete(orderID common.OrderID, itemID common.OrderItemID, complete bool) error {
-	return nil
-}
-
-type mockEventBus struct {
-	publishFn func(ctx context.Context, topic string, event interface{}) error
-}
-
-func (m *mockEventBus) Publish(ctx context.Context, topic string, event interface{}) error {
-	if m.publishFn != nil {
-		return m.publishFn(ctx, topic, event)
-	}
-	return nil
+func (m *mockOrderRepo) UpdateItemPrepComplete(o *order.Order, itemID common.OrderItemID) error {
+	return m.store(o, nil)
 }
 
 // Helper to create a valid order
@@ -9,6 +9,7 @@ import (
 	"bitmerchant/internal/ordering/domain/order"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMarkOrderCompletedHandler_Handle(t *testing.T) {
@@ -17,7 +18,6 @@ func TestMarkOrderCompletedHandler_Handle(t *testing.T) {
 		existingOrder := createTestOrder("order-123", common.FulfillmentStatusReady, common.PaymentStatusPaid)
 
 		var savedOrder *order.Order
-		var publishedEvent string
 
 		mockOrderRepo := &mockOrderRepo{
 			findByIDFn: func(id common.OrderID) (*order.Order, error) {
@@ -29,20 +29,14 @@ func TestMarkOrderCompletedHandler_Handle(t *testing.T) {
 			},
 		}
 
-		mockEventBus := &mockEventBus{
-			publishFn: func(ctx context.Context, topic string, event interface{}) error {
-				publishedEvent = topic
-				return nil
-			},
-		}
-
-		uc := kitchenCmd.NewMarkOrderCompletedHandler(mockOrderRepo, mockEventBus, nil, nil)
+		uc := kitchenCmd.NewMarkOrderCompletedHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderCompleted{OrderID: orderID})
 
 		assert.NoError(t, err)
 		assert.Equal(t, common.FulfillmentStatusCompleted, savedOrder.FulfillmentStatus)
 		assert.NotNil(t, savedOrder.CompletedAt)
-		assert.Equal(t, common.EventOrderCompleted, publishedEvent)
+		require.Len(t, mockOrderRepo.published, 1)
+		assert.Equal(t, common.EventOrderCompleted, mockOrderRepo.published[0].EventName())
 	})
 
 	t.Run("fails if status transition is invalid", func(t *testing.T) {
@@ -56,7 +50,7 @@ func TestMarkOrderCompletedHandler_Handle(t *testing.T) {
 			},
 		}
 
-		uc := kitchenCmd.NewMarkOrderCompletedHandler(mockOrderRepo, &mockEventBus{}, nil, nil)
+		uc := kitchenCmd.NewMarkOrderCompletedHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderCompleted{OrderID: orderID})
 
 		assert.Error(t, err)
@@ -5,10 +5,14 @@ import (
 	kitchenCmd "bitmerchant/internal/ordering/app/command"
 	"bitmerchant/internal/ordering/domain/order"
 
+	"bitmerchant/internal/common/money"
+
 	"context"
 	"errors"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -18,7 +22,6 @@ func TestMarkOrderPaidHandler_Handle(t *testing.T) {
 		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPending)
 
 		var savedOrder *order.Order
-		var publishedEvent string
 
 		mockOrderRepo := &mockOrderRepo{
 			findByIDFn: func(id common.OrderID) (*order.Order, error) {
@@ -33,21 +36,95 @@ func TestMarkOrderPaidHandler_Handle(t *testing.T) {
 			},
 		}
 
-		mockEventBus := &mockEventBus{
-			publishFn: func(ctx context.Context, topic string, event interface{}) error {
-				publishedEvent = topic
-				return nil
-			},
-		}
-
-		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, mockEventBus, nil, nil)
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(recordNothing), nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: orderID})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, savedOrder)
 		assert.Equal(t, common.PaymentStatusPaid, savedOrder.PaymentStatus)
 		assert.NotNil(t, savedOrder.PaidAt)
-		assert.Equal(t, "order.paid", publishedEvent)
+		require.Len(t, mockOrderRepo.published, 1)
+		assert.Equal(t, "order.paid", mockOrderRepo.published[0].EventName())
+	})
+
+	t.Run("keeps the exchange rate the payment was taken at", func(t *testing.T) {
+		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		rate := money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coingecko", AsOf: time.Now()}
+
+		var savedOrder *order.Order
+		mockOrderRepo := &mockOrderRepo{
+			findByIDFn: func(common.OrderID) (*order.Order, error) { return existingOrder, nil },
+			updateFn: func(o *order.Order) error {
+				savedOrder = o
+				return nil
+			},
+		}
+		record := func(context.Context, *order.Order, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+			return kitchenCmd.SettledPayment{PaymentID: "pay-1", FXRate: rate}, nil
+		}
+
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(record), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-123"})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, savedOrder) {
+			assert.Equal(t, rate, savedOrder.FXRate)
+		}
+	})
+
+	t.Run("uses a payment the payment context already settled", func(t *testing.T) {
+		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		rate := money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coingecko", AsOf: time.Now()}
+		mockOrderRepo := &mockOrderRepo{
+			findByIDFn: func(common.OrderID) (*order.Order, error) { return existingOrder, nil },
+			updateFn:   func(*order.Order) error { return nil },
+		}
+		recorded := false
+		record := func(context.Context, *order.Order, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+			recorded = true
+			return kitchenCmd.SettledPayment{}, nil
+		}
+
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(record), nil, nil)
+		o, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{
+			OrderID: "order-123",
+			Method:  common.PaymentMethodTypeLightning,
+			Settled: &kitchenCmd.SettledPayment{PaymentID: "pay-ln", FXRate: rate},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, recorded, "a paid invoice is not recorded again")
+		assert.Equal(t, common.PaymentStatusPaid, o.PaymentStatus)
+		assert.Equal(t, rate, o.FXRate)
+	})
+
+	t.Run("leaves an already paid order unchanged", func(t *testing.T) {
+		paidAt := time.Now().Add(-time.Hour)
+		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPaid)
+		existingOrder.PaidAt = &paidAt
+
+		updated, recorded := false, false
+		mockOrderRepo := &mockOrderRepo{
+			findByIDFn: func(common.OrderID) (*order.Order, error) { return existingOrder, nil },
+			updateFn: func(*order.Order) error {
+				updated = true
+				return nil
+			},
+		}
+		record := func(context.Context, *order.Order, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+			recorded = true
+			return kitchenCmd.SettledPayment{}, nil
+		}
+
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(record), nil, nil)
+		o, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-123"})
+
+		require.NoError(t, err)
+		assert.Same(t, existingOrder, o)
+		assert.Equal(t, paidAt, *o.PaidAt)
+		assert.False(t, recorded, "payment is not recorded again")
+		assert.False(t, updated)
+		assert.Empty(t, mockOrderRepo.published)
 	})
 
 	t.Run("returns error when order not found", func(t *testing.T) {
@@ -57,7 +134,7 @@ func TestMarkOrderPaidHandler_Handle(t *testing.T) {
 			},
 		}
 
-		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, &mockEventBus{}, nil, nil)
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(recordNothing), nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: common.OrderID("non-existent")})
 
 		assert.Error(t, err)
@@ -77,10 +154,81 @@ func TestMarkOrderPaidHandler_Handle(t *testing.T) {
 			},
 		}
 
-		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, &mockEventBus{}, nil, nil)
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(recordNothing), nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: orderID})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "db error")
 	})
+
+	t.Run("passes tender to the payment recorder", func(t *testing.T) {
+		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		mockOrderRepo := &mockOrderRepo{
+			findByIDFn: func(id common.OrderID) (*order.Order, error) { return existingOrder, nil },
+			updateFn:   func(order *order.Order) error { return nil },
+		}
+
+		var gotMethod common.PaymentMethodType
+		var gotTendered money.Money
+		var gotCollector common.UserID
+		record := func(_ context.Context, o *order.Order, method common.PaymentMethodType, tendered money.Money, collectedBy common.UserID) (kitchenCmd.SettledPayment, error) {
+			gotMethod, gotTendered, gotCollector = method, tendered, collectedBy
+			return kitchenCmd.SettledPayment{}, nil
+		}
+
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(record), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-123", Method: common.PaymentMethodTypeCash, Tendered: money.New(2000, money.USD), CollectedBy: "user-1"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, common.PaymentMethodTypeCash, gotMethod)
+		assert.Equal(t, money.New(2000, money.USD), gotTendered)
+		assert.Equal(t, common.UserID("user-1"), gotCollector)
+	})
+
+	t.Run("defaults the method to the order's", func(t *testing.T) {
+		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		existingOrder.PaymentMethod = common.PaymentMethodTypeLightning
+		mockOrderRepo := &mockOrderRepo{
+			findByIDFn: func(id common.OrderID) (*order.Order, error) { return existingOrder, nil },
+			updateFn:   func(order *order.Order) error { return nil },
+		}
+
+		var gotMethod common.PaymentMethodType
+		record := func(_ context.Context, _ *order.Order, method common.PaymentMethodType, _ money.Money, _ common.UserID) (kitchenCmd.SettledPayment, error) {
+			gotMethod = method
+			return kitchenCmd.SettledPayment{}, nil
+		}
+
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(record), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, common.PaymentMethodTypeLightning, gotMethod)
+	})
+
+	t.Run("leaves order unpaid when payment is rejected", func(t *testing.T) {
+		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		updated := false
+		mockOrderRepo := &mockOrderRepo{
+			findByIDFn: func(id common.OrderID) (*order.Order, error) { return existingOrder, nil },
+			updateFn: func(order *order.Order) error {
+				updated = true
+				return nil
+			},
+		}
+		reject := func(context.Context, *order.Order, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+			return kitchenCmd.SettledPayment{}, errors.New("tendered amount is less than the amount due")
+		}
+
+		uc := kitchenCmd.NewMarkOrderPaidHandler(mockOrderRepo, kitchenCmd.PaymentRecorderFunc(reject), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-123", Tendered: money.New(100, money.USD)})
+
+		assert.Error(t, err)
+		assert.False(t, updated)
+		assert.Equal(t, common.PaymentStatusPending, existingOrder.PaymentStatus)
+	})
+}
+
+func recordNothing(context.Context, *order.Order, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+	return kitchenCmd.SettledPayment{}, nil
 }
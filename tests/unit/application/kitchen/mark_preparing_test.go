@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -18,7 +19,6 @@ func TestMarkOrderPreparingHandler_Handle(t *testing.T) {
 		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPaid, common.PaymentStatusPaid)
 
 		var savedOrder *order.Order
-		var publishedEvent string
 
 		mockOrderRepo := &mockOrderRepo{
 			findByIDFn: func(id common.OrderID) (*order.Order, error) {
@@ -30,20 +30,14 @@ func TestMarkOrderPreparingHandler_Handle(t *testing.T) {
 			},
 		}
 
-		mockEventBus := &mockEventBus{
-			publishFn: func(ctx context.Context, topic string, event interface{}) error {
-				publishedEvent = topic
-				return nil
-			},
-		}
-
-		uc := kitchenCmd.NewMarkOrderPreparingHandler(mockOrderRepo, mockEventBus, nil, nil)
+		uc := kitchenCmd.NewMarkOrderPreparingHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPreparing{OrderID: orderID})
 
 		assert.NoError(t, err)
 		assert.Equal(t, common.FulfillmentStatusPreparing, savedOrder.FulfillmentStatus)
 		assert.NotNil(t, savedOrder.PreparingAt)
-		assert.Equal(t, "order.preparing", publishedEvent)
+		require.Len(t, mockOrderRepo.published, 1)
+		assert.Equal(t, "order.preparing", mockOrderRepo.published[0].EventName())
 	})
 
 	t.Run("fails if order is not paid", func(t *testing.T) {
@@ -56,7 +50,7 @@ func TestMarkOrderPreparingHandler_Handle(t *testing.T) {
 			},
 		}
 
-		uc := kitchenCmd.NewMarkOrderPreparingHandler(mockOrderRepo, &mockEventBus{}, nil, nil)
+		uc := kitchenCmd.NewMarkOrderPreparingHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPreparing{OrderID: orderID})
 
 		assert.Error(t, err)
@@ -77,7 +71,7 @@ func TestMarkOrderPreparingHandler_Handle(t *testing.T) {
 			},
 		}
 
-		uc := kitchenCmd.NewMarkOrderPreparingHandler(mockOrderRepo, &mockEventBus{}, nil, nil)
+		uc := kitchenCmd.NewMarkOrderPreparingHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPreparing{OrderID: orderID})
 
 		assert.Error(t, err)
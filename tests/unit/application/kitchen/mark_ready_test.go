@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -17,7 +18,6 @@ func TestMarkOrderReadyHandler_Handle(t *testing.T) {
 		existingOrder := createTestOrder("order-123", common.FulfillmentStatusPreparing, common.PaymentStatusPaid)
 
 		var savedOrder *order.Order
-		var publishedEvent string
 
 		mockOrderRepo := &mockOrderRepo{
 			findByIDFn: func(id common.OrderID) (*order.Order, error) {
@@ -29,20 +29,14 @@ func TestMarkOrderReadyHandler_Handle(t *testing.T) {
 			},
 		}
 
-		mockEventBus := &mockEventBus{
-			publishFn: func(ctx context.Context, topic string, event interface{}) error {
-				publishedEvent = topic
-				return nil
-			},
-		}
-
-		uc := kitchenCmd.NewMarkOrderReadyHandler(mockOrderRepo, mockEventBus, nil, nil)
+		uc := kitchenCmd.NewMarkOrderReadyHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderReady{OrderID: orderID})
 
 		assert.NoError(t, err)
 		assert.Equal(t, common.FulfillmentStatusReady, savedOrder.FulfillmentStatus)
 		assert.NotNil(t, savedOrder.ReadyAt)
-		assert.Equal(t, "order.ready", publishedEvent)
+		require.Len(t, mockOrderRepo.published, 1)
+		assert.Equal(t, "order.ready", mockOrderRepo.published[0].EventName())
 	})
 
 	t.Run("fails if status transition is invalid", func(t *testing.T) {
@@ -56,7 +50,7 @@ func TestMarkOrderReadyHandler_Handle(t *testing.T) {
 			},
 		}
 
-		uc := kitchenCmd.NewMarkOrderReadyHandler(mockOrderRepo, &mockEventBus{}, nil, nil)
+		uc := kitchenCmd.NewMarkOrderReadyHandler(mockOrderRepo, nil, nil)
 		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderReady{OrderID: orderID})
 
 		assert.Error(t, err)
@@ -0,0 +1,138 @@
+package kitchen_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	kitchenCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/app/event"
+	"bitmerchant/internal/ordering/domain/order"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func voidNothing(context.Context, *order.Order, string) error { return nil }
+
+func TestSplitBillHandler_Handle(t *testing.T) {
+	t.Run("splits evenly and publishes order.bill_split", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		var updated *order.Order
+		repo := &mockOrderRepo{
+			findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil },
+			updateFn:   func(o *order.Order) error { updated = o; return nil },
+		}
+		var voided bool
+		voider := func(_ context.Context, o *order.Order, _ string) error {
+			voided = true
+			return nil
+		}
+
+		uc := kitchenCmd.NewSplitBillHandler(repo, kitchenCmd.OpenPaymentVoiderFunc(voider), nil, nil)
+		o, err := uc.Handle(context.Background(), kitchenCmd.SplitBill{OrderID: "order-1", RestaurantID: "rest-1", Mode: order.SplitEvenly, Ways: 2})
+
+		require.NoError(t, err)
+		assert.True(t, voided)
+		require.NotNil(t, updated)
+		require.Len(t, o.BillParts, 2)
+		assert.NotEmpty(t, o.BillParts[0].ID)
+		assert.NotEqual(t, o.BillParts[0].ID, o.BillParts[1].ID)
+		require.Len(t, repo.published, 1)
+		ev := repo.published[0].(event.OrderBillSplit)
+		assert.Equal(t, 2, ev.Parts)
+		assert.Equal(t, int64(1000), ev.Outstanding)
+	})
+
+	t.Run("custom amounts are in the order currency", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		uc := kitchenCmd.NewSplitBillHandler(repo, kitchenCmd.OpenPaymentVoiderFunc(voidNothing), nil, nil)
+
+		o, err := uc.Handle(context.Background(), kitchenCmd.SplitBill{OrderID: "order-1", Mode: order.SplitCustom, Amounts: []money.Money{money.New(250, money.USD)}})
+		require.NoError(t, err)
+		require.Len(t, o.BillParts, 2)
+		assert.Equal(t, int64(250), o.BillParts[0].Amount)
+		assert.Equal(t, int64(750), o.BillParts[1].Amount)
+
+		_, err = uc.Handle(context.Background(), kitchenCmd.SplitBill{OrderID: "order-1", Mode: order.SplitCustom, Amounts: []money.Money{money.New(250, money.THB)}})
+		assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+	})
+
+	t.Run("rejects a paid order and another restaurant's order", func(t *testing.T) {
+		existing := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPaid)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		uc := kitchenCmd.NewSplitBillHandler(repo, kitchenCmd.OpenPaymentVoiderFunc(voidNothing), nil, nil)
+
+		_, err := uc.Handle(context.Background(), kitchenCmd.SplitBill{OrderID: "order-1", Mode: order.SplitEvenly, Ways: 2})
+		assert.ErrorIs(t, err, order.ErrNothingOutstanding)
+		_, err = uc.Handle(context.Background(), kitchenCmd.SplitBill{OrderID: "order-1", RestaurantID: "rest-2", Mode: order.SplitEvenly, Ways: 2})
+		assert.EqualError(t, err, "order not found")
+	})
+}
+
+func TestPayBillPartHandler_Handle(t *testing.T) {
+	newSplitOrder := func(t *testing.T) *order.Order {
+		o := createTestOrder("order-1", common.FulfillmentStatusPaid, common.PaymentStatusPending)
+		o.PaymentMethod = common.PaymentMethodTypeCash
+		require.NoError(t, o.Split([]order.BillPart{{ID: "p1", Amount: 600}, {ID: "p2", Amount: 400}}))
+		return o
+	}
+
+	t.Run("publishes part paid until the last part, then order.paid", func(t *testing.T) {
+		existing := newSplitOrder(t)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		var recorded []int64
+		recorder := func(_ context.Context, o *order.Order, part order.BillPart, method common.PaymentMethodType, _ money.Money, _ common.UserID) (kitchenCmd.SettledPayment, error) {
+			assert.Equal(t, common.PaymentMethodTypeCash, method)
+			recorded = append(recorded, part.Amount)
+			return kitchenCmd.SettledPayment{PaymentID: common.PaymentID("pay-" + string(part.ID))}, nil
+		}
+		uc := kitchenCmd.NewPayBillPartHandler(repo, kitchenCmd.BillPartRecorderFunc(recorder), nil, nil)
+
+		o, err := uc.Handle(context.Background(), kitchenCmd.PayBillPart{OrderID: "order-1", PartID: "p1"})
+		require.NoError(t, err)
+		assert.Equal(t, common.PaymentStatusPartiallyPaid, o.PaymentStatus)
+		require.Len(t, repo.published, 1)
+		partEv := repo.published[0].(event.OrderBillPartPaid)
+		assert.Equal(t, common.PaymentID("pay-p1"), partEv.PaymentID)
+		assert.Equal(t, int64(400), partEv.Outstanding)
+
+		_, err = uc.Handle(context.Background(), kitchenCmd.PayBillPart{OrderID: "order-1", PartID: "p1"})
+		require.NoError(t, err)
+		assert.Len(t, recorded, 1, "a paid part is not recorded again")
+
+		o, err = uc.Handle(context.Background(), kitchenCmd.PayBillPart{OrderID: "order-1", PartID: "p2"})
+		require.NoError(t, err)
+		assert.Equal(t, common.PaymentStatusPaid, o.PaymentStatus)
+		require.Len(t, repo.published, 2)
+		_, ok := repo.published[1].(event.OrderPaid)
+		assert.True(t, ok)
+	})
+
+	t.Run("a rejected payment leaves the part unpaid", func(t *testing.T) {
+		existing := newSplitOrder(t)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		recorder := func(context.Context, *order.Order, order.BillPart, common.PaymentMethodType, money.Money, common.UserID) (kitchenCmd.SettledPayment, error) {
+			return kitchenCmd.SettledPayment{}, errors.New("tender too short")
+		}
+		uc := kitchenCmd.NewPayBillPartHandler(repo, kitchenCmd.BillPartRecorderFunc(recorder), nil, nil)
+
+		_, err := uc.Handle(context.Background(), kitchenCmd.PayBillPart{OrderID: "order-1", PartID: "p1"})
+		assert.Error(t, err)
+		part, _ := existing.Part("p1")
+		assert.False(t, part.IsPaid())
+		_, err = uc.Handle(context.Background(), kitchenCmd.PayBillPart{OrderID: "order-1", PartID: "nope"})
+		assert.ErrorIs(t, err, order.ErrBillPartNotFound)
+	})
+
+	t.Run("mark paid is refused for a split bill", func(t *testing.T) {
+		existing := newSplitOrder(t)
+		repo := &mockOrderRepo{findByIDFn: func(common.OrderID) (*order.Order, error) { return existing, nil }}
+		uc := kitchenCmd.NewMarkOrderPaidHandler(repo, kitchenCmd.PaymentRecorderFunc(recordNothing), nil, nil)
+		_, err := uc.Handle(context.Background(), kitchenCmd.MarkOrderPaid{OrderID: "order-1"})
+		assert.ErrorIs(t, err, order.ErrBillIsSplit)
+	})
+}
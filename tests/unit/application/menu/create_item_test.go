@@ -73,7 +73,7 @@ func TestCreateMenuItemHandler_Handle(t *testing.T) {
 
 		repo.On("FindByCategoryID", categoryID).Return([]*menu.MenuItem{}, nil)
 		repo.On("Save", mock.MatchedBy(func(i *menu.MenuItem) bool {
-			return i.Name == "Burger" && i.RestaurantID == restaurantID && i.CategoryID == categoryID && i.Price == 12.50 && i.DisplayOrder == 0
+			return i.Name == "Burger" && i.RestaurantID == restaurantID && i.CategoryID == categoryID && i.Price == 1250 && i.DisplayOrder == 0
 		})).Return(nil)
 
 		req := menuCmd.CreateMenuItem{
@@ -81,7 +81,7 @@ func TestCreateMenuItemHandler_Handle(t *testing.T) {
 			CategoryID:   categoryID,
 			Name:         "Burger",
 			Description:  "Delicious burger",
-			Price:        12.50,
+			Price:        1250,
 			Available:    true,
 		}
 
@@ -90,7 +90,7 @@ func TestCreateMenuItemHandler_Handle(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 		assert.Equal(t, "Burger", resp.Name)
-		assert.Equal(t, 12.50, resp.Price)
+		assert.Equal(t, int64(1250), resp.Price)
 		repo.AssertExpectations(t)
 	})
 
@@ -102,7 +102,7 @@ func TestCreateMenuItemHandler_Handle(t *testing.T) {
 			RestaurantID: "rest-1",
 			CategoryID:   "cat-1",
 			Name:         "Burger",
-			Price:        -10.0,
+			Price:        -1000,
 		}
 
 		resp, err := useCase.Handle(context.Background(), req)
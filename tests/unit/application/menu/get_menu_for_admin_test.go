@@ -44,11 +44,11 @@ func TestMenuForAdminHandler_IncludesUnavailableItemsAndEmptyCategories(t *testi
 	require.NoError(t, err)
 	require.NoError(t, repoCat.Save(catWith))
 
-	avail, err := menu.NewMenuItem("it1", catWith.ID, rid, "Burger", 9)
+	avail, err := menu.NewMenuItem("it1", catWith.ID, rid, "Burger", 900)
 	require.NoError(t, err)
 	require.NoError(t, repoItem.Save(avail))
 
-	unavail, err := menu.NewMenuItem("it2", catWith.ID, rid, "Soup", 5)
+	unavail, err := menu.NewMenuItem("it2", catWith.ID, rid, "Soup", 500)
 	require.NoError(t, err)
 	unavail.SetAvailable(false)
 	require.NoError(t, repoItem.Save(unavail))
@@ -105,7 +105,7 @@ func TestMenuForAdminHandler_PresignsPhotoURLs(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, repoCat.Save(cat))
 
-	it, err := menu.NewMenuItem("i1", cat.ID, rid, "Burger", 9)
+	it, err := menu.NewMenuItem("i1", cat.ID, rid, "Burger", 900)
 	require.NoError(t, err)
 	it.SetPhotoURLs("restaurants/r1/items/x.jpg", "restaurants/r1/items/x.jpg")
 	require.NoError(t, repoItem.Save(it))
@@ -43,8 +43,8 @@ func TestMenuForCustomerHandler(t *testing.T) {
 	require.NoError(t, catRepo.Save(cat1))
 	require.NoError(t, catRepo.Save(cat2))
 
-	item1, _ := menu.NewMenuItem("i1", "c1", restID, "Salad", 10.0)
-	item2, _ := menu.NewMenuItem("i2", "c2", restID, "Steak", 20.0)
+	item1, _ := menu.NewMenuItem("i1", "c1", restID, "Salad", 1000)
+	item2, _ := menu.NewMenuItem("i2", "c2", restID, "Steak", 2000)
 	require.NoError(t, itemRepo.Save(item1))
 	require.NoError(t, itemRepo.Save(item2))
 
@@ -69,7 +69,7 @@ func TestMenuForCustomerHandler(t *testing.T) {
 		ucPhoto := menuQuery.NewMenuForCustomerHandler(catRepo, itemRepo, restRepo, fakePhotoStorage{}, menuQuery.PhotoSignerConfig{
 			Bucket: "mybucket",
 		}, nil, nil)
-		itemPhoto, _ := menu.NewMenuItem("iphoto", "c1", restID, "With Pix", 10.0)
+		itemPhoto, _ := menu.NewMenuItem("iphoto", "c1", restID, "With Pix", 1000)
 		itemPhoto.SetPhotoURLs("restaurants/r1/items/x.jpg", "restaurants/r1/items/x.jpg")
 		require.NoError(t, itemRepo.Save(itemPhoto))
 
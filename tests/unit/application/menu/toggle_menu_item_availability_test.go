@@ -21,7 +21,7 @@ func TestToggleMenuItemAvailabilityHandler(t *testing.T) {
 	cat, err := menu.NewMenuCategory("c1", rid, "M", 0)
 	require.NoError(t, err)
 	require.NoError(t, repoCat.Save(cat))
-	item, err := menu.NewMenuItem("i1", cat.ID, rid, "X", 1)
+	item, err := menu.NewMenuItem("i1", cat.ID, rid, "X", 100)
 	require.NoError(t, err)
 	require.NoError(t, repoItem.Save(item))
 	assert.True(t, item.IsAvailable)
@@ -26,7 +26,7 @@ func TestUpdateMenuItemHandler_Handle(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, repoCat.Save(cat2))
 
-	item, err := menu.NewMenuItem("i1", cat1.ID, rid, "Cola", 2)
+	item, err := menu.NewMenuItem("i1", cat1.ID, rid, "Cola", 200)
 	require.NoError(t, err)
 	require.NoError(t, repoItem.Save(item))
 
@@ -37,7 +37,7 @@ func TestUpdateMenuItemHandler_Handle(t *testing.T) {
 		CategoryID:   cat2.ID,
 		Name:         "Cola Zero",
 		Description:  "iced",
-		Price:        2.5,
+		Price:        250,
 		Available:    false,
 	})
 	require.NoError(t, err)
@@ -46,7 +46,7 @@ func TestUpdateMenuItemHandler_Handle(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, cat2.ID, updated.CategoryID)
 	assert.Equal(t, "Cola Zero", updated.Name)
-	assert.Equal(t, 2.5, updated.Price)
+	assert.Equal(t, int64(250), updated.Price)
 	assert.False(t, updated.IsAvailable)
 	assert.Equal(t, "iced", updated.Description)
 }
@@ -61,7 +61,7 @@ func TestUpdateMenuItemHandler_WrongRestaurant(t *testing.T) {
 	cat, err := menu.NewMenuCategory("c1", r1, "X", 0)
 	require.NoError(t, err)
 	require.NoError(t, repoCat.Save(cat))
-	item, err := menu.NewMenuItem("i1", cat.ID, r1, "A", 1)
+	item, err := menu.NewMenuItem("i1", cat.ID, r1, "A", 100)
 	require.NoError(t, err)
 	require.NoError(t, repoItem.Save(item))
 
@@ -71,7 +71,7 @@ func TestUpdateMenuItemHandler_WrongRestaurant(t *testing.T) {
 		ItemID:       item.ID,
 		CategoryID:   cat.ID,
 		Name:         "B",
-		Price:        2,
+		Price:        200,
 		Available:    true,
 	})
 	require.Error(t, err)
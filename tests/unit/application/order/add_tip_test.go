@@ -0,0 +1,52 @@
+package order_test
+
+import (
+	"context"
+	"testing"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	orderCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/domain/order"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTipHandler(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryOrderRepository()
+	h := orderCmd.NewAddTipHandler(repo, nil, nil)
+
+	o, err := order.NewOrder("o1", "0001", "r1", "sess", []order.OrderItem{{}}, 1000, common.PaymentMethodTypeCash)
+	require.NoError(t, err)
+	o.MarkPaid()
+	require.NoError(t, repo.Save(o))
+
+	cmd := orderCmd.AddTip{OrderID: "o1", PaymentID: "pay_tip", Amount: money.New(150, money.USD)}
+	_, err = h.Handle(ctx, cmd)
+	assert.ErrorIs(t, err, order.ErrTipNotOpen, "the order has not been served")
+
+	require.NoError(t, o.StartPreparing())
+	require.NoError(t, o.MarkReady())
+	require.NoError(t, repo.Update(o))
+
+	_, err = h.Handle(ctx, orderCmd.AddTip{OrderID: "o1", PaymentID: "pay_tip", Amount: money.New(150, money.THB)})
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+
+	tipped, err := h.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), tipped.TipAmount)
+	assert.Equal(t, int64(1150), tipped.TotalAmount)
+
+	stored, err := repo.FindByID("o1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), stored.LateTipAmount)
+	assert.Equal(t, 1, outboxCount(repo, common.EventOrderTipAdded))
+
+	again, err := h.Handle(ctx, cmd)
+	require.NoError(t, err, "a settlement retried for the same payment")
+	assert.Equal(t, int64(1150), again.TotalAmount)
+	assert.Equal(t, 1, outboxCount(repo, common.EventOrderTipAdded))
+}
@@ -3,14 +3,16 @@ package order_test
 import (
 	"bitmerchant/internal/common"
 	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/common/tip"
 
-	"bitmerchant/internal/infrastructure/events"
 	"bitmerchant/internal/infrastructure/logging"
 	"bitmerchant/internal/infrastructure/payment/cash"
 	"bitmerchant/internal/infrastructure/repositories/memory"
 	"bitmerchant/internal/menu/domain/menu"
 	"bitmerchant/internal/ordering/app/cart"
 	orderCmd "bitmerchant/internal/ordering/app/command"
+	"bitmerchant/internal/ordering/domain/order"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 	"context"
 	"sync"
@@ -24,7 +26,6 @@ func TestCreateOrderHandler(t *testing.T) {
 	orderRepo := memory.NewMemoryOrderRepository()
 	paymentRepo := memory.NewMemoryPaymentRepository()
 	restRepo := memory.NewMemoryRestaurantRepository()
-	eventBus := events.NewEventBus()
 	paymentMethod := cash.NewCashPaymentMethod()
 	logger := logging.NewLogger()
 
@@ -38,7 +39,8 @@ func TestCreateOrderHandler(t *testing.T) {
 	uc := orderCmd.NewCreateOrderHandler(
 		orderRepo,
 		restRepo,
-		eventBus,
+		nil,
+		nil,
 		logger.Logger,
 		nil,
 	)
@@ -47,7 +49,7 @@ func TestCreateOrderHandler(t *testing.T) {
 		// Setup Cart
 		cartSvc := cart.NewCartService()
 		sessionID := "sess_1"
-		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 10.0)
+		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
 		require.NoError(t, cartSvc.AddItem(sessionID, item, 2))
 
 		userCart := cartSvc.GetCart(sessionID)
@@ -76,17 +78,21 @@ func TestCreateOrderHandler(t *testing.T) {
 		assert.Equal(t, int64(160), savedOrder.TaxAmount)
 		assert.Equal(t, int64(300), savedOrder.TipAmount)
 		assert.Equal(t, int64(2460), savedOrder.TotalAmount)
-		assert.Equal(t, 24.60, savedOrder.FiatAmount)
 		assert.Equal(t, money.USD, savedOrder.Currency)
 		assert.Equal(t, "$24.60", savedOrder.Total().Format())
 		assert.Equal(t, "Maya", savedOrder.CustomerName)
 		assert.Equal(t, "7", savedOrder.TableLabel)
+
+		pending := orderRepo.Outbox().Pending()
+		require.Len(t, pending, 1, "the order is saved with its OrderCreated event")
+		assert.Equal(t, common.EventOrderCreated, pending[0].Topic)
+		assert.Contains(t, string(pending[0].Payload), string(resp.OrderID))
 	})
 
 	t.Run("RejectsInvalidTipPercent", func(t *testing.T) {
 		cartSvc := cart.NewCartService()
 		sessionID := "sess_bad_tip"
-		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 10.0)
+		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
 		require.NoError(t, cartSvc.AddItem(sessionID, item, 1))
 
 		_, err := uc.Handle(context.Background(), orderCmd.CreateOrder{
@@ -108,7 +114,6 @@ func TestCreateOrderHandler(t *testing.T) {
 func TestCreateOrderHandler_SatoshiRestaurant(t *testing.T) {
 	orderRepo := memory.NewMemoryOrderRepository()
 	restRepo := memory.NewMemoryRestaurantRepository()
-	eventBus := events.NewEventBus()
 	logger := logging.NewLogger()
 
 	restID := common.RestaurantID("r_sat")
@@ -116,7 +121,7 @@ func TestCreateOrderHandler_SatoshiRestaurant(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, restRepo.Save(rest))
 
-	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, eventBus, logger.Logger, nil)
+	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logger.Logger, nil)
 
 	cartSvc := cart.NewCartService()
 	sessionID := "sess_sat"
@@ -152,14 +157,13 @@ func TestCreateOrderHandler_SatoshiRestaurant(t *testing.T) {
 func TestCreateOrderHandler_ConcurrentNumbersAreUnique(t *testing.T) {
 	orderRepo := memory.NewMemoryOrderRepository()
 	restRepo := memory.NewMemoryRestaurantRepository()
-	eventBus := events.NewEventBus()
 	logger := logging.NewLogger()
 
 	restID := common.RestaurantID("r1")
 	rest, _ := restaurant.NewRestaurant(restID, "Test Rest")
 	require.NoError(t, restRepo.Save(rest))
 
-	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, eventBus, logger.Logger, nil)
+	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logger.Logger, nil)
 
 	const concurrency = 25
 	results := make([]string, concurrency)
@@ -170,7 +174,7 @@ func TestCreateOrderHandler_ConcurrentNumbersAreUnique(t *testing.T) {
 			defer wg.Done()
 			cartSvc := cart.NewCartService()
 			sessionID := "sess_" + string(rune('A'+idx))
-			item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 10.0)
+			item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
 			require.NoError(t, cartSvc.AddItem(sessionID, item, 1))
 			req := orderCmd.CreateOrder{
 				RestaurantID:  restID,
@@ -194,3 +198,174 @@ func TestCreateOrderHandler_ConcurrentNumbersAreUnique(t *testing.T) {
 	}
 	assert.Len(t, seen, concurrency)
 }
+
+func TestCreateOrderHandler_AppliesAndRedeemsDiscount(t *testing.T) {
+	orderRepo := memory.NewMemoryOrderRepository()
+	restRepo := memory.NewMemoryRestaurantRepository()
+	rest, _ := restaurant.NewRestaurant("r1", "Test Rest")
+	require.NoError(t, restRepo.Save(rest))
+
+	var redeemed []common.OrderID
+	price := func(_ context.Context, sessionID string, c *cart.Cart) (cart.Discount, error) {
+		if c.PromoCode == "EXPIRED" {
+			return cart.Discount{}, &orderCmd.PromotionRejectedError{Reason: "this code has expired"}
+		}
+		return cart.Discount{PromotionID: "promo-1", Code: c.PromoCode, Label: "Spring (SPRING)", Amount: 500}, nil
+	}
+	redeem := func(_ context.Context, d cart.Discount, sessionID string, orderID common.OrderID) error {
+		redeemed = append(redeemed, orderID)
+		return nil
+	}
+	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, orderCmd.DiscountPricerFunc(price), orderCmd.DiscountRedeemerFunc(redeem), logging.NewLogger().Logger, nil)
+
+	cartSvc := cart.NewCartService()
+	item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
+	require.NoError(t, cartSvc.AddItem("sess_1", item, 2))
+	cartSvc.SetPromoCode("sess_1", "spring")
+	c := cartSvc.GetCart("sess_1")
+	assert.Equal(t, "SPRING", c.PromoCode)
+
+	resp, err := uc.Handle(context.Background(), orderCmd.CreateOrder{
+		RestaurantID: "r1", SessionID: "sess_1", Cart: c,
+		PaymentMethod: common.PaymentMethodTypeCash, CustomerName: "Maya",
+	})
+	require.NoError(t, err)
+
+	// Subtotal 2000, discount 500, tax 8% of 1500 = 120, total 1620.
+	saved, err := orderRepo.FindByID(resp.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), saved.Subtotal)
+	assert.Equal(t, int64(500), saved.DiscountAmount)
+	assert.Equal(t, common.PromotionID("promo-1"), saved.PromotionID)
+	assert.Equal(t, "Spring (SPRING)", saved.DiscountLabel)
+	assert.Equal(t, int64(120), saved.TaxAmount)
+	assert.Equal(t, int64(1620), saved.TotalAmount)
+	assert.Equal(t, []common.OrderID{resp.OrderID}, redeemed)
+
+	c.PromoCode = "EXPIRED"
+	_, err = uc.Handle(context.Background(), orderCmd.CreateOrder{
+		RestaurantID: "r1", SessionID: "sess_1", Cart: c,
+		PaymentMethod: common.PaymentMethodTypeCash, CustomerName: "Maya",
+	})
+	var rejected *orderCmd.PromotionRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, "this code has expired", rejected.Reason)
+	assert.Len(t, redeemed, 1)
+}
+
+func TestCreateOrderHandler_NamedTaxesAndServiceCharge(t *testing.T) {
+	orderRepo := memory.NewMemoryOrderRepository()
+	restRepo := memory.NewMemoryRestaurantRepository()
+	rest, _ := restaurant.NewRestaurant("r1", "Test Rest")
+	require.NoError(t, rest.SetTaxSettings(tax.Config{
+		Mode:              tax.ModeExclusive,
+		Taxes:             []tax.Tax{{Name: "Sales tax", Rate: 800}, {Name: "Liquor", Rate: 1000, Classes: []tax.Class{"alcohol"}}},
+		ServiceChargeRate: 1800,
+	}))
+	require.NoError(t, restRepo.Save(rest))
+	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logging.NewLogger().Logger, nil)
+
+	cartSvc := cart.NewCartService()
+	burger, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1500)
+	beer, _ := menu.NewMenuItem("i2", "c2", "r1", "Beer", 500)
+	require.NoError(t, beer.SetTaxClass("Alcohol"))
+	require.NoError(t, cartSvc.AddItem("sess_1", burger, 1))
+	require.NoError(t, cartSvc.AddItem("sess_1", beer, 1))
+
+	resp, err := uc.Handle(context.Background(), orderCmd.CreateOrder{
+		RestaurantID: "r1", SessionID: "sess_1", Cart: cartSvc.GetCart("sess_1"),
+		PaymentMethod: common.PaymentMethodTypeCash, CustomerName: "Maya",
+	})
+	require.NoError(t, err)
+
+	// Subtotal 2000; service 18% = 360; sales tax 160; liquor 10% of 500 = 50.
+	saved, err := orderRepo.FindByID(resp.OrderID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(360), saved.ServiceCharge)
+	assert.Equal(t, []tax.Charge{{Name: "Sales tax", Rate: 800, Amount: 160}, {Name: "Liquor", Rate: 1000, Amount: 50}}, saved.TaxLines)
+	assert.Equal(t, int64(210), saved.TaxAmount)
+	assert.False(t, saved.TaxInclusive)
+	assert.Equal(t, int64(2000+360+210), saved.TotalAmount)
+}
+
+func TestCreateOrderHandler_RoundsCashTotals(t *testing.T) {
+	orderRepo := memory.NewMemoryOrderRepository()
+	restRepo := memory.NewMemoryRestaurantRepository()
+	rest, _ := restaurant.NewRestaurantWithCurrency("r1", "Test Rest", money.THB)
+	require.NoError(t, rest.SetTaxSettings(tax.Exclusive(0.07)))
+	require.NoError(t, rest.SetCashRounding("0.25"))
+	require.NoError(t, restRepo.Save(rest))
+	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logging.NewLogger().Logger, nil)
+
+	place := func(session string, method common.PaymentMethodType) *order.Order {
+		cartSvc := cart.NewCartService()
+		item, _ := menu.NewMenuItemWithCurrency("i1", "c1", "r1", "Som tam", 1000, money.THB)
+		require.NoError(t, cartSvc.AddItem(session, item, 1))
+		resp, err := uc.Handle(context.Background(), orderCmd.CreateOrder{
+			RestaurantID: "r1", SessionID: session, Cart: cartSvc.GetCart(session),
+			PaymentMethod: method, CustomerName: "Maya", TipPercent: 15,
+		})
+		require.NoError(t, err)
+		saved, err := orderRepo.FindByID(resp.OrderID)
+		require.NoError(t, err)
+		return saved
+	}
+
+	// ฿10.00 + ฿0.70 VAT + ฿1.50 tip = ฿12.20, paid in cash as ฿12.25.
+	cash := place("sess_cash", common.PaymentMethodTypeCash)
+	assert.Equal(t, int64(5), cash.CashRounding)
+	assert.Equal(t, int64(1225), cash.TotalAmount)
+	assert.Equal(t, cash.Subtotal+cash.TaxAmount+cash.TipAmount+cash.CashRounding, cash.TotalAmount)
+
+	ln := place("sess_ln", common.PaymentMethodTypeLightning)
+	assert.Zero(t, ln.CashRounding, "only cash totals are rounded")
+	assert.Equal(t, int64(1220), ln.TotalAmount)
+}
+
+func TestCreateOrderHandler_RestaurantTipSettings(t *testing.T) {
+	orderRepo := memory.NewMemoryOrderRepository()
+	restRepo := memory.NewMemoryRestaurantRepository()
+	rest, _ := restaurant.NewRestaurant("r1", "Test Rest")
+	require.NoError(t, rest.SetTipSettings(tip.Settings{Presets: []int{18, 22}, Default: 18, Custom: true}))
+	require.NoError(t, restRepo.Save(rest))
+	uc := orderCmd.NewCreateOrderHandler(orderRepo, restRepo, nil, nil, logging.NewLogger().Logger, nil)
+
+	place := func(session string, pct int, custom int64) (*order.Order, error) {
+		cartSvc := cart.NewCartService()
+		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Burger", 1000)
+		require.NoError(t, cartSvc.AddItem(session, item, 1))
+		resp, err := uc.Handle(context.Background(), orderCmd.CreateOrder{
+			RestaurantID: "r1", SessionID: session, Cart: cartSvc.GetCart(session),
+			PaymentMethod: common.PaymentMethodTypeCash, CustomerName: "Maya", TipPercent: pct, CustomTip: custom,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return orderRepo.FindByID(resp.OrderID)
+	}
+
+	preset, err := place("sess_preset", 18, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(180), preset.TipAmount)
+
+	_, err = place("sess_standard", 15, 0)
+	assert.Error(t, err, "15% is not one of this restaurant's presets")
+
+	custom, err := place("sess_custom", 0, 275)
+	require.NoError(t, err)
+	assert.Equal(t, int64(275), custom.TipAmount)
+	assert.Equal(t, custom.Subtotal+custom.TaxAmount+custom.TipAmount, custom.TotalAmount)
+
+	_, err = place("sess_huge", 0, 1001)
+	assert.ErrorIs(t, err, tip.ErrInvalidCustom)
+
+	require.NoError(t, rest.SetTipSettings(tip.Settings{Off: true}))
+	require.NoError(t, restRepo.Save(rest))
+	_, err = place("sess_off_pct", 18, 0)
+	assert.Error(t, err, "tipping is off")
+	_, err = place("sess_off_custom", 0, 275)
+	assert.ErrorIs(t, err, tip.ErrNotAllowed)
+	none, err := place("sess_off_none", 0, 0)
+	require.NoError(t, err)
+	assert.Zero(t, none.TipAmount)
+}
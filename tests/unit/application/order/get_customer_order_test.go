@@ -15,7 +15,7 @@ import (
 
 func TestCustomerOrderByLookupHandler(t *testing.T) {
 	repo := memory.NewMemoryOrderRepository()
-	item, _ := order.NewOrderItem("oi", "o1", "mi", "Burger", 1, 10)
+	item, _ := order.NewOrderItem("oi", "o1", "mi", "Burger", 1, 1000)
 	o, _ := order.NewOrder("o1", "5555", "restaurant_1", "sess-z", []order.OrderItem{*item}, 1000, common.PaymentMethodTypeCash)
 	require.NoError(t, repo.Save(o))
 
@@ -30,3 +30,24 @@ func TestCustomerOrderByLookupHandler(t *testing.T) {
 	_, err = uc.Handle(context.Background(), orderQuery.CustomerOrderByLookup{SessionID: "sess-z", OrderNumber: "9999"})
 	assert.Error(t, err)
 }
+
+func TestCustomerOrderByLookupHandler_SameNumberAtTwoRestaurants(t *testing.T) {
+	repo := memory.NewMemoryOrderRepository()
+	for _, rest := range []common.RestaurantID{"restaurant_1", "restaurant_2"} {
+		id := common.OrderID("o-" + string(rest))
+		item, _ := order.NewOrderItem("oi-"+common.OrderItemID(rest), id, "mi", "Burger", 1, 1000)
+		o, _ := order.NewOrder(id, "1001", rest, "sess-z", []order.OrderItem{*item}, 1000, common.PaymentMethodTypeCash)
+		require.NoError(t, repo.Save(o))
+	}
+
+	uc := orderQuery.NewCustomerOrderByLookupHandler(repo, nil, nil)
+	for _, rest := range []common.RestaurantID{"restaurant_1", "restaurant_2"} {
+		got, err := uc.Handle(context.Background(), orderQuery.CustomerOrderByLookup{SessionID: "sess-z", OrderNumber: "1001", RestaurantID: rest})
+		require.NoError(t, err)
+		assert.Equal(t, rest, got.RestaurantID, "the active restaurant's order wins")
+	}
+
+	got, err := uc.Handle(context.Background(), orderQuery.CustomerOrderByLookup{SessionID: "sess-z", OrderNumber: "1001", RestaurantID: "restaurant_3"})
+	require.NoError(t, err, "an order elsewhere is still found")
+	assert.Equal(t, common.OrderNumber("1001"), got.OrderNumber)
+}
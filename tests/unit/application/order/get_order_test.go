@@ -19,7 +19,7 @@ func TestOrderByNumberForRestaurantHandler(t *testing.T) {
 
 	t.Run("Handle Success", func(t *testing.T) {
 		// Setup order
-		item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Burger", 1, 10.0)
+		item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Burger", 1, 1000)
 		existingOrder, _ := order.NewOrder(
 			"o1",
 			"1234",
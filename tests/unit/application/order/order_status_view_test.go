@@ -15,7 +15,7 @@ import (
 
 func mustOrder(t *testing.T, id, num string, restID common.RestaurantID, createdOffset time.Duration, status common.FulfillmentStatus) *order.Order {
 	t.Helper()
-	item, err := order.NewOrderItem(common.OrderItemID("oi-"+id), common.OrderID(id), common.ItemID("mi-"+id), "Bao", 1, 6.5)
+	item, err := order.NewOrderItem(common.OrderItemID("oi-"+id), common.OrderID(id), common.ItemID("mi-"+id), "Bao", 1, 650)
 	require.NoError(t, err)
 	o, err := order.NewOrder(common.OrderID(id), common.OrderNumber(num), restID, "sess-"+id, []order.OrderItem{*item}, 650, common.PaymentMethodTypeCash)
 	require.NoError(t, err)
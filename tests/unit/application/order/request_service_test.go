@@ -2,7 +2,6 @@ package order_test
 
 import (
 	"context"
-	"sync"
 	"testing"
 
 	"bitmerchant/internal/common"
@@ -14,25 +13,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// recordingBus counts events published per topic so we can assert idempotency.
-type recordingBus struct {
-	mu     sync.Mutex
-	topics []string
-}
-
-func (b *recordingBus) Publish(_ context.Context, topic string, _ interface{}) error {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-	b.topics = append(b.topics, topic)
-	return nil
-}
-
-func (b *recordingBus) count(topic string) int {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+// outboxCount counts the events on topic waiting in repo's outbox, so we can
+// assert idempotency.
+func outboxCount(repo *memory.MemoryOrderRepository, topic string) int {
 	n := 0
-	for _, t := range b.topics {
-		if t == topic {
+	for _, m := range repo.Outbox().Pending() {
+		if m.Topic == topic {
 			n++
 		}
 	}
@@ -41,7 +27,7 @@ func (b *recordingBus) count(topic string) int {
 
 func seedActiveOrder(t *testing.T, repo order.Repository) *order.Order {
 	t.Helper()
-	item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Bao", 1, 9.0)
+	item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Bao", 1, 900)
 	o, err := order.NewOrder("o1", "A29F", "r1", "sess1", []order.OrderItem{*item}, 900, common.PaymentMethodTypeCash)
 	require.NoError(t, err)
 	o.CustomerName = "Maya"
@@ -52,14 +38,13 @@ func seedActiveOrder(t *testing.T, repo order.Repository) *order.Order {
 
 func TestRequestServerHandler_PublishesOnceThenThrottles(t *testing.T) {
 	repo := memory.NewMemoryOrderRepository()
-	bus := &recordingBus{}
 	seedActiveOrder(t, repo)
 
-	h := orderCmd.NewRequestServerHandler(repo, bus, nil, nil)
+	h := orderCmd.NewRequestServerHandler(repo, nil, nil)
 
 	_, err := h.Handle(context.Background(), orderCmd.RequestServer{OrderID: "o1"})
 	require.NoError(t, err)
-	assert.Equal(t, 1, bus.count(common.EventServerCalled), "first call publishes")
+	assert.Equal(t, 1, outboxCount(repo, common.EventServerCalled), "first call publishes")
 
 	saved, _ := repo.FindByID("o1")
 	assert.NotNil(t, saved.ServerCalledAt, "request persisted on the order")
@@ -67,21 +52,20 @@ func TestRequestServerHandler_PublishesOnceThenThrottles(t *testing.T) {
 	// Immediate repeat is within the throttle window: idempotent success, no event.
 	_, err = h.Handle(context.Background(), orderCmd.RequestServer{OrderID: "o1"})
 	require.NoError(t, err)
-	assert.Equal(t, 1, bus.count(common.EventServerCalled), "repeat within window does not publish")
+	assert.Equal(t, 1, outboxCount(repo, common.EventServerCalled), "repeat within window does not publish")
 }
 
 func TestRequestBillHandler_PublishesOnceThenThrottles(t *testing.T) {
 	repo := memory.NewMemoryOrderRepository()
-	bus := &recordingBus{}
 	seedActiveOrder(t, repo)
 
-	h := orderCmd.NewRequestBillHandler(repo, bus, nil, nil)
+	h := orderCmd.NewRequestBillHandler(repo, nil, nil)
 
 	_, err := h.Handle(context.Background(), orderCmd.RequestBill{OrderID: "o1"})
 	require.NoError(t, err)
-	assert.Equal(t, 1, bus.count(common.EventBillRequested))
+	assert.Equal(t, 1, outboxCount(repo, common.EventBillRequested))
 
 	_, err = h.Handle(context.Background(), orderCmd.RequestBill{OrderID: "o1"})
 	require.NoError(t, err)
-	assert.Equal(t, 1, bus.count(common.EventBillRequested))
+	assert.Equal(t, 1, outboxCount(repo, common.EventBillRequested))
 }
@@ -0,0 +1,233 @@
+package payment_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/envelope"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/outbox"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	"bitmerchant/internal/payment/app/event"
+	"bitmerchant/internal/payment/domain/payment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outboxEvents decodes the events on topic waiting in repo's outbox.
+func outboxEvents[T any](t *testing.T, repo *payAdapters.MemoryPaymentRepository, topic string) []T {
+	t.Helper()
+	var out []T
+	for _, m := range repo.Outbox().Pending() {
+		if m.Topic != topic {
+			continue
+		}
+		var ev T
+		_, err := envelope.Decode(m.Payload, &ev)
+		require.NoError(t, err)
+		out = append(out, ev)
+	}
+	return out
+}
+
+// flakyBus fails its first `failures` publishes, then records the topics of
+// the rest.
+type flakyBus struct {
+	mu        sync.Mutex
+	failures  int
+	published []string
+}
+
+func (b *flakyBus) PublishRaw(topic, _ string, _ []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures > 0 {
+		b.failures--
+		return errors.New("broker unavailable")
+	}
+	b.published = append(b.published, topic)
+	return nil
+}
+
+func (b *flakyBus) topics() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.published...)
+}
+
+func TestRecordPayment_CreatesCashPayment(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+
+	p, err := h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(1250, money.USD), Tendered: money.New(2000, money.USD), CollectedBy: "user-1",
+	})
+	require.NoError(t, err)
+
+	stored, err := repo.FindByOrderID("o1")
+	require.NoError(t, err)
+	assert.Equal(t, p.ID, stored.ID)
+	assert.Equal(t, common.PaymentStatusPaid, stored.Status)
+	assert.Equal(t, int64(1250), stored.Amount)
+	assert.Equal(t, int64(2000), stored.TenderedAmount)
+	assert.Equal(t, int64(750), stored.ChangeGiven)
+	assert.Equal(t, common.UserID("user-1"), stored.CollectedBy)
+
+	completed := outboxEvents[event.PaymentCompleted](t, repo, common.EventPaymentCompleted)
+	require.Len(t, completed, 1)
+	ev := completed[0]
+	assert.Equal(t, common.EventPaymentCompleted, ev.EventName())
+	assert.Equal(t, p.ID, ev.PaymentID)
+	assert.Equal(t, "USD", ev.Currency)
+	assert.Equal(t, int64(750), ev.ChangeGiven)
+}
+
+func TestRecordPayment_AlreadyPaidIsIdempotent(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	cmd := payCmd.RecordPayment{OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash, Amount: money.New(500, money.USD)}
+
+	first, err := h.Handle(context.Background(), cmd)
+	require.NoError(t, err)
+	second, err := h.Handle(context.Background(), cmd)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Len(t, outboxEvents[event.PaymentCompleted](t, repo, common.EventPaymentCompleted), 1)
+}
+
+// The event is written with the payment, so a broker outage only delays it:
+// the relay retries, and marking paid again does not need to republish.
+func TestRecordPayment_EventSurvivesAFailedPublish(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	cmd := payCmd.RecordPayment{OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash, Amount: money.New(500, money.USD)}
+	_, err := h.Handle(context.Background(), cmd)
+	require.NoError(t, err)
+
+	bus := &flakyBus{failures: 1}
+	relay := outbox.NewRelay(repo.Outbox(), bus, nil)
+	n, err := relay.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, repo.Outbox().Pending(), 1, "kept for a retry")
+
+	_, err = h.Handle(context.Background(), cmd)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go relay.Run(ctx)
+	require.Eventually(t, func() bool { return len(bus.topics()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{common.EventPaymentCompleted}, bus.topics())
+	assert.Empty(t, repo.Outbox().Pending())
+}
+
+func TestRecordPayment_SettlesPendingPayment(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	pending, err := payment.NewPaymentWithCurrency("pay_pending", "o1", "r1", common.PaymentMethodTypeCash, 500, money.USD)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(pending))
+
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	p, err := h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash, Amount: money.New(500, money.USD),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentID("pay_pending"), p.ID)
+	assert.Equal(t, common.PaymentStatusPaid, p.Status)
+}
+
+// Marking a Lightning order paid by hand must not book sats that never
+// arrived: the open invoice stays pending.
+func TestRecordPayment_RefusesToSettleLightningByHand(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	pending, err := payment.NewPaymentWithCurrency("pay_pending", "o1", "r1", common.PaymentMethodTypeLightning, 2000, money.SAT)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(pending))
+
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	_, err = h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeLightning, Amount: money.New(500, money.USD),
+	})
+	assert.ErrorIs(t, err, payment.ErrSettledByInvoice)
+
+	stored, err := repo.FindByID("pay_pending")
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPending, stored.Status)
+	assert.Empty(t, repo.Outbox().Pending())
+}
+
+func TestRecordPayment_KeepsSettledLightningInvoice(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	settled, err := payment.NewPaymentWithCurrency("pay_ln", "o1", "r1", common.PaymentMethodTypeLightning, 2000, money.SAT)
+	require.NoError(t, err)
+	require.NoError(t, settled.MarkPaid("o1"))
+	require.NoError(t, repo.Save(settled))
+
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	p, err := h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeLightning, Amount: money.New(500, money.USD),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentID("pay_ln"), p.ID)
+}
+
+func TestRecordPayment_SwitchedTenderExpiresPending(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	pending, err := payment.NewPaymentWithCurrency("pay_pending", "o1", "r1", common.PaymentMethodTypeLightning, 2000, money.SAT)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(pending))
+
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	p, err := h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash, Amount: money.New(500, money.USD),
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, common.PaymentID("pay_pending"), p.ID)
+	assert.Equal(t, common.PaymentMethodTypeCash, p.Method)
+
+	stale, err := repo.FindByID("pay_pending")
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusExpired, stale.Status)
+}
+
+func TestRecordPayment_RejectsShortTender(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+
+	_, err := h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Amount: money.New(1250, money.USD), Tendered: money.New(1000, money.USD),
+	})
+	assert.ErrorIs(t, err, payment.ErrInsufficientTender)
+	_, err = repo.FindByOrderID("o1")
+	assert.Error(t, err)
+	assert.Empty(t, repo.Outbox().Pending())
+}
+
+func TestRecordPayment_KeepsCashRoundingAdjustment(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	h := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+
+	_, err := h.Handle(context.Background(), payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(1075, money.THB), Tendered: money.New(2000, money.THB),
+		RoundingAdjustment: -5,
+	})
+	require.NoError(t, err)
+
+	stored, err := repo.FindByOrderID("o1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5), stored.RoundingAdjustment)
+	assert.Equal(t, int64(925), stored.ChangeGiven, "change is counted from the rounded amount")
+
+	refund, err := payment.NewRefund("ref-1", stored, "wrong table", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5), refund.RoundingAdjustment)
+}
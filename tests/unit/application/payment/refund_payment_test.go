@@ -0,0 +1,153 @@
+package payment_test
+
+import (
+	"context"
+	"testing"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	"bitmerchant/internal/payment/app/event"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/shift"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundPayment_CashLeavesTheDrawer(t *testing.T) {
+	ctx := context.Background()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	shifts := payAdapters.NewMemoryShiftRepository()
+	open := payCmd.NewOpenShiftHandler(shifts, nil, nil)
+	record := payCmd.NewRecordPaymentHandler(repo, shifts, nil, nil)
+	refund := payCmd.NewRefundPaymentHandler(repo, shifts, nil, nil)
+
+	_, err := open.Handle(ctx, payCmd.OpenShift{RestaurantID: "r1", StaffID: "user-1", Currency: money.USD, OpeningFloat: 5000})
+	require.NoError(t, err)
+	charge, err := record.Handle(ctx, payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(1250, money.USD), CollectedBy: "user-1",
+	})
+	require.NoError(t, err)
+
+	cmd := payCmd.RefundPayment{OrderID: "o1", Reason: "Kitchen error: dropped", RefundedBy: "user-1"}
+	require.NoError(t, refund.Handle(ctx, cmd))
+	require.NoError(t, refund.Handle(ctx, cmd), "a second refund is a no-op")
+
+	stored, err := repo.FindByOrderID("o1")
+	require.NoError(t, err)
+	assert.Equal(t, charge.ID, stored.ID, "refund rows are not returned as the order's charge")
+	assert.Equal(t, common.PaymentStatusRefunded, stored.Status)
+	assert.NotNil(t, stored.RefundedAt)
+
+	all, err := repo.FindByRestaurantID("r1")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	s, err := shifts.FindOpenByStaff("r1", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250), s.Total(shift.MovementRefund))
+	assert.Equal(t, int64(5000), s.ExpectedCash())
+
+	refunded := outboxEvents[event.PaymentRefunded](t, repo, common.EventPaymentRefunded)
+	require.Len(t, refunded, 1)
+	ev := refunded[0]
+	assert.Equal(t, common.EventPaymentRefunded, ev.EventName())
+	assert.Equal(t, charge.ID, ev.PaymentID)
+	assert.Equal(t, int64(1250), ev.Amount)
+}
+
+func TestRefundPayment_NoChargeIsNoop(t *testing.T) {
+	repo := payAdapters.NewMemoryPaymentRepository()
+	refund := payCmd.NewRefundPaymentHandler(repo, nil, nil, nil)
+	assert.NoError(t, refund.Handle(context.Background(), payCmd.RefundPayment{OrderID: "missing", RefundedBy: "user-1"}))
+	assert.Empty(t, repo.Outbox().Pending())
+}
+
+func TestVoidPayment(t *testing.T) {
+	ctx := context.Background()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	void := payCmd.NewVoidPaymentHandler(repo, nil, nil)
+
+	pending, _ := payment.NewPayment("pay-1", "o1", "r1", common.PaymentMethodTypeLightning, 1000)
+	require.NoError(t, repo.Save(pending))
+	require.NoError(t, void.Handle(ctx, payCmd.VoidPayment{OrderID: "o1", Reason: "Duplicate order"}))
+
+	stored, err := repo.FindByOrderID("o1")
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusVoided, stored.Status)
+	watched, err := repo.FindPendingByMethod(common.PaymentMethodTypeLightning)
+	require.NoError(t, err)
+	assert.Empty(t, watched)
+
+	paid, _ := payment.NewPayment("pay-2", "o2", "r1", common.PaymentMethodTypeCash, 1000)
+	paid.MarkAsPaid()
+	require.NoError(t, repo.Save(paid))
+	assert.ErrorIs(t, void.Handle(ctx, payCmd.VoidPayment{OrderID: "o2"}), payment.ErrAlreadySettled)
+
+	assert.NoError(t, void.Handle(ctx, payCmd.VoidPayment{OrderID: "no-ledger"}))
+}
+
+func TestRefundPayment_SplitBillRefundsEveryPart(t *testing.T) {
+	ctx := context.Background()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	record := payCmd.NewRecordPaymentHandler(repo, nil, nil, nil)
+	refund := payCmd.NewRefundPaymentHandler(repo, nil, nil, nil)
+
+	for _, part := range []common.BillPartID{"part-1", "part-2"} {
+		p, err := record.Handle(ctx, payCmd.RecordPayment{
+			OrderID: "o1", RestaurantID: "r1", BillPartID: part, Method: common.PaymentMethodTypeCash,
+			Amount: money.New(500, money.USD), CollectedBy: "user-1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, part, p.BillPartID)
+	}
+	again, err := record.Handle(ctx, payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", BillPartID: "part-1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(500, money.USD),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, common.BillPartID("part-1"), again.BillPartID, "a settled part is not charged twice")
+
+	require.NoError(t, refund.Handle(ctx, payCmd.RefundPayment{OrderID: "o1", Reason: "Duplicate order", RefundedBy: "user-1"}))
+
+	charges, err := repo.FindChargesByOrderID("o1")
+	require.NoError(t, err)
+	require.Len(t, charges, 2)
+	for _, c := range charges {
+		assert.Equal(t, common.PaymentStatusRefunded, c.Status)
+	}
+	refunded := outboxEvents[event.PaymentRefunded](t, repo, common.EventPaymentRefunded)
+	require.Len(t, refunded, 2)
+	for _, r := range refunded {
+		assert.Equal(t, int64(500), r.Amount)
+	}
+}
+
+func TestVoidPayment_KeepSettled(t *testing.T) {
+	ctx := context.Background()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	void := payCmd.NewVoidPaymentHandler(repo, nil, nil)
+
+	paid, _ := payment.NewPayment("pay-1", "o1", "r1", common.PaymentMethodTypeCash, 500)
+	paid.BillPartID = "part-1"
+	paid.MarkAsPaid()
+	require.NoError(t, repo.Save(paid))
+	pending, _ := payment.NewPayment("pay-2", "o1", "r1", common.PaymentMethodTypeLightning, 500)
+	pending.BillPartID = "part-2"
+	require.NoError(t, repo.Save(pending))
+
+	assert.ErrorIs(t, void.Handle(ctx, payCmd.VoidPayment{OrderID: "o1"}), payment.ErrAlreadySettled)
+	require.NoError(t, void.Handle(ctx, payCmd.VoidPayment{OrderID: "o1", Reason: "bill split", KeepSettled: true}))
+
+	charges, err := repo.FindChargesByOrderID("o1")
+	require.NoError(t, err)
+	statuses := map[common.PaymentID]common.PaymentStatus{}
+	for _, c := range charges {
+		statuses[c.ID] = c.Status
+	}
+	assert.Equal(t, common.PaymentStatusPaid, statuses["pay-1"])
+	assert.Equal(t, common.PaymentStatusVoided, statuses["pay-2"])
+}
@@ -0,0 +1,167 @@
+package payment_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/payment"
+	"bitmerchant/internal/payment/domain/shift"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftLifecycle(t *testing.T) {
+	ctx := context.Background()
+	shifts := payAdapters.NewMemoryShiftRepository()
+	open := payCmd.NewOpenShiftHandler(shifts, nil, nil)
+	move := payCmd.NewRecordDrawerMovementHandler(shifts, nil, nil)
+	closeShift := payCmd.NewCloseShiftHandler(shifts, nil, nil)
+	record := payCmd.NewRecordPaymentHandler(payAdapters.NewMemoryPaymentRepository(), shifts, nil, nil)
+
+	s, err := open.Handle(ctx, payCmd.OpenShift{RestaurantID: "r1", StaffID: "user-1", Currency: money.USD, OpeningFloat: 5000})
+	require.NoError(t, err)
+	_, err = open.Handle(ctx, payCmd.OpenShift{RestaurantID: "r1", StaffID: "user-1", Currency: money.USD})
+	assert.ErrorIs(t, err, shift.ErrShiftAlreadyOpen)
+
+	_, err = record.Handle(ctx, payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(1250, money.USD), Tendered: money.New(2000, money.USD), CollectedBy: "user-1",
+	})
+	require.NoError(t, err)
+	_, err = record.Handle(ctx, payCmd.RecordPayment{
+		OrderID: "o2", RestaurantID: "r1", Method: common.PaymentMethodTypeLightning,
+		Amount: money.New(900, money.USD), CollectedBy: "user-1",
+	})
+	assert.ErrorIs(t, err, payment.ErrSettledByInvoice)
+
+	_, err = move.Handle(ctx, payCmd.RecordDrawerMovement{RestaurantID: "r1", StaffID: "user-1", Kind: shift.MovementPayOut, Amount: money.New(500, money.USD), Reason: "Ice"})
+	require.NoError(t, err)
+	_, err = move.Handle(ctx, payCmd.RecordDrawerMovement{RestaurantID: "r1", StaffID: "user-1", Kind: "tip", Amount: money.New(500, money.USD), Reason: "x"})
+	assert.Error(t, err)
+
+	closed, err := closeShift.Handle(ctx, payCmd.CloseShift{RestaurantID: "r1", StaffID: "user-1", Counted: money.New(5700, money.USD), Note: "ok"})
+	require.NoError(t, err)
+	assert.Equal(t, s.ID, closed.ID)
+	assert.Equal(t, int64(1250), closed.Total(shift.MovementSale), "only cash sales land in the drawer")
+	assert.Equal(t, int64(5750), closed.ExpectedCash())
+	assert.Equal(t, int64(-50), closed.Variance())
+
+	_, err = closeShift.Handle(ctx, payCmd.CloseShift{RestaurantID: "r1", StaffID: "user-1", Counted: money.New(100, money.USD)})
+	assert.ErrorIs(t, err, shift.ErrShiftNotFound)
+
+	history := payQuery.NewShiftHistoryHandler(shifts, nil, nil)
+	all, err := history.Handle(ctx, payQuery.ShiftHistory{RestaurantID: "r1", StaffID: "user-1"})
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+	none, err := history.Handle(ctx, payQuery.ShiftHistory{RestaurantID: "r1", StaffID: "user-2"})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	report := payQuery.NewShiftReportHandler(shifts, nil, nil)
+	_, err = report.Handle(ctx, payQuery.ShiftReport{RestaurantID: "r2", ShiftID: s.ID})
+	assert.ErrorIs(t, err, shift.ErrShiftNotFound)
+}
+
+func TestRecordPayment_BooksCashIntoSoleOpenDrawer(t *testing.T) {
+	ctx := context.Background()
+	shifts := payAdapters.NewMemoryShiftRepository()
+	s, _ := shift.Open("shift-1", "r1", "server-1", money.USD, 0)
+	require.NoError(t, shifts.Save(s))
+	record := payCmd.NewRecordPaymentHandler(payAdapters.NewMemoryPaymentRepository(), shifts, nil, nil)
+
+	// The owner marks paid without a drawer of their own.
+	_, err := record.Handle(ctx, payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(800, money.USD), CollectedBy: "owner-1",
+	})
+	require.NoError(t, err)
+
+	got, err := shifts.FindByID("shift-1")
+	require.NoError(t, err)
+	require.Len(t, got.Movements, 1)
+	assert.Equal(t, common.UserID("owner-1"), got.Movements[0].By)
+	assert.Equal(t, int64(800), got.ExpectedCash())
+}
+
+// flakyShifts fails its first `failures` updates. It hands out copies, as the
+// Postgres repository does, so a failed update leaves the stored drawer as
+// it was.
+type flakyShifts struct {
+	*payAdapters.MemoryShiftRepository
+	failures int
+}
+
+func (r *flakyShifts) FindOpenByStaff(restaurantID common.RestaurantID, staffID common.UserID) (*shift.Shift, error) {
+	s, err := r.MemoryShiftRepository.FindOpenByStaff(restaurantID, staffID)
+	if err != nil {
+		return nil, err
+	}
+	c := *s
+	c.Movements = append([]shift.Movement(nil), s.Movements...)
+	return &c, nil
+}
+
+func (r *flakyShifts) Update(s *shift.Shift) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("database unavailable")
+	}
+	return r.MemoryShiftRepository.Update(s)
+}
+
+func TestRecordPayment_RetryBooksCashTheDrawerMissed(t *testing.T) {
+	ctx := context.Background()
+	shifts := &flakyShifts{MemoryShiftRepository: payAdapters.NewMemoryShiftRepository(), failures: 1}
+	s, _ := shift.Open("shift-1", "r1", "user-1", money.USD, 0)
+	require.NoError(t, shifts.Save(s))
+	record := payCmd.NewRecordPaymentHandler(payAdapters.NewMemoryPaymentRepository(), shifts, nil, nil)
+	cmd := payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(800, money.USD), CollectedBy: "user-1",
+	}
+
+	_, err := record.Handle(ctx, cmd)
+	require.Error(t, err, "the payment is stored but the drawer update fails")
+
+	// Marking paid again finds the payment already paid and books it now,
+	// and once only however often it is retried.
+	_, err = record.Handle(ctx, cmd)
+	require.NoError(t, err)
+	_, err = record.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	got, err := shifts.FindByID("shift-1")
+	require.NoError(t, err)
+	require.Len(t, got.Movements, 1)
+	assert.Equal(t, int64(800), got.ExpectedCash())
+}
+
+func TestRecordPayment_RetryLeavesLaterDrawerAlone(t *testing.T) {
+	ctx := context.Background()
+	shifts := payAdapters.NewMemoryShiftRepository()
+	record := payCmd.NewRecordPaymentHandler(payAdapters.NewMemoryPaymentRepository(), shifts, nil, nil)
+	cmd := payCmd.RecordPayment{
+		OrderID: "o1", RestaurantID: "r1", Method: common.PaymentMethodTypeCash,
+		Amount: money.New(800, money.USD), CollectedBy: "user-1",
+	}
+	_, err := record.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	// The cash was taken before this drawer opened, so a retry must not
+	// count it here.
+	s, _ := shift.Open("shift-1", "r1", "user-1", money.USD, 0)
+	require.NoError(t, shifts.Save(s))
+	_, err = record.Handle(ctx, cmd)
+	require.NoError(t, err)
+
+	got, err := shifts.FindByID("shift-1")
+	require.NoError(t, err)
+	assert.Empty(t, got.Movements)
+}
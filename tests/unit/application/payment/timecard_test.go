@@ -0,0 +1,59 @@
+package payment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	payQuery "bitmerchant/internal/payment/app/query"
+	"bitmerchant/internal/payment/domain/timecard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeClockLifecycle(t *testing.T) {
+	ctx := context.Background()
+	cards := payAdapters.NewMemoryTimecardRepository()
+	clockIn := payCmd.NewClockInHandler(cards, nil, nil)
+	clockOut := payCmd.NewClockOutHandler(cards, nil, nil)
+	recent := payQuery.NewStaffTimecardsHandler(cards, nil, nil)
+
+	_, err := clockOut.Handle(ctx, payCmd.ClockOut{RestaurantID: "r1", StaffID: "user-1"})
+	assert.ErrorIs(t, err, timecard.ErrNotFound, "nothing to clock out of")
+
+	in, err := clockIn.Handle(ctx, payCmd.ClockIn{RestaurantID: "r1", StaffID: "user-1", Role: common.RoleServer})
+	require.NoError(t, err)
+	assert.True(t, in.IsOpen())
+	_, err = clockIn.Handle(ctx, payCmd.ClockIn{RestaurantID: "r1", StaffID: "user-1", Role: common.RoleServer})
+	assert.ErrorIs(t, err, timecard.ErrAlreadyClockedIn)
+	_, err = clockIn.Handle(ctx, payCmd.ClockIn{RestaurantID: "r2", StaffID: "user-1", Role: common.RoleKitchenStaff})
+	assert.NoError(t, err, "the open card is per restaurant")
+	_, err = clockIn.Handle(ctx, payCmd.ClockIn{RestaurantID: "r1", StaffID: "guest", Role: common.RoleCustomer})
+	assert.Error(t, err, "only staff can clock in")
+
+	out, err := clockOut.Handle(ctx, payCmd.ClockOut{RestaurantID: "r1", StaffID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, in.ID, out.ID)
+	assert.False(t, out.IsOpen())
+
+	list, err := recent.Handle(ctx, payQuery.StaffTimecards{RestaurantID: "r1", StaffID: "user-1"})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.NotNil(t, list[0].ClockedOutAt)
+}
+
+func TestTimecardWorked_ClipsToPeriod(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	out := day.Add(2 * time.Hour)
+	card := &timecard.Timecard{ClockedInAt: day.Add(-1 * time.Hour), ClockedOutAt: &out}
+
+	assert.Equal(t, 2*time.Hour, card.Worked(day, day.AddDate(0, 0, 1), day), "the hour before the period is left out")
+	assert.Zero(t, card.Worked(day.Add(3*time.Hour), day.AddDate(0, 0, 1), day))
+
+	open := &timecard.Timecard{ClockedInAt: day.Add(8 * time.Hour)}
+	assert.Equal(t, 90*time.Minute, open.Worked(day, day.AddDate(0, 0, 1), day.Add(9*time.Hour+30*time.Minute)), "an open card counts up to now")
+}
@@ -32,9 +32,8 @@ func TestSessionVisitedPlacesHandler(t *testing.T) {
 	require.NoError(t, visits.Upsert(context.Background(), visit.NewSessionRestaurantVisit("s1", "r1", older, older)))
 	require.NoError(t, visits.Upsert(context.Background(), visit.NewSessionRestaurantVisit("s1", "r2", newer, newer)))
 
-	item, _ := order.NewOrderItem("oi", "o1", "mi", "X", 1, 1)
+	item, _ := order.NewOrderItem("oi", "o1", "mi", "X", 1, 100)
 	o, _ := order.NewOrder("o1", "1001", "r1", "s1", []order.OrderItem{*item}, 100, common.PaymentMethodTypeCash)
-	o.FiatAmount = 1
 	require.NoError(t, ord.Save(o))
 
 	h := placesQuery.NewSessionVisitedPlacesHandler(visits, rest, ord, nil, nil)
@@ -0,0 +1,117 @@
+package promotion_test
+
+import (
+	"context"
+	"testing"
+
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	promoAdapters "bitmerchant/internal/promotion/adapters"
+	promoCmd "bitmerchant/internal/promotion/app/command"
+	promoQuery "bitmerchant/internal/promotion/app/query"
+	"bitmerchant/internal/promotion/domain/promotion"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	repo   *promoAdapters.MemoryPromotionRepository
+	create promoCmd.CreatePromotionHandler
+	redeem promoCmd.RedeemPromotionHandler
+	price  promoQuery.OrderDiscountHandler
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	rests := memory.NewMemoryRestaurantRepository()
+	r, err := restaurant.NewRestaurant("r1", "Cafe")
+	require.NoError(t, err)
+	require.NoError(t, rests.Save(r))
+	repo := promoAdapters.NewMemoryPromotionRepository()
+	return fixture{
+		repo:   repo,
+		create: promoCmd.NewCreatePromotionHandler(repo, rests, nil, nil),
+		redeem: promoCmd.NewRedeemPromotionHandler(repo, nil, nil),
+		price:  promoQuery.NewOrderDiscountHandler(repo, nil, nil),
+	}
+}
+
+func (f fixture) add(t *testing.T, terms promotion.Terms) *promotion.Promotion {
+	t.Helper()
+	p, err := f.create.Handle(context.Background(), promoCmd.CreatePromotion{RestaurantID: "r1", Terms: terms})
+	require.NoError(t, err)
+	return p
+}
+
+var order = promoQuery.OrderDiscount{
+	RestaurantID: "r1",
+	SessionID:    "sess-1",
+	Lines:        []promotion.Line{{CategoryID: "drinks", Quantity: 2, UnitPrice: 500}, {CategoryID: "mains", Quantity: 1, UnitPrice: 2000}},
+	Subtotal:     3000,
+}
+
+func TestOrderDiscount_BestPromotionWins(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.add(t, promotion.Terms{Name: "Drinks deal", Kind: promotion.KindFixed, AmountOff: 300, CategoryID: "drinks"})
+	tenOff := f.add(t, promotion.Terms{Name: "Ten off", Code: "TEN", Kind: promotion.KindPercentage, PercentOff: 10})
+
+	d, err := f.price.Handle(ctx, order)
+	require.NoError(t, err)
+	require.NotNil(t, d.Promotion)
+	assert.Equal(t, "Drinks deal", d.Promotion.Name, "automatic deals apply without a code")
+	assert.Equal(t, int64(300), d.Amount)
+
+	withCode := order
+	withCode.Code = "ten"
+	d, err = f.price.Handle(ctx, withCode)
+	require.NoError(t, err)
+	assert.Equal(t, tenOff.ID, d.Promotion.ID, "on a tie the entered code wins")
+	assert.Equal(t, int64(300), d.Amount)
+
+	f.add(t, promotion.Terms{Name: "Mains deal", Kind: promotion.KindFixed, AmountOff: 800, CategoryID: "mains"})
+	d, err = f.price.Handle(ctx, withCode)
+	require.NoError(t, err)
+	assert.Equal(t, "Mains deal", d.Promotion.Name, "promotions never stack; the larger saving wins")
+	assert.Equal(t, int64(800), d.Amount)
+}
+
+func TestOrderDiscount_RejectsUnusableCode(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.add(t, promotion.Terms{Name: "Big spender", Code: "BIG", Kind: promotion.KindFixed, AmountOff: 500, MinSubtotal: 5000})
+
+	q := order
+	q.Code = "NOPE"
+	_, err := f.price.Handle(ctx, q)
+	assert.ErrorIs(t, err, promotion.ErrUnknownCode)
+
+	q.Code = "BIG"
+	_, err = f.price.Handle(ctx, q)
+	assert.ErrorIs(t, err, promotion.ErrBelowMinimum)
+	assert.True(t, promotion.IsRejection(err))
+}
+
+func TestRedeemPromotion_EnforcesLimits(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	p := f.add(t, promotion.Terms{Name: "Launch", Code: "LAUNCH", Kind: promotion.KindPercentage, PercentOff: 20, MaxUses: 2, MaxUsesPerSession: 1})
+
+	require.NoError(t, f.redeem.Handle(ctx, promoCmd.RedeemPromotion{PromotionID: p.ID, SessionID: "sess-1", OrderID: "o1", Amount: 600}))
+	err := f.redeem.Handle(ctx, promoCmd.RedeemPromotion{PromotionID: p.ID, SessionID: "sess-1", OrderID: "o2", Amount: 600})
+	assert.ErrorIs(t, err, promotion.ErrSessionLimitReached)
+
+	q := order
+	q.Code = "LAUNCH"
+	_, err = f.price.Handle(ctx, q)
+	assert.ErrorIs(t, err, promotion.ErrSessionLimitReached, "the customer is told before ordering")
+
+	require.NoError(t, f.redeem.Handle(ctx, promoCmd.RedeemPromotion{PromotionID: p.ID, SessionID: "sess-2", OrderID: "o3", Amount: 600}))
+	err = f.redeem.Handle(ctx, promoCmd.RedeemPromotion{PromotionID: p.ID, SessionID: "sess-3", OrderID: "o4", Amount: 600})
+	assert.ErrorIs(t, err, promotion.ErrUsageLimitReached)
+
+	stored, err := f.repo.FindByID(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.UsesCount)
+}
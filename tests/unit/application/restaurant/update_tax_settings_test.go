@@ -0,0 +1,54 @@
+package restaurant_test
+
+import (
+	"context"
+	"testing"
+
+	"bitmerchant/internal/common/tax"
+	"bitmerchant/internal/infrastructure/repositories/memory"
+	restaurantCmd "bitmerchant/internal/restaurant/app/command"
+	"bitmerchant/internal/restaurant/domain/restaurant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTaxSettingsHandler(t *testing.T) {
+	repo := memory.NewMemoryRestaurantRepository()
+	r, err := restaurant.NewRestaurant("r1", "Diner")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(r))
+
+	h := restaurantCmd.NewUpdateTaxSettingsHandler(repo, nil, nil)
+
+	t.Run("unset restaurants fall back to their single rate", func(t *testing.T) {
+		stored, err := repo.FindByID("r1")
+		require.NoError(t, err)
+		assert.Equal(t, tax.Exclusive(restaurant.DefaultTaxRate), stored.EffectiveTaxSettings())
+	})
+
+	t.Run("valid settings are normalized and persisted", func(t *testing.T) {
+		require.NoError(t, h.Handle(context.Background(), restaurantCmd.UpdateTaxSettings{
+			RestaurantID: "r1",
+			Settings: tax.Config{
+				Mode:              tax.ModeInclusive,
+				Taxes:             []tax.Tax{{Name: " VAT ", Rate: 700}},
+				ServiceChargeRate: 1000,
+			},
+		}))
+		stored, err := repo.FindByID("r1")
+		require.NoError(t, err)
+		cfg := stored.EffectiveTaxSettings()
+		assert.True(t, cfg.Inclusive())
+		assert.Equal(t, []tax.Tax{{Name: "VAT", Rate: 700}}, cfg.Taxes)
+		assert.Equal(t, int64(1000), cfg.ServiceChargeRate)
+	})
+
+	t.Run("rejects invalid taxes", func(t *testing.T) {
+		err := h.Handle(context.Background(), restaurantCmd.UpdateTaxSettings{
+			RestaurantID: "r1",
+			Settings:     tax.Config{Taxes: []tax.Tax{{Name: "VAT", Rate: 12_000}}},
+		})
+		assert.ErrorIs(t, err, tax.ErrInvalidRate)
+	})
+}
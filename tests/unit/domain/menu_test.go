@@ -57,7 +57,7 @@ func TestNewMenuItem(t *testing.T) {
 		catID := common.CategoryID("cat_1")
 		restID := common.RestaurantID("rest_1")
 		name := "Burger"
-		price := 12.50
+		price := int64(1250)
 
 		item, err := menu.NewMenuItem(id, catID, restID, name, price)
 
@@ -77,9 +77,11 @@ func TestNewMenuItem(t *testing.T) {
 		assert.Equal(t, "5,000 sats", item.Money().Format())
 	})
 
-	t.Run("should reject fractional sat prices", func(t *testing.T) {
-		_, err := menu.NewMenuItemWithCurrency("id", "cat", "rest", "n", 0.5, money.SAT)
+	t.Run("should reject fiat prices above the cap", func(t *testing.T) {
+		_, err := menu.NewMenuItemWithCurrency("id", "cat", "rest", "n", 10_000_000_001, money.USD)
 		assert.Error(t, err)
+		_, err = menu.NewMenuItemWithCurrency("id", "cat", "rest", "n", 10_000_000_000, money.USD)
+		assert.NoError(t, err)
 	})
 
 	t.Run("should reject sat prices above the cap", func(t *testing.T) {
@@ -90,18 +92,18 @@ func TestNewMenuItem(t *testing.T) {
 	t.Run("should fail with invalid price", func(t *testing.T) {
 		_, err := menu.NewMenuItem("id", "cid", "rid", "name", 0)
 		assert.Error(t, err)
-		_, err = menu.NewMenuItem("id", "cid", "rid", "name", -10)
+		_, err = menu.NewMenuItem("id", "cid", "rid", "name", -1000)
 		assert.Error(t, err)
 	})
 
 	t.Run("should fail with invalid name", func(t *testing.T) {
-		_, err := menu.NewMenuItem("id", "cid", "rid", "", 10)
+		_, err := menu.NewMenuItem("id", "cid", "rid", "", 1000)
 		assert.Error(t, err)
 	})
 }
 
 func TestMenuItem_Setters(t *testing.T) {
-	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 10)
+	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 1000)
 
 	// Description
 	err := item.SetDescription("Tasty burger")
@@ -127,7 +129,7 @@ func TestMenuItem_Setters(t *testing.T) {
 }
 
 func TestNewMenuItem_Defaults(t *testing.T) {
-	item, err := menu.NewMenuItem("id", "cid", "rid", "name", 10)
+	item, err := menu.NewMenuItem("id", "cid", "rid", "name", 1000)
 	require.NoError(t, err)
 	assert.Equal(t, menu.ScheduleAllDay, item.Schedule, "new items default to ALL_DAY schedule")
 	assert.True(t, item.AllowSpecialInstructions, "new items allow special instructions by default")
@@ -264,7 +266,7 @@ func TestValidateOptionGroupRules(t *testing.T) {
 }
 
 func TestMenuItem_SetOptionGroups_ValidatesEach(t *testing.T) {
-	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 10)
+	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 1000)
 
 	t.Run("happy path", func(t *testing.T) {
 		defaultID := "o1"
@@ -287,7 +289,7 @@ func TestMenuItem_SetOptionGroups_ValidatesEach(t *testing.T) {
 }
 
 func TestMenuItem_SetBadges_SetAllergens_Roundtrip(t *testing.T) {
-	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 10)
+	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 1000)
 	require.NoError(t, item.SetBadges([]string{"Popular", "  ", "Popular"}))
 	assert.Equal(t, []string{"Popular"}, item.Badges)
 
@@ -296,7 +298,7 @@ func TestMenuItem_SetBadges_SetAllergens_Roundtrip(t *testing.T) {
 }
 
 func TestMenuItem_DietaryTagsString_IncludesExpandedSet(t *testing.T) {
-	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 10)
+	item, _ := menu.NewMenuItem("id", "cid", "rid", "name", 1000)
 	item.SetDietaryFlags(true, true, false, true, false, true)
 	tags := item.DietaryTagsString()
 	assert.Contains(t, tags, "vegetarian")
@@ -306,7 +308,7 @@ func TestMenuItem_DietaryTagsString_IncludesExpandedSet(t *testing.T) {
 }
 
 func TestMenuItem_Translations(t *testing.T) {
-	item, err := menu.NewMenuItem("i1", "c1", "r1", "Pork Bao", 6.50)
+	item, err := menu.NewMenuItem("i1", "c1", "r1", "Pork Bao", 650)
 	require.NoError(t, err)
 	item.Description = "Steamed bun with pork"
 
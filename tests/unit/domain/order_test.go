@@ -2,7 +2,9 @@ package domain_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/ordering/domain/order"
+	"fmt"
 
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -10,7 +12,7 @@ import (
 )
 
 func TestNewOrder(t *testing.T) {
-	item, _ := order.NewOrderItem("oi_1", "o_1", "mi_1", "Burger", 2, 10.0)
+	item, _ := order.NewOrderItem("oi_1", "o_1", "mi_1", "Burger", 2, 1000)
 	items := []order.OrderItem{*item}
 
 	t.Run("should create valid order", func(t *testing.T) {
@@ -44,14 +46,14 @@ func TestNewOrder(t *testing.T) {
 
 func TestNewOrderItem(t *testing.T) {
 	t.Run("should create valid order item", func(t *testing.T) {
-		item, err := order.NewOrderItem("oi_1", "o_1", "mi_1", "Burger", 2, 10.50)
+		item, err := order.NewOrderItem("oi_1", "o_1", "mi_1", "Burger", 2, 1050)
 		assert.NoError(t, err)
-		assert.Equal(t, 21.0, item.Subtotal)
+		assert.Equal(t, int64(2100), item.Subtotal)
 		assert.Equal(t, "Burger", item.Name)
 	})
 
 	t.Run("should fail with invalid quantity", func(t *testing.T) {
-		_, err := order.NewOrderItem("oi_1", "o_1", "mi_1", "Burger", 0, 10.0)
+		_, err := order.NewOrderItem("oi_1", "o_1", "mi_1", "Burger", 0, 1000)
 		assert.Error(t, err)
 	})
 
@@ -61,7 +63,7 @@ func TestNewOrderItem(t *testing.T) {
 	})
 
 	t.Run("should fail with empty name", func(t *testing.T) {
-		_, err := order.NewOrderItem("oi_1", "o_1", "mi_1", "", 1, 10.0)
+		_, err := order.NewOrderItem("oi_1", "o_1", "mi_1", "", 1, 1000)
 		assert.Error(t, err)
 	})
 }
@@ -124,3 +126,249 @@ func TestOrder_RequestBillThrottle(t *testing.T) {
 	assert.False(t, o.RequestBill(base.Add(time.Second)))
 	assert.True(t, o.RequestBill(base.Add(order.ServiceRequestThrottle+time.Second)))
 }
+
+func TestOrder_Cancel(t *testing.T) {
+	t.Run("voids an unpaid order", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 100, common.PaymentMethodTypeCash)
+		assert.False(t, o.NeedsRefund())
+		assert.NoError(t, o.Cancel(order.CancelReasonDuplicate, "user-1", ""))
+		assert.True(t, o.IsCancelled())
+		assert.Equal(t, common.PaymentStatusVoided, o.PaymentStatus)
+		assert.NotNil(t, o.CancelledAt)
+		assert.Equal(t, common.UserID("user-1"), o.CancelledBy)
+		assert.Equal(t, "Duplicate order", o.CancelSummary())
+	})
+
+	t.Run("refunds a paid order", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 100, common.PaymentMethodTypeCash)
+		o.MarkPaid()
+		assert.True(t, o.NeedsRefund())
+		assert.NoError(t, o.Cancel(order.CancelReasonKitchenError, "user-1", "dropped"))
+		assert.Equal(t, common.PaymentStatusRefunded, o.PaymentStatus)
+		assert.Equal(t, common.FulfillmentStatusCancelled, o.FulfillmentStatus)
+		assert.Equal(t, "dropped", o.CancelNote)
+	})
+
+	t.Run("validates reason, note and canceller", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 100, common.PaymentMethodTypeCash)
+		assert.ErrorIs(t, o.Cancel("changed_mind", "user-1", ""), order.ErrInvalidCancelReason)
+		assert.ErrorIs(t, o.Cancel(order.CancelReasonOther, "user-1", "  "), order.ErrCancelNoteRequired)
+		assert.ErrorIs(t, o.Cancel(order.CancelReasonDuplicate, "", ""), order.ErrCancellerRequired)
+		assert.False(t, o.IsCancelled())
+	})
+
+	t.Run("cancelled is terminal", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 100, common.PaymentMethodTypeCash)
+		assert.NoError(t, o.Cancel(order.CancelReasonOutOfStock, "user-1", ""))
+		assert.False(t, o.CanCancel())
+		assert.ErrorIs(t, o.Cancel(order.CancelReasonOutOfStock, "user-1", ""), order.ErrCancelNotPermitted)
+		assert.Error(t, o.UpdateFulfillmentStatus(common.FulfillmentStatusPreparing))
+	})
+}
+
+func TestOrder_SplitBill(t *testing.T) {
+	newSplittable := func() *order.Order {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{
+			{ID: "i_1", Subtotal: 20},
+			{ID: "i_2", Subtotal: 10},
+		}, 1000, common.PaymentMethodTypeCash)
+		return o
+	}
+	withIDs := func(parts []order.BillPart) []order.BillPart {
+		for i := range parts {
+			parts[i].ID = common.BillPartID(fmt.Sprintf("p_%d", i+1))
+		}
+		return parts
+	}
+
+	t.Run("even split hands leftover cents to the first guests", func(t *testing.T) {
+		o := newSplittable()
+		parts, err := o.PlanEvenSplit(3)
+		assert.NoError(t, err)
+		if assert.Len(t, parts, 3) {
+			assert.Equal(t, []int64{334, 333, 333}, []int64{parts[0].Amount, parts[1].Amount, parts[2].Amount})
+			assert.Equal(t, "Guest 1 of 3", parts[0].Label)
+		}
+		_, err = o.PlanEvenSplit(1)
+		assert.ErrorIs(t, err, order.ErrInvalidSplit)
+	})
+
+	t.Run("item split follows item subtotals", func(t *testing.T) {
+		o := newSplittable()
+		parts, err := o.PlanItemSplit(map[common.OrderItemID]int{"i_1": 1, "i_2": 2})
+		assert.NoError(t, err)
+		if assert.Len(t, parts, 2) {
+			assert.Equal(t, int64(667), parts[0].Amount)
+			assert.Equal(t, int64(333), parts[1].Amount)
+			assert.Equal(t, []common.OrderItemID{"i_2"}, parts[1].ItemIDs)
+		}
+		_, err = o.PlanItemSplit(map[common.OrderItemID]int{"i_1": 1})
+		assert.ErrorIs(t, err, order.ErrSplitItemUnassigned)
+	})
+
+	t.Run("custom split adds a remaining balance part", func(t *testing.T) {
+		o := newSplittable()
+		parts, err := o.PlanCustomSplit([]money.Money{money.New(400, money.USD)})
+		assert.NoError(t, err)
+		if assert.Len(t, parts, 2) {
+			assert.Equal(t, "Remaining balance", parts[1].Label)
+			assert.Equal(t, int64(600), parts[1].Amount)
+		}
+		_, err = o.PlanCustomSplit([]money.Money{money.New(1200, money.USD)})
+		assert.ErrorIs(t, err, order.ErrSplitMismatch)
+	})
+
+	t.Run("split must cover the outstanding balance", func(t *testing.T) {
+		o := newSplittable()
+		assert.ErrorIs(t, o.Split(withIDs([]order.BillPart{{Amount: 500}, {Amount: 400}})), order.ErrSplitMismatch)
+		assert.ErrorIs(t, o.Split(withIDs([]order.BillPart{{Amount: 1000}})), order.ErrInvalidSplit)
+		assert.False(t, o.IsSplit())
+	})
+
+	t.Run("order is paid once every part settles", func(t *testing.T) {
+		o := newSplittable()
+		parts, _ := o.PlanEvenSplit(2)
+		assert.NoError(t, o.Split(withIDs(parts)))
+
+		covered, err := o.SettlePart("p_1", "pay_1", common.PaymentMethodTypeCash, money.ExchangeRate{}, time.Now())
+		assert.NoError(t, err)
+		assert.False(t, covered)
+		assert.Equal(t, common.PaymentStatusPartiallyPaid, o.PaymentStatus)
+		assert.Equal(t, int64(500), o.Outstanding().Amount)
+		assert.True(t, o.NeedsRefund())
+
+		// Settling the same part again changes nothing.
+		covered, err = o.SettlePart("p_1", "pay_1", common.PaymentMethodTypeCash, money.ExchangeRate{}, time.Now())
+		assert.NoError(t, err)
+		assert.False(t, covered)
+
+		_, err = o.SettlePart("p_9", "pay_9", common.PaymentMethodTypeCash, money.ExchangeRate{}, time.Now())
+		assert.ErrorIs(t, err, order.ErrBillPartNotFound)
+
+		covered, err = o.SettlePart("p_2", "pay_2", common.PaymentMethodTypeLightning, money.ExchangeRate{}, time.Now())
+		assert.NoError(t, err)
+		assert.True(t, covered)
+		assert.Equal(t, common.PaymentStatusPaid, o.PaymentStatus)
+		assert.True(t, o.Outstanding().IsZero())
+		assert.Equal(t, int64(1000), o.AmountPaid().Amount)
+	})
+
+	t.Run("re-splitting keeps paid parts", func(t *testing.T) {
+		o := newSplittable()
+		parts, _ := o.PlanEvenSplit(2)
+		assert.NoError(t, o.Split(withIDs(parts)))
+		_, _ = o.SettlePart("p_1", "pay_1", common.PaymentMethodTypeCash, money.ExchangeRate{}, time.Now())
+
+		rest, err := o.PlanEvenSplit(2)
+		assert.NoError(t, err)
+		assert.Equal(t, "Guest 2 of 3", rest[0].Label)
+		for i := range rest {
+			rest[i].ID = common.BillPartID(fmt.Sprintf("q_%d", i+1))
+		}
+		assert.NoError(t, o.Split(rest))
+		assert.Len(t, o.BillParts, 3)
+		assert.Equal(t, common.BillPartID("p_1"), o.BillParts[0].ID)
+		assert.Equal(t, []int64{500, 250, 250}, []int64{o.BillParts[0].Amount, o.BillParts[1].Amount, o.BillParts[2].Amount})
+	})
+}
+
+func TestOrder_ChargeFor(t *testing.T) {
+	// A cash order whose 998 total was rounded up to the nearest 5.
+	newRounded := func() *order.Order {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{ID: "i_1", Subtotal: 1000}}, 1000, common.PaymentMethodTypeCash)
+		assert.NoError(t, o.SetCashRounding(2))
+		return o
+	}
+
+	t.Run("only cash carries the rounding", func(t *testing.T) {
+		o := newRounded()
+		amount, rounding := o.ChargeFor(common.PaymentMethodTypeCash)
+		assert.Equal(t, int64(1000), amount.Amount)
+		assert.Equal(t, int64(2), rounding)
+
+		amount, rounding = o.ChargeFor(common.PaymentMethodTypeLightning)
+		assert.Equal(t, int64(998), amount.Amount)
+		assert.Zero(t, rounding)
+	})
+
+	t.Run("split bill rounds the part that clears the balance", func(t *testing.T) {
+		o := newRounded()
+		parts, _ := o.PlanEvenSplit(2)
+		parts[0].ID, parts[1].ID = "p_1", "p_2"
+		assert.NoError(t, o.Split(parts))
+
+		amount, rounding := o.PartChargeFor(o.BillParts[0], common.PaymentMethodTypeCash)
+		assert.Equal(t, int64(500), amount.Amount)
+		assert.Zero(t, rounding)
+		_, err := o.SettlePart("p_1", "pay_1", common.PaymentMethodTypeCash, money.ExchangeRate{}, time.Now())
+		assert.NoError(t, err)
+
+		amount, rounding = o.PartChargeFor(o.BillParts[1], common.PaymentMethodTypeCash)
+		assert.Equal(t, int64(500), amount.Amount)
+		assert.Equal(t, int64(2), rounding)
+
+		amount, rounding = o.PartChargeFor(o.BillParts[1], common.PaymentMethodTypeLightning)
+		assert.Equal(t, int64(498), amount.Amount)
+		assert.Zero(t, rounding)
+	})
+}
+
+func TestOrder_SatsAtSale(t *testing.T) {
+	usdToSats := money.ExchangeRate{From: "USD", To: "SAT", Rate: 2000, Source: "coingecko", AsOf: time.Now()}
+
+	t.Run("unsplit order uses its recorded rate once paid", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 1000, common.PaymentMethodTypeLightning)
+		o.RecordExchangeRate(usdToSats)
+		assert.Equal(t, usdToSats, o.FXRate)
+		assert.True(t, o.SatsAtSale().IsZero())
+
+		o.MarkPaid()
+		assert.Equal(t, money.New(20000, money.SAT), o.SatsAtSale())
+	})
+
+	t.Run("rates from another currency are ignored", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 1000, common.PaymentMethodTypeCash)
+		o.RecordExchangeRate(money.ExchangeRate{From: "THB", To: "SAT", Rate: 50})
+		o.RecordExchangeRate(money.ExchangeRate{From: "USD", To: "USD", Rate: 1})
+		assert.True(t, o.FXRate.IsZero())
+	})
+
+	t.Run("split bill values each part at its own rate", func(t *testing.T) {
+		o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 1000, common.PaymentMethodTypeCash)
+		parts, _ := o.PlanEvenSplit(2)
+		parts[0].ID, parts[1].ID = "p_1", "p_2"
+		assert.NoError(t, o.Split(parts))
+
+		_, err := o.SettlePart("p_1", "pay_1", common.PaymentMethodTypeLightning, usdToSats, time.Now())
+		assert.NoError(t, err)
+		_, err = o.SettlePart("p_2", "pay_2", common.PaymentMethodTypeCash, money.ExchangeRate{}, time.Now())
+		assert.NoError(t, err)
+
+		assert.Equal(t, usdToSats, o.BillParts[0].FXRate)
+		assert.Equal(t, money.New(10000, money.SAT), o.SatsAtSale())
+	})
+}
+
+func TestOrder_AddTip(t *testing.T) {
+	o, _ := order.NewOrder("o_1", "101", "r_1", "session_1", []order.OrderItem{{}}, 1000, common.PaymentMethodTypeCash)
+	assert.False(t, o.AcceptsLateTip(), "unpaid")
+	assert.ErrorIs(t, o.AddTip("tip-1", 200), order.ErrTipNotOpen)
+
+	o.MarkPaid()
+	assert.NoError(t, o.StartPreparing())
+	assert.False(t, o.AcceptsLateTip(), "not served yet")
+
+	assert.NoError(t, o.MarkReady())
+	assert.True(t, o.AcceptsLateTip())
+	assert.ErrorIs(t, o.AddTip("tip-1", 0), order.ErrInvalidTip)
+
+	assert.False(t, o.HasLateTip("tip-1"))
+	assert.NoError(t, o.AddTip("tip-1", 200))
+	assert.True(t, o.HasLateTip("tip-1"))
+	assert.False(t, o.HasLateTip(""))
+	assert.Equal(t, int64(200), o.TipAmount)
+	assert.Equal(t, int64(200), o.LateTipAmount)
+	assert.Equal(t, int64(1200), o.TotalAmount)
+	assert.False(t, o.AcceptsLateTip(), "one late tip per order")
+	assert.ErrorIs(t, o.AddTip("tip-2", 100), order.ErrTipNotOpen)
+}
@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
 	"bitmerchant/internal/payment/domain/payment"
 
 	"github.com/stretchr/testify/assert"
@@ -15,13 +16,13 @@ func TestNewPayment(t *testing.T) {
 			"order_1",
 			"rest_1",
 			common.PaymentMethodTypeCash,
-			25.50,
+			2550,
 		)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, p)
 		assert.Equal(t, common.PaymentStatusPending, p.Status)
-		assert.Equal(t, 25.50, p.Amount)
+		assert.Equal(t, int64(2550), p.Amount)
 	})
 
 	t.Run("should fail with invalid amount", func(t *testing.T) {
@@ -32,14 +33,14 @@ func TestNewPayment(t *testing.T) {
 
 func TestPayment_StateTransitions(t *testing.T) {
 	t.Run("MarkAsPaid", func(t *testing.T) {
-		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 10)
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
 		p.MarkAsPaid()
 		assert.Equal(t, common.PaymentStatusPaid, p.Status)
 		assert.NotNil(t, p.PaidAt)
 	})
 
 	t.Run("MarkPaid with OrderID", func(t *testing.T) {
-		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 10)
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
 		err := p.MarkPaid("new_order_id")
 		assert.NoError(t, err)
 		assert.Equal(t, common.PaymentStatusPaid, p.Status)
@@ -47,7 +48,7 @@ func TestPayment_StateTransitions(t *testing.T) {
 	})
 
 	t.Run("MarkFailed", func(t *testing.T) {
-		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 10)
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
 		err := p.MarkFailed("insufficient funds")
 		assert.NoError(t, err)
 		assert.Equal(t, common.PaymentStatusFailed, p.Status)
@@ -55,14 +56,14 @@ func TestPayment_StateTransitions(t *testing.T) {
 	})
 
 	t.Run("MarkExpired", func(t *testing.T) {
-		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 10)
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
 		err := p.MarkExpired()
 		assert.NoError(t, err)
 		assert.Equal(t, common.PaymentStatusExpired, p.Status)
 	})
 
 	t.Run("Invalid Transitions", func(t *testing.T) {
-		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 10)
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
 		p.MarkAsPaid()
 
 		// Cannot mark failed if already paid
@@ -74,3 +75,69 @@ func TestPayment_StateTransitions(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPayment_Collect(t *testing.T) {
+	t.Run("records tender and change", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1235)
+		assert.NoError(t, p.Collect(money.New(2000, money.USD), "user-1"))
+		assert.Equal(t, common.PaymentStatusPaid, p.Status)
+		assert.Equal(t, int64(2000), p.TenderedAmount)
+		assert.Equal(t, int64(765), p.ChangeGiven)
+		assert.Equal(t, common.UserID("user-1"), p.CollectedBy)
+	})
+
+	t.Run("zero tender means exact", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1235)
+		assert.NoError(t, p.Collect(money.Money{}, ""))
+		assert.Equal(t, int64(1235), p.TenderedAmount)
+		assert.Zero(t, p.ChangeGiven)
+	})
+
+	t.Run("rejects short tender", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1235)
+		assert.ErrorIs(t, p.Collect(money.New(1000, money.USD), ""), payment.ErrInsufficientTender)
+		assert.Equal(t, common.PaymentStatusPending, p.Status)
+	})
+
+	t.Run("rejects tender in another currency", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1235)
+		assert.ErrorIs(t, p.Collect(money.New(2000, money.THB), ""), money.ErrCurrencyMismatch)
+		assert.Equal(t, common.PaymentStatusPending, p.Status)
+	})
+
+	t.Run("rejects settled payment", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
+		p.MarkAsPaid()
+		assert.Error(t, p.Collect(money.New(1000, money.USD), ""))
+	})
+}
+
+func TestPayment_RefundAndVoid(t *testing.T) {
+	t.Run("refund reverses a settled charge", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "cash", 1000)
+		_, err := payment.NewRefund("ref", p, "Duplicate order", "user-1")
+		assert.ErrorIs(t, err, payment.ErrNotRefundable)
+
+		assert.NoError(t, p.Collect(money.New(1000, money.USD), "user-1"))
+		refund, err := payment.NewRefund("ref", p, "Duplicate order", "user-1")
+		assert.NoError(t, err)
+		assert.True(t, refund.IsRefund())
+		assert.Equal(t, p.ID, refund.RefundOf)
+		assert.Equal(t, int64(1000), refund.Amount)
+		assert.Equal(t, common.PaymentStatusRefunded, refund.Status)
+
+		_, err = payment.NewRefund("ref2", refund, "again", "user-1")
+		assert.ErrorIs(t, err, payment.ErrNotRefundable)
+	})
+
+	t.Run("void only applies to unsettled payments", func(t *testing.T) {
+		p, _ := payment.NewPayment("id", "oid", "rid", "lightning", 1000)
+		assert.NoError(t, p.Void("Out of stock"))
+		assert.Equal(t, common.PaymentStatusVoided, p.Status)
+		assert.NoError(t, p.Void("Out of stock"))
+
+		paid, _ := payment.NewPayment("id2", "oid", "rid", "cash", 1000)
+		paid.MarkAsPaid()
+		assert.ErrorIs(t, paid.Void("x"), payment.ErrAlreadySettled)
+	})
+}
@@ -0,0 +1,109 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/promotion/domain/promotion"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromotion(t *testing.T, terms promotion.Terms) *promotion.Promotion {
+	t.Helper()
+	p, err := promotion.New("promo-1", "rest-1", money.USD, terms)
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewPromotion_Validation(t *testing.T) {
+	p := newPromotion(t, promotion.Terms{Name: " Spring ", Code: " spring10 ", Kind: promotion.KindPercentage, PercentOff: 10})
+	assert.Equal(t, "Spring", p.Name)
+	assert.Equal(t, "SPRING10", p.Code)
+	assert.True(t, p.Active)
+	assert.False(t, p.IsAutomatic())
+	assert.Equal(t, "Spring (SPRING10)", p.Label())
+
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, -1)
+	for name, terms := range map[string]promotion.Terms{
+		"no name":           {Kind: promotion.KindPercentage, PercentOff: 10},
+		"unknown kind":      {Name: "X", Kind: "bogus"},
+		"percent over":      {Name: "X", Kind: promotion.KindPercentage, PercentOff: 101},
+		"zero amount":       {Name: "X", Kind: promotion.KindFixed},
+		"no free units":     {Name: "X", Kind: promotion.KindBuyXGetY, BuyQuantity: 2},
+		"space in code":     {Name: "X", Code: "TWO WORDS", Kind: promotion.KindPercentage, PercentOff: 10},
+		"ends before start": {Name: "X", Kind: promotion.KindPercentage, PercentOff: 10, StartsAt: &start, EndsAt: &end},
+	} {
+		_, err := promotion.New("promo-x", "rest-1", money.USD, terms)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPromotionDiscount_Percentage(t *testing.T) {
+	p := newPromotion(t, promotion.Terms{Name: "Fifteen off", Kind: promotion.KindPercentage, PercentOff: 15})
+	lines := []promotion.Line{{CategoryID: "drinks", Quantity: 3, UnitPrice: 450}, {CategoryID: "mains", Quantity: 1, UnitPrice: 1299}}
+
+	amount, err := p.Discount(lines, 2649)
+	require.NoError(t, err)
+	assert.Equal(t, int64(397), amount, "15% of $26.49 = $3.9735, rounded half up")
+}
+
+func TestPromotionDiscount_FixedIsCappedAtEligibleAmount(t *testing.T) {
+	p := newPromotion(t, promotion.Terms{Name: "Five off drinks", Kind: promotion.KindFixed, AmountOff: 500, CategoryID: "drinks"})
+
+	amount, err := p.Discount([]promotion.Line{{CategoryID: "drinks", Quantity: 1, UnitPrice: 350}, {CategoryID: "mains", Quantity: 1, UnitPrice: 1200}}, 1550)
+	require.NoError(t, err)
+	assert.Equal(t, int64(350), amount, "never more than the drinks in the order")
+
+	_, err = p.Discount([]promotion.Line{{CategoryID: "mains", Quantity: 1, UnitPrice: 1200}}, 1200)
+	assert.ErrorIs(t, err, promotion.ErrNotApplicable)
+	assert.True(t, promotion.IsRejection(err))
+}
+
+func TestPromotionDiscount_BuyXGetYFreesTheCheapest(t *testing.T) {
+	p := newPromotion(t, promotion.Terms{Name: "Three for two", Kind: promotion.KindBuyXGetY, BuyQuantity: 2, GetQuantity: 1})
+	lines := []promotion.Line{
+		{Quantity: 2, UnitPrice: 900},
+		{Quantity: 3, UnitPrice: 400},
+		{Quantity: 1, UnitPrice: 600},
+	}
+
+	// Units by price: 900 900 600 | 400 400 400 — one free unit per group of three.
+	amount, err := p.Discount(lines, 3600)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), amount)
+
+	_, err = p.Discount([]promotion.Line{{Quantity: 2, UnitPrice: 900}}, 1800)
+	assert.ErrorIs(t, err, promotion.ErrNotApplicable, "two units are not a full group")
+}
+
+func TestPromotionDiscount_MinimumSubtotal(t *testing.T) {
+	p := newPromotion(t, promotion.Terms{Name: "Big order", Kind: promotion.KindFixed, AmountOff: 500, MinSubtotal: 3000})
+
+	_, err := p.Discount([]promotion.Line{{Quantity: 1, UnitPrice: 2999}}, 2999)
+	assert.ErrorIs(t, err, promotion.ErrBelowMinimum)
+
+	amount, err := p.Discount([]promotion.Line{{Quantity: 1, UnitPrice: 3000}}, 3000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), amount)
+}
+
+func TestPromotionCheckAvailable(t *testing.T) {
+	start := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC)
+	p := newPromotion(t, promotion.Terms{Name: "May week", Kind: promotion.KindPercentage, PercentOff: 10, StartsAt: &start, EndsAt: &end, MaxUses: 2})
+
+	assert.ErrorIs(t, p.CheckAvailable(start.Add(-time.Second)), promotion.ErrNotStarted)
+	assert.NoError(t, p.CheckAvailable(start))
+	assert.ErrorIs(t, p.CheckAvailable(end), promotion.ErrExpired, "ends_at is exclusive")
+
+	p.UsesCount = 2
+	assert.ErrorIs(t, p.CheckAvailable(start), promotion.ErrUsageLimitReached)
+
+	p.UsesCount = 0
+	p.SetActive(false)
+	assert.ErrorIs(t, p.CheckAvailable(start), promotion.ErrInactive)
+}
@@ -2,6 +2,8 @@ package domain_test
 
 import (
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/common/tip"
 	"bitmerchant/internal/restaurant/domain/restaurant"
 
 	"github.com/stretchr/testify/assert"
@@ -147,3 +149,70 @@ func TestRestaurant_PauseAndResume(t *testing.T) {
 }
 
 func ptrTime(t time.Time) *time.Time { return &t }
+
+func TestNormalizeLightningAddress(t *testing.T) {
+	valid := map[string]string{
+		"":                             "",
+		"  Alice@Wallet.Example ":      "alice@wallet.example",
+		"lightning:bob@127.0.0.1:8080": "bob@127.0.0.1:8080",
+		"https://pay.example/lnurlp/x": "https://pay.example/lnurlp/x",
+	}
+	for in, want := range valid {
+		got, err := restaurant.NormalizeLightningAddress(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, bad := range []string{"alice", "alice@", "ftp://pay.example"} {
+		_, err := restaurant.NormalizeLightningAddress(bad)
+		assert.ErrorIs(t, err, restaurant.ErrInvalidLightningAddress, bad)
+	}
+}
+
+func TestRestaurantSetCashRounding(t *testing.T) {
+	r, err := restaurant.NewRestaurantWithCurrency("r1", "Cafe", money.THB)
+	require.NoError(t, err)
+
+	require.NoError(t, r.SetCashRounding(" 0.25 "))
+	assert.Equal(t, int64(25), r.CashRounding)
+	assert.Equal(t, "฿0.25", r.CashRoundingStep().Format())
+
+	require.NoError(t, r.SetCashRounding(""))
+	assert.Zero(t, r.CashRounding, "blank turns rounding off")
+
+	for _, bad := range []string{"0.255", "-0.25", "abc", "100.01"} {
+		assert.ErrorIs(t, r.SetCashRounding(bad), restaurant.ErrInvalidCashRounding, bad)
+	}
+}
+
+func TestRestaurantSetTipSettings(t *testing.T) {
+	r, err := restaurant.NewRestaurant("r1", "Cafe")
+	require.NoError(t, err)
+	assert.Equal(t, tip.Standard(), r.EffectiveTipSettings(), "unset falls back to 10/15/20%")
+
+	require.NoError(t, r.SetTipSettings(tip.Settings{Presets: []int{18, 22, 25}, Default: 22, Custom: true}))
+	assert.Equal(t, []int{0, 18, 22, 25}, r.EffectiveTipSettings().Percents())
+
+	require.NoError(t, r.SetTipSettings(tip.Settings{Off: true}))
+	assert.False(t, r.EffectiveTipSettings().Enabled(), "no-tipping markets stay off")
+
+	assert.ErrorIs(t, r.SetTipSettings(tip.Settings{Presets: []int{60}}), tip.ErrInvalidPreset)
+	assert.True(t, r.TipSettings.Off, "a rejected change leaves the settings alone")
+}
+
+func TestRestaurantSetTipPooling(t *testing.T) {
+	r, err := restaurant.NewRestaurant("r1", "Cafe")
+	require.NoError(t, err)
+	assert.False(t, r.TipPooling.Enabled(), "staff keep their own tips by default")
+
+	require.NoError(t, r.SetTipPooling(tip.Pooling{Pools: []tip.Pool{
+		{Name: "FOH", Share: 60, Roles: []common.MemberRole{common.RoleServer}},
+		{Name: "Kitchen", Share: 40, Roles: []common.MemberRole{common.RoleKitchenStaff}},
+	}}))
+	assert.Equal(t, tip.SplitHours, r.TipPooling.Split)
+	assert.Equal(t, "Kitchen", r.TipPooling.PoolFor(common.RoleKitchenStaff))
+
+	assert.ErrorIs(t, r.SetTipPooling(tip.Pooling{Pools: []tip.Pool{
+		{Name: "FOH", Share: 50, Roles: []common.MemberRole{common.RoleServer}},
+	}}), tip.ErrPoolShares)
+	assert.Len(t, r.TipPooling.Pools, 2, "a rejected change leaves the pools alone")
+}
@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/payment/domain/shift"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usd(cents int64) money.Money { return money.New(cents, money.USD) }
+
+func TestOpenShift(t *testing.T) {
+	s, err := shift.Open("shift-1", "rest-1", "user-1", money.USD, 10000)
+	require.NoError(t, err)
+	assert.True(t, s.IsOpen())
+	assert.Equal(t, int64(10000), s.ExpectedCash())
+
+	_, err = shift.Open("shift-2", "rest-1", "user-1", money.USD, -1)
+	assert.Error(t, err)
+	_, err = shift.Open("shift-3", "rest-1", "", money.USD, 0)
+	assert.Error(t, err)
+}
+
+func TestShift_Reconciliation(t *testing.T) {
+	s, _ := shift.Open("shift-1", "rest-1", "user-1", money.USD, 10000)
+
+	require.NoError(t, s.AddCashSale("pay-1", usd(1250), "user-1", time.Now()))
+	require.NoError(t, s.AddCashSale("pay-2", usd(725), "user-1", time.Now()))
+	require.NoError(t, s.AddCashSale("pay-1", usd(1250), "user-1", time.Now()), "duplicate sale is a no-op")
+	require.NoError(t, s.PayIn(usd(2000), "Change top-up", "user-1"))
+	require.NoError(t, s.PayOut(usd(1510), "Milk delivery", "user-1"))
+
+	assert.Equal(t, int64(1975), s.Total(shift.MovementSale))
+	assert.Equal(t, int64(12465), s.ExpectedCash())
+	assert.Zero(t, s.Variance())
+
+	assert.ErrorIs(t, s.PayIn(usd(500), "  ", "user-1"), shift.ErrReasonRequired)
+	assert.ErrorIs(t, s.PayOut(usd(0), "x", "user-1"), shift.ErrInvalidAmount)
+	assert.ErrorIs(t, s.PayIn(money.New(500, money.THB), "wrong drawer", "user-1"), money.ErrCurrencyMismatch)
+
+	t.Run("short", func(t *testing.T) {
+		short := *s
+		require.NoError(t, short.Close(usd(12000), "user-1", ""))
+		assert.Equal(t, int64(-465), short.Variance())
+	})
+
+	t.Run("over", func(t *testing.T) {
+		over := *s
+		require.NoError(t, over.Close(usd(12500), "owner-1", "found a fiver"))
+		assert.Equal(t, int64(35), over.Variance())
+		assert.Equal(t, "found a fiver", over.Note)
+	})
+
+	require.NoError(t, s.Close(usd(12465), "user-1", ""))
+	assert.False(t, s.IsOpen())
+	assert.Zero(t, s.Variance())
+	assert.ErrorIs(t, s.AddCashSale("pay-3", usd(100), "user-1", time.Now()), shift.ErrShiftClosed)
+	assert.ErrorIs(t, s.Close(usd(0), "user-1", ""), shift.ErrShiftClosed)
+}
@@ -0,0 +1,74 @@
+package fx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common/money"
+	"bitmerchant/internal/infrastructure/fx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinGecko(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/simple/price", r.URL.Path)
+		assert.Equal(t, "bitcoin", r.URL.Query().Get("ids"))
+		_, _ = w.Write([]byte(`{"bitcoin":{"thb":2300000}}`))
+	}))
+	defer srv.Close()
+
+	p := fx.NewCoinGecko(srv.URL, nil)
+	price, err := p.BTCPrice(context.Background(), "THB")
+	require.NoError(t, err)
+	assert.Equal(t, 2300000.0, price)
+
+	_, err = p.BTCPrice(context.Background(), "USD")
+	assert.Error(t, err)
+}
+
+func TestCoinbase(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/prices/BTC-USD/spot" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"base":"BTC","currency":"USD","amount":"65000.50"}}`))
+	}))
+	defer srv.Close()
+
+	p := fx.NewCoinbase(srv.URL, nil)
+	price, err := p.BTCPrice(context.Background(), "usd")
+	require.NoError(t, err)
+	assert.Equal(t, 65000.5, price)
+
+	_, err = p.BTCPrice(context.Background(), "EUR")
+	assert.Error(t, err)
+}
+
+func TestNewProvider(t *testing.T) {
+	for _, name := range []string{"coingecko", " Coinbase "} {
+		p, err := fx.NewProvider(name, nil)
+		require.NoError(t, err, name)
+		assert.NotEmpty(t, p.Name())
+	}
+	_, err := fx.NewProvider("kraken", nil)
+	assert.Error(t, err)
+}
+
+func TestRateConverter_FallsBackToFixture(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	c := money.NewRateConverter(money.RateConverterOptions{TTL: time.Minute},
+		fx.NewCoinGecko(down.URL, nil), money.FixtureRates{"USD": 50000})
+	got, err := c.Convert(context.Background(), money.New(1000, money.USD), money.SAT)
+	require.NoError(t, err)
+	assert.Equal(t, money.New(20000, money.SAT), got)
+}
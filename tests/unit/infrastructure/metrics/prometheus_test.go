@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common/server"
+	"bitmerchant/internal/infrastructure/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, p *metrics.Prometheus, token, auth string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	if auth != "" {
+		req.Header.Set(echo.HeaderAuthorization, auth)
+	}
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	require.NoError(t, server.MetricsEndpoint(p.Handler(), token)(c))
+	return rec
+}
+
+func TestPrometheus_ExposesApplicationMetrics(t *testing.T) {
+	p := metrics.NewPrometheus()
+	p.ObserveCommand("CreateOrder", 20*time.Millisecond, nil)
+	p.ObserveCommand("CreateOrder", 5*time.Millisecond, errors.New("boom"))
+	p.ObserveQuery("GetMenu", time.Millisecond, nil)
+	p.ObserveHTTPRequest(http.MethodGet, "/order/:orderNumber", http.StatusOK, 3*time.Millisecond)
+	p.IncPublished("order.created")
+	p.IncConsumed("order_created_sse")
+	p.IncHandlerFailure("order_created_push")
+	p.IncDeadLettered("order_created_push")
+	p.IncPush("customer", "accepted")
+	p.ObserveSSEClients(func() map[string]int { return map[string]int{"kitchen": 2} })
+
+	rec := scrape(t, p, "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	for _, line := range []string{
+		`bitmerchant_commands_total{name="CreateOrder"} 2`,
+		`bitmerchant_command_errors_total{name="CreateOrder"} 1`,
+		`bitmerchant_command_duration_seconds_count{name="CreateOrder"} 2`,
+		`bitmerchant_queries_total{name="GetMenu"} 1`,
+		`bitmerchant_http_requests_total{method="GET",route="/order/:orderNumber",status="200"} 1`,
+		`bitmerchant_events_published_total{topic="order.created"} 1`,
+		`bitmerchant_events_consumed_total{handler="order_created_sse"} 1`,
+		`bitmerchant_event_handler_failures_total{handler="order_created_push"} 1`,
+		`bitmerchant_events_dead_lettered_total{handler="order_created_push"} 1`,
+		`bitmerchant_web_push_sends_total{outcome="accepted",role="customer"} 1`,
+		`bitmerchant_sse_clients{topic="kitchen"} 2`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
+
+func TestMetricsEndpoint_RequiresTokenWhenSet(t *testing.T) {
+	p := metrics.NewPrometheus()
+
+	assert.Equal(t, http.StatusUnauthorized, scrape(t, p, "s3cret", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, scrape(t, p, "s3cret", "Bearer wrong").Code)
+	assert.Equal(t, http.StatusOK, scrape(t, p, "s3cret", "Bearer s3cret").Code)
+}
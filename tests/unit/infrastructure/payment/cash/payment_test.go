@@ -19,18 +19,18 @@ func TestCashPaymentMethod(t *testing.T) {
 	})
 
 	t.Run("ValidatePayment", func(t *testing.T) {
-		err := method.ValidatePayment(context.Background(), "o1")
+		err := method.ValidatePayment(context.Background(), "pay_1")
 		assert.NoError(t, err)
 	})
 
 	t.Run("ProcessPayment", func(t *testing.T) {
-		payment, err := method.ProcessPayment(context.Background(), "o1", "r1", money.FromMajor(10.0, money.USD))
+		payment, err := method.ProcessPayment(context.Background(), "o1", "r1", money.New(1000, money.USD))
 
 		assert.NoError(t, err)
 		assert.NotNil(t, payment)
 		assert.Equal(t, common.PaymentMethodTypeCash, payment.Method)
 		assert.Equal(t, common.PaymentStatusPending, payment.Status)
-		assert.Equal(t, 10.0, payment.Amount)
+		assert.Equal(t, int64(1000), payment.Amount)
 		assert.Equal(t, money.USD, payment.Currency)
 	})
 
@@ -0,0 +1,324 @@
+package lightning_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	"bitmerchant/internal/payment/domain/payment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var usdRates = money.StaticConverter{PerBTC: map[string]float64{"USD": 50000}}
+
+func TestLightningPaymentMethod_ProcessPayment(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+
+	assert.Equal(t, common.PaymentMethodTypeLightning, method.GetPaymentMethodType())
+
+	p, err := method.ProcessPayment(ctx, "o1", "r1", money.New(1000, money.USD))
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPending, p.Status)
+	assert.Equal(t, money.SAT, p.Currency)
+	assert.Equal(t, money.New(20000, money.SAT), p.Money())
+	assert.NotEmpty(t, p.PaymentHash)
+	assert.Contains(t, p.Invoice, "lnbcrt200000n1")
+	require.NotNil(t, p.InvoiceExpiresAt)
+	assert.Equal(t, "USD", p.FXRate.From)
+	assert.Equal(t, "SAT", p.FXRate.To)
+	assert.Equal(t, 2000.0, p.FXRate.Rate)
+	assert.Equal(t, "static", p.FXRate.Source)
+	assert.Equal(t, money.New(1000, money.USD), p.ValueAtSale())
+
+	require.NoError(t, repo.Save(p))
+	assert.ErrorIs(t, method.ValidatePayment(ctx, p.ID), payment.ErrInvoiceNotSettled)
+	require.NoError(t, node.Settle(p.PaymentHash))
+	assert.NoError(t, method.ValidatePayment(ctx, p.ID))
+}
+
+// A split bill holds one charge per part; each is validated against its own
+// invoice, not the order's latest.
+func TestLightningPaymentMethod_ValidatesTheChargeAsked(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+
+	first, err := method.ProcessPayment(ctx, "o1", "r1", money.New(500, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(first))
+	second, err := method.ProcessPayment(ctx, "o1", "r1", money.New(500, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(second))
+	require.NoError(t, node.Settle(first.PaymentHash))
+
+	assert.NoError(t, method.ValidatePayment(ctx, first.ID))
+	assert.ErrorIs(t, method.ValidatePayment(ctx, second.ID), payment.ErrInvoiceNotSettled)
+}
+
+func TestLightningPaymentMethod_ConversionUnsupported(t *testing.T) {
+	method := payAdapters.NewLightningPaymentMethod(payAdapters.NewFakeLightningNode(), money.NoopConverter{}, nil, 0)
+	_, err := method.ProcessPayment(context.Background(), "o1", "r1", money.New(1000, money.USD))
+	assert.ErrorIs(t, err, money.ErrConversionNotSupported)
+}
+
+func TestRequestLightningInvoice_ReusesOpenInvoice(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+	h := payCmd.NewRequestLightningInvoiceHandler(repo, nil, method, nil, nil)
+
+	cmd := payCmd.RequestLightningInvoice{OrderID: "o1", RestaurantID: "r1", Amount: money.New(500, money.USD)}
+	first, err := h.Handle(ctx, cmd)
+	require.NoError(t, err)
+	second, err := h.Handle(ctx, cmd)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, first.PaymentHash, second.PaymentHash)
+}
+
+func TestRequestLightningInvoice_ReplacesExpiredInvoice(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+	h := payCmd.NewRequestLightningInvoiceHandler(repo, nil, method, nil, nil)
+
+	first, err := h.Handle(ctx, payCmd.RequestLightningInvoice{OrderID: "o1", RestaurantID: "r1", Amount: money.New(500, money.USD)})
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Second)
+	first.InvoiceExpiresAt = &past
+	require.NoError(t, repo.Update(first))
+	time.Sleep(time.Millisecond)
+
+	second, err := h.Handle(ctx, payCmd.RequestLightningInvoice{OrderID: "o1", RestaurantID: "r1", Amount: money.New(500, money.USD)})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.PaymentHash, second.PaymentHash)
+
+	stale, err := repo.FindByPaymentHash(first.PaymentHash)
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusExpired, stale.Status)
+}
+
+func TestRequestLightningInvoice_TipAfterPayment(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+	h := payCmd.NewRequestLightningInvoiceHandler(repo, nil, method, nil, nil)
+
+	charge, err := h.Handle(ctx, payCmd.RequestLightningInvoice{OrderID: "o1", RestaurantID: "r1", Amount: money.New(1000, money.USD)})
+	require.NoError(t, err)
+	require.NoError(t, charge.MarkPaid("o1"))
+	require.NoError(t, repo.Update(charge))
+
+	tipCmd := payCmd.RequestLightningInvoice{OrderID: "o1", RestaurantID: "r1", Amount: money.New(150, money.USD), Tip: true}
+	tip, err := h.Handle(ctx, tipCmd)
+	require.NoError(t, err)
+	assert.NotEqual(t, charge.ID, tip.ID, "a tip is a payment of its own")
+	assert.True(t, tip.IsTip())
+	assert.Equal(t, common.PaymentStatusPending, tip.Status)
+	assert.True(t, tip.InvoicedFor(money.New(150, money.USD)))
+
+	again, err := h.Handle(ctx, tipCmd)
+	require.NoError(t, err)
+	assert.Equal(t, tip.ID, again.ID, "reloading the pay page keeps the invoice")
+
+	tipCmd.Amount = money.New(200, money.USD)
+	changed, err := h.Handle(ctx, tipCmd)
+	require.NoError(t, err)
+	assert.NotEqual(t, tip.ID, changed.ID, "a different amount gets a fresh invoice")
+	stale, err := repo.FindByID(tip.ID)
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusExpired, stale.Status)
+
+	latest, err := repo.FindByOrderID("o1")
+	require.NoError(t, err)
+	assert.Equal(t, charge.ID, latest.ID, "tips are never the order's charge")
+	charges, err := repo.FindChargesByOrderID("o1")
+	require.NoError(t, err)
+	assert.Len(t, charges, 3, "refunds and voids still see the tips")
+}
+
+func TestLightningSettlementWatcher(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+
+	paid, err := method.ProcessPayment(ctx, "o-paid", "r1", money.New(100, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(paid))
+	cancelled, err := method.ProcessPayment(ctx, "o-cancelled", "r1", money.New(100, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(cancelled))
+	open, err := method.ProcessPayment(ctx, "o-open", "r1", money.New(100, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(open))
+
+	require.NoError(t, node.Settle(paid.PaymentHash))
+	require.NoError(t, node.Cancel(cancelled.PaymentHash))
+
+	var settled []common.OrderID
+	w := payAdapters.NewLightningSettlementWatcher(repo, node, nil, time.Second, func(_ context.Context, p *payment.Payment) error {
+		settled = append(settled, p.OrderID)
+		return nil
+	}, nil)
+	w.CheckPending(ctx)
+
+	assert.Equal(t, []common.OrderID{"o-paid"}, settled)
+	got, err := repo.FindByOrderID("o-paid")
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPaid, got.Status)
+	got, err = repo.FindByOrderID("o-cancelled")
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusExpired, got.Status)
+	got, err = repo.FindByOrderID("o-open")
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPending, got.Status)
+
+	// A second pass must not re-fire the callback for already-settled payments.
+	w.CheckPending(ctx)
+	assert.Len(t, settled, 1)
+}
+
+// A paid invoice whose order could not be settled stays pending, so the
+// next pass settles the order rather than leaving it unpaid for good.
+func TestLightningSettlementWatcher_RetriesWhenOrderSettlementFails(t *testing.T) {
+	ctx := context.Background()
+	node := payAdapters.NewFakeLightningNode()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLightningPaymentMethod(node, usdRates, repo, time.Minute)
+
+	p, err := method.ProcessPayment(ctx, "o1", "r1", money.New(100, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(p))
+	require.NoError(t, node.Settle(p.PaymentHash))
+
+	fail := true
+	var orderPaid []common.PaymentID
+	w := payAdapters.NewLightningSettlementWatcher(repo, node, nil, time.Second, func(_ context.Context, settled *payment.Payment) error {
+		if fail {
+			fail = false
+			return errors.New("order store unavailable")
+		}
+		assert.Equal(t, common.PaymentStatusPaid, settled.Status)
+		orderPaid = append(orderPaid, settled.ID)
+		return nil
+	}, nil)
+
+	w.CheckPending(ctx)
+	got, err := repo.FindByID(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPending, got.Status, "kept for the next pass")
+	assert.Empty(t, orderPaid)
+
+	w.CheckPending(ctx)
+	assert.Equal(t, []common.PaymentID{p.ID}, orderPaid)
+	got, err = repo.FindByID(p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, common.PaymentStatusPaid, got.Status)
+
+	w.CheckPending(ctx)
+	assert.Len(t, orderPaid, 1)
+}
+
+func TestFakeLightningNode_AutoSettle(t *testing.T) {
+	node := payAdapters.NewFakeLightningNode()
+	node.AutoSettleAfter = time.Nanosecond
+	inv, err := node.CreateInvoice(context.Background(), 1000, "memo", time.Minute)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	state, err := node.LookupInvoice(context.Background(), inv.PaymentHash)
+	require.NoError(t, err)
+	assert.Equal(t, payment.InvoiceStateSettled, state)
+}
+
+func TestLNDNode(t *testing.T) {
+	rawHash := []byte{0xde, 0xad, 0xbe, 0xef}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc123", r.Header.Get("Grpc-Metadata-macaroon"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/invoices":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "2500", body["value"])
+			assert.Equal(t, "900", body["expiry"])
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"r_hash":          base64.StdEncoding.EncodeToString(rawHash),
+				"payment_request": "lnbc25u1ptest",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/invoice/deadbeef":
+			_ = json.NewEncoder(w).Encode(map[string]string{"state": "SETTLED"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	node, err := payAdapters.NewLNDNode(payAdapters.LNDConfig{BaseURL: srv.URL, MacaroonHex: "abc123"})
+	require.NoError(t, err)
+
+	inv, err := node.CreateInvoice(context.Background(), 2500, "order", 15*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(rawHash), inv.PaymentHash)
+	assert.Equal(t, "lnbc25u1ptest", inv.PaymentRequest)
+
+	state, err := node.LookupInvoice(context.Background(), inv.PaymentHash)
+	require.NoError(t, err)
+	assert.Equal(t, payment.InvoiceStateSettled, state)
+
+	_, err = node.LookupInvoice(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+func TestCLNNode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "rune-token", r.Header.Get("Rune"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/v1/invoice":
+			assert.EqualValues(t, 2500000, body["amount_msat"])
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"bolt11":       "lnbc25u1pcln",
+				"payment_hash": "cafe",
+				"expires_at":   time.Now().Add(time.Hour).Unix(),
+			})
+		case "/v1/listinvoices":
+			assert.Equal(t, "cafe", body["payment_hash"])
+			_ = json.NewEncoder(w).Encode(map[string]any{"invoices": []map[string]string{{"status": "expired"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	node, err := payAdapters.NewCLNNode(payAdapters.CLNConfig{BaseURL: srv.URL, Rune: "rune-token"})
+	require.NoError(t, err)
+
+	inv, err := node.CreateInvoice(context.Background(), 2500, "order", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "cafe", inv.PaymentHash)
+	assert.Equal(t, "lnbc25u1pcln", inv.PaymentRequest)
+
+	state, err := node.LookupInvoice(context.Background(), "cafe")
+	require.NoError(t, err)
+	assert.Equal(t, payment.InvoiceStateCanceled, state)
+}
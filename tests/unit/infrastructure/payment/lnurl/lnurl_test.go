@@ -0,0 +1,183 @@
+package lnurl_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/money"
+	payAdapters "bitmerchant/internal/payment/adapters"
+	payCmd "bitmerchant/internal/payment/app/command"
+	"bitmerchant/internal/payment/domain/payment"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var usdRates = money.StaticConverter{PerBTC: map[string]float64{"USD": 50000}}
+
+func TestResolvePayURL(t *testing.T) {
+	cases := map[string]string{
+		"Alice@Wallet.example":         "https://wallet.example/.well-known/lnurlp/alice",
+		"lightning:bob@127.0.0.1:8080": "http://127.0.0.1:8080/.well-known/lnurlp/bob",
+		"carol@localhost":              "http://localhost/.well-known/lnurlp/carol",
+		"https://pay.example/lnurlp/x": "https://pay.example/lnurlp/x",
+		// LUD-01 example from the spec.
+		"LNURL1DP68GURN8GHJ7UM9WFMXJCM99E3K7MF0V9CXJ0M385EKVCENXC6R2C35XVUKXEFCV5MKVV34X5EKZD3EV56NYD3HXQURZEPEXEJXXEPNXSCRVWFNV9NXZCN9XQ6XYEFHVGCXXCMYXYMNSERXFQ5FNS": "https://service.com/api?q=3fc3645b439ce8e7f2553a69e5267081d96dcd340693afabe04be7b0ccd178df",
+	}
+	for in, want := range cases {
+		got, err := payAdapters.ResolvePayURL(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, bad := range []string{"", "not an address", "http://wallet.example/lnurlp/x"} {
+		_, err := payAdapters.ResolvePayURL(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func newStandIn(t *testing.T) (*payAdapters.FakeLightningNode, *payAdapters.LNURLStandIn, string) {
+	t.Helper()
+	node := payAdapters.NewFakeLightningNode()
+	standIn := payAdapters.NewLNURLStandIn(node)
+	srv := httptest.NewServer(standIn)
+	t.Cleanup(srv.Close)
+	return node, standIn, "alice@" + strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestLNURLPayMethod_InvoiceAndVerify(t *testing.T) {
+	ctx := context.Background()
+	node, standIn, address := newStandIn(t)
+	repo := payAdapters.NewMemoryPaymentRepository()
+	method := payAdapters.NewLNURLPayMethod(payAdapters.NewLNURLClient(true), usdRates, repo,
+		func(context.Context, common.RestaurantID) (string, error) { return address, nil })
+
+	p, err := method.IssueInvoice(ctx, payment.InvoiceRequest{
+		OrderID: "o1", RestaurantID: "r1", Amount: money.New(1000, money.USD), Memo: "Order #42",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, money.New(20000, money.SAT), p.Money())
+	assert.NotEmpty(t, p.PaymentHash)
+	assert.NotEmpty(t, p.VerifyURL)
+	assert.Equal(t, "Order #42", standIn.Comment(p.PaymentHash))
+
+	require.NoError(t, repo.Save(p))
+	assert.ErrorIs(t, method.ValidatePayment(ctx, p.ID), payment.ErrInvoiceNotSettled)
+	require.NoError(t, node.Settle(p.PaymentHash))
+	assert.NoError(t, method.ValidatePayment(ctx, p.ID))
+}
+
+func TestLNURLPayMethod_NoAddress(t *testing.T) {
+	method := payAdapters.NewLNURLPayMethod(payAdapters.NewLNURLClient(false), usdRates, nil,
+		func(context.Context, common.RestaurantID) (string, error) { return "", nil })
+	_, err := method.ProcessPayment(context.Background(), "o1", "r1", money.New(1000, money.USD))
+	assert.ErrorIs(t, err, payment.ErrNoLightningAddress)
+}
+
+func TestLNURLClient_RejectsMismatchedAmount(t *testing.T) {
+	node := payAdapters.NewFakeLightningNode()
+	mux := http.NewServeMux()
+	var host string
+	mux.HandleFunc("/.well-known/lnurlp/alice", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tag":"payRequest","callback":"http://` + host + `/cb","minSendable":1000,"maxSendable":100000000000,"metadata":"[]"}`))
+	})
+	mux.HandleFunc("/cb", func(w http.ResponseWriter, r *http.Request) {
+		inv, _ := node.CreateInvoice(r.Context(), 1, "", time.Hour)
+		_, _ = w.Write([]byte(`{"pr":"` + inv.PaymentRequest + `","verify":"http://` + host + `/v"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	host = strings.TrimPrefix(srv.URL, "http://")
+
+	_, err := payAdapters.NewLNURLClient(true).FetchInvoice(context.Background(), "alice@"+host, 500, "")
+	assert.Error(t, err)
+}
+
+func TestLNURLClient_RefusesLocalWallets(t *testing.T) {
+	ctx := context.Background()
+	_, _, address := newStandIn(t)
+	client := payAdapters.NewLNURLClient(false)
+
+	// Plain http to loopback is a dev convenience only.
+	_, err := client.FetchInvoice(ctx, address, 500, "")
+	assert.Error(t, err)
+
+	// https passes the scheme check, so the dialler is what refuses the
+	// loopback address.
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	_, err = client.FetchInvoice(ctx, srv.URL+"/.well-known/lnurlp/alice", 500, "")
+	assert.ErrorContains(t, err, "local or private address")
+
+	for _, verifyURL := range []string{"http://10.0.0.1/verify", "https://192.168.1.1/verify", "https://[::1]/verify"} {
+		_, err := client.Verify(ctx, verifyURL)
+		assert.Error(t, err, verifyURL)
+	}
+}
+
+func TestLNURLClient_RejectsInsecureVerifyURL(t *testing.T) {
+	node := payAdapters.NewFakeLightningNode()
+	mux := http.NewServeMux()
+	var host string
+	mux.HandleFunc("/.well-known/lnurlp/alice", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tag":"payRequest","callback":"http://` + host + `/cb","minSendable":1000,"maxSendable":100000000000,"metadata":"[]"}`))
+	})
+	mux.HandleFunc("/cb", func(w http.ResponseWriter, r *http.Request) {
+		inv, _ := node.CreateInvoice(r.Context(), 500, "", time.Hour)
+		_, _ = w.Write([]byte(`{"pr":"` + inv.PaymentRequest + `","verify":"http://wallet.example/v"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	host = strings.TrimPrefix(srv.URL, "http://")
+
+	_, err := payAdapters.NewLNURLClient(true).FetchInvoice(context.Background(), "alice@"+host, 500, "")
+	assert.ErrorContains(t, err, "must use https")
+}
+
+func TestLightningSettlementWatcher_VerifyURL(t *testing.T) {
+	ctx := context.Background()
+	node, _, address := newStandIn(t)
+	repo := payAdapters.NewMemoryPaymentRepository()
+	client := payAdapters.NewLNURLClient(true)
+	method := payAdapters.NewLNURLPayMethod(client, usdRates, repo,
+		func(context.Context, common.RestaurantID) (string, error) { return address, nil })
+
+	p, err := method.ProcessPayment(ctx, "o1", "r1", money.New(100, money.USD))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(p))
+
+	var settled []common.OrderID
+	w := payAdapters.NewLightningSettlementWatcher(repo, nil, client, time.Second, func(_ context.Context, p *payment.Payment) error {
+		settled = append(settled, p.OrderID)
+		return nil
+	}, nil)
+
+	w.CheckPending(ctx)
+	assert.Empty(t, settled)
+	require.NoError(t, node.Settle(p.PaymentHash))
+	w.CheckPending(ctx)
+	assert.Equal(t, []common.OrderID{"o1"}, settled)
+}
+
+func TestRequestLightningInvoice_FallsBackToNode(t *testing.T) {
+	ctx := context.Background()
+	repo := payAdapters.NewMemoryPaymentRepository()
+	address := payAdapters.NewLNURLPayMethod(payAdapters.NewLNURLClient(false), usdRates, repo,
+		func(context.Context, common.RestaurantID) (string, error) { return "", nil })
+	node := payAdapters.NewLightningPaymentMethod(payAdapters.NewFakeLightningNode(), usdRates, repo, time.Minute)
+	h := payCmd.NewRequestLightningInvoiceHandler(repo, address, node, nil, nil)
+
+	p, err := h.Handle(ctx, payCmd.RequestLightningInvoice{OrderID: "o1", RestaurantID: "r1", Amount: money.New(500, money.USD)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, p.Invoice)
+	assert.Empty(t, p.VerifyURL)
+
+	addressOnly := payCmd.NewRequestLightningInvoiceHandler(repo, address, nil, nil, nil)
+	_, err = addressOnly.Handle(ctx, payCmd.RequestLightningInvoice{OrderID: "o2", RestaurantID: "r1", Amount: money.New(500, money.USD)})
+	assert.ErrorIs(t, err, payment.ErrNoLightningAddress)
+}
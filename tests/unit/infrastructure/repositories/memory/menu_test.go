@@ -41,7 +41,7 @@ func TestMemoryMenuItemRepository(t *testing.T) {
 	repo := memory.NewMemoryMenuItemRepository()
 
 	t.Run("Save and FindByID", func(t *testing.T) {
-		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Item 1", 10.0)
+		item, _ := menu.NewMenuItem("i1", "c1", "r1", "Item 1", 1000)
 		err := repo.Save(item)
 		assert.NoError(t, err)
 
@@ -51,9 +51,9 @@ func TestMemoryMenuItemRepository(t *testing.T) {
 	})
 
 	t.Run("FindByRestaurantID", func(t *testing.T) {
-		item1, _ := menu.NewMenuItem("i2", "c1", "r2", "Item 1", 10.0)
-		item2, _ := menu.NewMenuItem("i3", "c2", "r2", "Item 2", 12.0)
-		item3, _ := menu.NewMenuItem("i4", "c3", "r3", "Item 3", 15.0)
+		item1, _ := menu.NewMenuItem("i2", "c1", "r2", "Item 1", 1000)
+		item2, _ := menu.NewMenuItem("i3", "c2", "r2", "Item 2", 1200)
+		item3, _ := menu.NewMenuItem("i4", "c3", "r3", "Item 3", 1500)
 
 		require.NoError(t, repo.Save(item1))
 		require.NoError(t, repo.Save(item2))
@@ -65,8 +65,8 @@ func TestMemoryMenuItemRepository(t *testing.T) {
 	})
 
 	t.Run("FindAvailableByRestaurantID", func(t *testing.T) {
-		item1, _ := menu.NewMenuItem("i5", "c1", "r4", "Item 1", 10.0)
-		item2, _ := menu.NewMenuItem("i6", "c2", "r4", "Item 2", 12.0)
+		item1, _ := menu.NewMenuItem("i5", "c1", "r4", "Item 1", 1000)
+		item2, _ := menu.NewMenuItem("i6", "c2", "r4", "Item 2", 1200)
 		item2.SetAvailable(false)
 
 		require.NoError(t, repo.Save(item1))
@@ -15,7 +15,7 @@ func TestMemoryOrderRepository(t *testing.T) {
 	repo := memory.NewMemoryOrderRepository()
 
 	t.Run("Save and FindByID", func(t *testing.T) {
-		item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Burger", 1, 10.0)
+		item, _ := order.NewOrderItem("oi1", "o1", "mi1", "Burger", 1, 1000)
 		order, _ := order.NewOrder("o1", "101", "r1", "session_1", []order.OrderItem{*item}, 100, common.PaymentMethodTypeCash)
 
 		err := repo.Save(order)
@@ -27,7 +27,7 @@ func TestMemoryOrderRepository(t *testing.T) {
 	})
 
 	t.Run("FindByOrderNumber", func(t *testing.T) {
-		item, _ := order.NewOrderItem("oi2", "o2", "mi1", "Burger", 1, 10.0)
+		item, _ := order.NewOrderItem("oi2", "o2", "mi1", "Burger", 1, 1000)
 		order, _ := order.NewOrder("o2", "102", "r1", "session_1", []order.OrderItem{*item}, 100, common.PaymentMethodTypeCash)
 		require.NoError(t, repo.Save(order))
 
@@ -41,11 +41,11 @@ func TestMemoryOrderRepository(t *testing.T) {
 
 	t.Run("FindActiveByRestaurantID", func(t *testing.T) {
 		// Active
-		item1, _ := order.NewOrderItem("oi3", "o3", "mi1", "Burger", 1, 10.0)
+		item1, _ := order.NewOrderItem("oi3", "o3", "mi1", "Burger", 1, 1000)
 		order1, _ := order.NewOrder("o3", "103", "r2", "session_1", []order.OrderItem{*item1}, 100, common.PaymentMethodTypeCash)
 
 		// Completed (not active)
-		item2, _ := order.NewOrderItem("oi4", "o4", "mi1", "Burger", 1, 10.0)
+		item2, _ := order.NewOrderItem("oi4", "o4", "mi1", "Burger", 1, 1000)
 		order2, _ := order.NewOrder("o4", "104", "r2", "session_1", []order.OrderItem{*item2}, 100, common.PaymentMethodTypeCash)
 		require.NoError(t, order2.UpdateFulfillmentStatus(common.FulfillmentStatusPreparing))
 		require.NoError(t, order2.UpdateFulfillmentStatus(common.FulfillmentStatusReady))
@@ -15,7 +15,7 @@ func TestMemoryPaymentRepository(t *testing.T) {
 	repo := memory.NewMemoryPaymentRepository()
 
 	t.Run("Save and FindByID", func(t *testing.T) {
-		p, _ := payment.NewPayment("p1", "o1", "r1", common.PaymentMethodTypeCash, 10.0)
+		p, _ := payment.NewPayment("p1", "o1", "r1", common.PaymentMethodTypeCash, 1000)
 		err := repo.Save(p)
 		assert.NoError(t, err)
 
@@ -25,7 +25,7 @@ func TestMemoryPaymentRepository(t *testing.T) {
 	})
 
 	t.Run("FindByOrderID", func(t *testing.T) {
-		p, _ := payment.NewPayment("p2", "o2", "r1", common.PaymentMethodTypeCash, 10.0)
+		p, _ := payment.NewPayment("p2", "o2", "r1", common.PaymentMethodTypeCash, 1000)
 		require.NoError(t, repo.Save(p))
 
 		found, err := repo.FindByOrderID("o2")
@@ -37,9 +37,9 @@ func TestMemoryPaymentRepository(t *testing.T) {
 	})
 
 	t.Run("FindByRestaurantID", func(t *testing.T) {
-		p1, _ := payment.NewPayment("p3", "o3", "r2", common.PaymentMethodTypeCash, 10.0)
-		p2, _ := payment.NewPayment("p4", "o4", "r2", common.PaymentMethodTypeCash, 10.0)
-		p3, _ := payment.NewPayment("p5", "o5", "r3", common.PaymentMethodTypeCash, 10.0)
+		p1, _ := payment.NewPayment("p3", "o3", "r2", common.PaymentMethodTypeCash, 1000)
+		p2, _ := payment.NewPayment("p4", "o4", "r2", common.PaymentMethodTypeCash, 1000)
+		p3, _ := payment.NewPayment("p5", "o5", "r3", common.PaymentMethodTypeCash, 1000)
 
 		require.NoError(t, repo.Save(p1))
 		require.NoError(t, repo.Save(p2))
@@ -0,0 +1,68 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	commonhttp "bitmerchant/internal/common/http"
+	"bitmerchant/internal/common/money"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLocaleMiddleware_FormatsForRegion checks amounts follow the region in
+// Accept-Language while the menu language stays the primary subtag.
+func TestLocaleMiddleware_FormatsForRegion(t *testing.T) {
+	brl, _ := money.ISO("BRL")
+	cases := []struct {
+		name, query, acceptLanguage string
+		wantLocale, wantAmount      string
+	}{
+		{"Brazilian Portuguese", "", "pt-BR,pt;q=0.9", "pt", "R$ 1.234,50"},
+		{"Portuguese without a region", "", "pt", "pt", "1.234,50 R$"},
+		{"region of another language is ignored", "?lang=pt", "en-US", "pt", "1.234,50 R$"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.query, nil)
+			req.Header.Set("Accept-Language", tc.acceptLanguage)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var locale, amount string
+			h := commonhttp.LocaleMiddleware()(func(c echo.Context) error {
+				locale = commonhttp.ResolveLocale(c)
+				amount = money.New(123450, brl).FormatContext(c.Request().Context())
+				return nil
+			})
+			assert.NoError(t, h(c))
+			assert.Equal(t, tc.wantLocale, locale)
+			assert.Equal(t, tc.wantAmount, amount)
+		})
+	}
+}
+
+// TestLocale_OnlyTheSwitchWritesTheCookie checks the middleware, which runs on
+// every request, leaves the lang cookie alone even when ?lang= is present.
+func TestLocale_OnlyTheSwitchWritesTheCookie(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/?lang=th", nil), rec)
+	h := commonhttp.LocaleMiddleware()(func(c echo.Context) error {
+		assert.Equal(t, "th", commonhttp.ResolveLocale(c))
+		return nil
+	})
+	assert.NoError(t, h(c))
+	assert.Empty(t, rec.Result().Cookies())
+
+	rec = httptest.NewRecorder()
+	c = e.NewContext(httptest.NewRequest(http.MethodGet, "/menu?lang=th", nil), rec)
+	assert.Equal(t, "th", commonhttp.SwitchLocale(c))
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, commonhttp.LocaleCookieName, cookies[0].Name)
+		assert.Equal(t, "th", cookies[0].Value)
+	}
+}
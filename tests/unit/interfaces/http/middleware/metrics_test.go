@@ -0,0 +1,48 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpMiddleware "bitmerchant/internal/common/http/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	method, route string
+	status        int
+}
+
+type requestRecorder struct {
+	requests []recordedRequest
+}
+
+func (r *requestRecorder) ObserveHTTPRequest(method, route string, status int, _ time.Duration) {
+	r.requests = append(r.requests, recordedRequest{method: method, route: route, status: status})
+}
+
+func TestMetricsMiddleware_RecordsRoutePatternAndStatus(t *testing.T) {
+	metrics := &requestRecorder{}
+	e := echo.New()
+	e.Use(httpMiddleware.MetricsMiddleware(metrics))
+	e.GET("/order/:orderNumber", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden, "no")
+	})
+
+	for _, path := range []string{"/order/ORD-42", "/fail", "/nowhere"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, []recordedRequest{
+		{method: http.MethodGet, route: "/order/:orderNumber", status: http.StatusNoContent},
+		{method: http.MethodGet, route: "/fail", status: http.StatusForbidden},
+		{method: http.MethodGet, route: "unmatched", status: http.StatusNotFound},
+	}, metrics.requests)
+}
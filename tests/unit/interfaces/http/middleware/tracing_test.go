@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpMiddleware "bitmerchant/internal/common/http/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	e := echo.New()
+	e.Use(httpMiddleware.TracingMiddleware())
+	var handled trace.SpanContext
+	e.GET("/order/:orderNumber", func(c echo.Context) error {
+		handled = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/boom", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadGateway, "upstream down")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/order/ORD-42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handled.TraceID().String())
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	order := ended[0]
+	assert.Equal(t, "GET /order/:orderNumber", order.Name())
+	assert.Equal(t, trace.SpanKindServer, order.SpanKind())
+	assert.Equal(t, "00f067aa0ba902b7", order.Parent().SpanID().String())
+	assert.Contains(t, order.Attributes(), attribute.Int("http.response.status_code", http.StatusNoContent))
+
+	boom := ended[1]
+	assert.Equal(t, "GET /boom", boom.Name())
+	assert.False(t, boom.Parent().IsValid(), "a request without traceparent starts a trace")
+	assert.Equal(t, codes.Error, boom.Status().Code)
+}
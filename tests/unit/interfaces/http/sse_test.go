@@ -1,12 +1,20 @@
 package http_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	commonhttp "bitmerchant/internal/common/http"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFormatDatastarEvent(t *testing.T) {
@@ -33,3 +41,215 @@ func TestFormatDatastarPatch(t *testing.T) {
 	assert.Contains(t, str, "data: elements <div id='item'>Item</div>")
 	assert.True(t, strings.HasSuffix(str, "\n\n"))
 }
+
+// loopbackRelay stands in for the event bus: every Publish reaches every
+// subscribed replica.
+type loopbackRelay struct {
+	mu       sync.Mutex
+	replicas []func(topic string, message []byte)
+	down     bool
+}
+
+func (r *loopbackRelay) Publish(topic string, message []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.down {
+		return errors.New("relay down")
+	}
+	for _, deliver := range r.replicas {
+		deliver(topic, message)
+	}
+	return nil
+}
+
+func (r *loopbackRelay) Subscribe(deliver func(topic string, message []byte)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replicas = append(r.replicas, deliver)
+	return nil
+}
+
+type frameWriter struct {
+	header http.Header
+	frames chan string
+}
+
+func (w *frameWriter) Header() http.Header { return w.header }
+func (w *frameWriter) WriteHeader(int)     {}
+func (w *frameWriter) Flush()              {}
+func (w *frameWriter) Write(p []byte) (int, error) {
+	w.frames <- string(p)
+	return len(p), nil
+}
+
+func openStream(t *testing.T, h *commonhttp.SSEHandler, topic string) *frameWriter {
+	t.Helper()
+	return resumeStream(t, h, topic, "", nil)
+}
+
+func resumeStream(t *testing.T, h *commonhttp.SSEHandler, topic, lastEventID string, snapshot commonhttp.Snapshot) *frameWriter {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	w := &frameWriter{header: make(http.Header), frames: make(chan string, 4)}
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	c := echo.New().NewContext(req, w)
+	go func() { _ = h.Stream(c, topic, snapshot) }()
+	return w
+}
+
+// nextWrite returns the next write, which may be the cursor a stream opens
+// with.
+func nextWrite(t *testing.T, w *frameWriter) string {
+	t.Helper()
+	select {
+	case frame := <-w.frames:
+		return frame
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an SSE frame")
+		return ""
+	}
+}
+
+// nextFrame returns the next broadcast frame's payload, skipping cursors.
+func nextFrame(t *testing.T, w *frameWriter) string {
+	t.Helper()
+	for {
+		frame := nextWrite(t, w)
+		if strings.HasPrefix(frame, ": connected") {
+			continue
+		}
+		_, payload, _ := strings.Cut(frame, "\n")
+		return payload
+	}
+}
+
+// eventID reads the id line off a frame or cursor.
+func eventID(t *testing.T, frame string) string {
+	t.Helper()
+	for _, line := range strings.Split(frame, "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			return id
+		}
+	}
+	t.Fatalf("no id in frame %q", frame)
+	return ""
+}
+
+func TestRelayedSSEHandler_BroadcastReachesEveryReplica(t *testing.T) {
+	relay := &loopbackRelay{}
+	replicaA, err := commonhttp.NewRelayedSSEHandler(relay)
+	require.NoError(t, err)
+	replicaB, err := commonhttp.NewRelayedSSEHandler(relay)
+	require.NoError(t, err)
+
+	topic := commonhttp.KitchenTopic("restaurant_1")
+	onA := openStream(t, replicaA, topic)
+	onB := openStream(t, replicaB, topic)
+	time.Sleep(50 * time.Millisecond)
+
+	replicaA.Broadcast(topic, []byte("new order"))
+	assert.Equal(t, "new order", nextFrame(t, onA))
+	assert.Equal(t, "new order", nextFrame(t, onB), "the tablet on the other replica sees it too")
+
+	// Event IDs travel with the frame, so a tablet can resume on either replica.
+	replicaA.Broadcast(topic, []byte("second order"))
+	seenOnA := nextWrite(t, onA)
+	require.Contains(t, seenOnA, "second order")
+	replicaA.Broadcast(topic, []byte("third order"))
+	assert.Equal(t, "third order", nextFrame(t, resumeStream(t, replicaB, topic, eventID(t, seenOnA), nil)))
+	nextFrame(t, onA)
+	nextFrame(t, onB)
+	nextFrame(t, onB)
+
+	relay.down = true
+	replicaB.Broadcast(topic, []byte("relay down"))
+	assert.Equal(t, "relay down", nextFrame(t, onB), "local clients are still served")
+	select {
+	case frame := <-onA.frames:
+		t.Fatalf("unexpected frame on the other replica: %s", frame)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSSEStream_ResumesFromLastEventID(t *testing.T) {
+	h := commonhttp.NewSSEHandler()
+	topic := commonhttp.KitchenTopic("restaurant_1")
+
+	first := openStream(t, h, topic)
+	cursor := eventID(t, nextWrite(t, first))
+	time.Sleep(20 * time.Millisecond)
+	h.Broadcast(topic, []byte("one\n\n"))
+	seen := nextWrite(t, first)
+	assert.Contains(t, seen, "one")
+	assert.NotEqual(t, cursor, eventID(t, seen))
+
+	// The tablet drops off; two orders arrive while it is away.
+	h.Broadcast(topic, []byte("two\n\n"))
+	h.Broadcast(topic, []byte("three\n\n"))
+
+	back := resumeStream(t, h, topic, eventID(t, seen), nil)
+	assert.Equal(t, "two\n\n", nextFrame(t, back))
+	assert.Equal(t, "three\n\n", nextFrame(t, back))
+
+	fromCursor := resumeStream(t, h, topic, cursor, nil)
+	assert.Equal(t, "one\n\n", nextFrame(t, fromCursor), "a client that saw nothing yet replays everything")
+}
+
+func TestSSEStream_SnapshotWhenPastTheBuffer(t *testing.T) {
+	h := commonhttp.NewSSEHandler()
+	topic := commonhttp.ServerTopic("restaurant_1")
+	h.Broadcast(topic, []byte("first\n\n"))
+	snapshot := func(context.Context) ([]byte, error) { return []byte("full board\n\n"), nil }
+
+	stale := resumeStream(t, h, topic, "gone-42", snapshot)
+	frame := nextWrite(t, stale)
+	assert.Contains(t, frame, "full board")
+	assert.NotEmpty(t, eventID(t, frame), "the snapshot carries the newest id so the next resume works")
+
+	// Without a snapshot the client just carries on from now.
+	carryOn := resumeStream(t, h, topic, "gone-42", nil)
+	assert.True(t, strings.HasPrefix(nextWrite(t, carryOn), ": connected"))
+}
+
+func TestSSEStream_SlowClientIsResynced(t *testing.T) {
+	h := commonhttp.NewSSEHandler()
+	topic := commonhttp.KitchenTopic("restaurant_1")
+	snapshot := func(context.Context) ([]byte, error) { return []byte("full board\n\n"), nil }
+	slow := resumeStream(t, h, topic, "", snapshot)
+	nextWrite(t, slow)
+	time.Sleep(20 * time.Millisecond)
+
+	// The writer holds 4 frames and the client queue a few dozen more; the
+	// rest can't be queued, so the client is owed a snapshot.
+	for i := 0; i < 200; i++ {
+		h.Broadcast(topic, []byte("order\n\n"))
+	}
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case frame := <-slow.frames:
+			if strings.Contains(frame, "full board") {
+				return
+			}
+		case <-deadline:
+			t.Fatal("slow client was never resynced")
+		}
+	}
+}
+
+func TestSSEHandler_ClientCountsByTopicKind(t *testing.T) {
+	h := commonhttp.NewSSEHandler()
+	for _, topic := range []string{
+		commonhttp.KitchenTopic("restaurant_1"),
+		commonhttp.KitchenTopic("restaurant_2"),
+		commonhttp.OrderTopic("restaurant_1", "order_1"),
+	} {
+		nextWrite(t, openStream(t, h, topic))
+	}
+
+	assert.Equal(t, map[string]int{"kitchen": 2, "order": 1}, h.ClientCounts())
+}
@@ -46,7 +46,7 @@ func TestOrderStatus_RendersSatoshiTotal(t *testing.T) {
 
 func TestOrderStatus_RendersFiatTotal(t *testing.T) {
 	items := []order.OrderItem{
-		mustOrderItemUSD(t, "oi1", "ord1", "i1", "Burger", 2, 10.00),
+		mustOrderItemUSD(t, "oi1", "ord1", "i1", "Burger", 2, 1000),
 	}
 	o, err := order.NewOrderWithCurrency(
 		"ord1", "0001", "r1", "sess",
@@ -60,14 +60,34 @@ func TestOrderStatus_RendersFiatTotal(t *testing.T) {
 	assert.Contains(t, sb.String(), "$20.00")
 }
 
-func mustOrderItemSAT(t *testing.T, id, oid, mid, name string, qty int, unitPrice float64) order.OrderItem {
+// TestOrderStatus_FormatsForRequestLocale checks templates format amounts
+// with the locale LocaleMiddleware puts on the request context.
+func TestOrderStatus_FormatsForRequestLocale(t *testing.T) {
+	items := []order.OrderItem{
+		mustOrderItemUSD(t, "oi1", "ord1", "i1", "Burger", 2, 61725),
+	}
+	o, err := order.NewOrderWithCurrency(
+		"ord1", "0001", "r1", "sess",
+		items, 123450, 123450, 0, 0, "", "", common.PaymentMethodTypeCash, money.USD,
+	)
+	require.NoError(t, err)
+
+	var sb strings.Builder
+	ctx := money.WithLocale(context.Background(), "de")
+	require.NoError(t, templates.OrderStatus(mustView(t, o)).Render(ctx, &sb))
+
+	assert.Contains(t, sb.String(), "1.234,50 $")
+	assert.NotContains(t, sb.String(), "$1,234.50")
+}
+
+func mustOrderItemSAT(t *testing.T, id, oid, mid, name string, qty int, unitPrice int64) order.OrderItem {
 	t.Helper()
 	oi, err := order.NewOrderItemWithCurrency(common.OrderItemID(id), common.OrderID(oid), common.ItemID(mid), name, qty, unitPrice, money.SAT, nil, "")
 	require.NoError(t, err)
 	return *oi
 }
 
-func mustOrderItemUSD(t *testing.T, id, oid, mid, name string, qty int, unitPrice float64) order.OrderItem {
+func mustOrderItemUSD(t *testing.T, id, oid, mid, name string, qty int, unitPrice int64) order.OrderItem {
 	t.Helper()
 	oi, err := order.NewOrderItem(common.OrderItemID(id), common.OrderID(oid), common.ItemID(mid), name, qty, unitPrice)
 	require.NoError(t, err)
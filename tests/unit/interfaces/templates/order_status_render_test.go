@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"bitmerchant/internal/common"
+	"bitmerchant/internal/common/tip"
 	"bitmerchant/internal/interfaces/templates"
 	"bitmerchant/internal/ordering/app/query"
 	"bitmerchant/internal/ordering/domain/order"
@@ -24,7 +25,7 @@ func renderStatus(t *testing.T, view *query.OrderStatusView) string {
 
 func paidOrder(t *testing.T) *order.Order {
 	t.Helper()
-	item, err := order.NewOrderItem("oi", "ord", "mi", "Pork Belly Bao", 2, 6.5)
+	item, err := order.NewOrderItem("oi", "ord", "mi", "Pork Belly Bao", 2, 650)
 	require.NoError(t, err)
 	o, err := order.NewOrder("ord", "A29F", "r1", "sess", []order.OrderItem{*item}, 1300, common.PaymentMethodTypeCash)
 	require.NoError(t, err)
@@ -93,3 +94,27 @@ func TestOrderStatus_UnpaidShowsCashUnpaidBadge(t *testing.T) {
 	out := renderStatus(t, view)
 	assert.Contains(t, out, "Cash · unpaid")
 }
+
+func TestOrderStatus_ServedOrderAsksForTip(t *testing.T) {
+	o := paidOrder(t)
+	view, err := query.BuildOrderStatusView(nil, o, query.DefaultPrepTarget)
+	require.NoError(t, err)
+	lookup := func(common.RestaurantID) tip.Settings { return tip.Settings{Presets: []int{10, 20}, Custom: true} }
+
+	view.OfferLateTip(lookup)
+	assert.NotContains(t, renderStatus(t, view), "Add a tip", "not before the food is served")
+
+	require.NoError(t, o.StartPreparing())
+	require.NoError(t, o.MarkReady())
+	view.OfferLateTip(lookup)
+	out := renderStatus(t, view)
+	assert.Contains(t, out, "Add a tip")
+	assert.Contains(t, out, "/order/A29F/pay?tip=1.30", "10% of $13.00")
+	assert.Contains(t, out, "/order/A29F/pay?tip=2.60")
+	assert.Contains(t, out, `name="tip"`, "custom amounts are offered")
+
+	require.NoError(t, o.AddTip("tip-1", 260))
+	out = renderStatus(t, view)
+	assert.NotContains(t, out, "Add a tip", "one late tip per order")
+	assert.Contains(t, out, "Includes tip added after paying")
+}